@@ -65,6 +65,11 @@ type Context struct {
 	ready      bool
 
 	playingPlayers map[*playerImpl]struct{}
+	duckers        map[*Ducker]struct{}
+
+	listenerX      float64
+	listenerY      float64
+	spatialOptions SpatialOptions
 
 	m         sync.Mutex
 	semaphore chan struct{}
@@ -83,6 +88,32 @@ var (
 //
 // NewContext panics when an audio context is already created.
 func NewContext(sampleRate int) *Context {
+	return NewContextWithOptions(sampleRate, nil)
+}
+
+// ContextOptions represents options for NewContextWithOptions.
+type ContextOptions struct {
+	// BufferSize specifies the buffer size of the underlying audio device.
+	//
+	// If 0 is specified, the driver's default buffer size is used.
+	// A small buffer size reduces the output latency, which is useful e.g. for rhythm games.
+	// On the other hand, a too small buffer size can cause glitch noises due to buffer shortage.
+	//
+	// This is different from (*Player).SetBufferSize, which adjusts the buffer of each player.
+	// To reduce the total latency, adjust both.
+	BufferSize time.Duration
+}
+
+// NewContextWithOptions creates a new audio context with the given sample rate and options.
+//
+// If options is nil, NewContextWithOptions is the same as NewContext.
+//
+// NewContextWithOptions panics when an audio context is already created.
+func NewContextWithOptions(sampleRate int, options *ContextOptions) *Context {
+	if options == nil {
+		options = &ContextOptions{}
+	}
+
 	theContextLock.Lock()
 	defer theContextLock.Unlock()
 
@@ -92,8 +123,9 @@ func NewContext(sampleRate int) *Context {
 
 	c := &Context{
 		sampleRate:     sampleRate,
-		playerFactory:  newPlayerFactory(sampleRate),
+		playerFactory:  newPlayerFactory(sampleRate, options.BufferSize),
 		playingPlayers: map[*playerImpl]struct{}{},
+		duckers:        map[*Ducker]struct{}{},
 		semaphore:      make(chan struct{}, 1),
 	}
 	theContext = c
@@ -269,6 +301,7 @@ func (c *Context) updatePlayers() error {
 			return err
 		}
 		p.updatePosition()
+		p.updateSpatial()
 		if !p.IsPlaying() {
 			playersToRemove = append(playersToRemove, p)
 		}
@@ -278,11 +311,53 @@ func (c *Context) updatePlayers() error {
 	for _, p := range playersToRemove {
 		delete(c.playingPlayers, p)
 	}
+	duckers := make([]*Ducker, 0, len(c.duckers))
+	for d := range c.duckers {
+		duckers = append(duckers, d)
+	}
 	c.m.Unlock()
 
+	now := time.Now()
+	gains := map[*playerImpl]float64{}
+	for _, d := range duckers {
+		d.update(now)
+		d.multiplyTargetGains(gains)
+	}
+	for p, g := range gains {
+		p.setDuckingGain(g)
+	}
+
 	return nil
 }
 
+func (c *Context) addDucker(d *Ducker) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.duckers[d] = struct{}{}
+}
+
+func (c *Context) removeDucker(d *Ducker) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	delete(c.duckers, d)
+}
+
+// duckingGain returns the multiplication of the ducking gains for the player p by all the Duckers.
+func (c *Context) duckingGain(p *playerImpl) float64 {
+	c.m.Lock()
+	duckers := make([]*Ducker, 0, len(c.duckers))
+	for d := range c.duckers {
+		duckers = append(duckers, d)
+	}
+	c.m.Unlock()
+
+	gain := 1.0
+	for _, d := range duckers {
+		gain *= d.targetGain(p)
+	}
+	return gain
+}
+
 // IsReady returns a boolean value indicating whether the audio is ready or not.
 //
 // On some browsers, user interaction like click or pressing keys is required to start audio.
@@ -448,6 +523,44 @@ func (p *Player) SetVolume(volume float64) {
 	p.p.SetVolume(volume)
 }
 
+// SetLoopPoints sets the loop of this player.
+// introSamples is the length of the intro part and loopLengthSamples is the length of the loop part,
+// in the number of samples per channel from the beginning of the source.
+//
+// After the player plays the intro part and the loop part, the player goes back to the start of the loop part
+// and continues playing forever.
+// This is useful for music with an intro that is not repeated.
+// If the source ends before the end of the loop, the player goes back to the start of the loop part at the end.
+//
+// If loopLengthSamples is 0, the loop is cleared.
+//
+// SetLoopPoints returns an error when the source is not an io.Seeker, an argument is negative,
+// or the loop start is at or past the end of the source.
+//
+// Unlike InfiniteLoop, the loop joint is not blended.
+func (p *Player) SetLoopPoints(introSamples, loopLengthSamples int64) error {
+	return p.p.SetLoopPoints(introSamples, loopLengthSamples)
+}
+
+// Pan returns the current stereo balance of this player [-1-1].
+func (p *Player) Pan() float64 {
+	return p.p.Pan()
+}
+
+// SetPan sets the stereo balance of this player.
+// -1 means only the left channel is audible, 0 means the center, and 1 means only the right channel is audible.
+// The channel on the opposite side of pan is attenuated linearly, and the other channel is not changed.
+//
+// pan must be in between -1 and 1. SetPan panics otherwise.
+//
+// The pan is added to the pan calculated from the spatial position by SetSpatialPosition.
+func (p *Player) SetPan(pan float64) {
+	if pan < -1 || pan > 1 {
+		panic("audio: pan must be in between -1 and 1")
+	}
+	p.p.SetPan(pan)
+}
+
 // SetBufferSize adjusts the buffer size of the player.
 // If 0 is specified, the default buffer size is used.
 // A small buffer size is useful if you want to play a real-time PCM for example.
@@ -497,3 +610,53 @@ func Resample(source io.ReadSeeker, size int64, from, to int) io.ReadSeeker {
 	}
 	return convert.NewResampling(source, size, from, to)
 }
+
+// ResamplingQuality represents the algorithm used for resampling.
+type ResamplingQuality int
+
+const (
+	// ResamplingQualityHigh uses a windowed sinc filter with a wide window.
+	// This is the default.
+	ResamplingQualityHigh ResamplingQuality = ResamplingQuality(convert.ResamplingQualityHigh)
+
+	// ResamplingQualityMedium uses a windowed sinc filter with a narrow window.
+	// This is about twice as fast as ResamplingQualityHigh.
+	ResamplingQualityMedium ResamplingQuality = ResamplingQuality(convert.ResamplingQualityMedium)
+
+	// ResamplingQualityLow uses linear interpolation.
+	// This is the fastest, but high frequencies might be distorted.
+	ResamplingQualityLow ResamplingQuality = ResamplingQuality(convert.ResamplingQualityLow)
+)
+
+func (q ResamplingQuality) validate() {
+	switch q {
+	case ResamplingQualityHigh, ResamplingQualityMedium, ResamplingQualityLow:
+	default:
+		panic(fmt.Sprintf("audio: invalid ResamplingQuality: %d", q))
+	}
+}
+
+// SetResamplingQuality sets the resampling quality used by Resample and the decoders
+// like mp3.DecodeWithSampleRate, vorbis.DecodeWithSampleRate and wav.DecodeWithSampleRate.
+//
+// The quality is applied to streams created after SetResamplingQuality is called.
+// The default quality is ResamplingQualityHigh.
+//
+// SetResamplingQuality panics if quality is not a valid value.
+//
+// SetResamplingQuality is concurrent-safe.
+func SetResamplingQuality(quality ResamplingQuality) {
+	quality.validate()
+	convert.SetDefaultResamplingQuality(convert.ResamplingQuality(quality))
+}
+
+// ResampleWithQuality is the same as Resample but uses the given resampling quality.
+//
+// ResampleWithQuality panics if quality is not a valid value.
+func ResampleWithQuality(source io.ReadSeeker, size int64, from, to int, quality ResamplingQuality) io.ReadSeeker {
+	quality.validate()
+	if from == to {
+		return source
+	}
+	return convert.NewResamplingWithQuality(source, size, from, to, convert.ResamplingQuality(quality))
+}
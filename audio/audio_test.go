@@ -16,6 +16,8 @@ package audio_test
 
 import (
 	"bytes"
+	"io"
+	"math"
 	"runtime"
 	"testing"
 	"time"
@@ -116,3 +118,188 @@ func TestPauseBeforeInit(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestDucker(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// The trigger keeps playing until the writer is closed.
+	r, w := io.Pipe()
+	defer w.Close()
+
+	trigger, err := context.NewPlayer(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := context.NewPlayerFromBytes(make([]byte, 4))
+	target.SetVolume(0.5)
+
+	d := context.NewDucker(&audio.DuckerOptions{
+		Attenuation: 20,
+	})
+	d.AddTrigger(trigger)
+	d.AddTarget(target)
+
+	trigger.Play()
+	if err := audio.UpdateForTesting(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 20dB is 1/10 in amplitude.
+	if got, want := target.UnderlyingVolumeForTesting(), 0.05; math.Abs(got-want) > 1e-9 {
+		t.Errorf("underlying volume while ducking: got: %v, want: %v", got, want)
+	}
+	if got, want := target.Volume(), 0.5; got != want {
+		t.Errorf("Volume while ducking: got: %v, want: %v", got, want)
+	}
+
+	trigger.Pause()
+	if err := audio.UpdateForTesting(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := target.UnderlyingVolumeForTesting(), 0.5; math.Abs(got-want) > 1e-9 {
+		t.Errorf("underlying volume after ducking: got: %v, want: %v", got, want)
+	}
+
+	trigger.Play()
+	if err := audio.UpdateForTesting(); err != nil {
+		t.Fatal(err)
+	}
+	d.Close()
+	if got, want := target.UnderlyingVolumeForTesting(), 0.5; math.Abs(got-want) > 1e-9 {
+		t.Errorf("underlying volume after Close: got: %v, want: %v", got, want)
+	}
+}
+
+func TestDuckerMultipleDuckers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	r, w := io.Pipe()
+	defer w.Close()
+
+	trigger, err := context.NewPlayer(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := context.NewPlayerFromBytes(make([]byte, 4))
+	target.SetVolume(1)
+
+	d0 := context.NewDucker(&audio.DuckerOptions{
+		Attenuation: 20,
+	})
+	defer d0.Close()
+	d0.AddTrigger(trigger)
+	d0.AddTarget(target)
+
+	d1 := context.NewDucker(&audio.DuckerOptions{
+		Attenuation: 20,
+	})
+	defer d1.Close()
+	d1.AddTrigger(trigger)
+	d1.AddTarget(target)
+
+	trigger.Play()
+	if err := audio.UpdateForTesting(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The gains are multiplied: 20dB + 20dB is 1/100 in amplitude.
+	if got, want := target.UnderlyingVolumeForTesting(), 0.01; math.Abs(got-want) > 1e-9 {
+		t.Errorf("underlying volume while ducking: got: %v, want: %v", got, want)
+	}
+
+	// Removing the target from one Ducker keeps the other's gain.
+	d1.RemoveTarget(target)
+	if got, want := target.UnderlyingVolumeForTesting(), 0.1; math.Abs(got-want) > 1e-9 {
+		t.Errorf("underlying volume after RemoveTarget: got: %v, want: %v", got, want)
+	}
+}
+
+func TestPan(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// A stereo sample: (L, R) = (0x1000, 0x1000).
+	p := context.NewPlayerFromBytes([]byte{0x00, 0x10, 0x00, 0x10})
+	p.SetPan(0.5)
+	p.Play()
+
+	if got, want := p.Pan(), 0.5; got != want {
+		t.Errorf("Pan: got: %v, want: %v", got, want)
+	}
+	if l, r := p.StereoGainsForTesting(); l != 0.5 || r != 1 {
+		t.Errorf("stereo gains: got: (%v, %v), want: (0.5, 1)", l, r)
+	}
+
+	p.SetPan(-1)
+	if l, r := p.StereoGainsForTesting(); l != 1 || r != 0 {
+		t.Errorf("stereo gains: got: (%v, %v), want: (1, 0)", l, r)
+	}
+}
+
+func TestSpatialPosition(t *testing.T) {
+	setup()
+	defer teardown()
+
+	context.SetSpatialOptions(&audio.SpatialOptions{
+		ReferenceDistance: 100,
+		PanDistance:       400,
+	})
+	context.SetListenerPosition(100, 100)
+
+	r, w := io.Pipe()
+	defer w.Close()
+
+	p, err := context.NewPlayer(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.SetVolume(0.5)
+	p.SetSpatialPosition(100, 100)
+	p.Play()
+	if got, want := p.UnderlyingVolumeForTesting(), 0.5; math.Abs(got-want) > 1e-9 {
+		t.Errorf("underlying volume: got: %v, want: %v", got, want)
+	}
+
+	// The listener moves away. The source is at 200 to the left of the listener.
+	context.SetListenerPosition(300, 100)
+	if err := audio.UpdateForTesting(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := p.UnderlyingVolumeForTesting(), 0.25; math.Abs(got-want) > 1e-9 {
+		t.Errorf("underlying volume: got: %v, want: %v", got, want)
+	}
+	if got, want := p.Volume(), 0.5; got != want {
+		t.Errorf("Volume: got: %v, want: %v", got, want)
+	}
+	if l, r := p.StereoGainsForTesting(); l != 1 || r != 0.5 {
+		t.Errorf("stereo gains: got: (%v, %v), want: (1, 0.5)", l, r)
+	}
+
+	p.ClearSpatialPosition()
+	if got, want := p.UnderlyingVolumeForTesting(), 0.5; math.Abs(got-want) > 1e-9 {
+		t.Errorf("underlying volume after ClearSpatialPosition: got: %v, want: %v", got, want)
+	}
+	if l, r := p.StereoGainsForTesting(); l != 1 || r != 1 {
+		t.Errorf("stereo gains after ClearSpatialPosition: got: (%v, %v), want: (1, 1)", l, r)
+	}
+}
+
+func TestSetResamplingQualityWithInvalidValue(t *testing.T) {
+	defer func() {
+		if e := recover(); e == nil {
+			t.Errorf("SetResamplingQuality with an invalid value must panic")
+		}
+	}()
+	audio.SetResamplingQuality(audio.ResamplingQuality(-1))
+}
+
+func TestResampleWithQualityWithInvalidValue(t *testing.T) {
+	defer func() {
+		if e := recover(); e == nil {
+			t.Errorf("ResampleWithQuality with an invalid value must panic")
+		}
+	}()
+	audio.ResampleWithQuality(bytes.NewReader(make([]byte, 16)), 16, 44100, 48000, audio.ResamplingQuality(3))
+}
@@ -0,0 +1,245 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// BufferedStreamOptions represents options for a BufferedStream.
+type BufferedStreamOptions struct {
+	// BufferSize is the size of the ring buffer in bytes.
+	//
+	// If BufferSize is 0, 256 KiB is used, which is about 1.5 seconds of 16bit stereo at 44100 [Hz].
+	BufferSize int
+
+	// PrebufferSize is the size in bytes to buffer before the playback starts, and before the playback resumes
+	// after an underrun.
+	//
+	// If PrebufferSize is 0, the half of BufferSize is used.
+	// If PrebufferSize is negative, the playback starts or resumes as soon as any data is buffered.
+	PrebufferSize int
+
+	// BytesPerFrame is the size of one frame, i.e. a sample of all the channels, in bytes.
+	// A BufferedStream returns data and silence by frames so that the channels are not swapped.
+	//
+	// If BytesPerFrame is 0, 4 is used for 16bit stereo, which NewPlayer accepts.
+	// Specify 8 for 32bit float stereo, which NewPlayerF32 accepts.
+	BytesPerFrame int
+}
+
+// BufferedStream is a decoded stream that reads a source stream ahead into a ring buffer in the background.
+//
+// BufferedStream is useful to play a stream from a network, e.g. an internet radio, where the source is not
+// seekable and its data might be delayed. Pass a decoded stream, e.g. a stream decoded by mp3.DecodeWithSampleRate
+// from an HTTP response body, to NewBufferedStream, and pass the BufferedStream to NewPlayer.
+//
+// When the buffer runs out of data, BufferedStream returns silence until the prebuffer size is buffered again.
+// The player keeps playing, and the data that is not arrived yet is not lost but played later.
+// BufferedStream never blocks reading, as all the players are read on one goroutine and blocking one
+// player would stop all the other players.
+//
+// BufferedStream doesn't implement io.Seeker, so a player with a BufferedStream cannot be rewound or seeked.
+type BufferedStream struct {
+	src io.Reader
+
+	buf  []byte
+	head int
+	size int
+
+	// err is an error returned by src, including io.EOF.
+	err error
+
+	closed    bool
+	buffering bool
+	underruns int
+
+	prebufferSize int
+	bytesPerFrame int
+
+	m    sync.Mutex
+	cond *sync.Cond
+}
+
+// NewBufferedStream creates a new BufferedStream and starts reading src in the background.
+//
+// A BufferedStream doesn't close src even if src implements io.Closer.
+// Closing the source is src owner's responsibility.
+func NewBufferedStream(src io.Reader, options *BufferedStreamOptions) *BufferedStream {
+	if options == nil {
+		options = &BufferedStreamOptions{}
+	}
+
+	bytesPerFrame := options.BytesPerFrame
+	if bytesPerFrame <= 0 {
+		bytesPerFrame = bytesPerSampleInt16
+	}
+	bufferSize := options.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = 256 * 1024
+	}
+	bufferSize = (bufferSize + bytesPerFrame - 1) / bytesPerFrame * bytesPerFrame
+
+	prebufferSize := options.PrebufferSize
+	if prebufferSize == 0 {
+		prebufferSize = bufferSize / 2
+	}
+	if prebufferSize > bufferSize {
+		prebufferSize = bufferSize
+	}
+
+	s := &BufferedStream{
+		src:           src,
+		buf:           make([]byte, bufferSize),
+		buffering:     prebufferSize > 0,
+		prebufferSize: prebufferSize,
+		bytesPerFrame: bytesPerFrame,
+	}
+	s.cond = sync.NewCond(&s.m)
+	go s.loop()
+	return s
+}
+
+func (s *BufferedStream) loop() {
+	for {
+		s.m.Lock()
+		for s.size == len(s.buf) && !s.closed {
+			s.cond.Wait()
+		}
+		if s.closed {
+			s.m.Unlock()
+			return
+		}
+		// Only the free region is written, and Read never touches the free region.
+		tail := (s.head + s.size) % len(s.buf)
+		n := len(s.buf) - s.size
+		if n > len(s.buf)-tail {
+			n = len(s.buf) - tail
+		}
+		s.m.Unlock()
+
+		n, err := s.src.Read(s.buf[tail : tail+n])
+
+		s.m.Lock()
+		s.size += n
+		if err != nil {
+			s.err = err
+		}
+		if s.buffering && (s.size >= s.prebufferSize || s.err != nil) {
+			s.buffering = false
+		}
+		s.cond.Broadcast()
+		s.m.Unlock()
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Read is implementation of io.Reader's Read.
+func (s *BufferedStream) Read(buf []byte) (int, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	n := len(buf) / s.bytesPerFrame * s.bytesPerFrame
+	if n == 0 {
+		return 0, nil
+	}
+
+	if s.closed {
+		return 0, errors.New("audio: the stream is already closed")
+	}
+
+	// Return only whole frames unless the source reaches its end.
+	available := s.size / s.bytesPerFrame * s.bytesPerFrame
+	if s.err != nil {
+		available = s.size
+	}
+
+	if !s.buffering && available > 0 {
+		if n > available {
+			n = available
+		}
+		m := copy(buf[:n], s.buf[s.head:])
+		if m < n {
+			copy(buf[m:n], s.buf)
+		}
+		s.head = (s.head + n) % len(s.buf)
+		s.size -= n
+		s.cond.Broadcast()
+		return n, nil
+	}
+
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	if !s.buffering {
+		s.underruns++
+		s.buffering = s.prebufferSize > 0
+	}
+
+	// Don't block here. Players are read on one goroutine, and blocking here would stop all the players.
+	for i := range buf[:n] {
+		buf[i] = 0
+	}
+	return n, nil
+}
+
+// Buffered returns the size of the buffered data in bytes.
+func (s *BufferedStream) Buffered() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.size
+}
+
+// IsBuffering reports whether the stream is waiting for data to start or resume the playback.
+func (s *BufferedStream) IsBuffering() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.buffering
+}
+
+// Underruns returns the number of times the buffer ran out of data.
+func (s *BufferedStream) Underruns() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.underruns
+}
+
+// Err returns the error returned by the source stream other than io.EOF.
+func (s *BufferedStream) Err() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.err == io.EOF {
+		return nil
+	}
+	return s.err
+}
+
+// Close stops reading the source stream in the background.
+//
+// If the background reading is blocked by the source, e.g. a network stall, the reading finishes when the source
+// returns. Close the source, e.g. an HTTP response body, to stop it immediately.
+func (s *BufferedStream) Close() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.closed = true
+	s.cond.Broadcast()
+	return nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// eofNotifier notifies when the source reaches its end.
+type eofNotifier struct {
+	r    io.Reader
+	done chan struct{}
+}
+
+func (e *eofNotifier) Read(buf []byte) (int, error) {
+	n, err := e.r.Read(buf)
+	if err == io.EOF {
+		close(e.done)
+	}
+	return n, err
+}
+
+func TestBufferedStream(t *testing.T) {
+	src := make([]byte, 1000)
+	for i := range src {
+		src[i] = byte(i)
+	}
+
+	r := &eofNotifier{
+		r:    bytes.NewReader(src),
+		done: make(chan struct{}),
+	}
+	// Use a small buffer so that the ring buffer wraps around.
+	s := audio.NewBufferedStream(r, &audio.BufferedStreamOptions{
+		BufferSize:    64,
+		PrebufferSize: -1,
+	})
+	defer s.Close()
+
+	// Read only buffered data not to get silence.
+	var got []byte
+	buf := make([]byte, 16)
+	timeout := time.Now().Add(5 * time.Second)
+	for {
+		if time.Now().After(timeout) {
+			t.Fatal("timeout")
+		}
+		if s.Buffered() == 0 {
+			select {
+			case <-r.done:
+			default:
+				time.Sleep(time.Millisecond)
+				continue
+			}
+			if s.Buffered() == 0 {
+				if _, err := s.Read(buf); err != io.EOF {
+					t.Errorf("err: got: %v, want: %v", err, io.EOF)
+				}
+				break
+			}
+		}
+		n, err := s.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, buf[:n]...)
+	}
+	if !bytes.Equal(got, src) {
+		t.Errorf("got: %v, want: %v", got, src)
+	}
+}
+
+func TestBufferedStreamDoesNotBlock(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	s := audio.NewBufferedStream(r, nil)
+	defer s.Close()
+
+	// Read must return silence immediately without any data.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 16)
+		n, err := s.Read(buf)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got, want := buf[:n], make([]byte, 16); !bytes.Equal(got, want) {
+			t.Errorf("got: %v, want: %v", got, want)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Read blocked")
+	}
+}
+
+func waitForBuffered(t *testing.T, s *audio.BufferedStream, size int) {
+	t.Helper()
+	timeout := time.Now().Add(5 * time.Second)
+	for s.Buffered() < size {
+		if time.Now().After(timeout) {
+			t.Fatalf("timeout: Buffered(): got: %d, want: %d", s.Buffered(), size)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBufferedStreamUnderrunSilence(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	s := audio.NewBufferedStream(r, &audio.BufferedStreamOptions{
+		BufferSize:    64,
+		PrebufferSize: 16,
+	})
+	defer s.Close()
+
+	buf := make([]byte, 8)
+
+	// Silence is returned until the prebuffering finishes.
+	if _, err := w.Write(bytes.Repeat([]byte{1}, 8)); err != nil {
+		t.Fatal(err)
+	}
+	waitForBuffered(t, s, 8)
+	if !s.IsBuffering() {
+		t.Errorf("IsBuffering(): got: false, want: true")
+	}
+	n, err := s.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf[:n], make([]byte, 8); !bytes.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	if _, err := w.Write(bytes.Repeat([]byte{2}, 8)); err != nil {
+		t.Fatal(err)
+	}
+	waitForBuffered(t, s, 16)
+	if s.IsBuffering() {
+		t.Errorf("IsBuffering(): got: true, want: false")
+	}
+
+	got := make([]byte, 16)
+	if _, err := io.ReadFull(s, got); err != nil {
+		t.Fatal(err)
+	}
+	if want := append(bytes.Repeat([]byte{1}, 8), bytes.Repeat([]byte{2}, 8)...); !bytes.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// The buffer runs out of data.
+	n, err = s.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf[:n], make([]byte, 8); !bytes.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := s.Underruns(), 1; got != want {
+		t.Errorf("Underruns(): got: %d, want: %d", got, want)
+	}
+	if !s.IsBuffering() {
+		t.Errorf("IsBuffering(): got: false, want: true")
+	}
+}
+
+func TestBufferedStreamFrames(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	s := audio.NewBufferedStream(r, &audio.BufferedStreamOptions{
+		PrebufferSize: -1,
+	})
+	defer s.Close()
+
+	// A partial frame must not be returned.
+	if _, err := w.Write([]byte{1, 2, 3, 4, 5, 6}); err != nil {
+		t.Fatal(err)
+	}
+	waitForBuffered(t, s, 6)
+
+	buf := make([]byte, 8)
+	n, err := s.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf[:n], []byte{1, 2, 3, 4}; !bytes.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
@@ -12,19 +12,23 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+//go:build !ebitenginecustomplatform
+
 package audio
 
 import (
 	"io"
+	"time"
 
 	"github.com/ebitengine/oto/v3"
 )
 
-func newContext(sampleRate int) (context, chan struct{}, error) {
+func newContext(sampleRate int, bufferSize time.Duration) (context, chan struct{}, error) {
 	ctx, ready, err := oto.NewContext(&oto.NewContextOptions{
 		SampleRate:   sampleRate,
 		ChannelCount: channelCount,
 		Format:       oto.FormatSignedInt16LE,
+		BufferSize:   bufferSize,
 	})
 	err = addErrorInfoForContextCreation(err)
 	return &contextProxy{ctx}, ready, err
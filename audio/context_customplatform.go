@@ -0,0 +1,47 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ebitenginecustomplatform
+
+package audio
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/customplatform"
+)
+
+func newContext(sampleRate int, bufferSize time.Duration) (context, chan struct{}, error) {
+	a, ok := customplatform.Get().(customplatform.Audio)
+	if !ok {
+		return nil, nil, errors.New("audio: the platform layer doesn't support audio")
+	}
+	ctx, ready, err := a.NewAudioContext(sampleRate, channelCount, bufferSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &contextProxy{ctx}, ready, nil
+}
+
+// contextProxy is a proxy between customplatform.AudioContext and context.
+type contextProxy struct {
+	customplatform.AudioContext
+}
+
+// NewPlayer implements context.
+func (c *contextProxy) NewPlayer(r io.Reader) player {
+	return c.AudioContext.NewPlayer(r)
+}
@@ -0,0 +1,220 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// DuckerOptions represents options for NewDucker.
+type DuckerOptions struct {
+	// Attenuation is the amount to lower the volume of the target players in decibels while ducking.
+	// For example, 12 lowers the volume by 12dB.
+	//
+	// Attenuation must not be negative.
+	Attenuation float64
+
+	// Attack is the time to reach the full attenuation after a trigger player starts playing.
+	//
+	// If Attack is 0, the volume is lowered immediately.
+	Attack time.Duration
+
+	// Release is the time to recover the volume after all the trigger players stop playing.
+	//
+	// If Release is 0, the volume is recovered immediately.
+	Release time.Duration
+}
+
+// Ducker lowers the volume of target players while any of trigger players is playing.
+// This is known as sidechain ducking, e.g. lowering the music while a narration is playing.
+//
+// The volumes are updated once per tick, not in the audio mixer. Then, the ducking gain changes step by step at each tick
+// even during Attack and Release.
+// The volume of a target player is the multiplication of its SetVolume value and the ducking gain,
+// so Volume still returns the value given to SetVolume.
+// If a player is a target of multiple Duckers, the ducking gains are multiplied.
+//
+// A closed player is removed from the triggers and the targets automatically.
+type Ducker struct {
+	context *Context
+	options DuckerOptions
+
+	triggers map[*playerImpl]struct{}
+	targets  map[*playerImpl]struct{}
+
+	// level is the current ducking level in [0, 1]. 0 means no ducking, and 1 means the full attenuation.
+	level      float64
+	lastUpdate time.Time
+
+	// gain is the current ducking gain for the targets.
+	gain float64
+
+	m sync.Mutex
+}
+
+// NewDucker creates a new Ducker.
+//
+// NewDucker panics if options.Attenuation is negative.
+func (c *Context) NewDucker(options *DuckerOptions) *Ducker {
+	if options == nil {
+		options = &DuckerOptions{}
+	}
+	if options.Attenuation < 0 {
+		panic("audio: Attenuation must not be negative")
+	}
+	d := &Ducker{
+		context:  c,
+		options:  *options,
+		triggers: map[*playerImpl]struct{}{},
+		targets:  map[*playerImpl]struct{}{},
+		gain:     1,
+	}
+	c.addDucker(d)
+	return d
+}
+
+// AddTrigger adds a player whose playing causes ducking.
+func (d *Ducker) AddTrigger(player *Player) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.triggers[player.p] = struct{}{}
+}
+
+// RemoveTrigger removes a trigger player.
+func (d *Ducker) RemoveTrigger(player *Player) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	delete(d.triggers, player.p)
+}
+
+// AddTarget adds a player whose volume is lowered while ducking.
+func (d *Ducker) AddTarget(player *Player) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.targets[player.p] = struct{}{}
+}
+
+// RemoveTarget removes a target player. The ducking gain of the player is reset.
+func (d *Ducker) RemoveTarget(player *Player) {
+	d.m.Lock()
+	delete(d.targets, player.p)
+	d.m.Unlock()
+
+	player.p.setDuckingGain(d.context.duckingGain(player.p))
+}
+
+// Close stops ducking and resets the ducking gains of all the target players.
+// After Close is called, the Ducker must not be used.
+func (d *Ducker) Close() {
+	d.context.removeDucker(d)
+
+	d.m.Lock()
+	targets := make([]*playerImpl, 0, len(d.targets))
+	for p := range d.targets {
+		targets = append(targets, p)
+	}
+	d.targets = map[*playerImpl]struct{}{}
+	d.triggers = map[*playerImpl]struct{}{}
+	d.m.Unlock()
+
+	for _, p := range targets {
+		p.setDuckingGain(d.context.duckingGain(p))
+	}
+}
+
+// update updates the ducking level and the gain, and removes closed players.
+// The gain is applied to the target players by Context.
+//
+// A Ducker must not call playerImpl's functions with a lock (#2737).
+func (d *Ducker) update(now time.Time) {
+	d.m.Lock()
+	players := make([]*playerImpl, 0, len(d.triggers)+len(d.targets))
+	for p := range d.triggers {
+		players = append(players, p)
+	}
+	triggerCount := len(players)
+	for p := range d.targets {
+		players = append(players, p)
+	}
+	d.m.Unlock()
+
+	var triggered bool
+	var closed []*playerImpl
+	for i, p := range players {
+		if p.isClosed() {
+			closed = append(closed, p)
+			continue
+		}
+		if i < triggerCount && p.IsPlaying() {
+			triggered = true
+		}
+	}
+
+	d.m.Lock()
+	for _, p := range closed {
+		delete(d.triggers, p)
+		delete(d.targets, p)
+	}
+
+	var dt time.Duration
+	if !d.lastUpdate.IsZero() {
+		dt = now.Sub(d.lastUpdate)
+	}
+	d.lastUpdate = now
+	d.level = nextDuckingLevel(d.level, triggered, dt, d.options.Attack, d.options.Release)
+	d.gain = math.Pow(10, -d.options.Attenuation*d.level/20)
+	d.m.Unlock()
+}
+
+// multiplyTargetGains multiplies the gains of the target players in gains by the ducking gain.
+func (d *Ducker) multiplyTargetGains(gains map[*playerImpl]float64) {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	for p := range d.targets {
+		g, ok := gains[p]
+		if !ok {
+			g = 1
+		}
+		gains[p] = g * d.gain
+	}
+}
+
+// targetGain returns the ducking gain for the player p, or 1 if p is not a target.
+func (d *Ducker) targetGain(p *playerImpl) float64 {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	if _, ok := d.targets[p]; !ok {
+		return 1
+	}
+	return d.gain
+}
+
+// nextDuckingLevel returns the ducking level after dt.
+func nextDuckingLevel(level float64, triggered bool, dt time.Duration, attack, release time.Duration) float64 {
+	if triggered {
+		if attack <= 0 {
+			return 1
+		}
+		return math.Min(level+float64(dt)/float64(attack), 1)
+	}
+	if release <= 0 {
+		return 0
+	}
+	return math.Max(level-float64(dt)/float64(release), 0)
+}
@@ -154,3 +154,36 @@ func ResetContextForTesting() {
 func (i *InfiniteLoop) SetNoBlendForTesting(value bool) {
 	i.noBlendForTesting = value
 }
+
+func (p *Player) UnderlyingVolumeForTesting() float64 {
+	p.p.m.Lock()
+	defer p.p.m.Unlock()
+	if p.p.player == nil {
+		return 0
+	}
+	return p.p.player.Volume()
+}
+
+func (p *Player) StereoGainsForTesting() (float64, float64) {
+	p.p.m.Lock()
+	defer p.p.m.Unlock()
+	if p.p.stream == nil {
+		return 0, 0
+	}
+	p.p.stream.m.Lock()
+	defer p.p.stream.m.Unlock()
+	return p.p.stream.leftGain, p.p.stream.rightGain
+}
+
+func NewLoopStreamForTesting(src io.ReadSeeker, introSamples, loopLengthSamples int64) (io.Reader, error) {
+	s, err := newTimeStream(src, 44100)
+	if err != nil {
+		return nil, err
+	}
+	s.setLoop(introSamples*bytesPerSampleInt16, loopLengthSamples*bytesPerSampleInt16)
+	return s, nil
+}
+
+func PlayedPositionForTesting(loopStream io.Reader, bufferedSize int64) int64 {
+	return loopStream.(*timeStream).playedPosition(bufferedSize)
+}
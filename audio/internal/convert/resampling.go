@@ -15,11 +15,39 @@
 package convert
 
 import (
+	"fmt"
 	"io"
 	"math"
 	"sync"
+	"sync/atomic"
 )
 
+// ResamplingQuality represents the algorithm of resampling.
+type ResamplingQuality int
+
+const (
+	// ResamplingQualityHigh uses a windowed sinc filter with a wide window.
+	ResamplingQualityHigh ResamplingQuality = iota
+
+	// ResamplingQualityMedium uses a windowed sinc filter with a narrow window.
+	ResamplingQualityMedium
+
+	// ResamplingQualityLow uses linear interpolation.
+	ResamplingQualityLow
+)
+
+var defaultResamplingQuality atomic.Int32
+
+// SetDefaultResamplingQuality sets the quality used by NewResampling.
+func SetDefaultResamplingQuality(quality ResamplingQuality) {
+	defaultResamplingQuality.Store(int32(quality))
+}
+
+// DefaultResamplingQuality returns the quality used by NewResampling.
+func DefaultResamplingQuality() ResamplingQuality {
+	return ResamplingQuality(defaultResamplingQuality.Load())
+}
+
 var (
 	// cosTable contains values of cosine applied to the range [0, π/2).
 	// It must be initialised the first time it is referenced
@@ -82,6 +110,7 @@ type Resampling struct {
 	size         int64
 	from         int
 	to           int
+	quality      ResamplingQuality
 	pos          int64
 	srcBlock     int64
 	srcBufL      map[int64][]float64
@@ -89,12 +118,19 @@ type Resampling struct {
 	lruSrcBlocks []int64
 }
 
+// NewResampling creates a new Resampling with the default quality.
 func NewResampling(source io.ReadSeeker, size int64, from, to int) *Resampling {
+	return NewResamplingWithQuality(source, size, from, to, DefaultResamplingQuality())
+}
+
+// NewResamplingWithQuality creates a new Resampling with the given quality.
+func NewResamplingWithQuality(source io.ReadSeeker, size int64, from, to int, quality ResamplingQuality) *Resampling {
 	r := &Resampling{
 		source:   source,
 		size:     size,
 		from:     from,
 		to:       to,
+		quality:  quality,
 		srcBlock: -1,
 		srcBufL:  map[int64][]float64{},
 		srcBufR:  map[int64][]float64{},
@@ -174,8 +210,20 @@ func (r *Resampling) src(i int64) (float64, float64, error) {
 }
 
 func (r *Resampling) at(t int64) (float64, float64, error) {
-	windowSize := 8.0
 	tInSrc := float64(t) * float64(r.from) / float64(r.to)
+
+	var windowSize float64
+	switch r.quality {
+	case ResamplingQualityHigh:
+		windowSize = 8
+	case ResamplingQualityMedium:
+		windowSize = 4
+	case ResamplingQualityLow:
+		return r.linearAt(tInSrc)
+	default:
+		panic(fmt.Sprintf("convert: unexpected resampling quality: %d", r.quality))
+	}
+
 	startN := int64(tInSrc - windowSize)
 	if startN < 0 {
 		startN = 0
@@ -215,6 +263,24 @@ func (r *Resampling) at(t int64) (float64, float64, error) {
 	return lv, rv, nil
 }
 
+func (r *Resampling) linearAt(tInSrc float64) (float64, float64, error) {
+	n := int64(tInSrc)
+	l0, r0, err := r.src(n)
+	if err != nil {
+		return 0, 0, err
+	}
+	l1, r1, err := r.src(n + 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	// At the end of the source, src returns 0. Keep the last sample instead of fading out to 0.
+	if r.size/4 <= n+1 {
+		l1, r1 = l0, r0
+	}
+	d := tInSrc - float64(n)
+	return l0*(1-d) + l1*d, r0*(1-d) + r1*d, nil
+}
+
 func (r *Resampling) Read(b []byte) (int, error) {
 	if r.pos == r.Length() {
 		return 0, io.EOF
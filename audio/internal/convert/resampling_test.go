@@ -87,3 +87,58 @@ func TestResampling(t *testing.T) {
 		}
 	}
 }
+
+func TestResamplingWithQuality(t *testing.T) {
+	cases := []struct {
+		In  int
+		Out int
+	}{
+		{
+			In:  44100,
+			Out: 48000,
+		},
+		{
+			In:  48000,
+			Out: 44100,
+		},
+	}
+	qualities := []struct {
+		Quality   convert.ResamplingQuality
+		Tolerance float64
+	}{
+		{
+			Quality:   convert.ResamplingQualityHigh,
+			Tolerance: 0.025,
+		},
+		{
+			Quality:   convert.ResamplingQualityMedium,
+			Tolerance: 0.025,
+		},
+		{
+			// Linear interpolation is less accurate especially around the clipped peaks.
+			Quality:   convert.ResamplingQualityLow,
+			Tolerance: 0.05,
+		},
+	}
+	for _, q := range qualities {
+		for _, c := range cases {
+			inB := newSoundBytes(c.In)
+			outS := convert.NewResamplingWithQuality(bytes.NewReader(inB), int64(len(inB)), c.In, c.Out, q.Quality)
+			gotB, err := io.ReadAll(outS)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantB := newSoundBytes(c.Out)
+			if len(gotB) != len(wantB) {
+				t.Errorf("quality: %d, len(gotB) == %d but len(wantB) == %d", q.Quality, len(gotB), len(wantB))
+			}
+			for i := 0; i < len(gotB)/2; i++ {
+				got := float64(int16(gotB[2*i])|(int16(gotB[2*i+1])<<8)) / (1<<15 - 1)
+				want := float64(int16(wantB[2*i])|(int16(wantB[2*i+1])<<8)) / (1<<15 - 1)
+				if math.Abs(got-want) > q.Tolerance {
+					t.Errorf("quality: %d, sample rate: %d, index: %d: got: %f, want: %f", q.Quality, c.Out, i, got, want)
+				}
+			}
+		}
+	}
+}
@@ -257,3 +257,142 @@ func TestInfiniteLoopWithSlowSource(t *testing.T) {
 		t.Errorf("got: %d, want: %d", got, want)
 	}
 }
+
+func TestLoopPoints(t *testing.T) {
+	const (
+		introSamples   = 3
+		loopSamples    = 5
+		bytesPerSample = 4
+	)
+
+	// The source has extra samples after the loop end, which must not be played.
+	src := make([]byte, (introSamples+loopSamples+2)*bytesPerSample)
+	for i := range src {
+		src[i] = byte(i)
+	}
+	s, err := audio.NewLoopStreamForTesting(bytes.NewReader(src), introSamples, loopSamples)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, (introSamples+loopSamples*3)*bytesPerSample)
+	if _, err := io.ReadFull(s, buf); err != nil {
+		t.Fatal(err)
+	}
+	for i, b := range buf {
+		want := byte(i)
+		if i >= introSamples*bytesPerSample {
+			want = byte(introSamples*bytesPerSample + (i-introSamples*bytesPerSample)%(loopSamples*bytesPerSample))
+		}
+		if b != want {
+			t.Errorf("buf[%d]: got: %d, want: %d", i, b, want)
+		}
+	}
+}
+
+func TestLoopPointsWithShortSource(t *testing.T) {
+	// The source ends before the loop end.
+	src := make([]byte, 16)
+	for i := range src {
+		src[i] = byte(i)
+	}
+	s, err := audio.NewLoopStreamForTesting(bytes.NewReader(src), 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 40)
+	if _, err := io.ReadFull(s, buf); err != nil {
+		t.Fatal(err)
+	}
+	for i, b := range buf {
+		want := byte(i)
+		if i >= 4 {
+			want = byte(4 + (i-4)%12)
+		}
+		if b != want {
+			t.Errorf("buf[%d]: got: %d, want: %d", i, b, want)
+		}
+	}
+}
+
+func TestSetLoopPointsWithNonSeeker(t *testing.T) {
+	setup()
+	defer teardown()
+
+	r, w := io.Pipe()
+	defer w.Close()
+
+	p, err := context.NewPlayer(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.SetLoopPoints(0, 100); err == nil {
+		t.Errorf("SetLoopPoints with a non-seeker must return an error")
+	}
+}
+
+func TestLoopPointsPlayedPosition(t *testing.T) {
+	src := make([]byte, 40)
+	s, err := audio.NewLoopStreamForTesting(bytes.NewReader(src), 1, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Read the intro, the loop, and 2 samples of the next pass.
+	buf := make([]byte, 24+8)
+	if _, err := io.ReadFull(s, buf); err != nil {
+		t.Fatal(err)
+	}
+	// 12 bytes are not played yet, and 8 of them are from the next pass.
+	if got, want := audio.PlayedPositionForTesting(s, 12), int64(20); got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	// Seeking resets the loop state.
+	if _, err := s.(io.Seeker).Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := audio.PlayedPositionForTesting(s, 0), int64(0); got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
+func TestLoopPointsPlayedPositionWithShortSource(t *testing.T) {
+	// The source ends before the loop end.
+	src := make([]byte, 16)
+	s, err := audio.NewLoopStreamForTesting(bytes.NewReader(src), 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Read the whole source and 2 samples of the next pass.
+	buf := make([]byte, 16+8)
+	if _, err := io.ReadFull(s, buf); err != nil {
+		t.Fatal(err)
+	}
+	// The wrap must be calculated from the actual end of the source, not from the loop end.
+	if got, want := audio.PlayedPositionForTesting(s, 12), int64(12); got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
+func TestSetLoopPointsWithTooLongIntro(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// The source has 4 samples.
+	p, err := context.NewPlayer(bytes.NewReader(make([]byte, 16)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.SetLoopPoints(4, 1); err == nil {
+		t.Errorf("SetLoopPoints with the loop start at the source end must return an error")
+	}
+	if err := p.SetLoopPoints(5, 1); err == nil {
+		t.Errorf("SetLoopPoints with the loop start past the source end must return an error")
+	}
+	if err := p.SetLoopPoints(3, 1); err != nil {
+		t.Error(err)
+	}
+}
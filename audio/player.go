@@ -15,7 +15,10 @@
 package audio
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"math"
 	"runtime"
 	"sync"
 	"time"
@@ -46,15 +49,17 @@ type context interface {
 type playerFactory struct {
 	context    context
 	sampleRate int
+	bufferSize time.Duration
 
 	m sync.Mutex
 }
 
 var driverForTesting context
 
-func newPlayerFactory(sampleRate int) *playerFactory {
+func newPlayerFactory(sampleRate int, bufferSize time.Duration) *playerFactory {
 	f := &playerFactory{
 		sampleRate: sampleRate,
+		bufferSize: bufferSize,
 	}
 	if driverForTesting != nil {
 		f.context = driverForTesting
@@ -70,6 +75,29 @@ type playerImpl struct {
 	factory        *playerFactory
 	initBufferSize int
 
+	// volume is the volume specified by SetVolume.
+	volume float64
+
+	// duckingGain is the gain applied by a Ducker.
+	duckingGain float64
+
+	// pan is the stereo balance specified by SetPan.
+	pan float64
+
+	// spatial reports whether the spatial position is specified by SetSpatialPosition.
+	spatial  bool
+	spatialX float64
+	spatialY float64
+
+	// loopStart and loopLength are the loop specified by SetLoopPoints in bytes.
+	loopStart  int64
+	loopLength int64
+
+	// spatialGain and spatialPan are the gain and the pan calculated from the spatial position.
+	// The actual volume of the underlying player is volume * duckingGain * spatialGain.
+	spatialGain float64
+	spatialPan  float64
+
 	// adjustedPosition is the player's more accurate position.
 	// The underlying buffer might not be changed even if the player is playing.
 	// adjustedPosition is adjusted by the time duration during the player position doesn't change while its playing.
@@ -82,6 +110,9 @@ type playerImpl struct {
 	// stopwatch is a stopwatch to measure the time duration during the player position doesn't change while its playing.
 	stopwatch stopwatch
 
+	// closed reports whether Close is called.
+	closed bool
+
 	m sync.Mutex
 }
 
@@ -94,6 +125,9 @@ func (f *playerFactory) newPlayer(context *Context, src io.Reader) (*playerImpl,
 		context:     context,
 		factory:     f,
 		lastSamples: -1,
+		volume:      1,
+		duckingGain: 1,
+		spatialGain: 1,
 	}
 	runtime.SetFinalizer(p, (*playerImpl).Close)
 	return p, nil
@@ -137,7 +171,7 @@ func (f *playerFactory) initContextIfNeeded() (<-chan struct{}, error) {
 		return nil, nil
 	}
 
-	c, ready, err := newContext(f.sampleRate)
+	c, ready, err := newContext(f.sampleRate, f.bufferSize)
 	if err != nil {
 		return nil, err
 	}
@@ -168,9 +202,14 @@ func (p *playerImpl) ensurePlayer() error {
 			return err
 		}
 		p.stream = s
+		p.updateStereoGains()
+		p.stream.setLoop(p.loopStart, p.loopLength)
 	}
 	if p.player == nil {
 		p.player = p.factory.context.NewPlayer(p.stream)
+		if v := p.underlyingVolume(); v != 1 {
+			p.player.SetVolume(v)
+		}
 		if p.initBufferSize != 0 {
 			p.player.SetBufferSize(p.initBufferSize)
 			p.initBufferSize = 0
@@ -232,7 +271,7 @@ func (p *playerImpl) Volume() float64 {
 		p.context.setError(err)
 		return 0
 	}
-	return p.player.Volume()
+	return p.volume
 }
 
 func (p *playerImpl) SetVolume(volume float64) {
@@ -243,13 +282,100 @@ func (p *playerImpl) SetVolume(volume float64) {
 		p.context.setError(err)
 		return
 	}
-	p.player.SetVolume(volume)
+	p.volume = volume
+	p.player.SetVolume(p.underlyingVolume())
+}
+
+func (p *playerImpl) setDuckingGain(gain float64) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.duckingGain == gain {
+		return
+	}
+	p.duckingGain = gain
+	if p.player == nil {
+		return
+	}
+	p.player.SetVolume(p.underlyingVolume())
+}
+
+func (p *playerImpl) underlyingVolume() float64 {
+	return p.volume * p.duckingGain * p.spatialGain
+}
+
+func (p *playerImpl) Pan() float64 {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.pan
+}
+
+func (p *playerImpl) SetPan(pan float64) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.pan = pan
+	p.updateStereoGains()
+}
+
+func (p *playerImpl) setSpatialPosition(x, y float64) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.spatial = true
+	p.spatialX = x
+	p.spatialY = y
+	p.updateSpatialParams()
+}
+
+func (p *playerImpl) clearSpatialPosition() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.spatial = false
+	p.updateSpatialParams()
+}
+
+// updateSpatial updates the spatial gain and pan with the current listener position.
+func (p *playerImpl) updateSpatial() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if !p.spatial {
+		return
+	}
+	p.updateSpatialParams()
+}
+
+func (p *playerImpl) updateSpatialParams() {
+	gain, pan := 1.0, 0.0
+	if p.spatial {
+		gain, pan = p.context.spatialParams(p.spatialX, p.spatialY)
+	}
+	if p.spatialGain == gain && p.spatialPan == pan {
+		return
+	}
+	p.spatialGain = gain
+	p.spatialPan = pan
+	if p.player != nil {
+		p.player.SetVolume(p.underlyingVolume())
+	}
+	p.updateStereoGains()
+}
+
+func (p *playerImpl) updateStereoGains() {
+	if p.stream == nil {
+		return
+	}
+	l, r := stereoGains(math.Max(-1, math.Min(p.pan+p.spatialPan, 1)))
+	p.stream.setStereoGains(l, r)
 }
 
 func (p *playerImpl) Close() error {
 	p.m.Lock()
 	defer p.m.Unlock()
 	runtime.SetFinalizer(p, nil)
+	p.closed = true
 
 	if p.player != nil {
 		defer func() {
@@ -262,6 +388,12 @@ func (p *playerImpl) Close() error {
 	return nil
 }
 
+func (p *playerImpl) isClosed() bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.closed
+}
+
 func (p *playerImpl) Position() time.Duration {
 	p.m.Lock()
 	defer p.m.Unlock()
@@ -299,6 +431,43 @@ func (p *playerImpl) SetPosition(offset time.Duration) error {
 	return nil
 }
 
+func (p *playerImpl) SetLoopPoints(introSamples, loopLengthSamples int64) error {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if introSamples < 0 || loopLengthSamples < 0 {
+		return errors.New("audio: introSamples and loopLengthSamples must not be negative")
+	}
+	seeker, ok := p.src.(io.Seeker)
+	if !ok && loopLengthSamples != 0 {
+		return errors.New("audio: the source must be io.Seeker to loop")
+	}
+
+	loopStart := introSamples * bytesPerSampleInt16
+	if loopLengthSamples != 0 {
+		var length int64
+		var err error
+		if p.stream != nil {
+			length, err = p.stream.sourceLength()
+		} else {
+			length, err = seekerLength(seeker)
+		}
+		if err != nil {
+			return err
+		}
+		if loopStart >= length {
+			return fmt.Errorf("audio: introSamples (%d) must be less than the source length (%d samples)", introSamples, length/bytesPerSampleInt16)
+		}
+	}
+
+	p.loopStart = loopStart
+	p.loopLength = loopLengthSamples * bytesPerSampleInt16
+	if p.stream != nil {
+		p.stream.setLoop(p.loopStart, p.loopLength)
+	}
+	return nil
+}
+
 func (p *playerImpl) Err() error {
 	p.m.Lock()
 	defer p.m.Unlock()
@@ -361,7 +530,7 @@ func (p *playerImpl) updatePosition() {
 		return
 	}
 
-	samples := (p.stream.position() - int64(p.player.BufferedSize())) / bytesPerSampleInt16
+	samples := p.stream.playedPosition(int64(p.player.BufferedSize())) / bytesPerSampleInt16
 
 	var adjustingTime time.Duration
 	if p.lastSamples >= 0 && p.lastSamples == samples {
@@ -385,6 +554,19 @@ type timeStream struct {
 	sampleRate int
 	pos        int64
 
+	// leftGain and rightGain are the gains applied to the left and the right channels for panning.
+	leftGain  float64
+	rightGain float64
+
+	// loopStart and loopEnd are the byte positions of the loop. If loopEnd is 0, the stream is not looped.
+	loopStart int64
+	loopEnd   int64
+
+	// loopedEnd is the byte position where the stream went back to the loop start last time.
+	// This is the loop end, or the end of the source if the source ends before the loop end.
+	// If loopedEnd is 0, the stream has not gone back to the loop start since the last seek.
+	loopedEnd int64
+
 	// m is a mutex for this stream.
 	// All the exported functions are protected by this mutex as Read can be read from a different goroutine than Seek.
 	m sync.Mutex
@@ -394,6 +576,8 @@ func newTimeStream(r io.Reader, sampleRate int) (*timeStream, error) {
 	s := &timeStream{
 		r:          r,
 		sampleRate: sampleRate,
+		leftGain:   1,
+		rightGain:  1,
 	}
 	if seeker, ok := s.r.(io.Seeker); ok {
 		// Get the current position of the source.
@@ -410,11 +594,125 @@ func (s *timeStream) Read(buf []byte) (int, error) {
 	s.m.Lock()
 	defer s.m.Unlock()
 
+	if s.loopEnd == 0 {
+		return s.read(buf)
+	}
+
+	if s.pos >= s.loopEnd {
+		if err := s.seekToLoopStart(); err != nil {
+			return 0, err
+		}
+	}
+	if rest := s.loopEnd - s.pos; int64(len(buf)) > rest {
+		buf = buf[:rest]
+	}
+	n, err := s.read(buf)
+	if err == io.EOF {
+		// The source ends before the loop end. Go back to the loop start.
+		if err := s.seekToLoopStart(); err != nil {
+			return n, err
+		}
+		return n, nil
+	}
+	return n, err
+}
+
+func (s *timeStream) read(buf []byte) (int, error) {
+	if s.leftGain == 1 && s.rightGain == 1 {
+		n, err := s.r.Read(buf)
+		s.pos += int64(n)
+		return n, err
+	}
+
+	// Read whole samples so that the gains can be applied to each channel.
+	if len(buf) >= bytesPerSampleInt16 {
+		buf = buf[:len(buf)-len(buf)%bytesPerSampleInt16]
+	}
 	n, err := s.r.Read(buf)
+	if err == nil && n%bytesPerSampleInt16 != 0 && len(buf) >= bytesPerSampleInt16 {
+		m, err2 := io.ReadFull(s.r, buf[n:n+bytesPerSampleInt16-n%bytesPerSampleInt16])
+		n += m
+		if err2 == io.ErrUnexpectedEOF {
+			err2 = io.EOF
+		}
+		err = err2
+	}
+
+	// s.pos is aligned with the samples at the beginning of the source.
+	start := int((bytesPerSampleInt16 - s.pos%bytesPerSampleInt16) % bytesPerSampleInt16)
+	if start < n {
+		applyStereoGains(buf[start:n], s.leftGain, s.rightGain)
+	}
 	s.pos += int64(n)
 	return n, err
 }
 
+func (s *timeStream) seekToLoopStart() error {
+	seeker, ok := s.r.(io.Seeker)
+	if !ok {
+		panic("audio: the source must be io.Seeker when looping but not")
+	}
+	end := s.pos
+	pos, err := seeker.Seek(s.loopStart, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	s.pos = pos
+	s.loopedEnd = end
+	return nil
+}
+
+// sourceLength returns the length of the source in bytes.
+func (s *timeStream) sourceLength() (int64, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	seeker, ok := s.r.(io.Seeker)
+	if !ok {
+		panic("audio: the source must be io.Seeker when getting the length but not")
+	}
+	return seekerLength(seeker)
+}
+
+// seekerLength returns the length of the seeker in bytes without changing the current position.
+func seekerLength(seeker io.Seeker) (int64, error) {
+	cur, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := seeker.Seek(cur, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return end, nil
+}
+
+// setLoop sets the loop in bytes. If length is 0, the loop is cleared.
+func (s *timeStream) setLoop(start, length int64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if length == 0 {
+		s.loopStart = 0
+		s.loopEnd = 0
+		s.loopedEnd = 0
+		return
+	}
+	s.loopStart = start
+	s.loopEnd = start + length
+}
+
+func (s *timeStream) setStereoGains(left, right float64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.leftGain = left
+	s.rightGain = right
+}
+
 func (s *timeStream) Seek(offset int64, whence int) (int64, error) {
 	s.m.Lock()
 	defer s.m.Unlock()
@@ -430,6 +728,8 @@ func (s *timeStream) Seek(offset int64, whence int) (int64, error) {
 	}
 
 	s.pos = pos
+	// The buffered data after seeking is never from the previous pass of the loop.
+	s.loopedEnd = 0
 	return pos, nil
 }
 
@@ -446,11 +746,18 @@ func (s *timeStream) timeDurationToPos(offset time.Duration) int64 {
 	return o
 }
 
-func (s *timeStream) position() int64 {
+// playedPosition returns the byte position of the data that has been played,
+// considering bufferedSize bytes are not played yet.
+func (s *timeStream) playedPosition(bufferedSize int64) int64 {
 	s.m.Lock()
 	defer s.m.Unlock()
 
-	return s.pos
+	pos := s.pos - bufferedSize
+	// The buffered data might include the data before the stream went back to the loop start.
+	if s.loopedEnd != 0 && pos < s.loopStart {
+		pos += s.loopedEnd - s.loopStart
+	}
+	return pos
 }
 
 func (s *timeStream) positionInTimeDuration() time.Duration {
@@ -0,0 +1,119 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"math"
+)
+
+// SpatialOptions represents options for the 2D spatial audio of a Context.
+type SpatialOptions struct {
+	// ReferenceDistance is the distance from the listener within which the volume is not attenuated.
+	// Beyond ReferenceDistance, the volume is multiplied by ReferenceDistance / distance.
+	//
+	// If ReferenceDistance is 0, the volume is not attenuated by the distance.
+	ReferenceDistance float64
+
+	// PanDistance is the horizontal distance from the listener at which the sound is fully panned to one side.
+	//
+	// If PanDistance is 0, the sound is not panned by the position.
+	PanDistance float64
+}
+
+// SetSpatialOptions sets the options for the players whose spatial positions are specified by SetSpatialPosition.
+//
+// If options is nil, the default options are used, which neither attenuate nor pan the sounds.
+func (c *Context) SetSpatialOptions(options *SpatialOptions) {
+	if options == nil {
+		options = &SpatialOptions{}
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.spatialOptions = *options
+}
+
+// ListenerPosition returns the position of the listener.
+func (c *Context) ListenerPosition() (x, y float64) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.listenerX, c.listenerY
+}
+
+// SetListenerPosition sets the position of the listener, e.g. the camera or the player character.
+// The default position is (0, 0).
+//
+// The volumes and the pans of the playing players are updated every tick.
+func (c *Context) SetListenerPosition(x, y float64) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.listenerX = x
+	c.listenerY = y
+}
+
+// spatialParams returns the gain and the pan of a sound at (x, y).
+func (c *Context) spatialParams(x, y float64) (gain, pan float64) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return calcSpatialParams(x-c.listenerX, y-c.listenerY, c.spatialOptions)
+}
+
+func calcSpatialParams(dx, dy float64, options SpatialOptions) (gain, pan float64) {
+	gain = 1
+	if ref := options.ReferenceDistance; ref > 0 {
+		if d := math.Hypot(dx, dy); d > ref {
+			gain = ref / d
+		}
+	}
+	if options.PanDistance > 0 {
+		pan = math.Max(-1, math.Min(dx/options.PanDistance, 1))
+	}
+	return gain, pan
+}
+
+// SetSpatialPosition sets the position of the sound source of this player in the same coordinates as
+// the listener's position.
+//
+// The volume is attenuated and the sound is panned based on the relative position to the listener and
+// the Context's SpatialOptions.
+// The actual volume is the multiplication of Volume and the attenuation, so Volume still returns the value
+// given to SetVolume.
+func (p *Player) SetSpatialPosition(x, y float64) {
+	p.p.setSpatialPosition(x, y)
+}
+
+// ClearSpatialPosition stops the spatial audio of this player, and resets the attenuation and the pan by the position.
+func (p *Player) ClearSpatialPosition() {
+	p.p.clearSpatialPosition()
+}
+
+// stereoGains returns the gains for the left and the right channels for pan.
+func stereoGains(pan float64) (left, right float64) {
+	if pan < 0 {
+		return 1, 1 + pan
+	}
+	return 1 - pan, 1
+}
+
+// applyStereoGains applies the gains to 16bit little endian stereo samples.
+func applyStereoGains(buf []byte, left, right float64) {
+	for i := 0; i+bytesPerSampleInt16 <= len(buf); i += bytesPerSampleInt16 {
+		l := int16(float64(int16(buf[i])|int16(buf[i+1])<<8) * left)
+		r := int16(float64(int16(buf[i+2])|int16(buf[i+3])<<8) * right)
+		buf[i] = byte(l)
+		buf[i+1] = byte(l >> 8)
+		buf[i+2] = byte(r)
+		buf[i+3] = byte(r >> 8)
+	}
+}
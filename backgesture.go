@@ -0,0 +1,69 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// BackGesturePhaseType represents a phase of a back gesture.
+type BackGesturePhaseType = ui.BackGesturePhase
+
+// BackGesturePhaseTypes
+const (
+	// BackGesturePhaseStarted indicates that the user started a back gesture.
+	BackGesturePhaseStarted BackGesturePhaseType = ui.BackGesturePhaseStarted
+
+	// BackGesturePhaseProgressed indicates that the user is moving the back gesture.
+	BackGesturePhaseProgressed BackGesturePhaseType = ui.BackGesturePhaseProgressed
+
+	// BackGesturePhaseCancelled indicates that the user cancelled the back gesture.
+	BackGesturePhaseCancelled BackGesturePhaseType = ui.BackGesturePhaseCancelled
+
+	// BackGesturePhaseInvoked indicates that the user completed the back gesture or pressed the back button.
+	BackGesturePhaseInvoked BackGesturePhaseType = ui.BackGesturePhaseInvoked
+)
+
+// BackGestureEvent represents an event of a back gesture.
+type BackGestureEvent struct {
+	// Phase is the phase of the back gesture.
+	Phase BackGesturePhaseType
+
+	// Progress is the progress of the back gesture in [0, 1].
+	// Progress is valid only when Phase is BackGesturePhaseProgressed.
+	Progress float64
+}
+
+// BackGestureHandler is an interface for a game to handle the back gesture, including the predictive back gesture
+// on Android.
+//
+// On Android, the back gesture is delivered only when the application opts in the predictive back gesture with
+// android:enableOnBackInvokedCallback="true" in AndroidManifest.xml, and the back gesture is available as of Android 13.
+// The progress of the gesture is available as of Android 14.
+//
+// On Android, whether the game implements BackGestureHandler is checked when the view is attached to the window.
+// The back gesture is not delivered to a game that replaces a game without BackGestureHandler after that.
+//
+// BackGestureHandler works only on Android so far.
+type BackGestureHandler interface {
+	// HandleBackGesture is called before Update when a back gesture event happens.
+	//
+	// A back gesture can be cancelled by the user. In this case, an event with BackGesturePhaseCancelled is
+	// delivered instead of BackGesturePhaseInvoked, and the game should revert a preview of the back navigation.
+	//
+	// The application is not closed by the back gesture while the game implements BackGestureHandler.
+	// It is the game's responsibility to navigate back when an event with BackGesturePhaseInvoked is delivered.
+	HandleBackGesture(event BackGestureEvent)
+}
@@ -16,8 +16,10 @@ package ebiten
 
 import (
 	"fmt"
+	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
 
 // Blend is a blending way of the source color and the destination color.
@@ -39,6 +41,13 @@ import (
 //
 // A blend operation is a binary operator of a source color and a destination color.
 // The default is adding.
+//
+// If LogicOperation is specified, a logic operation is used instead of the blend factors and the blend operations.
+//
+// BlendFactorConstantColor and BlendFactorOneMinusConstantColor use the blend color specified by BlendColor
+// of the options like DrawImageOptions. Draw calls with different blend colors are not batched,
+// so changing BlendColor for each call can increase the number of draw calls.
+// BlendColor is ignored and doesn't affect batching unless the blend uses the blend color.
 type Blend struct {
 	// BlendFactorSourceRGB is a factor for source RGB values.
 	BlendFactorSourceRGB BlendFactor
@@ -57,6 +66,14 @@ type Blend struct {
 
 	// BlendOperationAlpha is an operation for source and destination alpha values.
 	BlendOperationAlpha BlendOperation
+
+	// LogicOperation is a logic operation for source and destination color values.
+	// If LogicOperation is not LogicOperationNone, the blend factors and the blend operations are ignored.
+	//
+	// LogicOperation is available only with desktop OpenGL so far.
+	// If logic operations are not available, e.g. with DirectX, Metal or OpenGL ES, LogicOperation is ignored.
+	// See IsLogicOperationAvailable.
+	LogicOperation LogicOperation
 }
 
 var (
@@ -82,9 +99,71 @@ func (b Blend) internalBlend() graphicsdriver.Blend {
 		BlendFactorDestinationAlpha: b.BlendFactorDestinationAlpha.internalBlendFactor(false),
 		BlendOperationRGB:           b.BlendOperationRGB.internalBlendOperation(),
 		BlendOperationAlpha:         b.BlendOperationAlpha.internalBlendOperation(),
+		LogicOperation:              b.LogicOperation.internalLogicOperation(),
 	}
 }
 
+// LogicOperation is a logic operation for source and destination color values.
+//
+// A logic operation is applied to each bit of the RGBA values of the source and destination colors.
+// Note that the color values are premultiplied-alpha values.
+//
+// Logic operations are available only with desktop OpenGL so far, and not with DirectX, Metal or OpenGL ES.
+// See IsLogicOperationAvailable.
+type LogicOperation byte
+
+const (
+	// LogicOperationNone represents no logic operation. This is the default value.
+	// The blend factors and the blend operations are used.
+	LogicOperationNone LogicOperation = iota
+
+	// LogicOperationAnd represents a bitwise AND:
+	//
+	//     c_out = c_src & c_dst
+	LogicOperationAnd
+
+	// LogicOperationOr represents a bitwise OR:
+	//
+	//     c_out = c_src | c_dst
+	LogicOperationOr
+
+	// LogicOperationXor represents a bitwise XOR:
+	//
+	//     c_out = c_src ^ c_dst
+	LogicOperationXor
+
+	// LogicOperationInvert represents a bitwise NOT of the destination color:
+	//
+	//     c_out = ^c_dst
+	LogicOperationInvert
+)
+
+func (l LogicOperation) internalLogicOperation() graphicsdriver.LogicOperation {
+	switch l {
+	case LogicOperationNone:
+		return graphicsdriver.LogicOperationNone
+	case LogicOperationAnd:
+		return graphicsdriver.LogicOperationAnd
+	case LogicOperationOr:
+		return graphicsdriver.LogicOperationOr
+	case LogicOperationXor:
+		return graphicsdriver.LogicOperationXor
+	case LogicOperationInvert:
+		return graphicsdriver.LogicOperationInvert
+	default:
+		panic(fmt.Sprintf("ebiten: invalid logic operation: %d", l))
+	}
+}
+
+// IsLogicOperationAvailable reports whether Blend's LogicOperation is available in the current environment.
+//
+// Logic operations are available only with desktop OpenGL so far.
+//
+// IsLogicOperationAvailable returns false before the game starts.
+func IsLogicOperationAvailable() bool {
+	return ui.Get().IsLogicOperationAvailable()
+}
+
 // BlendFactor is a factor for source and destination color values.
 type BlendFactor byte
 
@@ -143,6 +222,20 @@ const (
 	//     1 - (destination alpha)
 	BlendFactorOneMinusDestinationAlpha
 
+	// BlendFactorConstantColor is a factor:
+	//
+	//     (blend color RGBA)
+	//
+	// The blend color is specified by BlendColor of the options like DrawImageOptions.
+	BlendFactorConstantColor
+
+	// BlendFactorOneMinusConstantColor is a factor:
+	//
+	//     1 - (blend color RGBA)
+	//
+	// The blend color is specified by BlendColor of the options like DrawImageOptions.
+	BlendFactorOneMinusConstantColor
+
 	// TODO: Add BlendFactorSourceAlphaSaturated. This might not work well on some platforms like Steam SDK (#2382).
 )
 
@@ -174,11 +267,44 @@ func (b BlendFactor) internalBlendFactor(source bool) graphicsdriver.BlendFactor
 		return graphicsdriver.BlendFactorDestinationAlpha
 	case BlendFactorOneMinusDestinationAlpha:
 		return graphicsdriver.BlendFactorOneMinusDestinationAlpha
+	case BlendFactorConstantColor:
+		return graphicsdriver.BlendFactorConstantColor
+	case BlendFactorOneMinusConstantColor:
+		return graphicsdriver.BlendFactorOneMinusConstantColor
 	default:
 		panic(fmt.Sprintf("ebiten: invalid blend factor: %d", b))
 	}
 }
 
+// blendColorToInternal returns the internal blend color for the blend.
+//
+// Draw commands with different blend colors are not merged. If the blend doesn't use the blend color,
+// blendColorToInternal returns a zero color regardless of clr so that the commands can still be merged.
+func blendColorToInternal(blend graphicsdriver.Blend, clr color.Color) [4]float32 {
+	if clr == nil || !usesBlendColor(blend) {
+		return [4]float32{}
+	}
+	r, g, b, a := clr.RGBA()
+	return [4]float32{float32(r) / 0xffff, float32(g) / 0xffff, float32(b) / 0xffff, float32(a) / 0xffff}
+}
+
+func usesBlendColor(blend graphicsdriver.Blend) bool {
+	if blend.LogicOperation != graphicsdriver.LogicOperationNone {
+		return false
+	}
+	for _, f := range [...]graphicsdriver.BlendFactor{
+		blend.BlendFactorSourceRGB,
+		blend.BlendFactorSourceAlpha,
+		blend.BlendFactorDestinationRGB,
+		blend.BlendFactorDestinationAlpha,
+	} {
+		if f == graphicsdriver.BlendFactorConstantColor || f == graphicsdriver.BlendFactorOneMinusConstantColor {
+			return true
+		}
+	}
+	return false
+}
+
 // BlendOperation is an operation for source and destination color values.
 type BlendOperation byte
 
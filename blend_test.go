@@ -0,0 +1,83 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestBlendColorToInternal(t *testing.T) {
+	constant := ebiten.Blend{
+		BlendFactorSourceRGB:        ebiten.BlendFactorConstantColor,
+		BlendFactorSourceAlpha:      ebiten.BlendFactorOne,
+		BlendFactorDestinationRGB:   ebiten.BlendFactorOneMinusSourceAlpha,
+		BlendFactorDestinationAlpha: ebiten.BlendFactorOneMinusSourceAlpha,
+		BlendOperationRGB:           ebiten.BlendOperationAdd,
+		BlendOperationAlpha:         ebiten.BlendOperationAdd,
+	}
+	oneMinusConstant := ebiten.BlendSourceOver
+	oneMinusConstant.BlendFactorDestinationAlpha = ebiten.BlendFactorOneMinusConstantColor
+	logic := constant
+	logic.LogicOperation = ebiten.LogicOperationXor
+
+	clr := color.RGBA{0xff, 0, 0xff, 0xff}
+	testCases := []struct {
+		Name  string
+		Blend ebiten.Blend
+		Color color.Color
+		Want  [4]float32
+	}{
+		{
+			Name:  "constant color",
+			Blend: constant,
+			Color: clr,
+			Want:  [4]float32{1, 0, 1, 1},
+		},
+		{
+			Name:  "one minus constant color",
+			Blend: oneMinusConstant,
+			Color: clr,
+			Want:  [4]float32{1, 0, 1, 1},
+		},
+		{
+			Name:  "nil",
+			Blend: constant,
+			Color: nil,
+		},
+		{
+			// The blend color is ignored so that draw calls can be batched.
+			Name:  "not used",
+			Blend: ebiten.BlendSourceOver,
+			Color: clr,
+		},
+		{
+			Name:  "logic operation",
+			Blend: logic,
+			Color: clr,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			got := ebiten.BlendColorToInternalForTesting(tc.Blend, tc.Color)
+			if got != tc.Want {
+				t.Errorf("got: %v, want: %v", got, tc.Want)
+			}
+		})
+	}
+}
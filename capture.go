@@ -0,0 +1,332 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"errors"
+	"image"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/png"
+)
+
+// VideoEncoder is an interface to encode frames captured by StartVideoCapture.
+type VideoEncoder interface {
+	// EncodeFrame encodes a captured frame.
+	// img is in premultiplied alpha, and is valid only during the call.
+	// t is the elapsed time from the start of the capture.
+	//
+	// If EncodeFrame returns an error, the following frames are not given to the encoder.
+	EncodeFrame(img *image.RGBA, t time.Duration) error
+
+	// Close is called when the capture is stopped and all the frames are encoded.
+	// The returned error is ignored.
+	Close() error
+}
+
+// CaptureScreenshot captures the screen drawn at the current frame's Draw, and encodes it as a PNG image to w.
+//
+// CaptureScreenshot returns immediately. The pixels are read from GPU at the next frame to avoid waiting for GPU,
+// and the encoding is done in another goroutine.
+// After the encoding, done is called with an error if any. done is called on another goroutine. done can be nil.
+//
+// The captured image is the screen image given to Draw, not the final screen.
+//
+// CaptureScreenshot is concurrent-safe.
+func CaptureScreenshot(w io.Writer, done func(err error)) {
+	theCapturer.addScreenshot(w, done)
+}
+
+// StartVideoCapture starts capturing the screen drawn at every frame's Draw, and gives the frames to encoder.
+//
+// The pixels are read from GPU one frame later to avoid waiting for GPU, and the encoding is done in another goroutine.
+// If encoder is too slow, some frames are dropped so that the game is not blocked.
+//
+// The captured images are the screen images given to Draw, not the final screen.
+//
+// StartVideoCapture returns an error if a video capture is already running.
+//
+// StartVideoCapture is concurrent-safe.
+func StartVideoCapture(encoder VideoEncoder) error {
+	return theCapturer.startVideo(encoder)
+}
+
+// StopVideoCapture stops the current video capture.
+// The remaining frames are given to the encoder, and then the encoder's Close is called in another goroutine.
+//
+// StopVideoCapture does nothing if there is no video capture.
+//
+// StopVideoCapture is concurrent-safe.
+func StopVideoCapture() {
+	theCapturer.stopVideo()
+}
+
+// IsVideoCapturing reports whether a video capture is running.
+//
+// IsVideoCapturing is concurrent-safe.
+func IsVideoCapturing() bool {
+	return theCapturer.isVideoCapturing()
+}
+
+type screenshotRequest struct {
+	writer io.Writer
+	done   func(err error)
+}
+
+type videoCapture struct {
+	encoder VideoEncoder
+	start   time.Time
+
+	// failed is accessed only from the encoding goroutine.
+	failed bool
+}
+
+// captureSlot is a GPU-side copy of a frame waiting for being read.
+type captureSlot struct {
+	image       *Image
+	valid       bool
+	screenshots []screenshotRequest
+	video       *videoCapture
+	videoTime   time.Duration
+}
+
+type captureJob struct {
+	img         *image.RGBA
+	screenshots []screenshotRequest
+	video       *videoCapture
+	videoTime   time.Duration
+	closeVideo  bool
+}
+
+// maxQueuedVideoFrames is the maximum number of video frames waiting for being encoded.
+// When the queue is full, a new video frame is dropped without being read from GPU.
+const maxQueuedVideoFrames = 4
+
+type capturer struct {
+	screenshots   []screenshotRequest
+	video         *videoCapture
+	stoppedVideos []*videoCapture
+
+	// slots are double-buffered so that the pixels are read one frame after drawing.
+	slots   [2]captureSlot
+	current int
+
+	m sync.Mutex
+
+	// queue is the jobs waiting for being encoded.
+	// queue never blocks the game: screenshots are always queued, and video frames are dropped when the queue is full.
+	queue             []captureJob
+	queuedVideoFrames int
+	queueCond         *sync.Cond
+	queueOnce         sync.Once
+	queueM            sync.Mutex
+}
+
+var theCapturer capturer
+
+func (c *capturer) addScreenshot(w io.Writer, done func(err error)) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.screenshots = append(c.screenshots, screenshotRequest{
+		writer: w,
+		done:   done,
+	})
+}
+
+func (c *capturer) startVideo(encoder VideoEncoder) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.video != nil {
+		return errors.New("ebiten: a video capture is already running")
+	}
+	c.video = &videoCapture{
+		encoder: encoder,
+		start:   time.Now(),
+	}
+	return nil
+}
+
+func (c *capturer) stopVideo() {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.video == nil {
+		return
+	}
+	c.stoppedVideos = append(c.stoppedVideos, c.video)
+	c.video = nil
+}
+
+func (c *capturer) isVideoCapturing() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.video != nil
+}
+
+// capture is called after Draw with the screen image.
+//
+// capture must be called from the game goroutine.
+func (c *capturer) capture(screen *Image, transparent bool) {
+	// Read the frame drawn at the previous frame. The GPU should already have finished drawing it.
+	// The slot is not touched by the other goroutines, so the pixels are read outside the lock.
+	prev, stoppedVideos := c.prepareCapture(screen)
+	if prev.image != nil {
+		c.enqueueJob(c.readSlot(&prev, transparent))
+	}
+
+	// The stopped videos never get new frames. Close them after the remaining frames.
+	for _, v := range stoppedVideos {
+		c.enqueueJob(captureJob{video: v, closeVideo: true})
+	}
+}
+
+// prepareCapture copies the screen to the current slot if needed, and returns the previous slot to be read.
+// If the previous slot doesn't have to be read, the returned slot's image is nil.
+func (c *capturer) prepareCapture(screen *Image) (captureSlot, []*videoCapture) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	var prev captureSlot
+	if s := &c.slots[1-c.current]; s.valid {
+		// Skip reading a video frame from GPU if the frame would be dropped anyway.
+		if len(s.screenshots) > 0 || c.canEnqueueVideoFrame() {
+			prev = *s
+			prev.screenshots = append([]screenshotRequest(nil), s.screenshots...)
+		}
+		s.valid = false
+		s.screenshots = s.screenshots[:0]
+		s.video = nil
+	}
+
+	stoppedVideos := c.stoppedVideos
+	c.stoppedVideos = nil
+
+	if len(c.screenshots) == 0 && c.video == nil {
+		return prev, stoppedVideos
+	}
+
+	s := &c.slots[c.current]
+	b := screen.Bounds()
+	if s.image == nil || s.image.Bounds().Size() != b.Size() {
+		if s.image != nil {
+			s.image.Deallocate()
+		}
+		s.image = NewImageWithOptions(image.Rect(0, 0, b.Dx(), b.Dy()), &NewImageOptions{
+			Unmanaged: true,
+		})
+	}
+	op := &DrawImageOptions{}
+	op.Blend = BlendCopy
+	op.GeoM.Translate(float64(-b.Min.X), float64(-b.Min.Y))
+	s.image.DrawImage(screen, op)
+
+	s.valid = true
+	s.screenshots = append(s.screenshots[:0], c.screenshots...)
+	c.screenshots = c.screenshots[:0]
+	s.video = c.video
+	if c.video != nil {
+		s.videoTime = time.Since(c.video.start)
+	}
+
+	c.current = 1 - c.current
+	return prev, stoppedVideos
+}
+
+func (c *capturer) readSlot(s *captureSlot, transparent bool) captureJob {
+	b := s.image.Bounds()
+	img := image.NewRGBA(b)
+	s.image.ReadPixels(img.Pix)
+	if !transparent {
+		// The pixels are in premultiplied alpha. Making them opaque is the same as drawing them on a black background.
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 0xff
+		}
+	}
+
+	return captureJob{
+		img:         img,
+		screenshots: s.screenshots,
+		video:       s.video,
+		videoTime:   s.videoTime,
+	}
+}
+
+func (c *capturer) canEnqueueVideoFrame() bool {
+	c.queueM.Lock()
+	defer c.queueM.Unlock()
+	return c.queuedVideoFrames < maxQueuedVideoFrames
+}
+
+// enqueueJob queues job without blocking.
+// Screenshots must not be dropped, but a video frame is dropped if the queue is full.
+func (c *capturer) enqueueJob(job captureJob) {
+	c.queueOnce.Do(func() {
+		c.queueCond = sync.NewCond(&c.queueM)
+		go c.loop()
+	})
+
+	c.queueM.Lock()
+	defer c.queueM.Unlock()
+
+	isVideoFrame := job.video != nil && !job.closeVideo
+	if isVideoFrame {
+		if len(job.screenshots) == 0 && c.queuedVideoFrames >= maxQueuedVideoFrames {
+			return
+		}
+		c.queuedVideoFrames++
+	}
+	c.queue = append(c.queue, job)
+	c.queueCond.Signal()
+}
+
+func (c *capturer) dequeueJob() captureJob {
+	c.queueM.Lock()
+	defer c.queueM.Unlock()
+
+	for len(c.queue) == 0 {
+		c.queueCond.Wait()
+	}
+	job := c.queue[0]
+	c.queue[0] = captureJob{}
+	c.queue = c.queue[1:]
+	if job.video != nil && !job.closeVideo {
+		c.queuedVideoFrames--
+	}
+	return job
+}
+
+func (c *capturer) loop() {
+	for {
+		job := c.dequeueJob()
+		if job.closeVideo {
+			_ = job.video.encoder.Close()
+			continue
+		}
+
+		for _, s := range job.screenshots {
+			err := png.Encode(s.writer, job.img)
+			if s.done != nil {
+				s.done(err)
+			}
+		}
+
+		if v := job.video; v != nil && !v.failed {
+			if err := v.encoder.EncodeFrame(job.img, job.videoTime); err != nil {
+				v.failed = true
+			}
+		}
+	}
+}
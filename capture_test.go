@@ -0,0 +1,125 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestCaptureScreenshot(t *testing.T) {
+	screen := ebiten.NewImage(16, 16)
+	screen.Fill(color.RGBA{R: 0x80, A: 0x80})
+
+	var buf bytes.Buffer
+	done := make(chan error, 1)
+	ebiten.CaptureScreenshot(&buf, func(err error) {
+		done <- err
+	})
+
+	// The pixels are read at the next capture.
+	ebiten.CaptureForTesting(screen, false)
+	ebiten.CaptureForTesting(screen, false)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := img.Bounds(), image.Rect(0, 0, 16, 16); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	got := color.RGBAModel.Convert(img.At(8, 8)).(color.RGBA)
+	want := color.RGBA{R: 0x80, A: 0xff}
+	if got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+type testVideoEncoder struct {
+	block  chan struct{}
+	frames int
+	closed chan struct{}
+	m      sync.Mutex
+}
+
+func (e *testVideoEncoder) EncodeFrame(img *image.RGBA, t time.Duration) error {
+	<-e.block
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.frames++
+	return nil
+}
+
+func (e *testVideoEncoder) Close() error {
+	close(e.closed)
+	return nil
+}
+
+func TestVideoCaptureDropsFrames(t *testing.T) {
+	screen := ebiten.NewImage(16, 16)
+
+	e := &testVideoEncoder{
+		block:  make(chan struct{}),
+		closed: make(chan struct{}),
+	}
+	if err := ebiten.StartVideoCapture(e); err != nil {
+		t.Fatal(err)
+	}
+	if err := ebiten.StartVideoCapture(e); err == nil {
+		t.Errorf("StartVideoCapture must return an error when a video capture is already running")
+	}
+
+	// The encoder blocks, but capturing must not block.
+	const n = 32
+	for i := 0; i < n; i++ {
+		ebiten.CaptureForTesting(screen, false)
+	}
+
+	ebiten.StopVideoCapture()
+	if ebiten.IsVideoCapturing() {
+		t.Errorf("IsVideoCapturing must return false after StopVideoCapture")
+	}
+	ebiten.CaptureForTesting(screen, false)
+	close(e.block)
+
+	select {
+	case <-e.closed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	e.m.Lock()
+	defer e.m.Unlock()
+	// One frame might be being encoded in addition to the queued frames.
+	if e.frames == 0 || e.frames > ebiten.MaxQueuedVideoFramesForTesting+1 {
+		t.Errorf("got: %d frames, want: 1 to %d frames", e.frames, ebiten.MaxQueuedVideoFramesForTesting+1)
+	}
+}
@@ -14,6 +14,7 @@
 
 package {{.JavaPkg}}.{{.PrefixLower}};
 
+import android.app.ActivityManager;
 import android.content.Context;
 import android.opengl.GLSurfaceView;
 import android.os.Handler;
@@ -88,13 +89,22 @@ class EbitenSurfaceView extends GLSurfaceView implements RenderRequester {
     }
 
     private void initialize() {
-        setEGLContextClientVersion(3);
+        setEGLContextClientVersion(glesVersion());
         setEGLConfigChooser(8, 8, 8, 8, 0, 0);
         setRenderer(new EbitenRenderer());
         setPreserveEGLContextOnPause(true);
         Ebitenmobileview.setRenderRequester(this);
     }
 
+    private int glesVersion() {
+        // Fall back to OpenGL ES 2.0 on old devices without OpenGL ES 3.0.
+        ActivityManager am = (ActivityManager)getContext().getSystemService(Context.ACTIVITY_SERVICE);
+        if (am != null && am.getDeviceConfigurationInfo().reqGlEsVersion < 0x30000) {
+            return 2;
+        }
+        return 3;
+    }
+
     private void onErrorOnGameUpdate(Exception e) {
         ((EbitenView)getParent()).onErrorOnGameUpdate(e);
     }
@@ -20,18 +20,27 @@ import java.util.Comparator;
 import java.util.List;
 
 import android.content.Context;
+import android.graphics.Insets;
+import android.graphics.Rect;
 import android.hardware.input.InputManager;
+import android.os.Build;
 import android.os.Handler;
 import android.os.Looper;
 import android.util.AttributeSet;
 import android.util.DisplayMetrics;
 import android.util.Log;
 import android.view.Display;
+import android.view.DisplayCutout;
 import android.view.KeyEvent;
 import android.view.InputDevice;
 import android.view.MotionEvent;
 import android.view.ViewGroup;
+import android.view.WindowInsets;
 import android.view.WindowManager;
+import android.window.BackEvent;
+import android.window.OnBackAnimationCallback;
+import android.window.OnBackInvokedCallback;
+import android.window.OnBackInvokedDispatcher;
 
 import {{.JavaPkg}}.ebitenmobileview.Ebitenmobileview;
 
@@ -132,6 +141,118 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
         Ebitenmobileview.layout(widthInDp, heightInDp);
     }
 
+    @Override
+    public WindowInsets onApplyWindowInsets(WindowInsets insets) {
+        int left, top, right, bottom;
+        if (Build.VERSION.SDK_INT >= Build.VERSION_CODES.R) {
+            Insets i = insets.getInsets(WindowInsets.Type.systemBars() | WindowInsets.Type.displayCutout() | WindowInsets.Type.mandatorySystemGestures());
+            left = i.left;
+            top = i.top;
+            right = i.right;
+            bottom = i.bottom;
+        } else {
+            left = insets.getSystemWindowInsetLeft();
+            top = insets.getSystemWindowInsetTop();
+            right = insets.getSystemWindowInsetRight();
+            bottom = insets.getSystemWindowInsetBottom();
+        }
+
+        Ebitenmobileview.resetDisplayCutouts();
+        if (Build.VERSION.SDK_INT >= Build.VERSION_CODES.P) {
+            DisplayCutout cutout = insets.getDisplayCutout();
+            if (cutout != null) {
+                left = Math.max(left, cutout.getSafeInsetLeft());
+                top = Math.max(top, cutout.getSafeInsetTop());
+                right = Math.max(right, cutout.getSafeInsetRight());
+                bottom = Math.max(bottom, cutout.getSafeInsetBottom());
+                for (Rect r : cutout.getBoundingRects()) {
+                    Ebitenmobileview.addDisplayCutout(pxToDp(r.left), pxToDp(r.top), pxToDp(r.right), pxToDp(r.bottom));
+                }
+            }
+        }
+        Ebitenmobileview.setSafeAreaInsets(pxToDp(left), pxToDp(top), pxToDp(right), pxToDp(bottom));
+
+        return super.onApplyWindowInsets(insets);
+    }
+
+    // The phases must be synced with mobile/ebitenmobileview/input_android.go.
+    private static final int BACK_GESTURE_PHASE_STARTED = 0;
+    private static final int BACK_GESTURE_PHASE_PROGRESSED = 1;
+    private static final int BACK_GESTURE_PHASE_CANCELLED = 2;
+    private static final int BACK_GESTURE_PHASE_INVOKED = 3;
+
+    @Override
+    protected void onAttachedToWindow() {
+        super.onAttachedToWindow();
+        // Register the callbacks only when the game handles the back gesture.
+        // Otherwise, the back gesture should close the application as usual.
+        if (!Ebitenmobileview.isBackGestureHandledOnAndroid()) {
+            return;
+        }
+        if (Build.VERSION.SDK_INT >= 34) {
+            registerOnBackAnimationCallback();
+        } else if (Build.VERSION.SDK_INT >= 33) {
+            registerOnBackInvokedCallback();
+        }
+    }
+
+    @Override
+    protected void onDetachedFromWindow() {
+        if (Build.VERSION.SDK_INT >= 33 && this.onBackInvokedCallback != null) {
+            unregisterOnBackInvokedCallback();
+        }
+        super.onDetachedFromWindow();
+    }
+
+    // registerOnBackAnimationCallback registers a callback for the predictive back gesture with its progress.
+    // This is available as of Android 14 (API level 34).
+    private void registerOnBackAnimationCallback() {
+        OnBackAnimationCallback callback = new OnBackAnimationCallback() {
+            @Override
+            public void onBackStarted(BackEvent backEvent) {
+                Ebitenmobileview.onBackGestureOnAndroid(BACK_GESTURE_PHASE_STARTED, backEvent.getProgress());
+            }
+
+            @Override
+            public void onBackProgressed(BackEvent backEvent) {
+                Ebitenmobileview.onBackGestureOnAndroid(BACK_GESTURE_PHASE_PROGRESSED, backEvent.getProgress());
+            }
+
+            @Override
+            public void onBackCancelled() {
+                Ebitenmobileview.onBackGestureOnAndroid(BACK_GESTURE_PHASE_CANCELLED, 0);
+            }
+
+            @Override
+            public void onBackInvoked() {
+                Ebitenmobileview.onBackGestureOnAndroid(BACK_GESTURE_PHASE_INVOKED, 1);
+            }
+        };
+        this.findOnBackInvokedDispatcher().registerOnBackInvokedCallback(OnBackInvokedDispatcher.PRIORITY_DEFAULT, callback);
+        this.onBackInvokedCallback = callback;
+    }
+
+    // registerOnBackInvokedCallback registers a callback for the back gesture without its progress.
+    // This is available as of Android 13 (API level 33).
+    private void registerOnBackInvokedCallback() {
+        OnBackInvokedCallback callback = new OnBackInvokedCallback() {
+            @Override
+            public void onBackInvoked() {
+                Ebitenmobileview.onBackGestureOnAndroid(BACK_GESTURE_PHASE_INVOKED, 1);
+            }
+        };
+        this.findOnBackInvokedDispatcher().registerOnBackInvokedCallback(OnBackInvokedDispatcher.PRIORITY_DEFAULT, callback);
+        this.onBackInvokedCallback = callback;
+    }
+
+    private void unregisterOnBackInvokedCallback() {
+        OnBackInvokedDispatcher dispatcher = this.findOnBackInvokedDispatcher();
+        if (dispatcher != null) {
+            dispatcher.unregisterOnBackInvokedCallback((OnBackInvokedCallback)this.onBackInvokedCallback);
+        }
+        this.onBackInvokedCallback = null;
+    }
+
     @Override
     public boolean onKeyDown(int keyCode, KeyEvent event) {
         Ebitenmobileview.onKeyDownOnAndroid(keyCode, event.getUnicodeChar(), event.getSource(), event.getDeviceId());
@@ -438,5 +559,8 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
 
     private EbitenSurfaceView ebitenSurfaceView;
     private InputManager inputManager;
+
+    // onBackInvokedCallback is an OnBackInvokedCallback, typed as Object so that this class can be loaded before Android 13.
+    private Object onBackInvokedCallback;
     private ArrayList<Gamepad> gamepads;
 }
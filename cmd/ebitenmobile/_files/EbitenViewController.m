@@ -20,7 +20,7 @@
 
 #import "Ebitenmobileview.objc.h"
 
-@interface {{.PrefixUpper}}EbitenViewController : UIViewController<EbitenmobileviewRenderRequester, EbitenmobileviewSetGameNotifier>
+@interface {{.PrefixUpper}}EbitenViewController : UIViewController<EbitenmobileviewRenderRequester, EbitenmobileviewFrameRateRangeRequester, EbitenmobileviewSetGameNotifier>
 @end
 
 @implementation {{.PrefixUpper}}EbitenViewController {
@@ -150,6 +150,7 @@
   displayLink_ = [CADisplayLink displayLinkWithTarget:self selector:@selector(drawFrame)];
   [displayLink_ addToRunLoop:[NSRunLoop currentRunLoop] forMode:NSDefaultRunLoopMode];
   EbitenmobileviewSetRenderRequester(self);
+  EbitenmobileviewSetFrameRateRangeRequester(self);
 
   // Run the loop. This will never return.
   [[NSRunLoop currentRunLoop] run];
@@ -188,6 +189,10 @@
 
   CGRect viewRect = [[self view] frame];
 
+  if (@available(iOS 11.0, *)) {
+    UIEdgeInsets insets = [[self view] safeAreaInsets];
+    EbitenmobileviewSetSafeAreaInsets(insets.left, insets.top, insets.right, insets.bottom);
+  }
   EbitenmobileviewLayout(viewRect.size.width, viewRect.size.height);
 }
 
@@ -383,6 +388,29 @@
   }
 }
 
+- (void)setPreferredFrameRateRange:(double)minimum maximum:(double)maximum {
+  @synchronized(self) {
+    if (@available(iOS 15.0, *)) {
+      if (minimum == 0 && maximum == 0) {
+        [displayLink_ setPreferredFrameRateRange:CAFrameRateRangeDefault];
+        return;
+      }
+      float max = maximum;
+      if (max == 0) {
+        max = [[UIScreen mainScreen] maximumFramesPerSecond];
+      }
+      float min = minimum;
+      if (min > max) {
+        min = max;
+      }
+      [displayLink_ setPreferredFrameRateRange:CAFrameRateRangeMake(min, max, max)];
+    } else {
+      // 0 means the native frame rate of the display.
+      [displayLink_ setPreferredFramesPerSecond:(NSInteger)maximum];
+    }
+  }
+}
+
 - (void)notifySetGame {
   dispatch_async(dispatch_get_main_queue(), ^{
       gameSet_ = true;
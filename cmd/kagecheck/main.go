@@ -0,0 +1,351 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// kagecheck validates Kage shaders in a project.
+//
+// Usage:
+//
+//	kagecheck [-v] [-glsl dir] [-hlsl dir] [-msl dir] [path ...]
+//
+// A path is a shader file or a directory. A path ending with /... means the directory and its subdirectories.
+// If no path is given, ./... is used.
+//
+// In a directory, kagecheck treats the following files as Kage shaders:
+//
+//   - Files with the extension .kage
+//   - Go files with the build constraint `//go:build ignore` and a `//kage:unit` directive or a `Fragment` function
+//
+// kagecheck reports compile errors and warnings with their positions.
+// With -v, kagecheck also prints the uniform variables and the source images that each shader uses.
+//
+// With -glsl, -hlsl, or -msl, kagecheck writes the generated shader programs into the given directory.
+// The output files keep the shaders' paths relative to the given directory path, e.g. effects/blur.kage in ./...
+// is written as effects/blur.frag with -glsl. A shader file given directly is written with its file name.
+// The generated HLSL and MSL are the inputs to precompile shaders with fxc and metal commands.
+// See also the shaderprecomp package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/scanner"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/shader"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir/glsl"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir/hlsl"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir/msl"
+)
+
+var (
+	flagVerbose = flag.Bool("v", false, "print the uniform variables and the source images of each shader")
+	flagGLSL    = flag.String("glsl", "", "directory to write generated GLSL files")
+	flagHLSL    = flag.String("hlsl", "", "directory to write generated HLSL files")
+	flagMSL     = flag.String("msl", "", "directory to write generated MSL files")
+)
+
+var (
+	reBuildIgnore = regexp.MustCompile(`(?m)^//go:build\s+ignore\s*$`)
+	reKageUnit    = regexp.MustCompile(`(?m)^\s*//kage:unit\s`)
+	reFragment    = regexp.MustCompile(`(?m)^func\s+Fragment\s*\(`)
+	reImageSrc    = regexp.MustCompile(`\bimageSrc(\d)`)
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: kagecheck [-v] [-glsl dir] [-hlsl dir] [-msl dir] [path ...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"./..."}
+	}
+
+	for _, dir := range []string{*flagGLSL, *flagHLSL, *flagMSL} {
+		if dir == "" {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+
+	files, err := findShaderFiles(paths)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	var failed bool
+	for _, file := range files {
+		if !check(file.path, file.name) {
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// shaderFile is a shader file to check.
+type shaderFile struct {
+	// path is the path to read the file.
+	path string
+
+	// name is the base name of the output files, i.e. the path relative to the given directory without the extension.
+	// name uses slashes as separators.
+	name string
+}
+
+func findShaderFiles(paths []string) ([]shaderFile, error) {
+	var files []shaderFile
+	for _, path := range paths {
+		recursive := false
+		if strings.HasSuffix(path, "/...") {
+			path = strings.TrimSuffix(path, "/...")
+			if path == "" {
+				path = "/"
+			}
+			recursive = true
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, newShaderFile(path, filepath.Base(path)))
+			continue
+		}
+
+		if err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if p == path {
+					return nil
+				}
+				if !recursive {
+					return filepath.SkipDir
+				}
+				// Skip directories ignored by the Go tool.
+				if name := d.Name(); strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			ok, err := isShaderFile(p)
+			if err != nil {
+				return err
+			}
+			if ok {
+				rel, err := filepath.Rel(path, p)
+				if err != nil {
+					return err
+				}
+				files = append(files, newShaderFile(p, rel))
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].path < files[j].path
+	})
+
+	// Different files must not be written to the same output files.
+	names := map[string]string{}
+	for _, f := range files {
+		if p, ok := names[f.name]; ok && p != f.path {
+			return nil, fmt.Errorf("kagecheck: %s and %s have the same output name %s", p, f.path, f.name)
+		}
+		names[f.name] = f.path
+	}
+	return files, nil
+}
+
+func newShaderFile(path, rel string) shaderFile {
+	return shaderFile{
+		path: path,
+		name: filepath.ToSlash(strings.TrimSuffix(rel, filepath.Ext(rel))),
+	}
+}
+
+func isShaderFile(path string) (bool, error) {
+	switch filepath.Ext(path) {
+	case ".kage":
+		return true, nil
+	case ".go":
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return false, err
+		}
+		if !reBuildIgnore.Match(src) {
+			return false, nil
+		}
+		return reKageUnit.Match(src) || reFragment.Match(src), nil
+	}
+	return false, nil
+}
+
+// check checks the shader file, and reports whether the shader is valid.
+// name is the base name of the output files.
+func check(path string, name string) bool {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+		return false
+	}
+
+	ir, err := graphics.CompileShader(src)
+	if err != nil {
+		reportError(path, err)
+		return false
+	}
+
+	if !reKageUnit.Match(src) {
+		fmt.Fprintf(os.Stderr, "%s: warning: //kage:unit is not specified and texels is used; //kage:unit pixels is recommended\n", path)
+	}
+
+	if *flagVerbose {
+		printInterface(path, src, ir)
+	}
+
+	if err := emit(name, ir); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+		return false
+	}
+	return true
+}
+
+func reportError(path string, err error) {
+	var msgs []string
+	switch err := err.(type) {
+	case *shader.ParseError:
+		msgs = strings.Split(err.Error(), "\n")
+	case scanner.ErrorList:
+		for _, e := range err {
+			msgs = append(msgs, e.Error())
+		}
+	default:
+		msgs = []string{err.Error()}
+	}
+	for _, msg := range msgs {
+		// The compiler's positions don't have a file name like "1:2: ...".
+		if len(msg) > 0 && msg[0] >= '0' && msg[0] <= '9' {
+			fmt.Fprintf(os.Stderr, "%s:%s\n", path, msg)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "%s: %s\n", path, msg)
+	}
+}
+
+func printInterface(path string, src []byte, ir *shaderir.Program) {
+	fmt.Printf("%s:\n", path)
+
+	unit := "texels"
+	if ir.Unit == shaderir.Pixels {
+		unit = "pixels"
+	}
+	fmt.Printf("\tunit: %s\n", unit)
+
+	for i, name := range ir.UniformNames {
+		// Uniform variables starting with __ are built-in ones.
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		fmt.Printf("\tuniform %s %s\n", name, ir.Uniforms[i].String())
+	}
+
+	// The compiler doesn't record which images are used, so find the built-in function names in the source.
+	used := map[string]struct{}{}
+	for _, m := range reImageSrc.FindAllSubmatch(stripComments(src), -1) {
+		used[string(m[1])] = struct{}{}
+	}
+	var images []string
+	for idx := range used {
+		images = append(images, idx)
+	}
+	sort.Strings(images)
+	for _, idx := range images {
+		fmt.Printf("\timage imageSrc%s\n", idx)
+	}
+}
+
+// stripComments removes line comments roughly to avoid detecting function names in comments.
+func stripComments(src []byte) []byte {
+	var buf bytes.Buffer
+	for _, line := range bytes.Split(src, []byte("\n")) {
+		if i := bytes.Index(line, []byte("//")); i >= 0 {
+			line = line[:i]
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+func emit(name string, ir *shaderir.Program) error {
+	return emitTo(*flagGLSL, *flagHLSL, *flagMSL, name, ir)
+}
+
+// emitTo writes the generated shader programs into the directories. An empty directory means no output.
+func emitTo(glslDir, hlslDir, mslDir string, name string, ir *shaderir.Program) error {
+	if glslDir != "" {
+		vs, fs := glsl.Compile(ir, glsl.GLSLVersionDefault)
+		if err := writeFile(glslDir, name+".vert", []byte(vs)); err != nil {
+			return err
+		}
+		if err := writeFile(glslDir, name+".frag", []byte(fs)); err != nil {
+			return err
+		}
+	}
+	if hlslDir != "" {
+		vs, ps := hlsl.Compile(ir)
+		if err := writeFile(hlslDir, name+"_vs.hlsl", []byte(vs)); err != nil {
+			return err
+		}
+		if err := writeFile(hlslDir, name+"_ps.hlsl", []byte(ps)); err != nil {
+			return err
+		}
+	}
+	if mslDir != "" {
+		if err := writeFile(mslDir, name+".metal", []byte(msl.Compile(ir))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile writes the data to the file in dir. name is a slash-separated path relative to dir.
+func writeFile(dir, name string, data []byte) error {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
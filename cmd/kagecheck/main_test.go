@@ -0,0 +1,180 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+)
+
+const testShader = `//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`
+
+const testGoShader = `//go:build ignore
+
+//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`
+
+const testGoFile = `package main
+
+func main() {
+}
+`
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFindShaderFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"a.kage":                testShader,
+		"main.go":               testGoFile,
+		"effects/blur.kage":     testShader,
+		"effects/glow.go":       testGoShader,
+		"effects/glow_test.go":  testGoFile,
+		"others/blur.kage":      testShader,
+		"testdata/ignored.kage": testShader,
+		"_ignored/ignored.kage": testShader,
+		".ignored/ignored.kage": testShader,
+	})
+
+	testCases := []struct {
+		Name  string
+		Paths []string
+		Want  []shaderFile
+	}{
+		{
+			Name:  "recursive",
+			Paths: []string{dir + "/..."},
+			Want: []shaderFile{
+				{path: filepath.Join(dir, "a.kage"), name: "a"},
+				{path: filepath.Join(dir, "effects", "blur.kage"), name: "effects/blur"},
+				{path: filepath.Join(dir, "effects", "glow.go"), name: "effects/glow"},
+				{path: filepath.Join(dir, "others", "blur.kage"), name: "others/blur"},
+			},
+		},
+		{
+			Name:  "not recursive",
+			Paths: []string{dir},
+			Want: []shaderFile{
+				{path: filepath.Join(dir, "a.kage"), name: "a"},
+			},
+		},
+		{
+			Name:  "subdirectory",
+			Paths: []string{filepath.Join(dir, "effects") + "/..."},
+			Want: []shaderFile{
+				{path: filepath.Join(dir, "effects", "blur.kage"), name: "blur"},
+				{path: filepath.Join(dir, "effects", "glow.go"), name: "glow"},
+			},
+		},
+		{
+			Name:  "file",
+			Paths: []string{filepath.Join(dir, "effects", "blur.kage")},
+			Want: []shaderFile{
+				{path: filepath.Join(dir, "effects", "blur.kage"), name: "blur"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			got, err := findShaderFiles(tc.Paths)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.Want) {
+				t.Errorf("got: %v, want: %v", got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestFindShaderFilesSameName(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"a/blur.kage": testShader,
+		"b/blur.kage": testShader,
+	})
+
+	if _, err := findShaderFiles([]string{filepath.Join(dir, "a") + "/...", filepath.Join(dir, "b") + "/..."}); err == nil {
+		t.Errorf("findShaderFiles must return an error for the same output names")
+	}
+}
+
+func TestEmit(t *testing.T) {
+	ir, err := graphics.CompileShader([]byte(testShader))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	glslDir := filepath.Join(dir, "glsl")
+	hlslDir := filepath.Join(dir, "hlsl")
+	mslDir := filepath.Join(dir, "msl")
+	for _, name := range []string{"effects/blur", "others/blur"} {
+		if err := emitTo(glslDir, hlslDir, mslDir, name, ir); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, path := range []string{
+		filepath.Join(glslDir, "effects", "blur.vert"),
+		filepath.Join(glslDir, "effects", "blur.frag"),
+		filepath.Join(glslDir, "others", "blur.vert"),
+		filepath.Join(glslDir, "others", "blur.frag"),
+		filepath.Join(hlslDir, "effects", "blur_vs.hlsl"),
+		filepath.Join(hlslDir, "effects", "blur_ps.hlsl"),
+		filepath.Join(hlslDir, "others", "blur_vs.hlsl"),
+		filepath.Join(hlslDir, "others", "blur_ps.hlsl"),
+		filepath.Join(mslDir, "effects", "blur.metal"),
+		filepath.Join(mslDir, "others", "blur.metal"),
+	} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if info.Size() == 0 {
+			t.Errorf("%s is empty", path)
+		}
+	}
+}
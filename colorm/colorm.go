@@ -111,6 +111,78 @@ func (c *ColorM) ChangeHSV(hueTheta float64, saturationScale float64, valueScale
 	c.impl = affine.ChangeHSV(c.affineColorM(), hueTheta, float32(saturationScale), float32(valueScale))
 }
 
+// ChangeHSL changes HSL (Hue-Saturation-Lightness) values.
+// hueTheta is a radian value to rotate hue.
+// saturationScale is a value to scale saturation.
+// lightnessDelta is a value in [-1, 1] to change lightness. 1 makes the color white, and -1 makes the color black.
+//
+// The hue and the saturation are changed in the same way as ChangeHSV.
+func (c *ColorM) ChangeHSL(hueTheta float64, saturationScale float64, lightnessDelta float64) {
+	c.ChangeHSV(hueTheta, saturationScale, 1)
+	switch {
+	case lightnessDelta > 0:
+		if lightnessDelta > 1 {
+			lightnessDelta = 1
+		}
+		c.Scale(1-lightnessDelta, 1-lightnessDelta, 1-lightnessDelta, 1)
+		c.Translate(lightnessDelta, lightnessDelta, lightnessDelta, 0)
+	case lightnessDelta < 0:
+		if lightnessDelta < -1 {
+			lightnessDelta = -1
+		}
+		c.Scale(1+lightnessDelta, 1+lightnessDelta, 1+lightnessDelta, 1)
+	}
+}
+
+// Grayscale converts colors to grayscale.
+// amount is a value in [0, 1] to blend the original color and the grayscale color.
+// 0 keeps the original color, and 1 makes the color grayscale completely.
+//
+// The luminance is calculated with the coefficients of ITU-R BT.601.
+func (c *ColorM) Grayscale(amount float64) {
+	c.concatBlended([3][3]float64{
+		{0.299, 0.587, 0.114},
+		{0.299, 0.587, 0.114},
+		{0.299, 0.587, 0.114},
+	}, amount)
+}
+
+// Sepia converts colors to sepia tones.
+// amount is a value in [0, 1] to blend the original color and the sepia color.
+// 0 keeps the original color, and 1 makes the color sepia completely.
+func (c *ColorM) Sepia(amount float64) {
+	c.concatBlended([3][3]float64{
+		{0.393, 0.769, 0.189},
+		{0.349, 0.686, 0.168},
+		{0.272, 0.534, 0.131},
+	}, amount)
+}
+
+// concatBlended concatenates the RGB matrix m blended with the identity matrix by amount.
+func (c *ColorM) concatBlended(m [3][3]float64, amount float64) {
+	if amount < 0 {
+		amount = 0
+	}
+	if amount > 1 {
+		amount = 1
+	}
+	if amount == 0 {
+		return
+	}
+
+	var other ColorM
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			var id float64
+			if i == j {
+				id = 1
+			}
+			other.SetElement(i, j, id+(m[i][j]-id)*amount)
+		}
+	}
+	c.Concat(other)
+}
+
 // Element returns a value of a matrix at (i, j).
 func (c *ColorM) Element(i, j int) float64 {
 	return float64(c.affineColorM().At(i, j))
@@ -244,3 +244,58 @@ func TestColorMConcat(t *testing.T) {
 		t.Errorf("got: %f, want: %f", got, want)
 	}
 }
+
+func TestColorMGrayscale(t *testing.T) {
+	m0 := colorm.ColorM{}
+	m0.ChangeHSV(0, 0, 1)
+	m1 := colorm.ColorM{}
+	m1.Grayscale(1)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 5; j++ {
+			got := m1.Element(i, j)
+			want := m0.Element(i, j)
+			if math.Abs(want-got) > 0.0001 {
+				t.Errorf("m.Element(%d, %d) = %f, want %f", i, j, got, want)
+			}
+		}
+	}
+
+	m2 := colorm.ColorM{}
+	m2.Grayscale(0.5)
+	if got, want := m2.Element(0, 0), (1+0.299)/2; math.Abs(want-got) > 0.0001 {
+		t.Errorf("m.Element(0, 0) = %f, want %f", got, want)
+	}
+}
+
+func TestColorMChangeHSL(t *testing.T) {
+	cases := []struct {
+		In        color.RGBA
+		Lightness float64
+		Out       color.RGBA
+	}{
+		{
+			In:        color.RGBA{R: 0x80, G: 0x40, B: 0x00, A: 0xff},
+			Lightness: 0,
+			Out:       color.RGBA{R: 0x80, G: 0x40, B: 0x00, A: 0xff},
+		},
+		{
+			In:        color.RGBA{R: 0x80, G: 0x40, B: 0x00, A: 0xff},
+			Lightness: 1,
+			Out:       color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		},
+		{
+			In:        color.RGBA{R: 0x80, G: 0x40, B: 0x00, A: 0xff},
+			Lightness: -1,
+			Out:       color.RGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+		},
+	}
+	for _, c := range cases {
+		m := colorm.ColorM{}
+		m.ChangeHSL(0, 1, c.Lightness)
+		r0, g0, b0, a0 := m.Apply(c.In).RGBA()
+		r1, g1, b1, a1 := c.Out.RGBA()
+		if absDiffU32(r0, r1) > 0x200 || absDiffU32(g0, g1) > 0x200 || absDiffU32(b0, b1) > 0x200 || absDiffU32(a0, a1) > 0x200 {
+			t.Errorf("m.Apply(%v) with lightness %f = %v, want %v", c.In, c.Lightness, m.Apply(c.In), c.Out)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colorm
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const lutShaderSrc = `//kage:unit pixels
+
+package main
+
+// LUTSize is the number of the entries in each axis of the LUT.
+var LUTSize float
+
+func lutAt(p vec2) vec3 {
+	// The LUT is the second image. See imageSrc1At's document for the positions.
+	return imageSrc1At(imageSrc0Origin() + p + 0.5).rgb
+}
+
+// slice returns the interpolated color in a slice of the LUT for blue b.
+func slice(rg vec2, b float) vec3 {
+	x0 := floor(rg)
+	x1 := min(x0+1, LUTSize-1)
+	f := rg - x0
+	o := vec2(b*LUTSize, 0)
+	c00 := lutAt(o + vec2(x0.x, x0.y))
+	c10 := lutAt(o + vec2(x1.x, x0.y))
+	c01 := lutAt(o + vec2(x0.x, x1.y))
+	c11 := lutAt(o + vec2(x1.x, x1.y))
+	return mix(mix(c00, c10, f.x), mix(c01, c11, f.x), f.y)
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	c := imageSrc0At(srcPos)
+	if c.a == 0 {
+		return vec4(0)
+	}
+	x := clamp(c.rgb/c.a, 0, 1) * (LUTSize - 1)
+	b0 := floor(x.b)
+	b1 := min(b0+1, LUTSize-1)
+	rgb := mix(slice(x.rg, b0), slice(x.rg, b1), x.b-b0)
+	return vec4(rgb*c.a, c.a) * color
+}
+`
+
+var (
+	lutShader     *ebiten.Shader
+	lutShaderOnce sync.Once
+)
+
+func getLUTShader() *ebiten.Shader {
+	lutShaderOnce.Do(func() {
+		s, err := ebiten.NewShader([]byte(lutShaderSrc))
+		if err != nil {
+			panic(fmt.Sprintf("colorm: NewShader for the LUT shader failed: %v", err))
+		}
+		lutShader = s
+	})
+	return lutShader
+}
+
+// DrawImageWithLUTOptions represents options for DrawImageWithLUT.
+type DrawImageWithLUTOptions struct {
+	// GeoM is a geometry matrix to draw.
+	// The default (zero) value is identity, which draws the image at (0, 0).
+	GeoM ebiten.GeoM
+
+	// ColorScale is a scale of color applied after the LUT.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ebiten.ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend ebiten.Blend
+}
+
+// DrawImageWithLUT draws src onto dst, converting the colors with the 3D lookup table (LUT) image lut.
+//
+// lut is a horizontal strip of N slices of N x N pixels, i.e. the size is (N * N, N), where N is the number of
+// the entries in each axis. In a slice, red increases from left to right, and green increases from top to bottom.
+// Blue increases from the left slice to the right slice. This is a common format of LUT images,
+// e.g. a 16 x 16 x 16 LUT is a 256 x 16 image. An identity LUT is useful to make a LUT with image editors.
+//
+// The colors are interpolated trilinearly between the LUT's entries.
+//
+// If lut's size is not (N * N, N) where N >= 2, DrawImageWithLUT panics.
+func DrawImageWithLUT(dst, src, lut *ebiten.Image, op *DrawImageWithLUTOptions) {
+	if op == nil {
+		op = &DrawImageWithLUTOptions{}
+	}
+
+	n := lut.Bounds().Dy()
+	if n < 2 || lut.Bounds().Dx() != n*n {
+		panic(fmt.Sprintf("colorm: the LUT image size must be (N * N, N) where N >= 2 but was %v", lut.Bounds().Size()))
+	}
+
+	b := src.Bounds()
+	cs := op.ColorScale
+	vs := make([]ebiten.Vertex, 4)
+	for i, p := range [...][2]int{{b.Min.X, b.Min.Y}, {b.Max.X, b.Min.Y}, {b.Min.X, b.Max.Y}, {b.Max.X, b.Max.Y}} {
+		x, y := op.GeoM.Apply(float64(p[0]-b.Min.X), float64(p[1]-b.Min.Y))
+		vs[i] = ebiten.Vertex{
+			DstX:   float32(x),
+			DstY:   float32(y),
+			SrcX:   float32(p[0]),
+			SrcY:   float32(p[1]),
+			ColorR: cs.R(),
+			ColorG: cs.G(),
+			ColorB: cs.B(),
+			ColorA: cs.A(),
+		}
+	}
+	is := []uint16{0, 1, 2, 1, 3, 2}
+
+	opShader := &ebiten.DrawTrianglesShaderOptions{}
+	opShader.Blend = op.Blend
+	opShader.Uniforms = map[string]any{
+		"LUTSize": float32(n),
+	}
+	opShader.Images[0] = src
+	opShader.Images[1] = lut
+	dst.DrawTrianglesShader(vs, is, getLUTShader(), opShader)
+}
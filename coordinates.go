@@ -0,0 +1,70 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// There are three coordinate systems:
+//
+//   - Screen (logical) coordinates: the coordinates of the screen image given to Draw. The size is determined by Layout.
+//   - Window coordinates: the coordinates of the window's client area in device-independent pixels.
+//     On browsers, this is the canvas. On mobiles, this is the view.
+//   - Device pixel coordinates: the window coordinates multiplied by the device scale factor.
+//
+// The conversion considers the scale and the offsets made by Layout, including the letterboxes.
+
+// ScreenToWindowPosition converts a position on the screen (logical coordinates) into a position
+// in the window's client area in device-independent pixels.
+//
+// ScreenToWindowPosition should be called in Update, Draw or Layout.
+// Before the first Layout call, the result is undefined.
+func ScreenToWindowPosition(x, y float64) (float64, float64) {
+	return ui.Get().LogicalPositionToClientPosition(x, y)
+}
+
+// WindowToScreenPosition converts a position in the window's client area in device-independent pixels
+// into a position on the screen (logical coordinates).
+//
+// WindowToScreenPosition is the inverse of ScreenToWindowPosition.
+// For example, WindowToScreenPosition converts a cursor position reported by the window system into
+// the same coordinates as CursorPosition.
+//
+// WindowToScreenPosition should be called in Update, Draw or Layout.
+// Before the first Layout call, WindowToScreenPosition returns NaN values.
+func WindowToScreenPosition(x, y float64) (float64, float64) {
+	return ui.Get().ClientPositionToLogicalPosition(x, y)
+}
+
+// ScreenToDevicePixelPosition converts a position on the screen (logical coordinates) into a position
+// in the window's client area in device pixels.
+//
+// ScreenToDevicePixelPosition should be called in Update, Draw or Layout.
+// Before the first Layout call, the result is undefined.
+func ScreenToDevicePixelPosition(x, y float64) (float64, float64) {
+	return ui.Get().LogicalPositionToDevicePixelPosition(x, y)
+}
+
+// DevicePixelToScreenPosition converts a position in the window's client area in device pixels
+// into a position on the screen (logical coordinates).
+//
+// DevicePixelToScreenPosition is the inverse of ScreenToDevicePixelPosition.
+//
+// DevicePixelToScreenPosition should be called in Update, Draw or Layout.
+// Before the first Layout call, DevicePixelToScreenPosition returns NaN values.
+func DevicePixelToScreenPosition(x, y float64) (float64, float64) {
+	return ui.Get().DevicePixelPositionToLogicalPosition(x, y)
+}
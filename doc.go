@@ -60,10 +60,27 @@
 // to take a screenshot. For example, if you run your game with
 // `EBITENGINE_SCREENSHOT_KEY=q`, you can take a game screen's screenshot
 // by pressing Q key. This works only on desktops and browsers.
+// A screenshot is saved as a PNG file with a JSON file describing its metadata like internal images.
 //
 // `EBITENGINE_INTERNAL_IMAGES_KEY` environment variable specifies the key
 // to dump all the internal images. This is valid only when the build tag
 // 'ebitenginedebug' is specified. This works only on desktops and browsers.
+// Each internal image is dumped as a PNG file with a JSON file describing its metadata like its size,
+// its internal size, its pixel format and its label (e.g. "atlas-source" for a texture atlas for rendering sources).
+// For a texture atlas page, the metadata also has the regions of the images on the page.
+//
+// `EBITENGINE_INTERNAL_IMAGES_FILTER` environment variable specifies a regular expression to filter the internal
+// images dumped by `EBITENGINE_INTERNAL_IMAGES_KEY`. Only the images whose names (e.g. "1_atlas-source") match the
+// regular expression are dumped.
+//
+// `EBITENGINE_FRAME_CAPTURE_KEY` environment variable specifies the key to capture the graphics commands
+// in the next frame. The commands are saved as a JSON file. See also CaptureNextFrame.
+// This works only on desktops.
+//
+// `EBITENGINE_IMAGE_LEAK_REPORT` environment variable enables reporting images that become unreachable
+// without Deallocate or Dispose. If this is not empty, such images are reported to the standard error with the stack
+// traces where the images were created. The images are still released by the garbage collector as usual.
+// This is useful to find images that should have been deallocated explicitly to reduce video memory usage.
 //
 // `EBITENGINE_GRAPHICS_LIBRARY` environment variable specifies the graphics library.
 // If the specified graphics library is not available, RunGame returns an error.
@@ -97,6 +114,8 @@
 // You can specify multiple values separated by a comma. The default value is empty (i.e. no parameters).
 //
 //	"es": Use OpenGL ES. Without this, OpenGL and OpenGL ES are automatically chosen.
+//	"debug": Enable the debug output of the driver (OpenGL 4.3, OpenGL ES 3.2, or KHR_debug).
+//	         The messages from the driver are printed to the standard error. This doesn't work with WebGL.
 //
 // # Build tags
 //
@@ -119,4 +138,7 @@
 // `nintendosdkprofile` enables a profiler for NintendoSDK.
 //
 // `playstation5` is for PlayStation 5.
+//
+// `ebitenginecustomplatform` is for a platform layer registered by the package exp/platform.
+// This is for porting Ebitengine to platforms that Ebitengine doesn't support directly, e.g. other consoles.
 package ebiten
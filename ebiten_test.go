@@ -15,6 +15,7 @@
 package ebiten_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -30,3 +31,24 @@ func TestScreenSizeInFullscreen(t *testing.T) {
 		t.Errorf("h must be positive but not: %d", h)
 	}
 }
+
+func TestCoordinatesConversion(t *testing.T) {
+	for _, p := range [][2]float64{{0, 0}, {1, 2}, {12.5, -3.25}} {
+		wx, wy := ebiten.ScreenToWindowPosition(p[0], p[1])
+		x, y := ebiten.WindowToScreenPosition(wx, wy)
+		if math.Abs(x-p[0]) > 1e-9 || math.Abs(y-p[1]) > 1e-9 {
+			t.Errorf("WindowToScreenPosition(ScreenToWindowPosition(%v, %v)): got: (%v, %v), want: (%v, %v)", p[0], p[1], x, y, p[0], p[1])
+		}
+
+		dx, dy := ebiten.ScreenToDevicePixelPosition(p[0], p[1])
+		x, y = ebiten.DevicePixelToScreenPosition(dx, dy)
+		if math.Abs(x-p[0]) > 1e-9 || math.Abs(y-p[1]) > 1e-9 {
+			t.Errorf("DevicePixelToScreenPosition(ScreenToDevicePixelPosition(%v, %v)): got: (%v, %v), want: (%v, %v)", p[0], p[1], x, y, p[0], p[1])
+		}
+
+		s := ebiten.Monitor().DeviceScaleFactor()
+		if math.Abs(wx*s-dx) > 1e-9 || math.Abs(wy*s-dy) > 1e-9 {
+			t.Errorf("device pixels and window positions mismatch: window: (%v, %v), device pixels: (%v, %v), scale: %v", wx, wy, dx, dy, s)
+		}
+	}
+}
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build microsoftgdk || nintendosdk || playstation5
+//go:build microsoftgdk || nintendosdk || playstation5 || ebitenginecustomplatform
 
 // This file is for some special environments.
 // You usually don't have to care about this file.
@@ -0,0 +1,429 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package animation provides functions to load and play animated images like animated GIF and animated WebP.
+// This package is experimental and the API might be changed in the future.
+//
+// This package is separated from ebitenutil, as it imports image/gif and golang.org/x/image/webp.
+// Note that importing this package registers the GIF and WebP decoders for image.Decode as a side effect.
+package animation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+
+	"golang.org/x/image/webp"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Animation represents frames of an animated image like an animated GIF.
+type Animation struct {
+	// Frames is the frames of the animation.
+	// Each frame is already composed with the previous frames, and has the same size as the animation.
+	Frames []*ebiten.Image
+
+	// Delays is the durations to show the frames.
+	// The length of Delays is the same as Frames.
+	Delays []time.Duration
+
+	// LoopCount is the number of times to play the animation.
+	// 0 means that the animation is played forever.
+	LoopCount int
+}
+
+// Duration returns the total duration of one loop of the animation.
+func (a *Animation) Duration() time.Duration {
+	var d time.Duration
+	for _, delay := range a.Delays {
+		d += delay
+	}
+	return d
+}
+
+// Decode loads an animated image from the io.Reader and returns Animation.
+//
+// Decode supports animated GIF and animated WebP.
+// A still image, including a still GIF or WebP image, is loaded as an animation with one frame.
+// Image decoders for other types of still images must be registered, e.g. by importing image/png, when using Decode.
+func Decode(reader io.Reader) (*Animation, error) {
+	f, err := decodeFrames(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Animation{
+		Delays:    f.delays,
+		LoopCount: f.loopCount,
+	}
+	for _, img := range f.images {
+		a.Frames = append(a.Frames, ebiten.NewImageFromImage(img))
+	}
+	return a, nil
+}
+
+// frames is a decoded animation on CPU.
+type frames struct {
+	images    []image.Image
+	delays    []time.Duration
+	loopCount int
+}
+
+func decodeFrames(reader io.Reader) (*frames, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(data, []byte("GIF8")):
+		return decodeGIF(data)
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return decodeWebP(data)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return &frames{
+		images:    []image.Image{img},
+		delays:    []time.Duration{0},
+		loopCount: 1,
+	}, nil
+}
+
+func cloneRGBA(img *image.RGBA) *image.RGBA {
+	c := image.NewRGBA(img.Rect)
+	copy(c.Pix, img.Pix)
+	return c
+}
+
+func decodeGIF(data []byte) (*frames, error) {
+	g, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() {
+		for _, img := range g.Image {
+			bounds = bounds.Union(img.Bounds())
+		}
+	}
+
+	f := &frames{}
+	// In GIF, 0 means forever and -1 means playing only once. Otherwise, the animation is repeated LoopCount times.
+	switch {
+	case g.LoopCount == 0:
+		f.loopCount = 0
+	case g.LoopCount < 0:
+		f.loopCount = 1
+	default:
+		f.loopCount = g.LoopCount + 1
+	}
+
+	canvas := image.NewRGBA(bounds)
+	var prev []byte
+	for i, img := range g.Image {
+		var disposal byte
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		if disposal == gif.DisposalPrevious {
+			prev = append(prev[:0], canvas.Pix...)
+		}
+
+		draw.Draw(canvas, img.Bounds(), img, img.Bounds().Min, draw.Over)
+		f.images = append(f.images, cloneRGBA(canvas))
+
+		var delay int
+		if i < len(g.Delay) {
+			delay = g.Delay[i]
+		}
+		// Many browsers treat a too short delay as 100[ms].
+		if delay <= 1 {
+			delay = 10
+		}
+		f.delays = append(f.delays, time.Duration(delay)*10*time.Millisecond)
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, img.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			copy(canvas.Pix, prev)
+		}
+	}
+
+	return f, nil
+}
+
+type webPChunk struct {
+	fourCC string
+	// raw is the whole chunk including the header and the padding.
+	raw     []byte
+	payload []byte
+}
+
+func readWebPChunks(data []byte) ([]webPChunk, error) {
+	var chunks []webPChunk
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return nil, errors.New("animation: invalid WebP chunk")
+		}
+		size := int(binary.LittleEndian.Uint32(data[4:8]))
+		if size > len(data)-8 {
+			return nil, errors.New("animation: invalid WebP chunk size")
+		}
+		n := 8 + size
+		// A chunk is padded to an even size.
+		if n%2 == 1 && n < len(data) {
+			n++
+		}
+		chunks = append(chunks, webPChunk{
+			fourCC:  string(data[0:4]),
+			raw:     data[:n],
+			payload: data[8 : 8+size],
+		})
+		data = data[n:]
+	}
+	return chunks, nil
+}
+
+func uint24(b []byte) int {
+	return int(b[0]) | int(b[1])<<8 | int(b[2])<<16
+}
+
+func putUint24(b []byte, v int) {
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+}
+
+func decodeWebP(data []byte) (*frames, error) {
+	riffSize := int(binary.LittleEndian.Uint32(data[4:8]))
+	if riffSize < 4 || riffSize > len(data)-8 {
+		return nil, errors.New("animation: invalid WebP file size")
+	}
+	chunks, err := readWebPChunks(data[12 : 8+riffSize])
+	if err != nil {
+		return nil, err
+	}
+
+	var bounds image.Rectangle
+	var animated bool
+	f := &frames{}
+	for _, c := range chunks {
+		switch c.fourCC {
+		case "VP8X":
+			if len(c.payload) < 10 {
+				return nil, errors.New("animation: invalid VP8X chunk")
+			}
+			bounds = image.Rect(0, 0, 1+uint24(c.payload[4:7]), 1+uint24(c.payload[7:10]))
+		case "ANIM":
+			if len(c.payload) < 6 {
+				return nil, errors.New("animation: invalid ANIM chunk")
+			}
+			animated = true
+			f.loopCount = int(binary.LittleEndian.Uint16(c.payload[4:6]))
+		}
+	}
+
+	if !animated {
+		img, err := webp.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return &frames{
+			images:    []image.Image{img},
+			delays:    []time.Duration{0},
+			loopCount: 1,
+		}, nil
+	}
+
+	// The background color in the ANIM chunk is just a hint. Use a transparent color as a background.
+	canvas := image.NewRGBA(bounds)
+	for _, c := range chunks {
+		if c.fourCC != "ANMF" {
+			continue
+		}
+		if len(c.payload) < 16 {
+			return nil, errors.New("animation: invalid ANMF chunk")
+		}
+		x := 2 * uint24(c.payload[0:3])
+		y := 2 * uint24(c.payload[3:6])
+		w := 1 + uint24(c.payload[6:9])
+		h := 1 + uint24(c.payload[9:12])
+		duration := uint24(c.payload[12:15])
+		flags := c.payload[15]
+
+		img, err := decodeWebPFrame(c.payload[16:], w, h)
+		if err != nil {
+			return nil, err
+		}
+
+		r := image.Rect(x, y, x+w, y+h)
+		op := draw.Over
+		if flags&0x02 != 0 {
+			op = draw.Src
+		}
+		draw.Draw(canvas, r, img, img.Bounds().Min, op)
+		f.images = append(f.images, cloneRGBA(canvas))
+		f.delays = append(f.delays, time.Duration(duration)*time.Millisecond)
+
+		if flags&0x01 != 0 {
+			draw.Draw(canvas, r, image.Transparent, image.Point{}, draw.Src)
+		}
+	}
+	if len(f.images) == 0 {
+		return nil, errors.New("animation: no frames in the animated WebP")
+	}
+
+	return f, nil
+}
+
+// decodeWebPFrame decodes the frame data in an ANMF chunk by wrapping it as a still WebP file.
+func decodeWebPFrame(data []byte, width, height int) (image.Image, error) {
+	chunks, err := readWebPChunks(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var alpha, bitstream []byte
+	for _, c := range chunks {
+		switch c.fourCC {
+		case "ALPH":
+			alpha = c.raw
+		case "VP8 ", "VP8L":
+			bitstream = c.raw
+		}
+	}
+	if bitstream == nil {
+		return nil, errors.New("animation: no bitstream in the WebP frame")
+	}
+
+	var body []byte
+	body = append(body, "WEBP"...)
+	if alpha != nil {
+		// An alpha chunk requires an extended format header.
+		vp8x := make([]byte, 18)
+		copy(vp8x[0:4], "VP8X")
+		binary.LittleEndian.PutUint32(vp8x[4:8], 10)
+		// Set the alpha flag.
+		vp8x[8] = 0x10
+		putUint24(vp8x[12:15], width-1)
+		putUint24(vp8x[15:18], height-1)
+		body = append(body, vp8x...)
+		body = append(body, alpha...)
+	}
+	body = append(body, bitstream...)
+
+	file := make([]byte, 8, 8+len(body))
+	copy(file[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(file[4:8], uint32(len(body)))
+	file = append(file, body...)
+
+	return webp.Decode(bytes.NewReader(file))
+}
+
+// Player plays an Animation.
+type Player struct {
+	animation *Animation
+	index     int
+	elapsed   time.Duration
+	loop      int
+}
+
+// NewPlayer creates a new Player for the animation.
+//
+// NewPlayer panics if the animation has no frames.
+func NewPlayer(animation *Animation) *Player {
+	if len(animation.Frames) == 0 {
+		panic("animation: the animation must have at least one frame")
+	}
+	return &Player{
+		animation: animation,
+	}
+}
+
+// Update advances the animation by one tick.
+//
+// Update is intended to be called at every Update of a game.
+func (p *Player) Update() {
+	tps := ebiten.TPS()
+	if tps == ebiten.SyncWithFPS {
+		tps = int(ebiten.ActualFPS())
+	}
+	if tps <= 0 {
+		return
+	}
+	p.Advance(time.Second / time.Duration(tps))
+}
+
+// Advance advances the animation by d.
+func (p *Player) Advance(d time.Duration) {
+	if p.IsFinished() {
+		return
+	}
+	if p.animation.Duration() <= 0 {
+		return
+	}
+
+	p.elapsed += d
+	for p.elapsed >= p.animation.Delays[p.index] {
+		p.elapsed -= p.animation.Delays[p.index]
+		p.index++
+		if p.index < len(p.animation.Frames) {
+			continue
+		}
+
+		p.loop++
+		if p.IsFinished() {
+			// Keep showing the last frame.
+			p.index = len(p.animation.Frames) - 1
+			p.elapsed = 0
+			return
+		}
+		p.index = 0
+	}
+}
+
+// Image returns the current frame's image.
+func (p *Player) Image() *ebiten.Image {
+	return p.animation.Frames[p.index]
+}
+
+// FrameIndex returns the current frame's index.
+func (p *Player) FrameIndex() int {
+	return p.index
+}
+
+// IsFinished reports whether the animation has been played LoopCount times.
+// IsFinished always returns false if the animation's LoopCount is 0.
+func (p *Player) IsFinished() bool {
+	return p.animation.LoopCount > 0 && p.loop >= p.animation.LoopCount
+}
+
+// Rewind rewinds the animation to the first frame.
+func (p *Player) Rewind() {
+	p.index = 0
+	p.elapsed = 0
+	p.loop = 0
+}
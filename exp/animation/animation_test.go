@@ -0,0 +1,343 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/animation"
+)
+
+type bitWriter struct {
+	buf []byte
+	n   uint
+}
+
+func (w *bitWriter) write(v uint32, bits uint) {
+	for i := uint(0); i < bits; i++ {
+		if w.n%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		if (v>>i)&1 != 0 {
+			w.buf[len(w.buf)-1] |= 1 << (w.n % 8)
+		}
+		w.n++
+	}
+}
+
+// vp8lSolid returns a VP8L bitstream of an image filled with the color.
+func vp8lSolid(width, height int, clr color.NRGBA) []byte {
+	var w bitWriter
+	w.write(0x2f, 8)
+	w.write(uint32(width-1), 14)
+	w.write(uint32(height-1), 14)
+	// alpha_is_used and version.
+	w.write(1, 1)
+	w.write(0, 3)
+	// No transforms, no color cache, and no meta prefix codes.
+	w.write(0, 1)
+	w.write(0, 1)
+	w.write(0, 1)
+	// Simple prefix codes with one symbol for green, red, blue, and alpha.
+	// A code with one symbol doesn't consume any bits, so the pixels don't need any data.
+	for _, v := range []uint8{clr.G, clr.R, clr.B, clr.A} {
+		w.write(1, 1)
+		w.write(0, 1)
+		w.write(1, 1)
+		w.write(uint32(v), 8)
+	}
+	// The distance code.
+	w.write(1, 1)
+	w.write(0, 1)
+	w.write(0, 1)
+	w.write(0, 1)
+	return w.buf
+}
+
+func webPChunk(fourCC string, payload []byte) []byte {
+	b := make([]byte, 8, 8+len(payload)+1)
+	copy(b, fourCC)
+	binary.LittleEndian.PutUint32(b[4:], uint32(len(payload)))
+	b = append(b, payload...)
+	if len(payload)%2 == 1 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+func uint24(v int) []byte {
+	return []byte{byte(v), byte(v >> 8), byte(v >> 16)}
+}
+
+type webPFrame struct {
+	X, Y     int
+	Width    int
+	Height   int
+	Color    color.NRGBA
+	Duration int
+	Dispose  bool
+	NoBlend  bool
+}
+
+func animatedWebP(width, height int, loopCount int, frames []webPFrame) []byte {
+	var body []byte
+	body = append(body, "WEBP"...)
+
+	vp8x := []byte{0x02 | 0x10, 0, 0, 0}
+	vp8x = append(vp8x, uint24(width-1)...)
+	vp8x = append(vp8x, uint24(height-1)...)
+	body = append(body, webPChunk("VP8X", vp8x)...)
+
+	anim := make([]byte, 6)
+	binary.LittleEndian.PutUint16(anim[4:], uint16(loopCount))
+	body = append(body, webPChunk("ANIM", anim)...)
+
+	for _, f := range frames {
+		var anmf []byte
+		anmf = append(anmf, uint24(f.X/2)...)
+		anmf = append(anmf, uint24(f.Y/2)...)
+		anmf = append(anmf, uint24(f.Width-1)...)
+		anmf = append(anmf, uint24(f.Height-1)...)
+		anmf = append(anmf, uint24(f.Duration)...)
+		var flags byte
+		if f.NoBlend {
+			flags |= 0x02
+		}
+		if f.Dispose {
+			flags |= 0x01
+		}
+		anmf = append(anmf, flags)
+		anmf = append(anmf, webPChunk("VP8L", vp8lSolid(f.Width, f.Height, f.Color))...)
+		body = append(body, webPChunk("ANMF", anmf)...)
+	}
+
+	file := make([]byte, 8, 8+len(body))
+	copy(file, "RIFF")
+	binary.LittleEndian.PutUint32(file[4:], uint32(len(body)))
+	return append(file, body...)
+}
+
+func rgbaAt(img image.Image, x, y int) color.RGBA {
+	return color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+}
+
+func TestDecodeAnimatedWebP(t *testing.T) {
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	blue := color.NRGBA{B: 0xff, A: 0xff}
+	green := color.NRGBA{G: 0xff, A: 0xff}
+	data := animatedWebP(4, 2, 3, []webPFrame{
+		{
+			Width:    4,
+			Height:   2,
+			Color:    red,
+			Duration: 100,
+			NoBlend:  true,
+		},
+		{
+			Width:    1,
+			Height:   1,
+			Color:    blue,
+			Duration: 200,
+			Dispose:  true,
+		},
+		{
+			X:        2,
+			Width:    1,
+			Height:   1,
+			Color:    green,
+			Duration: 300,
+		},
+	})
+
+	images, delays, loopCount, err := animation.DecodeFramesForTesting(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(images), 3; got != want {
+		t.Fatalf("len(images): got: %d, want: %d", got, want)
+	}
+	if got, want := loopCount, 3; got != want {
+		t.Errorf("loop count: got: %d, want: %d", got, want)
+	}
+	for i, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond} {
+		if got := delays[i]; got != want {
+			t.Errorf("delays[%d]: got: %v, want: %v", i, got, want)
+		}
+	}
+	for i, img := range images {
+		if got, want := img.Bounds(), image.Rect(0, 0, 4, 2); got != want {
+			t.Errorf("images[%d].Bounds(): got: %v, want: %v", i, got, want)
+		}
+	}
+
+	testCases := []struct {
+		Frame int
+		X, Y  int
+		Want  color.RGBA
+	}{
+		{Frame: 0, X: 0, Y: 0, Want: color.RGBA{R: 0xff, A: 0xff}},
+		{Frame: 0, X: 3, Y: 1, Want: color.RGBA{R: 0xff, A: 0xff}},
+		{Frame: 1, X: 0, Y: 0, Want: color.RGBA{B: 0xff, A: 0xff}},
+		{Frame: 1, X: 1, Y: 0, Want: color.RGBA{R: 0xff, A: 0xff}},
+		// The second frame is disposed to the transparent color.
+		{Frame: 2, X: 0, Y: 0, Want: color.RGBA{}},
+		{Frame: 2, X: 1, Y: 0, Want: color.RGBA{R: 0xff, A: 0xff}},
+		{Frame: 2, X: 2, Y: 0, Want: color.RGBA{G: 0xff, A: 0xff}},
+	}
+	for _, tc := range testCases {
+		if got, want := rgbaAt(images[tc.Frame], tc.X, tc.Y), tc.Want; got != want {
+			t.Errorf("images[%d].At(%d, %d): got: %v, want: %v", tc.Frame, tc.X, tc.Y, got, want)
+		}
+	}
+}
+
+func TestDecodeInvalidWebP(t *testing.T) {
+	data := animatedWebP(2, 2, 0, []webPFrame{
+		{
+			Width:    2,
+			Height:   2,
+			Color:    color.NRGBA{A: 0xff},
+			Duration: 100,
+		},
+	})
+	// Break the size of the RIFF header.
+	binary.LittleEndian.PutUint32(data[4:], uint32(len(data)))
+	if _, _, _, err := animation.DecodeFramesForTesting(data); err == nil {
+		t.Errorf("DecodeFramesForTesting must return an error for an invalid size")
+	}
+
+	// An animation without frames.
+	data = animatedWebP(2, 2, 0, nil)
+	if _, _, _, err := animation.DecodeFramesForTesting(data); err == nil {
+		t.Errorf("DecodeFramesForTesting must return an error for an animation without frames")
+	}
+}
+
+func TestDecodeAnimatedGIF(t *testing.T) {
+	palette := color.Palette{color.Transparent, color.RGBA{R: 0xff, A: 0xff}, color.RGBA{B: 0xff, A: 0xff}}
+	img0 := image.NewPaletted(image.Rect(0, 0, 2, 2), palette)
+	for i := range img0.Pix {
+		img0.Pix[i] = 1
+	}
+	img1 := image.NewPaletted(image.Rect(1, 1, 2, 2), palette)
+	img1.Pix[0] = 2
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, &gif.GIF{
+		Image:     []*image.Paletted{img0, img1},
+		Delay:     []int{5, 0},
+		LoopCount: 2,
+		Config: image.Config{
+			ColorModel: palette,
+			Width:      2,
+			Height:     2,
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	images, delays, loopCount, err := animation.DecodeFramesForTesting(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(images), 2; got != want {
+		t.Fatalf("len(images): got: %d, want: %d", got, want)
+	}
+	// A GIF's loop count 2 means that the animation is played 3 times.
+	if got, want := loopCount, 3; got != want {
+		t.Errorf("loop count: got: %d, want: %d", got, want)
+	}
+	// A too short delay is treated as 100[ms].
+	for i, want := range []time.Duration{50 * time.Millisecond, 100 * time.Millisecond} {
+		if got := delays[i]; got != want {
+			t.Errorf("delays[%d]: got: %v, want: %v", i, got, want)
+		}
+	}
+	// The second frame is composed with the first frame.
+	if got, want := rgbaAt(images[1], 0, 0), (color.RGBA{R: 0xff, A: 0xff}); got != want {
+		t.Errorf("images[1].At(0, 0): got: %v, want: %v", got, want)
+	}
+	if got, want := rgbaAt(images[1], 1, 1), (color.RGBA{B: 0xff, A: 0xff}); got != want {
+		t.Errorf("images[1].At(1, 1): got: %v, want: %v", got, want)
+	}
+}
+
+func TestPlayerAdvance(t *testing.T) {
+	a := &animation.Animation{
+		// The images are not used in this test.
+		Frames:    make([]*ebiten.Image, 3),
+		Delays:    []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond},
+		LoopCount: 2,
+	}
+	p := animation.NewPlayer(a)
+
+	testCases := []struct {
+		Advance  time.Duration
+		Index    int
+		Finished bool
+	}{
+		{Advance: 50 * time.Millisecond, Index: 0},
+		{Advance: 50 * time.Millisecond, Index: 1},
+		{Advance: 250 * time.Millisecond, Index: 2},
+		// The animation loops.
+		{Advance: 300 * time.Millisecond, Index: 0},
+		// Skip multiple frames at once.
+		{Advance: 350 * time.Millisecond, Index: 2},
+		// The animation finishes at the last loop, and keeps showing the last frame.
+		{Advance: time.Second, Index: 2, Finished: true},
+		{Advance: time.Second, Index: 2, Finished: true},
+	}
+	for i, tc := range testCases {
+		p.Advance(tc.Advance)
+		if got, want := p.FrameIndex(), tc.Index; got != want {
+			t.Errorf("%d: FrameIndex(): got: %d, want: %d", i, got, want)
+		}
+		if got, want := p.IsFinished(), tc.Finished; got != want {
+			t.Errorf("%d: IsFinished(): got: %v, want: %v", i, got, want)
+		}
+	}
+
+	p.Rewind()
+	if got, want := p.FrameIndex(), 0; got != want {
+		t.Errorf("FrameIndex() after Rewind: got: %d, want: %d", got, want)
+	}
+	if p.IsFinished() {
+		t.Errorf("IsFinished() after Rewind: got: true, want: false")
+	}
+}
+
+func TestPlayerAdvanceForever(t *testing.T) {
+	a := &animation.Animation{
+		Frames: make([]*ebiten.Image, 2),
+		Delays: []time.Duration{100 * time.Millisecond, 100 * time.Millisecond},
+	}
+	p := animation.NewPlayer(a)
+	for i := 0; i < 100; i++ {
+		p.Advance(100 * time.Millisecond)
+		if p.IsFinished() {
+			t.Fatalf("IsFinished() for an endless animation: got: true, want: false")
+		}
+	}
+	if got, want := p.FrameIndex(), 0; got != want {
+		t.Errorf("FrameIndex(): got: %d, want: %d", got, want)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package camera provides a 2D camera that converts world coordinates into screen coordinates.
+// This package is experimental and the API might be changed in the future.
+package camera
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/vecmath"
+)
+
+// Camera represents a 2D camera that converts world coordinates into screen coordinates.
+//
+// A camera's position is the world position shown at the center of the viewport.
+// The typical usage is to call Follow and Update at every Update, and to draw world objects with
+// a GeoM concatenated with the camera's GeoM at Draw.
+//
+// The zero value is not usable. Use New to create a camera.
+type Camera struct {
+	position vecmath.Vec2
+	viewport vecmath.Vec2
+
+	zoom     float64
+	rotation float64
+
+	deadZone     vecmath.Vec2
+	smoothing    float64
+	pixelPerfect bool
+
+	shakeAmplitude float64
+	shakeTicks     int
+	shakeTick      int
+}
+
+// New creates a new camera with the given viewport size.
+// The viewport size is usually the screen size returned by Layout.
+func New(viewportSize vecmath.Vec2) *Camera {
+	return &Camera{
+		viewport: viewportSize,
+		zoom:     1,
+	}
+}
+
+// SetViewportSize sets the viewport size.
+func (c *Camera) SetViewportSize(size vecmath.Vec2) {
+	c.viewport = size
+}
+
+// ViewportSize returns the viewport size.
+func (c *Camera) ViewportSize() vecmath.Vec2 {
+	return c.viewport
+}
+
+// Position returns the world position shown at the center of the viewport.
+func (c *Camera) Position() vecmath.Vec2 {
+	return c.position
+}
+
+// SetPosition sets the world position shown at the center of the viewport immediately.
+func (c *Camera) SetPosition(position vecmath.Vec2) {
+	c.position = position
+}
+
+// Zoom returns the zoom factor. The default value is 1.
+func (c *Camera) Zoom() float64 {
+	return c.zoom
+}
+
+// SetZoom sets the zoom factor.
+//
+// SetZoom panics if zoom is not positive.
+func (c *Camera) SetZoom(zoom float64) {
+	if zoom <= 0 {
+		panic("camera: zoom must be positive")
+	}
+	c.zoom = zoom
+}
+
+// Rotation returns the rotation in radian.
+func (c *Camera) Rotation() float64 {
+	return c.rotation
+}
+
+// SetRotation sets the rotation in radian.
+// The world is rotated counterclockwise on the screen, which is the opposite direction of the camera's rotation.
+func (c *Camera) SetRotation(rotation float64) {
+	c.rotation = rotation
+}
+
+// SetDeadZone sets the size of the dead zone in world coordinates.
+// Follow doesn't move the camera while the target is in the dead zone around the camera's position.
+//
+// The default value is (0, 0), which means that the camera always follows the target.
+func (c *Camera) SetDeadZone(size vecmath.Vec2) {
+	c.deadZone = size
+}
+
+// SetSmoothing sets the smoothing factor of Follow in [0, 1).
+// With 0, the camera follows the target immediately.
+// With a larger value, the camera follows the target more slowly.
+//
+// SetSmoothing panics if smoothing is out of range.
+func (c *Camera) SetSmoothing(smoothing float64) {
+	if smoothing < 0 || smoothing >= 1 {
+		panic("camera: smoothing must be in [0, 1)")
+	}
+	c.smoothing = smoothing
+}
+
+// SetPixelPerfect sets whether the translation of the camera's GeoM is snapped to integer screen pixels.
+//
+// The pixel-perfect mode is useful for pixel art, where a fractional translation causes blurry or jittery sprites.
+// The snapping is done only when the rotation is 0.
+// An integer zoom factor is recommended with the pixel-perfect mode.
+func (c *Camera) SetPixelPerfect(pixelPerfect bool) {
+	c.pixelPerfect = pixelPerfect
+}
+
+// Follow moves the camera toward the target position in world coordinates, considering the dead zone and the smoothing.
+//
+// Follow is intended to be called once per tick.
+func (c *Camera) Follow(target vecmath.Vec2) {
+	d := vecmath.V(followDelta(target.X-c.position.X, c.deadZone.X/2), followDelta(target.Y-c.position.Y, c.deadZone.Y/2))
+	c.position = c.position.Add(d.Scale(1 - c.smoothing))
+}
+
+// followDelta returns the distance to move so that the target is on the edge of the dead zone.
+func followDelta(delta float64, halfDeadZone float64) float64 {
+	switch {
+	case delta > halfDeadZone:
+		return delta - halfDeadZone
+	case delta < -halfDeadZone:
+		return delta + halfDeadZone
+	}
+	return 0
+}
+
+// Shake starts shaking the camera with the given amplitude in screen pixels for the given ticks.
+// The amplitude decreases linearly over time.
+func (c *Camera) Shake(amplitude float64, ticks int) {
+	c.shakeAmplitude = amplitude
+	c.shakeTicks = ticks
+	c.shakeTick = 0
+}
+
+// IsShaking reports whether the camera is shaking.
+func (c *Camera) IsShaking() bool {
+	return c.shakeTick < c.shakeTicks
+}
+
+// Update advances the camera's state like shaking by one tick.
+//
+// Update is intended to be called once per tick.
+func (c *Camera) Update() {
+	if c.shakeTick < c.shakeTicks {
+		c.shakeTick++
+	}
+}
+
+func (c *Camera) shakeOffset() vecmath.Vec2 {
+	if !c.IsShaking() {
+		return vecmath.Vec2{}
+	}
+	a := c.shakeAmplitude * float64(c.shakeTicks-c.shakeTick) / float64(c.shakeTicks)
+	t := float64(c.shakeTick)
+	// Use incommensurate frequencies so that the motion doesn't look periodic.
+	return vecmath.V(a*math.Sin(t*1.7), a*math.Cos(t*2.3))
+}
+
+// GeoM returns a geometry matrix that converts world coordinates into screen coordinates.
+func (c *Camera) GeoM() ebiten.GeoM {
+	var g ebiten.GeoM
+	g.Translate(-c.position.X, -c.position.Y)
+	g.Rotate(-c.rotation)
+	g.Scale(c.zoom, c.zoom)
+
+	t := c.viewport.Scale(0.5).Add(c.shakeOffset())
+	g.Translate(t.X, t.Y)
+
+	if c.pixelPerfect && c.rotation == 0 {
+		// Snap the translation so that the world's integer positions are always on the screen's integer positions.
+		g.SetElement(0, 2, math.Round(g.Element(0, 2)))
+		g.SetElement(1, 2, math.Round(g.Element(1, 2)))
+	}
+	return g
+}
+
+// WorldToScreen converts a world position into a screen position.
+func (c *Camera) WorldToScreen(position vecmath.Vec2) vecmath.Vec2 {
+	g := c.GeoM()
+	return position.Apply(&g)
+}
+
+// ScreenToWorld converts a screen position, like a cursor position, into a world position.
+func (c *Camera) ScreenToWorld(position vecmath.Vec2) vecmath.Vec2 {
+	g := c.GeoM()
+	g.Invert()
+	return position.Apply(&g)
+}
+
+// VisibleRect returns the rectangle in world coordinates that covers the viewport.
+// This is useful to cull objects out of the screen.
+//
+// If the camera is rotated or shaking, the rectangle is the bounding box of the viewport and might be larger
+// than the actually visible region.
+func (c *Camera) VisibleRect() vecmath.Rect {
+	g := c.GeoM()
+	g.Invert()
+	return vecmath.Rect{Max: c.viewport}.Apply(&g)
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package camera_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/camera"
+	"github.com/hajimehoshi/ebiten/v2/exp/vecmath"
+)
+
+func nearlyEqual(a, b vecmath.Vec2) bool {
+	const epsilon = 1e-9
+	return math.Abs(a.X-b.X) < epsilon && math.Abs(a.Y-b.Y) < epsilon
+}
+
+func TestWorldToScreen(t *testing.T) {
+	c := camera.New(vecmath.V(320, 240))
+	c.SetPosition(vecmath.V(100, 50))
+	c.SetZoom(2)
+
+	if got, want := c.WorldToScreen(vecmath.V(100, 50)), vecmath.V(160, 120); !nearlyEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := c.WorldToScreen(vecmath.V(110, 60)), vecmath.V(180, 140); !nearlyEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := c.ScreenToWorld(vecmath.V(180, 140)), vecmath.V(110, 60); !nearlyEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestVisibleRect(t *testing.T) {
+	c := camera.New(vecmath.V(320, 240))
+	c.SetPosition(vecmath.V(0.5, 0.25))
+	c.SetZoom(4)
+
+	// The rectangle must not be rounded to integers.
+	got := c.VisibleRect()
+	want := vecmath.R(-39.5, -29.75, 40.5, 30.25)
+	if !nearlyEqual(got.Min, want.Min) || !nearlyEqual(got.Max, want.Max) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	c.SetRotation(math.Pi / 2)
+	got = c.VisibleRect()
+	want = vecmath.R(-29.5, -39.75, 30.5, 40.25)
+	if !nearlyEqual(got.Min, want.Min) || !nearlyEqual(got.Max, want.Max) {
+		t.Errorf("rotated: got: %v, want: %v", got, want)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	c := camera.New(vecmath.V(320, 240))
+	c.SetDeadZone(vecmath.V(20, 10))
+
+	// The target is in the dead zone.
+	c.Follow(vecmath.V(10, -5))
+	if got, want := c.Position(), vecmath.V(0, 0); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// The target is out of the dead zone. The target must be on the edge of the dead zone.
+	c.Follow(vecmath.V(30, -20))
+	if got, want := c.Position(), vecmath.V(20, -15); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	c.SetDeadZone(vecmath.Vec2{})
+	c.SetSmoothing(0.5)
+	c.Follow(vecmath.V(40, -15))
+	if got, want := c.Position(), vecmath.V(30, -15); got != want {
+		t.Errorf("with smoothing: got: %v, want: %v", got, want)
+	}
+}
+
+func TestPixelPerfect(t *testing.T) {
+	c := camera.New(vecmath.V(320, 240))
+	c.SetPosition(vecmath.V(0.3, 0.6))
+	c.SetPixelPerfect(true)
+
+	g := c.GeoM()
+	if got, want := g.Element(0, 2), 160.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := g.Element(1, 2), 119.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestShake(t *testing.T) {
+	c := camera.New(vecmath.V(320, 240))
+	c.Shake(10, 3)
+	for i := 0; i < 3; i++ {
+		if !c.IsShaking() {
+			t.Fatalf("IsShaking at tick %d: got: false, want: true", i)
+		}
+		c.Update()
+	}
+	if c.IsShaking() {
+		t.Errorf("IsShaking after the ticks: got: true, want: false")
+	}
+	if got, want := c.WorldToScreen(vecmath.Vec2{}), vecmath.V(160, 120); !nearlyEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
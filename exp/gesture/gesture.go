@@ -0,0 +1,478 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gesture provides a recognizer of touch gestures like taps, long presses, pinches and pans.
+// This package is experimental and the API might be changed in the future.
+//
+// A Recognizer tracks the touches every tick, and produces gesture events from them:
+//
+//	var recognizer = gesture.NewRecognizer(nil)
+//
+//	func (g *Game) Update() error {
+//		recognizer.Update()
+//		for _, e := range recognizer.AppendEvents(nil) {
+//			switch e.Type {
+//			case gesture.EventTypeTap:
+//				// Handle a tap at (e.X, e.Y).
+//			case gesture.EventTypePinch:
+//				// Zoom the camera by e.Scale.
+//			}
+//		}
+//		return nil
+//	}
+package gesture
+
+import (
+	"math"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// EventType represents a type of gesture events.
+type EventType int
+
+const (
+	// EventTypeTap is a short press and release without moving.
+	EventTypeTap EventType = iota
+
+	// EventTypeDoubleTap is a second tap close to the previous tap in time and space.
+	// An EventTypeTap event is also produced for the second tap.
+	EventTypeDoubleTap
+
+	// EventTypeLongPress is a press held without moving.
+	// EventTypeLongPress is produced once when the press is held long enough, and no tap is produced for the press.
+	EventTypeLongPress
+
+	// EventTypePan is a movement of one touch.
+	EventTypePan
+
+	// EventTypePinch is a movement of two touches.
+	EventTypePinch
+)
+
+// Phase represents a phase of a continuous gesture, i.e. a pan or a pinch.
+type Phase int
+
+const (
+	// PhaseBegan is the first event of the gesture.
+	PhaseBegan Phase = iota
+
+	// PhaseChanged is an event while the gesture continues.
+	PhaseChanged
+
+	// PhaseEnded is the last event of the gesture.
+	PhaseEnded
+)
+
+// Event represents a gesture event.
+type Event struct {
+	// Type is the type of the gesture.
+	Type EventType
+
+	// Phase is the phase of the gesture.
+	// Phase is used only for EventTypePan and EventTypePinch. Phase is PhaseBegan for the other types.
+	Phase Phase
+
+	// X and Y are the position of the gesture.
+	// For EventTypePinch, X and Y are the center of the two touches.
+	X float64
+	Y float64
+
+	// DX and DY are the movement of the position since the previous event of the gesture.
+	// DX and DY are used only for EventTypePan and EventTypePinch.
+	DX float64
+	DY float64
+
+	// Scale is the ratio of the current distance between the two touches to the distance at the beginning.
+	// Scale is used only for EventTypePinch.
+	Scale float64
+
+	// Angle is the rotation of the two touches since the beginning in radians.
+	// Angle is used only for EventTypePinch.
+	Angle float64
+}
+
+// Options represents options for a Recognizer.
+//
+// The thresholds of time are in ticks, and the thresholds of distance are in the same unit as ebiten.TouchPosition.
+type Options struct {
+	// TapMaxTicks is the maximum duration of a tap.
+	//
+	// If TapMaxTicks is 0, 15 is used.
+	TapMaxTicks int
+
+	// DoubleTapMaxTicks is the maximum interval between the releases of two taps of a double tap.
+	//
+	// If DoubleTapMaxTicks is 0, 20 is used.
+	DoubleTapMaxTicks int
+
+	// LongPressTicks is the duration to recognize a long press.
+	//
+	// If LongPressTicks is 0, 30 is used.
+	LongPressTicks int
+
+	// MoveThreshold is the distance of a movement to recognize a pan.
+	// Until a touch moves by this distance, the touch can still be a tap or a long press.
+	//
+	// If MoveThreshold is 0, 10 is used.
+	MoveThreshold float64
+
+	// DoubleTapMaxDistance is the maximum distance between two taps of a double tap.
+	//
+	// If DoubleTapMaxDistance is 0, 30 is used.
+	DoubleTapMaxDistance float64
+}
+
+type touch struct {
+	startX float64
+	startY float64
+	x      float64
+	y      float64
+
+	startTick int64
+
+	moved       bool
+	longPressed bool
+
+	// multi reports whether another touch was present at the same time.
+	multi bool
+}
+
+// Recognizer recognizes gestures from touches.
+type Recognizer struct {
+	options Options
+
+	tick   int64
+	ids    []ebiten.TouchID
+	touchM map[ebiten.TouchID]*touch
+
+	// releasedIDs is a buffer to handle the released touches in the order of the IDs.
+	releasedIDs []ebiten.TouchID
+
+	hasLastTap  bool
+	lastTapTick int64
+	lastTapX    float64
+	lastTapY    float64
+
+	panning bool
+	panID   ebiten.TouchID
+	panX    float64
+	panY    float64
+
+	pinching   bool
+	pinchIDs   [2]ebiten.TouchID
+	pinchDist  float64
+	pinchAngle float64
+	pinchScale float64
+	pinchRot   float64
+	pinchX     float64
+	pinchY     float64
+
+	events []Event
+}
+
+// NewRecognizer creates a new Recognizer.
+//
+// If options is nil, the default options are used.
+func NewRecognizer(options *Options) *Recognizer {
+	var o Options
+	if options != nil {
+		o = *options
+	}
+	if o.TapMaxTicks == 0 {
+		o.TapMaxTicks = 15
+	}
+	if o.DoubleTapMaxTicks == 0 {
+		o.DoubleTapMaxTicks = 20
+	}
+	if o.LongPressTicks == 0 {
+		o.LongPressTicks = 30
+	}
+	if o.MoveThreshold == 0 {
+		o.MoveThreshold = 10
+	}
+	if o.DoubleTapMaxDistance == 0 {
+		o.DoubleTapMaxDistance = 30
+	}
+	return &Recognizer{
+		options: o,
+		touchM:  map[ebiten.TouchID]*touch{},
+	}
+}
+
+// Update updates the state of the recognizer with the current touches.
+//
+// Update must be called exactly once in every game's Update, not Draw.
+// The events produced by the previous Update are discarded.
+func (r *Recognizer) Update() {
+	r.ids = ebiten.AppendTouchIDs(r.ids[:0])
+	positions := make(map[ebiten.TouchID][2]float64, len(r.ids))
+	for _, id := range r.ids {
+		x, y := ebiten.TouchPosition(id)
+		positions[id] = [2]float64{float64(x), float64(y)}
+	}
+	r.update(positions)
+}
+
+func distance(x0, y0, x1, y1 float64) float64 {
+	return math.Hypot(x1-x0, y1-y0)
+}
+
+func (r *Recognizer) update(positions map[ebiten.TouchID][2]float64) {
+	r.events = r.events[:0]
+	r.tick++
+
+	// Handle the released touches.
+	// Iterate them in the order of the IDs so that the events are deterministic.
+	r.releasedIDs = r.releasedIDs[:0]
+	for id := range r.touchM {
+		if _, ok := positions[id]; ok {
+			continue
+		}
+		r.releasedIDs = append(r.releasedIDs, id)
+	}
+	sort.Slice(r.releasedIDs, func(i, j int) bool {
+		return r.releasedIDs[i] < r.releasedIDs[j]
+	})
+	for _, id := range r.releasedIDs {
+		t := r.touchM[id]
+		delete(r.touchM, id)
+
+		if r.pinching && (id == r.pinchIDs[0] || id == r.pinchIDs[1]) {
+			r.endPinch()
+		}
+		if r.panning && id == r.panID {
+			r.endPan()
+			continue
+		}
+		if t.moved || t.longPressed || t.multi {
+			continue
+		}
+		if r.tick-t.startTick > int64(r.options.TapMaxTicks) {
+			continue
+		}
+		r.tap(t.x, t.y)
+	}
+
+	// Handle the pressed and the moved touches.
+	for id, p := range positions {
+		t, ok := r.touchM[id]
+		if !ok {
+			t = &touch{
+				startX:    p[0],
+				startY:    p[1],
+				startTick: r.tick,
+			}
+			r.touchM[id] = t
+		}
+		t.x, t.y = p[0], p[1]
+		if !t.moved && distance(t.startX, t.startY, t.x, t.y) >= r.options.MoveThreshold {
+			t.moved = true
+		}
+	}
+
+	if len(r.touchM) >= 2 {
+		for _, t := range r.touchM {
+			t.multi = true
+		}
+	}
+
+	switch len(r.touchM) {
+	case 1:
+		for id, t := range r.touchM {
+			if t.multi {
+				break
+			}
+			if t.moved {
+				r.updatePan(id, t)
+				break
+			}
+			if !t.longPressed && r.tick-t.startTick >= int64(r.options.LongPressTicks) {
+				t.longPressed = true
+				r.events = append(r.events, Event{
+					Type: EventTypeLongPress,
+					X:    t.x,
+					Y:    t.y,
+				})
+			}
+		}
+	case 2:
+		if r.panning {
+			r.endPan()
+		}
+		r.updatePinch()
+	default:
+		if r.panning {
+			r.endPan()
+		}
+		if r.pinching {
+			r.endPinch()
+		}
+	}
+}
+
+func (r *Recognizer) tap(x, y float64) {
+	r.events = append(r.events, Event{
+		Type: EventTypeTap,
+		X:    x,
+		Y:    y,
+	})
+
+	if r.hasLastTap && r.tick-r.lastTapTick <= int64(r.options.DoubleTapMaxTicks) &&
+		distance(r.lastTapX, r.lastTapY, x, y) <= r.options.DoubleTapMaxDistance {
+		r.events = append(r.events, Event{
+			Type: EventTypeDoubleTap,
+			X:    x,
+			Y:    y,
+		})
+		// A third tap starts a new double tap.
+		r.hasLastTap = false
+		return
+	}
+
+	r.hasLastTap = true
+	r.lastTapTick = r.tick
+	r.lastTapX = x
+	r.lastTapY = y
+}
+
+func (r *Recognizer) updatePan(id ebiten.TouchID, t *touch) {
+	if !r.panning || r.panID != id {
+		r.panning = true
+		r.panID = id
+		r.events = append(r.events, Event{
+			Type:  EventTypePan,
+			Phase: PhaseBegan,
+			X:     t.x,
+			Y:     t.y,
+			DX:    t.x - t.startX,
+			DY:    t.y - t.startY,
+		})
+		r.panX, r.panY = t.x, t.y
+		return
+	}
+
+	if t.x == r.panX && t.y == r.panY {
+		return
+	}
+	r.events = append(r.events, Event{
+		Type:  EventTypePan,
+		Phase: PhaseChanged,
+		X:     t.x,
+		Y:     t.y,
+		DX:    t.x - r.panX,
+		DY:    t.y - r.panY,
+	})
+	r.panX, r.panY = t.x, t.y
+}
+
+func (r *Recognizer) endPan() {
+	r.panning = false
+	r.events = append(r.events, Event{
+		Type:  EventTypePan,
+		Phase: PhaseEnded,
+		X:     r.panX,
+		Y:     r.panY,
+	})
+}
+
+func (r *Recognizer) updatePinch() {
+	var ts [2]*touch
+	var ids [2]ebiten.TouchID
+	var i int
+	for id, t := range r.touchM {
+		ids[i] = id
+		ts[i] = t
+		i++
+	}
+	// Keep the order stable so that the angle doesn't flip.
+	if ids[0] > ids[1] {
+		ids[0], ids[1] = ids[1], ids[0]
+		ts[0], ts[1] = ts[1], ts[0]
+	}
+
+	x := (ts[0].x + ts[1].x) / 2
+	y := (ts[0].y + ts[1].y) / 2
+	d := distance(ts[0].x, ts[0].y, ts[1].x, ts[1].y)
+	a := math.Atan2(ts[1].y-ts[0].y, ts[1].x-ts[0].x)
+
+	if r.pinching && r.pinchIDs != ids {
+		r.endPinch()
+	}
+	if !r.pinching {
+		r.pinching = true
+		r.pinchIDs = ids
+		r.pinchDist = d
+		r.pinchAngle = a
+		r.pinchScale = 1
+		r.pinchRot = 0
+		r.pinchX, r.pinchY = x, y
+		r.events = append(r.events, Event{
+			Type:  EventTypePinch,
+			Phase: PhaseBegan,
+			X:     x,
+			Y:     y,
+			Scale: 1,
+		})
+		return
+	}
+
+	if x == r.pinchX && y == r.pinchY && d == r.pinchDist && a == r.pinchAngle {
+		return
+	}
+
+	scale := 1.0
+	if r.pinchDist > 0 {
+		scale = d / r.pinchDist
+	}
+	angle := a - r.pinchAngle
+	for angle > math.Pi {
+		angle -= 2 * math.Pi
+	}
+	for angle < -math.Pi {
+		angle += 2 * math.Pi
+	}
+	r.events = append(r.events, Event{
+		Type:  EventTypePinch,
+		Phase: PhaseChanged,
+		X:     x,
+		Y:     y,
+		DX:    x - r.pinchX,
+		DY:    y - r.pinchY,
+		Scale: scale,
+		Angle: angle,
+	})
+	r.pinchScale = scale
+	r.pinchRot = angle
+	r.pinchX, r.pinchY = x, y
+}
+
+func (r *Recognizer) endPinch() {
+	r.pinching = false
+	r.events = append(r.events, Event{
+		Type:  EventTypePinch,
+		Phase: PhaseEnded,
+		X:     r.pinchX,
+		Y:     r.pinchY,
+		Scale: r.pinchScale,
+		Angle: r.pinchRot,
+	})
+}
+
+// AppendEvents appends the gesture events produced by the last Update to events, and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+func (r *Recognizer) AppendEvents(events []Event) []Event {
+	return append(events, r.events...)
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gesture
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type touches map[ebiten.TouchID][2]float64
+
+func eventTypes(events []Event) []EventType {
+	var types []EventType
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func equalEventTypes(a, b []EventType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTapAndDoubleTap(t *testing.T) {
+	r := NewRecognizer(nil)
+
+	r.update(touches{1: {10, 10}})
+	r.update(touches{1: {11, 10}})
+	r.update(touches{})
+	if got, want := eventTypes(r.AppendEvents(nil)), []EventType{EventTypeTap}; !equalEventTypes(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	r.update(touches{2: {12, 10}})
+	r.update(touches{})
+	if got, want := eventTypes(r.AppendEvents(nil)), []EventType{EventTypeTap, EventTypeDoubleTap}; !equalEventTypes(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// The events are discarded at the next update.
+	r.update(touches{})
+	if got := r.AppendEvents(nil); len(got) != 0 {
+		t.Errorf("got: %v, want: empty", got)
+	}
+}
+
+func TestLongPress(t *testing.T) {
+	r := NewRecognizer(&Options{
+		LongPressTicks: 5,
+	})
+
+	var longPresses int
+	for i := 0; i < 10; i++ {
+		r.update(touches{1: {10, 10}})
+		for _, e := range r.AppendEvents(nil) {
+			if e.Type == EventTypeLongPress {
+				longPresses++
+			}
+		}
+	}
+	if got, want := longPresses, 1; got != want {
+		t.Errorf("long presses: got: %d, want: %d", got, want)
+	}
+
+	// A tap is not produced after a long press.
+	r.update(touches{})
+	if got := r.AppendEvents(nil); len(got) != 0 {
+		t.Errorf("got: %v, want: empty", got)
+	}
+}
+
+func TestPan(t *testing.T) {
+	r := NewRecognizer(nil)
+
+	r.update(touches{1: {0, 0}})
+	r.update(touches{1: {20, 0}})
+	events := r.AppendEvents(nil)
+	if len(events) != 1 || events[0].Type != EventTypePan || events[0].Phase != PhaseBegan {
+		t.Fatalf("got: %v, want: a pan began", events)
+	}
+
+	r.update(touches{1: {25, 5}})
+	events = r.AppendEvents(nil)
+	if len(events) != 1 || events[0].Phase != PhaseChanged || events[0].DX != 5 || events[0].DY != 5 {
+		t.Fatalf("got: %v, want: a pan changed by (5, 5)", events)
+	}
+
+	r.update(touches{})
+	events = r.AppendEvents(nil)
+	if len(events) != 1 || events[0].Type != EventTypePan || events[0].Phase != PhaseEnded {
+		t.Fatalf("got: %v, want: a pan ended", events)
+	}
+}
+
+func TestPinch(t *testing.T) {
+	r := NewRecognizer(nil)
+
+	r.update(touches{1: {0, 0}, 2: {10, 0}})
+	events := r.AppendEvents(nil)
+	if len(events) != 1 || events[0].Type != EventTypePinch || events[0].Phase != PhaseBegan {
+		t.Fatalf("got: %v, want: a pinch began", events)
+	}
+
+	r.update(touches{1: {0, 0}, 2: {0, 20}})
+	events = r.AppendEvents(nil)
+	if len(events) != 1 || events[0].Phase != PhaseChanged {
+		t.Fatalf("got: %v, want: a pinch changed", events)
+	}
+	if got, want := events[0].Scale, 2.0; got != want {
+		t.Errorf("Scale: got: %f, want: %f", got, want)
+	}
+	if got, want := events[0].Angle, math.Pi/2; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Angle: got: %f, want: %f", got, want)
+	}
+
+	// Releasing one touch ends the pinch, and neither a tap nor a pan is produced.
+	r.update(touches{1: {0, 0}})
+	r.update(touches{1: {50, 50}})
+	r.update(touches{})
+	events = r.AppendEvents(nil)
+	if len(events) != 0 {
+		t.Errorf("got: %v, want: empty", events)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inputviewer
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func (v *Viewer) AppendHistoryForTesting(name string) {
+	v.appendHistory(name)
+}
+
+func (v *Viewer) HistoryForTesting() []string {
+	return v.history
+}
+
+func MouseButtonToStringForTesting(b ebiten.MouseButton) (string, bool) {
+	s, ok := mouseButtonToString[b]
+	return s, ok
+}
+
+func StandardGamepadButtonToStringForTesting(b ebiten.StandardGamepadButton) (string, bool) {
+	s, ok := standardButtonToString[b]
+	return s, ok
+}
+
+func GamepadButtonToStringForTesting(b ebiten.GamepadButton) string {
+	return gamepadButtonToString(b)
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inputviewer provides a debug widget to render the current inputs on the screen.
+// This is useful for trailers, tutorials and streaming overlays.
+// This package is experimental and the API might be changed in the future.
+//
+// The inputs are rendered as text with the debug font, and the standard gamepad sticks are rendered as circles.
+package inputviewer
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	lineHeight  = 16
+	stickRadius = 12
+)
+
+// Options represents options for a Viewer.
+type Options struct {
+	// HistorySize is the number of the recently pressed inputs to render.
+	//
+	// If HistorySize is 0, the history is not rendered.
+	HistorySize int
+}
+
+// Viewer records the current inputs and renders them.
+type Viewer struct {
+	options Options
+
+	keys         []ebiten.Key
+	mouseButtons []ebiten.MouseButton
+	gamepads     []gamepadState
+	history      []string
+
+	gamepadIDsBuf []ebiten.GamepadID
+}
+
+type gamepadState struct {
+	id       ebiten.GamepadID
+	standard bool
+	buttons  []string
+	sticks   [2][2]float64
+}
+
+// New creates a new Viewer.
+func New(options *Options) *Viewer {
+	v := &Viewer{}
+	if options != nil {
+		v.options = *options
+	}
+	return v
+}
+
+// Update records the current inputs.
+//
+// Update must be called every tick, typically in Game's Update.
+func (v *Viewer) Update() {
+	v.keys = inpututil.AppendPressedKeys(v.keys[:0])
+	for _, k := range inpututil.AppendJustPressedKeys(nil) {
+		v.appendHistory(k.String())
+	}
+
+	v.mouseButtons = v.mouseButtons[:0]
+	for b := ebiten.MouseButton(0); b <= ebiten.MouseButtonMax; b++ {
+		if ebiten.IsMouseButtonPressed(b) {
+			v.mouseButtons = append(v.mouseButtons, b)
+		}
+		if inpututil.IsMouseButtonJustPressed(b) {
+			v.appendHistory(mouseButtonToString[b])
+		}
+	}
+
+	v.gamepadIDsBuf = ebiten.AppendGamepadIDs(v.gamepadIDsBuf[:0])
+	v.gamepads = v.gamepads[:0]
+	for _, id := range v.gamepadIDsBuf {
+		g := gamepadState{
+			id:       id,
+			standard: ebiten.IsStandardGamepadLayoutAvailable(id),
+		}
+		if g.standard {
+			for _, b := range inpututil.AppendPressedStandardGamepadButtons(id, nil) {
+				g.buttons = append(g.buttons, standardButtonToString[b])
+			}
+			for _, b := range inpututil.AppendJustPressedStandardGamepadButtons(id, nil) {
+				v.appendHistory(standardButtonToString[b])
+			}
+			g.sticks[0][0] = ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal)
+			g.sticks[0][1] = ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical)
+			g.sticks[1][0] = ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisRightStickHorizontal)
+			g.sticks[1][1] = ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisRightStickVertical)
+		} else {
+			for _, b := range inpututil.AppendPressedGamepadButtons(id, nil) {
+				g.buttons = append(g.buttons, gamepadButtonToString(b))
+			}
+			for _, b := range inpututil.AppendJustPressedGamepadButtons(id, nil) {
+				v.appendHistory(gamepadButtonToString(b))
+			}
+		}
+		v.gamepads = append(v.gamepads, g)
+	}
+}
+
+func (v *Viewer) appendHistory(name string) {
+	if v.options.HistorySize <= 0 {
+		return
+	}
+	v.history = append(v.history, name)
+	if n := len(v.history) - v.options.HistorySize; n > 0 {
+		v.history = append(v.history[:0], v.history[n:]...)
+	}
+}
+
+// Draw renders the inputs recorded by the last Update call at (x, y) on screen.
+func (v *Viewer) Draw(screen *ebiten.Image, x, y int) {
+	names := make([]string, 0, len(v.keys))
+	for _, k := range v.keys {
+		names = append(names, k.String())
+	}
+	ebitenutil.DebugPrintAt(screen, "Keys: "+strings.Join(names, ", "), x, y)
+	y += lineHeight
+
+	names = names[:0]
+	for _, b := range v.mouseButtons {
+		names = append(names, mouseButtonToString[b])
+	}
+	ebitenutil.DebugPrintAt(screen, "Mouse: "+strings.Join(names, ", "), x, y)
+	y += lineHeight
+
+	for _, g := range v.gamepads {
+		ebitenutil.DebugPrintAt(screen, ebiten.GamepadName(g.id)+": "+strings.Join(g.buttons, ", "), x, y)
+		y += lineHeight
+		if !g.standard {
+			continue
+		}
+		for i, s := range g.sticks {
+			cx := float32(x + stickRadius + 1 + i*(2*stickRadius+8))
+			cy := float32(y + stickRadius + 1)
+			vector.StrokeCircle(screen, cx, cy, stickRadius, 1, color.White, true)
+			vector.DrawFilledCircle(screen, cx+float32(s[0])*stickRadius, cy+float32(s[1])*stickRadius, 3, color.White, true)
+		}
+		y += 2*stickRadius + 4
+	}
+
+	if v.options.HistorySize > 0 {
+		ebitenutil.DebugPrintAt(screen, "History: "+strings.Join(v.history, " "), x, y)
+	}
+}
+
+var mouseButtonToString = map[ebiten.MouseButton]string{
+	ebiten.MouseButtonLeft:   "MouseLeft",
+	ebiten.MouseButtonRight:  "MouseRight",
+	ebiten.MouseButtonMiddle: "MouseMiddle",
+	ebiten.MouseButton3:      "Mouse3",
+	ebiten.MouseButton4:      "Mouse4",
+}
+
+var standardButtonToString = map[ebiten.StandardGamepadButton]string{
+	ebiten.StandardGamepadButtonRightBottom:      "RB",
+	ebiten.StandardGamepadButtonRightRight:       "RR",
+	ebiten.StandardGamepadButtonRightLeft:        "RL",
+	ebiten.StandardGamepadButtonRightTop:         "RT",
+	ebiten.StandardGamepadButtonFrontTopLeft:     "FTL",
+	ebiten.StandardGamepadButtonFrontTopRight:    "FTR",
+	ebiten.StandardGamepadButtonFrontBottomLeft:  "FBL",
+	ebiten.StandardGamepadButtonFrontBottomRight: "FBR",
+	ebiten.StandardGamepadButtonCenterLeft:       "CL",
+	ebiten.StandardGamepadButtonCenterRight:      "CR",
+	ebiten.StandardGamepadButtonLeftStick:        "LS",
+	ebiten.StandardGamepadButtonRightStick:       "RS",
+	ebiten.StandardGamepadButtonLeftBottom:       "LB",
+	ebiten.StandardGamepadButtonLeftRight:        "LR",
+	ebiten.StandardGamepadButtonLeftLeft:         "LL",
+	ebiten.StandardGamepadButtonLeftTop:          "LT",
+	ebiten.StandardGamepadButtonCenterCenter:     "CC",
+}
+
+func gamepadButtonToString(b ebiten.GamepadButton) string {
+	return "B" + strconv.Itoa(int(b))
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inputviewer_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/inputviewer"
+)
+
+func TestHistory(t *testing.T) {
+	v := inputviewer.New(&inputviewer.Options{HistorySize: 3})
+	for _, name := range []string{"A", "B", "C", "D", "E"} {
+		v.AppendHistoryForTesting(name)
+	}
+	// Only the most recent inputs are kept.
+	if got, want := v.HistoryForTesting(), []string{"C", "D", "E"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestHistoryDisabled(t *testing.T) {
+	for _, v := range []*inputviewer.Viewer{
+		inputviewer.New(nil),
+		inputviewer.New(&inputviewer.Options{}),
+		inputviewer.New(&inputviewer.Options{HistorySize: -1}),
+	} {
+		v.AppendHistoryForTesting("A")
+		if got := v.HistoryForTesting(); len(got) != 0 {
+			t.Errorf("got: %v, want: empty", got)
+		}
+	}
+}
+
+func TestButtonNames(t *testing.T) {
+	names := map[string]struct{}{}
+	for b := ebiten.MouseButton(0); b <= ebiten.MouseButtonMax; b++ {
+		s, ok := inputviewer.MouseButtonToStringForTesting(b)
+		if !ok || s == "" {
+			t.Errorf("the mouse button %d has no name", b)
+		}
+		names[s] = struct{}{}
+	}
+	for b := ebiten.StandardGamepadButton(0); b <= ebiten.StandardGamepadButtonMax; b++ {
+		s, ok := inputviewer.StandardGamepadButtonToStringForTesting(b)
+		if !ok || s == "" {
+			t.Errorf("the standard gamepad button %d has no name", b)
+		}
+		names[s] = struct{}{}
+	}
+	// The names must be unique so that the history is not ambiguous.
+	if got, want := len(names), int(ebiten.MouseButtonMax)+1+int(ebiten.StandardGamepadButtonMax)+1; got != want {
+		t.Errorf("the number of the unique names: got: %d, want: %d", got, want)
+	}
+
+	if got, want := inputviewer.GamepadButtonToStringForTesting(ebiten.GamepadButton12), "B12"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interpolation provides values that are interpolated between ticks for smooth rendering.
+// This package is experimental and the API might be changed in the future.
+//
+// When FPS is higher than TPS, e.g. TPS is 30 and the display's refresh rate is 60Hz, rendering the state of
+// the last tick as it is looks choppy.
+// A Value keeps the states at the last two ticks, and returns the interpolated state for the current frame
+// with ebiten.TickInterpolationFactor.
+//
+// A Value is double-buffered automatically: the previous state is updated with the current state just before every tick.
+// Set a new state in Update, and use Interpolated in Draw:
+//
+//	var pos = interpolation.NewVec2(vecmath.V(0, 0))
+//
+//	func (g *Game) Update() error {
+//		pos.Set(pos.Get().Add(vecmath.V(1, 0)))
+//		return nil
+//	}
+//
+//	func (g *Game) Draw(screen *ebiten.Image) {
+//		p := pos.Interpolated()
+//		op := &ebiten.DrawImageOptions{}
+//		op.GeoM.Translate(p.X, p.Y)
+//		screen.DrawImage(img, op)
+//	}
+package interpolation
+
+import (
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/vecmath"
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
+)
+
+type snapshotter interface {
+	snapshot()
+}
+
+var (
+	values   = map[snapshotter]struct{}{}
+	valuesM  sync.Mutex
+	hookOnce sync.Once
+)
+
+func register(v snapshotter) {
+	hookOnce.Do(func() {
+		hook.AppendHookOnBeforeUpdate(func() error {
+			valuesM.Lock()
+			defer valuesM.Unlock()
+			for v := range values {
+				v.snapshot()
+			}
+			return nil
+		})
+	})
+
+	valuesM.Lock()
+	defer valuesM.Unlock()
+	values[v] = struct{}{}
+}
+
+func unregister(v snapshotter) {
+	valuesM.Lock()
+	defer valuesM.Unlock()
+	delete(values, v)
+}
+
+// Value is a value interpolated between the last two ticks.
+//
+// Value's functions are not concurrent-safe.
+type Value[T any] struct {
+	prev T
+	curr T
+	lerp func(a, b T, t float64) T
+}
+
+// NewValue creates a new Value with an initial state and a linear interpolation function.
+// lerp returns the state between a and b at t in [0, 1].
+//
+// A Value is registered to be updated before every tick until Close is called.
+func NewValue[T any](initial T, lerp func(a, b T, t float64) T) *Value[T] {
+	v := &Value[T]{
+		prev: initial,
+		curr: initial,
+		lerp: lerp,
+	}
+	register(v)
+	return v
+}
+
+// NewFloat64 creates a new Value for float64.
+func NewFloat64(initial float64) *Value[float64] {
+	return NewValue(initial, func(a, b float64, t float64) float64 {
+		return a + (b-a)*t
+	})
+}
+
+// NewVec2 creates a new Value for vecmath.Vec2.
+func NewVec2(initial vecmath.Vec2) *Value[vecmath.Vec2] {
+	return NewValue(initial, func(a, b vecmath.Vec2, t float64) vecmath.Vec2 {
+		return a.Lerp(b, t)
+	})
+}
+
+// NewGeoM creates a new Value for ebiten.GeoM.
+// The interpolation is done by (*ebiten.GeoM).Interpolate.
+func NewGeoM(initial ebiten.GeoM) *Value[ebiten.GeoM] {
+	return NewValue(initial, func(a, b ebiten.GeoM, t float64) ebiten.GeoM {
+		a.Interpolate(b, t)
+		return a
+	})
+}
+
+func (v *Value[T]) snapshot() {
+	v.prev = v.curr
+}
+
+// Set sets the state at the current tick.
+//
+// Set should be called in Update.
+func (v *Value[T]) Set(value T) {
+	v.curr = value
+}
+
+// Get returns the state at the current tick.
+func (v *Value[T]) Get() T {
+	return v.curr
+}
+
+// Teleport sets the state without interpolation, e.g. when a character is moved to a different place instantly.
+func (v *Value[T]) Teleport(value T) {
+	v.prev = value
+	v.curr = value
+}
+
+// Interpolated returns the state interpolated between the last two ticks for the current frame.
+//
+// Interpolated should be called in Draw.
+func (v *Value[T]) Interpolated() T {
+	return v.interpolatedAt(ebiten.TickInterpolationFactor())
+}
+
+func (v *Value[T]) interpolatedAt(t float64) T {
+	return v.lerp(v.prev, v.curr, t)
+}
+
+// Close unregisters the Value. After Close is called, the Value is no longer double-buffered.
+func (v *Value[T]) Close() {
+	unregister(v)
+}
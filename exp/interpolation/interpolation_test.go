@@ -0,0 +1,108 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interpolation_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/interpolation"
+	"github.com/hajimehoshi/ebiten/v2/exp/vecmath"
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
+)
+
+// tick emulates the beginning of a tick.
+func tick(t *testing.T) {
+	if err := hook.RunBeforeUpdateHooks(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	v := interpolation.NewFloat64(10)
+	defer v.Close()
+
+	if got, want := v.InterpolatedAtForTesting(0.5), 10.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	tick(t)
+	v.Set(20)
+	if got, want := v.Get(), 20.0; got != want {
+		t.Errorf("Get(): got: %v, want: %v", got, want)
+	}
+	testCases := []struct {
+		T    float64
+		Want float64
+	}{
+		{T: 0, Want: 10},
+		{T: 0.25, Want: 12.5},
+		{T: 1, Want: 20},
+	}
+	for _, tc := range testCases {
+		if got := v.InterpolatedAtForTesting(tc.T); got != tc.Want {
+			t.Errorf("InterpolatedAt(%v): got: %v, want: %v", tc.T, got, tc.Want)
+		}
+	}
+
+	// At the next tick, the current state becomes the previous state.
+	tick(t)
+	if got, want := v.InterpolatedAtForTesting(0), 20.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	v.Set(40)
+	if got, want := v.InterpolatedAtForTesting(0.5), 30.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestTeleport(t *testing.T) {
+	v := interpolation.NewVec2(vecmath.V(0, 0))
+	defer v.Close()
+
+	tick(t)
+	v.Teleport(vecmath.V(100, 50))
+	if got, want := v.InterpolatedAtForTesting(0.5), vecmath.V(100, 50); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestGeoM(t *testing.T) {
+	v := interpolation.NewGeoM(ebiten.GeoM{})
+	defer v.Close()
+
+	tick(t)
+	var g ebiten.GeoM
+	g.Translate(10, 20)
+	v.Set(g)
+
+	got := v.InterpolatedAtForTesting(0.5)
+	if x, y := got.Apply(0, 0); x != 5 || y != 10 {
+		t.Errorf("got: (%v, %v), want: (5, 10)", x, y)
+	}
+}
+
+func TestClose(t *testing.T) {
+	v := interpolation.NewFloat64(0)
+	tick(t)
+	v.Set(10)
+	v.Close()
+
+	// A closed Value is no longer double-buffered.
+	tick(t)
+	if got, want := v.InterpolatedAtForTesting(0), 0.0; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/png"
+)
+
+// WriteSnapshotForTesting queues a snapshot of img with the given name as if the image was read at Update,
+// and waits for the write to finish.
+func (j *Journal) WriteSnapshotForTesting(name string, img *image.RGBA) error {
+	path := j.snapshotPath(name)
+	j.fileM.Lock()
+	gen := j.generations[path]
+	j.fileM.Unlock()
+	return j.writeSnapshot(&png.Encoder{}, writeJob{
+		path:       path,
+		img:        img,
+		generation: gen,
+	})
+}
+
+// WriteStaleSnapshotForTesting writes a snapshot as if the job was queued before the image was unregistered.
+func (j *Journal) WriteStaleSnapshotForTesting(name string, img *image.RGBA) error {
+	path := j.snapshotPath(name)
+	j.fileM.Lock()
+	gen := j.generations[path] - 1
+	j.fileM.Unlock()
+	return j.writeSnapshot(&png.Encoder{}, writeJob{
+		path:       path,
+		img:        img,
+		generation: gen,
+	})
+}
@@ -0,0 +1,368 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package journal provides an autosave journal that periodically snapshots images to disk,
+// and restores them after a crash.
+// This package is experimental and the API might be changed in the future.
+//
+// This is useful for paint tools and editors that keep users' work in images.
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/internal/png"
+)
+
+const (
+	// sessionFileName is the name of the file that exists while a journal is open.
+	// If this file exists when a journal is opened, the previous session was not closed cleanly.
+	sessionFileName = "session"
+
+	// snapshotExt is the extension of snapshot files.
+	// A snapshot is a PNG file, but a dedicated extension is used so that Close never removes other files in the directory.
+	snapshotExt = ".ebitenjournal"
+
+	tmpExt = ".tmp"
+)
+
+// Options represents options for Open.
+type Options struct {
+	// Interval is the interval between snapshots.
+	//
+	// The default (zero) value is 30 seconds.
+	Interval time.Duration
+}
+
+// Journal is an autosave journal of images.
+//
+// A Journal's methods must be called from the game's Update or Draw.
+type Journal struct {
+	dir      string
+	interval time.Duration
+
+	// recoverable reports whether the previous session was not closed cleanly.
+	recoverable bool
+
+	images map[string]*ebiten.Image
+
+	lastSnapshotTime time.Time
+
+	// pending is a set of GPU-side copies of the images waiting for being read at the next Update.
+	// The pixels are read one tick later so that the GPU has already finished copying.
+	pending map[string]*ebiten.Image
+
+	// copies are reused for GPU-side copies.
+	copies map[string]*ebiten.Image
+
+	jobs    chan writeJob
+	writing sync.WaitGroup
+
+	// generations is incremented for a snapshot path when the image is unregistered.
+	// A write job with an old generation is discarded.
+	generations map[string]int
+
+	// fileM is a mutex for generations and for replacing and removing snapshot files.
+	fileM sync.Mutex
+
+	err  error
+	errM sync.Mutex
+}
+
+type writeJob struct {
+	path       string
+	img        *image.RGBA
+	generation int
+}
+
+// Open opens a journal in the directory dir. If the directory doesn't exist, Open creates it.
+//
+// If options is nil, the default values are used.
+func Open(dir string, options *Options) (*Journal, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	interval := options.Interval
+	if interval == 0 {
+		interval = 30 * time.Second
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("journal: creating the directory failed: %w", err)
+	}
+
+	session := filepath.Join(dir, sessionFileName)
+	var recoverable bool
+	if _, err := os.Stat(session); err == nil {
+		recoverable = true
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("journal: checking the session file failed: %w", err)
+	}
+	if err := os.WriteFile(session, nil, 0644); err != nil {
+		return nil, fmt.Errorf("journal: creating the session file failed: %w", err)
+	}
+
+	j := &Journal{
+		dir:              dir,
+		interval:         interval,
+		recoverable:      recoverable,
+		images:           map[string]*ebiten.Image{},
+		pending:          map[string]*ebiten.Image{},
+		copies:           map[string]*ebiten.Image{},
+		generations:      map[string]int{},
+		lastSnapshotTime: time.Now(),
+		jobs:             make(chan writeJob, 4),
+	}
+	go j.loop()
+	return j, nil
+}
+
+// IsRecoverable reports whether the previous session was not closed cleanly, i.e. the application crashed.
+//
+// Even if IsRecoverable returns true, there might be no snapshot for an image.
+func (j *Journal) IsRecoverable() bool {
+	return j.recoverable
+}
+
+// Register registers an image to be snapshotted with the given name.
+// If an image is already registered with the same name, the image is replaced.
+func (j *Journal) Register(name string, img *ebiten.Image) {
+	j.images[name] = img
+}
+
+// Unregister unregisters the image with the given name, and removes its snapshot.
+//
+// Unregister doesn't wait for the pending writes. A pending write of the image is discarded.
+func (j *Journal) Unregister(name string) {
+	delete(j.images, name)
+	delete(j.pending, name)
+	if img, ok := j.copies[name]; ok {
+		img.Deallocate()
+		delete(j.copies, name)
+	}
+
+	path := j.snapshotPath(name)
+	j.fileM.Lock()
+	defer j.fileM.Unlock()
+	j.generations[path]++
+	_ = os.Remove(path)
+}
+
+// Restore restores the image with the given name from its snapshot, and writes the pixels to dst.
+//
+// Restore returns false if there is no snapshot with the name.
+// Restore returns an error if the snapshot is broken or the size doesn't match with dst.
+func (j *Journal) Restore(name string, dst *ebiten.Image) (bool, error) {
+	img, ok, err := j.Load(name)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, nil
+	}
+	if img.Bounds().Size() != dst.Bounds().Size() {
+		return false, fmt.Errorf("journal: the snapshot size %v doesn't match with the image size %v", img.Bounds().Size(), dst.Bounds().Size())
+	}
+	dst.WritePixels(img.Pix)
+	return true, nil
+}
+
+// Load loads the snapshot with the given name.
+// The pixels are in premultiplied alpha.
+//
+// Load returns false if there is no snapshot with the name.
+func (j *Journal) Load(name string) (*image.RGBA, bool, error) {
+	f, err := os.Open(j.snapshotPath(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("journal: opening the snapshot failed: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, false, fmt.Errorf("journal: decoding the snapshot failed: %w", err)
+	}
+
+	// A snapshot is decoded as *image.NRGBA. Convert it back to premultiplied alpha.
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba, true, nil
+}
+
+// Update takes snapshots of the registered images when the interval passes.
+// Update should be called every tick, e.g. at the game's Update.
+//
+// Update returns an error if writing a previous snapshot failed.
+func (j *Journal) Update() error {
+	j.readPending()
+
+	if time.Since(j.lastSnapshotTime) >= j.interval {
+		j.Snapshot()
+	}
+
+	j.errM.Lock()
+	defer j.errM.Unlock()
+	err := j.err
+	j.err = nil
+	return err
+}
+
+// Snapshot starts taking snapshots of all the registered images immediately.
+// The pixels are read at the next Update, and written to files asynchronously.
+func (j *Journal) Snapshot() {
+	j.lastSnapshotTime = time.Now()
+	for name, img := range j.images {
+		b := img.Bounds()
+		c, ok := j.copies[name]
+		if !ok || c.Bounds().Size() != b.Size() {
+			if ok {
+				c.Deallocate()
+			}
+			c = ebiten.NewImageWithOptions(image.Rect(0, 0, b.Dx(), b.Dy()), &ebiten.NewImageOptions{
+				Unmanaged: true,
+			})
+			j.copies[name] = c
+		}
+		op := &ebiten.DrawImageOptions{}
+		op.Blend = ebiten.BlendCopy
+		c.DrawImage(img, op)
+		j.pending[name] = c
+	}
+}
+
+func (j *Journal) readPending() {
+	for name, c := range j.pending {
+		delete(j.pending, name)
+
+		rgba := image.NewRGBA(c.Bounds())
+		c.ReadPixels(rgba.Pix)
+
+		path := j.snapshotPath(name)
+		j.fileM.Lock()
+		gen := j.generations[path]
+		j.fileM.Unlock()
+
+		job := writeJob{
+			path:       path,
+			img:        rgba,
+			generation: gen,
+		}
+		// If writing is slow, skip this snapshot so as not to block the game. The next snapshot will be written.
+		j.writing.Add(1)
+		select {
+		case j.jobs <- job:
+		default:
+			j.writing.Done()
+		}
+	}
+}
+
+func (j *Journal) loop() {
+	e := &png.Encoder{
+		CompressionLevel: png.BestSpeed,
+	}
+	for job := range j.jobs {
+		if err := j.writeSnapshot(e, job); err != nil {
+			j.errM.Lock()
+			j.err = err
+			j.errM.Unlock()
+		}
+		j.writing.Done()
+	}
+}
+
+func (j *Journal) writeSnapshot(e *png.Encoder, job writeJob) error {
+	// Write to a temporary file and rename it so that a crash while writing never breaks the previous snapshot.
+	tmp := job.path + tmpExt
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("journal: creating a snapshot failed: %w", err)
+	}
+	if err := e.Encode(f, job.img); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("journal: encoding a snapshot failed: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("journal: writing a snapshot failed: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("journal: closing a snapshot failed: %w", err)
+	}
+
+	j.fileM.Lock()
+	defer j.fileM.Unlock()
+
+	// The image was unregistered after this job was queued.
+	if j.generations[job.path] != job.generation {
+		_ = os.Remove(tmp)
+		return nil
+	}
+	if err := os.Rename(tmp, job.path); err != nil {
+		return fmt.Errorf("journal: renaming a snapshot failed: %w", err)
+	}
+	return nil
+}
+
+func (j *Journal) snapshotPath(name string) string {
+	return filepath.Join(j.dir, url.PathEscape(name)+snapshotExt)
+}
+
+// Close closes the journal when the application exits cleanly.
+// Close waits for the pending writes, and then removes all the snapshots and the session file.
+// Files other than the journal's own files in the directory are never removed.
+//
+// After Close, the journal must not be used.
+func (j *Journal) Close() error {
+	close(j.jobs)
+	j.writing.Wait()
+
+	for _, c := range j.copies {
+		c.Deallocate()
+	}
+
+	// Remove snapshots including ones from a previous session that are not registered in this session.
+	var files []string
+	for _, pattern := range []string{"*" + snapshotExt, "*" + snapshotExt + tmpExt} {
+		fs, err := filepath.Glob(filepath.Join(j.dir, pattern))
+		if err != nil {
+			return fmt.Errorf("journal: listing snapshots failed: %w", err)
+		}
+		files = append(files, fs...)
+	}
+	for _, f := range files {
+		if err := os.Remove(f); err != nil {
+			return fmt.Errorf("journal: removing a snapshot failed: %w", err)
+		}
+	}
+	if err := os.Remove(filepath.Join(j.dir, sessionFileName)); err != nil {
+		return fmt.Errorf("journal: removing the session file failed: %w", err)
+	}
+	return nil
+}
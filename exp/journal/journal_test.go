@@ -0,0 +1,170 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal_test
+
+import (
+	"bytes"
+	"image"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/journal"
+)
+
+func testImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 3))
+	for i := range img.Pix {
+		img.Pix[i] = byte(i)
+	}
+	// Keep the pixels valid in premultiplied alpha.
+	for i := 3; i < len(img.Pix); i += 4 {
+		img.Pix[i] = 0xff
+	}
+	return img
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	j, err := journal.Open(t.TempDir(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	img := testImage()
+	if err := j.WriteSnapshotForTesting("canvas/1", img); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok, err := j.Load("canvas/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("Load must find the snapshot")
+	}
+	if got.Bounds() != img.Bounds() {
+		t.Errorf("bounds: got: %v, want: %v", got.Bounds(), img.Bounds())
+	}
+	if !bytes.Equal(got.Pix, img.Pix) {
+		t.Errorf("pixels: got: %v, want: %v", got.Pix, img.Pix)
+	}
+
+	if _, ok, err := j.Load("unknown"); err != nil || ok {
+		t.Errorf("Load for an unknown name: got: (%v, %v), want: (false, nil)", ok, err)
+	}
+}
+
+func TestIsRecoverable(t *testing.T) {
+	dir := t.TempDir()
+
+	j, err := journal.Open(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if j.IsRecoverable() {
+		t.Errorf("IsRecoverable must be false for a new directory")
+	}
+
+	// Open again without closing, as if the application crashed.
+	j2, err := journal.Open(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !j2.IsRecoverable() {
+		t.Errorf("IsRecoverable must be true after a crash")
+	}
+	if err := j2.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	j3, err := journal.Open(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j3.Close()
+	if j3.IsRecoverable() {
+		t.Errorf("IsRecoverable must be false after Close")
+	}
+}
+
+func TestCloseRemovesOnlyJournalFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	// Files that don't belong to the journal.
+	others := []string{"user.png", "notes.txt"}
+	for _, name := range others {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("data"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	j, err := journal.Open(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.WriteSnapshotForTesting("canvas", testImage()); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range ents {
+		names = append(names, e.Name())
+	}
+	// os.ReadDir returns the entries sorted by name.
+	if want := []string{"notes.txt", "user.png"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("files after Close: got: %v, want: %v", names, want)
+	}
+}
+
+func TestUnregisterDiscardsPendingWrite(t *testing.T) {
+	j, err := journal.Open(t.TempDir(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	if err := j.WriteSnapshotForTesting("canvas", testImage()); err != nil {
+		t.Fatal(err)
+	}
+	j.Unregister("canvas")
+	if _, ok, err := j.Load("canvas"); err != nil || ok {
+		t.Errorf("Load after Unregister: got: (%v, %v), want: (false, nil)", ok, err)
+	}
+
+	// A write queued before Unregister must not bring the snapshot back.
+	if err := j.WriteStaleSnapshotForTesting("canvas", testImage()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := j.Load("canvas"); err != nil || ok {
+		t.Errorf("Load after a stale write: got: (%v, %v), want: (false, nil)", ok, err)
+	}
+
+	// A write after Unregister is written as usual.
+	if err := j.WriteSnapshotForTesting("canvas", testImage()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := j.Load("canvas"); err != nil || !ok {
+		t.Errorf("Load after a new write: got: (%v, %v), want: (true, nil)", ok, err)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides performance metrics of Ebitengine like FPS, TPS and draw calls
+// in the formats of expvar and Prometheus.
+// This package is experimental and the API might be changed in the future.
+//
+// A typical usage is to start a local endpoint while playtesting:
+//
+//	go func() {
+//		if err := metrics.ListenAndServe("localhost:6060"); err != nil {
+//			log.Print(err)
+//		}
+//	}()
+//
+// Then, the metrics are available at http://localhost:6060/metrics (Prometheus) and
+// http://localhost:6060/debug/vars (expvar).
+package metrics
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Metrics represents a snapshot of Ebitengine's performance metrics.
+type Metrics struct {
+	// FPS is the current actual FPS.
+	FPS float64 `json:"fps"`
+
+	// TPS is the current actual TPS.
+	TPS float64 `json:"tps"`
+
+	// DroppedFrames is the current number of dropped frames per second.
+	DroppedFrames float64 `json:"dropped_frames"`
+
+	// DrawCalls is the number of draw calls in the last frame.
+	DrawCalls int `json:"draw_calls"`
+
+	// TextureBytes is the estimated number of bytes of the textures allocated by Ebitengine.
+	TextureBytes int64 `json:"texture_bytes"`
+
+	// UploadedBytes is the number of bytes sent from CPU to GPU in the last frame.
+	UploadedBytes int64 `json:"uploaded_bytes"`
+
+	// ReadBackBytes is the number of bytes read back from GPU to CPU in the last frame.
+	ReadBackBytes int64 `json:"read_back_bytes"`
+
+	// FrameSeconds is the time taken to update and draw the last frame in seconds.
+	FrameSeconds float64 `json:"frame_seconds"`
+}
+
+// Read returns the current metrics.
+//
+// Read is concurrent-safe.
+func Read() Metrics {
+	var d ebiten.DebugInfo
+	ebiten.ReadDebugInfo(&d)
+	return Metrics{
+		FPS:           ebiten.ActualFPS(),
+		TPS:           ebiten.ActualTPS(),
+		DroppedFrames: ebiten.ActualDroppedFrames(),
+		DrawCalls:     d.DrawCalls,
+		TextureBytes:  d.TextureBytes,
+		UploadedBytes: d.UploadedBytes,
+		ReadBackBytes: d.ReadBackBytes,
+		FrameSeconds:  d.FrameDuration.Seconds(),
+	}
+}
+
+var publishOnce sync.Once
+
+// Publish publishes the metrics as an expvar variable named "ebitengine".
+//
+// Publish can be called multiple times. The variable is published only once.
+func Publish() {
+	publishOnce.Do(func() {
+		expvar.Publish("ebitengine", expvar.Func(func() any {
+			return Read()
+		}))
+	})
+}
+
+// Handler returns an HTTP handler that serves the metrics in the Prometheus text exposition format.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := writePrometheus(w, Read()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// ListenAndServe listens on the TCP network address addr and serves the metrics.
+// The Prometheus format is served at /metrics and the expvar format is served at /debug/vars.
+//
+// ListenAndServe blocks until an error happens. ListenAndServe always returns a non-nil error.
+func ListenAndServe(addr string) error {
+	Publish()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	mux.Handle("/debug/vars", expvar.Handler())
+	return http.ListenAndServe(addr, mux)
+}
+
+func writePrometheus(w io.Writer, m Metrics) error {
+	for _, e := range []struct {
+		name  string
+		help  string
+		value any
+	}{
+		{"ebitengine_fps", "The current actual FPS.", m.FPS},
+		{"ebitengine_tps", "The current actual TPS.", m.TPS},
+		{"ebitengine_dropped_frames", "The current number of dropped frames per second.", m.DroppedFrames},
+		{"ebitengine_draw_calls", "The number of draw calls in the last frame.", m.DrawCalls},
+		{"ebitengine_texture_bytes", "The estimated number of bytes of the allocated textures.", m.TextureBytes},
+		{"ebitengine_uploaded_bytes", "The number of bytes sent from CPU to GPU in the last frame.", m.UploadedBytes},
+		{"ebitengine_read_back_bytes", "The number of bytes read back from GPU to CPU in the last frame.", m.ReadBackBytes},
+		{"ebitengine_frame_seconds", "The time taken to update and draw the last frame.", m.FrameSeconds},
+	} {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %v\n", e.name, e.help, e.name, e.name, e.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/metrics"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	m := metrics.Metrics{
+		FPS:           59.5,
+		TPS:           60,
+		DroppedFrames: 1,
+		DrawCalls:     12,
+		TextureBytes:  1 << 20,
+		UploadedBytes: 4096,
+		ReadBackBytes: 0,
+		FrameSeconds:  0.0125,
+	}
+	var b strings.Builder
+	if err := metrics.WritePrometheusForTesting(&b, m); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+
+	for _, want := range []string{
+		"ebitengine_fps 59.5\n",
+		"ebitengine_tps 60\n",
+		"ebitengine_dropped_frames 1\n",
+		"ebitengine_draw_calls 12\n",
+		"ebitengine_texture_bytes 1048576\n",
+		"ebitengine_uploaded_bytes 4096\n",
+		"ebitengine_read_back_bytes 0\n",
+		"ebitengine_frame_seconds 0.0125\n",
+		"# TYPE ebitengine_fps gauge\n",
+		"# HELP ebitengine_draw_calls ",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("the output must contain %q: %q", want, got)
+		}
+	}
+
+	// Every metric has one HELP line, one TYPE line, and one sample line.
+	if got, want := strings.Count(got, "\n"), 3*8; got != want {
+		t.Errorf("the number of lines: got: %d, want: %d", got, want)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if got, want := rec.Code, http.StatusOK; got != want {
+		t.Errorf("status: got: %d, want: %d", got, want)
+	}
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type: got: %q, want: the Prometheus text format", got)
+	}
+	if !strings.Contains(rec.Body.String(), "ebitengine_fps ") {
+		t.Errorf("the body must contain ebitengine_fps: %q", rec.Body.String())
+	}
+}
+
+func TestPublish(t *testing.T) {
+	// Publish can be called multiple times.
+	metrics.Publish()
+	metrics.Publish()
+
+	v := expvar.Get("ebitengine")
+	if v == nil {
+		t.Fatal("the expvar variable is not published")
+	}
+	var m map[string]any
+	if err := json.Unmarshal([]byte(v.String()), &m); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"fps", "tps", "dropped_frames", "draw_calls", "texture_bytes", "uploaded_bytes", "read_back_bytes", "frame_seconds"} {
+		if _, ok := m[key]; !ok {
+			t.Errorf("the key %q is missing: %v", key, m)
+		}
+	}
+}
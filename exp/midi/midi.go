@@ -0,0 +1,389 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package midi provides MIDI input devices like MIDI keyboards as game controllers.
+// This package is experimental and the API might be changed in the future.
+//
+// This package is supported by macOS, Windows, Linux and Web browsers so far.
+// On Linux, ALSA raw MIDI devices (/dev/snd/midiC*D*) are used.
+// On browsers, Web MIDI API is used.
+package midi
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// MessageType represents a type of a MIDI message.
+type MessageType int
+
+const (
+	// MessageTypeOther represents a message that is not categorized in the other types, e.g. Aftertouch or System Exclusive.
+	MessageTypeOther MessageType = iota
+
+	// MessageTypeNoteOff represents a Note Off message.
+	// A Note On message with velocity 0 is also treated as a Note Off message.
+	MessageTypeNoteOff
+
+	// MessageTypeNoteOn represents a Note On message.
+	MessageTypeNoteOn
+
+	// MessageTypeControlChange represents a Control Change message.
+	MessageTypeControlChange
+
+	// MessageTypeProgramChange represents a Program Change message.
+	MessageTypeProgramChange
+
+	// MessageTypePitchBend represents a Pitch Bend Change message.
+	MessageTypePitchBend
+
+	// MessageTypeClock represents a Timing Clock message, which is sent 24 times per quarter note.
+	MessageTypeClock
+
+	// MessageTypeStart represents a Start message.
+	MessageTypeStart
+
+	// MessageTypeContinue represents a Continue message.
+	MessageTypeContinue
+
+	// MessageTypeStop represents a Stop message.
+	MessageTypeStop
+)
+
+// Message represents a MIDI message.
+type Message struct {
+	// Type is the type of the message.
+	Type MessageType
+
+	// Channel is the channel of a channel message in between 0 and 15.
+	Channel int
+
+	// Note is the note number of a Note On or Note Off message in between 0 and 127.
+	Note int
+
+	// Velocity is the velocity of a Note On or Note Off message in between 0 and 127.
+	Velocity int
+
+	// Controller is the controller number of a Control Change message in between 0 and 127.
+	Controller int
+
+	// Value is the value of the message.
+	// For a Control Change message or a Program Change message, Value is in between 0 and 127.
+	// For a Pitch Bend message, Value is in between -8192 and 8191.
+	Value int
+
+	// Bytes is the raw bytes of the message including the status byte.
+	Bytes []byte
+
+	// Time is the time when the message is received.
+	Time time.Time
+}
+
+// Input represents a MIDI input device.
+type Input struct {
+	id   string
+	name string
+
+	native   nativeInput
+	parser   parser
+	messages []Message
+
+	m sync.Mutex
+}
+
+type nativeInput interface {
+	// isConnected reports whether the device is still connected.
+	isConnected() bool
+
+	close() error
+}
+
+var (
+	theInputs  = map[string]*Input{}
+	theInputsM sync.Mutex
+)
+
+// AppendInputs appends the currently available MIDI input devices to inputs and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+//
+// The same *Input value is returned for the same device.
+//
+// On browsers, the user's permission is requested at the first call.
+// AppendInputs returns no devices until the permission is granted.
+//
+// AppendInputs is concurrent-safe.
+func AppendInputs(inputs []*Input) ([]*Input, error) {
+	infos, err := appendInputInfos(nil)
+	if err != nil {
+		return inputs, err
+	}
+
+	theInputsM.Lock()
+	defer theInputsM.Unlock()
+
+	for _, info := range infos {
+		i, ok := theInputs[info.id]
+		if !ok {
+			i = &Input{
+				id:   info.id,
+				name: info.name,
+			}
+			theInputs[info.id] = i
+		}
+		inputs = append(inputs, i)
+	}
+	return inputs, nil
+}
+
+// Name returns the name of the device.
+//
+// Name is concurrent-safe.
+func (i *Input) Name() string {
+	return i.name
+}
+
+// Open starts receiving messages from the device.
+// If the device is already opened, Open does nothing.
+//
+// Open is concurrent-safe.
+func (i *Input) Open() error {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if i.native != nil {
+		return nil
+	}
+	n, err := openInput(i.id, i.receive)
+	if err != nil {
+		return err
+	}
+	i.native = n
+	return nil
+}
+
+// Close stops receiving messages from the device.
+// If the device is not opened, Close does nothing.
+//
+// Close is concurrent-safe.
+func (i *Input) Close() error {
+	i.m.Lock()
+	n := i.native
+	i.native = nil
+	i.m.Unlock()
+
+	if n == nil {
+		return nil
+	}
+	// Closing a native input might wait for the callbacks, then don't hold the lock here.
+	return n.close()
+}
+
+// IsOpen reports whether the device is opened.
+// IsOpen returns false after the device is disconnected, and the device can be opened again by Open after
+// it is reconnected.
+//
+// IsOpen is concurrent-safe.
+func (i *Input) IsOpen() bool {
+	i.m.Lock()
+	n := i.native
+	if n == nil {
+		i.m.Unlock()
+		return false
+	}
+	if n.isConnected() {
+		i.m.Unlock()
+		return true
+	}
+	i.native = nil
+	i.m.Unlock()
+
+	// The device is already disconnected. Release the native resources and ignore errors.
+	// Closing a native input might wait for the callbacks, then don't hold the lock here.
+	_ = n.close()
+	return false
+}
+
+// AppendMessages appends the messages received since the last call to msgs and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+//
+// AppendMessages is concurrent-safe.
+func (i *Input) AppendMessages(msgs []Message) []Message {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	msgs = append(msgs, i.messages...)
+	i.messages = i.messages[:0]
+	return msgs
+}
+
+// maxMessageCount is the maximum number of the pending messages.
+// If the messages are not consumed, older messages are dropped.
+const maxMessageCount = 4096
+
+// receive is called from a native input with a part of the byte stream.
+func (i *Input) receive(data []byte) {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	now := time.Now()
+	i.messages = i.parser.appendMessages(i.messages, data, now)
+	if n := len(i.messages) - maxMessageCount; n > 0 {
+		i.messages = append(i.messages[:0], i.messages[n:]...)
+	}
+}
+
+type inputInfo struct {
+	id   string
+	name string
+}
+
+var errNotSupported = errors.New("midi: MIDI is not supported on this environment")
+
+// parser parses a MIDI byte stream into messages.
+type parser struct {
+	// status is the current running status, or 0 if there is none.
+	status byte
+
+	data []byte
+
+	// sysEx reports whether the parser is in a System Exclusive message.
+	sysEx bool
+}
+
+// dataLength returns the number of data bytes for the given status byte.
+func dataLength(status byte) int {
+	switch status & 0xf0 {
+	case 0x80, 0x90, 0xa0, 0xb0, 0xe0:
+		return 2
+	case 0xc0, 0xd0:
+		return 1
+	}
+	switch status {
+	case 0xf1, 0xf3:
+		return 1
+	case 0xf2:
+		return 2
+	}
+	return 0
+}
+
+func (p *parser) appendMessages(msgs []Message, data []byte, now time.Time) []Message {
+	for _, b := range data {
+		// System Real-Time messages can be interleaved anywhere, even in other messages.
+		if b >= 0xf8 {
+			msgs = append(msgs, newMessage([]byte{b}, now))
+			continue
+		}
+
+		if p.sysEx {
+			// Any status byte terminates a System Exclusive message.
+			if b&0x80 == 0 {
+				p.data = append(p.data, b)
+				continue
+			}
+			p.sysEx = false
+			if b == 0xf7 {
+				p.data = append(p.data, b)
+			}
+			msgs = append(msgs, newMessage(append([]byte{0xf0}, p.data...), now))
+			p.data = p.data[:0]
+			if b == 0xf7 {
+				continue
+			}
+		}
+
+		if b&0x80 != 0 {
+			p.data = p.data[:0]
+			switch {
+			case b == 0xf0:
+				p.status = 0
+				p.sysEx = true
+				continue
+			case b >= 0xf0:
+				// System Common messages cancel the running status.
+				p.status = 0
+				if dataLength(b) == 0 {
+					msgs = append(msgs, newMessage([]byte{b}, now))
+					continue
+				}
+			}
+			p.status = b
+			continue
+		}
+
+		// A data byte without a status is ignored.
+		if p.status == 0 {
+			continue
+		}
+		p.data = append(p.data, b)
+		if len(p.data) < dataLength(p.status) {
+			continue
+		}
+		msgs = append(msgs, newMessage(append([]byte{p.status}, p.data...), now))
+		p.data = p.data[:0]
+		if p.status >= 0xf0 {
+			p.status = 0
+		}
+	}
+	return msgs
+}
+
+func newMessage(bs []byte, now time.Time) Message {
+	msg := Message{
+		Bytes: bs,
+		Time:  now,
+	}
+	status := bs[0]
+	if status < 0xf0 {
+		msg.Channel = int(status & 0x0f)
+	}
+
+	switch status & 0xf0 {
+	case 0x80:
+		msg.Type = MessageTypeNoteOff
+		msg.Note = int(bs[1])
+		msg.Velocity = int(bs[2])
+	case 0x90:
+		msg.Type = MessageTypeNoteOn
+		if bs[2] == 0 {
+			msg.Type = MessageTypeNoteOff
+		}
+		msg.Note = int(bs[1])
+		msg.Velocity = int(bs[2])
+	case 0xb0:
+		msg.Type = MessageTypeControlChange
+		msg.Controller = int(bs[1])
+		msg.Value = int(bs[2])
+	case 0xc0:
+		msg.Type = MessageTypeProgramChange
+		msg.Value = int(bs[1])
+	case 0xe0:
+		msg.Type = MessageTypePitchBend
+		msg.Value = (int(bs[1]) | int(bs[2])<<7) - 8192
+	}
+
+	switch status {
+	case 0xf8:
+		msg.Type = MessageTypeClock
+	case 0xfa:
+		msg.Type = MessageTypeStart
+	case 0xfb:
+		msg.Type = MessageTypeContinue
+	case 0xfc:
+		msg.Type = MessageTypeStop
+	}
+
+	return msg
+}
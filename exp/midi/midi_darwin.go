@@ -0,0 +1,276 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ios
+
+package midi
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+)
+
+type (
+	_CFStringRef      uintptr
+	_CFStringEncoding uint32
+	_MIDIObjectRef    uint32
+	_MIDIClientRef    = _MIDIObjectRef
+	_MIDIPortRef      = _MIDIObjectRef
+	_MIDIEndpointRef  = _MIDIObjectRef
+	_OSStatus         int32
+)
+
+const kCFStringEncodingUTF8 _CFStringEncoding = 0x08000100
+
+type _MIDIReadProc func(pktlist unsafe.Pointer, readProcRefCon unsafe.Pointer, srcConnRefCon uintptr)
+
+var (
+	_CFRelease                 func(cf uintptr)
+	_CFStringCreateWithCString func(alloc uintptr, cstr []byte, encoding _CFStringEncoding) _CFStringRef
+	_CFStringGetCString        func(theString _CFStringRef, buffer []byte, encoding _CFStringEncoding) bool
+
+	_MIDIClientCreate             func(name _CFStringRef, notifyProc uintptr, notifyRefCon uintptr, outClient *_MIDIClientRef) _OSStatus
+	_MIDIGetNumberOfSources       func() uint64
+	_MIDIGetSource                func(sourceIndex0 uint64) _MIDIEndpointRef
+	_MIDIInputPortCreate          func(client _MIDIClientRef, portName _CFStringRef, readProc _MIDIReadProc, refCon uintptr, outPort *_MIDIPortRef) _OSStatus
+	_MIDIObjectGetIntegerProperty func(obj _MIDIObjectRef, propertyID _CFStringRef, outValue *int32) _OSStatus
+	_MIDIObjectGetStringProperty  func(obj _MIDIObjectRef, propertyID _CFStringRef, str *_CFStringRef) _OSStatus
+	_MIDIPortConnectSource        func(port _MIDIPortRef, source _MIDIEndpointRef, connRefCon uintptr) _OSStatus
+	_MIDIPortDisconnectSource     func(port _MIDIPortRef, source _MIDIEndpointRef) _OSStatus
+
+	kMIDIPropertyDisplayName _CFStringRef
+	kMIDIPropertyUniqueID    _CFStringRef
+)
+
+var (
+	theClient     _MIDIClientRef
+	thePort       _MIDIPortRef
+	theClientErr  error
+	theClientOnce sync.Once
+
+	callbacks  = map[uintptr]func(data []byte){}
+	callbacksM sync.Mutex
+)
+
+func initializeCoreMIDI() error {
+	corefoundation, err := purego.Dlopen("/System/Library/Frameworks/CoreFoundation.framework/CoreFoundation", purego.RTLD_LAZY|purego.RTLD_GLOBAL)
+	if err != nil {
+		return err
+	}
+	purego.RegisterLibFunc(&_CFRelease, corefoundation, "CFRelease")
+	purego.RegisterLibFunc(&_CFStringCreateWithCString, corefoundation, "CFStringCreateWithCString")
+	purego.RegisterLibFunc(&_CFStringGetCString, corefoundation, "CFStringGetCString")
+
+	coremidi, err := purego.Dlopen("/System/Library/Frameworks/CoreMIDI.framework/CoreMIDI", purego.RTLD_LAZY|purego.RTLD_GLOBAL)
+	if err != nil {
+		return err
+	}
+	purego.RegisterLibFunc(&_MIDIClientCreate, coremidi, "MIDIClientCreate")
+	purego.RegisterLibFunc(&_MIDIGetNumberOfSources, coremidi, "MIDIGetNumberOfSources")
+	purego.RegisterLibFunc(&_MIDIGetSource, coremidi, "MIDIGetSource")
+	purego.RegisterLibFunc(&_MIDIInputPortCreate, coremidi, "MIDIInputPortCreate")
+	purego.RegisterLibFunc(&_MIDIObjectGetIntegerProperty, coremidi, "MIDIObjectGetIntegerProperty")
+	purego.RegisterLibFunc(&_MIDIObjectGetStringProperty, coremidi, "MIDIObjectGetStringProperty")
+	purego.RegisterLibFunc(&_MIDIPortConnectSource, coremidi, "MIDIPortConnectSource")
+	purego.RegisterLibFunc(&_MIDIPortDisconnectSource, coremidi, "MIDIPortDisconnectSource")
+
+	displayName, err := purego.Dlsym(coremidi, "kMIDIPropertyDisplayName")
+	if err != nil {
+		return err
+	}
+	kMIDIPropertyDisplayName = **(**_CFStringRef)(unsafe.Pointer(&displayName))
+	uniqueID, err := purego.Dlsym(coremidi, "kMIDIPropertyUniqueID")
+	if err != nil {
+		return err
+	}
+	kMIDIPropertyUniqueID = **(**_CFStringRef)(unsafe.Pointer(&uniqueID))
+
+	return nil
+}
+
+func cfString(str string) _CFStringRef {
+	return _CFStringCreateWithCString(0, append([]byte(str), 0), kCFStringEncodingUTF8)
+}
+
+func ensureClient() error {
+	theClientOnce.Do(func() {
+		if err := initializeCoreMIDI(); err != nil {
+			theClientErr = fmt.Errorf("midi: initializing CoreMIDI failed: %w", err)
+			return
+		}
+
+		name := cfString("Ebitengine")
+		defer _CFRelease(uintptr(name))
+
+		if status := _MIDIClientCreate(name, 0, 0, &theClient); status != 0 {
+			theClientErr = fmt.Errorf("midi: MIDIClientCreate failed: %d", status)
+			return
+		}
+		if status := _MIDIInputPortCreate(theClient, name, midiReadProc, 0, &thePort); status != 0 {
+			theClientErr = fmt.Errorf("midi: MIDIInputPortCreate failed: %d", status)
+			return
+		}
+	})
+	return theClientErr
+}
+
+func midiReadProc(pktlist unsafe.Pointer, readProcRefCon unsafe.Pointer, srcConnRefCon uintptr) {
+	callbacksM.Lock()
+	f := callbacks[srcConnRefCon]
+	callbacksM.Unlock()
+	if f == nil {
+		return
+	}
+
+	// MIDIPacketList and MIDIPacket are packed with 4 bytes alignment.
+	//
+	//   struct MIDIPacketList { UInt32 numPackets; MIDIPacket packet[1]; };
+	//   struct MIDIPacket { MIDITimeStamp timeStamp; UInt16 length; Byte data[256]; };
+	num := *(*uint32)(pktlist)
+	p := unsafe.Add(pktlist, 4)
+	for i := uint32(0); i < num; i++ {
+		length := *(*uint16)(unsafe.Add(p, 8))
+		data := unsafe.Add(p, 10)
+		f(unsafe.Slice((*byte)(data), length))
+
+		// See MIDIPacketNext.
+		p = unsafe.Add(data, length)
+		if runtime.GOARCH == "arm64" {
+			p = unsafe.Add(p, (4-uintptr(p)%4)%4)
+		}
+	}
+}
+
+func sourceUniqueID(source _MIDIEndpointRef) (int32, bool) {
+	var id int32
+	if status := _MIDIObjectGetIntegerProperty(source, kMIDIPropertyUniqueID, &id); status != 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+func sourceName(source _MIDIEndpointRef) string {
+	var str _CFStringRef
+	if status := _MIDIObjectGetStringProperty(source, kMIDIPropertyDisplayName, &str); status != 0 || str == 0 {
+		return ""
+	}
+	defer _CFRelease(uintptr(str))
+
+	buf := make([]byte, 256)
+	if !_CFStringGetCString(str, buf, kCFStringEncodingUTF8) {
+		return ""
+	}
+	for i, b := range buf {
+		if b == 0 {
+			return string(buf[:i])
+		}
+	}
+	return string(buf)
+}
+
+// findSource returns the source with the given unique ID.
+func findSource(uniqueID int32) (_MIDIEndpointRef, bool) {
+	n := _MIDIGetNumberOfSources()
+	for i := uint64(0); i < n; i++ {
+		source := _MIDIGetSource(i)
+		if source == 0 {
+			continue
+		}
+		if id, ok := sourceUniqueID(source); ok && id == uniqueID {
+			return source, true
+		}
+	}
+	return 0, false
+}
+
+func appendInputInfos(infos []inputInfo) ([]inputInfo, error) {
+	if err := ensureClient(); err != nil {
+		return nil, err
+	}
+
+	n := _MIDIGetNumberOfSources()
+	for i := uint64(0); i < n; i++ {
+		source := _MIDIGetSource(i)
+		if source == 0 {
+			continue
+		}
+		id, ok := sourceUniqueID(source)
+		if !ok {
+			continue
+		}
+		infos = append(infos, inputInfo{
+			id:   strconv.Itoa(int(id)),
+			name: sourceName(source),
+		})
+	}
+	return infos, nil
+}
+
+type nativeInputImpl struct {
+	source   _MIDIEndpointRef
+	uniqueID int32
+}
+
+func openInput(id string, callback func(data []byte)) (nativeInput, error) {
+	if err := ensureClient(); err != nil {
+		return nil, err
+	}
+
+	uniqueID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("midi: invalid device ID: %s", id)
+	}
+	source, ok := findSource(int32(uniqueID))
+	if !ok {
+		return nil, fmt.Errorf("midi: the device %s is not found", id)
+	}
+
+	callbacksM.Lock()
+	callbacks[uintptr(uint32(uniqueID))] = callback
+	callbacksM.Unlock()
+
+	if status := _MIDIPortConnectSource(thePort, source, uintptr(uint32(uniqueID))); status != 0 {
+		callbacksM.Lock()
+		delete(callbacks, uintptr(uint32(uniqueID)))
+		callbacksM.Unlock()
+		return nil, fmt.Errorf("midi: MIDIPortConnectSource failed: %d", status)
+	}
+
+	return &nativeInputImpl{
+		source:   source,
+		uniqueID: int32(uniqueID),
+	}, nil
+}
+
+func (n *nativeInputImpl) isConnected() bool {
+	// The properties of a source are not available after the source is removed.
+	_, ok := sourceUniqueID(n.source)
+	return ok
+}
+
+func (n *nativeInputImpl) close() error {
+	callbacksM.Lock()
+	delete(callbacks, uintptr(uint32(n.uniqueID)))
+	callbacksM.Unlock()
+
+	if status := _MIDIPortDisconnectSource(thePort, n.source); status != 0 {
+		return fmt.Errorf("midi: MIDIPortDisconnectSource failed: %d", status)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package midi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"syscall/js"
+)
+
+var (
+	midiAccess          js.Value
+	midiAccessRequested bool
+	midiAccessErr       error
+	midiAccessM         sync.Mutex
+)
+
+// requestMIDIAccess requests the Web MIDI API access asynchronously.
+// requestMIDIAccess returns an undefined value until the access is granted.
+func requestMIDIAccess() (js.Value, error) {
+	midiAccessM.Lock()
+	defer midiAccessM.Unlock()
+
+	if midiAccessErr != nil {
+		return js.Undefined(), midiAccessErr
+	}
+	if midiAccessRequested {
+		return midiAccess, nil
+	}
+	midiAccessRequested = true
+
+	navigator := js.Global().Get("navigator")
+	if !navigator.Get("requestMIDIAccess").Truthy() {
+		midiAccessErr = errNotSupported
+		return js.Undefined(), midiAccessErr
+	}
+
+	var then, catch js.Func
+	then = js.FuncOf(func(this js.Value, args []js.Value) any {
+		midiAccessM.Lock()
+		defer midiAccessM.Unlock()
+		midiAccess = args[0]
+		then.Release()
+		catch.Release()
+		return nil
+	})
+	catch = js.FuncOf(func(this js.Value, args []js.Value) any {
+		midiAccessM.Lock()
+		defer midiAccessM.Unlock()
+		midiAccessErr = fmt.Errorf("midi: requestMIDIAccess failed: %s", args[0].Call("toString").String())
+		then.Release()
+		catch.Release()
+		return nil
+	})
+	navigator.Call("requestMIDIAccess").Call("then", then).Call("catch", catch)
+	return js.Undefined(), nil
+}
+
+func appendInputInfos(infos []inputInfo) ([]inputInfo, error) {
+	access, err := requestMIDIAccess()
+	if err != nil {
+		return nil, err
+	}
+	if !access.Truthy() {
+		return infos, nil
+	}
+
+	f := js.FuncOf(func(this js.Value, args []js.Value) any {
+		port := args[0]
+		infos = append(infos, inputInfo{
+			id:   port.Get("id").String(),
+			name: port.Get("name").String(),
+		})
+		return nil
+	})
+	defer f.Release()
+	access.Get("inputs").Call("forEach", f)
+
+	return infos, nil
+}
+
+type nativeInputImpl struct {
+	port      js.Value
+	onMessage js.Func
+}
+
+func openInput(id string, callback func(data []byte)) (nativeInput, error) {
+	access, err := requestMIDIAccess()
+	if err != nil {
+		return nil, err
+	}
+	if !access.Truthy() {
+		return nil, errors.New("midi: MIDI access is not granted yet")
+	}
+	port := access.Get("inputs").Call("get", id)
+	if !port.Truthy() {
+		return nil, fmt.Errorf("midi: the device %s is not found", id)
+	}
+
+	var buf []byte
+	onMessage := js.FuncOf(func(this js.Value, args []js.Value) any {
+		data := args[0].Get("data")
+		if n := data.Get("length").Int(); len(buf) < n {
+			buf = make([]byte, n)
+		}
+		n := js.CopyBytesToGo(buf, data)
+		callback(buf[:n])
+		return nil
+	})
+	port.Call("addEventListener", "midimessage", onMessage)
+
+	return &nativeInputImpl{
+		port:      port,
+		onMessage: onMessage,
+	}, nil
+}
+
+func (n *nativeInputImpl) isConnected() bool {
+	return n.port.Get("state").String() == "connected"
+}
+
+func (n *nativeInputImpl) close() error {
+	n.port.Call("removeEventListener", "midimessage", n.onMessage)
+	n.onMessage.Release()
+	n.port.Call("close")
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !android
+
+package midi
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/sys/unix"
+)
+
+var reRawMIDI = regexp.MustCompile(`^midiC(\d+)D(\d+)$`)
+
+func appendInputInfos(infos []inputInfo) ([]inputInfo, error) {
+	paths, err := filepath.Glob("/dev/snd/midiC*D*")
+	if err != nil {
+		return nil, fmt.Errorf("midi: glob failed: %w", err)
+	}
+	for _, path := range paths {
+		m := reRawMIDI.FindStringSubmatch(filepath.Base(path))
+		if m == nil {
+			continue
+		}
+		infos = append(infos, inputInfo{
+			id:   path,
+			name: rawMIDIName(m[1], m[2], path),
+		})
+	}
+	return infos, nil
+}
+
+// rawMIDIName returns the name of the raw MIDI device from the proc file system.
+func rawMIDIName(card, device string, path string) string {
+	f, err := os.Open(fmt.Sprintf("/proc/asound/card%s/midi%s", card, device))
+	if err != nil {
+		return filepath.Base(path)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	// The first line is the name of the device.
+	s := bufio.NewScanner(f)
+	if !s.Scan() {
+		return filepath.Base(path)
+	}
+	if name := strings.TrimSpace(s.Text()); name != "" {
+		return name
+	}
+	return filepath.Base(path)
+}
+
+type nativeInputImpl struct {
+	fd   int
+	done chan struct{}
+	wait chan struct{}
+
+	// disconnected is set when reading the device fails, e.g. the device is unplugged.
+	disconnected atomic.Bool
+}
+
+func openInput(id string, callback func(data []byte)) (nativeInput, error) {
+	fd, err := unix.Open(id, unix.O_RDONLY|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("midi: opening %s failed: %w", id, err)
+	}
+
+	n := &nativeInputImpl{
+		fd:   fd,
+		done: make(chan struct{}),
+		wait: make(chan struct{}),
+	}
+	go n.loop(callback)
+	return n, nil
+}
+
+func (n *nativeInputImpl) loop(callback func(data []byte)) {
+	defer close(n.wait)
+
+	buf := make([]byte, 256)
+	fds := []unix.PollFd{{Fd: int32(n.fd), Events: unix.POLLIN}}
+	for {
+		select {
+		case <-n.done:
+			return
+		default:
+		}
+
+		// Poll with a timeout so that closing is detected.
+		if _, err := unix.Poll(fds, 100); err != nil {
+			if errors.Is(err, unix.EINTR) {
+				continue
+			}
+			n.disconnected.Store(true)
+			return
+		}
+		if fds[0].Revents&(unix.POLLERR|unix.POLLHUP|unix.POLLNVAL) != 0 {
+			// The device is disconnected.
+			n.disconnected.Store(true)
+			return
+		}
+		if fds[0].Revents&unix.POLLIN == 0 {
+			continue
+		}
+
+		l, err := unix.Read(n.fd, buf)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+			n.disconnected.Store(true)
+			return
+		}
+		if l > 0 {
+			callback(buf[:l])
+		}
+	}
+}
+
+func (n *nativeInputImpl) isConnected() bool {
+	return !n.disconnected.Load()
+}
+
+func (n *nativeInputImpl) close() error {
+	close(n.done)
+	<-n.wait
+	if err := unix.Close(n.fd); err != nil {
+		return fmt.Errorf("midi: closing a device failed: %w", err)
+	}
+	return nil
+}
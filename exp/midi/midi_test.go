@@ -0,0 +1,147 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package midi
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParser(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Input [][]byte
+		Want  []Message
+	}{
+		{
+			Name:  "note on and off",
+			Input: [][]byte{{0x91, 60, 100, 0x81, 60, 0}},
+			Want: []Message{
+				{Type: MessageTypeNoteOn, Channel: 1, Note: 60, Velocity: 100, Bytes: []byte{0x91, 60, 100}},
+				{Type: MessageTypeNoteOff, Channel: 1, Note: 60, Bytes: []byte{0x81, 60, 0}},
+			},
+		},
+		{
+			Name:  "running status and note on with velocity 0",
+			Input: [][]byte{{0x90, 60, 100, 62}, {90, 60, 0}},
+			Want: []Message{
+				{Type: MessageTypeNoteOn, Note: 60, Velocity: 100, Bytes: []byte{0x90, 60, 100}},
+				{Type: MessageTypeNoteOn, Note: 62, Velocity: 90, Bytes: []byte{0x90, 62, 90}},
+				{Type: MessageTypeNoteOff, Note: 60, Bytes: []byte{0x90, 60, 0}},
+			},
+		},
+		{
+			Name:  "real-time in a message",
+			Input: [][]byte{{0xb2, 7, 0xf8, 127}},
+			Want: []Message{
+				{Type: MessageTypeClock, Bytes: []byte{0xf8}},
+				{Type: MessageTypeControlChange, Channel: 2, Controller: 7, Value: 127, Bytes: []byte{0xb2, 7, 127}},
+			},
+		},
+		{
+			Name:  "pitch bend",
+			Input: [][]byte{{0xe0, 0, 0x40, 0xe0, 0x7f, 0x7f}},
+			Want: []Message{
+				{Type: MessageTypePitchBend, Value: 0, Bytes: []byte{0xe0, 0, 0x40}},
+				{Type: MessageTypePitchBend, Value: 8191, Bytes: []byte{0xe0, 0x7f, 0x7f}},
+			},
+		},
+		{
+			Name:  "system exclusive",
+			Input: [][]byte{{0xf0, 1, 2}, {3, 0xf7, 0xfa, 0xfc}},
+			Want: []Message{
+				{Type: MessageTypeOther, Bytes: []byte{0xf0, 1, 2, 3, 0xf7}},
+				{Type: MessageTypeStart, Bytes: []byte{0xfa}},
+				{Type: MessageTypeStop, Bytes: []byte{0xfc}},
+			},
+		},
+		{
+			Name:  "data without status",
+			Input: [][]byte{{60, 100, 0xc3, 5, 6}},
+			Want: []Message{
+				{Type: MessageTypeProgramChange, Channel: 3, Value: 5, Bytes: []byte{0xc3, 5}},
+				{Type: MessageTypeProgramChange, Channel: 3, Value: 6, Bytes: []byte{0xc3, 6}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var p parser
+			var got []Message
+			now := time.Now()
+			for _, in := range tc.Input {
+				got = p.appendMessages(got, in, now)
+			}
+			if len(got) != len(tc.Want) {
+				t.Fatalf("len(got): %d, want: %d", len(got), len(tc.Want))
+			}
+			for i := range got {
+				want := tc.Want[i]
+				want.Time = now
+				if !reflect.DeepEqual(got[i], want) {
+					t.Errorf("got[%d]: %+v, want: %+v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+type testNativeInput struct {
+	connected bool
+	closed    int
+}
+
+func (n *testNativeInput) isConnected() bool {
+	return n.connected
+}
+
+func (n *testNativeInput) close() error {
+	n.closed++
+	return nil
+}
+
+func TestInputDisconnected(t *testing.T) {
+	n := &testNativeInput{connected: true}
+	i := &Input{native: n}
+
+	if !i.IsOpen() {
+		t.Errorf("IsOpen: got: false, want: true")
+	}
+	if n.closed != 0 {
+		t.Errorf("closed: got: %d, want: 0", n.closed)
+	}
+
+	n.connected = false
+	if i.IsOpen() {
+		t.Errorf("IsOpen after disconnection: got: true, want: false")
+	}
+	if n.closed != 1 {
+		t.Errorf("closed: got: %d, want: 1", n.closed)
+	}
+
+	// The native input must not be closed twice.
+	if err := i.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if i.IsOpen() {
+		t.Errorf("IsOpen after Close: got: true, want: false")
+	}
+	if n.closed != 1 {
+		t.Errorf("closed: got: %d, want: 1", n.closed)
+	}
+}
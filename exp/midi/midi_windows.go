@@ -0,0 +1,238 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package midi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	_CALLBACK_FUNCTION = 0x00030000
+	_MIM_DATA          = 0x3c3
+	_MMSYSERR_NOERROR  = 0
+)
+
+type _MIDIINCAPSW struct {
+	wMid           uint16
+	wPid           uint16
+	vDriverVersion uint32
+	szPname        [32]uint16
+	dwSupport      uint32
+}
+
+var (
+	winmm = windows.NewLazySystemDLL("winmm.dll")
+
+	procMidiInClose       = winmm.NewProc("midiInClose")
+	procMidiInGetDevCapsW = winmm.NewProc("midiInGetDevCapsW")
+	procMidiInGetNumDevs  = winmm.NewProc("midiInGetNumDevs")
+	procMidiInOpen        = winmm.NewProc("midiInOpen")
+	procMidiInReset       = winmm.NewProc("midiInReset")
+	procMidiInStart       = winmm.NewProc("midiInStart")
+	procMidiInStop        = winmm.NewProc("midiInStop")
+)
+
+func midiInClose(hmi uintptr) error {
+	r, _, _ := procMidiInClose.Call(hmi)
+	if r != _MMSYSERR_NOERROR {
+		return fmt.Errorf("midi: midiInClose failed: %d", r)
+	}
+	return nil
+}
+
+func midiInGetDevCaps(deviceID uint32) (_MIDIINCAPSW, error) {
+	var caps _MIDIINCAPSW
+	r, _, _ := procMidiInGetDevCapsW.Call(uintptr(deviceID), uintptr(unsafe.Pointer(&caps)), unsafe.Sizeof(caps))
+	if r != _MMSYSERR_NOERROR {
+		return _MIDIINCAPSW{}, fmt.Errorf("midi: midiInGetDevCapsW failed: %d", r)
+	}
+	return caps, nil
+}
+
+func midiInGetNumDevs() uint32 {
+	r, _, _ := procMidiInGetNumDevs.Call()
+	return uint32(r)
+}
+
+func midiInOpen(deviceID uint32, callback uintptr, instance uintptr) (uintptr, error) {
+	var hmi uintptr
+	r, _, _ := procMidiInOpen.Call(uintptr(unsafe.Pointer(&hmi)), uintptr(deviceID), callback, instance, _CALLBACK_FUNCTION)
+	if r != _MMSYSERR_NOERROR {
+		return 0, fmt.Errorf("midi: midiInOpen failed: %d", r)
+	}
+	return hmi, nil
+}
+
+func midiInReset(hmi uintptr) error {
+	r, _, _ := procMidiInReset.Call(hmi)
+	if r != _MMSYSERR_NOERROR {
+		return fmt.Errorf("midi: midiInReset failed: %d", r)
+	}
+	return nil
+}
+
+func midiInStart(hmi uintptr) error {
+	r, _, _ := procMidiInStart.Call(hmi)
+	if r != _MMSYSERR_NOERROR {
+		return fmt.Errorf("midi: midiInStart failed: %d", r)
+	}
+	return nil
+}
+
+func midiInStop(hmi uintptr) error {
+	r, _, _ := procMidiInStop.Call(hmi)
+	if r != _MMSYSERR_NOERROR {
+		return fmt.Errorf("midi: midiInStop failed: %d", r)
+	}
+	return nil
+}
+
+var (
+	// midiInProcCallback is shared among all the devices as the number of callbacks is limited.
+	midiInProcCallback     uintptr
+	midiInProcCallbackOnce sync.Once
+
+	callbacks              = map[uintptr]func(data []byte){}
+	nextCallbackID uintptr = 1
+	callbacksM     sync.Mutex
+)
+
+func midiInProc(hmi uintptr, msg uintptr, instance uintptr, param1 uintptr, param2 uintptr) uintptr {
+	if msg != _MIM_DATA {
+		return 0
+	}
+
+	callbacksM.Lock()
+	f := callbacks[instance]
+	callbacksM.Unlock()
+	if f == nil {
+		return 0
+	}
+
+	// A short message is packed into param1. The first byte is the status.
+	bs := []byte{byte(param1), byte(param1 >> 8), byte(param1 >> 16)}
+	f(bs[:1+dataLength(bs[0])])
+	return 0
+}
+
+func appendInputInfos(infos []inputInfo) ([]inputInfo, error) {
+	n := midiInGetNumDevs()
+	for i := uint32(0); i < n; i++ {
+		caps, err := midiInGetDevCaps(i)
+		if err != nil {
+			// The device might be disconnected just now.
+			continue
+		}
+		name := windows.UTF16ToString(caps.szPname[:])
+		// The device ID is an index and might be changed when a device is connected or disconnected.
+		// Use the pair of the index and the name as an ID.
+		infos = append(infos, inputInfo{
+			id:   strconv.Itoa(int(i)) + ":" + name,
+			name: name,
+		})
+	}
+	return infos, nil
+}
+
+type nativeInputImpl struct {
+	hmi        uintptr
+	callbackID uintptr
+	deviceID   uint32
+	name       string
+}
+
+func openInput(id string, callback func(data []byte)) (nativeInput, error) {
+	idx, name, ok := strings.Cut(id, ":")
+	if !ok {
+		return nil, fmt.Errorf("midi: invalid device ID: %s", id)
+	}
+	deviceID, err := strconv.Atoi(idx)
+	if err != nil {
+		return nil, fmt.Errorf("midi: invalid device ID: %s", id)
+	}
+	caps, err := midiInGetDevCaps(uint32(deviceID))
+	if err != nil {
+		return nil, err
+	}
+	if windows.UTF16ToString(caps.szPname[:]) != name {
+		return nil, fmt.Errorf("midi: the device %s is not found", name)
+	}
+
+	midiInProcCallbackOnce.Do(func() {
+		midiInProcCallback = windows.NewCallback(midiInProc)
+	})
+
+	callbacksM.Lock()
+	callbackID := nextCallbackID
+	nextCallbackID++
+	callbacks[callbackID] = callback
+	callbacksM.Unlock()
+
+	hmi, err := midiInOpen(uint32(deviceID), midiInProcCallback, callbackID)
+	if err != nil {
+		callbacksM.Lock()
+		delete(callbacks, callbackID)
+		callbacksM.Unlock()
+		return nil, err
+	}
+	if err := midiInStart(hmi); err != nil {
+		_ = midiInClose(hmi)
+		callbacksM.Lock()
+		delete(callbacks, callbackID)
+		callbacksM.Unlock()
+		return nil, err
+	}
+
+	return &nativeInputImpl{
+		hmi:        hmi,
+		callbackID: callbackID,
+		deviceID:   uint32(deviceID),
+		name:       name,
+	}, nil
+}
+
+func (n *nativeInputImpl) isConnected() bool {
+	// The device ID is an index, and another device might take the index after the device is disconnected.
+	caps, err := midiInGetDevCaps(n.deviceID)
+	if err != nil {
+		return false
+	}
+	return windows.UTF16ToString(caps.szPname[:]) == n.name
+}
+
+func (n *nativeInputImpl) close() error {
+	defer func() {
+		callbacksM.Lock()
+		delete(callbacks, n.callbackID)
+		callbacksM.Unlock()
+	}()
+
+	if err := midiInStop(n.hmi); err != nil {
+		return err
+	}
+	if err := midiInReset(n.hmi); err != nil {
+		return err
+	}
+	if err := midiInClose(n.hmi); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package platform provides an interface to plug a platform layer into Ebitengine.
+// This package is experimental and the API might be changed in the future.
+//
+// This package is for porting Ebitengine to platforms that Ebitengine doesn't support directly,
+// e.g. video game consoles whose SDKs are not public.
+// A platform layer implements Platform and registers it by Register, typically in an init function:
+//
+//	func init() {
+//		platform.Register(&myPlatform{})
+//	}
+//
+// The platform layer can be a closed-source package outside of Ebitengine.
+// Ebitengine's internal packages don't have to be patched.
+//
+// The registered platform layer is used only with the build tag `ebitenginecustomplatform`.
+// Without the build tag, Register has no effect.
+// The build tag works with GOOS=linux, in the same way as the build tags for other consoles like `nintendosdk`.
+//
+// The platform layer provides these hooks:
+//
+//   - Graphics: OpenGL or OpenGL ES 3.x functions and a rendering surface. See OpenGL.
+//   - Input: touches and gamepads. See Platform.AppendTouches and Platform.AppendGamepads.
+//   - Audio: an optional audio output used by the package audio. See Audio.
+package platform
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/customplatform"
+)
+
+// Platform represents a platform layer.
+//
+// Initialize is called once on the main thread before any other functions are called.
+//
+// ScreenSize returns the screen size in pixels. ScreenSize is called every frame.
+//
+// OpenGL returns the graphics hooks. OpenGL is called after Initialize, and must return the same value every time.
+//
+// AppendTouches appends the current touches to touches and returns the extended buffer.
+// The positions are in pixels of the screen.
+// AppendTouches is called once per tick on the main thread.
+//
+// AppendGamepads appends the current states of the connected gamepads to gamepads and returns the extended buffer.
+// AppendGamepads is called once per tick on the main thread.
+//
+// A Platform can implement GamepadVibrator and Audio optionally.
+type Platform = customplatform.Platform
+
+// OpenGL represents graphics hooks for OpenGL or OpenGL ES.
+//
+// IsES reports whether the graphics library is OpenGL ES 3.0 or later.
+// If IsES returns false, the graphics library must be OpenGL 3.2 or later.
+//
+// GetProcAddress returns the address of the OpenGL function specified by the name, e.g. "glDrawElements".
+//
+// MakeContextCurrent makes the OpenGL context current on the calling thread.
+//
+// SwapBuffers presents the rendering result on the screen.
+//
+// All the functions are called on the main thread.
+type OpenGL = customplatform.OpenGL
+
+// Touch represents a touch state.
+//
+// ID is an identifier of the touch. ID must be unique among the current touches.
+//
+// X and Y represent the position in pixels of the screen.
+type Touch = customplatform.Touch
+
+// Gamepad represents a gamepad state.
+//
+// ID is an identifier of the gamepad. ID must be unique among the connected gamepads,
+// and must not be changed while the gamepad is connected.
+//
+// Name is the name of the gamepad.
+//
+// StandardLayout reports whether the buttons and the axes are in the order of the standard layout,
+// i.e. the order of ebiten.StandardGamepadButton and ebiten.StandardGamepadAxis.
+//
+// Axes are the axis values in [-1, 1].
+//
+// Buttons are the button values in [0, 1], and ButtonsPressed are the button states.
+// The lengths of Buttons and ButtonsPressed must be the same.
+//
+// The lengths of the slices must not be changed while the gamepad is connected.
+type Gamepad = customplatform.Gamepad
+
+// GamepadVibrator is an optional interface for Platform to vibrate gamepads.
+//
+// VibrateGamepad vibrates the gamepad specified by id.
+type GamepadVibrator = customplatform.GamepadVibrator
+
+// Audio is an optional interface for Platform to output audio.
+// If Platform doesn't implement Audio, the package audio doesn't work.
+//
+// NewAudioContext creates a new audio context.
+// The returned channel is closed when the audio context is ready.
+// NewAudioContext is called at most once.
+type Audio = customplatform.Audio
+
+// AudioContext represents an audio context.
+//
+// NewPlayer creates a new player reading the audio data from r.
+// The format of the data is interleaved signed 16bit integers in little endian with the sample rate and
+// the channel count given to Audio.NewAudioContext.
+//
+// Suspend and Resume suspend and resume all the players.
+//
+// Err returns an error if the audio device has a failure.
+type AudioContext = customplatform.AudioContext
+
+// AudioPlayer represents an audio player.
+// The methods have the same semantics as the methods of the package audio's Player.
+type AudioPlayer = customplatform.AudioPlayer
+
+// Register registers the platform layer.
+//
+// Register must be called before running a game, e.g. in an init function.
+// If Register is called multiple times, the last registered platform layer is used.
+func Register(platform Platform) {
+	if platform == nil {
+		panic("platform: platform must not be nil")
+	}
+	customplatform.Register(platform)
+}
@@ -0,0 +1,277 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scene provides a minimal scene manager with transition effects.
+// This package is experimental and the API might be changed in the future.
+//
+// A Manager holds a stack of scenes, and forwards Update and Draw to the top scene.
+// When the top scene is changed, the outgoing scene's last rendering result is blended with the incoming scene
+// by a transition effect like a fade or a wipe.
+package scene
+
+import (
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Scene represents a scene, e.g. a title screen or a game stage.
+type Scene interface {
+	// Update updates the scene's state. Update is called every tick while the scene is the top scene.
+	Update() error
+
+	// Draw draws the scene onto the screen.
+	Draw(screen *ebiten.Image)
+}
+
+// TransitionType represents a type of transition effects.
+type TransitionType int
+
+const (
+	// TransitionNone switches the scenes immediately.
+	TransitionNone TransitionType = iota
+
+	// TransitionFade fades the outgoing scene out to black, and then fades the incoming scene in.
+	TransitionFade
+
+	// TransitionCrossfade blends the outgoing scene and the incoming scene.
+	TransitionCrossfade
+
+	// TransitionWipe replaces the outgoing scene with the incoming scene from left to right.
+	TransitionWipe
+)
+
+// Transition represents a transition effect.
+type Transition struct {
+	// Type is the type of the effect.
+	Type TransitionType
+
+	// Duration is the length of the effect in ticks.
+	//
+	// If Duration is 0 or less, the scenes are switched immediately.
+	Duration int
+}
+
+// Manager manages a stack of scenes.
+type Manager struct {
+	scenes []Scene
+
+	transition     Transition
+	transitionTick int
+
+	// outgoing is the scene whose rendering result is not taken yet as a snapshot.
+	outgoing Scene
+
+	snapshot  *ebiten.Image
+	offscreen *ebiten.Image
+}
+
+// NewManager creates a new Manager with the initial scene.
+func NewManager(initial Scene) *Manager {
+	return &Manager{
+		scenes: []Scene{initial},
+	}
+}
+
+// Current returns the top scene. Current returns nil if there is no scene.
+func (m *Manager) Current() Scene {
+	if len(m.scenes) == 0 {
+		return nil
+	}
+	return m.scenes[len(m.scenes)-1]
+}
+
+// Len returns the number of the scenes in the stack.
+func (m *Manager) Len() int {
+	return len(m.scenes)
+}
+
+// Push pushes a new scene onto the stack with a transition effect.
+// If transition is nil, the scenes are switched immediately.
+func (m *Manager) Push(scene Scene, transition *Transition) {
+	outgoing := m.Current()
+	m.scenes = append(m.scenes, scene)
+	m.startTransition(outgoing, transition)
+}
+
+// Pop removes the top scene from the stack with a transition effect, and returns the removed scene.
+// If transition is nil, the scenes are switched immediately.
+//
+// Pop returns nil if there is no scene.
+func (m *Manager) Pop(transition *Transition) Scene {
+	outgoing := m.Current()
+	if outgoing == nil {
+		return nil
+	}
+	m.scenes[len(m.scenes)-1] = nil
+	m.scenes = m.scenes[:len(m.scenes)-1]
+	m.startTransition(outgoing, transition)
+	return outgoing
+}
+
+// Replace replaces the top scene with a new scene with a transition effect.
+// If transition is nil, the scenes are switched immediately.
+//
+// If there is no scene, Replace is the same as Push.
+func (m *Manager) Replace(scene Scene, transition *Transition) {
+	outgoing := m.Current()
+	if outgoing == nil {
+		m.Push(scene, transition)
+		return
+	}
+	m.scenes[len(m.scenes)-1] = scene
+	m.startTransition(outgoing, transition)
+}
+
+func (m *Manager) startTransition(outgoing Scene, transition *Transition) {
+	if transition == nil || transition.Type == TransitionNone || transition.Duration <= 0 || outgoing == nil {
+		m.transition = Transition{}
+		m.transitionTick = 0
+		m.outgoing = nil
+		return
+	}
+	m.transition = *transition
+	m.transitionTick = 0
+	m.outgoing = outgoing
+}
+
+// IsTransitioning reports whether a transition effect is in progress.
+func (m *Manager) IsTransitioning() bool {
+	return m.transition.Duration > 0
+}
+
+// Update updates the top scene and the transition effect.
+//
+// Update must be called every tick, typically in Game's Update.
+func (m *Manager) Update() error {
+	if m.IsTransitioning() {
+		m.transitionTick++
+		if m.transitionTick >= m.transition.Duration {
+			m.transition = Transition{}
+			m.transitionTick = 0
+			m.outgoing = nil
+		}
+	}
+
+	s := m.Current()
+	if s == nil {
+		return nil
+	}
+	return s.Update()
+}
+
+// Draw draws the top scene onto the screen with the transition effect if any.
+//
+// Draw must be called every frame, typically in Game's Draw.
+func (m *Manager) Draw(screen *ebiten.Image) {
+	s := m.Current()
+	if !m.IsTransitioning() {
+		if s != nil {
+			s.Draw(screen)
+		}
+		return
+	}
+
+	b := screen.Bounds()
+	m.snapshot = ensureImage(m.snapshot, b)
+	m.offscreen = ensureImage(m.offscreen, b)
+
+	// Take a snapshot of the outgoing scene only once, as the outgoing scene is no longer updated.
+	if m.outgoing != nil {
+		m.snapshot.Clear()
+		m.outgoing.Draw(m.snapshot)
+		m.outgoing = nil
+	}
+
+	m.offscreen.Clear()
+	if s != nil {
+		s.Draw(m.offscreen)
+	}
+
+	shader := transitionShader()
+	op := &ebiten.DrawRectShaderOptions{}
+	op.GeoM.Translate(float64(b.Min.X), float64(b.Min.Y))
+	op.Images[0] = m.snapshot
+	op.Images[1] = m.offscreen
+	op.Uniforms = map[string]any{
+		"Mode":     int(m.transition.Type),
+		"Progress": float32(m.transitionTick) / float32(m.transition.Duration),
+	}
+	screen.DrawRectShader(b.Dx(), b.Dy(), shader, op)
+}
+
+func ensureImage(img *ebiten.Image, bounds image.Rectangle) *ebiten.Image {
+	if img != nil && img.Bounds().Size() == bounds.Size() {
+		return img
+	}
+	if img != nil {
+		img.Deallocate()
+	}
+	return ebiten.NewImage(bounds.Dx(), bounds.Dy())
+}
+
+var (
+	theTransitionShader  *ebiten.Shader
+	transitionShaderOnce sync.Once
+)
+
+func transitionShader() *ebiten.Shader {
+	transitionShaderOnce.Do(func() {
+		s, err := ebiten.NewShader([]byte(transitionShaderSrc))
+		if err != nil {
+			panic(fmt.Sprintf("scene: compiling the transition shader failed: %v", err))
+		}
+		theTransitionShader = s
+	})
+	return theTransitionShader
+}
+
+const transitionShaderSrc = `//kage:unit pixels
+
+package main
+
+var Mode int
+var Progress float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	from := imageSrc0UnsafeAt(srcPos)
+	to := imageSrc1UnsafeAt(srcPos)
+
+	// Fade
+	if Mode == 1 {
+		if Progress < 0.5 {
+			return vec4(from.rgb*(1-2*Progress), from.a)
+		}
+		return vec4(to.rgb*(2*Progress-1), to.a)
+	}
+
+	// Crossfade
+	if Mode == 2 {
+		return mix(from, to, Progress)
+	}
+
+	// Wipe
+	if Mode == 3 {
+		x := (srcPos.x - imageSrc0Origin().x) / imageSrc0Size().x
+		if x < Progress {
+			return to
+		}
+		return from
+	}
+
+	return to
+}
+`
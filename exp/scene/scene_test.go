@@ -0,0 +1,172 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scene_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/scene"
+)
+
+type testScene struct {
+	updateCount int
+	drawCount   int
+	err         error
+}
+
+func (s *testScene) Update() error {
+	s.updateCount++
+	return s.err
+}
+
+func (s *testScene) Draw(screen *ebiten.Image) {
+	s.drawCount++
+}
+
+func TestManagerStack(t *testing.T) {
+	s0, s1, s2 := &testScene{}, &testScene{}, &testScene{}
+	m := scene.NewManager(s0)
+	if got, want := m.Len(), 1; got != want {
+		t.Errorf("Len(): got: %d, want: %d", got, want)
+	}
+	if got, want := m.Current(), scene.Scene(s0); got != want {
+		t.Errorf("Current(): got: %v, want: %v", got, want)
+	}
+
+	m.Push(s1, nil)
+	if got, want := m.Len(), 2; got != want {
+		t.Errorf("Len(): got: %d, want: %d", got, want)
+	}
+	if got, want := m.Current(), scene.Scene(s1); got != want {
+		t.Errorf("Current(): got: %v, want: %v", got, want)
+	}
+
+	m.Replace(s2, nil)
+	if got, want := m.Len(), 2; got != want {
+		t.Errorf("Len(): got: %d, want: %d", got, want)
+	}
+	if got, want := m.Current(), scene.Scene(s2); got != want {
+		t.Errorf("Current(): got: %v, want: %v", got, want)
+	}
+
+	if got, want := m.Pop(nil), scene.Scene(s2); got != want {
+		t.Errorf("Pop(): got: %v, want: %v", got, want)
+	}
+	if got, want := m.Pop(nil), scene.Scene(s0); got != want {
+		t.Errorf("Pop(): got: %v, want: %v", got, want)
+	}
+	if got := m.Pop(nil); got != nil {
+		t.Errorf("Pop(): got: %v, want: nil", got)
+	}
+	if got := m.Current(); got != nil {
+		t.Errorf("Current(): got: %v, want: nil", got)
+	}
+
+	// Replace with no scenes is the same as Push.
+	m.Replace(s1, nil)
+	if got, want := m.Len(), 1; got != want {
+		t.Errorf("Len(): got: %d, want: %d", got, want)
+	}
+	if got, want := m.Current(), scene.Scene(s1); got != want {
+		t.Errorf("Current(): got: %v, want: %v", got, want)
+	}
+}
+
+func TestManagerUpdate(t *testing.T) {
+	s0, s1 := &testScene{}, &testScene{}
+	m := scene.NewManager(s0)
+	m.Push(s1, nil)
+	if err := m.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if s0.updateCount != 0 || s1.updateCount != 1 {
+		t.Errorf("update counts: got: (%d, %d), want: (0, 1)", s0.updateCount, s1.updateCount)
+	}
+
+	s1.err = errors.New("test")
+	if err := m.Update(); !errors.Is(err, s1.err) {
+		t.Errorf("Update(): got: %v, want: %v", err, s1.err)
+	}
+
+	// Update without scenes does nothing.
+	m.Pop(nil)
+	m.Pop(nil)
+	if err := m.Update(); err != nil {
+		t.Errorf("Update(): got: %v, want: nil", err)
+	}
+}
+
+func TestManagerTransition(t *testing.T) {
+	s0, s1 := &testScene{}, &testScene{}
+	m := scene.NewManager(s0)
+
+	m.Push(s1, &scene.Transition{Type: scene.TransitionFade, Duration: 3})
+	if !m.IsTransitioning() {
+		t.Fatalf("IsTransitioning(): got: false, want: true")
+	}
+
+	screen := ebiten.NewImage(16, 16)
+	for i := 0; i < 3; i++ {
+		if !m.IsTransitioning() {
+			t.Errorf("IsTransitioning() at %d: got: false, want: true", i)
+		}
+		m.Draw(screen)
+		if err := m.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if m.IsTransitioning() {
+		t.Errorf("IsTransitioning(): got: true, want: false after the duration")
+	}
+
+	// The outgoing scene is drawn only once as a snapshot, and is not updated.
+	if got, want := s0.drawCount, 1; got != want {
+		t.Errorf("s0.drawCount: got: %d, want: %d", got, want)
+	}
+	if got, want := s0.updateCount, 0; got != want {
+		t.Errorf("s0.updateCount: got: %d, want: %d", got, want)
+	}
+	if got, want := s1.drawCount, 3; got != want {
+		t.Errorf("s1.drawCount: got: %d, want: %d", got, want)
+	}
+	if got, want := s1.updateCount, 3; got != want {
+		t.Errorf("s1.updateCount: got: %d, want: %d", got, want)
+	}
+}
+
+func TestManagerNoTransition(t *testing.T) {
+	for _, tr := range []*scene.Transition{
+		nil,
+		{Type: scene.TransitionNone, Duration: 10},
+		{Type: scene.TransitionCrossfade, Duration: 0},
+		{Type: scene.TransitionWipe, Duration: -1},
+	} {
+		m := scene.NewManager(&testScene{})
+		m.Push(&testScene{}, tr)
+		if m.IsTransitioning() {
+			t.Errorf("IsTransitioning() with %v: got: true, want: false", tr)
+		}
+	}
+
+	// Pushing onto an empty stack doesn't start a transition as there is no outgoing scene.
+	m := scene.NewManager(&testScene{})
+	m.Pop(nil)
+	m.Push(&testScene{}, &scene.Transition{Type: scene.TransitionFade, Duration: 10})
+	if m.IsTransitioning() {
+		t.Errorf("IsTransitioning(): got: true, want: false")
+	}
+}
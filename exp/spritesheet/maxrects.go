@@ -0,0 +1,108 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spritesheet
+
+import (
+	"image"
+	"math"
+)
+
+// maxRects is a rectangle packer with the MaxRects algorithm (the best short side fit rule).
+//
+// See Jukka Jylänki, "A Thousand Ways to Pack the Bin - A Practical Approach to Two-Dimensional Rectangle Bin Packing".
+type maxRects struct {
+	free []image.Rectangle
+}
+
+func newMaxRects(width, height int) *maxRects {
+	return &maxRects{
+		free: []image.Rectangle{image.Rect(0, 0, width, height)},
+	}
+}
+
+// insert finds a place for a rectangle with the given size, and returns the placed rectangle.
+// insert returns false if there is no space for the rectangle.
+func (m *maxRects) insert(width, height int) (image.Rectangle, bool) {
+	bestShort := math.MaxInt
+	bestLong := math.MaxInt
+	var best image.Rectangle
+	var found bool
+	for _, f := range m.free {
+		if f.Dx() < width || f.Dy() < height {
+			continue
+		}
+		dx := f.Dx() - width
+		dy := f.Dy() - height
+		short, long := dx, dy
+		if short > long {
+			short, long = long, short
+		}
+		if short < bestShort || (short == bestShort && long < bestLong) {
+			bestShort = short
+			bestLong = long
+			best = image.Rect(f.Min.X, f.Min.Y, f.Min.X+width, f.Min.Y+height)
+			found = true
+		}
+	}
+	if !found {
+		return image.Rectangle{}, false
+	}
+
+	m.place(best)
+	return best, true
+}
+
+func (m *maxRects) place(used image.Rectangle) {
+	// Split all the free rectangles overlapping with the used rectangle.
+	var newFree []image.Rectangle
+	for _, f := range m.free {
+		if !f.Overlaps(used) {
+			newFree = append(newFree, f)
+			continue
+		}
+		if used.Min.X > f.Min.X {
+			newFree = append(newFree, image.Rect(f.Min.X, f.Min.Y, used.Min.X, f.Max.Y))
+		}
+		if used.Max.X < f.Max.X {
+			newFree = append(newFree, image.Rect(used.Max.X, f.Min.Y, f.Max.X, f.Max.Y))
+		}
+		if used.Min.Y > f.Min.Y {
+			newFree = append(newFree, image.Rect(f.Min.X, f.Min.Y, f.Max.X, used.Min.Y))
+		}
+		if used.Max.Y < f.Max.Y {
+			newFree = append(newFree, image.Rect(f.Min.X, used.Max.Y, f.Max.X, f.Max.Y))
+		}
+	}
+
+	// Remove the free rectangles contained by other free rectangles.
+	m.free = m.free[:0]
+	for i, f := range newFree {
+		var contained bool
+		for j, g := range newFree {
+			if i == j || !f.In(g) {
+				continue
+			}
+			// When the two rectangles are the same, keep only the first one.
+			if f.Eq(g) && i < j {
+				continue
+			}
+			contained = true
+			break
+		}
+		if !contained {
+			m.free = append(m.free, f)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spritesheet
+
+import (
+	"image"
+	"testing"
+)
+
+func TestMaxRects(t *testing.T) {
+	m := newMaxRects(64, 64)
+	page := image.Rect(0, 0, 64, 64)
+
+	var used []image.Rectangle
+	for _, s := range []image.Point{{32, 32}, {32, 32}, {64, 16}, {16, 16}, {16, 16}, {8, 8}, {8, 8}} {
+		r, ok := m.insert(s.X, s.Y)
+		if !ok {
+			t.Fatalf("insert(%d, %d) failed", s.X, s.Y)
+		}
+		if r.Size() != s {
+			t.Errorf("got: %v, want: size %v", r, s)
+		}
+		if !r.In(page) {
+			t.Errorf("%v is out of the page", r)
+		}
+		for _, u := range used {
+			if r.Overlaps(u) {
+				t.Errorf("%v overlaps with %v", r, u)
+			}
+		}
+		used = append(used, r)
+	}
+
+	if _, ok := m.insert(64, 64); ok {
+		t.Errorf("insert(64, 64) must fail")
+	}
+}
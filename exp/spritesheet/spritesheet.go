@@ -0,0 +1,255 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spritesheet provides a builder to pack many small images into sprite sheets at runtime.
+// This package is experimental and the API might be changed in the future.
+//
+// Drawing sub-images of the same sprite sheet page is batched into one draw call.
+package spritesheet
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Sprite represents an image in a sprite sheet.
+type Sprite struct {
+	// Image is a sub-image of a page.
+	Image *ebiten.Image
+
+	// Rotated reports whether Image is rotated by 90 degrees clockwise in the page.
+	// Rotated can be true only for a sprite sheet loaded from an external tool.
+	Rotated bool
+
+	// Offset is the position of Image in the original image before trimming.
+	Offset image.Point
+
+	// SourceSize is the size of the original image before trimming.
+	SourceSize image.Point
+}
+
+// Sheet represents a sprite sheet, a set of pages and sprites in them.
+type Sheet struct {
+	pages   []*ebiten.Image
+	sprites map[string]*Sprite
+	names   []string
+}
+
+// Pages returns the pages of the sprite sheet.
+func (s *Sheet) Pages() []*ebiten.Image {
+	return s.pages
+}
+
+// Sprite returns the sprite with the given name.
+// Sprite returns nil if there is no sprite with the name.
+func (s *Sheet) Sprite(name string) *Sprite {
+	return s.sprites[name]
+}
+
+// Image returns the sprite image with the given name.
+// Image returns nil if there is no sprite with the name.
+func (s *Sheet) Image(name string) *ebiten.Image {
+	sprite, ok := s.sprites[name]
+	if !ok {
+		return nil
+	}
+	return sprite.Image
+}
+
+// Names returns the sprites' names in the added order.
+func (s *Sheet) Names() []string {
+	return s.names
+}
+
+// Deallocate deallocates all the pages.
+func (s *Sheet) Deallocate() {
+	for _, p := range s.pages {
+		p.Deallocate()
+	}
+}
+
+func (s *Sheet) addSprite(name string, sprite *Sprite) error {
+	if s.sprites == nil {
+		s.sprites = map[string]*Sprite{}
+	}
+	if _, ok := s.sprites[name]; ok {
+		return fmt.Errorf("spritesheet: duplicated name: %s", name)
+	}
+	s.sprites[name] = sprite
+	s.names = append(s.names, name)
+	return nil
+}
+
+// BuilderOptions represents options for NewBuilder.
+type BuilderOptions struct {
+	// PageWidth is the width of a page.
+	//
+	// The default (zero) value is 2048.
+	PageWidth int
+
+	// PageHeight is the height of a page.
+	//
+	// The default (zero) value is 2048.
+	PageHeight int
+
+	// Padding is the number of transparent pixels between sprites.
+	// Padding prevents sprites from bleeding into the neighbors with linear filtering.
+	//
+	// The default (zero) value is 0.
+	Padding int
+}
+
+// Builder packs images into pages of a sprite sheet.
+type Builder struct {
+	pageWidth  int
+	pageHeight int
+	padding    int
+
+	entries []builderEntry
+}
+
+type builderEntry struct {
+	name string
+	img  image.Image
+}
+
+// NewBuilder creates a new Builder.
+// If options is nil, the default values are used.
+func NewBuilder(options *BuilderOptions) *Builder {
+	if options == nil {
+		options = &BuilderOptions{}
+	}
+	b := &Builder{
+		pageWidth:  options.PageWidth,
+		pageHeight: options.PageHeight,
+		padding:    options.Padding,
+	}
+	if b.pageWidth == 0 {
+		b.pageWidth = 2048
+	}
+	if b.pageHeight == 0 {
+		b.pageHeight = 2048
+	}
+	return b
+}
+
+// Add adds an image with a name.
+// The image is not read until Build is called.
+func (b *Builder) Add(name string, img image.Image) {
+	b.entries = append(b.entries, builderEntry{
+		name: name,
+		img:  img,
+	})
+}
+
+// Build packs all the added images into pages, and returns a sprite sheet.
+//
+// Build returns an error when an image is larger than a page, or names are duplicated.
+func (b *Builder) Build() (*Sheet, error) {
+	names := map[string]struct{}{}
+	for _, e := range b.entries {
+		if _, ok := names[e.name]; ok {
+			return nil, fmt.Errorf("spritesheet: duplicated name: %s", e.name)
+		}
+		names[e.name] = struct{}{}
+	}
+
+	// Packing larger images first makes the result better.
+	order := make([]int, len(b.entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		si := b.entries[order[i]].img.Bounds().Size()
+		sj := b.entries[order[j]].img.Bounds().Size()
+		return max(si.X, si.Y) > max(sj.X, sj.Y)
+	})
+
+	type placement struct {
+		page   int
+		region image.Rectangle
+	}
+	placements := make([]placement, len(b.entries))
+	var packers []*maxRects
+	for _, idx := range order {
+		e := b.entries[idx]
+		size := e.img.Bounds().Size()
+		w := size.X + b.padding
+		h := size.Y + b.padding
+		if size.X > b.pageWidth || size.Y > b.pageHeight {
+			return nil, fmt.Errorf("spritesheet: the image %s (%d x %d) is larger than a page (%d x %d)", e.name, size.X, size.Y, b.pageWidth, b.pageHeight)
+		}
+
+		var placed bool
+		for i, p := range packers {
+			r, ok := p.insert(w, h)
+			if !ok {
+				continue
+			}
+			placements[idx] = placement{page: i, region: r}
+			placed = true
+			break
+		}
+		if placed {
+			continue
+		}
+
+		// The padding on the right and bottom edges of a page is not needed.
+		p := newMaxRects(b.pageWidth+b.padding, b.pageHeight+b.padding)
+		r, ok := p.insert(w, h)
+		if !ok {
+			panic("spritesheet: inserting an image into a new page must succeed")
+		}
+		packers = append(packers, p)
+		placements[idx] = placement{page: len(packers) - 1, region: r}
+	}
+
+	pixels := make([]*image.RGBA, len(packers))
+	for i := range pixels {
+		pixels[i] = image.NewRGBA(image.Rect(0, 0, b.pageWidth, b.pageHeight))
+	}
+	for i, e := range b.entries {
+		p := placements[i]
+		r := image.Rectangle{Min: p.region.Min, Max: p.region.Min.Add(e.img.Bounds().Size())}
+		draw.Draw(pixels[p.page], r, e.img, e.img.Bounds().Min, draw.Src)
+		placements[i].region = r
+	}
+
+	s := &Sheet{}
+	for _, p := range pixels {
+		s.pages = append(s.pages, ebiten.NewImageFromImage(p))
+	}
+	for i, e := range b.entries {
+		p := placements[i]
+		if err := s.addSprite(e.name, &Sprite{
+			Image:      s.pages[p.page].SubImage(p.region).(*ebiten.Image),
+			SourceSize: p.region.Size(),
+		}); err != nil {
+			s.Deallocate()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func max(a, b int) int {
+	if a < b {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spritesheet
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type texturePackerRect struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+type texturePackerSize struct {
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+type texturePackerFrame struct {
+	Filename         string            `json:"filename"`
+	Frame            texturePackerRect `json:"frame"`
+	Rotated          bool              `json:"rotated"`
+	Trimmed          bool              `json:"trimmed"`
+	SpriteSourceSize texturePackerRect `json:"spriteSourceSize"`
+	SourceSize       texturePackerSize `json:"sourceSize"`
+}
+
+type texturePackerFile struct {
+	Frames json.RawMessage `json:"frames"`
+}
+
+// NewSheetFromTexturePackerJSON creates a sprite sheet from a JSON file exported by TexturePacker and its page image.
+//
+// Both the JSON (Hash) format and the JSON (Array) format are supported.
+// The sprites in a hash are sorted by their names.
+//
+// If a sprite is rotated, the sprite's image is also rotated by 90 degrees clockwise in the page.
+// See Sprite's Rotated.
+func NewSheetFromTexturePackerJSON(data []byte, page *ebiten.Image) (*Sheet, error) {
+	var f texturePackerFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("spritesheet: parsing JSON failed: %w", err)
+	}
+
+	var frames []texturePackerFrame
+	if err := json.Unmarshal(f.Frames, &frames); err != nil {
+		hash := map[string]texturePackerFrame{}
+		if err := json.Unmarshal(f.Frames, &hash); err != nil {
+			return nil, fmt.Errorf("spritesheet: parsing frames failed: %w", err)
+		}
+		for name, frame := range hash {
+			frame.Filename = name
+			frames = append(frames, frame)
+		}
+		sort.Slice(frames, func(i, j int) bool {
+			return frames[i].Filename < frames[j].Filename
+		})
+	}
+
+	s := &Sheet{
+		pages: []*ebiten.Image{page},
+	}
+	for _, frame := range frames {
+		r := frame.Frame
+		w, h := r.W, r.H
+		// The size in the page is swapped for a rotated sprite.
+		if frame.Rotated {
+			w, h = h, w
+		}
+		region := image.Rect(r.X, r.Y, r.X+w, r.Y+h).Add(page.Bounds().Min)
+		if !region.In(page.Bounds()) {
+			return nil, fmt.Errorf("spritesheet: the frame %s %v is out of the page %v", frame.Filename, region, page.Bounds())
+		}
+
+		sourceSize := image.Pt(frame.SourceSize.W, frame.SourceSize.H)
+		if sourceSize == (image.Point{}) {
+			sourceSize = image.Pt(r.W, r.H)
+		}
+		sprite := &Sprite{
+			Image:      page.SubImage(region).(*ebiten.Image),
+			Rotated:    frame.Rotated,
+			SourceSize: sourceSize,
+		}
+		if frame.Trimmed {
+			sprite.Offset = image.Pt(frame.SpriteSourceSize.X, frame.SpriteSourceSize.Y)
+		}
+		if err := s.addSprite(frame.Filename, sprite); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spritesheet_test
+
+import (
+	"image"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/spritesheet"
+)
+
+type texturePackerSpriteForTesting struct {
+	Name       string
+	Bounds     image.Rectangle
+	Rotated    bool
+	Offset     image.Point
+	SourceSize image.Point
+}
+
+func texturePackerSpritesForTesting(sheet *spritesheet.Sheet) []texturePackerSpriteForTesting {
+	var sprites []texturePackerSpriteForTesting
+	for _, name := range sheet.Names() {
+		s := sheet.Sprite(name)
+		sprites = append(sprites, texturePackerSpriteForTesting{
+			Name:       name,
+			Bounds:     s.Image.Bounds(),
+			Rotated:    s.Rotated,
+			Offset:     s.Offset,
+			SourceSize: s.SourceSize,
+		})
+	}
+	return sprites
+}
+
+func TestNewSheetFromTexturePackerJSONHash(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "texturepacker_hash.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	page := ebiten.NewImage(64, 64)
+	sheet, err := spritesheet.NewSheetFromTexturePackerJSON(data, page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sheet.Deallocate()
+
+	if got, want := len(sheet.Pages()), 1; got != want {
+		t.Errorf("len(Pages()): got: %d, want: %d", got, want)
+	}
+
+	// The sprites in a hash are sorted by their names.
+	got := texturePackerSpritesForTesting(sheet)
+	want := []texturePackerSpriteForTesting{
+		{
+			Name:       "coin.png",
+			Bounds:     image.Rect(36, 2, 52, 18),
+			SourceSize: image.Pt(16, 16),
+		},
+		{
+			Name:       "player.png",
+			Bounds:     image.Rect(2, 2, 34, 50),
+			Offset:     image.Pt(4, 0),
+			SourceSize: image.Pt(40, 48),
+		},
+		{
+			// The size in the page is swapped for a rotated sprite.
+			Name:       "sword.png",
+			Bounds:     image.Rect(36, 20, 44, 44),
+			Rotated:    true,
+			SourceSize: image.Pt(24, 8),
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+
+	if sheet.Image("coin.png") != sheet.Sprite("coin.png").Image {
+		t.Errorf("Image and Sprite's Image must be the same")
+	}
+	if sheet.Sprite("missing.png") != nil {
+		t.Errorf("Sprite for a missing name must be nil")
+	}
+}
+
+func TestNewSheetFromTexturePackerJSONArray(t *testing.T) {
+	const data = `{"frames": [
+	{"filename": "b.png", "frame": {"x":0,"y":0,"w":8,"h":8}, "rotated": false, "trimmed": false, "spriteSourceSize": {"x":0,"y":0,"w":8,"h":8}, "sourceSize": {"w":8,"h":8}},
+	{"filename": "a.png", "frame": {"x":8,"y":0,"w":4,"h":4}, "rotated": false, "trimmed": false}
+]}`
+
+	page := ebiten.NewImage(16, 16)
+	sheet, err := spritesheet.NewSheetFromTexturePackerJSON([]byte(data), page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sheet.Deallocate()
+
+	// The sprites in an array keep the order.
+	got := texturePackerSpritesForTesting(sheet)
+	want := []texturePackerSpriteForTesting{
+		{
+			Name:       "b.png",
+			Bounds:     image.Rect(0, 0, 8, 8),
+			SourceSize: image.Pt(8, 8),
+		},
+		{
+			// A missing source size is the frame size.
+			Name:       "a.png",
+			Bounds:     image.Rect(8, 0, 12, 4),
+			SourceSize: image.Pt(4, 4),
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+}
+
+func TestNewSheetFromTexturePackerJSONSubImagePage(t *testing.T) {
+	const data = `{"frames": [{"filename": "a.png", "frame": {"x":0,"y":0,"w":4,"h":4}}]}`
+
+	img := ebiten.NewImage(16, 16)
+	defer img.Deallocate()
+	page := img.SubImage(image.Rect(8, 8, 16, 16)).(*ebiten.Image)
+
+	sheet, err := spritesheet.NewSheetFromTexturePackerJSON([]byte(data), page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sheet.Image("a.png").Bounds(), image.Rect(8, 8, 12, 12); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestNewSheetFromTexturePackerJSONError(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Data string
+	}{
+		{
+			Name: "broken JSON",
+			Data: `{"frames": `,
+		},
+		{
+			Name: "invalid frames",
+			Data: `{"frames": 1}`,
+		},
+		{
+			Name: "out of the page",
+			Data: `{"frames": [{"filename": "a.png", "frame": {"x":12,"y":0,"w":8,"h":8}}]}`,
+		},
+		{
+			Name: "rotated out of the page",
+			Data: `{"frames": [{"filename": "a.png", "frame": {"x":0,"y":0,"w":4,"h":20}, "rotated": true}]}`,
+		},
+		{
+			Name: "duplicated names",
+			Data: `{"frames": [{"filename": "a.png", "frame": {"x":0,"y":0,"w":4,"h":4}}, {"filename": "a.png", "frame": {"x":4,"y":0,"w":4,"h":4}}]}`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			page := ebiten.NewImage(16, 16)
+			defer page.Deallocate()
+			if _, err := spritesheet.NewSheetFromTexturePackerJSON([]byte(tc.Data), page); err == nil {
+				t.Errorf("NewSheetFromTexturePackerJSON must return an error")
+			}
+		})
+	}
+}
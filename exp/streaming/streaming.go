@@ -0,0 +1,455 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package streaming provides a streamer that keeps large images resident on the GPU based on hints like priorities
+// and requested resolution levels.
+// This package is experimental and the API might be changed in the future.
+//
+// This is useful for open-world 2D games that have more images than the GPU memory can hold at once.
+// A game gives a hint for each image at every tick, typically with HintForCamera, and the streamer loads
+// images with higher priorities first and evicts images with lower priorities within the memory budget.
+//
+// When a coarser level is requested for a resident image, the streamer derives it from the resident image on the GPU
+// with Ebitengine's mipmaps instead of loading the image again.
+package streaming
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+
+	xdraw "golang.org/x/image/draw"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/camera"
+	"github.com/hajimehoshi/ebiten/v2/exp/vecmath"
+)
+
+// Loader loads the image in its full resolution.
+// Loader is called on a goroutine other than the game's goroutine.
+type Loader func() (image.Image, error)
+
+// Hint represents a streaming hint of an image.
+type Hint struct {
+	// Priority is the priority of the image. An image with a higher priority is loaded first, and is evicted last.
+	// An image with 0 priority is not needed, and it can be evicted anytime.
+	Priority float64
+
+	// Level is the requested resolution level.
+	// Level 0 is the full resolution, and level n is 1/2^n of the full resolution in each direction.
+	Level int
+}
+
+// HintForCamera returns a hint for an image placed at worldRect in the world of the camera.
+//
+// The priority is higher when the image is visible or close to the visible region, and is 0 when the image is far
+// from the visible region more than the viewport size. The level is determined by the camera's zoom.
+func HintForCamera(c *camera.Camera, worldRect vecmath.Rect) Hint {
+	vr := c.VisibleRect()
+	diag := vr.Size().Len()
+	if diag == 0 {
+		return Hint{}
+	}
+
+	// The distance between the two rectangles. This is 0 when they overlap.
+	dx := math.Max(0, math.Max(vr.Min.X-worldRect.Max.X, worldRect.Min.X-vr.Max.X))
+	dy := math.Max(0, math.Max(vr.Min.Y-worldRect.Max.Y, worldRect.Min.Y-vr.Max.Y))
+	d := math.Hypot(dx, dy) / diag
+	if d >= 1 {
+		return Hint{}
+	}
+
+	var level int
+	if z := c.Zoom(); z < 1 {
+		level = int(math.Floor(math.Log2(1 / z)))
+	}
+	return Hint{
+		Priority: 1 - d,
+		Level:    level,
+	}
+}
+
+// Options represents options for NewStreamer.
+type Options struct {
+	// BudgetBytes is the maximum total bytes of the resident images.
+	//
+	// The default (zero) value is 256 MiB.
+	BudgetBytes int64
+
+	// MaxConcurrentLoads is the maximum number of images loaded concurrently.
+	//
+	// The default (zero) value is 2.
+	MaxConcurrentLoads int
+}
+
+type entry struct {
+	name   string
+	width  int
+	height int
+	loader Loader
+	hint   Hint
+
+	image *ebiten.Image
+	level int
+
+	loading bool
+
+	// failures is the number of the consecutive failures of loading.
+	failures int
+
+	// retryTick is the tick on and after which loading can be retried after a failure.
+	retryTick int64
+}
+
+func (e *entry) levelSize(level int) (int, int) {
+	w, h := e.width>>level, e.height>>level
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return w, h
+}
+
+func (e *entry) maxLevel() int {
+	var l int
+	for (e.width>>(l+1)) > 0 && (e.height>>(l+1)) > 0 {
+		l++
+	}
+	return l
+}
+
+func (e *entry) desiredLevel() int {
+	l := e.hint.Level
+	if l < 0 {
+		l = 0
+	}
+	if m := e.maxLevel(); l > m {
+		l = m
+	}
+	return l
+}
+
+func (e *entry) residentBytes() int64 {
+	if e.image == nil {
+		return 0
+	}
+	return e.levelBytes(e.level)
+}
+
+func (e *entry) levelBytes(level int) int64 {
+	w, h := e.levelSize(level)
+	return 4 * int64(w) * int64(h)
+}
+
+// needsLoading reports whether the image needs to be loaded for the current hint.
+func (e *entry) needsLoading() bool {
+	return e.hint.Priority > 0 && !e.loading && (e.image == nil || e.level > e.desiredLevel())
+}
+
+const (
+	// minRetryTicks is the number of ticks to wait before retrying loading after the first failure.
+	// The wait is doubled at every consecutive failure up to maxRetryTicks.
+	minRetryTicks = 30
+
+	// maxRetryTicks is the maximum number of ticks to wait before retrying loading.
+	maxRetryTicks = 30 * 60
+)
+
+// retryTicks returns the number of ticks to wait after the given number of consecutive failures.
+func retryTicks(failures int) int64 {
+	t := int64(minRetryTicks)
+	for i := 1; i < failures && t < maxRetryTicks; i++ {
+		t *= 2
+	}
+	if t > maxRetryTicks {
+		t = maxRetryTicks
+	}
+	return t
+}
+
+type loadResult struct {
+	entry *entry
+	level int
+	pix   *image.RGBA
+	err   error
+}
+
+// Streamer manages the residency of images.
+//
+// A Streamer's methods must be called from the game's Update or Draw.
+type Streamer struct {
+	budget             int64
+	maxConcurrentLoads int
+
+	entries map[string]*entry
+	loading int
+	results chan loadResult
+
+	tick int64
+}
+
+// NewStreamer creates a new streamer.
+// If options is nil, the default values are used.
+func NewStreamer(options *Options) *Streamer {
+	if options == nil {
+		options = &Options{}
+	}
+	s := &Streamer{
+		budget:             options.BudgetBytes,
+		maxConcurrentLoads: options.MaxConcurrentLoads,
+		entries:            map[string]*entry{},
+	}
+	if s.budget == 0 {
+		s.budget = 256 * 1024 * 1024
+	}
+	if s.maxConcurrentLoads == 0 {
+		s.maxConcurrentLoads = 2
+	}
+	s.results = make(chan loadResult, s.maxConcurrentLoads)
+	return s
+}
+
+// Add adds an image with the given name, the full resolution size, and the loader.
+// The image is not loaded until a hint with a positive priority is given.
+//
+// If an image with the same name already exists, Add panics.
+func (s *Streamer) Add(name string, width, height int, loader Loader) {
+	if _, ok := s.entries[name]; ok {
+		panic(fmt.Sprintf("streaming: the image %q already exists", name))
+	}
+	s.entries[name] = &entry{
+		name:   name,
+		width:  width,
+		height: height,
+		loader: loader,
+	}
+}
+
+// Remove removes the image with the given name, and deallocates its resident image.
+func (s *Streamer) Remove(name string) {
+	e, ok := s.entries[name]
+	if !ok {
+		return
+	}
+	if e.image != nil {
+		e.image.Deallocate()
+		e.image = nil
+	}
+	delete(s.entries, name)
+}
+
+// SetHint sets the streaming hint of the image with the given name.
+func (s *Streamer) SetHint(name string, hint Hint) {
+	if e, ok := s.entries[name]; ok {
+		e.hint = hint
+	}
+}
+
+// Image returns the resident image with the given name and its resolution level.
+// The resolution level might be different from the requested level while loading.
+//
+// To draw a resident image at the full resolution size, scale it by 2^level.
+//
+// Image returns nil if the image is not resident.
+func (s *Streamer) Image(name string) (*ebiten.Image, int) {
+	e, ok := s.entries[name]
+	if !ok || e.image == nil {
+		return nil, 0
+	}
+	return e.image, e.level
+}
+
+// ResidentBytes returns the total bytes of the resident images.
+func (s *Streamer) ResidentBytes() int64 {
+	var n int64
+	for _, e := range s.entries {
+		n += e.residentBytes()
+	}
+	return n
+}
+
+// Update applies the loaded images, evicts images within the budget, and starts loading images based on the hints.
+// Update should be called every tick, e.g. at the game's Update.
+//
+// If loading an image fails, the image is not loaded again for a while, and the wait gets longer at every
+// consecutive failure.
+//
+// Update returns the first error of the loaders since the last Update.
+func (s *Streamer) Update() error {
+	s.tick++
+
+	var err error
+	for done := false; !done; {
+		select {
+		case r := <-s.results:
+			s.loading--
+			if e := s.apply(r); e != nil && err == nil {
+				err = e
+			}
+		default:
+			done = true
+		}
+	}
+
+	entries := make([]*entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+	// Sort the entries by priority in the descending order. Use the names to make the order deterministic.
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].hint.Priority != entries[j].hint.Priority {
+			return entries[i].hint.Priority > entries[j].hint.Priority
+		}
+		return entries[i].name < entries[j].name
+	})
+
+	// Derive a coarser level from a resident image instead of loading it again.
+	for _, e := range entries {
+		if e.hint.Priority <= 0 || e.image == nil {
+			continue
+		}
+		if l := e.desiredLevel(); e.level < l {
+			s.downsample(e, l)
+		}
+	}
+
+	// Evict images with the lowest priorities first.
+	resident := s.ResidentBytes()
+	for i := len(entries) - 1; i >= 0 && resident > s.budget; i-- {
+		e := entries[i]
+		if e.image == nil {
+			continue
+		}
+		resident -= e.residentBytes()
+		e.image.Deallocate()
+		e.image = nil
+	}
+
+	// An image that is not needed is evicted only when the budget is insufficient for the images to load.
+	required := s.requiredBytes(entries)
+	for i := len(entries) - 1; i >= 0 && resident+required > s.budget; i-- {
+		e := entries[i]
+		if e.hint.Priority > 0 {
+			break
+		}
+		if e.image == nil {
+			continue
+		}
+		resident -= e.residentBytes()
+		e.image.Deallocate()
+		e.image = nil
+	}
+
+	// Start loading images with the highest priorities first.
+	for _, e := range entries {
+		if s.loading >= s.maxConcurrentLoads {
+			break
+		}
+		if e.hint.Priority <= 0 {
+			break
+		}
+		if !e.needsLoading() || s.tick < e.retryTick {
+			continue
+		}
+		level := e.desiredLevel()
+		if resident-e.residentBytes()+e.levelBytes(level) > s.budget {
+			continue
+		}
+		s.startLoading(e, level)
+	}
+
+	return err
+}
+
+// requiredBytes returns the additional bytes required for the images to be loaded next.
+func (s *Streamer) requiredBytes(entries []*entry) int64 {
+	var n int64
+	loads := s.loading
+	for _, e := range entries {
+		if loads >= s.maxConcurrentLoads {
+			break
+		}
+		if e.hint.Priority <= 0 {
+			break
+		}
+		if !e.needsLoading() || s.tick < e.retryTick {
+			continue
+		}
+		n += e.levelBytes(e.desiredLevel()) - e.residentBytes()
+		loads++
+	}
+	return n
+}
+
+// downsample replaces the resident image with the given coarser level.
+func (s *Streamer) downsample(e *entry, level int) {
+	w, h := e.levelSize(level)
+	b := e.image.Bounds()
+	img := ebiten.NewImage(w, h)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(float64(w)/float64(b.Dx()), float64(h)/float64(b.Dy()))
+	// With FilterLinear, Ebitengine uses mipmaps to scale the image down.
+	op.Filter = ebiten.FilterLinear
+	img.DrawImage(e.image, op)
+	e.image.Deallocate()
+	e.image = img
+	e.level = level
+}
+
+func (s *Streamer) startLoading(e *entry, level int) {
+	e.loading = true
+	s.loading++
+
+	loader := e.loader
+	w, h := e.levelSize(level)
+	go func() {
+		img, err := loader()
+		if err != nil {
+			s.results <- loadResult{entry: e, level: level, err: err}
+			return
+		}
+		dst := image.NewRGBA(image.Rect(0, 0, w, h))
+		if b := img.Bounds(); b.Dx() == w && b.Dy() == h {
+			xdraw.Draw(dst, dst.Bounds(), img, b.Min, xdraw.Src)
+		} else {
+			xdraw.ApproxBiLinear.Scale(dst, dst.Bounds(), img, b, xdraw.Src, nil)
+		}
+		s.results <- loadResult{entry: e, level: level, pix: dst}
+	}()
+}
+
+func (s *Streamer) apply(r loadResult) error {
+	e := r.entry
+	e.loading = false
+	if r.err != nil {
+		e.failures++
+		e.retryTick = s.tick + retryTicks(e.failures)
+		return fmt.Errorf("streaming: loading the image %q failed: %w", e.name, r.err)
+	}
+	e.failures = 0
+	e.retryTick = 0
+	// The entry might be removed while loading.
+	if s.entries[e.name] != e {
+		return nil
+	}
+	if e.image != nil {
+		e.image.Deallocate()
+	}
+	e.image = ebiten.NewImageFromImage(r.pix)
+	e.level = r.level
+	return nil
+}
@@ -0,0 +1,241 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming_test
+
+import (
+	"errors"
+	"image"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/camera"
+	"github.com/hajimehoshi/ebiten/v2/exp/streaming"
+	"github.com/hajimehoshi/ebiten/v2/exp/vecmath"
+)
+
+func TestHintForCamera(t *testing.T) {
+	// The visible region is (-160, -120)-(160, 120), and its diagonal is 400.
+	c := camera.New(vecmath.V(320, 240))
+
+	testCases := []struct {
+		Name      string
+		Zoom      float64
+		WorldRect vecmath.Rect
+		Want      streaming.Hint
+	}{
+		{
+			Name:      "visible",
+			Zoom:      1,
+			WorldRect: vecmath.R(0, 0, 10, 10),
+			Want:      streaming.Hint{Priority: 1},
+		},
+		{
+			Name:      "near",
+			Zoom:      1,
+			WorldRect: vecmath.R(200, 0, 240, 10),
+			Want:      streaming.Hint{Priority: 0.9},
+		},
+		{
+			Name:      "far",
+			Zoom:      1,
+			WorldRect: vecmath.R(1000, 0, 1010, 10),
+			Want:      streaming.Hint{},
+		},
+		{
+			Name:      "zoomed out",
+			Zoom:      0.25,
+			WorldRect: vecmath.R(0, 0, 10, 10),
+			Want:      streaming.Hint{Priority: 1, Level: 2},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			c.SetZoom(tc.Zoom)
+			got := streaming.HintForCamera(c, tc.WorldRect)
+			if math.Abs(got.Priority-tc.Want.Priority) > 1e-9 || got.Level != tc.Want.Level {
+				t.Errorf("got: %v, want: %v", got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestRetryTicks(t *testing.T) {
+	var prev int64
+	for i := 1; i < 20; i++ {
+		got := streaming.RetryTicksForTesting(i)
+		if got < prev {
+			t.Errorf("RetryTicks(%d): got: %d, must be >= %d", i, got, prev)
+		}
+		if got > 30*60 {
+			t.Errorf("RetryTicks(%d): got: %d, must be <= %d", i, got, 30*60)
+		}
+		prev = got
+	}
+	if got, want := streaming.RetryTicksForTesting(1), int64(30); got != want {
+		t.Errorf("RetryTicks(1): got: %d, want: %d", got, want)
+	}
+	if got, want := streaming.RetryTicksForTesting(2), int64(60); got != want {
+		t.Errorf("RetryTicks(2): got: %d, want: %d", got, want)
+	}
+}
+
+// updateUntil calls Update until cond returns true, and returns the errors of Update.
+func updateUntil(t *testing.T, s *streaming.Streamer, cond func() bool) []error {
+	t.Helper()
+	var errs []error
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout")
+		}
+		if err := s.Update(); err != nil {
+			errs = append(errs, err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return errs
+}
+
+func solidLoader(width, height int) streaming.Loader {
+	return func() (image.Image, error) {
+		return image.NewRGBA(image.Rect(0, 0, width, height)), nil
+	}
+}
+
+func TestStreamerBackoff(t *testing.T) {
+	s := streaming.NewStreamer(nil)
+	var calls atomic.Int32
+	s.Add("a", 4, 4, func() (image.Image, error) {
+		calls.Add(1)
+		return nil, errors.New("failed")
+	})
+	s.SetHint("a", streaming.Hint{Priority: 1})
+
+	if err := s.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if !s.IsLoadingForTesting("a") {
+		t.Fatalf("loading must start")
+	}
+	errs := updateUntil(t, s, func() bool {
+		return !s.IsLoadingForTesting("a")
+	})
+	if len(errs) != 1 {
+		t.Fatalf("the number of errors: got: %d, want: 1", len(errs))
+	}
+
+	// Loading is not retried until the backoff expires.
+	for i := 0; i < int(streaming.RetryTicksForTesting(1))-1; i++ {
+		if err := s.Update(); err != nil {
+			t.Fatal(err)
+		}
+		if s.IsLoadingForTesting("a") {
+			t.Fatalf("loading must not be retried at the %d-th tick", i+1)
+		}
+	}
+	if err := s.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if !s.IsLoadingForTesting("a") {
+		t.Errorf("loading must be retried after the backoff")
+	}
+	updateUntil(t, s, func() bool {
+		return !s.IsLoadingForTesting("a")
+	})
+	if got, want := calls.Load(), int32(2); got != want {
+		t.Errorf("the number of loader calls: got: %d, want: %d", got, want)
+	}
+}
+
+func TestStreamerEviction(t *testing.T) {
+	// The budget can hold two 4x4 images.
+	const imageBytes = 4 * 4 * 4
+	s := streaming.NewStreamer(&streaming.Options{
+		BudgetBytes: 2 * imageBytes,
+	})
+	for _, name := range []string{"a", "b", "c"} {
+		s.Add(name, 4, 4, solidLoader(4, 4))
+	}
+	isResident := func(name string) bool {
+		img, _ := s.Image(name)
+		return img != nil
+	}
+
+	s.SetHint("a", streaming.Hint{Priority: 1})
+	updateUntil(t, s, func() bool {
+		return isResident("a")
+	})
+
+	// An image with 0 priority is kept while the budget is sufficient.
+	s.SetHint("a", streaming.Hint{})
+	s.SetHint("b", streaming.Hint{Priority: 1})
+	updateUntil(t, s, func() bool {
+		return isResident("b")
+	})
+	if !isResident("a") {
+		t.Errorf("an image with 0 priority must not be evicted under the budget")
+	}
+
+	// An image with 0 priority is evicted when the budget is needed for another image.
+	s.SetHint("c", streaming.Hint{Priority: 1})
+	updateUntil(t, s, func() bool {
+		return isResident("c")
+	})
+	if isResident("a") {
+		t.Errorf("an image with 0 priority must be evicted for another image")
+	}
+	if !isResident("b") {
+		t.Errorf("an image with a positive priority must not be evicted under the budget")
+	}
+	if got := s.ResidentBytes(); got > 2*imageBytes {
+		t.Errorf("ResidentBytes(): got: %d, must be <= %d", got, 2*imageBytes)
+	}
+}
+
+func TestStreamerDownsample(t *testing.T) {
+	s := streaming.NewStreamer(nil)
+	var calls atomic.Int32
+	s.Add("a", 8, 8, func() (image.Image, error) {
+		calls.Add(1)
+		return image.NewRGBA(image.Rect(0, 0, 8, 8)), nil
+	})
+	s.SetHint("a", streaming.Hint{Priority: 1})
+	updateUntil(t, s, func() bool {
+		img, _ := s.Image("a")
+		return img != nil
+	})
+
+	// A coarser level is derived from the resident image without loading it again.
+	s.SetHint("a", streaming.Hint{Priority: 1, Level: 1})
+	if err := s.Update(); err != nil {
+		t.Fatal(err)
+	}
+	img, level := s.Image("a")
+	if got, want := level, 1; got != want {
+		t.Errorf("level: got: %d, want: %d", got, want)
+	}
+	if got, want := img.Bounds().Size(), image.Pt(4, 4); got != want {
+		t.Errorf("size: got: %v, want: %v", got, want)
+	}
+	if s.IsLoadingForTesting("a") {
+		t.Errorf("a coarser level must not be loaded again")
+	}
+	if got, want := calls.Load(), int32(1); got != want {
+		t.Errorf("the number of loader calls: got: %d, want: %d", got, want)
+	}
+}
@@ -27,6 +27,7 @@ const (
 	_ATTR_TARGET_NOTCONVERTED = 0x03
 
 	_CFS_CANDIDATEPOS = 0x0040
+	_CFS_EXCLUDE      = 0x0080
 
 	_GCS_COMPATTR   = 0x0010
 	_GCS_COMPCLAUSE = 0x0020
@@ -0,0 +1,43 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput
+
+func (t *TextField) TypeForTesting(str string) {
+	t.edit(str, true)
+}
+
+func (t *TextField) PasteForTesting(str string) {
+	t.edit(str, false)
+}
+
+func (t *TextField) SelectAllForTesting() {
+	t.selectAll()
+}
+
+func (t *TextField) DeleteBackwardForTesting() {
+	t.deleteBackward()
+}
+
+func (t *TextField) DeleteForwardForTesting() {
+	t.deleteForward()
+}
+
+func (t *TextField) MoveLeftForTesting(extend bool) {
+	t.moveLeft(extend)
+}
+
+func (t *TextField) MoveRightForTesting(extend bool) {
+	t.moveRight(extend)
+}
@@ -0,0 +1,732 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"unicode"
+
+	"github.com/go-text/typesetting/segmenter"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// TextFieldOptions represents options for a TextField.
+type TextFieldOptions struct {
+	// Multiline reports whether the field accepts newline characters.
+	Multiline bool
+
+	// Wrap reports whether the lines are wrapped at the field's width.
+	// Wrap is valid only when Multiline is true.
+	Wrap bool
+
+	// TextColor is the color of the text and the caret.
+	//
+	// If TextColor is nil, black is used.
+	TextColor color.Color
+
+	// SelectionColor is the color of the selection's background.
+	//
+	// If SelectionColor is nil, translucent blue is used.
+	SelectionColor color.Color
+}
+
+type textFieldSnapshot struct {
+	text   string
+	anchor int
+	caret  int
+}
+
+// TextField is a text editing widget, which handles the caret, the selection, the clipboard, IME and undo,
+// and renders the text with the text package.
+//
+// TextField is built on Field. TextField supports only horizontal left-to-right faces so far.
+//
+// TextField handles the keyboard shortcuts with Control or Meta (Command):
+// A to select all, C to copy, X to cut, V to paste, Z to undo, and Shift+Z or Y to redo.
+// The clipboard is available only on desktops so far.
+//
+// TextField doesn't render its background or its border. Draw them before calling Draw.
+type TextField struct {
+	field   Field
+	face    text.Face
+	bounds  image.Rectangle
+	options TextFieldOptions
+
+	// anchor and caret are the both ends of the selection in bytes. caret is the moving end.
+	anchor int
+	caret  int
+
+	undoStack []textFieldSnapshot
+	redoStack []textFieldSnapshot
+
+	// typing reports whether the last edit was typing, which is merged with the next typing in the undo history.
+	typing bool
+
+	dragging bool
+	scrollX  float64
+	scrollY  float64
+
+	tick                 int
+	caretMovedAt         int
+	lastCompositionRect  image.Rectangle
+	compositionRectDirty bool
+}
+
+// NewTextField creates a new TextField rendered with the face in the bounds.
+//
+// If options is nil, the default options are used.
+func NewTextField(face text.Face, bounds image.Rectangle, options *TextFieldOptions) *TextField {
+	t := &TextField{
+		face:   face,
+		bounds: bounds,
+	}
+	if options != nil {
+		t.options = *options
+	}
+	if !t.options.Multiline {
+		t.options.Wrap = false
+	}
+	return t
+}
+
+// Bounds returns the bounds of the field.
+func (t *TextField) Bounds() image.Rectangle {
+	return t.bounds
+}
+
+// SetBounds sets the bounds of the field.
+func (t *TextField) SetBounds(bounds image.Rectangle) {
+	t.bounds = bounds
+}
+
+// Text returns the current text.
+// The returned value doesn't include compositing texts.
+func (t *TextField) Text() string {
+	return t.field.Text()
+}
+
+// SetText sets the text, puts the caret at the end, and clears the undo history.
+func (t *TextField) SetText(text string) {
+	if !t.options.Multiline {
+		text = removeNewlines(text)
+	}
+	t.setTextAndSelection(text, len(text), len(text))
+	t.undoStack = nil
+	t.redoStack = nil
+	t.typing = false
+}
+
+// Selection returns the current selection range in bytes.
+func (t *TextField) Selection() (start, end int) {
+	return t.field.Selection()
+}
+
+// SetSelection sets the selection range in bytes.
+// end is the moving end of the selection, where the caret is.
+func (t *TextField) SetSelection(start, end int) {
+	t.setTextAndSelection(t.field.Text(), start, end)
+	t.typing = false
+}
+
+// Focus focuses the field.
+func (t *TextField) Focus() {
+	t.field.Focus()
+}
+
+// Blur removes the focus from the field.
+func (t *TextField) Blur() {
+	t.field.Blur()
+}
+
+// IsFocused reports whether the field is focused or not.
+func (t *TextField) IsFocused() bool {
+	return t.field.IsFocused()
+}
+
+// CanUndo reports whether Undo can be done.
+func (t *TextField) CanUndo() bool {
+	return len(t.undoStack) > 0
+}
+
+// CanRedo reports whether Redo can be done.
+func (t *TextField) CanRedo() bool {
+	return len(t.redoStack) > 0
+}
+
+// Undo reverts the last edit.
+func (t *TextField) Undo() {
+	if len(t.undoStack) == 0 {
+		return
+	}
+	s := t.undoStack[len(t.undoStack)-1]
+	t.undoStack = t.undoStack[:len(t.undoStack)-1]
+	t.redoStack = append(t.redoStack, t.snapshot())
+	t.setTextAndSelection(s.text, s.anchor, s.caret)
+	t.typing = false
+}
+
+// Redo reapplies the last edit reverted by Undo.
+func (t *TextField) Redo() {
+	if len(t.redoStack) == 0 {
+		return
+	}
+	s := t.redoStack[len(t.redoStack)-1]
+	t.redoStack = t.redoStack[:len(t.redoStack)-1]
+	t.undoStack = append(t.undoStack, t.snapshot())
+	t.setTextAndSelection(s.text, s.anchor, s.caret)
+	t.typing = false
+}
+
+func (t *TextField) snapshot() textFieldSnapshot {
+	return textFieldSnapshot{
+		text:   t.field.Text(),
+		anchor: t.anchor,
+		caret:  t.caret,
+	}
+}
+
+func (t *TextField) setTextAndSelection(text string, anchor, caret int) {
+	anchor = clampIndex(anchor, len(text))
+	caret = clampIndex(caret, len(text))
+	t.anchor = anchor
+	t.caret = caret
+	if anchor > caret {
+		anchor, caret = caret, anchor
+	}
+	t.field.SetTextAndSelection(text, anchor, caret)
+	t.caretMovedAt = t.tick
+	t.compositionRectDirty = true
+}
+
+// edit replaces the selection with str, and records the previous state in the undo history.
+func (t *TextField) edit(str string, typing bool) {
+	if !t.options.Multiline {
+		str = removeNewlines(str)
+	}
+
+	if !typing || !t.typing {
+		t.undoStack = append(t.undoStack, t.snapshot())
+	}
+	t.redoStack = nil
+	t.typing = typing
+
+	start, end := t.field.Selection()
+	text := t.field.Text()
+	text = text[:start] + str + text[end:]
+	i := start + len(str)
+	t.setTextAndSelection(text, i, i)
+}
+
+func (t *TextField) deleteRange(start, end int) {
+	if start == end {
+		return
+	}
+	t.setTextAndSelection(t.field.Text(), start, end)
+	t.edit("", false)
+}
+
+func (t *TextField) moveCaret(caret int, extend bool) {
+	anchor := caret
+	if extend {
+		anchor = t.anchor
+	}
+	t.setTextAndSelection(t.field.Text(), anchor, caret)
+	t.typing = false
+}
+
+func clampIndex(i, length int) int {
+	if i < 0 {
+		return 0
+	}
+	if i > length {
+		return length
+	}
+	return i
+}
+
+func removeNewlines(str string) string {
+	return strings.NewReplacer("\r\n", "", "\n", "", "\r", "").Replace(str)
+}
+
+func (t *TextField) lineHeight() float64 {
+	m := t.face.Metrics()
+	return m.HLineGap + m.HAscent + m.HDescent
+}
+
+func (t *TextField) wrapOptions() *text.WrapOptions {
+	op := &text.WrapOptions{}
+	op.LineSpacing = t.lineHeight()
+	if t.options.Wrap {
+		op.Width = float64(t.bounds.Dx())
+	}
+	return op
+}
+
+// caretPosition returns the position of the caret at idx relative to the text's origin.
+// Unlike text.CaretPosition, the spaces at the end of a line are taken into account.
+func (t *TextField) caretPosition(lines []text.Line, str string, idx int) (x, y float64) {
+	if len(lines) == 0 {
+		return 0, 0
+	}
+	l := lines[lineIndexForCaret(lines, idx)]
+	end := idx
+	if end < l.StartIndexInBytes {
+		end = l.StartIndexInBytes
+	}
+	// Do not count a newline character.
+	if i := strings.IndexByte(str[l.StartIndexInBytes:end], '\n'); i >= 0 {
+		end = l.StartIndexInBytes + i
+	}
+	return l.X + text.Advance(str[l.StartIndexInBytes:end], t.face), l.Y
+}
+
+func lineIndexForCaret(lines []text.Line, idx int) int {
+	var n int
+	for i, l := range lines {
+		if l.StartIndexInBytes > idx {
+			break
+		}
+		n = i
+	}
+	return n
+}
+
+func graphemeBoundaries(str string) []int {
+	var seg segmenter.Segmenter
+	runes := []rune(str)
+	seg.Init(runes)
+
+	byteIndices := make([]int, 0, len(runes)+1)
+	for i := range str {
+		byteIndices = append(byteIndices, i)
+	}
+	byteIndices = append(byteIndices, len(str))
+
+	boundaries := []int{0}
+	iter := seg.GraphemeIterator()
+	for iter.Next() {
+		g := iter.Grapheme()
+		boundaries = append(boundaries, byteIndices[g.Offset+len(g.Text)])
+	}
+	return boundaries
+}
+
+func prevGraphemeBoundary(str string, idx int) int {
+	b := graphemeBoundaries(str)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < idx {
+			return b[i]
+		}
+	}
+	return 0
+}
+
+func nextGraphemeBoundary(str string, idx int) int {
+	for _, b := range graphemeBoundaries(str) {
+		if b > idx {
+			return b
+		}
+	}
+	return len(str)
+}
+
+func isKeyRepeating(key ebiten.Key) bool {
+	d := inpututil.KeyPressDuration(key)
+	if d == 1 {
+		return true
+	}
+	tps := ebiten.TPS()
+	if tps <= 0 {
+		tps = 60
+	}
+	delay := tps / 2
+	interval := tps / 20
+	if interval < 1 {
+		interval = 1
+	}
+	return d >= delay && (d-delay)%interval == 0
+}
+
+func isShortcutModifierPressed() bool {
+	return ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+}
+
+// Update updates the field state by the user's input.
+// Update must be called every tick, i.e., every Update.
+//
+// Clicking or touching the field focuses the field, and clicking or touching outside of the field blurs the field.
+func (t *TextField) Update() error {
+	t.tick++
+
+	t.handlePointer()
+	if !t.IsFocused() {
+		t.dragging = false
+		return nil
+	}
+
+	lines := text.AppendLines(nil, t.field.TextForRendering(), t.face, t.wrapOptions())
+	cx, cy := t.caretPosition(lines, t.field.TextForRendering(), t.renderingCaretIndex())
+	x := t.bounds.Min.X + int(cx-t.scrollX)
+	y := t.bounds.Min.Y + int(cy-t.scrollY)
+
+	origText := t.field.Text()
+	origAnchor, origCaret := t.anchor, t.caret
+	handled, err := t.field.HandleInput(x, y+int(t.face.Metrics().HAscent))
+	if err != nil {
+		return err
+	}
+
+	if r := image.Rect(x, y, x+1, y+int(t.lineHeight())); t.compositionRectDirty || r != t.lastCompositionRect {
+		if err := SetCompositionRect(r); err != nil {
+			return err
+		}
+		t.lastCompositionRect = r
+		t.compositionRectDirty = false
+	}
+
+	// If the text inputting session is not available, e.g. the environment is not supported,
+	// handle the input characters and the keys by this field.
+	if t.field.ch == nil {
+		handled = false
+		if chars := ebiten.AppendInputChars(nil); len(chars) > 0 && !isShortcutModifierPressed() {
+			t.edit(string(chars), true)
+		}
+	}
+
+	if handled {
+		if text := t.field.Text(); text != origText {
+			// A text was committed by IME.
+			if !t.typing {
+				t.undoStack = append(t.undoStack, textFieldSnapshot{
+					text:   origText,
+					anchor: origAnchor,
+					caret:  origCaret,
+				})
+			}
+			t.redoStack = nil
+			t.typing = true
+
+			start, _ := t.field.Selection()
+			if !t.options.Multiline && strings.ContainsAny(text, "\r\n") {
+				n := len(removeNewlines(text[:start]))
+				text = removeNewlines(text)
+				start = n
+			}
+			t.setTextAndSelection(text, start, start)
+		}
+	} else {
+		t.handleKeys()
+	}
+
+	t.adjustScroll()
+	return nil
+}
+
+func (t *TextField) renderingCaretIndex() int {
+	start, end := t.field.Selection()
+	if s, _, ok := t.field.CompositionSelection(); ok {
+		return start + s
+	}
+	if t.caret == end {
+		return end
+	}
+	return start
+}
+
+func (t *TextField) indexAt(x, y int) int {
+	return text.HitTest(t.field.Text(), t.face, t.wrapOptions(), float64(x-t.bounds.Min.X)+t.scrollX, float64(y-t.bounds.Min.Y)+t.scrollY)
+}
+
+func (t *TextField) handlePointer() {
+	var x, y int
+	var justPressed bool
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y = ebiten.CursorPosition()
+		justPressed = true
+		t.dragging = true
+	} else if ids := inpututil.AppendJustPressedTouchIDs(nil); len(ids) > 0 {
+		x, y = ebiten.TouchPosition(ids[0])
+		justPressed = true
+	}
+
+	if justPressed {
+		if !image.Pt(x, y).In(t.bounds) {
+			t.Blur()
+			t.dragging = false
+			return
+		}
+		t.Focus()
+		t.moveCaret(t.indexAt(x, y), ebiten.IsKeyPressed(ebiten.KeyShift))
+		return
+	}
+
+	if t.dragging && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		if t.IsFocused() {
+			t.moveCaret(t.indexAt(ebiten.CursorPosition()), true)
+		}
+		return
+	}
+	t.dragging = false
+}
+
+func (t *TextField) handleKeys() {
+	str := t.field.Text()
+	start, end := t.field.Selection()
+	shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+
+	if isShortcutModifierPressed() {
+		switch {
+		case inpututil.IsKeyJustPressed(ebiten.KeyA):
+			t.selectAll()
+		case inpututil.IsKeyJustPressed(ebiten.KeyC):
+			if start != end {
+				_ = ui.Get().SetClipboardText(str[start:end])
+			}
+		case inpututil.IsKeyJustPressed(ebiten.KeyX):
+			if start != end {
+				if err := ui.Get().SetClipboardText(str[start:end]); err == nil {
+					t.edit("", false)
+				}
+			}
+		case inpututil.IsKeyJustPressed(ebiten.KeyV):
+			if s, err := ui.Get().ClipboardText(); err == nil && s != "" {
+				t.edit(s, false)
+			}
+		case isKeyRepeating(ebiten.KeyZ) && shift, isKeyRepeating(ebiten.KeyY):
+			t.Redo()
+		case isKeyRepeating(ebiten.KeyZ):
+			t.Undo()
+		}
+		return
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		if t.options.Multiline {
+			t.edit("\n", false)
+		}
+	case isKeyRepeating(ebiten.KeyBackspace):
+		t.deleteBackward()
+	case isKeyRepeating(ebiten.KeyDelete):
+		t.deleteForward()
+	case isKeyRepeating(ebiten.KeyLeft):
+		t.moveLeft(shift)
+	case isKeyRepeating(ebiten.KeyRight):
+		t.moveRight(shift)
+	case isKeyRepeating(ebiten.KeyUp), isKeyRepeating(ebiten.KeyDown):
+		if !t.options.Multiline {
+			break
+		}
+		lines := text.AppendLines(nil, str, t.face, t.wrapOptions())
+		x, y := t.caretPosition(lines, str, t.caret)
+		if ebiten.IsKeyPressed(ebiten.KeyUp) {
+			y -= t.lineHeight()
+		} else {
+			y += t.lineHeight()
+		}
+		if y < 0 {
+			t.moveCaret(0, shift)
+			break
+		}
+		if last := lines[len(lines)-1]; y >= last.Y+last.Height {
+			t.moveCaret(len(str), shift)
+			break
+		}
+		t.moveCaret(text.HitTest(str, t.face, t.wrapOptions(), x, y+t.lineHeight()/2), shift)
+	case inpututil.IsKeyJustPressed(ebiten.KeyHome):
+		lines := text.AppendLines(nil, str, t.face, t.wrapOptions())
+		t.moveCaret(lines[lineIndexForCaret(lines, t.caret)].StartIndexInBytes, shift)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnd):
+		lines := text.AppendLines(nil, str, t.face, t.wrapOptions())
+		i := lineIndexForCaret(lines, t.caret)
+		e := len(str)
+		if i+1 < len(lines) {
+			e = lines[i+1].StartIndexInBytes
+			// Put the caret before the newline character or the spaces at the line end.
+			for e > lines[i].EndIndexInBytes && e > 0 && unicode.IsSpace(rune(str[e-1])) {
+				e--
+			}
+		}
+		t.moveCaret(e, shift)
+	}
+}
+
+func (t *TextField) selectAll() {
+	t.setTextAndSelection(t.field.Text(), 0, len(t.field.Text()))
+	t.typing = false
+}
+
+// deleteBackward deletes the selection, or the grapheme before the caret if nothing is selected.
+func (t *TextField) deleteBackward() {
+	start, end := t.field.Selection()
+	if start != end {
+		t.edit("", false)
+		return
+	}
+	t.deleteRange(prevGraphemeBoundary(t.field.Text(), start), start)
+}
+
+// deleteForward deletes the selection, or the grapheme after the caret if nothing is selected.
+func (t *TextField) deleteForward() {
+	start, end := t.field.Selection()
+	if start != end {
+		t.edit("", false)
+		return
+	}
+	t.deleteRange(start, nextGraphemeBoundary(t.field.Text(), start))
+}
+
+// moveLeft moves the caret to the previous grapheme boundary.
+// If extend is false and there is a selection, moveLeft moves the caret to the start of the selection instead.
+func (t *TextField) moveLeft(extend bool) {
+	start, end := t.field.Selection()
+	if start != end && !extend {
+		t.moveCaret(start, false)
+		return
+	}
+	t.moveCaret(prevGraphemeBoundary(t.field.Text(), t.caret), extend)
+}
+
+// moveRight moves the caret to the next grapheme boundary.
+// If extend is false and there is a selection, moveRight moves the caret to the end of the selection instead.
+func (t *TextField) moveRight(extend bool) {
+	start, end := t.field.Selection()
+	if start != end && !extend {
+		t.moveCaret(end, false)
+		return
+	}
+	t.moveCaret(nextGraphemeBoundary(t.field.Text(), t.caret), extend)
+}
+
+func (t *TextField) adjustScroll() {
+	str := t.field.TextForRendering()
+	lines := text.AppendLines(nil, str, t.face, t.wrapOptions())
+	x, y := t.caretPosition(lines, str, t.renderingCaretIndex())
+
+	w := float64(t.bounds.Dx())
+	h := float64(t.bounds.Dy())
+	if !t.options.Wrap {
+		if x-t.scrollX > w-1 {
+			t.scrollX = x - w + 1
+		}
+		if x-t.scrollX < 0 {
+			t.scrollX = x
+		}
+	} else {
+		t.scrollX = 0
+	}
+	if y+t.lineHeight()-t.scrollY > h {
+		t.scrollY = y + t.lineHeight() - h
+	}
+	if y-t.scrollY < 0 {
+		t.scrollY = y
+	}
+}
+
+// Draw draws the text, the selection and the caret onto dst.
+// The rendering result is clipped by the field's bounds.
+func (t *TextField) Draw(dst *ebiten.Image) {
+	dst = dst.SubImage(t.bounds).(*ebiten.Image)
+
+	textColor := t.options.TextColor
+	if textColor == nil {
+		textColor = color.Black
+	}
+	selectionColor := t.options.SelectionColor
+	if selectionColor == nil {
+		selectionColor = color.RGBA{0, 0, 0x80, 0x40}
+	}
+
+	str := t.field.TextForRendering()
+	lines := text.AppendLines(nil, str, t.face, t.wrapOptions())
+	ox := float64(t.bounds.Min.X) - t.scrollX
+	oy := float64(t.bounds.Min.Y) - t.scrollY
+	lh := float32(t.lineHeight())
+
+	start, end := t.field.Selection()
+	compStart, compEnd := start, start
+	_, _, composing := t.field.CompositionSelection()
+	if composing {
+		compEnd = start + len(str) - (len(t.field.Text()) - (end - start))
+	}
+
+	// Draw the selection.
+	if t.IsFocused() && !composing && start != end {
+		for _, l := range lines {
+			s, e := start, end
+			if s < l.StartIndexInBytes {
+				s = l.StartIndexInBytes
+			}
+			if e > l.EndIndexInBytes {
+				e = l.EndIndexInBytes
+			}
+			if s > e {
+				continue
+			}
+			x0, y := t.caretPosition(lines, str, s)
+			x1, _ := t.caretPosition(lines, str, e)
+			if x1 == x0 && end <= l.EndIndexInBytes {
+				continue
+			}
+			if end > l.EndIndexInBytes {
+				// Show that the line end is selected.
+				x1 += text.Advance(" ", t.face)
+			}
+			vector.DrawFilledRect(dst, float32(ox+x0), float32(oy+y), float32(x1-x0), lh, selectionColor, false)
+		}
+	}
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(ox, oy)
+	op.ColorScale.ScaleWithColor(textColor)
+	op.LineSpacing = t.lineHeight()
+	// Wrap doesn't change the text other than the line breaks and the spaces at the line ends.
+	text.Draw(dst, text.Wrap(str, t.face, t.wrapOptions().Width), t.face, op)
+
+	// Draw the underline of the composition text.
+	if composing {
+		for _, l := range lines {
+			s, e := compStart, compEnd
+			if s < l.StartIndexInBytes {
+				s = l.StartIndexInBytes
+			}
+			if e > l.EndIndexInBytes {
+				e = l.EndIndexInBytes
+			}
+			if s >= e {
+				continue
+			}
+			x0, y := t.caretPosition(lines, str, s)
+			x1, _ := t.caretPosition(lines, str, e)
+			uy := float32(oy+y) + lh - 1
+			vector.StrokeLine(dst, float32(ox+x0), uy, float32(ox+x1), uy, 1, textColor, false)
+		}
+	}
+
+	// Draw the caret. The caret blinks, and is always visible just after it moves.
+	if t.IsFocused() && ((t.tick-t.caretMovedAt)/30)%2 == 0 {
+		x, y := t.caretPosition(lines, str, t.renderingCaretIndex())
+		cx := float32(ox + x)
+		cy := float32(oy + y)
+		vector.StrokeLine(dst, cx, cy, cx, cy+lh, 1, textColor, false)
+	}
+}
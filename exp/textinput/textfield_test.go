@@ -0,0 +1,182 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+)
+
+// The tests here don't render texts, so a face is not needed.
+
+func checkState(t *testing.T, f *textinput.TextField, text string, start, end int) {
+	t.Helper()
+	if got, want := f.Text(), text; got != want {
+		t.Errorf("Text(): got: %q, want: %q", got, want)
+	}
+	if gotStart, gotEnd := f.Selection(); gotStart != start || gotEnd != end {
+		t.Errorf("Selection(): got: (%d, %d), want: (%d, %d)", gotStart, gotEnd, start, end)
+	}
+}
+
+func TestTextFieldUndoRedo(t *testing.T) {
+	f := textinput.NewTextField(nil, image.Rectangle{}, nil)
+	if f.CanUndo() || f.CanRedo() {
+		t.Errorf("a new field must not be able to undo or redo")
+	}
+
+	// Consecutive typing is merged into one undo step.
+	f.TypeForTesting("a")
+	f.TypeForTesting("b")
+	f.TypeForTesting("c")
+	checkState(t, f, "abc", 3, 3)
+
+	// Pasting is an individual undo step.
+	f.PasteForTesting("XYZ")
+	checkState(t, f, "abcXYZ", 6, 6)
+
+	f.Undo()
+	checkState(t, f, "abc", 3, 3)
+	f.Undo()
+	checkState(t, f, "", 0, 0)
+	if f.CanUndo() {
+		t.Errorf("CanUndo(): got: true, want: false")
+	}
+
+	f.Redo()
+	checkState(t, f, "abc", 3, 3)
+	f.Redo()
+	checkState(t, f, "abcXYZ", 6, 6)
+	if f.CanRedo() {
+		t.Errorf("CanRedo(): got: true, want: false")
+	}
+
+	// A new edit clears the redo history.
+	f.Undo()
+	f.TypeForTesting("d")
+	checkState(t, f, "abcd", 4, 4)
+	if f.CanRedo() {
+		t.Errorf("CanRedo() after an edit: got: true, want: false")
+	}
+
+	// Undo restores the selection before the edit.
+	f.SetSelection(1, 3)
+	f.DeleteBackwardForTesting()
+	checkState(t, f, "ad", 1, 1)
+	f.Undo()
+	checkState(t, f, "abcd", 1, 3)
+
+	// SetText clears the history.
+	f.SetText("new")
+	if f.CanUndo() || f.CanRedo() {
+		t.Errorf("SetText must clear the undo history")
+	}
+	checkState(t, f, "new", 3, 3)
+}
+
+func TestTextFieldSelection(t *testing.T) {
+	f := textinput.NewTextField(nil, image.Rectangle{}, nil)
+	f.SetText("hello")
+
+	f.SelectAllForTesting()
+	checkState(t, f, "hello", 0, 5)
+
+	// Typing replaces the selection.
+	f.TypeForTesting("bye")
+	checkState(t, f, "bye", 3, 3)
+
+	// Extend the selection to the left. The caret is the moving end.
+	f.MoveLeftForTesting(true)
+	f.MoveLeftForTesting(true)
+	checkState(t, f, "bye", 1, 3)
+
+	// Moving without extending collapses the selection to its edge.
+	f.MoveRightForTesting(false)
+	checkState(t, f, "bye", 3, 3)
+	f.SetSelection(3, 1)
+	checkState(t, f, "bye", 1, 3)
+	f.MoveLeftForTesting(false)
+	checkState(t, f, "bye", 1, 1)
+
+	// The selection is clamped by the text length.
+	f.SetSelection(-1, 10)
+	checkState(t, f, "bye", 0, 3)
+
+	// Deleting forward with a selection deletes only the selection.
+	f.SetSelection(0, 2)
+	f.DeleteForwardForTesting()
+	checkState(t, f, "e", 0, 0)
+}
+
+func TestTextFieldSingleLine(t *testing.T) {
+	f := textinput.NewTextField(nil, image.Rectangle{}, nil)
+	f.PasteForTesting("a\nb\r\nc")
+	checkState(t, f, "abc", 3, 3)
+
+	f = textinput.NewTextField(nil, image.Rectangle{}, &textinput.TextFieldOptions{
+		Multiline: true,
+	})
+	f.PasteForTesting("a\nb")
+	checkState(t, f, "a\nb", 3, 3)
+}
+
+func TestTextFieldGraphemes(t *testing.T) {
+	// "e" with a combining acute accent, a family emoji with ZWJs, and a flag consist of multiple runes each.
+	const (
+		e      = "e\u0301"
+		family = "\U0001F468\u200d\U0001F469\u200d\U0001F467"
+		flag   = "\U0001F1EF\U0001F1F5"
+	)
+	str := "a" + e + family + flag + "b"
+
+	f := textinput.NewTextField(nil, image.Rectangle{}, nil)
+	f.SetText(str)
+
+	// Move the caret from the end to the start grapheme by grapheme.
+	boundaries := []int{
+		len(str),
+		len(str) - len("b"),
+		len(str) - len("b") - len(flag),
+		len("a" + e),
+		len("a"),
+		0,
+	}
+	for i, want := range boundaries {
+		if i > 0 {
+			f.MoveLeftForTesting(false)
+		}
+		checkState(t, f, str, want, want)
+	}
+	// Moving beyond the start does nothing.
+	f.MoveLeftForTesting(false)
+	checkState(t, f, str, 0, 0)
+
+	for i := len(boundaries) - 2; i >= 0; i-- {
+		f.MoveRightForTesting(false)
+		checkState(t, f, str, boundaries[i], boundaries[i])
+	}
+
+	// Deleting removes a whole grapheme.
+	f.SetSelection(len("a"), len("a"))
+	f.DeleteForwardForTesting()
+	checkState(t, f, "a"+family+flag+"b", 1, 1)
+	f.SetSelection(len("a"+family+flag), len("a"+family+flag))
+	f.DeleteBackwardForTesting()
+	checkState(t, f, "a"+family+"b", len("a"+family), len("a"+family))
+	f.DeleteBackwardForTesting()
+	checkState(t, f, "ab", 1, 1)
+}
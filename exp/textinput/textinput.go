@@ -15,10 +15,11 @@
 // Package textinput provides a text-inputting controller.
 // This package is experimental and the API might be changed in the future.
 //
-// This package is supported by macOS and Web browsers so far.
+// This package is supported by macOS, Windows and Web browsers so far.
 package textinput
 
 import (
+	"image"
 	"unicode/utf16"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
@@ -55,6 +56,18 @@ func Start(x, y int) (states chan State, close func()) {
 	return theTextInput.Start(int(cx), int(cy))
 }
 
+// SetCompositionRect sets the region of the text being composed, e.g. the caret, in the logical coordinates.
+// An IME shows its candidate window next to the region without covering the region.
+//
+// The region is reset by Start. Call SetCompositionRect after Start, e.g. when the caret moves during composition.
+//
+// SetCompositionRect does nothing if the current environment doesn't support this package.
+func SetCompositionRect(rect image.Rectangle) error {
+	x0, y0 := ui.Get().LogicalPositionToClientPositionInNativePixels(float64(rect.Min.X), float64(rect.Min.Y))
+	x1, y1 := ui.Get().LogicalPositionToClientPositionInNativePixels(float64(rect.Max.X), float64(rect.Max.Y))
+	return theTextInput.SetCompositionRect(image.Rect(int(x0), int(y0), int(x1), int(y1)))
+}
+
 func convertUTF16CountToByteCount(text string, c int) int {
 	return len(string(utf16.Decode(utf16.Encode([]rune(text))[:c])))
 }
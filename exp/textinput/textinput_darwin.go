@@ -21,6 +21,8 @@ package textinput
 import "C"
 
 import (
+	"image"
+
 	"github.com/ebitengine/purego/objc"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
@@ -44,6 +46,13 @@ func (t *textInput) Start(x, y int) (chan State, func()) {
 	return session.ch, session.end
 }
 
+func (t *textInput) SetCompositionRect(rect image.Rectangle) error {
+	ui.Get().RunOnMainThread(func() {
+		setCompositionRect(rect)
+	})
+	return nil
+}
+
 //export ebitengine_textinput_update
 func ebitengine_textinput_update(text *C.char, start, end C.int, committed C.int) {
 	theTextInput.update(C.GoString(text), int(start), int(end), committed != 0)
@@ -130,3 +139,16 @@ func start(x, y int) {
 		size:   nsSize{1, 1},
 	})
 }
+
+func setCompositionRect(rect image.Rectangle) {
+	t := getTextInputClient()
+	window := idNSApplication.Send(selSharedApplication).Send(selMainWindow)
+	contentView := window.Send(selContentView)
+
+	// The text input client's frame is used for the candidate window position by firstRectForCharacterRange.
+	r := objc.Send[nsRect](contentView, selFrame)
+	t.Send(selSetFrame, nsRect{
+		origin: nsPoint{float64(rect.Min.X), r.size.height - float64(rect.Max.Y)},
+		size:   nsSize{float64(rect.Dx()), float64(rect.Dy())},
+	})
+}
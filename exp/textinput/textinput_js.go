@@ -16,6 +16,7 @@ package textinput
 
 import (
 	"fmt"
+	"image"
 	"syscall/js"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
@@ -187,6 +188,21 @@ func (t *textInput) Start(x, y int) (chan State, func()) {
 	return nil, nil
 }
 
+func (t *textInput) SetCompositionRect(rect image.Rectangle) error {
+	if !t.textareaElement.Truthy() {
+		return nil
+	}
+
+	// Browsers show a candidate window next to the caret of the textarea.
+	style := t.textareaElement.Get("style")
+	style.Set("left", fmt.Sprintf("%dpx", rect.Min.X))
+	style.Set("top", fmt.Sprintf("%dpx", rect.Min.Y))
+	if h := rect.Dy(); h > 0 {
+		style.Set("height", fmt.Sprintf("%dpx", h))
+	}
+	return nil
+}
+
 func (t *textInput) trySend(committed bool) {
 	if t.session == nil {
 		return
@@ -16,6 +16,10 @@
 
 package textinput
 
+import (
+	"image"
+)
+
 type textInput struct{}
 
 var theTextInput textInput
@@ -23,3 +27,7 @@ var theTextInput textInput
 func (t *textInput) Start(x, y int) (chan State, func()) {
 	return nil, nil
 }
+
+func (t *textInput) SetCompositionRect(rect image.Rectangle) error {
+	return nil
+}
@@ -15,6 +15,7 @@
 package textinput
 
 import (
+	"image"
 	"unsafe"
 
 	"golang.org/x/sys/windows"
@@ -89,6 +90,48 @@ func (t *textInput) start(x, y int) error {
 	return nil
 }
 
+func (t *textInput) SetCompositionRect(rect image.Rectangle) error {
+	if microsoftgdk.IsXbox() {
+		return nil
+	}
+
+	var err error
+	ui.Get().RunOnMainThread(func() {
+		err = t.setCompositionRect(rect)
+	})
+	return err
+}
+
+// setCompositionRect must be called from the main thread.
+func (t *textInput) setCompositionRect(rect image.Rectangle) error {
+	if t.window == 0 {
+		t.window = _GetActiveWindow()
+	}
+
+	h := _ImmGetContext(t.window)
+	// CFS_EXCLUDE puts the candidate window at ptCurrentPos avoiding rcArea.
+	if err := _ImmSetCandidateWindow(h, &_CANDIDATEFORM{
+		dwIndex: 0,
+		dwStyle: _CFS_EXCLUDE,
+		ptCurrentPos: _POINT{
+			x: int32(rect.Min.X),
+			y: int32(rect.Max.Y),
+		},
+		rcArea: _RECT{
+			left:   int32(rect.Min.X),
+			top:    int32(rect.Min.Y),
+			right:  int32(rect.Max.X),
+			bottom: int32(rect.Max.Y),
+		},
+	}); err != nil {
+		return err
+	}
+	if err := _ImmReleaseContext(t.window, h); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (t *textInput) wndProc(hWnd uintptr, uMsg uint32, wParam, lParam uintptr) uintptr {
 	if t.session == nil {
 		return _CallWindowProcW(t.origWndProc, hWnd, uMsg, wParam, lParam)
@@ -0,0 +1,43 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilemap
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	CacheDelayForTesting    = cacheDelay
+	CacheLifetimeForTesting = cacheLifetime
+)
+
+func (t *Tileset) TileRectForTesting(index int) image.Rectangle {
+	return t.tileRect(index)
+}
+
+func (m *Map) VisibleTilesForTesting(bounds image.Rectangle, geoM ebiten.GeoM) (x0, y0, x1, y1 int, ok bool) {
+	return m.visibleTiles(bounds, geoM)
+}
+
+func (m *Map) IsChunkCachedForTesting(cx, cy int) bool {
+	c := &m.chunks[cy*m.chunkColumns+cx]
+	return c.cache != nil && c.cacheValid
+}
+
+func (m *Map) CachedChunkCountForTesting() int {
+	return len(m.cached)
+}
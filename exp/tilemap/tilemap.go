@@ -0,0 +1,439 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tilemap provides a tile map that renders a dense grid of tiles efficiently.
+// This package is experimental and the API might be changed in the future.
+//
+// Drawing a large map with DrawImage for each tile takes much CPU time.
+// A Map renders only the visible region as one mesh, and caches chunks that are not modified recently
+// into offscreen images automatically.
+package tilemap
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// EmptyTile is the tile index that represents no tile.
+const EmptyTile = -1
+
+// Tileset is a set of tiles arranged in a grid in one image.
+type Tileset struct {
+	image      *ebiten.Image
+	tileWidth  int
+	tileHeight int
+	columns    int
+	count      int
+}
+
+// NewTileset creates a new tileset from the image and the tile size.
+// The tiles are indexed from the upper-left tile in row-major order.
+//
+// If the tile size is not positive, or the image is smaller than a tile, NewTileset panics.
+func NewTileset(img *ebiten.Image, tileWidth, tileHeight int) *Tileset {
+	if tileWidth <= 0 || tileHeight <= 0 {
+		panic(fmt.Sprintf("tilemap: tile size must be positive but was (%d, %d)", tileWidth, tileHeight))
+	}
+	b := img.Bounds()
+	columns, rows := b.Dx()/tileWidth, b.Dy()/tileHeight
+	if columns == 0 || rows == 0 {
+		panic("tilemap: the image must be larger than a tile")
+	}
+	return &Tileset{
+		image:      img,
+		tileWidth:  tileWidth,
+		tileHeight: tileHeight,
+		columns:    columns,
+		count:      columns * rows,
+	}
+}
+
+// TileSize returns the size of a tile.
+func (t *Tileset) TileSize() (width, height int) {
+	return t.tileWidth, t.tileHeight
+}
+
+// TileCount returns the number of the tiles.
+func (t *Tileset) TileCount() int {
+	return t.count
+}
+
+func (t *Tileset) tileRect(index int) image.Rectangle {
+	b := t.image.Bounds()
+	x := b.Min.X + (index%t.columns)*t.tileWidth
+	y := b.Min.Y + (index/t.columns)*t.tileHeight
+	return image.Rect(x, y, x+t.tileWidth, y+t.tileHeight)
+}
+
+// MapOptions represents options for NewMap.
+type MapOptions struct {
+	// ChunkSize is the number of tiles in each direction of a chunk.
+	// A chunk is a unit of caching.
+	//
+	// The default (zero) value is 16.
+	ChunkSize int
+
+	// DisableCache disables caching chunks into offscreen images.
+	// This is useful when the map is modified every tick, or the tileset image is modified.
+	// With ebiten.FilterLinear, the edges of the cached chunks might be visible when the map is scaled.
+	// Disabling caches avoids this.
+	//
+	// The default (zero) value is false.
+	DisableCache bool
+}
+
+const (
+	// cacheDelay is the number of draws without modification before a chunk is cached.
+	cacheDelay = 60
+
+	// cacheLifetime is the number of draws without being visible before a cached chunk is released.
+	cacheLifetime = 300
+
+	// maxQuadsPerDraw is the maximum number of quads in one DrawTriangles call, limited by uint16 indices.
+	maxQuadsPerDraw = (1 << 16) / 4
+)
+
+type chunk struct {
+	// lastModified is the draw count when the chunk is modified last.
+	lastModified int
+
+	// lastDrawn is the draw count when the chunk is drawn last.
+	lastDrawn int
+
+	cache      *ebiten.Image
+	cacheValid bool
+}
+
+// Map is a tile map with a dense grid of tile indices.
+type Map struct {
+	tileset *Tileset
+	width   int
+	height  int
+	tiles   []int
+
+	chunkSize    int
+	chunkColumns int
+	chunks       []chunk
+	cached       map[int]struct{}
+	disableCache bool
+
+	drawCount int
+	vertices  []ebiten.Vertex
+	indices   []uint16
+}
+
+// NewMap creates a new map with the tileset and the size in tiles.
+// tiles is the tile indices in row-major order, and its length must be width * height.
+// tiles is copied. If tiles is nil, all the tiles are EmptyTile.
+//
+// If options is nil, the default values are used.
+func NewMap(tileset *Tileset, width, height int, tiles []int, options *MapOptions) *Map {
+	if width < 0 || height < 0 {
+		panic(fmt.Sprintf("tilemap: the size must not be negative but was (%d, %d)", width, height))
+	}
+	if tiles != nil && len(tiles) != width*height {
+		panic(fmt.Sprintf("tilemap: len(tiles) must be %d but was %d", width*height, len(tiles)))
+	}
+	if options == nil {
+		options = &MapOptions{}
+	}
+	chunkSize := options.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = 16
+	}
+
+	m := &Map{
+		tileset:      tileset,
+		width:        width,
+		height:       height,
+		tiles:        make([]int, width*height),
+		chunkSize:    chunkSize,
+		chunkColumns: (width + chunkSize - 1) / chunkSize,
+		cached:       map[int]struct{}{},
+		disableCache: options.DisableCache,
+	}
+	if tiles != nil {
+		copy(m.tiles, tiles)
+	} else {
+		for i := range m.tiles {
+			m.tiles[i] = EmptyTile
+		}
+	}
+	m.chunks = make([]chunk, m.chunkColumns*((height+chunkSize-1)/chunkSize))
+	return m
+}
+
+// Size returns the size of the map in tiles.
+func (m *Map) Size() (width, height int) {
+	return m.width, m.height
+}
+
+// Tile returns the tile index at (x, y).
+// Tile returns EmptyTile if (x, y) is out of the map.
+func (m *Map) Tile(x, y int) int {
+	if x < 0 || y < 0 || x >= m.width || y >= m.height {
+		return EmptyTile
+	}
+	return m.tiles[y*m.width+x]
+}
+
+// SetTile sets the tile index at (x, y).
+// The cache of the chunk with the tile is invalidated.
+//
+// If (x, y) is out of the map, SetTile panics.
+func (m *Map) SetTile(x, y, index int) {
+	if x < 0 || y < 0 || x >= m.width || y >= m.height {
+		panic(fmt.Sprintf("tilemap: (%d, %d) is out of the map", x, y))
+	}
+	if m.tiles[y*m.width+x] == index {
+		return
+	}
+	m.tiles[y*m.width+x] = index
+	c := &m.chunks[(y/m.chunkSize)*m.chunkColumns+x/m.chunkSize]
+	c.lastModified = m.drawCount
+	c.cacheValid = false
+}
+
+// Invalidate invalidates all the caches.
+// Invalidate should be called when the tileset image is modified.
+func (m *Map) Invalidate() {
+	for i := range m.chunks {
+		m.chunks[i].cacheValid = false
+	}
+}
+
+// Dispose releases all the cached images.
+func (m *Map) Dispose() {
+	for i := range m.cached {
+		m.releaseCache(i)
+	}
+}
+
+// DrawOptions represents options for Map.Draw.
+type DrawOptions struct {
+	// GeoM is a geometry matrix from the map's coordinate in pixels to the destination.
+	// The default (zero) value is identity.
+	GeoM ebiten.GeoM
+
+	// ColorScale is a scale of color.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ebiten.ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend ebiten.Blend
+
+	// Filter is a type of texture filter.
+	// The default (zero) value is ebiten.FilterNearest.
+	Filter ebiten.Filter
+}
+
+// Draw draws the visible region of the map onto dst.
+func (m *Map) Draw(dst *ebiten.Image, options *DrawOptions) {
+	if options == nil {
+		options = &DrawOptions{}
+	}
+	m.drawCount++
+
+	x0, y0, x1, y1, ok := m.visibleTiles(dst.Bounds(), options.GeoM)
+	cx0, cy0 := x0/m.chunkSize, y0/m.chunkSize
+	cx1, cy1 := (x1+m.chunkSize-1)/m.chunkSize, (y1+m.chunkSize-1)/m.chunkSize
+
+	if ok {
+		m.vertices = m.vertices[:0]
+		m.indices = m.indices[:0]
+		for cy := cy0; cy < cy1; cy++ {
+			for cx := cx0; cx < cx1; cx++ {
+				i := cy*m.chunkColumns + cx
+				c := &m.chunks[i]
+				c.lastDrawn = m.drawCount
+				if m.shouldCache(c) {
+					m.flushTiles(dst, options)
+					m.drawCache(dst, i, options)
+					continue
+				}
+				tx0, ty0 := cx*m.chunkSize, cy*m.chunkSize
+				m.appendTiles(dst, options, maxInt(tx0, x0), maxInt(ty0, y0), minInt(tx0+m.chunkSize, x1), minInt(ty0+m.chunkSize, y1), options.GeoM)
+			}
+		}
+		m.flushTiles(dst, options)
+	}
+
+	// Release the caches of the chunks that have not been visible for a while.
+	for i := range m.cached {
+		if m.drawCount-m.chunks[i].lastDrawn > cacheLifetime {
+			m.releaseCache(i)
+		}
+	}
+}
+
+// visibleTiles returns the range of the tiles visible in the bounds.
+func (m *Map) visibleTiles(bounds image.Rectangle, geoM ebiten.GeoM) (x0, y0, x1, y1 int, ok bool) {
+	if !geoM.IsInvertible() || bounds.Empty() {
+		return 0, 0, 0, 0, false
+	}
+	geoM.Invert()
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range [...]image.Point{bounds.Min, {bounds.Max.X, bounds.Min.Y}, {bounds.Min.X, bounds.Max.Y}, bounds.Max} {
+		x, y := geoM.Apply(float64(p.X), float64(p.Y))
+		minX, minY = math.Min(minX, x), math.Min(minY, y)
+		maxX, maxY = math.Max(maxX, x), math.Max(maxY, y)
+	}
+
+	tw, th := float64(m.tileset.tileWidth), float64(m.tileset.tileHeight)
+	x0 = clampInt(int(math.Floor(minX/tw)), 0, m.width)
+	y0 = clampInt(int(math.Floor(minY/th)), 0, m.height)
+	x1 = clampInt(int(math.Ceil(maxX/tw)), 0, m.width)
+	y1 = clampInt(int(math.Ceil(maxY/th)), 0, m.height)
+	if x0 >= x1 || y0 >= y1 {
+		return 0, 0, 0, 0, false
+	}
+	return x0, y0, x1, y1, true
+}
+
+func (m *Map) shouldCache(c *chunk) bool {
+	if m.disableCache {
+		return false
+	}
+	return m.drawCount-c.lastModified >= cacheDelay
+}
+
+// appendTiles appends the quads of the tiles in the range to the mesh.
+func (m *Map) appendTiles(dst *ebiten.Image, options *DrawOptions, x0, y0, x1, y1 int, geoM ebiten.GeoM) {
+	tw, th := m.tileset.tileWidth, m.tileset.tileHeight
+	cs := options.ColorScale
+	r, g, b, a := cs.R(), cs.G(), cs.B(), cs.A()
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			index := m.tiles[y*m.width+x]
+			if index < 0 || index >= m.tileset.count {
+				continue
+			}
+			if len(m.vertices)/4 >= maxQuadsPerDraw {
+				m.flushTiles(dst, options)
+			}
+
+			sr := m.tileset.tileRect(index)
+			dx0, dy0 := float64(x*tw), float64(y*th)
+			dx1, dy1 := dx0+float64(tw), dy0+float64(th)
+			n := uint16(len(m.vertices))
+			for _, p := range [...][4]float64{
+				{dx0, dy0, float64(sr.Min.X), float64(sr.Min.Y)},
+				{dx1, dy0, float64(sr.Max.X), float64(sr.Min.Y)},
+				{dx0, dy1, float64(sr.Min.X), float64(sr.Max.Y)},
+				{dx1, dy1, float64(sr.Max.X), float64(sr.Max.Y)},
+			} {
+				vx, vy := geoM.Apply(p[0], p[1])
+				m.vertices = append(m.vertices, ebiten.Vertex{
+					DstX:   float32(vx),
+					DstY:   float32(vy),
+					SrcX:   float32(p[2]),
+					SrcY:   float32(p[3]),
+					ColorR: r,
+					ColorG: g,
+					ColorB: b,
+					ColorA: a,
+				})
+			}
+			m.indices = append(m.indices, n, n+1, n+2, n+1, n+3, n+2)
+		}
+	}
+}
+
+// flushTiles draws the mesh of the tiles onto dst and clears the mesh.
+func (m *Map) flushTiles(dst *ebiten.Image, options *DrawOptions) {
+	if len(m.indices) == 0 {
+		return
+	}
+	op := &ebiten.DrawTrianglesOptions{}
+	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	op.Blend = options.Blend
+	op.Filter = options.Filter
+	dst.DrawTriangles(m.vertices, m.indices, m.tileset.image, op)
+	m.vertices = m.vertices[:0]
+	m.indices = m.indices[:0]
+}
+
+// drawCache draws the cached image of the chunk onto dst, updating the cache if needed.
+func (m *Map) drawCache(dst *ebiten.Image, index int, options *DrawOptions) {
+	c := &m.chunks[index]
+	cx, cy := index%m.chunkColumns, index/m.chunkColumns
+	tw, th := m.tileset.tileWidth, m.tileset.tileHeight
+	tx0, ty0 := cx*m.chunkSize, cy*m.chunkSize
+	tx1, ty1 := minInt(tx0+m.chunkSize, m.width), minInt(ty0+m.chunkSize, m.height)
+
+	if c.cache == nil {
+		c.cache = ebiten.NewImage((tx1-tx0)*tw, (ty1-ty0)*th)
+		m.cached[index] = struct{}{}
+	}
+	if !c.cacheValid {
+		c.cache.Clear()
+		var geoM ebiten.GeoM
+		geoM.Translate(float64(-tx0*tw), float64(-ty0*th))
+		op := &DrawOptions{
+			Blend: ebiten.BlendCopy,
+		}
+		// Use the current vertex buffer temporarily. The buffer is empty here as the tiles are flushed.
+		m.appendTiles(c.cache, op, tx0, ty0, tx1, ty1, geoM)
+		m.flushTiles(c.cache, op)
+		c.cacheValid = true
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(tx0*tw), float64(ty0*th))
+	op.GeoM.Concat(options.GeoM)
+	op.ColorScale = options.ColorScale
+	op.Blend = options.Blend
+	op.Filter = options.Filter
+	dst.DrawImage(c.cache, op)
+}
+
+func (m *Map) releaseCache(index int) {
+	c := &m.chunks[index]
+	if c.cache != nil {
+		c.cache.Deallocate()
+		c.cache = nil
+	}
+	c.cacheValid = false
+	delete(m.cached, index)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
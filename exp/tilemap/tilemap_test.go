@@ -0,0 +1,213 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilemap_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/tilemap"
+)
+
+func TestTileset(t *testing.T) {
+	// A 3x2 tileset with an extra margin that is not a whole tile.
+	img := ebiten.NewImage(50, 35)
+	ts := tilemap.NewTileset(img, 16, 16)
+	if got, want := ts.TileCount(), 6; got != want {
+		t.Errorf("TileCount(): got: %d, want: %d", got, want)
+	}
+
+	testCases := []struct {
+		Index int
+		Want  image.Rectangle
+	}{
+		{Index: 0, Want: image.Rect(0, 0, 16, 16)},
+		{Index: 2, Want: image.Rect(32, 0, 48, 16)},
+		{Index: 4, Want: image.Rect(16, 16, 32, 32)},
+	}
+	for _, tc := range testCases {
+		if got := ts.TileRectForTesting(tc.Index); got != tc.Want {
+			t.Errorf("tileRect(%d): got: %v, want: %v", tc.Index, got, tc.Want)
+		}
+	}
+
+	// The tiles in a sub-image start at its upper-left corner.
+	sub := tilemap.NewTileset(img.SubImage(image.Rect(2, 3, 50, 35)).(*ebiten.Image), 16, 16)
+	if got, want := sub.TileRectForTesting(4), image.Rect(18, 19, 34, 35); got != want {
+		t.Errorf("tileRect(4): got: %v, want: %v", got, want)
+	}
+}
+
+func TestNewTilesetPanics(t *testing.T) {
+	img := ebiten.NewImage(16, 16)
+	for _, size := range [][2]int{{0, 16}, {16, -1}, {32, 16}} {
+		size := size
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewTileset with the tile size %v must panic", size)
+				}
+			}()
+			tilemap.NewTileset(img, size[0], size[1])
+		}()
+	}
+}
+
+func TestMapTile(t *testing.T) {
+	ts := tilemap.NewTileset(ebiten.NewImage(16, 16), 16, 16)
+
+	m := tilemap.NewMap(ts, 3, 2, nil, nil)
+	if got, want := m.Tile(1, 1), tilemap.EmptyTile; got != want {
+		t.Errorf("Tile(1, 1): got: %d, want: %d", got, want)
+	}
+
+	tiles := []int{0, 1, 2, 3, 4, 5}
+	m = tilemap.NewMap(ts, 3, 2, tiles, nil)
+	// The given tiles must be copied.
+	tiles[0] = 100
+	if got, want := m.Tile(0, 0), 0; got != want {
+		t.Errorf("Tile(0, 0): got: %d, want: %d", got, want)
+	}
+	if got, want := m.Tile(2, 1), 5; got != want {
+		t.Errorf("Tile(2, 1): got: %d, want: %d", got, want)
+	}
+	if got, want := m.Tile(3, 0), tilemap.EmptyTile; got != want {
+		t.Errorf("Tile(3, 0): got: %d, want: %d", got, want)
+	}
+	if got, want := m.Tile(0, -1), tilemap.EmptyTile; got != want {
+		t.Errorf("Tile(0, -1): got: %d, want: %d", got, want)
+	}
+
+	m.SetTile(1, 0, 7)
+	if got, want := m.Tile(1, 0), 7; got != want {
+		t.Errorf("Tile(1, 0): got: %d, want: %d", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SetTile out of the map must panic")
+		}
+	}()
+	m.SetTile(3, 0, 0)
+}
+
+func TestMapVisibleTiles(t *testing.T) {
+	ts := tilemap.NewTileset(ebiten.NewImage(16, 16), 16, 16)
+	m := tilemap.NewMap(ts, 100, 50, nil, nil)
+	bounds := image.Rect(0, 0, 320, 240)
+
+	var translated ebiten.GeoM
+	translated.Translate(-100, -40)
+
+	var scaled ebiten.GeoM
+	scaled.Scale(0.5, 0.5)
+
+	var outside ebiten.GeoM
+	outside.Translate(-10000, 0)
+
+	var singular ebiten.GeoM
+	singular.Scale(0, 1)
+
+	testCases := []struct {
+		Name   string
+		GeoM   ebiten.GeoM
+		Want   [4]int
+		WantOK bool
+	}{
+		{Name: "identity", Want: [4]int{0, 0, 20, 15}, WantOK: true},
+		{Name: "translated", GeoM: translated, Want: [4]int{6, 2, 27, 18}, WantOK: true},
+		{Name: "scaled", GeoM: scaled, Want: [4]int{0, 0, 40, 30}, WantOK: true},
+		{Name: "outside", GeoM: outside},
+		{Name: "singular", GeoM: singular},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			x0, y0, x1, y1, ok := m.VisibleTilesForTesting(bounds, tc.GeoM)
+			if ok != tc.WantOK {
+				t.Fatalf("ok: got: %v, want: %v", ok, tc.WantOK)
+			}
+			if !ok {
+				return
+			}
+			if got := [4]int{x0, y0, x1, y1}; got != tc.Want {
+				t.Errorf("got: %v, want: %v", got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestMapCache(t *testing.T) {
+	ts := tilemap.NewTileset(ebiten.NewImage(32, 16), 16, 16)
+	// 2x1 chunks.
+	m := tilemap.NewMap(ts, 8, 4, make([]int, 8*4), &tilemap.MapOptions{ChunkSize: 4})
+	defer m.Dispose()
+
+	dst := ebiten.NewImage(128, 64)
+	for i := 0; i < tilemap.CacheDelayForTesting-1; i++ {
+		m.Draw(dst, nil)
+	}
+	if m.IsChunkCachedForTesting(0, 0) {
+		t.Errorf("the chunk must not be cached before the delay")
+	}
+	m.Draw(dst, nil)
+	if !m.IsChunkCachedForTesting(0, 0) || !m.IsChunkCachedForTesting(1, 0) {
+		t.Errorf("the chunks must be cached after the delay")
+	}
+
+	// Modifying a tile invalidates only the chunk with the tile.
+	m.SetTile(5, 2, 1)
+	if !m.IsChunkCachedForTesting(0, 0) {
+		t.Errorf("the chunk (0, 0) must be still cached")
+	}
+	if m.IsChunkCachedForTesting(1, 0) {
+		t.Errorf("the chunk (1, 0) must not be cached after SetTile")
+	}
+
+	// The chunks that are not visible are released after the lifetime.
+	var geoM ebiten.GeoM
+	geoM.Translate(-64, 0)
+	op := &tilemap.DrawOptions{GeoM: geoM}
+	for i := 0; i < tilemap.CacheLifetimeForTesting+1; i++ {
+		m.Draw(dst, op)
+	}
+	if got, want := m.CachedChunkCountForTesting(), 1; got != want {
+		t.Errorf("CachedChunkCountForTesting(): got: %d, want: %d", got, want)
+	}
+	if m.IsChunkCachedForTesting(0, 0) {
+		t.Errorf("the chunk (0, 0) must be released")
+	}
+	if !m.IsChunkCachedForTesting(1, 0) {
+		t.Errorf("the chunk (1, 0) must be cached")
+	}
+
+	m.Dispose()
+	if got, want := m.CachedChunkCountForTesting(), 0; got != want {
+		t.Errorf("CachedChunkCountForTesting(): got: %d, want: %d", got, want)
+	}
+}
+
+func TestMapDisableCache(t *testing.T) {
+	ts := tilemap.NewTileset(ebiten.NewImage(16, 16), 16, 16)
+	m := tilemap.NewMap(ts, 4, 4, make([]int, 4*4), &tilemap.MapOptions{DisableCache: true})
+	dst := ebiten.NewImage(64, 64)
+	for i := 0; i < tilemap.CacheDelayForTesting+1; i++ {
+		m.Draw(dst, nil)
+	}
+	if got, want := m.CachedChunkCountForTesting(), 0; got != want {
+		t.Errorf("CachedChunkCountForTesting(): got: %d, want: %d", got, want)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tween
+
+import (
+	"math"
+)
+
+// Easing is an easing curve.
+// Easing takes a progress in [0, 1] and returns an eased progress.
+// An eased progress is 0 at 0 and 1 at 1, and might be out of [0, 1] in between, e.g. for InBack.
+type Easing func(t float64) float64
+
+// Linear is an easing curve without easing.
+func Linear(t float64) float64 {
+	return t
+}
+
+// InQuad is a quadratic easing curve that starts slowly.
+func InQuad(t float64) float64 {
+	return t * t
+}
+
+// OutQuad is a quadratic easing curve that ends slowly.
+func OutQuad(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+// InOutQuad is a quadratic easing curve that starts and ends slowly.
+func InOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - 2*(1-t)*(1-t)
+}
+
+// InCubic is a cubic easing curve that starts slowly.
+func InCubic(t float64) float64 {
+	return t * t * t
+}
+
+// OutCubic is a cubic easing curve that ends slowly.
+func OutCubic(t float64) float64 {
+	return 1 - (1-t)*(1-t)*(1-t)
+}
+
+// InOutCubic is a cubic easing curve that starts and ends slowly.
+func InOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - 4*(1-t)*(1-t)*(1-t)
+}
+
+// InSine is a sinusoidal easing curve that starts slowly.
+func InSine(t float64) float64 {
+	return 1 - math.Cos(t*math.Pi/2)
+}
+
+// OutSine is a sinusoidal easing curve that ends slowly.
+func OutSine(t float64) float64 {
+	return math.Sin(t * math.Pi / 2)
+}
+
+// InOutSine is a sinusoidal easing curve that starts and ends slowly.
+func InOutSine(t float64) float64 {
+	return (1 - math.Cos(t*math.Pi)) / 2
+}
+
+// InExpo is an exponential easing curve that starts slowly.
+func InExpo(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	return math.Pow(2, 10*(t-1))
+}
+
+// OutExpo is an exponential easing curve that ends slowly.
+func OutExpo(t float64) float64 {
+	if t >= 1 {
+		return 1
+	}
+	return 1 - math.Pow(2, -10*t)
+}
+
+const backOvershoot = 1.70158
+
+// InBack is an easing curve that goes back slightly before it starts.
+func InBack(t float64) float64 {
+	return t * t * ((backOvershoot+1)*t - backOvershoot)
+}
+
+// OutBack is an easing curve that overshoots slightly before it ends.
+func OutBack(t float64) float64 {
+	return 1 - InBack(1-t)
+}
+
+// OutElastic is an easing curve that oscillates like a spring before it ends.
+func OutElastic(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	if t >= 1 {
+		return 1
+	}
+	return math.Pow(2, -10*t)*math.Sin((10*t-0.75)*2*math.Pi/3) + 1
+}
+
+// OutBounce is an easing curve that bounces like a ball before it ends.
+func OutBounce(t float64) float64 {
+	const (
+		n = 7.5625
+		d = 2.75
+	)
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
+
+// InBounce is an easing curve that bounces like a ball after it starts.
+func InBounce(t float64) float64 {
+	return 1 - OutBounce(1-t)
+}
@@ -0,0 +1,604 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tween provides tweens, which change values over time with easing curves.
+// This package is experimental and the API might be changed in the future.
+//
+// A tween is advanced by an explicit delta time with Update.
+// A Player advances its tweens by the duration of one tick, which is 1/TPS seconds, scaled by its time scale.
+// As the duration is calculated at every Update, a Player follows changes by ebiten.SetTPS, and a paused Player
+// doesn't advance its tweens. Player.After and Player.Every work as timers on the same clock.
+// Call Player.Update once at the game's Update:
+//
+//	var (
+//		player tween.Player
+//		x      = tween.NewFloat(0, 100, time.Second, tween.OutCubic)
+//	)
+//
+//	func init() {
+//		player.Add(x)
+//	}
+//
+//	func (g *Game) Update() error {
+//		player.Update()
+//		return nil
+//	}
+//
+//	func (g *Game) Draw(screen *ebiten.Image) {
+//		op := &ebiten.DrawImageOptions{}
+//		op.GeoM.Translate(x.Value(), 0)
+//		screen.DrawImage(img, op)
+//	}
+package tween
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Tween is a process that changes over time.
+type Tween interface {
+	// Update advances the tween by dt.
+	// Update returns the remaining duration of dt after the tween finishes, or 0 if the tween doesn't finish yet.
+	Update(dt time.Duration) time.Duration
+
+	// IsFinished reports whether the tween has finished.
+	IsFinished() bool
+
+	// Reset resets the tween to the initial state.
+	Reset()
+}
+
+// base is a common part of the value tweens.
+type base struct {
+	duration   time.Duration
+	easing     Easing
+	elapsed    time.Duration
+	finished   bool
+	onUpdate   func()
+	onComplete func()
+}
+
+func (b *base) Update(dt time.Duration) time.Duration {
+	if b.finished {
+		return dt
+	}
+	b.elapsed += dt
+	var rest time.Duration
+	if b.elapsed >= b.duration {
+		rest = b.elapsed - b.duration
+		b.elapsed = b.duration
+		b.finished = true
+	}
+	if b.onUpdate != nil {
+		b.onUpdate()
+	}
+	if b.finished && b.onComplete != nil {
+		b.onComplete()
+	}
+	return rest
+}
+
+func (b *base) IsFinished() bool {
+	return b.finished
+}
+
+func (b *base) Reset() {
+	b.elapsed = 0
+	b.finished = false
+}
+
+// progress returns the eased progress.
+func (b *base) progress() float64 {
+	if b.duration <= 0 {
+		return 1
+	}
+	t := float64(b.elapsed) / float64(b.duration)
+	if b.easing == nil {
+		return t
+	}
+	return b.easing(t)
+}
+
+// SetOnUpdate sets a function called every time the tween's value is updated.
+func (b *base) SetOnUpdate(f func()) {
+	b.onUpdate = f
+}
+
+// SetOnComplete sets a function called when the tween finishes.
+func (b *base) SetOnComplete(f func()) {
+	b.onComplete = f
+}
+
+// Float is a tween of a float64 value.
+type Float struct {
+	base
+
+	from float64
+	to   float64
+}
+
+// NewFloat creates a new tween from from to to in duration with the easing curve.
+// If easing is nil, Linear is used.
+func NewFloat(from, to float64, duration time.Duration, easing Easing) *Float {
+	return &Float{
+		base: base{
+			duration: duration,
+			easing:   easing,
+		},
+		from: from,
+		to:   to,
+	}
+}
+
+// Value returns the current value.
+func (f *Float) Value() float64 {
+	return lerp(f.from, f.to, f.progress())
+}
+
+// GeoM is a tween of an ebiten.GeoM value.
+// The matrix is interpolated by ebiten.GeoM.Interpolate, so a rotation doesn't shrink the image in the middle.
+type GeoM struct {
+	base
+
+	from ebiten.GeoM
+	to   ebiten.GeoM
+}
+
+// NewGeoM creates a new tween from from to to in duration with the easing curve.
+// If easing is nil, Linear is used.
+func NewGeoM(from, to ebiten.GeoM, duration time.Duration, easing Easing) *GeoM {
+	return &GeoM{
+		base: base{
+			duration: duration,
+			easing:   easing,
+		},
+		from: from,
+		to:   to,
+	}
+}
+
+// Value returns the current value.
+func (g *GeoM) Value() ebiten.GeoM {
+	m := g.from
+	m.Interpolate(g.to, g.progress())
+	return m
+}
+
+// Color is a tween of a color value.
+// The color is interpolated in premultiplied alpha.
+type Color struct {
+	base
+
+	from [4]float64
+	to   [4]float64
+}
+
+// NewColor creates a new tween from from to to in duration with the easing curve.
+// If easing is nil, Linear is used.
+func NewColor(from, to color.Color, duration time.Duration, easing Easing) *Color {
+	return &Color{
+		base: base{
+			duration: duration,
+			easing:   easing,
+		},
+		from: colorToFloats(from),
+		to:   colorToFloats(to),
+	}
+}
+
+func colorToFloats(clr color.Color) [4]float64 {
+	r, g, b, a := clr.RGBA()
+	return [4]float64{float64(r) / 0xffff, float64(g) / 0xffff, float64(b) / 0xffff, float64(a) / 0xffff}
+}
+
+// ColorScale returns the current value as an ebiten.ColorScale.
+func (c *Color) ColorScale() ebiten.ColorScale {
+	t := c.progress()
+	var cs ebiten.ColorScale
+	cs.SetR(float32(lerp(c.from[0], c.to[0], t)))
+	cs.SetG(float32(lerp(c.from[1], c.to[1], t)))
+	cs.SetB(float32(lerp(c.from[2], c.to[2], t)))
+	cs.SetA(float32(lerp(c.from[3], c.to[3], t)))
+	return cs
+}
+
+// Value returns the current value as a color.Color, clamped to the valid range.
+func (c *Color) Value() color.Color {
+	t := c.progress()
+	var vs [4]uint16
+	for i := range vs {
+		v := lerp(c.from[i], c.to[i], t)
+		if v < 0 {
+			v = 0
+		}
+		if v > 1 {
+			v = 1
+		}
+		vs[i] = uint16(v*0xffff + 0.5)
+	}
+	// Clamp the color elements by the alpha to keep the color valid in premultiplied alpha.
+	for i := 0; i < 3; i++ {
+		if vs[i] > vs[3] {
+			vs[i] = vs[3]
+		}
+	}
+	return color.RGBA64{R: vs[0], G: vs[1], B: vs[2], A: vs[3]}
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// Delay is a tween that just waits for the duration.
+// Delay is useful in a Sequence.
+type Delay struct {
+	base
+}
+
+// NewDelay creates a new tween that waits for the duration.
+func NewDelay(duration time.Duration) *Delay {
+	return &Delay{
+		base: base{
+			duration: duration,
+		},
+	}
+}
+
+// Func is a tween that calls a function once and finishes immediately.
+// Func is useful to call a function at a point in a Sequence.
+type Func struct {
+	f        func()
+	finished bool
+}
+
+// NewFunc creates a new tween that calls f.
+func NewFunc(f func()) *Func {
+	return &Func{
+		f: f,
+	}
+}
+
+// Update implements Tween.
+func (f *Func) Update(dt time.Duration) time.Duration {
+	if !f.finished {
+		f.finished = true
+		if f.f != nil {
+			f.f()
+		}
+	}
+	return dt
+}
+
+// IsFinished implements Tween.
+func (f *Func) IsFinished() bool {
+	return f.finished
+}
+
+// Reset implements Tween.
+func (f *Func) Reset() {
+	f.finished = false
+}
+
+// Sequence is a tween that runs tweens one by one.
+//
+// The remaining time of a finished tween is carried over to the next tween, so a Sequence doesn't drift
+// regardless of the delta times.
+type Sequence struct {
+	tweens  []Tween
+	current int
+}
+
+// NewSequence creates a new tween that runs the given tweens one by one.
+func NewSequence(tweens ...Tween) *Sequence {
+	return &Sequence{
+		tweens: tweens,
+	}
+}
+
+// Update implements Tween.
+func (s *Sequence) Update(dt time.Duration) time.Duration {
+	for s.current < len(s.tweens) {
+		dt = s.tweens[s.current].Update(dt)
+		if !s.tweens[s.current].IsFinished() {
+			return 0
+		}
+		s.current++
+	}
+	return dt
+}
+
+// IsFinished implements Tween.
+func (s *Sequence) IsFinished() bool {
+	return s.current >= len(s.tweens)
+}
+
+// Reset implements Tween.
+func (s *Sequence) Reset() {
+	for _, t := range s.tweens {
+		t.Reset()
+	}
+	s.current = 0
+}
+
+// Parallel is a tween that runs tweens at the same time.
+// Parallel finishes when all the tweens finish.
+type Parallel struct {
+	tweens []Tween
+}
+
+// NewParallel creates a new tween that runs the given tweens at the same time.
+func NewParallel(tweens ...Tween) *Parallel {
+	return &Parallel{
+		tweens: tweens,
+	}
+}
+
+// Update implements Tween.
+func (p *Parallel) Update(dt time.Duration) time.Duration {
+	if p.IsFinished() {
+		return dt
+	}
+	// The remaining time is the least remaining time of the tweens that are finished by this update.
+	rest := dt
+	for _, t := range p.tweens {
+		if t.IsFinished() {
+			continue
+		}
+		r := t.Update(dt)
+		if !t.IsFinished() {
+			rest = 0
+			continue
+		}
+		if r < rest {
+			rest = r
+		}
+	}
+	return rest
+}
+
+// IsFinished implements Tween.
+func (p *Parallel) IsFinished() bool {
+	for _, t := range p.tweens {
+		if !t.IsFinished() {
+			return false
+		}
+	}
+	return true
+}
+
+// Reset implements Tween.
+func (p *Parallel) Reset() {
+	for _, t := range p.tweens {
+		t.Reset()
+	}
+}
+
+// Loop is a tween that repeats a tween.
+type Loop struct {
+	tween Tween
+	count int
+	done  int
+}
+
+// NewLoop creates a new tween that repeats the tween count times.
+// If count is 0 or negative, the tween is repeated forever.
+func NewLoop(tween Tween, count int) *Loop {
+	return &Loop{
+		tween: tween,
+		count: count,
+	}
+}
+
+// Update implements Tween.
+func (l *Loop) Update(dt time.Duration) time.Duration {
+	for !l.IsFinished() {
+		dt = l.tween.Update(dt)
+		if !l.tween.IsFinished() {
+			return 0
+		}
+		l.done++
+		if l.IsFinished() {
+			break
+		}
+		l.tween.Reset()
+		// Avoid an infinite loop with a tween that finishes without consuming time.
+		if dt == 0 {
+			return 0
+		}
+	}
+	return dt
+}
+
+// IsFinished implements Tween.
+func (l *Loop) IsFinished() bool {
+	return l.count > 0 && l.done >= l.count
+}
+
+// Reset implements Tween.
+func (l *Loop) Reset() {
+	l.tween.Reset()
+	l.done = 0
+}
+
+// Player advances tweens by the game clock.
+//
+// The zero value is a player with no tweens and the time scale 1.
+//
+// Add, Remove, Clear, After and Every can be called from a tween's callback during Update.
+// A tween added during Update is updated from the next Update, and a tween removed during Update is never updated
+// after the removal.
+type Player struct {
+	tweens []*playerEntry
+
+	// updatingTweens is a reused buffer for the snapshot of tweens during Update.
+	updatingTweens []*playerEntry
+
+	// timeScaleMinus1 is the time scale minus 1, so that the zero value means the time scale 1.
+	timeScaleMinus1 float64
+
+	paused bool
+}
+
+// playerEntry is a tween in a player.
+type playerEntry struct {
+	tween Tween
+
+	// removed reports whether the tween is removed from the player.
+	// This is used to skip the tween removed during Update.
+	removed bool
+}
+
+// Add adds tweens to the player. A tween is removed from the player automatically when it finishes.
+func (p *Player) Add(tweens ...Tween) {
+	for _, t := range tweens {
+		p.tweens = append(p.tweens, &playerEntry{tween: t})
+	}
+}
+
+// Remove removes the tween from the player.
+// If the tween is not in the player, Remove does nothing.
+func (p *Player) Remove(tween Tween) {
+	for i, e := range p.tweens {
+		if e.tween != tween {
+			continue
+		}
+		e.removed = true
+		copy(p.tweens[i:], p.tweens[i+1:])
+		p.tweens[len(p.tweens)-1] = nil
+		p.tweens = p.tweens[:len(p.tweens)-1]
+		return
+	}
+}
+
+// After calls f once after the duration d on the player's clock, and returns the timer as a tween.
+// The timer can be canceled by Remove.
+func (p *Player) After(d time.Duration, f func()) Tween {
+	t := NewSequence(NewDelay(d), NewFunc(f))
+	p.Add(t)
+	return t
+}
+
+// Every calls f every interval on the player's clock, and returns the timer as a tween.
+// The timer runs until it is removed by Remove or Clear.
+//
+// As the remaining time is carried over, f is called multiple times in one Update if the interval is shorter than a tick.
+//
+// If interval is not positive, Every panics.
+func (p *Player) Every(interval time.Duration, f func()) Tween {
+	if interval <= 0 {
+		panic("tween: interval must be positive")
+	}
+	t := NewLoop(NewSequence(NewDelay(interval), NewFunc(f)), 0)
+	p.Add(t)
+	return t
+}
+
+// Clear removes all the tweens from the player.
+func (p *Player) Clear() {
+	for i, e := range p.tweens {
+		e.removed = true
+		p.tweens[i] = nil
+	}
+	p.tweens = p.tweens[:0]
+}
+
+// Len returns the number of the running tweens.
+func (p *Player) Len() int {
+	return len(p.tweens)
+}
+
+// TimeScale returns the time scale of the player.
+func (p *Player) TimeScale() float64 {
+	return p.timeScaleMinus1 + 1
+}
+
+// SetTimeScale sets the time scale of the player.
+// For example, 0.5 makes the tweens half as fast, and 2 makes them twice as fast.
+//
+// If timeScale is negative, SetTimeScale panics.
+func (p *Player) SetTimeScale(timeScale float64) {
+	if timeScale < 0 {
+		panic("tween: timeScale must not be negative")
+	}
+	p.timeScaleMinus1 = timeScale - 1
+}
+
+// SetPaused pauses or resumes the player.
+func (p *Player) SetPaused(paused bool) {
+	p.paused = paused
+}
+
+// IsPaused reports whether the player is paused.
+func (p *Player) IsPaused() bool {
+	return p.paused
+}
+
+// Update advances the tweens by the duration of one tick scaled by the time scale.
+// Update should be called once at the game's Update.
+func (p *Player) Update() {
+	p.UpdateBy(time.Duration(float64(TickDuration()) * p.TimeScale()))
+}
+
+// UpdateBy advances the tweens by dt. The time scale is not applied.
+func (p *Player) UpdateBy(dt time.Duration) {
+	if p.paused {
+		return
+	}
+	// A tween's callback might add or remove tweens, so iterate a snapshot.
+	// Take the buffer so that UpdateBy in a callback doesn't overwrite the snapshot.
+	tweens := append(p.updatingTweens[:0], p.tweens...)
+	p.updatingTweens = nil
+	for _, e := range tweens {
+		if e.removed {
+			continue
+		}
+		e.tween.Update(dt)
+	}
+	for i := range tweens {
+		tweens[i] = nil
+	}
+	p.updatingTweens = tweens[:0]
+
+	var j int
+	for _, e := range p.tweens {
+		if e.tween.IsFinished() {
+			e.removed = true
+			continue
+		}
+		p.tweens[j] = e
+		j++
+	}
+	for i := j; i < len(p.tweens); i++ {
+		p.tweens[i] = nil
+	}
+	p.tweens = p.tweens[:j]
+}
+
+// TickDuration returns the duration of one tick, which is 1/TPS seconds.
+//
+// If TPS is ebiten.SyncWithFPS, TickDuration returns the duration of a frame with the current actual FPS,
+// or 1/60 seconds if the actual FPS is not available yet.
+func TickDuration() time.Duration {
+	tps := float64(ebiten.TPS())
+	if tps == ebiten.SyncWithFPS {
+		tps = ebiten.ActualFPS()
+	}
+	if tps <= 0 {
+		tps = 60
+	}
+	return time.Duration(float64(time.Second) / tps)
+}
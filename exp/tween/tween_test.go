@@ -0,0 +1,439 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tween_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/tween"
+)
+
+func TestEasing(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Easing tween.Easing
+		Half   float64
+	}{
+		{Name: "Linear", Easing: tween.Linear, Half: 0.5},
+		{Name: "InQuad", Easing: tween.InQuad, Half: 0.25},
+		{Name: "OutQuad", Easing: tween.OutQuad, Half: 0.75},
+		{Name: "InOutQuad", Easing: tween.InOutQuad, Half: 0.5},
+		{Name: "InCubic", Easing: tween.InCubic, Half: 0.125},
+		{Name: "OutCubic", Easing: tween.OutCubic, Half: 0.875},
+		{Name: "InOutCubic", Easing: tween.InOutCubic, Half: 0.5},
+		{Name: "InSine", Easing: tween.InSine, Half: 1 - math.Cos(math.Pi/4)},
+		{Name: "OutSine", Easing: tween.OutSine, Half: math.Sin(math.Pi / 4)},
+		{Name: "InOutSine", Easing: tween.InOutSine, Half: 0.5},
+		{Name: "InExpo", Easing: tween.InExpo, Half: math.Pow(2, -5)},
+		{Name: "OutExpo", Easing: tween.OutExpo, Half: 1 - math.Pow(2, -5)},
+		{Name: "InBack", Easing: tween.InBack, Half: math.NaN()},
+		{Name: "OutBack", Easing: tween.OutBack, Half: math.NaN()},
+		{Name: "OutElastic", Easing: tween.OutElastic, Half: math.NaN()},
+		{Name: "OutBounce", Easing: tween.OutBounce, Half: math.NaN()},
+		{Name: "InBounce", Easing: tween.InBounce, Half: math.NaN()},
+	}
+	const epsilon = 1e-9
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := tc.Easing(0); math.Abs(got) > epsilon {
+				t.Errorf("%s(0): got: %v, want: 0", tc.Name, got)
+			}
+			if got := tc.Easing(1); math.Abs(got-1) > epsilon {
+				t.Errorf("%s(1): got: %v, want: 1", tc.Name, got)
+			}
+			if math.IsNaN(tc.Half) {
+				return
+			}
+			if got := tc.Easing(0.5); math.Abs(got-tc.Half) > epsilon {
+				t.Errorf("%s(0.5): got: %v, want: %v", tc.Name, got, tc.Half)
+			}
+		})
+	}
+}
+
+func TestFloatCompletion(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Duration time.Duration
+		Steps    []time.Duration
+		Value    float64
+		Finished bool
+		Rest     time.Duration
+	}{
+		{
+			Name:     "halfway",
+			Duration: time.Second,
+			Steps:    []time.Duration{250 * time.Millisecond, 250 * time.Millisecond},
+			Value:    50,
+		},
+		{
+			Name:     "exactly finished",
+			Duration: time.Second,
+			Steps:    []time.Duration{500 * time.Millisecond, 500 * time.Millisecond},
+			Value:    100,
+			Finished: true,
+		},
+		{
+			Name:     "overshoot",
+			Duration: time.Second,
+			Steps:    []time.Duration{800 * time.Millisecond, 300 * time.Millisecond},
+			Value:    100,
+			Finished: true,
+			Rest:     100 * time.Millisecond,
+		},
+		{
+			Name:     "zero duration",
+			Duration: 0,
+			Steps:    []time.Duration{0},
+			Value:    100,
+			Finished: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			f := tween.NewFloat(0, 100, tc.Duration, nil)
+			var rest time.Duration
+			for _, dt := range tc.Steps {
+				rest = f.Update(dt)
+			}
+			if got := f.Value(); got != tc.Value {
+				t.Errorf("value: got: %v, want: %v", got, tc.Value)
+			}
+			if got := f.IsFinished(); got != tc.Finished {
+				t.Errorf("finished: got: %v, want: %v", got, tc.Finished)
+			}
+			if rest != tc.Rest {
+				t.Errorf("rest: got: %v, want: %v", rest, tc.Rest)
+			}
+
+			f.Reset()
+			if f.IsFinished() {
+				t.Errorf("IsFinished after Reset must be false")
+			}
+			// A tween with zero duration is always at its end value.
+			want := 0.0
+			if tc.Duration == 0 {
+				want = 100
+			}
+			if got := f.Value(); got != want {
+				t.Errorf("value after Reset: got: %v, want: %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCallbacks(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Steps     int
+		Updates   int
+		Completes int
+	}{
+		{Name: "not finished", Steps: 3, Updates: 3, Completes: 0},
+		{Name: "finished", Steps: 4, Updates: 4, Completes: 1},
+		{Name: "after finished", Steps: 6, Updates: 4, Completes: 1},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var updates, completes int
+			f := tween.NewFloat(0, 1, 4*time.Second, nil)
+			f.SetOnUpdate(func() {
+				updates++
+			})
+			f.SetOnComplete(func() {
+				completes++
+			})
+			for i := 0; i < tc.Steps; i++ {
+				f.Update(time.Second)
+			}
+			if updates != tc.Updates {
+				t.Errorf("updates: got: %d, want: %d", updates, tc.Updates)
+			}
+			if completes != tc.Completes {
+				t.Errorf("completes: got: %d, want: %d", completes, tc.Completes)
+			}
+		})
+	}
+}
+
+func TestSequenceCarriesOverRest(t *testing.T) {
+	a := tween.NewFloat(0, 1, time.Second, nil)
+	b := tween.NewFloat(0, 1, time.Second, nil)
+	s := tween.NewSequence(a, b)
+
+	s.Update(1500 * time.Millisecond)
+	if !a.IsFinished() {
+		t.Errorf("the first tween must be finished")
+	}
+	if got, want := b.Value(), 0.5; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if rest := s.Update(time.Second); rest != 500*time.Millisecond {
+		t.Errorf("rest: got: %v, want: %v", rest, 500*time.Millisecond)
+	}
+	if !s.IsFinished() {
+		t.Errorf("the sequence must be finished")
+	}
+}
+
+func TestPlayerRemovesFinishedTweens(t *testing.T) {
+	var p tween.Player
+	a := tween.NewFloat(0, 1, time.Second, nil)
+	b := tween.NewFloat(0, 1, 2*time.Second, nil)
+	p.Add(a, b)
+
+	p.UpdateBy(time.Second)
+	if got, want := p.Len(), 1; got != want {
+		t.Errorf("Len: got: %d, want: %d", got, want)
+	}
+	p.UpdateBy(time.Second)
+	if got, want := p.Len(), 0; got != want {
+		t.Errorf("Len: got: %d, want: %d", got, want)
+	}
+}
+
+func TestPlayerClearInCallback(t *testing.T) {
+	var p tween.Player
+
+	var updated [3]int
+	var ts []*tween.Float
+	for i := range updated {
+		i := i
+		f := tween.NewFloat(0, 1, time.Hour, nil)
+		f.SetOnUpdate(func() {
+			updated[i]++
+		})
+		ts = append(ts, f)
+		p.Add(f)
+	}
+	ts[0].SetOnUpdate(func() {
+		updated[0]++
+		p.Clear()
+	})
+
+	p.UpdateBy(time.Second)
+	if updated != [3]int{1, 0, 0} {
+		t.Errorf("got: %v, want: %v", updated, [3]int{1, 0, 0})
+	}
+	if got, want := p.Len(), 0; got != want {
+		t.Errorf("Len: got: %d, want: %d", got, want)
+	}
+
+	// Updating again must not update the cleared tweens.
+	p.UpdateBy(time.Second)
+	if updated != [3]int{1, 0, 0} {
+		t.Errorf("got: %v, want: %v", updated, [3]int{1, 0, 0})
+	}
+}
+
+func TestPlayerAddInCallback(t *testing.T) {
+	var p tween.Player
+
+	var addedUpdates int
+	added := tween.NewFloat(0, 1, time.Hour, nil)
+	added.SetOnUpdate(func() {
+		addedUpdates++
+	})
+
+	var updates int
+	f := tween.NewFloat(0, 1, time.Hour, nil)
+	f.SetOnUpdate(func() {
+		updates++
+		if updates == 1 {
+			p.Add(added)
+		}
+	})
+	p.Add(f)
+
+	// A tween added in a callback is updated from the next update, and each tween is updated exactly once.
+	p.UpdateBy(time.Second)
+	if updates != 1 || addedUpdates != 0 {
+		t.Errorf("got: (%d, %d), want: (1, 0)", updates, addedUpdates)
+	}
+	p.UpdateBy(time.Second)
+	if updates != 2 || addedUpdates != 1 {
+		t.Errorf("got: (%d, %d), want: (2, 1)", updates, addedUpdates)
+	}
+	if got, want := p.Len(), 2; got != want {
+		t.Errorf("Len: got: %d, want: %d", got, want)
+	}
+}
+
+func TestPlayerRemove(t *testing.T) {
+	var p tween.Player
+	a := tween.NewFloat(0, 1, time.Hour, nil)
+	b := tween.NewFloat(0, 1, time.Hour, nil)
+	p.Add(a, b)
+
+	p.Remove(a)
+	if got, want := p.Len(), 1; got != want {
+		t.Errorf("Len: got: %d, want: %d", got, want)
+	}
+	// Removing a tween not in the player does nothing.
+	p.Remove(a)
+	if got, want := p.Len(), 1; got != want {
+		t.Errorf("Len: got: %d, want: %d", got, want)
+	}
+
+	p.UpdateBy(time.Second)
+	if got := a.Value(); got != 0 {
+		t.Errorf("a removed tween must not be updated: got: %v", got)
+	}
+	if got := b.Value(); got == 0 {
+		t.Errorf("a tween in the player must be updated")
+	}
+}
+
+func TestPlayerRemoveInCallback(t *testing.T) {
+	var p tween.Player
+
+	var updated [3]int
+	var ts []*tween.Float
+	for i := range updated {
+		i := i
+		f := tween.NewFloat(0, 1, time.Hour, nil)
+		f.SetOnUpdate(func() {
+			updated[i]++
+		})
+		ts = append(ts, f)
+		p.Add(f)
+	}
+	// The first tween removes itself and the second tween.
+	ts[0].SetOnUpdate(func() {
+		updated[0]++
+		p.Remove(ts[0])
+		p.Remove(ts[1])
+	})
+
+	p.UpdateBy(time.Second)
+	if want := [3]int{1, 0, 1}; updated != want {
+		t.Errorf("got: %v, want: %v", updated, want)
+	}
+	if got, want := p.Len(), 1; got != want {
+		t.Errorf("Len: got: %d, want: %d", got, want)
+	}
+
+	p.UpdateBy(time.Second)
+	if want := [3]int{1, 0, 2}; updated != want {
+		t.Errorf("got: %v, want: %v", updated, want)
+	}
+}
+
+func TestPlayerAfter(t *testing.T) {
+	var p tween.Player
+
+	var called int
+	p.After(2*time.Second, func() {
+		called++
+	})
+	p.UpdateBy(time.Second)
+	if called != 0 {
+		t.Errorf("called: got: %d, want: 0", called)
+	}
+	p.UpdateBy(time.Second)
+	if called != 1 {
+		t.Errorf("called: got: %d, want: 1", called)
+	}
+	p.UpdateBy(time.Second)
+	if called != 1 {
+		t.Errorf("called: got: %d, want: 1", called)
+	}
+	if got, want := p.Len(), 0; got != want {
+		t.Errorf("Len: got: %d, want: %d", got, want)
+	}
+}
+
+func TestPlayerEvery(t *testing.T) {
+	var p tween.Player
+
+	var called int
+	timer := p.Every(time.Second, func() {
+		called++
+	})
+	p.UpdateBy(time.Second)
+	if called != 1 {
+		t.Errorf("called: got: %d, want: 1", called)
+	}
+	// The remaining time is carried over.
+	p.UpdateBy(2500 * time.Millisecond)
+	if called != 3 {
+		t.Errorf("called: got: %d, want: 3", called)
+	}
+	p.UpdateBy(500 * time.Millisecond)
+	if called != 4 {
+		t.Errorf("called: got: %d, want: 4", called)
+	}
+
+	p.Remove(timer)
+	p.UpdateBy(time.Second)
+	if called != 4 {
+		t.Errorf("called after Remove: got: %d, want: 4", called)
+	}
+}
+
+func TestPlayerTimersInCallbacks(t *testing.T) {
+	var p tween.Player
+
+	var after, every int
+	var timer tween.Tween
+	p.After(time.Second, func() {
+		// Start timers from a timer's callback.
+		p.After(time.Second, func() {
+			after++
+		})
+		timer = p.Every(time.Second, func() {
+			every++
+			if every == 2 {
+				// Stop the timer from its own callback.
+				p.Remove(timer)
+			}
+		})
+	})
+
+	p.UpdateBy(time.Second)
+	if after != 0 || every != 0 {
+		t.Errorf("got: (%d, %d), want: (0, 0)", after, every)
+	}
+	p.UpdateBy(time.Second)
+	if after != 1 || every != 1 {
+		t.Errorf("got: (%d, %d), want: (1, 1)", after, every)
+	}
+	p.UpdateBy(time.Second)
+	if after != 1 || every != 2 {
+		t.Errorf("got: (%d, %d), want: (1, 2)", after, every)
+	}
+	p.UpdateBy(time.Second)
+	if after != 1 || every != 2 {
+		t.Errorf("got: (%d, %d), want: (1, 2)", after, every)
+	}
+	if got, want := p.Len(), 0; got != want {
+		t.Errorf("Len: got: %d, want: %d", got, want)
+	}
+}
+
+func TestPlayerEveryWithNonPositiveInterval(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Every with a non-positive interval must panic")
+		}
+	}()
+	var p tween.Player
+	p.Every(0, func() {})
+}
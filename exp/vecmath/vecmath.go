@@ -0,0 +1,226 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vecmath provides small 2D vector and rectangle types in float64.
+// This package is experimental and the API might be changed in the future.
+//
+// The types are values and all the operations return new values,
+// so they can be used in the same way as image.Point and image.Rectangle.
+package vecmath
+
+import (
+	"image"
+	"math"
+)
+
+// Vec2 is a 2D vector.
+type Vec2 struct {
+	X float64
+	Y float64
+}
+
+// V returns a Vec2 with the given components.
+func V(x, y float64) Vec2 {
+	return Vec2{X: x, Y: y}
+}
+
+// Add returns v + w.
+func (v Vec2) Add(w Vec2) Vec2 {
+	return Vec2{X: v.X + w.X, Y: v.Y + w.Y}
+}
+
+// Sub returns v - w.
+func (v Vec2) Sub(w Vec2) Vec2 {
+	return Vec2{X: v.X - w.X, Y: v.Y - w.Y}
+}
+
+// Scale returns v multiplied by s.
+func (v Vec2) Scale(s float64) Vec2 {
+	return Vec2{X: v.X * s, Y: v.Y * s}
+}
+
+// Dot returns the dot product of v and w.
+func (v Vec2) Dot(w Vec2) float64 {
+	return v.X*w.X + v.Y*w.Y
+}
+
+// Cross returns the z component of the cross product of v and w.
+func (v Vec2) Cross(w Vec2) float64 {
+	return v.X*w.Y - v.Y*w.X
+}
+
+// Len returns the length of v.
+func (v Vec2) Len() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+
+// Normalize returns the unit vector in the direction of v.
+// If v is zero, Normalize returns zero.
+func (v Vec2) Normalize() Vec2 {
+	l := v.Len()
+	if l == 0 {
+		return Vec2{}
+	}
+	return Vec2{X: v.X / l, Y: v.Y / l}
+}
+
+// Rotate returns v rotated by theta. The unit is radian.
+func (v Vec2) Rotate(theta float64) Vec2 {
+	sin, cos := math.Sincos(theta)
+	return Vec2{X: v.X*cos - v.Y*sin, Y: v.X*sin + v.Y*cos}
+}
+
+// Lerp returns the linear interpolation between v and w by t.
+func (v Vec2) Lerp(w Vec2, t float64) Vec2 {
+	return Vec2{X: v.X + (w.X-v.X)*t, Y: v.Y + (w.Y-v.Y)*t}
+}
+
+// Clamp returns v clamped into r.
+//
+// Unlike Contains, Clamp treats r.Max as inclusive: a v beyond r.Max is clamped to r.Max,
+// so the result is not necessarily contained in r.
+// If r is empty, Clamp returns r.Min.
+func (v Vec2) Clamp(r Rect) Vec2 {
+	if r.Empty() {
+		return r.Min
+	}
+	return Vec2{X: math.Min(math.Max(v.X, r.Min.X), r.Max.X), Y: math.Min(math.Max(v.Y, r.Min.Y), r.Max.Y)}
+}
+
+// Transformer is an interface to transform a point.
+//
+// *ebiten.GeoM implements Transformer. This package doesn't depend on the ebiten package.
+type Transformer interface {
+	Apply(x, y float64) (float64, float64)
+}
+
+// Apply returns v transformed by t.
+func (v Vec2) Apply(t Transformer) Vec2 {
+	x, y := t.Apply(v.X, v.Y)
+	return Vec2{X: x, Y: y}
+}
+
+// Rect is a rectangle with the minimum point (inclusive) and the maximum point (exclusive).
+//
+// A Rect is well-formed if Min.X <= Max.X and Min.Y <= Max.Y, like image.Rectangle.
+type Rect struct {
+	Min Vec2
+	Max Vec2
+}
+
+// R returns a Rect with the given coordinates. The coordinates are swapped if necessary to be well-formed.
+func R(x0, y0, x1, y1 float64) Rect {
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	if y0 > y1 {
+		y0, y1 = y1, y0
+	}
+	return Rect{Min: Vec2{X: x0, Y: y0}, Max: Vec2{X: x1, Y: y1}}
+}
+
+// FromImageRectangle returns a Rect with the same coordinates as r.
+func FromImageRectangle(r image.Rectangle) Rect {
+	return Rect{
+		Min: Vec2{X: float64(r.Min.X), Y: float64(r.Min.Y)},
+		Max: Vec2{X: float64(r.Max.X), Y: float64(r.Max.Y)},
+	}
+}
+
+// ImageRectangle returns the smallest image.Rectangle that contains r.
+func (r Rect) ImageRectangle() image.Rectangle {
+	return image.Rect(int(math.Floor(r.Min.X)), int(math.Floor(r.Min.Y)), int(math.Ceil(r.Max.X)), int(math.Ceil(r.Max.Y)))
+}
+
+// Dx returns r's width.
+func (r Rect) Dx() float64 {
+	return r.Max.X - r.Min.X
+}
+
+// Dy returns r's height.
+func (r Rect) Dy() float64 {
+	return r.Max.Y - r.Min.Y
+}
+
+// Size returns r's width and height as a Vec2.
+func (r Rect) Size() Vec2 {
+	return r.Max.Sub(r.Min)
+}
+
+// Center returns the center point of r.
+func (r Rect) Center() Vec2 {
+	return r.Min.Lerp(r.Max, 0.5)
+}
+
+// Empty reports whether r contains no points.
+func (r Rect) Empty() bool {
+	return r.Min.X >= r.Max.X || r.Min.Y >= r.Max.Y
+}
+
+// Contains reports whether p is in r.
+func (r Rect) Contains(p Vec2) bool {
+	return r.Min.X <= p.X && p.X < r.Max.X && r.Min.Y <= p.Y && p.Y < r.Max.Y
+}
+
+// Add returns r translated by v.
+func (r Rect) Add(v Vec2) Rect {
+	return Rect{Min: r.Min.Add(v), Max: r.Max.Add(v)}
+}
+
+// Intersect returns the largest rectangle contained by both r and s.
+// If the two rectangles don't overlap, Intersect returns the zero rectangle.
+func (r Rect) Intersect(s Rect) Rect {
+	r.Min.X = math.Max(r.Min.X, s.Min.X)
+	r.Min.Y = math.Max(r.Min.Y, s.Min.Y)
+	r.Max.X = math.Min(r.Max.X, s.Max.X)
+	r.Max.Y = math.Min(r.Max.Y, s.Max.Y)
+	if r.Empty() {
+		return Rect{}
+	}
+	return r
+}
+
+// Overlaps reports whether r and s have a non-empty intersection.
+func (r Rect) Overlaps(s Rect) bool {
+	return !r.Empty() && !s.Empty() &&
+		r.Min.X < s.Max.X && s.Min.X < r.Max.X &&
+		r.Min.Y < s.Max.Y && s.Min.Y < r.Max.Y
+}
+
+// Union returns the smallest rectangle that contains both r and s.
+func (r Rect) Union(s Rect) Rect {
+	if r.Empty() {
+		return s
+	}
+	if s.Empty() {
+		return r
+	}
+	r.Min.X = math.Min(r.Min.X, s.Min.X)
+	r.Min.Y = math.Min(r.Min.Y, s.Min.Y)
+	r.Max.X = math.Max(r.Max.X, s.Max.X)
+	r.Max.Y = math.Max(r.Max.Y, s.Max.Y)
+	return r
+}
+
+// Apply returns the bounding box of r transformed by t.
+func (r Rect) Apply(t Transformer) Rect {
+	p0 := r.Min.Apply(t)
+	p1 := Vec2{X: r.Max.X, Y: r.Min.Y}.Apply(t)
+	p2 := Vec2{X: r.Min.X, Y: r.Max.Y}.Apply(t)
+	p3 := r.Max.Apply(t)
+	return Rect{
+		Min: Vec2{X: math.Min(math.Min(p0.X, p1.X), math.Min(p2.X, p3.X)), Y: math.Min(math.Min(p0.Y, p1.Y), math.Min(p2.Y, p3.Y))},
+		Max: Vec2{X: math.Max(math.Max(p0.X, p1.X), math.Max(p2.X, p3.X)), Y: math.Max(math.Max(p0.Y, p1.Y), math.Max(p2.Y, p3.Y))},
+	}
+}
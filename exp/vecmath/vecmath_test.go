@@ -0,0 +1,119 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vecmath_test
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/exp/vecmath"
+)
+
+type transformerFunc func(x, y float64) (float64, float64)
+
+func (f transformerFunc) Apply(x, y float64) (float64, float64) {
+	return f(x, y)
+}
+
+func TestVec2(t *testing.T) {
+	v := vecmath.V(3, 4)
+	w := vecmath.V(1, -2)
+
+	if got, want := v.Add(w), vecmath.V(4, 2); got != want {
+		t.Errorf("Add: got: %v, want: %v", got, want)
+	}
+	if got, want := v.Sub(w), vecmath.V(2, 6); got != want {
+		t.Errorf("Sub: got: %v, want: %v", got, want)
+	}
+	if got, want := v.Dot(w), -5.0; got != want {
+		t.Errorf("Dot: got: %v, want: %v", got, want)
+	}
+	if got, want := v.Cross(w), -10.0; got != want {
+		t.Errorf("Cross: got: %v, want: %v", got, want)
+	}
+	if got, want := v.Len(), 5.0; got != want {
+		t.Errorf("Len: got: %v, want: %v", got, want)
+	}
+	if got, want := v.Normalize(), vecmath.V(0.6, 0.8); math.Abs(got.X-want.X) > 1e-9 || math.Abs(got.Y-want.Y) > 1e-9 {
+		t.Errorf("Normalize: got: %v, want: %v", got, want)
+	}
+	if got, want := (vecmath.Vec2{}).Normalize(), (vecmath.Vec2{}); got != want {
+		t.Errorf("Normalize for zero: got: %v, want: %v", got, want)
+	}
+	if got, want := v.Lerp(w, 0.5), vecmath.V(2, 1); got != want {
+		t.Errorf("Lerp: got: %v, want: %v", got, want)
+	}
+	if got, want := v.Clamp(vecmath.R(0, 0, 2, 2)), vecmath.V(2, 2); got != want {
+		t.Errorf("Clamp: got: %v, want: %v", got, want)
+	}
+	if got, want := vecmath.V(1, 0).Rotate(math.Pi/2), vecmath.V(0, 1); math.Abs(got.X-want.X) > 1e-9 || math.Abs(got.Y-want.Y) > 1e-9 {
+		t.Errorf("Rotate: got: %v, want: %v", got, want)
+	}
+
+	g := transformerFunc(func(x, y float64) (float64, float64) {
+		return 2*x + 1, 3*y + 1
+	})
+	if got, want := v.Apply(g), vecmath.V(7, 13); got != want {
+		t.Errorf("Apply: got: %v, want: %v", got, want)
+	}
+}
+
+func TestRect(t *testing.T) {
+	r := vecmath.R(4, 4, 0, 0)
+	if got, want := r, (vecmath.Rect{Max: vecmath.V(4, 4)}); got != want {
+		t.Errorf("R: got: %v, want: %v", got, want)
+	}
+
+	s := vecmath.R(2, 1, 6, 3)
+	if got, want := r.Intersect(s), vecmath.R(2, 1, 4, 3); got != want {
+		t.Errorf("Intersect: got: %v, want: %v", got, want)
+	}
+	if got, want := r.Intersect(vecmath.R(5, 5, 6, 6)), (vecmath.Rect{}); got != want {
+		t.Errorf("Intersect without overlapping: got: %v, want: %v", got, want)
+	}
+	if !r.Overlaps(s) {
+		t.Errorf("Overlaps: got: false, want: true")
+	}
+	if r.Overlaps(vecmath.R(4, 0, 5, 4)) {
+		t.Errorf("Overlaps for adjacent rectangles: got: true, want: false")
+	}
+	if got, want := r.Union(s), vecmath.R(0, 0, 6, 4); got != want {
+		t.Errorf("Union: got: %v, want: %v", got, want)
+	}
+	if !r.Contains(vecmath.V(0, 0)) || r.Contains(vecmath.V(4, 0)) {
+		t.Errorf("Contains must include Min and exclude Max")
+	}
+	if got, want := r.Center(), vecmath.V(2, 2); got != want {
+		t.Errorf("Center: got: %v, want: %v", got, want)
+	}
+
+	if got, want := vecmath.R(0.5, -0.5, 2.5, 1.5).ImageRectangle(), image.Rect(0, -1, 3, 2); got != want {
+		t.Errorf("ImageRectangle: got: %v, want: %v", got, want)
+	}
+	if got, want := vecmath.FromImageRectangle(image.Rect(1, 2, 3, 4)), vecmath.R(1, 2, 3, 4); got != want {
+		t.Errorf("FromImageRectangle: got: %v, want: %v", got, want)
+	}
+
+	// Rotate by 90 degrees.
+	g := transformerFunc(func(x, y float64) (float64, float64) {
+		return -y, x
+	})
+	got := vecmath.R(0, 0, 2, 1).Apply(g)
+	want := vecmath.R(-1, 0, 0, 2)
+	if math.Abs(got.Min.X-want.Min.X) > 1e-9 || math.Abs(got.Min.Y-want.Min.Y) > 1e-9 || math.Abs(got.Max.X-want.Max.X) > 1e-9 || math.Abs(got.Max.Y-want.Max.Y) > 1e-9 {
+		t.Errorf("Apply: got: %v, want: %v", got, want)
+	}
+}
@@ -14,6 +14,72 @@
 
 package ebiten
 
+import (
+	"image"
+	"image/color"
+	"io"
+)
+
 var (
 	ImageToBytes = imageToBytes
 )
+
+func CaptureForTesting(screen *Image, transparent bool) {
+	theCapturer.capture(screen, transparent)
+}
+
+const MaxQueuedVideoFramesForTesting = maxQueuedVideoFrames
+
+func LetterboxGameRegionForTesting(screenBounds, offscreenBounds image.Rectangle, scale, offsetX, offsetY float64) image.Rectangle {
+	return letterboxGameRegion(screenBounds, offscreenBounds, scale, offsetX, offsetY)
+}
+
+func PostEffectRegionForTesting(screenBounds image.Rectangle, geoM GeoM, gameWidth, gameHeight int) image.Rectangle {
+	return postEffectRegion(screenBounds, geoM, gameWidth, gameHeight)
+}
+
+type PostEffectChainForTesting struct {
+	chain postEffectChain
+}
+
+func (p *PostEffectChainForTesting) Draw(screen *Image, geoM GeoM, gameWidth, gameHeight int, drawGame func(dst *Image, geoM GeoM)) bool {
+	return p.chain.draw(screen, geoM, gameWidth, gameHeight, drawGame)
+}
+
+func BlendColorToInternalForTesting(blend Blend, clr color.Color) [4]float32 {
+	return blendColorToInternal(blend.internalBlend(), clr)
+}
+
+func NinePatchGridLinesForTesting(start, end int, inset0, inset1 int) [4]float64 {
+	return ninePatchGridLines(start, end, inset0, inset1)
+}
+
+type ImageLeakTrackerForTesting = imageLeakTracker
+
+// NewImageLeakTrackerForTesting creates a tracker. The caller of NewImageLeakTrackerForTesting is regarded as the creator.
+func NewImageLeakTrackerForTesting(width, height int) *ImageLeakTrackerForTesting {
+	return newImageLeakTrackerForTesting(width, height)
+}
+
+// newImageLeakTrackerForTesting is an additional frame corresponding to newImage.
+func newImageLeakTrackerForTesting(width, height int) *ImageLeakTrackerForTesting {
+	return newImageLeakTracker(width, height)
+}
+
+func (t *ImageLeakTrackerForTesting) ReleaseForTesting() {
+	t.release()
+}
+
+func (t *ImageLeakTrackerForTesting) ReportToForTesting(w io.Writer) {
+	t.reportTo(w)
+}
+
+func SetImageLeakReportWriterForTesting(w io.Writer) (restore func()) {
+	orig := imageLeakReportWriter
+	imageLeakReportWriter = w
+	return func() {
+		imageLeakReportWriter = orig
+	}
+}
+
+const ProjectiveShaderSrcForTesting = projectiveShaderSrc
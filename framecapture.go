@@ -0,0 +1,84 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
+)
+
+// CaptureNextFrame requests to write a report of the internal graphics commands in the next frame to w in JSON.
+//
+// The report has the executed commands in order with their destination and source images, shaders, and the numbers of vertices.
+// This is useful to know why a frame is slow, e.g. why draw calls are not merged.
+// The format of the report is not stable and might be changed in the future.
+//
+// w is written after the next frame is rendered, and might be written from a different goroutine from the caller's.
+// If writing to w fails, RunGame returns the error.
+//
+// Instead of calling CaptureNextFrame, you can also specify a key to capture a frame with
+// the environment variable EBITENGINE_FRAME_CAPTURE_KEY.
+//
+// CaptureNextFrame is concurrent-safe.
+func CaptureNextFrame(w io.Writer) {
+	graphicscommand.RequestFrameCapture(func(capture *graphicscommand.FrameCapture) error {
+		return writeFrameCapture(w, capture)
+	})
+}
+
+func writeFrameCapture(w io.Writer, capture *graphicscommand.FrameCapture) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	if err := e.Encode(capture); err != nil {
+		return fmt.Errorf("ebiten: writing a frame capture failed: %w", err)
+	}
+	return nil
+}
+
+func captureFrameToFile() error {
+	name := "framecapture_" + datetimeForFilename() + ".json"
+	// Use the home directory for mobiles as a provisional implementation.
+	if runtime.GOOS == "android" || runtime.GOOS == "ios" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		name = filepath.Join(home, name)
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	graphicscommand.RequestFrameCapture(func(capture *graphicscommand.FrameCapture) error {
+		if err := writeFrameCapture(f, capture); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(os.Stderr, "Saved frame capture: %s\n", name); err != nil {
+			return err
+		}
+		return nil
+	})
+	return nil
+}
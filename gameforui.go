@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"image"
 	"math"
+	"sync"
 	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
@@ -38,6 +39,13 @@ type gameForUI struct {
 	screenShader *Shader
 	imageDumper  imageDumper
 	transparent  bool
+	postEffects  postEffectChain
+
+	backGestureEvents []ui.BackGestureEvent
+
+	// nextGame is the game to replace game at the next layout.
+	nextGame  Game
+	nextGameM sync.Mutex
 }
 
 func newGameForUI(game Game, transparent bool) *gameForUI {
@@ -84,7 +92,24 @@ func (g *gameForUI) NewScreenImage(width, height int) *ui.Image {
 	return g.screen.image
 }
 
+// replaceGame replaces the game with the given game at the next frame.
+//
+// replaceGame is concurrent-safe.
+func (g *gameForUI) replaceGame(game Game) {
+	g.nextGameM.Lock()
+	defer g.nextGameM.Unlock()
+	g.nextGame = game
+}
+
 func (g *gameForUI) Layout(outsideWidth, outsideHeight float64) (float64, float64) {
+	// Layout is the first function called in a frame. Replace the game here.
+	g.nextGameM.Lock()
+	if g.nextGame != nil {
+		g.game = g.nextGame
+		g.nextGame = nil
+	}
+	g.nextGameM.Unlock()
+
 	if l, ok := g.game.(LayoutFer); ok {
 		return l.LayoutF(outsideWidth, outsideHeight)
 	}
@@ -109,6 +134,16 @@ func (g *gameForUI) UpdateInputState(fn func(*ui.InputState)) {
 }
 
 func (g *gameForUI) Update() error {
+	g.backGestureEvents = ui.Get().AppendBackGestureEvents(g.backGestureEvents[:0])
+	if h, ok := g.game.(BackGestureHandler); ok {
+		for _, e := range g.backGestureEvents {
+			h.HandleBackGesture(BackGestureEvent{
+				Phase:    e.Phase,
+				Progress: e.Progress,
+			})
+		}
+	}
+
 	if err := g.game.Update(); err != nil {
 		return err
 	}
@@ -123,6 +158,7 @@ func (g *gameForUI) DrawOffscreen() error {
 	if err := g.imageDumper.dump(g.offscreen, g.transparent); err != nil {
 		return err
 	}
+	theCapturer.capture(g.offscreen, g.transparent)
 	return nil
 }
 
@@ -131,8 +167,51 @@ func (g *gameForUI) DrawFinalScreen(scale, offsetX, offsetY float64) {
 	geoM.Scale(scale, scale)
 	geoM.Translate(offsetX, offsetY)
 
+	if d, ok := g.game.(LetterboxDrawer); ok {
+		d.DrawLetterbox(g.screen, letterboxGameRegion(g.screen.Bounds(), g.offscreen.Bounds(), scale, offsetX, offsetY))
+	}
+
+	w, h := g.offscreen.Bounds().Dx(), g.offscreen.Bounds().Dy()
+	if !g.postEffects.draw(g.screen, geoM, w, h, func(dst *Image, geoM GeoM) {
+		g.drawOffscreenOnFinalScreen(dst, scale, geoM)
+	}) {
+		g.drawOffscreenOnFinalScreen(g.screen, scale, geoM)
+	}
+
+	if d, ok := g.game.(LateDrawer); ok {
+		x, y := ui.Get().LatestCursorPosition()
+		d.DrawLate(g.screen, geoM, x, y)
+	}
+}
+
+// letterboxGameRegion returns the region of the screen where the offscreen is rendered with the given scale and offsets.
+func letterboxGameRegion(screenBounds, offscreenBounds image.Rectangle, scale, offsetX, offsetY float64) image.Rectangle {
+	w, h := offscreenBounds.Dx(), offscreenBounds.Dy()
+	r := image.Rect(
+		int(math.Floor(offsetX)),
+		int(math.Floor(offsetY)),
+		int(math.Ceil(offsetX+float64(w)*scale)),
+		int(math.Ceil(offsetY+float64(h)*scale)))
+	return r.Intersect(screenBounds)
+}
+
+func (g *gameForUI) ChangeAppLifecycleState(state ui.AppLifecycleState) error {
+	s, ok := g.game.(Suspender)
+	if !ok {
+		return nil
+	}
+	switch state {
+	case ui.AppLifecycleStateForeground:
+		return s.OnResume()
+	case ui.AppLifecycleStateBackground:
+		return s.OnSuspend()
+	}
+	return nil
+}
+
+func (g *gameForUI) drawOffscreenOnFinalScreen(screen *Image, scale float64, geoM GeoM) {
 	if d, ok := g.game.(FinalScreenDrawer); ok {
-		d.DrawFinalScreen(g.screen, g.offscreen, geoM)
+		d.DrawFinalScreen(screen, g.offscreen, geoM)
 		return
 	}
 
@@ -140,17 +219,17 @@ func (g *gameForUI) DrawFinalScreen(scale, offsetX, offsetY float64) {
 	case !screenFilterEnabled.Load(), math.Floor(scale) == scale:
 		op := &DrawImageOptions{}
 		op.GeoM = geoM
-		g.screen.DrawImage(g.offscreen, op)
+		screen.DrawImage(g.offscreen, op)
 	case scale < 1:
 		op := &DrawImageOptions{}
 		op.GeoM = geoM
 		op.Filter = FilterLinear
-		g.screen.DrawImage(g.offscreen, op)
+		screen.DrawImage(g.offscreen, op)
 	default:
 		op := &DrawRectShaderOptions{}
 		op.Images[0] = g.offscreen
 		op.GeoM = geoM
 		w, h := g.offscreen.Bounds().Dx(), g.offscreen.Bounds().Dy()
-		g.screen.DrawRectShader(w, h, g.screenShader, op)
+		screen.DrawRectShader(w, h, g.screenShader, op)
 	}
 }
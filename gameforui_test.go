@@ -0,0 +1,84 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestLetterboxGameRegion(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Screen    image.Rectangle
+		Offscreen image.Rectangle
+		Scale     float64
+		OffsetX   float64
+		OffsetY   float64
+		Want      image.Rectangle
+	}{
+		{
+			Name:      "same size",
+			Screen:    image.Rect(0, 0, 320, 240),
+			Offscreen: image.Rect(0, 0, 320, 240),
+			Scale:     1,
+			Want:      image.Rect(0, 0, 320, 240),
+		},
+		{
+			Name:      "pillarbox",
+			Screen:    image.Rect(0, 0, 800, 480),
+			Offscreen: image.Rect(0, 0, 320, 240),
+			Scale:     2,
+			OffsetX:   80,
+			Want:      image.Rect(80, 0, 720, 480),
+		},
+		{
+			Name:      "letterbox",
+			Screen:    image.Rect(0, 0, 640, 600),
+			Offscreen: image.Rect(0, 0, 320, 240),
+			Scale:     2,
+			OffsetY:   60,
+			Want:      image.Rect(0, 60, 640, 540),
+		},
+		{
+			Name:      "fractional",
+			Screen:    image.Rect(0, 0, 500, 300),
+			Offscreen: image.Rect(0, 0, 320, 240),
+			Scale:     1.25,
+			OffsetX:   49.5,
+			Want:      image.Rect(49, 0, 450, 300),
+		},
+		{
+			Name:      "clipped",
+			Screen:    image.Rect(0, 0, 300, 200),
+			Offscreen: image.Rect(0, 0, 320, 240),
+			Scale:     1,
+			OffsetX:   -10,
+			OffsetY:   -20,
+			Want:      image.Rect(0, 0, 300, 200),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			got := ebiten.LetterboxGameRegionForTesting(tc.Screen, tc.Offscreen, tc.Scale, tc.OffsetX, tc.OffsetY)
+			if got != tc.Want {
+				t.Errorf("got: %v, want: %v", got, tc.Want)
+			}
+		})
+	}
+}
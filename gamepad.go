@@ -20,6 +20,10 @@ import (
 )
 
 // GamepadButton represents a gamepad button.
+//
+// A gamepad like a joystick or a HOTAS can have more buttons than GamepadButtonMax.
+// Such buttons are available with values in [0, GamepadButtonCount(id)), even if they exceed GamepadButtonMax.
+// So far, such buttons are available only with DirectInput on Windows.
 type GamepadButton = gamepad.Button
 
 // GamepadButtons
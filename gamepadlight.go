@@ -0,0 +1,58 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
+)
+
+// IsGamepadLightBarAvailable reports whether the gamepad (id) has a light bar or LEDs whose color can be changed,
+// e.g. DualSense and DualShock 4.
+//
+// IsGamepadLightBarAvailable is Linux-only. The kernel drivers must expose the LEDs, e.g. hid-playstation and hid-sony,
+// and the LEDs' brightness files must be writable.
+// On the other environments including Windows, macOS and browsers, IsGamepadLightBarAvailable always returns false.
+//
+// IsGamepadLightBarAvailable is concurrent-safe.
+func IsGamepadLightBarAvailable(id GamepadID) bool {
+	g := gamepad.Get(id)
+	if g == nil {
+		return false
+	}
+	return g.HasLightBar()
+}
+
+// SetGamepadLightBarColor sets the color of the gamepad (id)'s light bar.
+// This is useful to indicate e.g. a player's color or health.
+//
+// The alpha value of clr is ignored.
+//
+// SetGamepadLightBarColor is Linux-only, and does nothing if the light bar is not available.
+// See IsGamepadLightBarAvailable.
+//
+// SetGamepadLightBarColor writes the LEDs' files in sysfs only when the color changes,
+// so calling SetGamepadLightBarColor with the same color every tick is cheap.
+//
+// SetGamepadLightBarColor is concurrent-safe.
+func SetGamepadLightBarColor(id GamepadID, clr color.Color) {
+	g := gamepad.Get(id)
+	if g == nil {
+		return
+	}
+	c := color.NRGBAModel.Convert(clr).(color.NRGBA)
+	g.SetLightBarColor(c.R, c.G, c.B)
+}
@@ -0,0 +1,215 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepaddb"
+)
+
+// GamepadMappingSourceType represents a type of a gamepad's input mapped to the standard layout.
+type GamepadMappingSourceType int
+
+const (
+	// GamepadMappingSourceButton represents a button e.g. GamepadButton0.
+	GamepadMappingSourceButton GamepadMappingSourceType = iota
+
+	// GamepadMappingSourceAxis represents an axis e.g. GamepadAxis0.
+	GamepadMappingSourceAxis
+
+	// GamepadMappingSourceHat represents a direction of a hat, i.e. a directional pad.
+	GamepadMappingSourceHat
+)
+
+// GamepadMappingAxisRange represents a range of an axis used for a mapping.
+type GamepadMappingAxisRange int
+
+const (
+	// GamepadMappingAxisRangeFull uses the full range of the axis, i.e. [-1, 1].
+	GamepadMappingAxisRangeFull GamepadMappingAxisRange = iota
+
+	// GamepadMappingAxisRangePositive uses only the positive half of the axis, i.e. [0, 1].
+	// This is useful to map an axis to a trigger.
+	GamepadMappingAxisRangePositive
+
+	// GamepadMappingAxisRangeNegative uses only the negative half of the axis, i.e. [-1, 0].
+	// The value is inverted so that 0 doesn't press a button.
+	GamepadMappingAxisRangeNegative
+)
+
+// Hat directions for GamepadMappingSource's HatState.
+const (
+	GamepadHatUp    = gamepaddb.HatUp
+	GamepadHatRight = gamepaddb.HatRight
+	GamepadHatDown  = gamepaddb.HatDown
+	GamepadHatLeft  = gamepaddb.HatLeft
+)
+
+// GamepadMappingSource represents a gamepad's input mapped to a standard button or a standard axis.
+type GamepadMappingSource struct {
+	// Type is the type of the input.
+	Type GamepadMappingSourceType
+
+	// Index is the index of the button, the axis or the hat.
+	Index int
+
+	// HatState is the direction of the hat, e.g. GamepadHatUp.
+	// HatState is used only when Type is GamepadMappingSourceHat.
+	HatState int
+
+	// AxisRange is the range of the axis.
+	// AxisRange is used only when Type is GamepadMappingSourceAxis.
+	//
+	// The default (zero) value is GamepadMappingAxisRangeFull.
+	AxisRange GamepadMappingAxisRange
+
+	// Inverted represents whether the axis value is inverted.
+	// Inverted is used only when Type is GamepadMappingSourceAxis.
+	Inverted bool
+}
+
+// sdlString returns the mapping element in the SDL_GameControllerDB format.
+// sdlString returns an error when the source is invalid or doesn't exist in the gamepad gp.
+func (g *GamepadMappingSource) sdlString(gp *gamepad.Gamepad) (string, error) {
+	if g.Index < 0 {
+		return "", fmt.Errorf("ebiten: Index must be non-negative but %d", g.Index)
+	}
+
+	switch g.Type {
+	case GamepadMappingSourceButton:
+		if n := gp.ButtonCount(); g.Index >= n {
+			return "", fmt.Errorf("ebiten: button %d doesn't exist in the gamepad with %d buttons", g.Index, n)
+		}
+		return "b" + strconv.Itoa(g.Index), nil
+	case GamepadMappingSourceAxis:
+		if n := gp.AxisCount(); g.Index >= n {
+			return "", fmt.Errorf("ebiten: axis %d doesn't exist in the gamepad with %d axes", g.Index, n)
+		}
+		var str string
+		switch g.AxisRange {
+		case GamepadMappingAxisRangeFull:
+			str = "a"
+		case GamepadMappingAxisRangePositive:
+			str = "+a"
+		case GamepadMappingAxisRangeNegative:
+			str = "-a"
+		default:
+			return "", fmt.Errorf("ebiten: invalid AxisRange: %d", g.AxisRange)
+		}
+		str += strconv.Itoa(g.Index)
+		if g.Inverted {
+			str += "~"
+		}
+		return str, nil
+	case GamepadMappingSourceHat:
+		if n := gp.HatCount(); g.Index >= n {
+			return "", fmt.Errorf("ebiten: hat %d doesn't exist in the gamepad with %d hats", g.Index, n)
+		}
+		if g.HatState <= 0 || g.HatState&^(GamepadHatUp|GamepadHatRight|GamepadHatDown|GamepadHatLeft) != 0 {
+			return "", fmt.Errorf("ebiten: invalid HatState: %d", g.HatState)
+		}
+		return "h" + strconv.Itoa(g.Index) + "." + strconv.Itoa(g.HatState), nil
+	default:
+		return "", fmt.Errorf("ebiten: invalid Type: %d", g.Type)
+	}
+}
+
+// StandardGamepadLayoutMapping represents a mapping from a gamepad's inputs to the standard gamepad layout.
+type StandardGamepadLayoutMapping struct {
+	// Buttons is a mapping for the standard buttons.
+	Buttons map[StandardGamepadButton]GamepadMappingSource
+
+	// Axes is a mapping for the standard axes.
+	Axes map[StandardGamepadAxis]GamepadMappingSource
+}
+
+// SetStandardGamepadLayoutMapping sets a custom mapping to the standard gamepad layout for the gamepad (id).
+// This is useful to let the player remap a gamepad which is not in the mapping database, or is mapped wrongly.
+//
+// The mapping is applied to all the gamepads with the same GamepadSDLID, and takes priority over the mappings
+// by UpdateStandardGamepadLayoutMappings and the built-in database. The standard buttons and axes not in mapping
+// become unavailable.
+//
+// SetStandardGamepadLayoutMapping returns an error when the gamepad doesn't exist or doesn't have an SDL ID,
+// e.g. on browsers and mobiles, or mapping is invalid, e.g. mapping refers to a button the gamepad doesn't have.
+// When an error is returned, the current mapping is not changed.
+//
+// SetStandardGamepadLayoutMapping is concurrent-safe.
+//
+// SetStandardGamepadLayoutMapping takes effect immediately.
+func SetStandardGamepadLayoutMapping(id GamepadID, mapping *StandardGamepadLayoutMapping) error {
+	g, err := gamepadForMapping(id)
+	if err != nil {
+		return err
+	}
+
+	if mapping == nil {
+		mapping = &StandardGamepadLayoutMapping{}
+	}
+
+	buttons := map[gamepaddb.StandardButton]string{}
+	for b, src := range mapping.Buttons {
+		if b < 0 || b > StandardGamepadButtonMax {
+			return fmt.Errorf("ebiten: invalid standard gamepad button: %d", b)
+		}
+		str, err := src.sdlString(g)
+		if err != nil {
+			return fmt.Errorf("ebiten: invalid mapping for standard gamepad button %d: %w", b, err)
+		}
+		buttons[b] = str
+	}
+	axes := map[gamepaddb.StandardAxis]string{}
+	for a, src := range mapping.Axes {
+		if a < 0 || a > StandardGamepadAxisMax {
+			return fmt.Errorf("ebiten: invalid standard gamepad axis: %d", a)
+		}
+		str, err := src.sdlString(g)
+		if err != nil {
+			return fmt.Errorf("ebiten: invalid mapping for standard gamepad axis %d: %w", a, err)
+		}
+		axes[a] = str
+	}
+	return gamepaddb.SetCustomMapping(g.SDLID(), buttons, axes)
+}
+
+// ResetStandardGamepadLayoutMapping removes the custom mapping set by SetStandardGamepadLayoutMapping
+// for the gamepad (id).
+//
+// ResetStandardGamepadLayoutMapping returns an error when the gamepad doesn't exist or doesn't have an SDL ID.
+//
+// ResetStandardGamepadLayoutMapping is concurrent-safe.
+func ResetStandardGamepadLayoutMapping(id GamepadID) error {
+	g, err := gamepadForMapping(id)
+	if err != nil {
+		return err
+	}
+	gamepaddb.RemoveCustomMapping(g.SDLID())
+	return nil
+}
+
+func gamepadForMapping(id GamepadID) (*gamepad.Gamepad, error) {
+	g := gamepad.Get(id)
+	if g == nil {
+		return nil, fmt.Errorf("ebiten: gamepad %d doesn't exist", id)
+	}
+	if g.SDLID() == "" {
+		return nil, errors.New("ebiten: the gamepad doesn't have an SDL ID")
+	}
+	return g, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
+)
+
+// GamepadMotion represents the values of a gamepad's motion sensors.
+//
+// The axes are in the gamepad's coordinate system reported by the driver.
+type GamepadMotion struct {
+	// Acceleration is the acceleration including the gravity for the X, Y and Z axes in [m/s^2].
+	Acceleration [3]float64
+
+	// AngularVelocity is the angular velocity around the X, Y and Z axes in [rad/s].
+	AngularVelocity [3]float64
+}
+
+// GamepadMotionValue returns the values of the motion sensors, i.e. an accelerometer and a gyroscope,
+// of the gamepad (id).
+// GamepadMotionValue returns false when the gamepad doesn't exist or the motion sensors are not available.
+//
+// The motion sensors are opt-in: Ebitengine starts reading the sensors of a gamepad when GamepadMotionValue is
+// called for the gamepad for the first time. Then, GamepadMotionValue might return false for a short while.
+//
+// GamepadMotionValue is available only on Linux with the kernel drivers exposing motion sensors,
+// e.g. DualSense, DualShock 4 and Switch Pro Controller.
+// On the other environments, GamepadMotionValue always returns false.
+//
+// GamepadMotionValue is concurrent-safe.
+func GamepadMotionValue(id GamepadID) (GamepadMotion, bool) {
+	g := gamepad.Get(id)
+	if g == nil {
+		return GamepadMotion{}, false
+	}
+	a, v, ok := g.Motion()
+	if !ok {
+		return GamepadMotion{}, false
+	}
+	return GamepadMotion{
+		Acceleration:    a,
+		AngularVelocity: v,
+	}, true
+}
+
+// GamepadTouchpadTouch represents a touch on a gamepad's touchpad.
+type GamepadTouchpadTouch struct {
+	// ID is an identifier of the touch, which is unique while the finger is on the touchpad.
+	ID int
+
+	// X and Y are the position of the touch in between 0 and 1.
+	// (0, 0) is the upper-left corner of the touchpad.
+	X float64
+	Y float64
+}
+
+var (
+	// theTouchpadTouchesBuffer is reused by AppendGamepadTouchpadTouches to avoid allocations every frame.
+	theTouchpadTouchesBuffer  []gamepad.TouchpadTouch
+	theTouchpadTouchesBufferM sync.Mutex
+)
+
+// AppendGamepadTouchpadTouches appends the current touches on the touchpad of the gamepad (id) to touches,
+// and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+//
+// The touchpad is opt-in like GamepadMotionValue:
+// Ebitengine starts reading the touchpad of a gamepad when AppendGamepadTouchpadTouches is called for the gamepad
+// for the first time.
+//
+// AppendGamepadTouchpadTouches is available only on Linux with the kernel drivers exposing touchpads,
+// e.g. DualSense and DualShock 4.
+// On the other environments, AppendGamepadTouchpadTouches doesn't append anything.
+//
+// AppendGamepadTouchpadTouches is concurrent-safe.
+func AppendGamepadTouchpadTouches(touches []GamepadTouchpadTouch, id GamepadID) []GamepadTouchpadTouch {
+	g := gamepad.Get(id)
+	if g == nil {
+		return touches
+	}
+
+	theTouchpadTouchesBufferM.Lock()
+	defer theTouchpadTouchesBufferM.Unlock()
+
+	theTouchpadTouchesBuffer = g.AppendTouchpadTouches(theTouchpadTouchesBuffer[:0])
+	for _, t := range theTouchpadTouchesBuffer {
+		touches = append(touches, GamepadTouchpadTouch{
+			ID: t.ID,
+			X:  t.X,
+			Y:  t.Y,
+		})
+	}
+	return touches
+}
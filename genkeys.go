@@ -806,7 +806,7 @@ func main() {
 		case filepath.Join("internal", "ui", "keys_mobile.go"):
 			buildConstraints = "//go:build android || ios"
 		case filepath.Join("internal", "ui", "keys_glfw.go"):
-			buildConstraints = "//go:build !android && !ios && !js && !nintendosdk && !playstation5"
+			buildConstraints = "//go:build !android && !ios && !js && !nintendosdk && !playstation5 && !ebitenginecustomplatform"
 		}
 		// NOTE: According to godoc, maps are automatically sorted by key.
 		if err := tmpl.Execute(f, struct {
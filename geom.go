@@ -198,6 +198,87 @@ func (g *GeoM) Invert() {
 	g.ty = ty
 }
 
+// TryInvert inverts the matrix if g is invertible, and reports whether g is inverted.
+// If g is not invertible, TryInvert doesn't modify g.
+func (g *GeoM) TryInvert() bool {
+	if !g.IsInvertible() {
+		return false
+	}
+	g.Invert()
+	return true
+}
+
+// Decompose decomposes the matrix into translation, rotation, scale and skew.
+//
+// The matrix is the same as the result of the following operations:
+//
+//	var g GeoM
+//	g.Scale(scaleX, scaleY)
+//	g.Skew(skewX, 0)
+//	g.Rotate(rotation)
+//	g.Translate(translateX, translateY)
+//
+// The units of rotation and skewX are radian. rotation is in (-π, π].
+// scaleX is never negative. A mirrored matrix has a negative scaleY.
+func (g *GeoM) Decompose() (translateX, translateY, rotation, scaleX, scaleY, skewX float64) {
+	a := g.a_1 + 1
+	b := g.b
+	c := g.c
+	d := g.d_1 + 1
+
+	translateX = g.tx
+	translateY = g.ty
+
+	scaleX = math.Hypot(a, c)
+	if scaleX == 0 {
+		// The first column is zero. Treat the second column as a rotated Y axis.
+		rotation = normalizeRotation(math.Atan2(-b, d))
+		scaleY = math.Hypot(b, d)
+		return
+	}
+
+	rotation = normalizeRotation(math.Atan2(c, a))
+	sin, cos := math.Sincos(rotation)
+	scaleY = -sin*b + cos*d
+	if scaleY != 0 {
+		skewX = math.Atan((cos*b + sin*d) / scaleY)
+	}
+	return
+}
+
+// normalizeRotation converts -π to π so that a rotation is in (-π, π].
+// math.Atan2 returns -π when y is -0 and x is negative.
+func normalizeRotation(rotation float64) float64 {
+	if rotation == -math.Pi {
+		return math.Pi
+	}
+	return rotation
+}
+
+// Interpolate sets g to the interpolation between g and other by t.
+// t = 0 keeps g, and t = 1 makes g the same as other.
+//
+// Interpolate interpolates the decomposed values by Decompose linearly,
+// and the rotation is interpolated in the shorter direction.
+// This is useful to blend animations or to smooth camera movements,
+// where interpolating the elements directly would shrink the image in the middle of a rotation.
+func (g *GeoM) Interpolate(other GeoM, t float64) {
+	tx0, ty0, r0, sx0, sy0, k0 := g.Decompose()
+	tx1, ty1, r1, sx1, sy1, k1 := other.Decompose()
+
+	dr := math.Remainder(r1-r0, 2*math.Pi)
+
+	lerp := func(x, y float64) float64 {
+		return x + (y-x)*t
+	}
+
+	g.Reset()
+	g.Scale(lerp(sx0, sx1), lerp(sy0, sy1))
+	g.Skew(lerp(k0, k1), 0)
+	g.Rotate(r0 + dr*t)
+	g.Translate(lerp(tx0, tx1), lerp(ty0, ty1))
+}
+
 // SetElement sets an element at (i, j).
 func (g *GeoM) SetElement(i, j int, element float64) {
 	e := element
@@ -374,3 +374,121 @@ func BenchmarkGeoM(b *testing.B) {
 		m.Rotate(math.Pi / 2)
 	}
 }
+
+func geoMAlmostEquals(a, b ebiten.GeoM) bool {
+	const delta = 1e-9
+	for i := 0; i < ebiten.GeoMDim-1; i++ {
+		for j := 0; j < ebiten.GeoMDim; j++ {
+			if math.Abs(a.Element(i, j)-b.Element(i, j)) > delta {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestGeoMDecompose(t *testing.T) {
+	mirrored := ebiten.GeoM{}
+	mirrored.Scale(-2, 1)
+	mirrored.Rotate(1)
+
+	skewed := ebiten.GeoM{}
+	skewed.Scale(2, 3)
+	skewed.Skew(0.5, 0.25)
+	skewed.Rotate(-2)
+	skewed.Translate(10, 20)
+
+	cases := []ebiten.GeoM{
+		{},
+		newGeoM(1, 0, 0, 1, 3, 4),
+		newGeoM(2, 0, 0, 3, 0, 0),
+		newGeoM(0, 0, 0, 0, 0, 0),
+		newGeoM(0, 1, 0, 2, 0, 0),
+		newGeoM(3, 1, 4, 1, 5, 9),
+		mirrored,
+		skewed,
+	}
+	for _, g := range cases {
+		tx, ty, r, sx, sy, k := g.Decompose()
+		if sx < 0 {
+			t.Errorf("%v: scaleX must not be negative but %f", g.String(), sx)
+		}
+		var got ebiten.GeoM
+		got.Scale(sx, sy)
+		got.Skew(k, 0)
+		got.Rotate(r)
+		got.Translate(tx, ty)
+		if !geoMAlmostEquals(got, g) {
+			t.Errorf("recomposed %v: got: %v, want: %v", g.String(), got.String(), g.String())
+		}
+	}
+}
+
+func TestGeoMDecomposeRotationRange(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	cases := []ebiten.GeoM{
+		newGeoM(-1, 0, negZero, -1, 0, 0),
+		newGeoM(-1, 0, 0, -1, 0, 0),
+		newGeoM(0, 0, 0, -1, 0, 0),
+		newGeoM(0, negZero, 0, -1, 0, 0),
+	}
+	for _, g := range cases {
+		_, _, r, _, _, _ := g.Decompose()
+		if r != math.Pi {
+			t.Errorf("%v: rotation: got: %v, want: %v", g.String(), r, math.Pi)
+		}
+	}
+}
+
+func TestGeoMInterpolate(t *testing.T) {
+	from := ebiten.GeoM{}
+	from.Rotate(math.Pi - 0.25)
+	from.Translate(10, 0)
+
+	to := ebiten.GeoM{}
+	to.Scale(3, 3)
+	to.Rotate(-math.Pi + 0.25)
+	to.Translate(20, 10)
+
+	g := from
+	g.Interpolate(to, 0)
+	if !geoMAlmostEquals(g, from) {
+		t.Errorf("t = 0: got: %v, want: %v", g.String(), from.String())
+	}
+
+	g = from
+	g.Interpolate(to, 1)
+	if !geoMAlmostEquals(g, to) {
+		t.Errorf("t = 1: got: %v, want: %v", g.String(), to.String())
+	}
+
+	// The rotation goes in the shorter direction through π.
+	g = from
+	g.Interpolate(to, 0.5)
+	want := ebiten.GeoM{}
+	want.Scale(2, 2)
+	want.Rotate(math.Pi)
+	want.Translate(15, 5)
+	if !geoMAlmostEquals(g, want) {
+		t.Errorf("t = 0.5: got: %v, want: %v", g.String(), want.String())
+	}
+}
+
+func TestGeoMTryInvert(t *testing.T) {
+	g := newGeoM(2, 0, 0, 4, 1, 2)
+	if !g.TryInvert() {
+		t.Errorf("TryInvert: got: false, want: true")
+	}
+	if want := newGeoM(0.5, 0, 0, 0.25, -0.5, -0.5); !geoMAlmostEquals(g, want) {
+		t.Errorf("TryInvert: got: %v, want: %v", g.String(), want.String())
+	}
+
+	zero := newGeoM(0, 0, 0, 0, 1, 2)
+	orig := zero
+	if zero.TryInvert() {
+		t.Errorf("TryInvert for a non-invertible matrix: got: true, want: false")
+	}
+	if zero != orig {
+		t.Errorf("TryInvert must not modify a non-invertible matrix: got: %v, want: %v", zero.String(), orig.String())
+	}
+}
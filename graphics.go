@@ -15,7 +15,10 @@
 package ebiten
 
 import (
+	"time"
+
 	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
 
@@ -66,9 +69,54 @@ var _ [GraphicsLibraryAuto]int = [0]int{}
 type DebugInfo struct {
 	// GraphicsLibrary represents the graphics library currently in use.
 	GraphicsLibrary GraphicsLibrary
+
+	// UploadedBytes is the number of bytes sent from CPU to GPU in the last frame,
+	// e.g. by (*Image).WritePixels.
+	UploadedBytes int64
+
+	// ReadBackBytes is the number of bytes read back from GPU to CPU in the last frame,
+	// e.g. by (*Image).ReadPixels or (*Image).At.
+	//
+	// If ReadBackBytes is non-zero every frame, an image might be unexpectedly synced with GPU every frame.
+	// With the build tag ebitenginedebug, each transfer is logged with its caller.
+	ReadBackBytes int64
+
+	// DrawCalls is the number of draw calls issued to the graphics library in the last frame.
+	DrawCalls int
+
+	// MergedDraws is the number of draw operations merged into their previous draw calls in the last frame.
+	// A draw operation is, e.g., (*Image).DrawImage or (*Image).DrawTriangles.
+	//
+	// Draw operations are merged when they have the same destination, source images, shader, uniforms, blend and so on.
+	// If MergedDraws is small compared to DrawCalls, the batching might be broken, e.g. by alternating source images.
+	MergedDraws int
+
+	// TextureSwitches is the number of draw calls in the last frame whose source images are different from
+	// the previous draw call's.
+	//
+	// Drawing images on the same atlas in a row avoids switches.
+	// Note that an image created with NewImageOptions.Unmanaged is never put on an atlas.
+	TextureSwitches int
+
+	// TextureBytes is the estimated number of bytes of the textures allocated by Ebitengine at the end of the last frame.
+	TextureBytes int64
+
+	// FrameDuration is the time taken to update and draw the last frame.
+	// FrameDuration doesn't include the time to present the screen.
+	FrameDuration time.Duration
 }
 
 // ReadDebugInfo writes debug info (e.g. current graphics library) into a provided struct.
 func ReadDebugInfo(d *DebugInfo) {
 	d.GraphicsLibrary = GraphicsLibrary(ui.Get().GraphicsLibrary())
+
+	var stats graphicscommand.FrameStats
+	graphicscommand.ReadLastFrameStats(&stats)
+	d.UploadedBytes = stats.UploadedBytes
+	d.ReadBackBytes = stats.ReadBackBytes
+	d.DrawCalls = stats.DrawCalls
+	d.MergedDraws = stats.MergedDraws
+	d.TextureSwitches = stats.TextureSwitches
+	d.TextureBytes = stats.TextureBytes
+	d.FrameDuration = ui.Get().LastFrameDuration()
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"math"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/affine"
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
@@ -46,6 +47,10 @@ type Image struct {
 	// tmpUniforms must not be reused until ui.Image.Draw* is called.
 	tmpUniforms []uint32
 
+	// leakTracker reports the image when the image is not released explicitly.
+	// leakTracker is nil unless EBITENGINE_IMAGE_LEAK_REPORT is specified.
+	leakTracker *imageLeakTracker
+
 	// Do not add a 'buffering' member that are resolved lazily.
 	// This tends to forget resolving the buffer easily (#2362).
 }
@@ -136,6 +141,13 @@ type DrawImageOptions struct {
 	// The default (zero) value is the regular alpha blending.
 	Blend Blend
 
+	// BlendColor is the constant color used by BlendFactorConstantColor and BlendFactorOneMinusConstantColor.
+	// The color values are used as they are, i.e. as premultiplied-alpha values returned by RGBA.
+	// The default (nil) value is treated as transparent, which is (0, 0, 0, 0).
+	//
+	// See Blend for how BlendColor affects batching.
+	BlendColor color.Color
+
 	// Filter is a type of texture filter.
 	// The default (zero) value is FilterNearest.
 	Filter Filter
@@ -230,6 +242,7 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 	} else {
 		blend = options.CompositeMode.blend().internalBlend()
 	}
+	blend.BlendColor = blendColorToInternal(blend, options.BlendColor)
 	filter := builtinshader.Filter(options.Filter)
 
 	geoM := options.GeoM
@@ -361,6 +374,13 @@ type DrawTrianglesOptions struct {
 	// The default (zero) value is the regular alpha blending.
 	Blend Blend
 
+	// BlendColor is the constant color used by BlendFactorConstantColor and BlendFactorOneMinusConstantColor.
+	// The color values are used as they are, i.e. as premultiplied-alpha values returned by RGBA.
+	// The default (nil) value is treated as transparent, which is (0, 0, 0, 0).
+	//
+	// See Blend for how BlendColor affects batching.
+	BlendColor color.Color
+
 	// Filter is a type of texture filter.
 	// The default (zero) value is FilterNearest.
 	Filter Filter
@@ -460,6 +480,7 @@ func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, o
 	} else {
 		blend = options.CompositeMode.blend().internalBlend()
 	}
+	blend.BlendColor = blendColorToInternal(blend, options.BlendColor)
 
 	address := builtinshader.Address(options.Address)
 	filter := builtinshader.Filter(options.Filter)
@@ -531,6 +552,13 @@ type DrawTrianglesShaderOptions struct {
 	// The default (zero) value is the regular alpha blending.
 	Blend Blend
 
+	// BlendColor is the constant color used by BlendFactorConstantColor and BlendFactorOneMinusConstantColor.
+	// The color values are used as they are, i.e. as premultiplied-alpha values returned by RGBA.
+	// The default (nil) value is treated as transparent, which is (0, 0, 0, 0).
+	//
+	// See Blend for how BlendColor affects batching.
+	BlendColor color.Color
+
 	// Uniforms is a set of uniform variables for the shader.
 	// The keys are the names of the uniform variables.
 	// The values must be a numeric type, or a slice or an array of a numeric type.
@@ -625,6 +653,7 @@ func (i *Image) DrawTrianglesShader(vertices []Vertex, indices []uint16, shader
 	} else {
 		blend = options.CompositeMode.blend().internalBlend()
 	}
+	blend.BlendColor = blendColorToInternal(blend, options.BlendColor)
 
 	vs := i.ensureTmpVertices(len(vertices) * graphics.VertexFloatCount)
 	dst := i
@@ -708,6 +737,13 @@ type DrawRectShaderOptions struct {
 	// The default (zero) value is the regular alpha blending.
 	Blend Blend
 
+	// BlendColor is the constant color used by BlendFactorConstantColor and BlendFactorOneMinusConstantColor.
+	// The color values are used as they are, i.e. as premultiplied-alpha values returned by RGBA.
+	// The default (nil) value is treated as transparent, which is (0, 0, 0, 0).
+	//
+	// See Blend for how BlendColor affects batching.
+	BlendColor color.Color
+
 	// Uniforms is a set of uniform variables for the shader.
 	// The keys are the names of the uniform variables.
 	// The values must be a numeric type, or a slice or an array of a numeric type.
@@ -770,6 +806,7 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 	} else {
 		blend = options.CompositeMode.blend().internalBlend()
 	}
+	blend.BlendColor = blendColorToInternal(blend, options.BlendColor)
 
 	var imgs [graphics.ShaderImageCount]*ui.Image
 	for i, img := range options.Images {
@@ -942,6 +979,109 @@ func (i *Image) RGBA64At(x, y int) color.RGBA64 {
 	return color.RGBA64{R: uint16(r) * 0x101, G: uint16(g) * 0x101, B: uint16(b) * 0x101, A: uint16(a) * 0x101}
 }
 
+// AtN reads the colors of the image at points, and writes them to colors.
+// Each color is in premultiplied alpha like At.
+//
+// AtN reads the bounding rectangle of points with one read, which is more efficient than calling At for each point,
+// e.g. to use an image's data as a heightmap or a collision mask. Like At, the image's pixels are loaded from GPU
+// to system memory only when necessary, e.g. at the first call after the image is modified.
+//
+// The length of colors must be the same as the length of points. Otherwise, AtN panics.
+//
+// For a point out of the bounds, AtN writes a transparent color.
+// AtN writes transparent colors if the image is disposed.
+//
+// AtN can't be called outside the main loop (ebiten.Run's updating function) starts.
+func (i *Image) AtN(points []image.Point, colors []color.RGBA) {
+	if len(points) != len(colors) {
+		panic(fmt.Sprintf("ebiten: len(colors) must be %d but %d at AtN", len(points), len(colors)))
+	}
+
+	if i.isDisposed() {
+		for j := range colors {
+			colors[j] = color.RGBA{}
+		}
+		return
+	}
+
+	// Read the bounding rectangle of the points at once.
+	b := i.Bounds()
+	var r image.Rectangle
+	for _, p := range points {
+		if !p.In(b) {
+			continue
+		}
+		r = r.Union(image.Rectangle{Min: p, Max: p.Add(image.Pt(1, 1))})
+	}
+	if r.Empty() {
+		for j := range colors {
+			colors[j] = color.RGBA{}
+		}
+		return
+	}
+
+	pix := make([]byte, 4*r.Dx()*r.Dy())
+	x, y := i.adjustPosition(r.Min.X, r.Min.Y)
+	i.image.ReadPixels(pix, image.Rect(x, y, x+r.Dx(), y+r.Dy()))
+	for j, p := range points {
+		if !p.In(b) {
+			colors[j] = color.RGBA{}
+			continue
+		}
+		idx := 4 * ((p.Y-r.Min.Y)*r.Dx() + (p.X - r.Min.X))
+		colors[j] = color.RGBA{R: pix[idx], G: pix[idx+1], B: pix[idx+2], A: pix[idx+3]}
+	}
+}
+
+// SampleBilinear returns the color of the image at the sub-pixel position (x, y) with bilinear interpolation.
+// The color is in premultiplied alpha.
+//
+// The center of the pixel at (i, j) is at (i+0.5, j+0.5).
+// Pixels out of the bounds are treated as transparent like At.
+//
+// SampleBilinear loads pixels from GPU to system memory if necessary, which means that SampleBilinear can be slow.
+// To sample many positions, use AtN.
+//
+// SampleBilinear always returns a transparent color if the image is disposed.
+//
+// SampleBilinear can't be called outside the main loop (ebiten.Run's updating function) starts.
+func (i *Image) SampleBilinear(x, y float64) color.RGBA64 {
+	x -= 0.5
+	y -= 0.5
+	fx, fy := math.Floor(x), math.Floor(y)
+	ix, iy := int(fx), int(fy)
+	tx, ty := x-fx, y-fy
+
+	points := [...]image.Point{
+		{ix, iy},
+		{ix + 1, iy},
+		{ix, iy + 1},
+		{ix + 1, iy + 1},
+	}
+	var colors [4]color.RGBA
+	i.AtN(points[:], colors[:])
+
+	weights := [...]float64{
+		(1 - tx) * (1 - ty),
+		tx * (1 - ty),
+		(1 - tx) * ty,
+		tx * ty,
+	}
+	var r, g, b, a float64
+	for j, c := range colors {
+		r += float64(c.R) * weights[j]
+		g += float64(c.G) * weights[j]
+		b += float64(c.B) * weights[j]
+		a += float64(c.A) * weights[j]
+	}
+	return color.RGBA64{
+		R: uint16(math.Round(r * 0x101)),
+		G: uint16(math.Round(g * 0x101)),
+		B: uint16(math.Round(b * 0x101)),
+		A: uint16(math.Round(a * 0x101)),
+	}
+}
+
 func (i *Image) at(x, y int) (r, g, b, a byte) {
 	if i.isDisposed() {
 		return 0, 0, 0, 0
@@ -1003,6 +1143,7 @@ func (i *Image) Dispose() {
 	}
 	i.image.Deallocate()
 	i.image = nil
+	i.releaseLeakTracker()
 }
 
 // Deallocate clears the image and deallocates the internal state of the image.
@@ -1026,6 +1167,15 @@ func (i *Image) Deallocate() {
 		return
 	}
 	i.image.Deallocate()
+	i.releaseLeakTracker()
+}
+
+func (i *Image) releaseLeakTracker() {
+	if i.leakTracker == nil {
+		return
+	}
+	i.leakTracker.release()
+	i.leakTracker = nil
 }
 
 // WritePixels replaces the pixels of the image.
@@ -1061,6 +1211,40 @@ func (i *Image) ReplacePixels(pixels []byte) {
 	i.WritePixels(pixels)
 }
 
+// ImageDebugInfo is a struct to store debug info about an image.
+type ImageDebugInfo struct {
+	// InternalImageID is the ID of the internal image (texture) that the image belongs to.
+	// The ID is the same as the prefix of the file names dumped by EBITENGINE_INTERNAL_IMAGES_KEY.
+	//
+	// InternalImageID is 0 if the internal image is not allocated yet e.g. the image has never been used.
+	InternalImageID int
+
+	// OnAtlas reports whether the image is on an internal automatic texture atlas shared with other images.
+	// An unmanaged image is never on an atlas.
+	OnAtlas bool
+}
+
+// ReadDebugInfo writes debug info about the image (e.g. the internal texture atlas) into a provided struct.
+//
+// Note that a managed image can be moved between internal textures automatically.
+// The result is valid only at the time of the call.
+//
+// If the image is a sub-image, ReadDebugInfo writes the info about the original image.
+//
+// If the image is disposed, ReadDebugInfo writes zero values.
+func (i *Image) ReadDebugInfo(d *ImageDebugInfo) {
+	i.copyCheck()
+
+	*d = ImageDebugInfo{}
+	if i.isDisposed() {
+		return
+	}
+
+	info := i.image.BackendInfo()
+	d.InternalImageID = info.ID
+	d.OnAtlas = info.OnAtlas
+}
+
 // NewImage returns an empty image.
 //
 // If width or height is less than 1 or more than device-dependent maximum size, NewImage panics.
@@ -1082,7 +1266,24 @@ type NewImageOptions struct {
 	// An unmanaged image is never on an internal automatic texture atlas.
 	// A regular image is a part of an internal texture atlas, and locating them is done automatically in Ebitengine.
 	// Unmanaged is useful when you want finer controls over the image for performance and memory reasons.
+	// For example, an image used as a render target that is recreated frequently can be unmanaged
+	// so that the image always has its own internal texture and is never moved by the automatic atlas heuristics.
+	//
+	// Whether an image is on an atlas can be checked by (*Image).ReadDebugInfo.
 	Unmanaged bool
+
+	// ExtrudeEdges represents whether the border pixels of the image are duplicated into the paddings around the image
+	// on an internal texture atlas.
+	// The default (zero) value is false, that means the paddings are transparent.
+	//
+	// Sampling without clamping to the image's region, like imageSrc0UnsafeAt in Kage or mipmaps, might read
+	// the paddings. With ExtrudeEdges, such sampling reads the border pixels instead of the transparent paddings,
+	// and never reads other images on the same atlas.
+	// The paddings are updated lazily when the image is used as a source after the image is modified,
+	// which costs an extra copy of the image.
+	//
+	// ExtrudeEdges is ignored if Unmanaged is true.
+	ExtrudeEdges bool
 }
 
 // NewImageWithOptions returns an empty image with the given bounds and the options.
@@ -1102,8 +1303,12 @@ type NewImageOptions struct {
 // NewImageWithOptions panics if RunGame already finishes.
 func NewImageWithOptions(bounds image.Rectangle, options *NewImageOptions) *Image {
 	imageType := atlas.ImageTypeRegular
-	if options != nil && options.Unmanaged {
-		imageType = atlas.ImageTypeUnmanaged
+	if options != nil {
+		if options.Unmanaged {
+			imageType = atlas.ImageTypeUnmanaged
+		} else if options.ExtrudeEdges {
+			imageType = atlas.ImageTypeExtruded
+		}
 	}
 	return newImage(bounds, imageType)
 }
@@ -1126,6 +1331,9 @@ func newImage(bounds image.Rectangle, imageType atlas.ImageType) *Image {
 		bounds: bounds,
 	}
 	i.addr = i
+	if isImageLeakReportEnabled {
+		i.leakTracker = newImageLeakTracker(width, height)
+	}
 	return i
 }
 
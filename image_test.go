@@ -16,11 +16,13 @@ package ebiten_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
 	_ "image/png"
+	"io"
 	"math"
 	"math/rand"
 	"runtime"
@@ -2330,6 +2332,60 @@ func TestImageDrawTrianglesDisposedImage(t *testing.T) {
 	dst.DrawTriangles(vs, is, src, nil)
 }
 
+func TestImageErrVariants(t *testing.T) {
+	dst := ebiten.NewImage(16, 16)
+	src := ebiten.NewImage(16, 16)
+
+	if err := dst.WritePixelsErr(make([]byte, 4*16*16)); err != nil {
+		t.Errorf("WritePixelsErr: %v", err)
+	}
+	if err := dst.WritePixelsErr(make([]byte, 4)); err == nil {
+		t.Errorf("WritePixelsErr with a wrong length must return an error")
+	}
+	if err := dst.ReadPixelsErr(make([]byte, 4)); err == nil {
+		t.Errorf("ReadPixelsErr with a wrong length must return an error")
+	}
+	if err := dst.DrawImageErr(src, nil); err != nil {
+		t.Errorf("DrawImageErr: %v", err)
+	}
+	if err := dst.DrawImageErr(dst.SubImage(image.Rect(0, 0, 8, 8)).(*ebiten.Image), nil); err == nil {
+		t.Errorf("DrawImageErr with a sub-image of the receiver must return an error")
+	}
+
+	vs := make([]ebiten.Vertex, 4)
+	if err := dst.DrawTrianglesErr(vs, []uint16{0, 1, 2, 1, 2, 3}, src, nil); err != nil {
+		t.Errorf("DrawTrianglesErr: %v", err)
+	}
+	if err := dst.DrawTrianglesErr(vs, []uint16{0, 1}, src, nil); err == nil {
+		t.Errorf("DrawTrianglesErr with len(indices) %% 3 != 0 must return an error")
+	}
+	if err := dst.DrawTrianglesErr(vs, []uint16{0, 1, 4}, src, nil); err == nil {
+		t.Errorf("DrawTrianglesErr with an out-of-range index must return an error")
+	}
+	if err := dst.DrawImageErr(src, &ebiten.DrawImageOptions{Filter: ebiten.Filter(100)}); err == nil {
+		t.Errorf("DrawImageErr with an invalid filter must return an error")
+	}
+	if err := dst.DrawTrianglesErr(vs, []uint16{0, 1, 2}, src, &ebiten.DrawTrianglesOptions{Address: ebiten.Address(100)}); err == nil {
+		t.Errorf("DrawTrianglesErr with an invalid address must return an error")
+	}
+	if err := dst.DrawTrianglesErr(vs, []uint16{0, 1, 2}, src, &ebiten.DrawTrianglesOptions{
+		Blend: ebiten.Blend{BlendFactorSourceRGB: ebiten.BlendFactor(100)},
+	}); err == nil {
+		t.Errorf("DrawTrianglesErr with an invalid blend factor must return an error")
+	}
+
+	src.Dispose()
+	if err := dst.DrawImageErr(src, nil); !errors.Is(err, ebiten.ErrImageDisposed) {
+		t.Errorf("DrawImageErr with a disposed image: got: %v, want: %v", err, ebiten.ErrImageDisposed)
+	}
+	if err := src.WritePixelsErr(make([]byte, 4*16*16)); !errors.Is(err, ebiten.ErrImageDisposed) {
+		t.Errorf("WritePixelsErr on a disposed image: got: %v, want: %v", err, ebiten.ErrImageDisposed)
+	}
+	if err := src.ReadPixelsErr(make([]byte, 4*16*16)); !errors.Is(err, ebiten.ErrImageDisposed) {
+		t.Errorf("ReadPixelsErr on a disposed image: got: %v, want: %v", err, ebiten.ErrImageDisposed)
+	}
+}
+
 func TestImageDrawTrianglesDeallocateImage(t *testing.T) {
 	dst := ebiten.NewImage(16, 16)
 	src := ebiten.NewImage(16, 16)
@@ -4218,6 +4274,76 @@ func TestImageBlendFactor(t *testing.T) {
 	}
 }
 
+func TestImageBlendConstantColor(t *testing.T) {
+	const w, h = 16, 16
+	dst := ebiten.NewImage(w, h)
+	dst.Fill(color.RGBA{0x40, 0x40, 0x40, 0x40})
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.White)
+
+	op := &ebiten.DrawImageOptions{}
+	op.Blend = ebiten.Blend{
+		BlendFactorSourceRGB:        ebiten.BlendFactorConstantColor,
+		BlendFactorSourceAlpha:      ebiten.BlendFactorConstantColor,
+		BlendFactorDestinationRGB:   ebiten.BlendFactorOneMinusConstantColor,
+		BlendFactorDestinationAlpha: ebiten.BlendFactorOneMinusConstantColor,
+		BlendOperationRGB:           ebiten.BlendOperationAdd,
+		BlendOperationAlpha:         ebiten.BlendOperationAdd,
+	}
+	op.BlendColor = color.RGBA{0x80, 0x00, 0xff, 0x80}
+	dst.DrawImage(src, op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			// 0xff * c + 0x40 * (1 - c) for each component c of the blend color.
+			want := color.RGBA{0x9f, 0x40, 0xff, 0x9f}
+			if !sameColors(got, want, 1) {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// Without BlendColor, the blend color is transparent.
+	dst.Fill(color.RGBA{0x40, 0x40, 0x40, 0x40})
+	op.BlendColor = nil
+	dst.DrawImage(src, op)
+	if got, want := dst.At(0, 0).(color.RGBA), (color.RGBA{0x40, 0x40, 0x40, 0x40}); !sameColors(got, want, 1) {
+		t.Errorf("dst.At(0, 0): got: %v, want: %v", got, want)
+	}
+}
+
+func TestImageBlendLogicOperation(t *testing.T) {
+	if !ebiten.IsLogicOperationAvailable() {
+		t.Skip("logic operations are not available")
+	}
+
+	const w, h = 16, 16
+	dst := ebiten.NewImage(w, h)
+	src := ebiten.NewImage(w, h)
+
+	for _, tc := range []struct {
+		op   ebiten.LogicOperation
+		want color.RGBA
+	}{
+		{ebiten.LogicOperationAnd, color.RGBA{0x0c & 0x0a, 0x30 & 0x50, 0xc0 & 0xa0, 0xff & 0xff}},
+		{ebiten.LogicOperationOr, color.RGBA{0x0c | 0x0a, 0x30 | 0x50, 0xc0 | 0xa0, 0xff | 0xff}},
+		{ebiten.LogicOperationXor, color.RGBA{0x0c ^ 0x0a, 0x30 ^ 0x50, 0xc0 ^ 0xa0, 0xff ^ 0xff}},
+		{ebiten.LogicOperationInvert, color.RGBA{^byte(0x0a), ^byte(0x50), ^byte(0xa0), ^byte(0xff)}},
+	} {
+		dst.Fill(color.RGBA{0x0a, 0x50, 0xa0, 0xff})
+		src.Fill(color.RGBA{0x0c, 0x30, 0xc0, 0xff})
+
+		op := &ebiten.DrawImageOptions{}
+		op.Blend.LogicOperation = tc.op
+		dst.DrawImage(src, op)
+
+		if got := dst.At(0, 0).(color.RGBA); got != tc.want {
+			t.Errorf("logic operation %d: got: %v, want: %v", tc.op, got, tc.want)
+		}
+	}
+}
+
 func TestImageAntiAlias(t *testing.T) {
 	// This value depends on internal/ui.bigOffscreenScale. Sync this.
 	const bigOffscreenScale = 2
@@ -4623,3 +4749,125 @@ func TestImageInvalidPremultipliedAlphaColor(t *testing.T) {
 		}
 	}
 }
+
+func TestImageReadDebugInfo(t *testing.T) {
+	const (
+		w = 16
+		h = 16
+	)
+
+	managed := ebiten.NewImage(w, h)
+	managed.Fill(color.White)
+	unmanaged := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+		Unmanaged: true,
+	})
+	unmanaged.Fill(color.White)
+
+	var d ebiten.ImageDebugInfo
+	managed.ReadDebugInfo(&d)
+	if d.InternalImageID == 0 {
+		t.Errorf("InternalImageID must not be 0")
+	}
+	if got, want := d.OnAtlas, true; got != want {
+		t.Errorf("OnAtlas: got: %v, want: %v", got, want)
+	}
+
+	unmanaged.ReadDebugInfo(&d)
+	if d.InternalImageID == 0 {
+		t.Errorf("InternalImageID must not be 0")
+	}
+	if got, want := d.OnAtlas, false; got != want {
+		t.Errorf("OnAtlas: got: %v, want: %v", got, want)
+	}
+
+	unmanaged.Dispose()
+	unmanaged.ReadDebugInfo(&d)
+	if got, want := d, (ebiten.ImageDebugInfo{}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestImageEncodeDecodePixels(t *testing.T) {
+	for _, compressed := range []bool{false, true} {
+		compressed := compressed
+		t.Run(fmt.Sprintf("compressed=%t", compressed), func(t *testing.T) {
+			src := ebiten.NewImageWithOptions(image.Rect(1, 2, 5, 5), nil)
+			for j := 2; j < 5; j++ {
+				for i := 1; i < 5; i++ {
+					src.Set(i, j, color.RGBA{byte(i * 0x10), byte(j * 0x10), 0x80, 0xff})
+				}
+			}
+
+			var buf bytes.Buffer
+			if err := src.EncodePixels(&buf, &ebiten.EncodePixelsOptions{Compressed: compressed}); err != nil {
+				t.Fatal(err)
+			}
+			dst, err := ebiten.DecodePixels(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := dst.Bounds(), src.Bounds(); got != want {
+				t.Errorf("bounds: got: %v, want: %v", got, want)
+			}
+			for j := 2; j < 5; j++ {
+				for i := 1; i < 5; i++ {
+					if got, want := dst.At(i, j), src.At(i, j); got != want {
+						t.Errorf("At(%d, %d): got: %v, want: %v", i, j, got, want)
+					}
+				}
+			}
+		})
+	}
+
+	if _, err := ebiten.DecodePixels(bytes.NewReader([]byte("invalid data, invalid data"))); err == nil {
+		t.Errorf("DecodePixels with an invalid blob must return an error")
+	}
+
+	// A header with too big bounds and without pixels must be rejected before the pixels are allocated.
+	tooBig := []byte{
+		'E', 'B', 'P', 'X', // Magic number
+		1,    // Version
+		0,    // Flags
+		0, 0, // Reserved
+		0, 0, 0, 0, // Min.X
+		0, 0, 0, 0, // Min.Y
+		0, 0, 0, 0x40, // Max.X
+		1, 0, 0, 0, // Max.Y
+	}
+	if _, err := ebiten.DecodePixels(bytes.NewReader(tooBig)); err == nil || errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("DecodePixels with too big bounds must return an error for the bounds but %v", err)
+	}
+}
+
+func TestImageAtNAndSampleBilinear(t *testing.T) {
+	img := ebiten.NewImage(8, 8)
+	for j := 0; j < 8; j++ {
+		for i := 0; i < 8; i++ {
+			img.Set(i, j, color.RGBA{byte(i * 0x20), byte(j * 0x20), 0, 0xff})
+		}
+	}
+	sub := img.SubImage(image.Rect(2, 2, 6, 6)).(*ebiten.Image)
+
+	points := []image.Point{{2, 3}, {5, 5}, {1, 1}, {3, 4}}
+	colors := make([]color.RGBA, len(points))
+	sub.AtN(points, colors)
+	for i, p := range points {
+		var want color.RGBA
+		if p.In(sub.Bounds()) {
+			want = img.At(p.X, p.Y).(color.RGBA)
+		}
+		if got := colors[i]; got != want {
+			t.Errorf("AtN: colors[%d] at %v: got: %v, want: %v", i, p, got, want)
+		}
+	}
+
+	if got, want := img.SampleBilinear(3.5, 4.5), img.RGBA64At(3, 4); got != want {
+		t.Errorf("SampleBilinear(3.5, 4.5): got: %v, want: %v", got, want)
+	}
+	got := img.SampleBilinear(4, 4.5)
+	want := color.RGBA64{R: 0x70 * 0x101, G: 0x80 * 0x101, B: 0, A: 0xffff}
+	if got != want {
+		t.Errorf("SampleBilinear(4, 4.5): got: %v, want: %v", got, want)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"time"
 
@@ -51,8 +52,8 @@ func takeScreenshot(screen *Image, transparent bool) error {
 	return nil
 }
 
-func dumpInternalImages() error {
-	dumpedDir, err := ui.Get().DumpImages("internalimages_" + datetimeForFilename())
+func dumpInternalImages(filter *regexp.Regexp) error {
+	dumpedDir, err := ui.Get().DumpImages("internalimages_"+datetimeForFilename(), filter)
 	if err != nil {
 		return err
 	}
@@ -79,8 +80,13 @@ type imageDumper struct {
 
 	hasDumpInternalImagesKey bool
 	dumpInternalImagesKey    Key
+	dumpInternalImagesFilter *regexp.Regexp
 	toDumpInternalImages     bool
 
+	hasFrameCaptureKey bool
+	frameCaptureKey    Key
+	toCaptureFrame     bool
+
 	err error
 }
 
@@ -100,6 +106,14 @@ func envInternalImagesKey() string {
 	return os.Getenv("EBITEN_INTERNAL_IMAGES_KEY")
 }
 
+func envInternalImagesFilter() string {
+	return os.Getenv("EBITENGINE_INTERNAL_IMAGES_FILTER")
+}
+
+func envFrameCaptureKey() string {
+	return os.Getenv("EBITENGINE_FRAME_CAPTURE_KEY")
+}
+
 func (i *imageDumper) update() error {
 	if i.err != nil {
 		return i.err
@@ -122,10 +136,25 @@ func (i *imageDumper) update() error {
 					i.hasDumpInternalImagesKey = true
 					i.dumpInternalImagesKey = key
 				}
+				if filter := envInternalImagesFilter(); filter != "" {
+					re, err := regexp.Compile(filter)
+					if err != nil {
+						i.err = fmt.Errorf("ebiten: EBITENGINE_INTERNAL_IMAGES_FILTER is invalid: %w", err)
+						return i.err
+					}
+					i.dumpInternalImagesFilter = re
+				}
 			} else {
 				fmt.Fprintf(os.Stderr, "EBITENGINE_INTERNAL_IMAGES_KEY is disabled. Specify a build tag 'ebitenginedebug' to enable it.\n")
 			}
 		}
+
+		if keyname := envFrameCaptureKey(); keyname != "" {
+			if key, ok := keyNameToKeyCode(keyname); ok {
+				i.hasFrameCaptureKey = true
+				i.frameCaptureKey = key
+			}
+		}
 	}
 
 	keys := map[Key]struct{}{}
@@ -135,6 +164,9 @@ func (i *imageDumper) update() error {
 	if i.hasDumpInternalImagesKey {
 		keys[i.dumpInternalImagesKey] = struct{}{}
 	}
+	if i.hasFrameCaptureKey {
+		keys[i.frameCaptureKey] = struct{}{}
+	}
 
 	for key := range keys {
 		if IsKeyPressed(key) {
@@ -146,6 +178,9 @@ func (i *imageDumper) update() error {
 				if i.hasDumpInternalImagesKey && key == i.dumpInternalImagesKey {
 					i.toDumpInternalImages = true
 				}
+				if i.hasFrameCaptureKey && key == i.frameCaptureKey {
+					i.toCaptureFrame = true
+				}
 			}
 		} else {
 			i.keyState[key] = 0
@@ -164,7 +199,14 @@ func (i *imageDumper) dump(screen *Image, transparent bool) error {
 
 	if i.toDumpInternalImages {
 		i.toDumpInternalImages = false
-		if err := dumpInternalImages(); err != nil {
+		if err := dumpInternalImages(i.dumpInternalImagesFilter); err != nil {
+			return err
+		}
+	}
+
+	if i.toCaptureFrame {
+		i.toCaptureFrame = false
+		if err := captureFrameToFile(); err != nil {
 			return err
 		}
 	}
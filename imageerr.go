@@ -0,0 +1,197 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
+)
+
+// The functions in this file are variants of Image's functions that return errors instead of panicking or silently doing nothing.
+// These are useful for applications like long-running tools that want to report misuses as recoverable errors.
+//
+// Each variant checks all the misuses that make the original function panic before calling the original function.
+
+// ErrImageDisposed is returned by the error-returning variants of Image's functions like WritePixelsErr
+// when a disposed image is used.
+var ErrImageDisposed = errors.New("ebiten: the image is already disposed")
+
+func (i *Image) checkCopyErr() error {
+	if i.addr != i {
+		return errors.New("ebiten: illegal use of non-zero Image copied by value")
+	}
+	return nil
+}
+
+// WritePixelsErr is the same as WritePixels, but returns an error instead of panicking or doing nothing.
+//
+// WritePixelsErr returns ErrImageDisposed if the image is disposed.
+// WritePixelsErr returns an error if len(pixels) is not correct, or the image is created from a native texture.
+func (i *Image) WritePixelsErr(pixels []byte) error {
+	if err := i.checkCopyErr(); err != nil {
+		return err
+	}
+	if i.isDisposed() {
+		return ErrImageDisposed
+	}
+	if i.image.ImageType() == atlas.ImageTypeExternal {
+		return errors.New("ebiten: an image created from a native texture cannot be modified")
+	}
+	b := i.Bounds()
+	if got, want := len(pixels), 4*b.Dx()*b.Dy(); got != want {
+		return fmt.Errorf("ebiten: len(pixels) must be %d but %d at WritePixelsErr", want, got)
+	}
+	i.WritePixels(pixels)
+	return nil
+}
+
+// ReadPixelsErr is the same as ReadPixels, but returns an error instead of panicking or filling pixels with zeros.
+//
+// ReadPixelsErr returns ErrImageDisposed if the image is disposed.
+// ReadPixelsErr returns an error if len(pixels) is not correct.
+func (i *Image) ReadPixelsErr(pixels []byte) error {
+	if err := i.checkCopyErr(); err != nil {
+		return err
+	}
+	if i.isDisposed() {
+		return ErrImageDisposed
+	}
+	b := i.Bounds()
+	if got, want := len(pixels), 4*b.Dx()*b.Dy(); got != want {
+		return fmt.Errorf("ebiten: len(pixels) must be %d but %d at ReadPixelsErr", want, got)
+	}
+	i.ReadPixels(pixels)
+	return nil
+}
+
+// DrawImageErr is the same as DrawImage, but returns an error instead of panicking or doing nothing.
+//
+// DrawImageErr returns an error wrapping ErrImageDisposed if the image i or the given image is disposed.
+// DrawImageErr returns an error if the given image shares the same underlying image with i, e.g. img is a sub-image of i.
+// DrawImageErr returns an error if the options have invalid values, e.g. an invalid Filter.
+func (i *Image) DrawImageErr(img *Image, options *DrawImageOptions) error {
+	if err := i.checkDrawErr(img, "DrawImageErr"); err != nil {
+		return err
+	}
+	if options != nil {
+		if err := checkBlendErr(options.CompositeMode, options.Blend); err != nil {
+			return err
+		}
+		if err := checkFilterErr(options.Filter); err != nil {
+			return err
+		}
+	}
+	i.DrawImage(img, options)
+	return nil
+}
+
+// DrawTrianglesErr is the same as DrawTriangles, but returns an error instead of panicking or doing nothing.
+//
+// DrawTrianglesErr returns an error wrapping ErrImageDisposed if the image i or the given image is disposed.
+// DrawTrianglesErr returns an error if the given image shares the same underlying image with i, e.g. img is a sub-image of i.
+// DrawTrianglesErr returns an error if len(indices) is not multiple of 3, or a value in indices is out of range of vertices.
+// DrawTrianglesErr returns an error if the options have invalid values, e.g. an invalid Address.
+func (i *Image) DrawTrianglesErr(vertices []Vertex, indices []uint16, img *Image, options *DrawTrianglesOptions) error {
+	if err := i.checkDrawErr(img, "DrawTrianglesErr"); err != nil {
+		return err
+	}
+	if options != nil {
+		if err := checkBlendErr(options.CompositeMode, options.Blend); err != nil {
+			return err
+		}
+		if err := checkFilterErr(options.Filter); err != nil {
+			return err
+		}
+		if options.Address < 0 || builtinshader.Address(options.Address) >= builtinshader.AddressCount {
+			return fmt.Errorf("ebiten: invalid address: %d", options.Address)
+		}
+		if options.FillRule != FillAll && options.FillRule != NonZero && options.FillRule != EvenOdd {
+			return fmt.Errorf("ebiten: invalid fill rule: %d", options.FillRule)
+		}
+	}
+	if len(indices)%3 != 0 {
+		return errors.New("ebiten: len(indices) % 3 must be 0")
+	}
+	n := len(vertices)
+	if n > graphicscommand.MaxVertexCount {
+		n = graphicscommand.MaxVertexCount
+	}
+	for i, idx := range indices {
+		if int(idx) >= n {
+			return fmt.Errorf("ebiten: indices[%d] must be less than len(vertices) (%d) but was %d", i, n, idx)
+		}
+	}
+	i.DrawTriangles(vertices, indices, img, options)
+	return nil
+}
+
+func (i *Image) checkDrawErr(img *Image, name string) error {
+	if err := i.checkCopyErr(); err != nil {
+		return err
+	}
+	if img == nil {
+		return fmt.Errorf("ebiten: the given image to %s must not be nil", name)
+	}
+	if img.isDisposed() {
+		return fmt.Errorf("ebiten: the given image to %s must not be disposed: %w", name, ErrImageDisposed)
+	}
+	if i.isDisposed() {
+		return ErrImageDisposed
+	}
+	if i.image == img.image {
+		return fmt.Errorf("ebiten: the given image to %s must be different from the receiver", name)
+	}
+	if i.image.ImageType() == atlas.ImageTypeExternal {
+		return errors.New("ebiten: an image created from a native texture cannot be modified")
+	}
+	if img.image.ImageType() == atlas.ImageTypeScreen {
+		return fmt.Errorf("ebiten: the screen image cannot be the given image to %s", name)
+	}
+	return nil
+}
+
+func checkBlendErr(compositeMode CompositeMode, blend Blend) error {
+	if compositeMode < CompositeModeCustom || compositeMode > CompositeModeMultiply {
+		return fmt.Errorf("ebiten: invalid composite mode: %d", compositeMode)
+	}
+	if compositeMode != CompositeModeCustom {
+		return nil
+	}
+	for _, f := range []BlendFactor{blend.BlendFactorSourceRGB, blend.BlendFactorSourceAlpha, blend.BlendFactorDestinationRGB, blend.BlendFactorDestinationAlpha} {
+		if f > BlendFactorOneMinusConstantColor {
+			return fmt.Errorf("ebiten: invalid blend factor: %d", f)
+		}
+	}
+	for _, o := range []BlendOperation{blend.BlendOperationRGB, blend.BlendOperationAlpha} {
+		if o > BlendOperationMax {
+			return fmt.Errorf("ebiten: invalid blend operation: %d", o)
+		}
+	}
+	if blend.LogicOperation > LogicOperationInvert {
+		return fmt.Errorf("ebiten: invalid logic operation: %d", blend.LogicOperation)
+	}
+	return nil
+}
+
+func checkFilterErr(filter Filter) error {
+	if filter < 0 || builtinshader.Filter(filter) >= builtinshader.FilterCount {
+		return fmt.Errorf("ebiten: invalid filter: %d", filter)
+	}
+	return nil
+}
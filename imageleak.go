@@ -0,0 +1,83 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// isImageLeakReportEnabled reports whether images that become unreachable without Deallocate or Dispose are reported.
+var isImageLeakReportEnabled = os.Getenv("EBITENGINE_IMAGE_LEAK_REPORT") != ""
+
+// imageLeakReportWriter is the destination of the reports.
+var imageLeakReportWriter io.Writer = os.Stderr
+
+// imageLeakTracker tracks an image's lifetime and reports the image when the image is not released explicitly.
+//
+// imageLeakTracker must not refer to the image, or the image would never be garbage-collected.
+type imageLeakTracker struct {
+	width   int
+	height  int
+	callers []uintptr
+}
+
+func newImageLeakTracker(width, height int) *imageLeakTracker {
+	const maxDepth = 32
+
+	callers := make([]uintptr, maxDepth)
+	// Skip runtime.Callers and newImageLeakTracker. The other frames in this package like NewImage are skipped at reportTo.
+	n := runtime.Callers(2, callers)
+	t := &imageLeakTracker{
+		width:   width,
+		height:  height,
+		callers: callers[:n],
+	}
+	runtime.SetFinalizer(t, (*imageLeakTracker).report)
+	return t
+}
+
+func (t *imageLeakTracker) release() {
+	runtime.SetFinalizer(t, nil)
+}
+
+func (t *imageLeakTracker) report() {
+	t.reportTo(imageLeakReportWriter)
+}
+
+func (t *imageLeakTracker) reportTo(w io.Writer) {
+	var b strings.Builder
+	frames := runtime.CallersFrames(t.callers)
+	// The stack trace starts with the first caller outside this package, e.g. the caller of NewImage.
+	creatorFound := false
+	for {
+		f, more := frames.Next()
+		if !creatorFound && !strings.HasPrefix(f.Function, "github.com/hajimehoshi/ebiten/v2.") {
+			creatorFound = true
+		}
+		if creatorFound {
+			fmt.Fprintf(&b, "\t%s\n\t\t%s:%d\n", f.Function, f.File, f.Line)
+		}
+		if !more {
+			break
+		}
+	}
+
+	// The internal state of the image is released by the garbage collector anyway.
+	fmt.Fprintf(w, "ebiten: an image (%d x %d) became unreachable without Deallocate or Dispose. The image was created at:\n%s", t.width, t.height, b.String())
+}
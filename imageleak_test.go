@@ -0,0 +1,76 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestImageLeakReport(t *testing.T) {
+	tracker := ebiten.NewImageLeakTrackerForTesting(3, 4)
+	defer tracker.ReleaseForTesting()
+
+	var b strings.Builder
+	tracker.ReportToForTesting(&b)
+	report := b.String()
+	if !strings.Contains(report, "(3 x 4)") {
+		t.Errorf("the report must have the image size: %q", report)
+	}
+	// The stack trace must start with the creator, not the internal functions.
+	if !strings.Contains(report, "TestImageLeakReport") {
+		t.Errorf("the report must have the creator: %q", report)
+	}
+	if strings.Contains(report, "ebiten/v2.") {
+		t.Errorf("the report must not have the functions in the ebiten package: %q", report)
+	}
+}
+
+type chanWriter chan string
+
+func (c chanWriter) Write(b []byte) (int, error) {
+	c <- string(b)
+	return len(b), nil
+}
+
+func TestImageLeakReportOnGC(t *testing.T) {
+	ch := make(chanWriter, 1)
+	restore := ebiten.SetImageLeakReportWriterForTesting(ch)
+	defer restore()
+
+	// A released tracker is not reported.
+	ebiten.NewImageLeakTrackerForTesting(1, 1).ReleaseForTesting()
+	// An unreachable tracker is reported.
+	ebiten.NewImageLeakTrackerForTesting(5, 6)
+
+	timeout := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case report := <-ch:
+			if !strings.Contains(report, "(5 x 6)") {
+				t.Errorf("got: %q, want: a report of the unreleased tracker", report)
+			}
+			return
+		case <-timeout:
+			t.Fatal("the unreachable tracker was not reported")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// The format of a pixel blob is:
+//
+//   - Magic number "EBPX" (4 bytes)
+//   - Version (1 byte)
+//   - Flags (1 byte): 1 if the pixels are compressed with DEFLATE.
+//   - Reserved (2 bytes)
+//   - The bounds: Min.X, Min.Y, Max.X and Max.Y (signed 32bit integers in little endian)
+//   - The pixels in the same format as ReadPixels, optionally compressed
+//
+// The format might have newer versions in the future, and DecodePixels will keep accepting the older versions.
+
+const (
+	pixelsMagic   = "EBPX"
+	pixelsVersion = 1
+
+	pixelsFlagCompressed = 1 << 0
+
+	// maxImageSizeBeforeGameStarts is the maximum image size for DecodePixels before the game starts,
+	// when the actual maximum size is not available yet.
+	// This is the largest maximum size among the graphics drivers in practice.
+	maxImageSizeBeforeGameStarts = 16384
+)
+
+type pixelsHeader struct {
+	Magic    [4]byte
+	Version  uint8
+	Flags    uint8
+	Reserved uint16
+	MinX     int32
+	MinY     int32
+	MaxX     int32
+	MaxY     int32
+}
+
+// EncodePixelsOptions represents options for EncodePixels.
+type EncodePixelsOptions struct {
+	// Compressed represents whether the pixels are compressed.
+	// Compression makes the blob smaller, but encoding and decoding take more time.
+	//
+	// The default (zero) value is false.
+	Compressed bool
+}
+
+// EncodePixels writes the pixels of the image to w as a binary blob with the image's bounds.
+// The blob can be loaded by DecodePixels.
+//
+// EncodePixels is useful for an asset cache to skip decoding e.g. PNG files on subsequent runs.
+// The pixels are the same as ReadPixels, i.e. premultiplied alpha RGBA, so DecodePixels doesn't need any conversion.
+//
+// EncodePixels returns ErrImageDisposed if the image is disposed.
+//
+// EncodePixels loads pixels from GPU to system memory if necessary, which means that EncodePixels can be slow.
+func (i *Image) EncodePixels(w io.Writer, options *EncodePixelsOptions) error {
+	if options == nil {
+		options = &EncodePixelsOptions{}
+	}
+	if i.isDisposed() {
+		return ErrImageDisposed
+	}
+
+	b := i.Bounds()
+	pix := make([]byte, 4*b.Dx()*b.Dy())
+	i.ReadPixels(pix)
+
+	h := pixelsHeader{
+		Version: pixelsVersion,
+		MinX:    int32(b.Min.X),
+		MinY:    int32(b.Min.Y),
+		MaxX:    int32(b.Max.X),
+		MaxY:    int32(b.Max.Y),
+	}
+	copy(h.Magic[:], pixelsMagic)
+	if options.Compressed {
+		h.Flags |= pixelsFlagCompressed
+	}
+	if err := binary.Write(w, binary.LittleEndian, &h); err != nil {
+		return err
+	}
+
+	if !options.Compressed {
+		_, err := w.Write(pix)
+		return err
+	}
+
+	fw, err := flate.NewWriter(w, flate.BestSpeed)
+	if err != nil {
+		return err
+	}
+	if _, err := fw.Write(pix); err != nil {
+		return err
+	}
+	return fw.Close()
+}
+
+// DecodePixels reads a binary blob written by EncodePixels and creates a new image with the same bounds and pixels.
+//
+// DecodePixels returns an error if the blob is broken, the version is not supported,
+// or the width or the height exceeds the maximum image size.
+func DecodePixels(r io.Reader) (*Image, error) {
+	var h pixelsHeader
+	if err := binary.Read(r, binary.LittleEndian, &h); err != nil {
+		return nil, err
+	}
+	if string(h.Magic[:]) != pixelsMagic {
+		return nil, errors.New("ebiten: invalid magic number at DecodePixels")
+	}
+	if h.Version != pixelsVersion {
+		return nil, fmt.Errorf("ebiten: unsupported version %d at DecodePixels", h.Version)
+	}
+
+	b := image.Rect(int(h.MinX), int(h.MinY), int(h.MaxX), int(h.MaxY))
+	if b.Dx() <= 0 || b.Dy() <= 0 || b.Min != image.Pt(int(h.MinX), int(h.MinY)) {
+		return nil, fmt.Errorf("ebiten: invalid bounds %v at DecodePixels", b)
+	}
+	// Reject a too big image before allocating its pixels, as the blob might be broken or malicious.
+	m := ui.Get().MaxImageSize()
+	if m == 0 {
+		m = maxImageSizeBeforeGameStarts
+	}
+	if b.Dx() > m || b.Dy() > m {
+		return nil, fmt.Errorf("ebiten: too big bounds %v at DecodePixels: the maximum size is %d", b, m)
+	}
+
+	if h.Flags&pixelsFlagCompressed != 0 {
+		fr := flate.NewReader(r)
+		defer fr.Close()
+		r = fr
+	}
+
+	pix := make([]byte, 4*b.Dx()*b.Dy())
+	if _, err := io.ReadFull(r, pix); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	img := NewImageWithOptions(b, nil)
+	img.WritePixels(pix)
+	return img, nil
+}
@@ -87,6 +87,30 @@ func CursorPosition() (x, y int) {
 	return int(cx), int(cy)
 }
 
+// CursorPositionF is the float version of CursorPosition.
+//
+// Unlike CursorPosition, CursorPositionF doesn't round the position.
+// This is useful when the screen is scaled, or the device scale factor is fractional.
+//
+// CursorPositionF is concurrent-safe.
+func CursorPositionF() (x, y float64) {
+	return theInputState.cursorPosition()
+}
+
+// SetCursorPosition moves the mouse cursor to the given position relative to the game screen (window).
+// The position is a 'logical' position in the same way as CursorPosition.
+//
+// The cursor is moved at the beginning of the next tick, and CursorPosition reflects the new position from the tick.
+//
+// SetCursorPosition does nothing when the cursor mode is CursorModeCaptured, or the window doesn't have the focus.
+//
+// SetCursorPosition works only on desktops. SetCursorPosition does nothing on browsers and mobiles.
+//
+// SetCursorPosition is concurrent-safe.
+func SetCursorPosition(x, y int) {
+	ui.Get().SetCursorPosition(float64(x), float64(y))
+}
+
 // Wheel returns x and y offsets of the mouse wheel or touchpad scroll.
 // It returns 0 if the wheel isn't being rolled.
 //
@@ -147,6 +171,25 @@ func AppendGamepadIDs(gamepadIDs []GamepadID) []GamepadID {
 	return gamepad.AppendGamepadIDs(gamepadIDs)
 }
 
+// RequestHIDGamepads shows a prompt to let the user choose HID gamepads to access via WebHID on browsers.
+//
+// Some controllers like flight sticks and racing wheels are mapped badly by the Gamepad API.
+// Once the user permits to access such devices, the devices are available as gamepads like the other gamepads,
+// and the extra axes, buttons and hats are exposed. A device accessed via WebHID is not reported via
+// the Gamepad API to avoid duplications.
+// The permitted devices are available automatically at the next launch.
+//
+// The prompt requires a user activation. Call RequestHIDGamepads just after a user interaction,
+// e.g. when IsMouseButtonPressed or IsKeyPressed returns true.
+//
+// WebHID requires a secure (HTTPS) context, and is available only on some browsers like Chrome.
+// RequestHIDGamepads does nothing on the other environments.
+//
+// RequestHIDGamepads is concurrent-safe.
+func RequestHIDGamepads() {
+	gamepad.RequestHIDDevices()
+}
+
 // GamepadIDs returns a slice indicating available gamepad IDs.
 //
 // Deprecated: as of v2.2. Use AppendGamepadIDs instead.
@@ -192,6 +235,13 @@ func GamepadAxis(id GamepadID, axis GamepadAxisType) float64 {
 
 // GamepadButtonCount returns the number of the buttons of the given gamepad (id).
 //
+// GamepadButtonCount includes the buttons for hat switches. Each hat switch is treated as 4 buttons (up, right, down and left)
+// following the actual buttons. A device with multiple hat switches has multiple sets of the 4 buttons.
+//
+// The returned value can exceed GamepadButtonMax+1 for devices with many buttons like HOTAS.
+// So far, this happens only with DirectInput on Windows. With the other backends, a device with more buttons than
+// GamepadButtonMax+1 is not treated as a gamepad.
+//
 // GamepadButtonCount is concurrent-safe.
 func GamepadButtonCount(id GamepadID) int {
 	g := gamepad.Get(id)
@@ -142,10 +142,14 @@ func (i *inputState) update() {
 	for _, id := range i.gamepadIDsBuf {
 		i.gamepadIDs[id] = struct{}{}
 
-		if _, ok := i.gamepadButtonDurations[id]; !ok {
-			i.gamepadButtonDurations[id] = make([]int, ebiten.GamepadButtonMax+1)
+		n := int(ebiten.GamepadButtonMax) + 1
+		if c := ebiten.GamepadButtonCount(id); n < c {
+			n = c
 		}
-		for b := ebiten.GamepadButton(0); b <= ebiten.GamepadButtonMax; b++ {
+		if len(i.gamepadButtonDurations[id]) < n {
+			i.gamepadButtonDurations[id] = append(i.gamepadButtonDurations[id], make([]int, n-len(i.gamepadButtonDurations[id]))...)
+		}
+		for b := ebiten.GamepadButton(0); int(b) < n; b++ {
 			if ebiten.IsGamepadButtonPressed(id, b) {
 				i.gamepadButtonDurations[id][b]++
 			} else {
@@ -460,16 +464,16 @@ func AppendJustReleasedGamepadButtons(id ebiten.GamepadID, buttons []ebiten.Game
 		return buttons
 	}
 
-	for b := ebiten.GamepadButton(0); b <= ebiten.GamepadButtonMax; b++ {
+	for b := range theInputState.gamepadButtonDurations[id] {
 		if theInputState.gamepadButtonDurations[id][b] == 0 {
 			continue
 		}
 
-		if theInputState.prevGamepadButtonDurations[id][b] > 0 {
+		if b < len(theInputState.prevGamepadButtonDurations[id]) && theInputState.prevGamepadButtonDurations[id][b] > 0 {
 			continue
 		}
 
-		buttons = append(buttons, b)
+		buttons = append(buttons, ebiten.GamepadButton(b))
 	}
 
 	return buttons
@@ -494,12 +498,12 @@ func IsGamepadButtonJustPressed(id ebiten.GamepadID, button ebiten.GamepadButton
 func IsGamepadButtonJustReleased(id ebiten.GamepadID, button ebiten.GamepadButton) bool {
 	theInputState.m.RLock()
 	prev := 0
-	if _, ok := theInputState.prevGamepadButtonDurations[id]; ok {
-		prev = theInputState.prevGamepadButtonDurations[id][button]
+	if ds, ok := theInputState.prevGamepadButtonDurations[id]; ok && int(button) < len(ds) {
+		prev = ds[button]
 	}
 	current := 0
-	if _, ok := theInputState.gamepadButtonDurations[id]; ok {
-		current = theInputState.gamepadButtonDurations[id][button]
+	if ds, ok := theInputState.gamepadButtonDurations[id]; ok && int(button) < len(ds) {
+		current = ds[button]
 	}
 	theInputState.m.RUnlock()
 	return current == 0 && prev > 0
@@ -513,8 +517,8 @@ func IsGamepadButtonJustReleased(id ebiten.GamepadID, button ebiten.GamepadButto
 func GamepadButtonPressDuration(id ebiten.GamepadID, button ebiten.GamepadButton) int {
 	theInputState.m.RLock()
 	s := 0
-	if _, ok := theInputState.gamepadButtonDurations[id]; ok {
-		s = theInputState.gamepadButtonDurations[id][button]
+	if ds, ok := theInputState.gamepadButtonDurations[id]; ok && int(button) < len(ds) {
+		s = ds[button]
 	}
 	theInputState.m.RUnlock()
 	return s
@@ -14,6 +14,12 @@
 
 package atlas
 
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+)
+
 const (
 	BaseCountToPutOnSourceBackend = baseCountToPutOnSourceBackend
 )
@@ -69,3 +75,34 @@ func DeferredFuncCountForTesting() int {
 	defer deferredM.Unlock()
 	return len(deferred)
 }
+
+func DefragmentSourceBackendsForTesting() {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+	defragmentSourceBackends()
+}
+
+func (i *Image) IsOnSameBackendForTesting(other *Image) bool {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+	return i.backend != nil && i.backend == other.backend
+}
+
+// ReadPixelsWithPaddingForTesting reads the pixels of the image including the paddings around it.
+// For ImageTypeExtruded, the region is (-1, -1)-(width+1, height+1).
+func (i *Image) ReadPixelsWithPaddingForTesting(graphicsDriver graphicsdriver.Graphics, pixels []byte) (bool, error) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		return false, nil
+	}
+	flushDeferred()
+
+	o := i.paddingOffset()
+	p := i.paddingSize()
+	if err := i.readPixels(graphicsDriver, pixels, image.Rect(-o, -o, i.width+p-o, i.height+p-o)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
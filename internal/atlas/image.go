@@ -19,7 +19,9 @@ import (
 	"image"
 	"math"
 	"math/bits"
+	"regexp"
 	"runtime"
+	"sort"
 	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/debug"
@@ -70,6 +72,14 @@ func flushDeferred() {
 	}
 }
 
+// DiscardDeferred discards the deferred function calls without executing them.
+// DiscardDeferred is used when there is no graphics driver, e.g., in the headless mode.
+func DiscardDeferred() {
+	deferredM.Lock()
+	defer deferredM.Unlock()
+	deferred = nil
+}
+
 // baseCountToPutOnSourceBackend represents the base time duration when the image can be put onto an atlas.
 // Actual time duration is increased in an exponential way for each usage as a rendering target.
 const baseCountToPutOnSourceBackend = 10
@@ -97,6 +107,82 @@ func putImagesOnSourceBackend() {
 	imagesToPutOnSourceBackend.clear()
 }
 
+const (
+	// defragmentationUsageThreshold is the ratio of the used area of a source backend.
+	// If the ratio is less than this value, the images on the backend are moved to other source backends so that
+	// the backend can be released.
+	defragmentationUsageThreshold = 0.25
+
+	// defragmentationBudgetPerFrame is the maximum number of pixels moved by defragmentation in one frame.
+	defragmentationBudgetPerFrame = 512 * 512
+)
+
+// defragmentSourceBackends moves images on the sparsest source backend to the other source backends, in order to
+// reclaim texture memory after many temporary images are created and disposed (e.g. at loading screens).
+//
+// Images are moved only within the per-frame budget, so a backend might be evacuated over multiple frames.
+func defragmentSourceBackends() {
+	var sparsest *backend
+	minUsage := defragmentationUsageThreshold
+	var sourceBackendCount int
+	for _, b := range theBackends {
+		if !b.source || b.page == nil {
+			continue
+		}
+		sourceBackendCount++
+		w, h := b.page.Size()
+		if usage := float64(b.page.UsedArea()) / float64(w*h); usage < minUsage {
+			sparsest = b
+			minUsage = usage
+		}
+	}
+	if sparsest == nil || sourceBackendCount < 2 {
+		return
+	}
+
+	budget := defragmentationBudgetPerFrame
+	for i := range sparsest.images {
+		r := i.regionWithPadding()
+		area := r.Dx() * r.Dy()
+		if area > budget {
+			continue
+		}
+		if !i.moveToAnotherSourceBackend() {
+			// There is no more space on the other backends.
+			return
+		}
+		budget -= area
+	}
+}
+
+// moveToAnotherSourceBackend moves the image to another existing source backend without extending the backend.
+// moveToAnotherSourceBackend reports whether the image is moved.
+func (i *Image) moveToAnotherSourceBackend() bool {
+	newI := NewImage(i.width, i.height, i.imageType)
+	if !newI.allocateOnExistingBackends([]*backend{i.backend}, true, false) {
+		return false
+	}
+
+	w, h := float32(i.width), float32(i.height)
+	vs := make([]float32, 4*graphics.VertexFloatCount)
+	graphics.QuadVertices(vs, 0, 0, w, h, 1, 0, 0, 1, 0, 0, 1, 1, 1, 1)
+	is := graphics.QuadIndices()
+	dr := image.Rect(0, 0, i.width, i.height)
+	newI.drawTriangles([graphics.ShaderImageCount]*Image{i}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, NearestFilterShader, nil, graphicsdriver.FillAll)
+	if i.imageType == ImageTypeExtruded {
+		newI.extrudeEdgesFrom(i)
+	}
+
+	// Keep the counters as the usage of the image doesn't change.
+	usedAsSourceCount := i.usedAsSourceCount
+	usedAsDestinationCount := i.usedAsDestinationCount
+	newI.moveTo(i)
+	imagesUsedAsDestination.remove(newI)
+	i.usedAsSourceCount = usedAsSourceCount
+	i.usedAsDestinationCount = usedAsDestinationCount
+	return true
+}
+
 type backend struct {
 	// image is an atlas on which there might be multiple images.
 	image *graphicscommand.Image
@@ -116,6 +202,54 @@ type backend struct {
 	// sourceInThisFrame reports whether this backend is used as a source in this frame.
 	// sourceInThisFrame is reset every frame.
 	sourceInThisFrame bool
+
+	// images is a set of images on the atlas.
+	// images is used only when page is not nil.
+	images map[*Image]struct{}
+}
+
+// label returns a label of the backend for debugging.
+func (b *backend) label() string {
+	switch {
+	case b.page != nil && b.source:
+		return "atlas-source"
+	case b.page != nil:
+		return "atlas-destination"
+	case b.source:
+		return "isolated-source"
+	default:
+		return "isolated"
+	}
+}
+
+// setAtlasPageForDump sets the information about the atlas page to the backend's image for dumping.
+func (b *backend) setAtlasPageForDump() {
+	if b.page == nil {
+		b.image.SetAtlasPage(false, nil)
+		return
+	}
+	regions := make([]image.Rectangle, 0, len(b.images))
+	for i := range b.images {
+		regions = append(regions, i.regionWithPadding())
+	}
+	sort.Slice(regions, func(i, j int) bool {
+		if regions[i].Min.Y != regions[j].Min.Y {
+			return regions[i].Min.Y < regions[j].Min.Y
+		}
+		return regions[i].Min.X < regions[j].Min.X
+	})
+	b.image.SetAtlasPage(true, regions)
+}
+
+func (b *backend) addImage(i *Image) {
+	if b.images == nil {
+		b.images = map[*Image]struct{}{}
+	}
+	b.images[i] = struct{}{}
+}
+
+func (b *backend) removeImage(i *Image) {
+	delete(b.images, i)
 }
 
 func (b *backend) tryAlloc(width, height int) (*packing.Node, bool) {
@@ -143,6 +277,7 @@ func (b *backend) extendIfNeeded(width, height int) {
 
 	// Assume that the screen image is never extended.
 	newImg := newClearedImage(width, height, false)
+	newImg.SetLabel(b.label())
 
 	// Use DrawTriangles instead of WritePixels because the image i might be stale and not have its pixels
 	// information.
@@ -214,12 +349,71 @@ var (
 
 	graphicsDriverInitialized bool
 
+	// backendCreatedInThisFrame reports whether a new backend with a page is created in this frame.
+	// Defragmentation is skipped in such frames since many images are likely being created (e.g. at loading).
+	backendCreatedInThisFrame bool
+
 	deferred []func()
 
 	// deferredM is a mutex for the slice operations. This must not be used for other usages.
 	deferredM sync.Mutex
 )
 
+// extrudeEdgesFrom duplicates the border pixels of src into i's paddings on all the four sides.
+// i must be ImageTypeExtruded. src must have the same size as i, and must not be on the same backend as i.
+func (i *Image) extrudeEdgesFrom(src *Image) {
+	w, h := float32(i.width), float32(i.height)
+	// Source regions and destination positions of the four sides and the four corners.
+	quads := [...][6]float32{
+		{0, 0, 1, h, -1, 0},
+		{w - 1, 0, w, h, w, 0},
+		{0, 0, w, 1, 0, -1},
+		{0, h - 1, w, h, 0, h},
+		{0, 0, 1, 1, -1, -1},
+		{w - 1, 0, w, 1, w, -1},
+		{0, h - 1, 1, h, -1, h},
+		{w - 1, h - 1, w, h, w, h},
+	}
+
+	const n = 4 * graphics.VertexFloatCount
+	vs := make([]float32, len(quads)*n)
+	qis := graphics.QuadIndices()
+	is := make([]uint32, 0, len(quads)*len(qis))
+	for j, q := range quads {
+		graphics.QuadVertices(vs[j*n:(j+1)*n], q[0], q[1], q[2], q[3], 1, 0, 0, 1, q[4], q[5], 1, 1, 1, 1)
+		for _, idx := range qis {
+			is = append(is, idx+uint32(4*j))
+		}
+	}
+
+	dr := image.Rect(-1, -1, i.width+1, i.height+1)
+	i.drawTriangles([graphics.ShaderImageCount]*Image{src}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, NearestFilterShader, nil, graphicsdriver.FillAll)
+	i.edgesDirty = false
+}
+
+// reextrudeEdges updates the paddings of i after i is modified.
+// As i cannot be a source and a destination at the same time, the pixels are copied to a temporary image first.
+func (i *Image) reextrudeEdges() {
+	i.edgesDirty = false
+	if i.backend == nil {
+		return
+	}
+
+	tmp := NewImage(i.width, i.height, ImageTypeRegular)
+	defer func() {
+		tmp.deallocate()
+		runtime.SetFinalizer(tmp, nil)
+	}()
+
+	w, h := float32(i.width), float32(i.height)
+	vs := make([]float32, 4*graphics.VertexFloatCount)
+	graphics.QuadVertices(vs, 0, 0, w, h, 1, 0, 0, 1, 0, 0, 1, 1, 1, 1)
+	is := graphics.QuadIndices()
+	dr := image.Rect(0, 0, i.width, i.height)
+	tmp.drawTriangles([graphics.ShaderImageCount]*Image{i}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, NearestFilterShader, nil, graphicsdriver.FillAll)
+	i.extrudeEdgesFrom(tmp)
+}
+
 type ImageType int
 
 const (
@@ -227,6 +421,15 @@ const (
 	ImageTypeScreen
 	ImageTypeVolatile
 	ImageTypeUnmanaged
+
+	// ImageTypeExternal is an image that wraps an existing native texture.
+	// An external image is read-only and never on an atlas.
+	ImageTypeExternal
+
+	// ImageTypeExtruded is a regular image whose border pixels are duplicated into the paddings on all the four sides.
+	// This prevents sampling outside the image, e.g. linear filtering and mipmaps, from reading transparent paddings
+	// or other images on the same atlas.
+	ImageTypeExtruded
 )
 
 // Image is a rectangle pixel set that might be on an atlas.
@@ -235,6 +438,9 @@ type Image struct {
 	height    int
 	imageType ImageType
 
+	// native is a native texture for ImageTypeExternal.
+	native uintptr
+
 	backend                   *backend
 	backendCreatedInThisFrame bool
 
@@ -256,6 +462,10 @@ type Image struct {
 	//
 	// usedAsDestinationCount is never reset.
 	usedAsDestinationCount int
+
+	// edgesDirty reports whether the paddings of an ImageTypeExtruded image might be stale after the image is modified.
+	// The paddings are updated when the image is used as a source next time.
+	edgesDirty bool
 }
 
 // moveTo moves its content to the given image dst.
@@ -266,6 +476,11 @@ func (i *Image) moveTo(dst *Image) {
 	dst.deallocate()
 	*dst = *i
 
+	if dst.isOnAtlas() {
+		dst.backend.removeImage(i)
+		dst.backend.addImage(dst)
+	}
+
 	// i is no longer available but the finalizer must not be called
 	// since i and dst share the same backend and the same node.
 	runtime.SetFinalizer(i, nil)
@@ -287,13 +502,38 @@ func (i *Image) resetUsedAsSourceCount() {
 	imagesToPutOnSourceBackend.remove(i)
 }
 
+// isRegular reports whether the image is a regular image that can be on an atlas.
+func (i *Image) isRegular() bool {
+	return i.imageType == ImageTypeRegular || i.imageType == ImageTypeExtruded
+}
+
+// paddingSize returns the total size of the paddings in each direction.
 func (i *Image) paddingSize() int {
-	if i.imageType == ImageTypeRegular {
+	switch i.imageType {
+	case ImageTypeRegular:
+		// The padding is only on the right and bottom sides.
+		return 1
+	case ImageTypeExtruded:
+		// The paddings are on all the four sides.
+		return 2
+	}
+	return 0
+}
+
+// paddingOffset returns the size of the paddings on the left and top sides.
+func (i *Image) paddingOffset() int {
+	if i.imageType == ImageTypeExtruded {
 		return 1
 	}
 	return 0
 }
 
+// contentOrigin returns the upper-left position of the image's content in the backend.
+func (i *Image) contentOrigin() image.Point {
+	o := i.paddingOffset()
+	return i.regionWithPadding().Min.Add(image.Pt(o, o))
+}
+
 func (i *Image) ensureIsolatedFromSource(backends []*backend) {
 	i.resetUsedAsSourceCount()
 
@@ -349,6 +589,9 @@ func (i *Image) ensureIsolatedFromSource(backends []*backend) {
 	dr := image.Rect(0, 0, i.width, i.height)
 
 	newI.drawTriangles([graphics.ShaderImageCount]*Image{i}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, NearestFilterShader, nil, graphicsdriver.FillAll)
+	if i.imageType == ImageTypeExtruded {
+		newI.extrudeEdgesFrom(i)
+	}
 	newI.moveTo(i)
 }
 
@@ -366,11 +609,11 @@ func (i *Image) putOnSourceBackend() {
 		panic("atlas: putOnSourceBackend cannot be called on a image that cannot be on an atlas")
 	}
 
-	if i.imageType != ImageTypeRegular {
-		panic(fmt.Sprintf("atlas: the image type must be ImageTypeRegular but %d", i.imageType))
+	if !i.isRegular() {
+		panic(fmt.Sprintf("atlas: the image type must be ImageTypeRegular or ImageTypeExtruded but %d", i.imageType))
 	}
 
-	newI := NewImage(i.width, i.height, ImageTypeRegular)
+	newI := NewImage(i.width, i.height, i.imageType)
 	newI.allocate(nil, true)
 
 	w, h := float32(i.width), float32(i.height)
@@ -379,6 +622,9 @@ func (i *Image) putOnSourceBackend() {
 	is := graphics.QuadIndices()
 	dr := image.Rect(0, 0, i.width, i.height)
 	newI.drawTriangles([graphics.ShaderImageCount]*Image{i}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, NearestFilterShader, nil, graphicsdriver.FillAll)
+	if i.imageType == ImageTypeExtruded {
+		newI.extrudeEdgesFrom(i)
+	}
 
 	newI.moveTo(i)
 	i.usedAsSourceCount = 0
@@ -436,6 +682,14 @@ func (i *Image) drawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices [
 		return
 	}
 
+	// Update the stale paddings of the sources before the sources' backends are determined,
+	// as updating the paddings might move the sources.
+	for _, src := range srcs {
+		if src != nil && src.edgesDirty {
+			src.reextrudeEdges()
+		}
+	}
+
 	// This slice is not escaped to the heap. This can be checked by `go build -gcflags=-m`.
 	backends := make([]*backend, 0, len(srcs))
 	for _, src := range srcs {
@@ -461,16 +715,16 @@ func (i *Image) drawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices [
 		}
 	}
 
-	r := i.regionWithPadding()
+	o := i.contentOrigin()
 	// TODO: Check if dstRegion does not to violate the region.
-	dstRegion = dstRegion.Add(r.Min)
+	dstRegion = dstRegion.Add(o)
 
-	dx, dy := float32(r.Min.X), float32(r.Min.Y)
+	dx, dy := float32(o.X), float32(o.Y)
 
 	var oxf, oyf float32
 	if srcs[0] != nil {
-		r := srcs[0].regionWithPadding()
-		oxf, oyf = float32(r.Min.X), float32(r.Min.Y)
+		o := srcs[0].contentOrigin()
+		oxf, oyf = float32(o.X), float32(o.Y)
 		n := len(vertices)
 		for i := 0; i < n; i += graphics.VertexFloatCount {
 			vertices[i] += dx
@@ -505,8 +759,7 @@ func (i *Image) drawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices [
 			continue
 		}
 
-		r := src.regionWithPadding()
-		srcRegions[i] = srcRegions[i].Add(r.Min)
+		srcRegions[i] = srcRegions[i].Add(src.contentOrigin())
 	}
 
 	var imgs [graphics.ShaderImageCount]*graphicscommand.Image
@@ -518,6 +771,9 @@ func (i *Image) drawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices [
 	}
 
 	i.backend.image.DrawTriangles(imgs, vertices, indices, blend, dstRegion, srcRegions, shader.ensureShader(), uniforms, fillRule)
+	if i.imageType == ImageTypeExtruded {
+		i.edgesDirty = true
+	}
 
 	for _, src := range srcs {
 		if src == nil {
@@ -566,7 +822,11 @@ func (i *Image) writePixels(pix []byte, region image.Rectangle) {
 	r := i.regionWithPadding()
 
 	if !region.Eq(image.Rect(0, 0, i.width, i.height)) || i.paddingSize() == 0 {
-		region = region.Add(r.Min)
+		if i.imageType == ImageTypeExtruded {
+			i.edgesDirty = true
+		}
+
+		region = region.Add(i.contentOrigin())
 
 		if pix == nil {
 			i.backend.clearPixels(region)
@@ -581,6 +841,11 @@ func (i *Image) writePixels(pix []byte, region image.Rectangle) {
 		return
 	}
 
+	if i.imageType == ImageTypeExtruded {
+		i.writePixelsWithExtrudedEdges(pix, r)
+		return
+	}
+
 	// TODO: These loops assume that paddingSize is 1.
 	// TODO: Is clearing edges explicitly really needed?
 	const paddingSize = 1
@@ -609,6 +874,28 @@ func (i *Image) writePixels(pix []byte, region image.Rectangle) {
 	i.backend.writePixels(pixb, r)
 }
 
+// writePixelsWithExtrudedEdges writes the whole pixels of an ImageTypeExtruded image with its paddings.
+// r is the region with the paddings.
+func (i *Image) writePixelsWithExtrudedEdges(pix []byte, r image.Rectangle) {
+	w, h := i.width, i.height
+	pixb := graphics.NewManagedBytes(4*r.Dx()*r.Dy(), func(bs []byte) {
+		rowPixels := 4 * r.Dx()
+		// Copy the content with the left and right edges.
+		for j := 0; j < h; j++ {
+			row := bs[rowPixels*(j+1) : rowPixels*(j+2)]
+			src := pix[4*j*w : 4*(j+1)*w]
+			copy(row[4:], src)
+			copy(row[:4], src[:4])
+			copy(row[rowPixels-4:], src[len(src)-4:])
+		}
+		// Copy the top and bottom edges including the corners.
+		copy(bs[:rowPixels], bs[rowPixels:2*rowPixels])
+		copy(bs[rowPixels*(r.Dy()-1):], bs[rowPixels*(r.Dy()-2):rowPixels*(r.Dy()-1)])
+	})
+	i.backend.writePixels(pixb, r)
+	i.edgesDirty = false
+}
+
 func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte, region image.Rectangle) (ok bool, err error) {
 	backendsM.Lock()
 	defer backendsM.Unlock()
@@ -628,6 +915,38 @@ func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte
 	return true, nil
 }
 
+// NativeTexture returns the image's native texture and the texture's size.
+// The image occupies the region from (0, 0) in the texture.
+//
+// NativeTexture is available only for ImageTypeUnmanaged and ImageTypeExternal, which are never on an atlas and
+// never moved to another texture.
+func (i *Image) NativeTexture(graphicsDriver graphicsdriver.Graphics) (native uintptr, width, height int, ok bool, err error) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if i.imageType != ImageTypeUnmanaged && i.imageType != ImageTypeExternal {
+		return 0, 0, 0, false, fmt.Errorf("atlas: the image type must be ImageTypeUnmanaged or ImageTypeExternal but %d", i.imageType)
+	}
+
+	if !inFrame {
+		// Not ready to get the texture. Try this later.
+		return 0, 0, 0, false, nil
+	}
+
+	flushDeferred()
+
+	if i.backend == nil {
+		i.allocate(nil, false)
+	}
+
+	native, err = i.backend.image.NativeTexture(graphicsDriver)
+	if err != nil {
+		return 0, 0, 0, false, err
+	}
+	width, height = i.backend.image.InternalSize()
+	return native, width, height, true, nil
+}
+
 func (i *Image) readPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte, region image.Rectangle) error {
 	if i.backend == nil || i.backend.image == nil {
 		for i := range pixels {
@@ -639,7 +958,7 @@ func (i *Image) readPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte
 	if err := i.backend.image.ReadPixels(graphicsDriver, []graphicsdriver.PixelsArgs{
 		{
 			Pixels: pixels,
-			Region: region.Add(i.regionWithPadding().Min),
+			Region: region.Add(i.contentOrigin()),
 		},
 	}); err != nil {
 		return err
@@ -686,6 +1005,7 @@ func (i *Image) deallocate() {
 		return
 	}
 
+	i.backend.removeImage(i)
 	i.backend.page.Free(i.node)
 	if !i.backend.page.IsEmpty() {
 		// As this part can be reused, this should be cleared explicitly.
@@ -709,6 +1029,34 @@ func (i *Image) deallocate() {
 	panic("atlas: backend not found at an image being deallocated")
 }
 
+// BackendInfo represents information about an image's backend for debugging.
+type BackendInfo struct {
+	// ID is the ID of the backend's internal image.
+	// ID is 0 if the image is not allocated yet.
+	ID int
+
+	// OnAtlas reports whether the image shares the backend with other images.
+	OnAtlas bool
+
+	// Source reports whether the backend is mainly used as a rendering source.
+	Source bool
+}
+
+// BackendInfo returns information about the image's backend for debugging.
+func (i *Image) BackendInfo() BackendInfo {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if i.backend == nil || i.backend.image == nil {
+		return BackendInfo{}
+	}
+	return BackendInfo{
+		ID:      i.backend.image.ID(),
+		OnAtlas: i.isOnAtlas(),
+		Source:  i.backend.source,
+	}
+}
+
 func NewImage(width, height int, imageType ImageType) *Image {
 	// Actual allocation is done lazily, and the lock is not needed.
 	return &Image{
@@ -718,11 +1066,21 @@ func NewImage(width, height int, imageType ImageType) *Image {
 	}
 }
 
+// NewExternalImage returns a new image that wraps an existing native texture.
+func NewExternalImage(width, height int, native uintptr) *Image {
+	return &Image{
+		width:     width,
+		height:    height,
+		imageType: ImageTypeExternal,
+		native:    native,
+	}
+}
+
 func (i *Image) canBePutOnAtlas() bool {
 	if minSourceSize == 0 || minDestinationSize == 0 || maxSize == 0 {
 		panic("atlas: min*Size or maxSize must be initialized")
 	}
-	if i.imageType != ImageTypeRegular {
+	if !i.isRegular() {
 		return false
 	}
 	return i.width+i.paddingSize() <= maxSize && i.height+i.paddingSize() <= maxSize
@@ -758,6 +1116,19 @@ func (i *Image) allocate(forbiddenBackends []*backend, asSource bool) {
 			width:  i.width,
 			height: i.height,
 		}
+		i.backend.image.SetLabel("screen")
+		theBackends = append(theBackends, i.backend)
+		return
+	}
+
+	if i.imageType == ImageTypeExternal {
+		// An external image doesn't have a padding, and must not be cleared.
+		i.backend = &backend{
+			image:  graphicscommand.NewImageFromNativeTexture(i.width, i.height, i.native),
+			width:  i.width,
+			height: i.height,
+		}
+		i.backend.image.SetLabel("external")
 		theBackends = append(theBackends, i.backend)
 		return
 	}
@@ -774,29 +1145,16 @@ func (i *Image) allocate(forbiddenBackends []*backend, asSource bool) {
 			image:  newClearedImage(wp, hp, false),
 			width:  wp,
 			height: hp,
-			source: asSource && i.imageType == ImageTypeRegular,
+			source: asSource && i.isRegular(),
 		}
+		i.backend.image.SetLabel(i.backend.label())
 		theBackends = append(theBackends, i.backend)
 		return
 	}
 
 	// Check if an existing backend is available.
-loop:
-	for _, b := range theBackends {
-		if b.source != asSource {
-			continue
-		}
-		for _, bb := range forbiddenBackends {
-			if b == bb {
-				continue loop
-			}
-		}
-
-		if n, ok := b.tryAlloc(wp, hp); ok {
-			i.backend = b
-			i.node = n
-			return
-		}
+	if i.allocateOnExistingBackends(forbiddenBackends, asSource, true) {
+		return
 	}
 
 	var width, height int
@@ -825,14 +1183,61 @@ loop:
 		page:   packing.NewPage(width, height, maxSize),
 		source: asSource,
 	}
+	b.image.SetLabel(b.label())
 	theBackends = append(theBackends, b)
 
+	backendCreatedInThisFrame = true
+
 	n := b.page.Alloc(wp, hp)
 	if n == nil {
 		panic("atlas: Alloc result must not be nil at allocate")
 	}
 	i.backend = b
 	i.node = n
+	b.addImage(i)
+}
+
+// allocateOnExistingBackends tries to allocate the image on one of the existing backends with pages.
+// If extend is false, the backends are never extended.
+// allocateOnExistingBackends reports whether the allocation succeeded.
+func (i *Image) allocateOnExistingBackends(forbiddenBackends []*backend, asSource bool, extend bool) bool {
+	wp := i.width + i.paddingSize()
+	hp := i.height + i.paddingSize()
+
+loop:
+	for _, b := range theBackends {
+		if b.source != asSource {
+			continue
+		}
+		for _, bb := range forbiddenBackends {
+			if b == bb {
+				continue loop
+			}
+		}
+
+		var n *packing.Node
+		if extend {
+			var ok bool
+			n, ok = b.tryAlloc(wp, hp)
+			if !ok {
+				continue
+			}
+		} else {
+			if b.page == nil {
+				continue
+			}
+			n = b.page.AllocWithoutExtension(wp, hp)
+			if n == nil {
+				continue
+			}
+		}
+
+		i.backend = b
+		i.node = n
+		b.addImage(i)
+		return true
+	}
+	return false
 }
 
 func (i *Image) DumpScreenshot(graphicsDriver graphicsdriver.Graphics, path string, blackbg bool) (string, error) {
@@ -843,6 +1248,7 @@ func (i *Image) DumpScreenshot(graphicsDriver graphicsdriver.Graphics, path stri
 		panic("atlas: DumpScreenshots must be called in between BeginFrame and EndFrame")
 	}
 
+	i.backend.setAtlasPageForDump()
 	return i.backend.image.Dump(graphicsDriver, path, blackbg, image.Rect(0, 0, i.width, i.height))
 }
 
@@ -898,6 +1304,19 @@ func floorPowerOf2(x int) int {
 	return 1 << (bits.Len(uint(x)) - 1)
 }
 
+// MaxImageSize returns the maximum width and height of a regular image.
+// MaxImageSize returns 0 if the graphics driver is not initialized yet.
+func MaxImageSize() int {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !graphicsDriverInitialized {
+		return 0
+	}
+	// A regular image has a padding on the right and bottom sides.
+	return maxSize - 1
+}
+
 func BeginFrame(graphicsDriver graphicsdriver.Graphics) error {
 	backendsM.Lock()
 	defer backendsM.Unlock()
@@ -937,11 +1356,19 @@ func BeginFrame(graphicsDriver graphicsdriver.Graphics) error {
 
 	flushDeferred()
 	putImagesOnSourceBackend()
+	if !backendCreatedInThisFrame {
+		defragmentSourceBackends()
+	}
+	backendCreatedInThisFrame = false
 
 	return nil
 }
 
-func DumpImages(graphicsDriver graphicsdriver.Graphics, dir string) (string, error) {
+// DumpImages dumps the internal images and their metadata to the specified directory.
+//
+// If filter is not nil, only the images whose names match with filter are dumped.
+// An image name consists of its ID and its label like "1_atlas-source".
+func DumpImages(graphicsDriver graphicsdriver.Graphics, dir string, filter *regexp.Regexp) (string, error) {
 	backendsM.Lock()
 	defer backendsM.Unlock()
 
@@ -954,7 +1381,8 @@ func DumpImages(graphicsDriver graphicsdriver.Graphics, dir string) (string, err
 		if backend.image == nil {
 			continue
 		}
+		backend.setAtlasPageForDump()
 		images = append(images, backend.image)
 	}
-	return graphicscommand.DumpImages(images, graphicsDriver, dir)
+	return graphicscommand.DumpImages(images, graphicsDriver, dir, filter)
 }
@@ -882,3 +882,199 @@ func TestGC(t *testing.T) {
 }
 
 // TODO: Add tests to extend image on an atlas out of the main loop
+
+func TestDefragmentSourceBackends(t *testing.T) {
+	const size = 256
+	atlas.SetImageSizeForTesting(size, size, size)
+	defer atlas.ResetImageSizeForTesting()
+
+	// Fill the first source backend with two images.
+	img0 := atlas.NewImage(size/2-1, size-1, atlas.ImageTypeRegular)
+	defer img0.Deallocate()
+	img0.WritePixels(make([]byte, 4*(size/2-1)*(size-1)), image.Rect(0, 0, size/2-1, size-1))
+
+	img1 := atlas.NewImage(size/2-1, size-1, atlas.ImageTypeRegular)
+	defer img1.Deallocate()
+	img1.WritePixels(make([]byte, 4*(size/2-1)*(size-1)), image.Rect(0, 0, size/2-1, size-1))
+
+	// The first source backend is full, then img2 is on another source backend.
+	const smallSize = 16
+	img2 := atlas.NewImage(smallSize, smallSize, atlas.ImageTypeRegular)
+	defer img2.Deallocate()
+	pix := make([]byte, 4*smallSize*smallSize)
+	for j := 0; j < smallSize; j++ {
+		for i := 0; i < smallSize; i++ {
+			pix[4*(i+j*smallSize)] = byte(i + j)
+			pix[4*(i+j*smallSize)+1] = byte(i + j)
+			pix[4*(i+j*smallSize)+2] = byte(i + j)
+			pix[4*(i+j*smallSize)+3] = byte(i + j)
+		}
+	}
+	img2.WritePixels(pix, image.Rect(0, 0, smallSize, smallSize))
+	if got, want := img2.IsOnSameBackendForTesting(img1), false; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// Make a space on the first source backend.
+	img0.Deallocate()
+
+	// img2's backend is sparse and img2 should be moved to the first source backend.
+	atlas.DefragmentSourceBackendsForTesting()
+	if got, want := img2.IsOnSameBackendForTesting(img1), true; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got, want := img2.IsOnSourceBackendForTesting(), true; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	pix = make([]byte, 4*smallSize*smallSize)
+	ok, err := img2.ReadPixels(ui.Get().GraphicsDriverForTesting(), pix, image.Rect(0, 0, smallSize, smallSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("ReadPixels failed")
+	}
+	for j := 0; j < smallSize; j++ {
+		for i := 0; i < smallSize; i++ {
+			want := color.RGBA{R: byte(i + j), G: byte(i + j), B: byte(i + j), A: byte(i + j)}
+			got := color.RGBA{R: pix[4*(i+j*smallSize)], G: pix[4*(i+j*smallSize)+1], B: pix[4*(i+j*smallSize)+2], A: pix[4*(i+j*smallSize)+3]}
+			if got != want {
+				t.Errorf("At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func extrudedTestPixels(w, h int, seed byte) []byte {
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (j*w + i)
+			pix[idx] = seed + byte(i)
+			pix[idx+1] = seed + byte(j)
+			pix[idx+2] = seed
+			pix[idx+3] = 0xff
+		}
+	}
+	return pix
+}
+
+// checkExtrudedEdges checks that every pixel in the paddings around the image is the same as its nearest border pixel.
+func checkExtrudedEdges(t *testing.T, img *atlas.Image, w, h int, want []byte) {
+	t.Helper()
+
+	pw, ph := w+2, h+2
+	pix := make([]byte, 4*pw*ph)
+	ok, err := img.ReadPixelsWithPaddingForTesting(ui.Get().GraphicsDriverForTesting(), pix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("ReadPixels failed")
+	}
+
+	clamp := func(v, max int) int {
+		if v < 0 {
+			return 0
+		}
+		if v >= max {
+			return max - 1
+		}
+		return v
+	}
+	for j := -1; j <= h; j++ {
+		for i := -1; i <= w; i++ {
+			idx := 4 * ((j+1)*pw + (i + 1))
+			got := color.RGBA{R: pix[idx], G: pix[idx+1], B: pix[idx+2], A: pix[idx+3]}
+			widx := 4 * (clamp(j, h)*w + clamp(i, w))
+			want := color.RGBA{R: want[widx], G: want[widx+1], B: want[widx+2], A: want[widx+3]}
+			if got != want {
+				t.Errorf("at(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestExtrudedEdges(t *testing.T) {
+	const w, h = 5, 3
+
+	// Put multiple images on the same atlas so that the images are adjacent.
+	var imgs []*atlas.Image
+	var pixs [][]byte
+	for i := 0; i < 3; i++ {
+		img := atlas.NewImage(w, h, atlas.ImageTypeExtruded)
+		defer img.Deallocate()
+		pix := extrudedTestPixels(w, h, byte(0x40*(i+1)))
+		img.WritePixels(pix, image.Rect(0, 0, w, h))
+		imgs = append(imgs, img)
+		pixs = append(pixs, pix)
+	}
+	for i, img := range imgs {
+		checkExtrudedEdges(t, img, w, h, pixs[i])
+	}
+	if !imgs[0].IsOnSameBackendForTesting(imgs[1]) || !imgs[1].IsOnSameBackendForTesting(imgs[2]) {
+		t.Error("the images must be on the same atlas")
+	}
+}
+
+func TestExtrudedEdgesAfterPartialWritePixels(t *testing.T) {
+	const w, h = 5, 3
+
+	img := atlas.NewImage(w, h, atlas.ImageTypeExtruded)
+	defer img.Deallocate()
+	pix := extrudedTestPixels(w, h, 0x10)
+	img.WritePixels(pix, image.Rect(0, 0, w, h))
+
+	// Overwrite the left column and the top row partially.
+	col := make([]byte, 4*h)
+	for i := range col {
+		col[i] = 0xff
+	}
+	img.WritePixels(col, image.Rect(0, 0, 1, h))
+	for j := 0; j < h; j++ {
+		copy(pix[4*j*w:4*j*w+4], col[4*j:4*j+4])
+	}
+	row := make([]byte, 4*w)
+	for i := 0; i < w; i++ {
+		row[4*i] = 0x80
+		row[4*i+3] = 0xff
+	}
+	img.WritePixels(row, image.Rect(0, 0, w, 1))
+	copy(pix[:4*w], row)
+
+	// Use the image as a source so that the paddings are updated.
+	dst := atlas.NewImage(w, h, atlas.ImageTypeRegular)
+	defer dst.Deallocate()
+	vs := quadVertices(w, h, 0, 0, 1)
+	is := graphics.QuadIndices()
+	dr := image.Rect(0, 0, w, h)
+	dst.DrawTriangles([graphics.ShaderImageCount]*atlas.Image{img}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, atlas.NearestFilterShader, nil, graphicsdriver.FillAll)
+
+	checkExtrudedEdges(t, img, w, h, pix)
+}
+
+func TestExtrudedEdgesOfRenderTarget(t *testing.T) {
+	const w, h = 5, 3
+
+	src := atlas.NewImage(w, h, atlas.ImageTypeRegular)
+	defer src.Deallocate()
+	pix := extrudedTestPixels(w, h, 0x20)
+	src.WritePixels(pix, image.Rect(0, 0, w, h))
+
+	// Render to an extruded image.
+	target := atlas.NewImage(w, h, atlas.ImageTypeExtruded)
+	defer target.Deallocate()
+	vs := quadVertices(w, h, 0, 0, 1)
+	is := graphics.QuadIndices()
+	dr := image.Rect(0, 0, w, h)
+	target.DrawTriangles([graphics.ShaderImageCount]*atlas.Image{src}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, atlas.NearestFilterShader, nil, graphicsdriver.FillAll)
+
+	// Use the render target as a source so that the paddings are updated.
+	dst := atlas.NewImage(w, h, atlas.ImageTypeRegular)
+	defer dst.Deallocate()
+	vs = quadVertices(w, h, 0, 0, 1)
+	dst.DrawTriangles([graphics.ShaderImageCount]*atlas.Image{target}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderImageCount]image.Rectangle{}, atlas.NearestFilterShader, nil, graphicsdriver.FillAll)
+
+	checkExtrudedEdges(t, target, w, h, pix)
+}
@@ -60,6 +60,15 @@ func NewImage(width, height int, imageType atlas.ImageType) *Image {
 	}
 }
 
+// NewExternalImage returns a new image that wraps an existing native texture.
+func NewExternalImage(width, height int, native uintptr) *Image {
+	return &Image{
+		img:    atlas.NewExternalImage(width, height, native),
+		width:  width,
+		height: height,
+	}
+}
+
 func (i *Image) Deallocate() {
 	i.img.Deallocate()
 	i.dotsBuffer = nil
@@ -67,6 +76,10 @@ func (i *Image) Deallocate() {
 	i.pixelsUnsynced = false
 }
 
+func (i *Image) BackendInfo() atlas.BackendInfo {
+	return i.img.BackendInfo()
+}
+
 func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte, region image.Rectangle) (bool, error) {
 	// Do not call flushDotsBufferIfNeeded here. This would slow (image/draw).Draw.
 	// See ebiten.TestImageDrawOver.
@@ -112,6 +125,12 @@ func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte
 	return true, nil
 }
 
+// NativeTexture returns the image's native texture and the texture's size.
+func (i *Image) NativeTexture(graphicsDriver graphicsdriver.Graphics) (native uintptr, width, height int, ok bool, err error) {
+	i.syncPixelsIfNeeded()
+	return i.img.NativeTexture(graphicsDriver)
+}
+
 func (i *Image) DumpScreenshot(graphicsDriver graphicsdriver.Graphics, name string, blackbg bool) (string, error) {
 	i.syncPixelsIfNeeded()
 	return i.img.DumpScreenshot(graphicsDriver, name, blackbg)
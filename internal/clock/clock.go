@@ -16,6 +16,7 @@
 package clock
 
 import (
+	"math"
 	"sync"
 	"time"
 )
@@ -42,6 +43,20 @@ var (
 	fpsCount    = 0
 	tpsCount    = 0
 
+	// interpolationFactor is the progress from the last tick to the next tick at the current frame in [0, 1].
+	interpolationFactor = 1.0
+
+	actualDroppedFrames float64
+	droppedFramesCount  = 0
+
+	// frameInterval is the estimated regular interval between frames for the heuristic to detect dropped frames.
+	frameInterval int64
+
+	// presentationMissedFrames is the total number of missed frames reported by the graphics driver.
+	presentationMissedFrames              uint64
+	presentationMissedFramesAtLastUpdated uint64
+	presentationMissedFramesAvailable     bool
+
 	m sync.Mutex
 )
 
@@ -64,6 +79,34 @@ func ActualTPS() float64 {
 	return actualTPS
 }
 
+// InterpolationFactor returns the progress from the last tick to the next tick at the current frame in [0, 1].
+func InterpolationFactor() float64 {
+	m.Lock()
+	defer m.Unlock()
+	return interpolationFactor
+}
+
+// ActualDroppedFrames returns the number of the dropped frames per second.
+func ActualDroppedFrames() float64 {
+	m.Lock()
+	defer m.Unlock()
+	return actualDroppedFrames
+}
+
+// SetPresentationMissedFrames sets the total number of the missed frames reported by the graphics driver.
+//
+// If available is true, the number is used for ActualDroppedFrames instead of the heuristic based on the frame timings.
+func SetPresentationMissedFrames(total uint64, available bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	if available && !presentationMissedFramesAvailable {
+		presentationMissedFramesAtLastUpdated = total
+	}
+	presentationMissedFrames = total
+	presentationMissedFramesAvailable = available
+}
+
 func max(a, b int64) int64 {
 	if a < b {
 		return b
@@ -128,9 +171,37 @@ func updateFPSAndTPS(now int64, count int) {
 	}
 	actualFPS = float64(fpsCount) * float64(time.Second) / float64(now-lastUpdated)
 	actualTPS = float64(tpsCount) * float64(time.Second) / float64(now-lastUpdated)
+	if presentationMissedFramesAvailable {
+		actualDroppedFrames = float64(presentationMissedFrames-presentationMissedFramesAtLastUpdated) * float64(time.Second) / float64(now-lastUpdated)
+	} else {
+		actualDroppedFrames = float64(droppedFramesCount) * float64(time.Second) / float64(now-lastUpdated)
+	}
 	lastUpdated = now
 	fpsCount = 0
 	tpsCount = 0
+	droppedFramesCount = 0
+	presentationMissedFramesAtLastUpdated = presentationMissedFrames
+}
+
+// countDroppedFrames returns the estimated number of the dropped frames from the interval between the last two frames,
+// and the new estimated regular interval between frames.
+//
+// A frame is regarded as dropped when the interval is longer than 1.5 times of the regular interval.
+func countDroppedFrames(interval, regularInterval int64) (int, int64) {
+	if interval <= 0 {
+		return 0, regularInterval
+	}
+	// A very long interval is likely a suspension, e.g. the window is minimized.
+	if interval >= int64(time.Second) {
+		return 0, regularInterval
+	}
+	if regularInterval == 0 {
+		return 0, interval
+	}
+	if interval*2 > regularInterval*3 {
+		return int((interval+regularInterval/2)/regularInterval) - 1, regularInterval
+	}
+	return 0, (regularInterval*7 + interval) / 8
 }
 
 // UpdateFrame updates the inner clock state and returns an integer value
@@ -149,6 +220,9 @@ func UpdateFrame() int {
 		// This ensures that now() must be monotonic (#875).
 		panic("clock: lastNow must be older than n")
 	}
+	var dropped int
+	dropped, frameInterval = countDroppedFrames(n-lastNow, frameInterval)
+	droppedFramesCount += dropped
 	lastNow = n
 
 	c := 0
@@ -159,6 +233,13 @@ func UpdateFrame() int {
 	}
 	updateFPSAndTPS(n, c)
 
+	interpolationFactor = 1
+	if tps > 0 {
+		// lastSystemTime is the logical time of the last tick. The rest is the time elapsed since the last tick.
+		f := float64(n-lastSystemTime) * float64(tps) / float64(time.Second)
+		interpolationFactor = math.Max(0, math.Min(f, 1))
+	}
+
 	return c
 }
 
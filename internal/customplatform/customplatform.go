@@ -0,0 +1,101 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package customplatform holds a platform layer registered via the package exp/platform.
+//
+// The platform layer is used only with the build tag ebitenginecustomplatform.
+package customplatform
+
+import (
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+type Platform interface {
+	Initialize() error
+	ScreenSize() (width, height int)
+	OpenGL() OpenGL
+	AppendTouches(touches []Touch) []Touch
+	AppendGamepads(gamepads []Gamepad) []Gamepad
+}
+
+type OpenGL interface {
+	IsES() bool
+	GetProcAddress(name string) uintptr
+	MakeContextCurrent() error
+	SwapBuffers() error
+}
+
+type Touch struct {
+	ID int
+	X  float64
+	Y  float64
+}
+
+type Gamepad struct {
+	ID             int
+	Name           string
+	StandardLayout bool
+	Axes           []float64
+	Buttons        []float64
+	ButtonsPressed []bool
+}
+
+type GamepadVibrator interface {
+	VibrateGamepad(id int, duration time.Duration, strongMagnitude float64, weakMagnitude float64)
+}
+
+type Audio interface {
+	NewAudioContext(sampleRate int, channelCount int, bufferSize time.Duration) (AudioContext, chan struct{}, error)
+}
+
+type AudioContext interface {
+	NewPlayer(r io.Reader) AudioPlayer
+	Suspend() error
+	Resume() error
+	Err() error
+}
+
+type AudioPlayer interface {
+	Pause()
+	Play()
+	IsPlaying() bool
+	Volume() float64
+	SetVolume(volume float64)
+	BufferedSize() int
+	Err() error
+	SetBufferSize(bufferSize int)
+	io.Seeker
+	io.Closer
+}
+
+type platformHolder struct {
+	platform Platform
+}
+
+var thePlatform atomic.Value
+
+func Register(platform Platform) {
+	thePlatform.Store(platformHolder{platform: platform})
+}
+
+// Get returns the registered platform, or nil if no platform is registered.
+func Get() Platform {
+	h, ok := thePlatform.Load().(platformHolder)
+	if !ok {
+		return nil
+	}
+	return h.platform
+}
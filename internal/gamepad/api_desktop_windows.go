@@ -48,12 +48,12 @@ const (
 	_DIERR_INPUTLOST   = windows.SEVERITY_ERROR<<31 | windows.FACILITY_WIN32<<16 | windows.ERROR_READ_FAULT
 	_DIERR_NOTACQUIRED = windows.SEVERITY_ERROR<<31 | windows.FACILITY_WIN32<<16 | windows.ERROR_INVALID_ACCESS
 
-	_DIJOFS_X  = uint32(unsafe.Offsetof(_DIJOYSTATE{}.lX))
-	_DIJOFS_Y  = uint32(unsafe.Offsetof(_DIJOYSTATE{}.lY))
-	_DIJOFS_Z  = uint32(unsafe.Offsetof(_DIJOYSTATE{}.lZ))
-	_DIJOFS_RX = uint32(unsafe.Offsetof(_DIJOYSTATE{}.lRx))
-	_DIJOFS_RY = uint32(unsafe.Offsetof(_DIJOYSTATE{}.lRy))
-	_DIJOFS_RZ = uint32(unsafe.Offsetof(_DIJOYSTATE{}.lRz))
+	_DIJOFS_X  = uint32(unsafe.Offsetof(_DIJOYSTATE2{}.lX))
+	_DIJOFS_Y  = uint32(unsafe.Offsetof(_DIJOYSTATE2{}.lY))
+	_DIJOFS_Z  = uint32(unsafe.Offsetof(_DIJOYSTATE2{}.lZ))
+	_DIJOFS_RX = uint32(unsafe.Offsetof(_DIJOYSTATE2{}.lRx))
+	_DIJOFS_RY = uint32(unsafe.Offsetof(_DIJOYSTATE2{}.lRy))
+	_DIJOFS_RZ = uint32(unsafe.Offsetof(_DIJOYSTATE2{}.lRz))
 
 	_DIPH_DEVICE = 0
 	_DIPH_BYID   = 2
@@ -109,15 +109,15 @@ func _DIDFT_GETTYPE(n uint32) byte {
 }
 
 func _DIJOFS_SLIDER(n int) uint32 {
-	return uint32(unsafe.Offsetof(_DIJOYSTATE{}.rglSlider) + uintptr(n)*unsafe.Sizeof(int32(0)))
+	return uint32(unsafe.Offsetof(_DIJOYSTATE2{}.rglSlider) + uintptr(n)*unsafe.Sizeof(int32(0)))
 }
 
 func _DIJOFS_POV(n int) uint32 {
-	return uint32(unsafe.Offsetof(_DIJOYSTATE{}.rgdwPOV) + uintptr(n)*unsafe.Sizeof(uint32(0)))
+	return uint32(unsafe.Offsetof(_DIJOYSTATE2{}.rgdwPOV) + uintptr(n)*unsafe.Sizeof(uint32(0)))
 }
 
 func _DIJOFS_BUTTON(n int) uint32 {
-	return uint32(unsafe.Offsetof(_DIJOYSTATE{}.rgbButtons) + uintptr(n))
+	return uint32(unsafe.Offsetof(_DIJOYSTATE2{}.rgbButtons) + uintptr(n))
 }
 
 var (
@@ -256,7 +256,7 @@ type _DIDEVICEOBJECTINSTANCEW struct {
 	wReserved           uint16
 }
 
-type _DIJOYSTATE struct {
+type _DIJOYSTATE2 struct {
 	lX         int32
 	lY         int32
 	lZ         int32
@@ -265,7 +265,28 @@ type _DIJOYSTATE struct {
 	lRz        int32
 	rglSlider  [2]int32
 	rgdwPOV    [4]uint32
-	rgbButtons [32]byte
+	rgbButtons [128]byte
+	lVX        int32
+	lVY        int32
+	lVZ        int32
+	lVRx       int32
+	lVRy       int32
+	lVRz       int32
+	rglVSlider [2]int32
+	lAX        int32
+	lAY        int32
+	lAZ        int32
+	lARx       int32
+	lARy       int32
+	lARz       int32
+	rglASlider [2]int32
+	lFX        int32
+	lFY        int32
+	lFZ        int32
+	lFRx       int32
+	lFRy       int32
+	lFRz       int32
+	rglFSlider [2]int32
 }
 
 type _DIOBJECTDATAFORMAT struct {
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !android && !nintendosdk && !playstation5
+//go:build !android && !nintendosdk && !playstation5 && !ebitenginecustomplatform
 
 package gamepad
 
@@ -61,6 +61,18 @@ const (
 	_BTN_DPAD_LEFT  = 0x222
 	_BTN_DPAD_RIGHT = 0x223
 
+	_ABS_MT_SLOT        = 0x2f
+	_ABS_MT_POSITION_X  = 0x35
+	_ABS_MT_POSITION_Y  = 0x36
+	_ABS_MT_TRACKING_ID = 0x39
+
+	_FF_RUMBLE   = 0x50
+	_FF_CONSTANT = 0x52
+	_FF_SPRING   = 0x53
+	_FF_DAMPER   = 0x55
+	_FF_MAX      = 0x7f
+	_FF_CNT      = _FF_MAX + 1
+
 	_IOC_NONE  = 0
 	_IOC_WRITE = 1
 	_IOC_READ  = 2
@@ -75,6 +87,11 @@ const (
 	_IOC_SIZESHIFT = _IOC_TYPESHIFT + _IOC_TYPEBITS
 	_IOC_DIRSHIFT  = _IOC_SIZESHIFT + _IOC_SIZEBITS
 
+	_INPUT_PROP_POINTER       = 0x00
+	_INPUT_PROP_ACCELEROMETER = 0x06
+	_INPUT_PROP_MAX           = 0x1f
+	_INPUT_PROP_CNT           = _INPUT_PROP_MAX + 1
+
 	_KEY_MAX = 0x2ff
 	_KEY_CNT = _KEY_MAX + 1
 
@@ -90,6 +107,10 @@ func _IOR(typ, nr, size uint) uint {
 	return _IOC(_IOC_READ, typ, nr, size)
 }
 
+func _IOW(typ, nr, size uint) uint {
+	return _IOC(_IOC_WRITE, typ, nr, size)
+}
+
 func _EVIOCGABS(abs uint) uint {
 	return _IOR('E', 0x40+abs, uint(unsafe.Sizeof(input_absinfo{})))
 }
@@ -106,6 +127,65 @@ func _EVIOCGNAME(len uint) uint {
 	return _IOC(_IOC_READ, 'E', 0x06, len)
 }
 
+func _EVIOCGUNIQ(len uint) uint {
+	return _IOC(_IOC_READ, 'E', 0x08, len)
+}
+
+func _EVIOCGPROP(len uint) uint {
+	return _IOC(_IOC_READ, 'E', 0x09, len)
+}
+
+func _EVIOCSFF() uint {
+	return _IOW('E', 0x80, uint(unsafe.Sizeof(ff_effect{})))
+}
+
+type ff_trigger struct {
+	button   uint16
+	interval uint16
+}
+
+type ff_replay struct {
+	length uint16
+	delay  uint16
+}
+
+type ff_rumble_effect struct {
+	strong_magnitude uint16
+	weak_magnitude   uint16
+}
+
+type ff_envelope struct {
+	attack_length uint16
+	attack_level  uint16
+	fade_length   uint16
+	fade_level    uint16
+}
+
+type ff_constant_effect struct {
+	level    int16
+	envelope ff_envelope
+}
+
+type ff_condition_effect struct {
+	right_saturation uint16
+	left_saturation  uint16
+	right_coeff      int16
+	left_coeff       int16
+	deadband         uint16
+	center           int16
+}
+
+type ff_effect struct {
+	typ       uint16
+	id        int16
+	direction uint16
+	trigger   ff_trigger
+	replay    ff_replay
+
+	// u is the union of the effects. The biggest member is ff_periodic_effect, which has a pointer at its end.
+	u [24/unsafe.Sizeof(uintptr(0)) + 1]uintptr
+}
+
 type input_absinfo struct {
 	value      int32
 	minimum    int32
@@ -71,6 +71,13 @@ func SetNativeWindow(nativeWindow uintptr) {
 	theGamepads.setNativeWindow(nativeWindow)
 }
 
+// RequestHIDDevices requests the user to permit to access HID gamepads.
+//
+// RequestHIDDevices is concurrent-safe.
+func RequestHIDDevices() {
+	theGamepads.requestHIDDevices()
+}
+
 func (g *gamepads) appendGamepadIDs(ids []ID) []ID {
 	g.m.Lock()
 	defer g.m.Unlock()
@@ -101,8 +108,9 @@ func (g *gamepads) update() error {
 	// A gamepad can be detected even though there are not. Apparently, some special devices are
 	// recognized as gamepads by OSes. In this case, the number of the 'buttons' can exceed the
 	// maximum. Skip such devices as a tentative solution (#1173, #2039).
+	// The maximum depends on the backend. See maxButtonCounter.
 	g.remove(func(gamepad *Gamepad) bool {
-		return gamepad.ButtonCount() > ButtonCount
+		return gamepad.ButtonCount() > gamepad.maxButtonCount()
 	})
 
 	for _, gp := range g.gamepads {
@@ -179,6 +187,16 @@ func (g *gamepads) setNativeWindow(nativeWindow uintptr) {
 	}
 }
 
+func (g *gamepads) requestHIDDevices() {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	var n any = g.native
+	if n, ok := n.(interface{ requestHIDDevices() }); ok {
+		n.requestHIDDevices()
+	}
+}
+
 type Gamepad struct {
 	name  string
 	sdlID string
@@ -251,6 +269,74 @@ type nativeGamepad interface {
 	vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64)
 }
 
+// maxButtonCounter is an optional interface for nativeGamepad that can have more buttons than ButtonCount.
+//
+// A gamepad without maxButtonCounter can have up to ButtonCount buttons.
+type maxButtonCounter interface {
+	maxButtonCount() int
+}
+
+// motionSensor is an optional interface for nativeGamepad that can report the values of motion sensors.
+type motionSensor interface {
+	motion() (acceleration, angularVelocity [3]float64, ok bool)
+}
+
+// touchpad is an optional interface for nativeGamepad that can report the touches on a touchpad.
+type touchpad interface {
+	appendTouchpadTouches(touches []TouchpadTouch) []TouchpadTouch
+}
+
+// forceFeedback is an optional interface for nativeGamepad that can play force feedback effects.
+type forceFeedback interface {
+	isForceFeedbackSupported(typ ForceFeedbackEffectType) bool
+	playForceFeedback(effect *ForceFeedbackEffect)
+	stopForceFeedback()
+}
+
+// lightBar is an optional interface for nativeGamepad that can change the color of a light bar.
+type lightBar interface {
+	hasLightBar() bool
+	setLightBarColor(r, g, b uint8)
+}
+
+// ForceFeedbackEffectType represents a type of a force feedback effect.
+type ForceFeedbackEffectType int
+
+const (
+	ForceFeedbackEffectTypeConstant ForceFeedbackEffectType = iota
+	ForceFeedbackEffectTypeSpring
+	ForceFeedbackEffectTypeDamper
+)
+
+// ForceFeedbackEffect represents a force feedback effect.
+type ForceFeedbackEffect struct {
+	Type ForceFeedbackEffectType
+
+	// Duration is the time duration of the effect. 0 means an infinite duration.
+	Duration time.Duration
+
+	// Magnitude is the force of a constant effect in between -1 and 1.
+	Magnitude float64
+
+	// Coefficient is the strength of a spring or damper effect in between 0 and 1.
+	Coefficient float64
+
+	// Center is the center of a spring effect in between -1 and 1.
+	Center float64
+
+	// Deadband is the size of the region around the center where a condition effect is not active, in between 0 and 1.
+	Deadband float64
+}
+
+// TouchpadTouch represents a touch on a gamepad's touchpad.
+type TouchpadTouch struct {
+	ID int
+
+	// X and Y are in between 0 and 1.
+	X float64
+	Y float64
+}
+
 func (g *Gamepad) update(gamepads *gamepads) error {
 	g.m.Lock()
 	defer g.m.Unlock()
@@ -289,6 +375,16 @@ func (g *Gamepad) ButtonCount() int {
 	return g.native.buttonCount()
 }
 
+func (g *Gamepad) maxButtonCount() int {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if m, ok := g.native.(maxButtonCounter); ok {
+		return m.maxButtonCount()
+	}
+	return ButtonCount
+}
+
 // HatCount is concurrent-safe.
 func (g *Gamepad) HatCount() int {
 	g.m.Lock()
@@ -417,3 +513,90 @@ func (g *Gamepad) Vibrate(duration time.Duration, strongMagnitude float64, weakM
 
 	g.native.vibrate(duration, strongMagnitude, weakMagnitude)
 }
+
+// IsForceFeedbackSupported is concurrent-safe.
+func (g *Gamepad) IsForceFeedbackSupported(typ ForceFeedbackEffectType) bool {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	f, ok := g.native.(forceFeedback)
+	if !ok {
+		return false
+	}
+	return f.isForceFeedbackSupported(typ)
+}
+
+// PlayForceFeedback is concurrent-safe.
+func (g *Gamepad) PlayForceFeedback(effect *ForceFeedbackEffect) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	f, ok := g.native.(forceFeedback)
+	if !ok {
+		return
+	}
+	if !f.isForceFeedbackSupported(effect.Type) {
+		return
+	}
+	f.playForceFeedback(effect)
+}
+
+// StopForceFeedback is concurrent-safe.
+func (g *Gamepad) StopForceFeedback() {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	f, ok := g.native.(forceFeedback)
+	if !ok {
+		return
+	}
+	f.stopForceFeedback()
+}
+
+// HasLightBar is concurrent-safe.
+func (g *Gamepad) HasLightBar() bool {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	l, ok := g.native.(lightBar)
+	if !ok {
+		return false
+	}
+	return l.hasLightBar()
+}
+
+// SetLightBarColor is concurrent-safe.
+func (g *Gamepad) SetLightBarColor(r, gr, b uint8) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	l, ok := g.native.(lightBar)
+	if !ok {
+		return
+	}
+	l.setLightBarColor(r, gr, b)
+}
+
+// Motion is concurrent-safe.
+func (g *Gamepad) Motion() (acceleration, angularVelocity [3]float64, ok bool) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	m, ok := g.native.(motionSensor)
+	if !ok {
+		return
+	}
+	return m.motion()
+}
+
+// AppendTouchpadTouches is concurrent-safe.
+func (g *Gamepad) AppendTouchpadTouches(touches []TouchpadTouch) []TouchpadTouch {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	t, ok := g.native.(touchpad)
+	if !ok {
+		return touches
+	}
+	return t.appendTouchpadTouches(touches)
+}
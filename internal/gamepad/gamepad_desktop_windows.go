@@ -17,6 +17,7 @@ package gamepad
 import (
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"sync/atomic"
@@ -51,38 +52,14 @@ var dinputObjectDataFormats = []_DIOBJECTDATAFORMAT{
 	{&_GUID_POV, _DIJOFS_POV(1), _DIDFT_POV | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
 	{&_GUID_POV, _DIJOFS_POV(2), _DIDFT_POV | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
 	{&_GUID_POV, _DIJOFS_POV(3), _DIDFT_POV | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(0), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(1), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(2), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(3), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(4), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(5), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(6), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(7), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(8), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(9), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(10), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(11), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(12), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(13), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(14), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(15), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(16), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(17), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(18), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(19), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(20), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(21), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(22), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(23), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(24), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(25), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(26), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(27), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(28), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(29), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(30), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
-	{nil, _DIJOFS_BUTTON(31), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0},
+}
+
+func init() {
+	// Joysticks like HOTAS can have many more buttons than usual gamepads. Accept as many buttons as DIJOYSTATE2 can hold.
+	for i := 0; i < len(_DIJOYSTATE2{}.rgbButtons); i++ {
+		dinputObjectDataFormats = append(dinputObjectDataFormats,
+			_DIOBJECTDATAFORMAT{nil, _DIJOFS_BUTTON(i), _DIDFT_BUTTON | _DIDFT_OPTIONAL | _DIDFT_ANYINSTANCE, 0})
+	}
 }
 
 var xinputButtons = []uint16{
@@ -106,6 +83,7 @@ type nativeGamepadsDesktop struct {
 	procDirectInput8Create    uintptr
 	procXInputGetCapabilities uintptr
 	procXInputGetState        uintptr
+	procXInputSetState        uintptr
 
 	origWndProc         uintptr
 	wndProcCallback     uintptr
@@ -169,6 +147,13 @@ func (g *nativeGamepadsDesktop) init(gamepads *gamepads) error {
 				}
 				g.procXInputGetState = p
 			}
+			{
+				p, err := windows.GetProcAddress(h, "XInputSetState")
+				if err != nil {
+					return err
+				}
+				g.procXInputSetState = p
+			}
 			break
 		}
 	}
@@ -224,6 +209,16 @@ func (g *nativeGamepadsDesktop) xinputGetState(dwUserIndex uint32, pState *_XINP
 	return nil
 }
 
+func (g *nativeGamepadsDesktop) xinputSetState(dwUserIndex uint32, pVibration *_XINPUT_VIBRATION) error {
+	// XInputSetState doesn't call SetLastError and returns an error code directly.
+	r, _, _ := syscall.Syscall(g.procXInputSetState, 2,
+		uintptr(dwUserIndex), uintptr(unsafe.Pointer(pVibration)), 0)
+	if e := syscall.Errno(uint32(r)); e != windows.ERROR_SUCCESS {
+		return fmt.Errorf("gamepad: XInputSetState failed: %w", e)
+	}
+	return nil
+}
+
 func (g *nativeGamepadsDesktop) detectConnection(gamepads *gamepads) error {
 	if g.dinput8 != 0 {
 		if g.enumDevicesCallback == 0 {
@@ -281,6 +276,7 @@ func (g *nativeGamepadsDesktop) detectConnection(gamepads *gamepads) error {
 			gp := gamepads.add(name, sdlID)
 			gp.native = &nativeGamepadDesktop{
 				xinputIndex: i,
+				gamepads:    g,
 			}
 		}
 	}
@@ -319,7 +315,7 @@ func (g *nativeGamepadsDesktop) dinput8EnumDevicesCallback(lpddi *_DIDEVICEINSTA
 		dwSize:     uint32(unsafe.Sizeof(_DIDATAFORMAT{})),
 		dwObjSize:  uint32(unsafe.Sizeof(_DIOBJECTDATAFORMAT{})),
 		dwFlags:    _DIDFT_ABSAXIS,
-		dwDataSize: uint32(unsafe.Sizeof(_DIJOYSTATE{})),
+		dwDataSize: uint32(unsafe.Sizeof(_DIJOYSTATE2{})),
 		dwNumObjs:  uint32(len(dinputObjectDataFormats)),
 		rgodf:      &dinputObjectDataFormats[0],
 	}
@@ -505,12 +501,18 @@ func (g *nativeGamepadsDesktop) dinputDevice8EnumObjectsCallback(lpddoi *_DIDEVI
 			index:      index,
 		})
 	case _DIDFT_GETTYPE(lpddoi.dwType)&_DIDFT_BUTTON != 0:
+		if ctx.buttonCount >= len(_DIJOYSTATE2{}.rgbButtons) {
+			return _DIENUM_CONTINUE
+		}
 		ctx.objects = append(ctx.objects, dinputObject{
 			objectType: dinputObjectTypeButton,
 			index:      ctx.buttonCount,
 		})
 		ctx.buttonCount++
 	case _DIDFT_GETTYPE(lpddoi.dwType)&_DIDFT_POV != 0:
+		if ctx.povCount >= len(_DIJOYSTATE2{}.rgdwPOV) {
+			return _DIENUM_CONTINUE
+		}
 		ctx.objects = append(ctx.objects, dinputObject{
 			objectType: dinputObjectTypePOV,
 			index:      ctx.povCount,
@@ -569,6 +571,12 @@ type nativeGamepadDesktop struct {
 
 	xinputIndex int
 	xinputState _XINPUT_STATE
+
+	// gamepads is used to call XInput functions out of update.
+	gamepads *nativeGamepadsDesktop
+
+	vib    bool
+	vibEnd time.Time
 }
 
 func (*nativeGamepadDesktop) hasOwnStandardLayoutMapping() bool {
@@ -608,7 +616,7 @@ func (g *nativeGamepadDesktop) update(gamepads *gamepads) (err error) {
 			}
 		}
 
-		var state _DIJOYSTATE
+		var state _DIJOYSTATE2
 		if err := g.dinputDevice.GetDeviceState(uint32(unsafe.Sizeof(state)), unsafe.Pointer(&state)); err != nil {
 			if !errors.Is(err, handleError(_DIERR_NOTACQUIRED)) && !errors.Is(err, handleError(_DIERR_INPUTLOST)) {
 				return err
@@ -695,6 +703,11 @@ func (g *nativeGamepadDesktop) update(gamepads *gamepads) (err error) {
 		return nil
 	}
 	g.xinputState = state
+
+	if g.vib && time.Now().Sub(g.vibEnd) >= 0 {
+		g.setXInputVibration(0, 0)
+		g.vib = false
+	}
 	return nil
 }
 
@@ -712,6 +725,15 @@ func (g *nativeGamepadDesktop) buttonCount() int {
 	return len(xinputButtons)
 }
 
+// maxButtonCount implements maxButtonCounter.
+// DirectInput can report up to 128 buttons for devices like HOTAS.
+func (g *nativeGamepadDesktop) maxButtonCount() int {
+	if g.usesDInput() {
+		return len(_DIJOYSTATE2{}.rgbButtons)
+	}
+	return ButtonCount
+}
+
 func (g *nativeGamepadDesktop) hatCount() int {
 	if g.usesDInput() {
 		return len(g.dinputHats)
@@ -808,5 +830,30 @@ func (g *nativeGamepadDesktop) hatState(hat int) int {
 }
 
 func (g *nativeGamepadDesktop) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
-	// TODO: Implement this (#1452)
+	// TODO: Implement this for DirectInput devices with force feedback effects (#1452)
+	if g.usesDInput() {
+		return
+	}
+
+	if strongMagnitude <= 0 && weakMagnitude <= 0 {
+		g.vib = false
+		g.setXInputVibration(0, 0)
+		return
+	}
+	g.vib = true
+	g.vibEnd = time.Now().Add(duration)
+	g.setXInputVibration(strongMagnitude, weakMagnitude)
+}
+
+func (g *nativeGamepadDesktop) setXInputVibration(strongMagnitude float64, weakMagnitude float64) {
+	if g.gamepads == nil || g.gamepads.procXInputSetState == 0 {
+		return
+	}
+	// The left motor is the low-frequency rumble motor, and the right motor is the high-frequency rumble motor.
+	v := _XINPUT_VIBRATION{
+		wLeftMotorSpeed:  uint16(math.Min(math.Max(strongMagnitude, 0), 1) * 0xffff),
+		wRightMotorSpeed: uint16(math.Min(math.Max(weakMagnitude, 0), 1) * 0xffff),
+	}
+	// An error can happen when the gamepad is just disconnected. Ignore the error.
+	_ = g.gamepads.xinputSetState(uint32(g.xinputIndex), &v)
 }
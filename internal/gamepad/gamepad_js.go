@@ -16,6 +16,7 @@ package gamepad
 
 import (
 	"encoding/hex"
+	"sync"
 	"syscall/js"
 	"time"
 
@@ -28,6 +29,11 @@ var (
 
 type nativeGamepadsImpl struct {
 	indices map[int]struct{}
+
+	hidDevices      []*hidDevice
+	onHIDConnect    js.Func
+	onHIDDisconnect js.Func
+	hidM            sync.Mutex
 }
 
 func newNativeGamepadsImpl() nativeGamepads {
@@ -35,6 +41,7 @@ func newNativeGamepadsImpl() nativeGamepads {
 }
 
 func (g *nativeGamepadsImpl) init(gamepads *gamepads) error {
+	g.initHID()
 	return nil
 }
 
@@ -47,6 +54,8 @@ func (g *nativeGamepadsImpl) update(gamepads *gamepads) error {
 		}
 	}()
 
+	g.updateHID(gamepads)
+
 	nav := js.Global().Get("navigator")
 	if !nav.Truthy() {
 		return nil
@@ -71,6 +80,11 @@ func (g *nativeGamepadsImpl) update(gamepads *gamepads) error {
 		}
 		index := gp.Get("index").Int()
 
+		// Prefer WebHID as the Gamepad API might map the device badly.
+		if g.isHIDDeviceGamepad(gp.Get("id").String()) {
+			continue
+		}
+
 		if g.indices == nil {
 			g.indices = map[int]struct{}{}
 		}
@@ -78,7 +92,8 @@ func (g *nativeGamepadsImpl) update(gamepads *gamepads) error {
 
 		// The gamepad is not registered yet, register this.
 		gamepad := gamepads.find(func(gamepad *Gamepad) bool {
-			return index == gamepad.native.(*nativeGamepadImpl).index
+			n, ok := gamepad.native.(*nativeGamepadImpl)
+			return ok && index == n.index
 		})
 		if gamepad == nil {
 			name := gp.Get("id").String()
@@ -99,7 +114,11 @@ func (g *nativeGamepadsImpl) update(gamepads *gamepads) error {
 
 	// Remove an unused gamepads.
 	gamepads.remove(func(gamepad *Gamepad) bool {
-		_, ok := g.indices[gamepad.native.(*nativeGamepadImpl).index]
+		n, ok := gamepad.native.(*nativeGamepadImpl)
+		if !ok {
+			return false
+		}
+		_, ok = g.indices[n.index]
 		return !ok
 	})
 
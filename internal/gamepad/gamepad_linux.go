@@ -12,12 +12,13 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !android && !nintendosdk && !playstation5
+//go:build !android && !nintendosdk && !playstation5 && !ebitenginecustomplatform
 
 package gamepad
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -102,7 +103,13 @@ func (*nativeGamepadsImpl) openGamepad(gamepads *gamepads, path string) (err err
 		return nil
 	}
 
-	fd, err := unix.Open(path, unix.O_RDONLY|unix.O_NONBLOCK, 0)
+	// Try to open the device with the write permission for force feedback first.
+	writable := true
+	fd, err := unix.Open(path, unix.O_RDWR|unix.O_NONBLOCK, 0)
+	if err == unix.EACCES || err == unix.EPERM {
+		writable = false
+		fd, err = unix.Open(path, unix.O_RDONLY|unix.O_NONBLOCK, 0)
+	}
 	if err != nil {
 		if err == unix.EACCES {
 			return nil
@@ -148,6 +155,28 @@ func (*nativeGamepadsImpl) openGamepad(gamepads *gamepads, path string) (err err
 		return nil
 	}
 
+	// Motion sensors and touchpads of a gamepad, e.g. a DualSense, are exposed as separate devices.
+	// These are not gamepads. See sensor_linux.go.
+	if isSensorDevice(fd) {
+		if err := unix.Close(fd); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	var ffRumble bool
+	var ffEffects [3]bool
+	if writable && isBitSet(evBits, unix.EV_FF) {
+		ffBits := make([]byte, (_FF_CNT+7)/8)
+		if err := ioctl(fd, _EVIOCGBIT(unix.EV_FF, uint(len(ffBits))), unsafe.Pointer(&ffBits[0])); err == nil {
+			ffRumble = isBitSet(ffBits, _FF_RUMBLE)
+			ffEffects[ForceFeedbackEffectTypeConstant] = isBitSet(ffBits, _FF_CONSTANT)
+			ffEffects[ForceFeedbackEffectTypeSpring] = isBitSet(ffBits, _FF_SPRING)
+			ffEffects[ForceFeedbackEffectTypeDamper] = isBitSet(ffBits, _FF_DAMPER)
+		}
+	}
+
 	cname := make([]byte, 256)
 	name := "Unknown"
 	// TODO: Is it OK to ignore the error here?
@@ -173,8 +202,13 @@ func (*nativeGamepadsImpl) openGamepad(gamepads *gamepads, path string) (err err
 	}
 
 	n := &nativeGamepadImpl{
-		path: path,
-		fd:   fd,
+		path:             path,
+		fd:               fd,
+		uniq:             deviceUniq(fd),
+		ffRumble:         ffRumble,
+		ffEffectID:       -1,
+		ffEffects:        ffEffects,
+		ffForceEffectIDs: [3]int16{-1, -1, -1},
 	}
 	gp := gamepads.add(name, sdlID)
 	gp.native = n
@@ -290,6 +324,8 @@ type nativeGamepadImpl struct {
 	absInfo [_ABS_CNT]input_absinfo
 	dropped bool
 
+	eventBuf inputEventBuffer
+
 	axes    [_ABS_CNT]float64
 	buttons [_KEY_CNT - _BTN_MISC]bool
 	hats    [4]int
@@ -300,6 +336,31 @@ type nativeGamepadImpl struct {
 
 	stdAxisMap   map[gamepaddb.StandardAxis]mappingInput
 	stdButtonMap map[gamepaddb.StandardButton]mappingInput
+
+	// ffRumble reports whether the device supports a rumble effect.
+	ffRumble bool
+
+	// ffEffectID is the ID of the uploaded rumble effect. ffEffectID is -1 if no effect is uploaded.
+	ffEffectID int16
+
+	// ffEffects reports whether the device supports each force feedback effect type.
+	ffEffects [3]bool
+
+	// ffForceEffectIDs are the IDs of the uploaded force feedback effects for each type. An ID is -1 if no effect is uploaded.
+	ffForceEffectIDs [3]int16
+
+	// lightBar is the LEDs of the light bar. lightBar is found lazily when requested.
+	lightBar         *lightBarDevice
+	lightBarSearched bool
+
+	// uniq is the unique identifier of the device, e.g. a MAC address. uniq might be empty.
+	uniq string
+
+	// motionSensor and touchpad are the devices belonging to the gamepad.
+	// These are opened lazily when their values are requested.
+	motionSensor      *sensorDevice
+	touchpad          *sensorDevice
+	sensorsSearchedAt time.Time
 }
 
 func (g *nativeGamepadImpl) close() {
@@ -307,6 +368,15 @@ func (g *nativeGamepadImpl) close() {
 		_ = unix.Close(g.fd)
 	}
 	g.fd = 0
+
+	if g.motionSensor != nil {
+		g.motionSensor.close()
+		g.motionSensor = nil
+	}
+	if g.touchpad != nil {
+		g.touchpad.close()
+		g.touchpad = nil
+	}
 }
 
 func (g *nativeGamepadImpl) update(gamepad *gamepads) error {
@@ -314,10 +384,26 @@ func (g *nativeGamepadImpl) update(gamepad *gamepads) error {
 		return nil
 	}
 
+	if g.motionSensor != nil {
+		if err := g.motionSensor.update(); err != nil {
+			return err
+		}
+		if g.motionSensor.fd == 0 {
+			g.motionSensor = nil
+		}
+	}
+	if g.touchpad != nil {
+		if err := g.touchpad.update(); err != nil {
+			return err
+		}
+		if g.touchpad.fd == 0 {
+			g.touchpad = nil
+		}
+	}
+
 	for {
-		buf := make([]byte, unsafe.Sizeof(input_event{}))
-		// TODO: Should the returned byte count be cared?
-		if _, err := unix.Read(g.fd, buf); err != nil {
+		e, err := readInputEvent(g.fd, &g.eventBuf)
+		if err != nil {
 			if err == unix.EAGAIN {
 				break
 			}
@@ -329,18 +415,6 @@ func (g *nativeGamepadImpl) update(gamepad *gamepads) error {
 			return fmt.Errorf("gamepad: Read failed: %w", err)
 		}
 
-		const (
-			offsetTyp   = unsafe.Offsetof(input_event{}.typ)
-			offsetCode  = unsafe.Offsetof(input_event{}.code)
-			offsetValue = unsafe.Offsetof(input_event{}.value)
-		)
-		// time is not used.
-		e := input_event{
-			typ:   uint16(buf[offsetTyp]) | uint16(buf[offsetTyp+1])<<8,
-			code:  uint16(buf[offsetCode]) | uint16(buf[offsetCode+1])<<8,
-			value: int32(buf[offsetValue]) | int32(buf[offsetValue+1])<<8 | int32(buf[offsetValue+2])<<16 | int32(buf[offsetValue+3])<<24,
-		}
-
 		if e.typ == unix.EV_SYN {
 			switch e.code {
 			case _SYN_DROPPED:
@@ -372,6 +446,28 @@ func (g *nativeGamepadImpl) update(gamepad *gamepads) error {
 	return nil
 }
 
+// inputEventBuffer is a buffer to read an input_event.
+type inputEventBuffer [unsafe.Sizeof(input_event{})]byte
+
+func readInputEvent(fd int, buf *inputEventBuffer) (input_event, error) {
+	// TODO: Should the returned byte count be cared?
+	if _, err := unix.Read(fd, buf[:]); err != nil {
+		return input_event{}, err
+	}
+
+	const (
+		offsetTyp   = unsafe.Offsetof(input_event{}.typ)
+		offsetCode  = unsafe.Offsetof(input_event{}.code)
+		offsetValue = unsafe.Offsetof(input_event{}.value)
+	)
+	// time is not used.
+	return input_event{
+		typ:   uint16(buf[offsetTyp]) | uint16(buf[offsetTyp+1])<<8,
+		code:  uint16(buf[offsetCode]) | uint16(buf[offsetCode+1])<<8,
+		value: int32(buf[offsetValue]) | int32(buf[offsetValue+1])<<8 | int32(buf[offsetValue+2])<<16 | int32(buf[offsetValue+3])<<24,
+	}, nil
+}
+
 func (g *nativeGamepadImpl) pollAbsState() error {
 	for code := 0; code < _ABS_CNT; code++ {
 		if g.absMap[code] < 0 {
@@ -618,5 +714,153 @@ func (g *nativeGamepadImpl) hatState(hat int) int {
 }
 
 func (g *nativeGamepadImpl) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
-	// TODO: Implement this (#1452)
+	if g.fd == 0 || !g.ffRumble {
+		return
+	}
+
+	if strongMagnitude <= 0 && weakMagnitude <= 0 {
+		if g.ffEffectID >= 0 {
+			g.writeFFEvent(g.ffEffectID, 0)
+		}
+		return
+	}
+
+	// The length is in milliseconds and represented as uint16.
+	length := duration / time.Millisecond
+	if length > math.MaxUint16 {
+		length = math.MaxUint16
+	}
+	e := ff_effect{
+		typ: _FF_RUMBLE,
+		id:  g.ffEffectID,
+		replay: ff_replay{
+			length: uint16(length),
+		},
+	}
+	r := (*ff_rumble_effect)(unsafe.Pointer(&e.u[0]))
+	r.strong_magnitude = uint16(math.Min(math.Max(strongMagnitude, 0), 1) * math.MaxUint16)
+	r.weak_magnitude = uint16(math.Min(math.Max(weakMagnitude, 0), 1) * math.MaxUint16)
+
+	// Upload the effect. If an effect is already uploaded, the effect is updated.
+	// The kernel assigns an ID to a new effect.
+	if err := ioctl(g.fd, _EVIOCSFF(), unsafe.Pointer(&e)); err != nil || e.id < 0 {
+		return
+	}
+	g.ffEffectID = e.id
+	g.writeFFEvent(g.ffEffectID, 1)
+}
+
+func (g *nativeGamepadImpl) isForceFeedbackSupported(typ ForceFeedbackEffectType) bool {
+	if g.fd == 0 {
+		return false
+	}
+	if typ < 0 || int(typ) >= len(g.ffEffects) {
+		return false
+	}
+	return g.ffEffects[typ]
+}
+
+func (g *nativeGamepadImpl) playForceFeedback(effect *ForceFeedbackEffect) {
+	// The length is in milliseconds and represented as uint16. 0 means an infinite length.
+	length := effect.Duration / time.Millisecond
+	if length < 0 {
+		length = 0
+	}
+	if length > math.MaxUint16 {
+		length = math.MaxUint16
+	}
+	// A positive duration shorter than 1ms must not be an infinite length.
+	if length == 0 && effect.Duration > 0 {
+		length = 1
+	}
+	e := ff_effect{
+		id: g.ffForceEffectIDs[effect.Type],
+		// The direction 0x4000 means the force along the X axis, which is the wheel's axis.
+		direction: 0x4000,
+		replay: ff_replay{
+			length: uint16(length),
+		},
+	}
+	switch effect.Type {
+	case ForceFeedbackEffectTypeConstant:
+		e.typ = _FF_CONSTANT
+		c := (*ff_constant_effect)(unsafe.Pointer(&e.u[0]))
+		c.level = int16(math.Min(math.Max(effect.Magnitude, -1), 1) * math.MaxInt16)
+	case ForceFeedbackEffectTypeSpring, ForceFeedbackEffectTypeDamper:
+		if effect.Type == ForceFeedbackEffectTypeSpring {
+			e.typ = _FF_SPRING
+		} else {
+			e.typ = _FF_DAMPER
+		}
+		coeff := int16(math.Min(math.Max(effect.Coefficient, 0), 1) * math.MaxInt16)
+		cs := (*[2]ff_condition_effect)(unsafe.Pointer(&e.u[0]))
+		for i := range cs {
+			cs[i] = ff_condition_effect{
+				right_saturation: math.MaxUint16,
+				left_saturation:  math.MaxUint16,
+				right_coeff:      coeff,
+				left_coeff:       coeff,
+				deadband:         uint16(math.Min(math.Max(effect.Deadband, 0), 1) * math.MaxUint16),
+				center:           int16(math.Min(math.Max(effect.Center, -1), 1) * math.MaxInt16),
+			}
+		}
+	default:
+		return
+	}
+
+	// Upload the effect. If an effect is already uploaded, the effect is updated.
+	if err := ioctl(g.fd, _EVIOCSFF(), unsafe.Pointer(&e)); err != nil || e.id < 0 {
+		return
+	}
+	g.ffForceEffectIDs[effect.Type] = e.id
+	g.writeFFEvent(e.id, 1)
+}
+
+func (g *nativeGamepadImpl) stopForceFeedback() {
+	if g.fd == 0 {
+		return
+	}
+	for _, id := range g.ffForceEffectIDs {
+		if id < 0 {
+			continue
+		}
+		g.writeFFEvent(id, 0)
+	}
+}
+
+func (g *nativeGamepadImpl) lightBarDevice() *lightBarDevice {
+	if !g.lightBarSearched {
+		g.lightBar = findLightBarDevice(g.path)
+		g.lightBarSearched = true
+	}
+	return g.lightBar
+}
+
+func (g *nativeGamepadImpl) hasLightBar() bool {
+	if g.fd == 0 {
+		return false
+	}
+	return g.lightBarDevice() != nil
+}
+
+func (g *nativeGamepadImpl) setLightBarColor(r, gr, b uint8) {
+	if g.fd == 0 {
+		return
+	}
+	l := g.lightBarDevice()
+	if l == nil {
+		return
+	}
+	// An error can happen when the gamepad is just disconnected. Ignore the error.
+	_ = l.setColor(r, gr, b)
+}
+
+func (g *nativeGamepadImpl) writeFFEvent(id int16, value int32) {
+	e := input_event{
+		typ:   unix.EV_FF,
+		code:  uint16(id),
+		value: value,
+	}
+	// An error can happen when the gamepad is just disconnected. Ignore the error.
+	_, _ = unix.Write(g.fd, unsafe.Slice((*byte)(unsafe.Pointer(&e)), unsafe.Sizeof(e)))
 }
@@ -0,0 +1,563 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepaddb"
+)
+
+// The HID usage pages and usages.
+// See https://usb.org/document-library/hid-usage-tables-14.
+const (
+	hidUsagePageGenericDesktop = 0x01
+	hidUsagePageSimulation     = 0x02
+	hidUsagePageButton         = 0x09
+
+	hidUsageJoystick              = 0x04
+	hidUsageGamepad               = 0x05
+	hidUsageMultiAxisController   = 0x08
+	hidUsageX                     = 0x30
+	hidUsageWheel                 = 0x38
+	hidUsageHatSwitch             = 0x39
+	hidUsageSimulationRudder      = 0xba
+	hidUsageSimulationThrottle    = 0xbb
+	hidUsageSimulationAccelerator = 0xc4
+	hidUsageSimulationBrake       = 0xc5
+	hidUsageSimulationSteering    = 0xc8
+)
+
+type hidFieldType int
+
+const (
+	hidFieldTypeAxis hidFieldType = iota
+	hidFieldTypeButton
+	hidFieldTypeHat
+)
+
+// hidField represents a value in an input report.
+type hidField struct {
+	typ       hidFieldType
+	usage     uint32
+	index     int
+	bitOffset int
+	bitSize   int
+	minimum   int
+	maximum   int
+}
+
+func (f *hidField) value(data []byte) int {
+	var v uint32
+	for i := 0; i < f.bitSize && i < 32; i++ {
+		bit := f.bitOffset + i
+		if bit/8 >= len(data) {
+			break
+		}
+		if data[bit/8]&(1<<(bit%8)) != 0 {
+			v |= 1 << i
+		}
+	}
+	// Extend the sign when the logical range includes negative values.
+	if f.minimum < 0 && f.bitSize > 0 && f.bitSize < 32 && v&(1<<(f.bitSize-1)) != 0 {
+		return int(v) - (1 << f.bitSize)
+	}
+	return int(int32(v))
+}
+
+func hidFieldTypeFromUsage(usage uint32) (hidFieldType, bool) {
+	page, id := usage>>16, usage&0xffff
+	switch page {
+	case hidUsagePageGenericDesktop:
+		if id >= hidUsageX && id <= hidUsageWheel {
+			return hidFieldTypeAxis, true
+		}
+		if id == hidUsageHatSwitch {
+			return hidFieldTypeHat, true
+		}
+	case hidUsagePageSimulation:
+		switch id {
+		case hidUsageSimulationRudder, hidUsageSimulationThrottle, hidUsageSimulationAccelerator, hidUsageSimulationBrake, hidUsageSimulationSteering:
+			return hidFieldTypeAxis, true
+		}
+	case hidUsagePageButton:
+		return hidFieldTypeButton, true
+	}
+	return 0, false
+}
+
+// hidDevice is a gamepad accessed via WebHID.
+type hidDevice struct {
+	device    js.Value
+	name      string
+	sdlID     string
+	vendorID  int
+	productID int
+
+	// fields is a map from a report ID to the fields in the report.
+	fields map[int][]hidField
+
+	axes    []float64
+	buttons []bool
+	hats    []int
+
+	onInputReport js.Func
+	closed        bool
+
+	m sync.Mutex
+}
+
+func newHIDDevice(device js.Value) *hidDevice {
+	vendorID := device.Get("vendorId").Int()
+	productID := device.Get("productId").Int()
+	d := &hidDevice{
+		device:    device,
+		name:      device.Get("productName").String(),
+		vendorID:  vendorID,
+		productID: productID,
+		sdlID: fmt.Sprintf("03000000%02x%02x0000%02x%02x000000000000",
+			byte(vendorID), byte(vendorID>>8),
+			byte(productID), byte(productID>>8)),
+		fields: map[int][]hidField{},
+	}
+
+	var fields []*hidField
+
+	// bitOffsets is a map from a report ID to the current bit offset in the report.
+	// The items of one report can be split into multiple collections, e.g. buttons in one collection and axes in
+	// its child collection. The items are packed in one report in the order of the collections, so the offset
+	// must be carried over the collections instead of being reset for each collection.
+	bitOffsets := map[int]int{}
+
+	var appendCollection func(collection js.Value)
+	appendCollection = func(collection js.Value) {
+		reports := collection.Get("inputReports")
+		for i := 0; i < reports.Length(); i++ {
+			report := reports.Index(i)
+			id := report.Get("reportId").Int()
+			items := report.Get("items")
+			bitOffset := bitOffsets[id]
+			for j := 0; j < items.Length(); j++ {
+				item := items.Index(j)
+				size := item.Get("reportSize").Int()
+				count := item.Get("reportCount").Int()
+				usages := item.Get("usages")
+				if !item.Get("isConstant").Bool() && !item.Get("isArray").Bool() {
+					for k := 0; k < count; k++ {
+						var usage uint32
+						if item.Get("isRange").Bool() {
+							usage = uint32(item.Get("usageMinimum").Int() + k)
+						} else if l := usages.Length(); l > 0 {
+							if k < l {
+								usage = uint32(usages.Index(k).Int())
+							} else {
+								usage = uint32(usages.Index(l - 1).Int())
+							}
+						}
+						typ, ok := hidFieldTypeFromUsage(usage)
+						if !ok {
+							continue
+						}
+						d.fields[id] = append(d.fields[id], hidField{
+							typ:       typ,
+							usage:     usage,
+							bitOffset: bitOffset + k*size,
+							bitSize:   size,
+							minimum:   item.Get("logicalMinimum").Int(),
+							maximum:   item.Get("logicalMaximum").Int(),
+						})
+					}
+				}
+				bitOffset += size * count
+			}
+			bitOffsets[id] = bitOffset
+		}
+		children := collection.Get("children")
+		for i := 0; i < children.Length(); i++ {
+			appendCollection(children.Index(i))
+		}
+	}
+	collections := device.Get("collections")
+	for i := 0; i < collections.Length(); i++ {
+		appendCollection(collections.Index(i))
+	}
+
+	// Order the values by the usages, like the other platforms' HID implementations.
+	for id := range d.fields {
+		for i := range d.fields[id] {
+			fields = append(fields, &d.fields[id][i])
+		}
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].usage < fields[j].usage
+	})
+	for _, f := range fields {
+		switch f.typ {
+		case hidFieldTypeAxis:
+			f.index = len(d.axes)
+			d.axes = append(d.axes, 0)
+		case hidFieldTypeButton:
+			f.index = len(d.buttons)
+			d.buttons = append(d.buttons, false)
+		case hidFieldTypeHat:
+			f.index = len(d.hats)
+			d.hats = append(d.hats, hatCentered)
+		}
+	}
+
+	d.onInputReport = js.FuncOf(func(this js.Value, args []js.Value) any {
+		d.handleInputReport(args[0])
+		return nil
+	})
+	device.Call("addEventListener", "inputreport", d.onInputReport)
+
+	return d
+}
+
+func (d *hidDevice) handleInputReport(e js.Value) {
+	fields, ok := d.fields[e.Get("reportId").Int()]
+	if !ok {
+		return
+	}
+
+	dataView := e.Get("data")
+	data := make([]byte, dataView.Get("byteLength").Int())
+	js.CopyBytesToGo(data, js.Global().Get("Uint8Array").New(dataView.Get("buffer"), dataView.Get("byteOffset"), dataView.Get("byteLength")))
+
+	hatStates := []int{
+		hatUp,
+		hatRightUp,
+		hatRight,
+		hatRightDown,
+		hatDown,
+		hatLeftDown,
+		hatLeft,
+		hatLeftUp,
+	}
+
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	for _, f := range fields {
+		v := f.value(data)
+		switch f.typ {
+		case hidFieldTypeAxis:
+			if f.maximum > f.minimum {
+				d.axes[f.index] = float64(v-f.minimum)/float64(f.maximum-f.minimum)*2 - 1
+			}
+		case hidFieldTypeButton:
+			d.buttons[f.index] = v-f.minimum > 0
+		case hidFieldTypeHat:
+			if state := v - f.minimum; state < 0 || state >= len(hatStates) {
+				d.hats[f.index] = hatCentered
+			} else {
+				d.hats[f.index] = hatStates[state]
+			}
+		}
+	}
+}
+
+func (d *hidDevice) close() {
+	if d.closed {
+		return
+	}
+	d.device.Call("removeEventListener", "inputreport", d.onInputReport)
+	d.onInputReport.Release()
+	d.closed = true
+}
+
+func isHIDGamepadAvailable() bool {
+	nav := js.Global().Get("navigator")
+	return nav.Truthy() && nav.Get("hid").Truthy()
+}
+
+func hidDeviceFilters() js.Value {
+	filters := js.Global().Get("Array").New()
+	for _, usage := range []int{hidUsageJoystick, hidUsageGamepad, hidUsageMultiAxisController} {
+		f := object.New()
+		f.Set("usagePage", hidUsagePageGenericDesktop)
+		f.Set("usage", usage)
+		filters.Call("push", f)
+	}
+	return filters
+}
+
+// initHID opens the HID devices the user already permitted to access, and starts listening connections.
+func (g *nativeGamepadsImpl) initHID() {
+	if !isHIDGamepadAvailable() {
+		return
+	}
+	hid := js.Global().Get("navigator").Get("hid")
+
+	// The connection listeners are never removed as the gamepads live as long as the application.
+	g.onHIDConnect = js.FuncOf(func(this js.Value, args []js.Value) any {
+		g.openHIDDevice(args[0].Get("device"))
+		return nil
+	})
+	g.onHIDDisconnect = js.FuncOf(func(this js.Value, args []js.Value) any {
+		g.closeHIDDevice(args[0].Get("device"))
+		return nil
+	})
+	hid.Call("addEventListener", "connect", g.onHIDConnect)
+	hid.Call("addEventListener", "disconnect", g.onHIDDisconnect)
+
+	awaitPromise(hid.Call("getDevices"), func(devices js.Value) {
+		for i := 0; i < devices.Length(); i++ {
+			g.openHIDDevice(devices.Index(i))
+		}
+	}, warnPromiseRejection)
+}
+
+// awaitPromise calls onFulfilled or onRejected when the promise is settled.
+// The callbacks for the promise are released after either is called.
+func awaitPromise(promise js.Value, onFulfilled func(value js.Value), onRejected func(reason js.Value)) {
+	var then, catch js.Func
+	then = js.FuncOf(func(this js.Value, args []js.Value) any {
+		then.Release()
+		catch.Release()
+		onFulfilled(args[0])
+		return nil
+	})
+	catch = js.FuncOf(func(this js.Value, args []js.Value) any {
+		then.Release()
+		catch.Release()
+		onRejected(args[0])
+		return nil
+	})
+	promise.Call("then", then, catch)
+}
+
+func warnPromiseRejection(reason js.Value) {
+	js.Global().Get("console").Call("warn", reason)
+}
+
+func (g *nativeGamepadsImpl) requestHIDDevices() {
+	if !isHIDGamepadAvailable() {
+		js.Global().Get("console").Call("warn", "navigator.hid is not available. WebHID requires a secure (HTTPS) context and a supported browser.")
+		return
+	}
+
+	options := object.New()
+	options.Set("filters", hidDeviceFilters())
+	// requestDevice shows a prompt to choose devices, and requires a user activation.
+	awaitPromise(js.Global().Get("navigator").Get("hid").Call("requestDevice", options), func(devices js.Value) {
+		for i := 0; i < devices.Length(); i++ {
+			g.openHIDDevice(devices.Index(i))
+		}
+	}, warnPromiseRejection)
+}
+
+func isHIDGamepadDevice(device js.Value) bool {
+	collections := device.Get("collections")
+	for i := 0; i < collections.Length(); i++ {
+		c := collections.Index(i)
+		if c.Get("usagePage").Int() != hidUsagePageGenericDesktop {
+			continue
+		}
+		switch c.Get("usage").Int() {
+		case hidUsageJoystick, hidUsageGamepad, hidUsageMultiAxisController:
+			return true
+		}
+	}
+	return false
+}
+
+func (g *nativeGamepadsImpl) openHIDDevice(device js.Value) {
+	if !isHIDGamepadDevice(device) {
+		return
+	}
+
+	g.hidM.Lock()
+	for _, d := range g.hidDevices {
+		if d.device.Equal(device) {
+			g.hidM.Unlock()
+			return
+		}
+	}
+	g.hidM.Unlock()
+
+	onOpen := func() {
+		d := newHIDDevice(device)
+		g.hidM.Lock()
+		defer g.hidM.Unlock()
+		g.hidDevices = append(g.hidDevices, d)
+	}
+
+	if device.Get("opened").Bool() {
+		onOpen()
+		return
+	}
+	awaitPromise(device.Call("open"), func(js.Value) {
+		onOpen()
+	}, warnPromiseRejection)
+}
+
+func (g *nativeGamepadsImpl) closeHIDDevice(device js.Value) {
+	g.hidM.Lock()
+	defer g.hidM.Unlock()
+
+	for i, d := range g.hidDevices {
+		if !d.device.Equal(device) {
+			continue
+		}
+		d.close()
+		g.hidDevices = append(g.hidDevices[:i], g.hidDevices[i+1:]...)
+		return
+	}
+}
+
+// updateHID registers and unregisters the gamepads for the HID devices.
+func (g *nativeGamepadsImpl) updateHID(gamepads *gamepads) {
+	g.hidM.Lock()
+	defer g.hidM.Unlock()
+
+	for _, d := range g.hidDevices {
+		d := d
+		if gamepads.find(func(gamepad *Gamepad) bool {
+			n, ok := gamepad.native.(*nativeHIDGamepadImpl)
+			return ok && n.device == d
+		}) != nil {
+			continue
+		}
+		gamepad := gamepads.add(d.name, d.sdlID)
+		gamepad.native = &nativeHIDGamepadImpl{
+			device: d,
+		}
+	}
+
+	gamepads.remove(func(gamepad *Gamepad) bool {
+		n, ok := gamepad.native.(*nativeHIDGamepadImpl)
+		return ok && n.device.closed
+	})
+}
+
+var (
+	// Chrome's ID is like "Foo (Vendor: 046d Product: c215)".
+	chromeGamepadIDRe = regexp.MustCompile(`Vendor: ([0-9a-fA-F]{4}) Product: ([0-9a-fA-F]{4})`)
+
+	// Firefox's ID is like "046d-c215-Foo".
+	firefoxGamepadIDRe = regexp.MustCompile(`^([0-9a-fA-F]{1,4})-([0-9a-fA-F]{1,4})-`)
+)
+
+// isHIDDeviceGamepad reports whether the Gamepad API's gamepad with the given ID is also accessed via WebHID.
+func (g *nativeGamepadsImpl) isHIDDeviceGamepad(id string) bool {
+	m := chromeGamepadIDRe.FindStringSubmatch(id)
+	if m == nil {
+		m = firefoxGamepadIDRe.FindStringSubmatch(id)
+	}
+	if m == nil {
+		return false
+	}
+	vendor, err := strconv.ParseInt(m[1], 16, 32)
+	if err != nil {
+		return false
+	}
+	product, err := strconv.ParseInt(m[2], 16, 32)
+	if err != nil {
+		return false
+	}
+
+	g.hidM.Lock()
+	defer g.hidM.Unlock()
+	for _, d := range g.hidDevices {
+		if d.vendorID == int(vendor) && d.productID == int(product) {
+			return true
+		}
+	}
+	return false
+}
+
+type nativeHIDGamepadImpl struct {
+	device *hidDevice
+}
+
+func (g *nativeHIDGamepadImpl) update(gamepads *gamepads) error {
+	return nil
+}
+
+func (g *nativeHIDGamepadImpl) hasOwnStandardLayoutMapping() bool {
+	return false
+}
+
+func (g *nativeHIDGamepadImpl) standardAxisInOwnMapping(axis gamepaddb.StandardAxis) mappingInput {
+	return nil
+}
+
+func (g *nativeHIDGamepadImpl) standardButtonInOwnMapping(button gamepaddb.StandardButton) mappingInput {
+	return nil
+}
+
+func (g *nativeHIDGamepadImpl) axisCount() int {
+	return len(g.device.axes)
+}
+
+func (g *nativeHIDGamepadImpl) buttonCount() int {
+	return len(g.device.buttons)
+}
+
+func (g *nativeHIDGamepadImpl) hatCount() int {
+	return len(g.device.hats)
+}
+
+func (g *nativeHIDGamepadImpl) isAxisReady(axis int) bool {
+	return axis >= 0 && axis < g.axisCount()
+}
+
+func (g *nativeHIDGamepadImpl) axisValue(axis int) float64 {
+	g.device.m.Lock()
+	defer g.device.m.Unlock()
+
+	if axis < 0 || axis >= len(g.device.axes) {
+		return 0
+	}
+	return g.device.axes[axis]
+}
+
+func (g *nativeHIDGamepadImpl) buttonValue(button int) float64 {
+	if g.isButtonPressed(button) {
+		return 1
+	}
+	return 0
+}
+
+func (g *nativeHIDGamepadImpl) isButtonPressed(button int) bool {
+	g.device.m.Lock()
+	defer g.device.m.Unlock()
+
+	if button < 0 || button >= len(g.device.buttons) {
+		return false
+	}
+	return g.device.buttons[button]
+}
+
+func (g *nativeHIDGamepadImpl) hatState(hat int) int {
+	g.device.m.Lock()
+	defer g.device.m.Unlock()
+
+	if hat < 0 || hat >= len(g.device.hats) {
+		return hatCentered
+	}
+	return g.device.hats[hat]
+}
+
+func (g *nativeHIDGamepadImpl) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
+	// Output reports are device-specific. Do nothing.
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !android && !nintendosdk && !playstation5 && !ebitenginecustomplatform
+
+package gamepad
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Kernel drivers for gamepads with a light bar, e.g. hid-playstation and hid-sony, expose the light bar as LED class devices
+// under the HID device. hid-playstation exposes a multicolor LED named "*:rgb:indicator", and hid-sony exposes
+// single color LEDs named "*:red", "*:green" and "*:blue".
+// The brightness files are usually writable only by root unless a udev rule allows them.
+
+// lightBarDevice represents the LEDs of a light bar in sysfs.
+type lightBarDevice struct {
+	// multicolor is the directory of a multicolor LED. multicolor is empty if the LED is not multicolor.
+	multicolor string
+
+	// rgb are the directories of the single color LEDs for red, green and blue.
+	rgb [3]string
+
+	// maxBrightness is the maximum brightness of the multicolor LED, or the single color LEDs for red, green and blue.
+	// The values are read once as they don't change.
+	maxBrightness [3]int
+
+	// color is the last color set successfully. Setting the same color again is skipped to avoid sysfs I/O.
+	color      [3]uint8
+	colorValid bool
+}
+
+// findLightBarDevice finds the LEDs of the light bar for the event device at the given path.
+func findLightBarDevice(path string) *lightBarDevice {
+	// /sys/class/input/eventN/device is the input device, and its parent is the HID device.
+	dir := filepath.Join("/sys/class/input", filepath.Base(path), "device", "device", "leds")
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var d lightBarDevice
+	for _, ent := range ents {
+		name := ent.Name()
+		p := filepath.Join(dir, name)
+		switch {
+		case strings.HasSuffix(name, ":rgb:indicator"):
+			if !isWritable(filepath.Join(p, "multi_intensity")) || !isWritable(filepath.Join(p, "brightness")) {
+				continue
+			}
+			d.multicolor = p
+		case strings.HasSuffix(name, ":red"):
+			d.rgb[0] = p
+		case strings.HasSuffix(name, ":green"):
+			d.rgb[1] = p
+		case strings.HasSuffix(name, ":blue"):
+			d.rgb[2] = p
+		}
+	}
+
+	if d.multicolor != "" {
+		return &lightBarDevice{
+			multicolor:    d.multicolor,
+			maxBrightness: [3]int{maxBrightness(d.multicolor)},
+		}
+	}
+	for i, p := range d.rgb {
+		if p == "" || !isWritable(filepath.Join(p, "brightness")) {
+			return nil
+		}
+		d.maxBrightness[i] = maxBrightness(p)
+	}
+	return &d
+}
+
+func isWritable(path string) bool {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return false
+	}
+	_ = f.Close()
+	return true
+}
+
+func maxBrightness(dir string) int {
+	bs, err := os.ReadFile(filepath.Join(dir, "max_brightness"))
+	if err != nil {
+		return 255
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(string(bs)))
+	if err != nil || v <= 0 {
+		return 255
+	}
+	return v
+}
+
+func (l *lightBarDevice) setColor(r, g, b uint8) error {
+	c := [...]uint8{r, g, b}
+	if l.colorValid && l.color == c {
+		return nil
+	}
+	if err := l.writeColor(r, g, b); err != nil {
+		l.colorValid = false
+		return err
+	}
+	l.color = c
+	l.colorValid = true
+	return nil
+}
+
+func (l *lightBarDevice) writeColor(r, g, b uint8) error {
+	if l.multicolor != "" {
+		// The brightness multiplies multi_intensity. Use the maximum brightness so that multi_intensity represents the color.
+		maxValue := l.maxBrightness[0]
+		if err := os.WriteFile(filepath.Join(l.multicolor, "multi_intensity"), []byte(fmt.Sprintf("%d %d %d", int(r)*maxValue/255, int(g)*maxValue/255, int(b)*maxValue/255)), 0); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(l.multicolor, "brightness"), []byte(strconv.Itoa(maxValue)), 0); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	for i, v := range [...]uint8{r, g, b} {
+		maxValue := l.maxBrightness[i]
+		if err := os.WriteFile(filepath.Join(l.rgb[i], "brightness"), []byte(strconv.Itoa(int(v)*maxValue/255)), 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !android && !nintendosdk && !playstation5 && !ebitenginecustomplatform
+
+package gamepad
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Kernel drivers for gamepads with motion sensors or touchpads, e.g. hid-playstation for DualSense and DualShock 4
+// and hid-nintendo for Switch Pro Controller, expose the sensors as separate event devices.
+// Such a device has INPUT_PROP_ACCELEROMETER or INPUT_PROP_POINTER, and shares uniq (a MAC address) with the gamepad.
+
+const standardGravity = 9.80665
+
+const maxTouchpadSlots = 16
+
+func deviceProps(fd int) ([]byte, bool) {
+	props := make([]byte, (_INPUT_PROP_CNT+7)/8)
+	// EVIOCGPROP might not be available with old kernels.
+	if err := ioctl(fd, _EVIOCGPROP(uint(len(props))), unsafe.Pointer(&props[0])); err != nil {
+		return nil, false
+	}
+	return props, true
+}
+
+func isSensorDevice(fd int) bool {
+	props, ok := deviceProps(fd)
+	if !ok {
+		return false
+	}
+	return isBitSet(props, _INPUT_PROP_ACCELEROMETER) || isBitSet(props, _INPUT_PROP_POINTER)
+}
+
+func deviceUniq(fd int) string {
+	cuniq := make([]byte, 256)
+	if err := ioctl(fd, _EVIOCGUNIQ(uint(len(cuniq))), unsafe.Pointer(&cuniq[0])); err != nil {
+		return ""
+	}
+	return unix.ByteSliceToString(cuniq)
+}
+
+// findSensorDevices finds a motion sensor device and a touchpad device with the given uniq.
+// Only the requested devices are opened.
+func findSensorDevices(uniq string, motionSensor, touchpad bool) (*sensorDevice, *sensorDevice) {
+	ents, err := os.ReadDir(dirName)
+	if err != nil {
+		return nil, nil
+	}
+
+	var m, t *sensorDevice
+	for _, ent := range ents {
+		if (!motionSensor || m != nil) && (!touchpad || t != nil) {
+			break
+		}
+		if ent.IsDir() {
+			continue
+		}
+		if !reEvent.MatchString(ent.Name()) {
+			continue
+		}
+
+		fd, err := unix.Open(filepath.Join(dirName, ent.Name()), unix.O_RDONLY|unix.O_NONBLOCK, 0)
+		if err != nil {
+			continue
+		}
+
+		props, ok := deviceProps(fd)
+		if !ok || deviceUniq(fd) != uniq {
+			_ = unix.Close(fd)
+			continue
+		}
+
+		switch {
+		case motionSensor && m == nil && isBitSet(props, _INPUT_PROP_ACCELEROMETER):
+			if d, err := newSensorDevice(fd); err == nil {
+				m = d
+				continue
+			}
+		case touchpad && t == nil && isBitSet(props, _INPUT_PROP_POINTER):
+			if d, err := newSensorDevice(fd); err == nil {
+				t = d
+				continue
+			}
+		}
+		_ = unix.Close(fd)
+	}
+	return m, t
+}
+
+type touchpadSlot struct {
+	// id is a tracking ID. id is -1 when the slot is not used.
+	id int32
+	x  int32
+	y  int32
+}
+
+type sensorDevice struct {
+	fd      int
+	absBits []byte
+	absInfo [_ABS_CNT]input_absinfo
+	dropped bool
+
+	eventBuf inputEventBuffer
+
+	// slot and slots are used only for a touchpad.
+	slot  int
+	slots []touchpadSlot
+}
+
+func newSensorDevice(fd int) (*sensorDevice, error) {
+	d := &sensorDevice{
+		fd:      fd,
+		absBits: make([]byte, (_ABS_CNT+7)/8),
+	}
+	if err := ioctl(fd, _EVIOCGBIT(unix.EV_ABS, uint(len(d.absBits))), unsafe.Pointer(&d.absBits[0])); err != nil {
+		return nil, fmt.Errorf("gamepad: ioctl for absBits failed: %w", err)
+	}
+	if err := d.pollAbsState(); err != nil {
+		return nil, err
+	}
+
+	if isBitSet(d.absBits, _ABS_MT_SLOT) {
+		n := int(d.absInfo[_ABS_MT_SLOT].maximum) + 1
+		if n > maxTouchpadSlots {
+			n = maxTouchpadSlots
+		}
+		if n < 1 {
+			n = 1
+		}
+		d.slots = make([]touchpadSlot, n)
+		for i := range d.slots {
+			d.slots[i].id = -1
+		}
+		d.slot = int(d.absInfo[_ABS_MT_SLOT].value)
+	}
+	return d, nil
+}
+
+func (d *sensorDevice) close() {
+	if d.fd != 0 {
+		_ = unix.Close(d.fd)
+	}
+	d.fd = 0
+}
+
+func (d *sensorDevice) pollAbsState() error {
+	for code := 0; code < _ABS_CNT; code++ {
+		if !isBitSet(d.absBits, code) {
+			continue
+		}
+		if err := ioctl(d.fd, uint(_EVIOCGABS(uint(code))), unsafe.Pointer(&d.absInfo[code])); err != nil {
+			return fmt.Errorf("gamepad: ioctl for an abs at pollAbsState failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (d *sensorDevice) update() error {
+	if d.fd == 0 {
+		return nil
+	}
+
+	for {
+		e, err := readInputEvent(d.fd, &d.eventBuf)
+		if err != nil {
+			if err == unix.EAGAIN {
+				break
+			}
+			// Disconnected
+			if err == unix.ENODEV {
+				d.close()
+				return nil
+			}
+			return fmt.Errorf("gamepad: Read failed: %w", err)
+		}
+
+		if e.typ == unix.EV_SYN {
+			switch e.code {
+			case _SYN_DROPPED:
+				d.dropped = true
+			case _SYN_REPORT:
+				d.dropped = false
+				// TODO: Poll the touchpad slots by EVIOCGMTSLOTS.
+				if err := d.pollAbsState(); err != nil {
+					return fmt.Errorf("gamepad: poll absolute state: %w", err)
+				}
+			}
+		}
+		if d.dropped {
+			continue
+		}
+
+		if e.typ != unix.EV_ABS || int(e.code) >= _ABS_CNT {
+			continue
+		}
+
+		switch e.code {
+		case _ABS_MT_SLOT:
+			d.slot = int(e.value)
+		case _ABS_MT_TRACKING_ID:
+			if d.slot >= 0 && d.slot < len(d.slots) {
+				d.slots[d.slot].id = e.value
+			}
+		case _ABS_MT_POSITION_X:
+			if d.slot >= 0 && d.slot < len(d.slots) {
+				d.slots[d.slot].x = e.value
+			}
+		case _ABS_MT_POSITION_Y:
+			if d.slot >= 0 && d.slot < len(d.slots) {
+				d.slots[d.slot].y = e.value
+			}
+		}
+		d.absInfo[e.code].value = e.value
+	}
+	return nil
+}
+
+// absValueInUnits returns the value in the unit of the resolution, e.g. g for an accelerometer and degrees per second
+// for a gyroscope.
+func (d *sensorDevice) absValueInUnits(code int) float64 {
+	info := d.absInfo[code]
+	if info.resolution == 0 {
+		return float64(info.value)
+	}
+	return float64(info.value) / float64(info.resolution)
+}
+
+func (d *sensorDevice) motion() (acceleration, angularVelocity [3]float64) {
+	for i := 0; i < 3; i++ {
+		acceleration[i] = d.absValueInUnits(_ABS_X+i) * standardGravity
+		angularVelocity[i] = d.absValueInUnits(_ABS_RX+i) * math.Pi / 180
+	}
+	return
+}
+
+func normalizeAbsValue(value int32, info input_absinfo) float64 {
+	r := float64(info.maximum) - float64(info.minimum)
+	if r == 0 {
+		return 0
+	}
+	return (float64(value) - float64(info.minimum)) / r
+}
+
+func (d *sensorDevice) appendTouchpadTouches(touches []TouchpadTouch) []TouchpadTouch {
+	for _, s := range d.slots {
+		if s.id < 0 {
+			continue
+		}
+		touches = append(touches, TouchpadTouch{
+			ID: int(s.id),
+			X:  normalizeAbsValue(s.x, d.absInfo[_ABS_MT_POSITION_X]),
+			Y:  normalizeAbsValue(s.y, d.absInfo[_ABS_MT_POSITION_Y]),
+		})
+	}
+	return touches
+}
+
+func (g *nativeGamepadImpl) openSensorsIfNeeded(motionSensor, touchpad bool) {
+	if g.fd == 0 || g.uniq == "" {
+		return
+	}
+	motionSensor = motionSensor && g.motionSensor == nil
+	touchpad = touchpad && g.touchpad == nil
+	if !motionSensor && !touchpad {
+		return
+	}
+
+	// Searching the devices is not cheap. Throttle this in case the gamepad doesn't have the sensors.
+	if !g.sensorsSearchedAt.IsZero() && time.Since(g.sensorsSearchedAt) < time.Second {
+		return
+	}
+	g.sensorsSearchedAt = time.Now()
+
+	m, t := findSensorDevices(g.uniq, motionSensor, touchpad)
+	if m != nil {
+		g.motionSensor = m
+	}
+	if t != nil {
+		g.touchpad = t
+	}
+}
+
+func (g *nativeGamepadImpl) motion() (acceleration, angularVelocity [3]float64, ok bool) {
+	g.openSensorsIfNeeded(true, false)
+	if g.motionSensor == nil {
+		return
+	}
+	acceleration, angularVelocity = g.motionSensor.motion()
+	return acceleration, angularVelocity, true
+}
+
+func (g *nativeGamepadImpl) appendTouchpadTouches(touches []TouchpadTouch) []TouchpadTouch {
+	g.openSensorsIfNeeded(false, true)
+	if g.touchpad == nil {
+		return touches
+	}
+	return g.touchpad.appendTouchpadTouches(touches)
+}
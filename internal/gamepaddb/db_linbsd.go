@@ -14,7 +14,7 @@
 
 // Code generated by gen.go using 'go generate'. DO NOT EDIT.
 
-//go:build (freebsd || (linux && !android) || netbsd || openbsd) && !nintendosdk && !playstation5
+//go:build (freebsd || (linux && !android) || netbsd || openbsd) && !nintendosdk && !playstation5 && !ebitenginecustomplatform
 
 package gamepaddb
 
@@ -83,7 +83,13 @@ var (
 	gamepadNames          = map[string]string{}
 	gamepadButtonMappings = map[string]map[StandardButton]mapping{}
 	gamepadAxisMappings   = map[string]map[StandardAxis]mapping{}
-	mappingsM             sync.RWMutex
+
+	// customButtonMappings and customAxisMappings are mappings set by SetCustomMapping.
+	// These take priority over the mappings from the database.
+	customButtonMappings = map[string]map[StandardButton]mapping{}
+	customAxisMappings   = map[string]map[StandardAxis]mapping{}
+
+	mappingsM sync.RWMutex
 )
 
 func parseLine(line string, platform platform) (id string, name string, buttons map[StandardButton]mapping, axes map[StandardAxis]mapping, err error) {
@@ -313,6 +319,9 @@ func toStandardGamepadAxis(str string) (StandardAxis, bool) {
 }
 
 func buttonMappings(id string) map[StandardButton]mapping {
+	if m, ok := customButtonMappings[id]; ok {
+		return m
+	}
 	if m, ok := gamepadButtonMappings[id]; ok {
 		return m
 	}
@@ -325,6 +334,9 @@ func buttonMappings(id string) map[StandardButton]mapping {
 }
 
 func axisMappings(id string) map[StandardAxis]mapping {
+	if m, ok := customAxisMappings[id]; ok {
+		return m
+	}
 	if m, ok := gamepadAxisMappings[id]; ok {
 		return m
 	}
@@ -480,8 +492,8 @@ func IsStandardButtonPressed(id string, button StandardButton, state GamepadStat
 	mappingsM.RLock()
 	defer mappingsM.RUnlock()
 
-	mappings, ok := gamepadButtonMappings[id]
-	if !ok {
+	mappings := buttonMappings(id)
+	if mappings == nil {
 		return false
 	}
 
@@ -551,6 +563,51 @@ func Update(mappingData []byte) error {
 	return nil
 }
 
+// SetCustomMapping sets a mapping for the gamepad id, which takes priority over the mappings from the database.
+//
+// The values of buttons and axes are mapping elements in the same format as SDL_GameControllerDB,
+// e.g. "b0", "a1", "+a2~" or "h0.4".
+func SetCustomMapping(id string, buttons map[StandardButton]string, axes map[StandardAxis]string) error {
+	bs := map[StandardButton]mapping{}
+	for b, str := range buttons {
+		if str == "" {
+			return fmt.Errorf("gamepaddb: empty mapping for button %d", b)
+		}
+		m, err := parseMappingElement(str)
+		if err != nil {
+			return fmt.Errorf("gamepaddb: invalid mapping %q for button %d: %w", str, b, err)
+		}
+		bs[b] = m
+	}
+	as := map[StandardAxis]mapping{}
+	for a, str := range axes {
+		if str == "" {
+			return fmt.Errorf("gamepaddb: empty mapping for axis %d", a)
+		}
+		m, err := parseMappingElement(str)
+		if err != nil {
+			return fmt.Errorf("gamepaddb: invalid mapping %q for axis %d: %w", str, a, err)
+		}
+		as[a] = m
+	}
+
+	mappingsM.Lock()
+	defer mappingsM.Unlock()
+
+	customButtonMappings[id] = bs
+	customAxisMappings[id] = as
+	return nil
+}
+
+// RemoveCustomMapping removes the mapping set by SetCustomMapping for the gamepad id.
+func RemoveCustomMapping(id string) {
+	mappingsM.Lock()
+	defer mappingsM.Unlock()
+
+	delete(customButtonMappings, id)
+	delete(customAxisMappings, id)
+}
+
 func addAndroidDefaultMappings(id string) bool {
 	// See https://github.com/libsdl-org/SDL/blob/120c76c84bbce4c1bfed4e9eb74e10678bd83120/src/joystick/SDL_gamecontroller.c#L468-L568
 
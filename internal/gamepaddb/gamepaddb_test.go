@@ -80,3 +80,97 @@ func TestGLFWGamepadMappings(t *testing.T) {
 		t.Errorf("got: %q, want: %q", got, want)
 	}
 }
+
+type testGamepadState struct {
+	axes    []float64
+	buttons []bool
+	hats    []int
+}
+
+func (s *testGamepadState) IsAxisReady(index int) bool {
+	return index < len(s.axes)
+}
+
+func (s *testGamepadState) Axis(index int) float64 {
+	return s.axes[index]
+}
+
+func (s *testGamepadState) Button(index int) bool {
+	return s.buttons[index]
+}
+
+func (s *testGamepadState) Hat(index int) int {
+	return s.hats[index]
+}
+
+func TestCustomMapping(t *testing.T) {
+	const id = "ffffffffffffffffffffffffffffffff"
+
+	if err := gamepaddb.SetCustomMapping(id, map[gamepaddb.StandardButton]string{
+		gamepaddb.StandardButtonRightBottom:     "b1",
+		gamepaddb.StandardButtonLeftTop:         "h0.1",
+		gamepaddb.StandardButtonFrontBottomLeft: "+a1",
+	}, map[gamepaddb.StandardAxis]string{
+		gamepaddb.StandardAxisLeftStickHorizontal: "a0~",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer gamepaddb.RemoveCustomMapping(id)
+
+	if got, want := gamepaddb.HasStandardLayoutMapping(id), true; got != want {
+		t.Errorf("HasStandardLayoutMapping: got: %v, want: %v", got, want)
+	}
+
+	s := &testGamepadState{
+		axes:    []float64{0.5, 1},
+		buttons: []bool{false, true},
+		hats:    []int{gamepaddb.HatUp},
+	}
+	if got, want := gamepaddb.IsStandardButtonPressed(id, gamepaddb.StandardButtonRightBottom, s), true; got != want {
+		t.Errorf("IsStandardButtonPressed(RightBottom): got: %v, want: %v", got, want)
+	}
+	if got, want := gamepaddb.IsStandardButtonPressed(id, gamepaddb.StandardButtonLeftTop, s), true; got != want {
+		t.Errorf("IsStandardButtonPressed(LeftTop): got: %v, want: %v", got, want)
+	}
+	if got, want := gamepaddb.StandardButtonValue(id, gamepaddb.StandardButtonFrontBottomLeft, s), 1.0; got != want {
+		t.Errorf("StandardButtonValue(FrontBottomLeft): got: %v, want: %v", got, want)
+	}
+	if got, want := gamepaddb.StandardAxisValue(id, gamepaddb.StandardAxisLeftStickHorizontal, s), -0.5; got != want {
+		t.Errorf("StandardAxisValue(LeftStickHorizontal): got: %v, want: %v", got, want)
+	}
+
+	gamepaddb.RemoveCustomMapping(id)
+	if got, want := gamepaddb.HasStandardLayoutMapping(id), false; got != want {
+		t.Errorf("HasStandardLayoutMapping after RemoveCustomMapping: got: %v, want: %v", got, want)
+	}
+}
+
+func TestCustomMappingError(t *testing.T) {
+	const id = "ffffffffffffffffffffffffffffffff"
+
+	for _, str := range []string{"", "x0", "bfoo", "h0"} {
+		if err := gamepaddb.SetCustomMapping(id, map[gamepaddb.StandardButton]string{
+			gamepaddb.StandardButtonRightBottom: str,
+		}, nil); err == nil {
+			t.Errorf("SetCustomMapping with %q must return an error", str)
+		}
+	}
+	if got, want := gamepaddb.HasStandardLayoutMapping(id), false; got != want {
+		t.Errorf("HasStandardLayoutMapping: got: %v, want: %v", got, want)
+	}
+	// A failed SetCustomMapping doesn't change the current mapping.
+	if err := gamepaddb.SetCustomMapping(id, map[gamepaddb.StandardButton]string{
+		gamepaddb.StandardButtonRightBottom: "b0",
+	}, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer gamepaddb.RemoveCustomMapping(id)
+	if err := gamepaddb.SetCustomMapping(id, map[gamepaddb.StandardButton]string{
+		gamepaddb.StandardButtonRightBottom: "bfoo",
+	}, nil); err == nil {
+		t.Errorf("SetCustomMapping with %q must return an error", "bfoo")
+	}
+	if got, want := gamepaddb.HasStandardButton(id, gamepaddb.StandardButtonRightBottom), true; got != want {
+		t.Errorf("HasStandardButton: got: %v, want: %v", got, want)
+	}
+}
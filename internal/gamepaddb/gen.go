@@ -117,7 +117,7 @@ func run() error {
 		},
 		"Linux": {
 			filenameSuffix:   "linbsd",
-			buildConstraints: "//go:build (freebsd || (linux && !android) || netbsd || openbsd) && !nintendosdk && !playstation5",
+			buildConstraints: "//go:build (freebsd || (linux && !android) || netbsd || openbsd) && !nintendosdk && !playstation5 && !ebitenginecustomplatform",
 		},
 		"iOS": {
 			filenameSuffix: "ios",
@@ -15,10 +15,12 @@
 package graphicscommand
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"math"
 	"strings"
+	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
@@ -273,6 +275,34 @@ func (c *readPixelsCommand) String() string {
 	return fmt.Sprintf("read-pixels: image: %d", c.img.id)
 }
 
+// nativeTextureCommand represents a command to get a native texture of an image.
+type nativeTextureCommand struct {
+	img    *Image
+	result uintptr
+}
+
+// Exec executes a nativeTextureCommand.
+func (c *nativeTextureCommand) Exec(commandQueue *commandQueue, graphicsDriver graphicsdriver.Graphics, indexOffset int) error {
+	e, ok := c.img.image.(graphicsdriver.NativeTextureExporter)
+	if !ok {
+		return errors.New("graphicscommand: the graphics driver doesn't support native textures")
+	}
+	native, err := e.NativeTexture()
+	if err != nil {
+		return err
+	}
+	c.result = native
+	return nil
+}
+
+func (c *nativeTextureCommand) NeedsSync() bool {
+	return true
+}
+
+func (c *nativeTextureCommand) String() string {
+	return fmt.Sprintf("native-texture: image: %d", c.img.id)
+}
+
 // disposeImageCommand represents a command to dispose an image.
 type disposeImageCommand struct {
 	target *Image
@@ -317,16 +347,25 @@ type newImageCommand struct {
 	width  int
 	height int
 	screen bool
+
+	// native is a native texture to wrap. native is 0 unless the image is created by NewImageFromNativeTexture.
+	native uintptr
 }
 
 func (c *newImageCommand) String() string {
-	return fmt.Sprintf("new-image: result: %d, width: %d, height: %d, screen: %t", c.result.id, c.width, c.height, c.screen)
+	return fmt.Sprintf("new-image: result: %d, width: %d, height: %d, screen: %t, native: %t", c.result.id, c.width, c.height, c.screen, c.native != 0)
 }
 
 // Exec executes a newImageCommand.
 func (c *newImageCommand) Exec(commandQueue *commandQueue, graphicsDriver graphicsdriver.Graphics, indexOffset int) error {
 	var err error
-	if c.screen {
+	if c.native != 0 {
+		i, ok := graphicsDriver.(graphicsdriver.NativeTextureImporter)
+		if !ok {
+			return errors.New("graphicscommand: the graphics driver doesn't support native textures")
+		}
+		c.result.image, err = i.NewImageFromNativeTexture(c.width, c.height, c.native)
+	} else if c.screen {
 		c.result.image, err = graphicsDriver.NewScreenFramebufferImage(c.width, c.height)
 	} else {
 		c.result.image, err = graphicsDriver.NewImage(c.width, c.height)
@@ -365,7 +404,10 @@ func (c *newShaderCommand) NeedsSync() bool {
 // InitializeGraphicsDriverState initialize the current graphics driver state.
 func InitializeGraphicsDriverState(graphicsDriver graphicsdriver.Graphics) (err error) {
 	runOnRenderThread(func() {
-		err = graphicsDriver.Initialize()
+		if err = graphicsDriver.Initialize(); err != nil {
+			return
+		}
+		err = negotiateCapabilities(graphicsDriver)
 	}, true)
 	return
 }
@@ -375,17 +417,70 @@ func InitializeGraphicsDriverState(graphicsDriver graphicsdriver.Graphics) (err
 func ResetGraphicsDriverState(graphicsDriver graphicsdriver.Graphics) (err error) {
 	if r, ok := graphicsDriver.(graphicsdriver.Resetter); ok {
 		runOnRenderThread(func() {
-			err = r.Reset()
+			if err = r.Reset(); err != nil {
+				return
+			}
+			// The capabilities might be changed, e.g. when the device is changed.
+			err = negotiateCapabilities(graphicsDriver)
 		}, true)
 	}
 	return nil
 }
 
-// MaxImageSize returns the maximum size of an image.
-func MaxImageSize(graphicsDriver graphicsdriver.Graphics) int {
-	var size int
+var (
+	theCapabilities      graphicsdriver.Capabilities
+	capabilitiesResolved bool
+	capabilitiesM        sync.Mutex
+)
+
+// negotiateCapabilities queries and caches the capabilities of the graphics driver.
+// negotiateCapabilities must be called on the render thread.
+func negotiateCapabilities(graphicsDriver graphicsdriver.Graphics) error {
+	c, err := graphicsDriver.Capabilities()
+	if err != nil {
+		return err
+	}
+
+	capabilitiesM.Lock()
+	defer capabilitiesM.Unlock()
+	theCapabilities = c
+	capabilitiesResolved = true
+	return nil
+}
+
+// Capabilities returns the capabilities of the graphics driver.
+//
+// The capabilities are negotiated when the graphics driver is initialized.
+// If the graphics driver is not initialized yet, Capabilities queries them from the driver directly.
+func Capabilities(graphicsDriver graphicsdriver.Graphics) (graphicsdriver.Capabilities, error) {
+	capabilitiesM.Lock()
+	c, ok := theCapabilities, capabilitiesResolved
+	capabilitiesM.Unlock()
+	if ok {
+		return c, nil
+	}
+
+	var err error
 	runOnRenderThread(func() {
-		size = graphicsDriver.MaxImageSize()
+		c, err = graphicsDriver.Capabilities()
 	}, true)
-	return size
+	return c, err
+}
+
+// MaxImageSize returns the maximum size of an image.
+func MaxImageSize(graphicsDriver graphicsdriver.Graphics) int {
+	c, err := Capabilities(graphicsDriver)
+	if err != nil {
+		return 0
+	}
+	return c.Limits.MaxImageSize
+}
+
+// IsLogicOperationAvailable reports whether logic operations are available.
+func IsLogicOperationAvailable(graphicsDriver graphicsdriver.Graphics) bool {
+	c, err := Capabilities(graphicsDriver)
+	if err != nil {
+		return false
+	}
+	return c.Has(graphicsdriver.FeatureLogicOperation)
 }
@@ -20,6 +20,7 @@ import (
 	"math"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/debug"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
@@ -57,15 +58,38 @@ func SetVsyncEnabled(enabled bool, graphicsDriver graphicsdriver.Graphics) {
 	}, true)
 }
 
+// SetFrameIntervalRange sets the range of the intervals between presenting frames.
+// SetFrameIntervalRange does nothing if the graphics driver doesn't support it.
+func SetFrameIntervalRange(minInterval, maxInterval time.Duration, graphicsDriver graphicsdriver.Graphics) {
+	g, ok := graphicsDriver.(interface {
+		SetFrameIntervalRange(minInterval, maxInterval time.Duration)
+	})
+	if !ok {
+		return
+	}
+	runOnRenderThread(func() {
+		g.SetFrameIntervalRange(minInterval, maxInterval)
+	}, true)
+}
+
 // FlushCommands flushes the command queue and present the screen if needed.
 // If endFrame is true, the current screen might be used to present.
 func FlushCommands(graphicsDriver graphicsdriver.Graphics, endFrame bool) error {
 	if err := theCommandQueueManager.flush(graphicsDriver, endFrame); err != nil {
 		return err
 	}
+	if endFrame {
+		endFrameStats()
+	}
 	return nil
 }
 
+// DiscardCommands discards the queued commands without executing them.
+// DiscardCommands is used when there is no graphics driver, e.g., in the headless mode.
+func DiscardCommands() {
+	theCommandQueueManager.discard()
+}
+
 // commandQueue is a command queue for drawing commands.
 type commandQueue struct {
 	// commands is a queue of drawing commands.
@@ -143,6 +167,7 @@ func (q *commandQueue) EnqueueDrawTrianglesCommand(dst *Image, srcs [graphics.Sh
 						IndexCount: len(indices),
 					})
 				}
+				recordMergedDraw()
 				return
 			}
 		}
@@ -163,6 +188,7 @@ func (q *commandQueue) EnqueueDrawTrianglesCommand(dst *Image, srcs [graphics.Sh
 	c.uniforms = uniforms
 	c.fillRule = fillRule
 	q.commands = append(q.commands, c)
+	recordDrawCall(srcs)
 }
 
 func (q *commandQueue) lastVertices(n int) []float32 {
@@ -185,7 +211,9 @@ func (q *commandQueue) Flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 
 	var sync bool
 	// Disable asynchronous rendering when vsync is on, as this causes a rendering delay (#2822).
-	if endFrame && vsyncEnabled.Load() {
+	// If queuing frames is enabled explicitly, the delay is acceptable for better frame pacing.
+	limiter := theFrameLimiter.Load()
+	if endFrame && vsyncEnabled.Load() && limiter == nil {
 		sync = true
 	}
 	if !sync {
@@ -199,9 +227,18 @@ func (q *commandQueue) Flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 
 	logger := debug.SwitchLogger()
 
+	// Count only the frames to be presented asynchronously. A synchronous flush is presented before it returns.
+	countFrame := endFrame && !sync && limiter != nil
+	if countFrame {
+		limiter.queueFrame()
+	}
+
 	var flushErr error
 	runOnRenderThread(func() {
 		defer logger.Flush()
+		if countFrame {
+			defer limiter.presentFrame()
+		}
 
 		if err := q.flush(graphicsDriver, endFrame, logger); err != nil {
 			if sync {
@@ -242,29 +279,14 @@ func (q *commandQueue) flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 		if err1 := graphicsDriver.End(endFrame); err1 != nil && err == nil {
 			err = err1
 		}
-
-		// Release the commands explicitly (#1803).
-		// Apparently, the part of a slice between len and cap-1 still holds references.
-		// Then, resetting the length by [:0] doesn't release the references.
-		for i, c := range q.commands {
-			if c, ok := c.(*drawTrianglesCommand); ok {
-				q.drawTrianglesCommandPool.put(c)
-			}
-			q.commands[i] = nil
-		}
-		q.commands = q.commands[:0]
-		q.vertices = q.vertices[:0]
-		q.indices = q.indices[:0]
-		q.tmpNumVertexFloats = 0
-
 		if endFrame {
-			q.uint32sBuffer.reset()
-			for i, f := range q.finalizers {
-				f()
-				q.finalizers[i] = nil
+			recordMissedFrames(graphicsDriver)
+			if err1 := endFrameCapture(); err1 != nil && err == nil {
+				err = err1
 			}
-			q.finalizers = q.finalizers[:0]
 		}
+
+		q.reset(endFrame)
 	}()
 
 	cs := q.commands
@@ -295,6 +317,7 @@ func (q *commandQueue) flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 				return err
 			}
 			logger.Logf("  %s\n", c)
+			captureCommand(c)
 			// TODO: indexOffset should be reset if the command type is different
 			// from the previous one. This fix is needed when another drawing command is
 			// introduced than drawTrianglesCommand.
@@ -308,6 +331,32 @@ func (q *commandQueue) flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 	return nil
 }
 
+// reset releases the commands. If endFrame is true, the finalizers are also executed.
+func (q *commandQueue) reset(endFrame bool) {
+	// Release the commands explicitly (#1803).
+	// Apparently, the part of a slice between len and cap-1 still holds references.
+	// Then, resetting the length by [:0] doesn't release the references.
+	for i, c := range q.commands {
+		if c, ok := c.(*drawTrianglesCommand); ok {
+			q.drawTrianglesCommandPool.put(c)
+		}
+		q.commands[i] = nil
+	}
+	q.commands = q.commands[:0]
+	q.vertices = q.vertices[:0]
+	q.indices = q.indices[:0]
+	q.tmpNumVertexFloats = 0
+
+	if endFrame {
+		q.uint32sBuffer.reset()
+		for i, f := range q.finalizers {
+			f()
+			q.finalizers[i] = nil
+		}
+		q.finalizers = q.finalizers[:0]
+	}
+}
+
 type rectangleF32 struct {
 	x      float32
 	y      float32
@@ -494,6 +543,13 @@ func (c *commandQueueManager) flush(graphicsDriver graphicsdriver.Graphics, endF
 	return nil
 }
 
+func (c *commandQueueManager) discard() {
+	if c.current == nil {
+		return
+	}
+	c.current.reset(true)
+}
+
 // uint32sBuffer is a reusable buffer to allocate []uint32.
 type uint32sBuffer struct {
 	buf []uint32
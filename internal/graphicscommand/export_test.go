@@ -0,0 +1,49 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicscommand
+
+func IsEbitengineFunctionForTesting(function string) bool {
+	return isEbitengineFunction(function)
+}
+
+func SetMaxQueuedFramesForTesting(maxQueuedFrames int) {
+	setMaxQueuedFrames(maxQueuedFrames)
+}
+
+func MaxQueuedFramesForTesting() int {
+	l := theFrameLimiter.Load()
+	if l == nil {
+		return 0
+	}
+	return cap(l.slots)
+}
+
+type FrameLimiterForTesting struct {
+	l *frameLimiter
+}
+
+func NewFrameLimiterForTesting(maxQueuedFrames int) *FrameLimiterForTesting {
+	return &FrameLimiterForTesting{
+		l: newFrameLimiter(maxQueuedFrames),
+	}
+}
+
+func (f *FrameLimiterForTesting) QueueFrame() {
+	f.l.queueFrame()
+}
+
+func (f *FrameLimiterForTesting) PresentFrame() {
+	f.l.presentFrame()
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicscommand
+
+import (
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+)
+
+// FrameCapture is a record of the graphics commands executed in one frame.
+type FrameCapture struct {
+	Commands []CapturedCommand `json:"commands"`
+}
+
+// CapturedCommand is a record of a graphics command.
+type CapturedCommand struct {
+	// Type is the type of the command like "draw-triangles".
+	Type string `json:"type"`
+
+	// Dst is the image the command affects.
+	Dst *CapturedImage `json:"dst,omitempty"`
+
+	// Srcs is the source images of a draw-triangles command.
+	Srcs []*CapturedImage `json:"srcs,omitempty"`
+
+	// ShaderID is the ID of the shader of a draw-triangles command.
+	ShaderID int `json:"shaderId,omitempty"`
+
+	// NumVertices is the number of the vertices of a draw-triangles command.
+	NumVertices int `json:"numVertices,omitempty"`
+
+	// NumIndices is the number of the indices of a draw-triangles command.
+	NumIndices int `json:"numIndices,omitempty"`
+
+	// NumDstRegions is the number of the destination regions of a draw-triangles command.
+	NumDstRegions int `json:"numDstRegions,omitempty"`
+
+	// Description is the same string as the debug log with the build tag ebitenginedebug.
+	Description string `json:"description"`
+}
+
+// CapturedImage is a record of an image used by a graphics command.
+type CapturedImage struct {
+	ID     int    `json:"id"`
+	Label  string `json:"label,omitempty"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Screen bool   `json:"screen,omitempty"`
+}
+
+var (
+	frameCaptureRequests []func(capture *FrameCapture) error
+	frameCaptureErr      error
+	frameCaptureM        sync.Mutex
+
+	// currentFrameCapture and currentFrameCaptureCallbacks must be accessed from the render thread.
+	currentFrameCapture          *FrameCapture
+	currentFrameCaptureCallbacks []func(capture *FrameCapture) error
+)
+
+// RequestFrameCapture requests to capture the graphics commands in the next frame.
+// f is called from a separate goroutine after the frame ends, so that a slow f doesn't block rendering.
+// If f returns an error, the error is returned at a later flush.
+//
+// RequestFrameCapture is concurrent-safe.
+func RequestFrameCapture(f func(capture *FrameCapture) error) {
+	frameCaptureM.Lock()
+	defer frameCaptureM.Unlock()
+	frameCaptureRequests = append(frameCaptureRequests, f)
+}
+
+// captureCommand records an executed command if a frame is being captured.
+//
+// captureCommand must be called from the render thread.
+func captureCommand(c command) {
+	if currentFrameCapture == nil {
+		return
+	}
+
+	cc := CapturedCommand{
+		Description: c.String(),
+	}
+	switch c := c.(type) {
+	case *drawTrianglesCommand:
+		cc.Type = "draw-triangles"
+		cc.Dst = c.dst.captured()
+		cc.Srcs = make([]*CapturedImage, len(c.srcs))
+		for i, src := range c.srcs {
+			cc.Srcs[i] = src.captured()
+		}
+		cc.ShaderID = c.shader.id
+		cc.NumVertices = c.numVertices() / graphics.VertexFloatCount
+		cc.NumIndices = c.numIndices()
+		cc.NumDstRegions = len(c.dstRegions)
+	case *writePixelsCommand:
+		cc.Type = "write-pixels"
+		cc.Dst = c.dst.captured()
+	case *readPixelsCommand:
+		cc.Type = "read-pixels"
+		cc.Dst = c.img.captured()
+	case *nativeTextureCommand:
+		cc.Type = "native-texture"
+		cc.Dst = c.img.captured()
+	case *disposeImageCommand:
+		cc.Type = "dispose-image"
+		cc.Dst = c.target.captured()
+	case *disposeShaderCommand:
+		cc.Type = "dispose-shader"
+		cc.ShaderID = c.target.id
+	case *newImageCommand:
+		cc.Type = "new-image"
+		cc.Dst = c.result.captured()
+	case *newShaderCommand:
+		cc.Type = "new-shader"
+		cc.ShaderID = c.result.id
+	}
+	currentFrameCapture.Commands = append(currentFrameCapture.Commands, cc)
+}
+
+// endFrameCapture finishes the current frame capture if exists, and starts a new capture for the next frame if requested.
+// endFrameCapture returns an error returned by a callback of the previous captures if exists.
+//
+// endFrameCapture must be called from the render thread at the end of a frame.
+func endFrameCapture() error {
+	if currentFrameCapture != nil {
+		capture := currentFrameCapture
+		callbacks := currentFrameCaptureCallbacks
+		currentFrameCapture = nil
+		currentFrameCaptureCallbacks = nil
+
+		// The capture is no longer modified by the render thread.
+		go func() {
+			for _, f := range callbacks {
+				if err := f(capture); err != nil {
+					frameCaptureM.Lock()
+					if frameCaptureErr == nil {
+						frameCaptureErr = err
+					}
+					frameCaptureM.Unlock()
+				}
+			}
+		}()
+	}
+
+	frameCaptureM.Lock()
+	defer frameCaptureM.Unlock()
+	err := frameCaptureErr
+	frameCaptureErr = nil
+	if len(frameCaptureRequests) > 0 {
+		currentFrameCapture = &FrameCapture{}
+		currentFrameCaptureCallbacks = frameCaptureRequests
+		frameCaptureRequests = nil
+	}
+	return err
+}
+
+func (i *Image) captured() *CapturedImage {
+	if i == nil {
+		return nil
+	}
+	return &CapturedImage{
+		ID:     i.id,
+		Label:  i.label,
+		Width:  i.width,
+		Height: i.height,
+		Screen: i.screen,
+	}
+}
@@ -15,9 +15,11 @@
 package graphicscommand
 
 import (
+	"encoding/json"
 	"fmt"
 	"image"
 	"io"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -36,6 +38,7 @@ type Image struct {
 	internalWidth  int
 	internalHeight int
 	screen         bool
+	external       bool
 
 	// id is an identifier for the image. This is used only when dumping the information.
 	//
@@ -43,6 +46,14 @@ type Image struct {
 	// have its graphicsdriver.Image.
 	id int
 
+	// label is a label for the image. This is used only when dumping the information.
+	label string
+
+	// atlasPage reports whether the image is an atlas page, and atlasRegions are the regions of the images on the page.
+	// These are used only when dumping the information.
+	atlasPage    bool
+	atlasRegions []image.Rectangle
+
 	bufferedWritePixelsArgs []writePixelsCommandArgs
 }
 
@@ -71,6 +82,30 @@ func NewImage(width, height int, screenFramebuffer bool) *Image {
 		screen: screenFramebuffer,
 	}
 	theCommandQueueManager.enqueueCommand(c)
+	if !screenFramebuffer {
+		recordTextureBytes(int64(4 * width * height))
+	}
+	return i
+}
+
+// NewImageFromNativeTexture returns a new image that wraps an existing native texture.
+//
+// The graphics driver must implement graphicsdriver.NativeTextureImporter.
+// The native texture is not released when the image is disposed.
+func NewImageFromNativeTexture(width, height int, native uintptr) *Image {
+	i := &Image{
+		width:    width,
+		height:   height,
+		external: true,
+		id:       genNextImageID(),
+	}
+	c := &newImageCommand{
+		result: i,
+		width:  width,
+		height: height,
+		native: native,
+	}
+	theCommandQueueManager.enqueueCommand(c)
 	return i
 }
 
@@ -90,14 +125,35 @@ func (i *Image) flushBufferedWritePixels() {
 
 func (i *Image) Dispose() {
 	i.bufferedWritePixelsArgs = nil
+	if !i.screen && !i.external {
+		recordTextureBytes(-int64(4 * i.width * i.height))
+	}
 	c := &disposeImageCommand{
 		target: i,
 	}
 	theCommandQueueManager.enqueueCommand(c)
 }
 
+// ID returns the image's identifier for debugging.
+func (i *Image) ID() int {
+	return i.id
+}
+
+// SetLabel sets a label for debugging.
+// The label is used for the file names of DumpImages.
+func (i *Image) SetLabel(label string) {
+	i.label = label
+}
+
+// SetAtlasPage sets whether the image is an atlas page and the regions of the images on the page for debugging.
+// The information is used for the metadata of Dump and DumpImages.
+func (i *Image) SetAtlasPage(atlasPage bool, regions []image.Rectangle) {
+	i.atlasPage = atlasPage
+	i.atlasRegions = regions
+}
+
 func (i *Image) InternalSize() (int, int) {
-	if i.screen {
+	if i.screen || i.external {
 		return i.width, i.height
 	}
 	if i.internalWidth == 0 {
@@ -149,6 +205,7 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices [
 // ReadPixels returns an error when an error happens in the graphics driver.
 func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, args []graphicsdriver.PixelsArgs) error {
 	i.flushBufferedWritePixels()
+	i.recordReadBack(args)
 	c := &readPixelsCommand{
 		img:  i,
 		args: args,
@@ -160,7 +217,23 @@ func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, args []graphi
 	return nil
 }
 
+// NativeTexture flushes the commands and returns the image's native texture.
+//
+// The native texture's size is InternalSize.
+func (i *Image) NativeTexture(graphicsDriver graphicsdriver.Graphics) (uintptr, error) {
+	i.flushBufferedWritePixels()
+	c := &nativeTextureCommand{
+		img: i,
+	}
+	theCommandQueueManager.enqueueCommand(c)
+	if err := theCommandQueueManager.flush(graphicsDriver, false); err != nil {
+		return 0, err
+	}
+	return c.result, nil
+}
+
 func (i *Image) WritePixels(pixels *graphics.ManagedBytes, region image.Rectangle) {
+	i.recordUploaded(region)
 	i.bufferedWritePixelsArgs = append(i.bufferedWritePixelsArgs, writePixelsCommandArgs{
 		pixels: pixels,
 		region: region,
@@ -171,6 +244,78 @@ func (i *Image) dumpName(path string) string {
 	return strings.ReplaceAll(path, "*", strconv.Itoa(i.id))
 }
 
+// dumpImagesName returns a file name without an extension for DumpImages.
+func (i *Image) dumpImagesName() string {
+	if i.label == "" {
+		return strconv.Itoa(i.id)
+	}
+	return strconv.Itoa(i.id) + "_" + i.label
+}
+
+// isDumpTarget reports whether the image is dumped by DumpImages with the given filter.
+func (i *Image) isDumpTarget(filter *regexp.Regexp) bool {
+	// Screen image cannot be dumped.
+	if i.screen {
+		return false
+	}
+	if filter == nil {
+		return true
+	}
+	return filter.MatchString(i.dumpImagesName())
+}
+
+type dumpedImageMetadata struct {
+	ID             int    `json:"id"`
+	Label          string `json:"label,omitempty"`
+	Width          int    `json:"width"`
+	Height         int    `json:"height"`
+	InternalWidth  int    `json:"internalWidth"`
+	InternalHeight int    `json:"internalHeight"`
+	Format         string `json:"format"`
+
+	// AtlasPage reports whether the image is an atlas page shared by multiple images.
+	AtlasPage bool `json:"atlasPage"`
+
+	// AtlasRegions are the regions including paddings of the images on the atlas page.
+	AtlasRegions []dumpedRegion `json:"atlasRegions,omitempty"`
+}
+
+type dumpedRegion struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// dumpMetadataTo dumps the image's metadata in JSON to the specified writer.
+func (i *Image) dumpMetadataTo(w io.Writer) error {
+	iw, ih := i.InternalSize()
+	m := dumpedImageMetadata{
+		ID:             i.id,
+		Label:          i.label,
+		Width:          i.width,
+		Height:         i.height,
+		InternalWidth:  iw,
+		InternalHeight: ih,
+		Format:         "RGBA8 (premultiplied alpha)",
+		AtlasPage:      i.atlasPage,
+	}
+	for _, r := range i.atlasRegions {
+		m.AtlasRegions = append(m.AtlasRegions, dumpedRegion{
+			X:      r.Min.X,
+			Y:      r.Min.Y,
+			Width:  r.Dx(),
+			Height: r.Dy(),
+		})
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	if err := e.Encode(&m); err != nil {
+		return err
+	}
+	return nil
+}
+
 // dumpTo dumps the image to the specified writer.
 //
 // If blackbg is true, any alpha values in the dumped image will be 255.
@@ -18,6 +18,9 @@ import (
 	"archive/zip"
 	"bytes"
 	"image"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"syscall/js"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
@@ -52,30 +55,45 @@ func (i *Image) Dump(graphicsDriver graphicsdriver.Graphics, path string, blackb
 
 	download(buf, "image/png", i.dumpName(path))
 
+	buf = &bytes.Buffer{}
+	if err := i.dumpMetadataTo(buf); err != nil {
+		return "", err
+	}
+	download(buf, "application/json", strings.TrimSuffix(i.dumpName(path), filepath.Ext(path))+".json")
+
 	return path, nil
 }
 
 // DumpImages dumps all the specified images to the specified directory.
+// For each image, a PNG file and a JSON file for its metadata are created in a zip file.
+//
+// If filter is not nil, only the images whose names (e.g. "1_atlas-source") match with filter are dumped.
 //
 // This is for testing usage.
-func DumpImages(images []*Image, graphicsDriver graphicsdriver.Graphics, dir string) (string, error) {
+func DumpImages(images []*Image, graphicsDriver graphicsdriver.Graphics, dir string, filter *regexp.Regexp) (string, error) {
 	buf := &bytes.Buffer{}
 	zw := zip.NewWriter(buf)
 
 	for _, img := range images {
-		// Screen image cannot be dumped.
-		if img.screen {
+		if !img.isDumpTarget(filter) {
 			continue
 		}
 
-		f, err := zw.Create(img.dumpName("*.png"))
+		f, err := zw.Create(img.dumpImagesName() + ".png")
 		if err != nil {
 			return "", err
 		}
-
 		if err := img.dumpTo(f, graphicsDriver, false, image.Rect(0, 0, img.width, img.height)); err != nil {
 			return "", err
 		}
+
+		f, err = zw.Create(img.dumpImagesName() + ".json")
+		if err != nil {
+			return "", err
+		}
+		if err := img.dumpMetadataTo(f); err != nil {
+			return "", err
+		}
 	}
 
 	_ = zw.Close()
@@ -21,12 +21,16 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
 )
 
+// Dump dumps the image as a PNG file to the specified path, and its metadata as a JSON file next to the PNG file.
 func (i *Image) Dump(graphicsDriver graphicsdriver.Graphics, path string, blackbg bool, rect image.Rectangle) (string, error) {
 	p, err := availableFilename(path)
 	if err != nil {
@@ -35,19 +39,12 @@ func (i *Image) Dump(graphicsDriver graphicsdriver.Graphics, path string, blackb
 	path = p
 
 	path = i.dumpName(path)
-	f, err := os.Create(path)
-	if err != nil {
-		return "", err
-	}
-	defer func() {
-		_ = f.Close()
-	}()
-
-	w := bufio.NewWriter(f)
-	if err := i.dumpTo(w, graphicsDriver, blackbg, rect); err != nil {
+	if err := dumpImageFile(path, func(w io.Writer) error {
+		return i.dumpTo(w, graphicsDriver, blackbg, rect)
+	}); err != nil {
 		return "", err
 	}
-	if err := w.Flush(); err != nil {
+	if err := dumpImageFile(strings.TrimSuffix(path, filepath.Ext(path))+".json", i.dumpMetadataTo); err != nil {
 		return "", err
 	}
 
@@ -55,9 +52,12 @@ func (i *Image) Dump(graphicsDriver graphicsdriver.Graphics, path string, blackb
 }
 
 // DumpImages dumps all the current images to the specified directory.
+// For each image, a PNG file and a JSON file for its metadata are created.
+//
+// If filter is not nil, only the images whose names (e.g. "1_atlas-source") match with filter are dumped.
 //
 // This is for testing usage.
-func DumpImages(images []*Image, graphicsDriver graphicsdriver.Graphics, dir string) (string, error) {
+func DumpImages(images []*Image, graphicsDriver graphicsdriver.Graphics, dir string, filter *regexp.Regexp) (string, error) {
 	d, err := availableFilename(dir)
 	if err != nil {
 		return "", err
@@ -69,24 +69,16 @@ func DumpImages(images []*Image, graphicsDriver graphicsdriver.Graphics, dir str
 	}
 
 	for _, img := range images {
-		// Screen image cannot be dumped.
-		if img.screen {
+		if !img.isDumpTarget(filter) {
 			continue
 		}
 
-		f, err := os.Create(filepath.Join(dir, img.dumpName("*.png")))
-		if err != nil {
-			return "", err
-		}
-		defer func() {
-			_ = f.Close()
-		}()
-
-		w := bufio.NewWriter(f)
-		if err := img.dumpTo(w, graphicsDriver, false, image.Rect(0, 0, img.width, img.height)); err != nil {
+		if err := dumpImageFile(filepath.Join(dir, img.dumpImagesName()+".png"), func(w io.Writer) error {
+			return img.dumpTo(w, graphicsDriver, false, image.Rect(0, 0, img.width, img.height))
+		}); err != nil {
 			return "", err
 		}
-		if err := w.Flush(); err != nil {
+		if err := dumpImageFile(filepath.Join(dir, img.dumpImagesName()+".json"), img.dumpMetadataTo); err != nil {
 			return "", err
 		}
 	}
@@ -94,6 +86,25 @@ func DumpImages(images []*Image, graphicsDriver graphicsdriver.Graphics, dir str
 	return dir, nil
 }
 
+func dumpImageFile(path string, dump func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	w := bufio.NewWriter(f)
+	if err := dump(w); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return nil
+}
+
 // availableFilename returns a filename that is valid as a new file or directory.
 func availableFilename(name string) (string, error) {
 	ext := filepath.Ext(name)
@@ -0,0 +1,193 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicscommand
+
+import (
+	"fmt"
+	"image"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/debug"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+)
+
+// FrameStats represents statistics of the graphics commands in one frame.
+type FrameStats struct {
+	// UploadedBytes is the number of bytes sent from CPU to GPU by WritePixels.
+	UploadedBytes int64
+
+	// ReadBackBytes is the number of bytes sent from GPU to CPU by ReadPixels.
+	ReadBackBytes int64
+
+	// DrawCalls is the number of draw-triangles commands after merging.
+	DrawCalls int
+
+	// MergedDraws is the number of draw-triangles commands merged into their previous commands.
+	MergedDraws int
+
+	// TextureSwitches is the number of draw-triangles commands whose source images are different from
+	// the previous command's in the same frame.
+	TextureSwitches int
+
+	// TextureBytes is the estimated number of bytes of the alive textures at the end of the frame.
+	TextureBytes int64
+}
+
+var (
+	currentFrameStats FrameStats
+	lastFrameStats    FrameStats
+	textureBytes      int64
+
+	// lastDrawSrcIDs is the IDs of the source images of the last draw-triangles command in the current frame.
+	// IDs are used instead of images not to keep the images alive.
+	lastDrawSrcIDs       [graphics.ShaderImageCount]int
+	lastDrawSrcIDsExists bool
+
+	missedFrames          uint64
+	missedFramesAvailable bool
+
+	frameStatsM sync.Mutex
+)
+
+// ReadLastFrameStats writes the statistics of the last completed frame into stats.
+//
+// ReadLastFrameStats is concurrent-safe.
+func ReadLastFrameStats(stats *FrameStats) {
+	frameStatsM.Lock()
+	defer frameStatsM.Unlock()
+	*stats = lastFrameStats
+}
+
+// MissedFrames returns the total number of the missed frames reported by the graphics driver,
+// and reports whether the number is available.
+//
+// MissedFrames is concurrent-safe.
+func MissedFrames() (uint64, bool) {
+	frameStatsM.Lock()
+	defer frameStatsM.Unlock()
+	return missedFrames, missedFramesAvailable
+}
+
+// recordMissedFrames must be called from the render thread after a frame is presented.
+func recordMissedFrames(graphicsDriver graphicsdriver.Graphics) {
+	c, ok := graphicsDriver.(graphicsdriver.MissedFramesCounter)
+	if !ok {
+		return
+	}
+	n, available := c.MissedFrames()
+
+	frameStatsM.Lock()
+	defer frameStatsM.Unlock()
+	missedFrames = n
+	missedFramesAvailable = available
+}
+
+func endFrameStats() {
+	frameStatsM.Lock()
+	defer frameStatsM.Unlock()
+	lastFrameStats = currentFrameStats
+	lastFrameStats.TextureBytes = textureBytes
+	currentFrameStats = FrameStats{}
+	lastDrawSrcIDsExists = false
+}
+
+func recordDrawCall(srcs [graphics.ShaderImageCount]*Image) {
+	var ids [graphics.ShaderImageCount]int
+	for i, src := range srcs {
+		if src == nil {
+			continue
+		}
+		ids[i] = src.id
+	}
+
+	frameStatsM.Lock()
+	defer frameStatsM.Unlock()
+	currentFrameStats.DrawCalls++
+	if lastDrawSrcIDsExists && lastDrawSrcIDs != ids {
+		currentFrameStats.TextureSwitches++
+	}
+	lastDrawSrcIDs = ids
+	lastDrawSrcIDsExists = true
+}
+
+func recordMergedDraw() {
+	frameStatsM.Lock()
+	defer frameStatsM.Unlock()
+	currentFrameStats.MergedDraws++
+}
+
+func recordTextureBytes(delta int64) {
+	frameStatsM.Lock()
+	defer frameStatsM.Unlock()
+	textureBytes += delta
+}
+
+func (i *Image) recordUploaded(region image.Rectangle) {
+	n := int64(4 * region.Dx() * region.Dy())
+	frameStatsM.Lock()
+	currentFrameStats.UploadedBytes += n
+	frameStatsM.Unlock()
+
+	if debug.IsDebug {
+		debug.Logf("  WritePixels: image: %s, region: %v, %d bytes, caller: %s\n", i.dumpImagesName(), region, n, firstCallerOutsideEbitengine())
+	}
+}
+
+func (i *Image) recordReadBack(args []graphicsdriver.PixelsArgs) {
+	var n int64
+	for _, a := range args {
+		n += int64(4 * a.Region.Dx() * a.Region.Dy())
+	}
+	frameStatsM.Lock()
+	currentFrameStats.ReadBackBytes += n
+	frameStatsM.Unlock()
+
+	if debug.IsDebug {
+		debug.Logf("  ReadPixels: image: %s, len(regions): %d, %d bytes, caller: %s\n", i.dumpImagesName(), len(args), n, firstCallerOutsideEbitengine())
+	}
+}
+
+// firstCallerOutsideEbitengine returns the position of the first caller that is not a part of Ebitengine.
+// This is used only for the debug logs to attribute pixel transfers to the user code.
+func firstCallerOutsideEbitengine() string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if !isEbitengineFunction(f.Function) {
+			return fmt.Sprintf("%s (%s:%d)", f.Function, f.File, f.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return "(unknown)"
+}
+
+// isEbitengineFunction reports whether the function is a part of Ebitengine's implementation of pixel transfers.
+//
+// Only the root package and the internal packages are skipped. The other public packages like text/v2, vector,
+// and exp/... are the callers of the root package, and they are worth reporting.
+func isEbitengineFunction(function string) bool {
+	const (
+		rootPkg     = "github.com/hajimehoshi/ebiten/v2."
+		internalPkg = "github.com/hajimehoshi/ebiten/v2/internal/"
+	)
+	return strings.HasPrefix(function, rootPkg) || strings.HasPrefix(function, internalPkg) || strings.HasPrefix(function, "runtime.")
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicscommand_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
+)
+
+func TestIsEbitengineFunction(t *testing.T) {
+	testCases := []struct {
+		Function string
+		Want     bool
+	}{
+		{
+			Function: "github.com/hajimehoshi/ebiten/v2.(*Image).WritePixels",
+			Want:     true,
+		},
+		{
+			Function: "github.com/hajimehoshi/ebiten/v2/internal/atlas.(*Image).WritePixels",
+			Want:     true,
+		},
+		{
+			Function: "runtime.goexit",
+			Want:     true,
+		},
+		{
+			Function: "github.com/hajimehoshi/ebiten/v2/text/v2.Draw",
+			Want:     false,
+		},
+		{
+			Function: "github.com/hajimehoshi/ebiten/v2/vector.DrawFilledRect",
+			Want:     false,
+		},
+		{
+			Function: "github.com/hajimehoshi/ebiten/v2/exp/textinput.(*TextField).Draw",
+			Want:     false,
+		},
+		{
+			Function: "github.com/hajimehoshi/ebiten/v2_test.TestImage",
+			Want:     false,
+		},
+		{
+			Function: "main.(*Game).Draw",
+			Want:     false,
+		},
+	}
+	for _, tc := range testCases {
+		if got, want := graphicscommand.IsEbitengineFunctionForTesting(tc.Function), tc.Want; got != want {
+			t.Errorf("%s: got: %v, want: %v", tc.Function, got, want)
+		}
+	}
+}
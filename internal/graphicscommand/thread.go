@@ -16,15 +16,71 @@ package graphicscommand
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/thread"
 )
 
 var theRenderThread thread.Thread = thread.NewNoopThread()
 
+// theFrameLimiter limits the number of frames queued to the render thread asynchronously even with vsync.
+// theFrameLimiter is nil when queuing frames is disabled.
+var theFrameLimiter atomic.Pointer[frameLimiter]
+
+// renderThreadQueueSizeForQueuedFrames is the number of functions the render thread can queue when queuing frames is enabled.
+// A frame can consist of multiple asynchronous flushes, and the number of frames is limited by frameLimiter instead.
+const renderThreadQueueSizeForQueuedFrames = 64
+
+// frameLimiter limits the number of frames that are queued but not presented yet.
+type frameLimiter struct {
+	slots chan struct{}
+}
+
+func newFrameLimiter(maxQueuedFrames int) *frameLimiter {
+	return &frameLimiter{
+		slots: make(chan struct{}, maxQueuedFrames),
+	}
+}
+
+// queueFrame reserves a slot for a new frame.
+// queueFrame blocks while the maximum number of frames are not presented yet.
+func (f *frameLimiter) queueFrame() {
+	f.slots <- struct{}{}
+}
+
+// presentFrame releases the slot of the oldest queued frame.
+// presentFrame must be called after the frame is presented.
+func (f *frameLimiter) presentFrame() {
+	<-f.slots
+}
+
 // SetOSThreadAsRenderThread sets an OS thread as rendering thread e.g. for OpenGL.
 func SetOSThreadAsRenderThread() {
 	theRenderThread = thread.NewOSThread()
+	setMaxQueuedFrames(0)
+}
+
+// SetOSThreadAsRenderThreadWithQueuedFrames sets an OS thread as rendering thread,
+// and lets the render thread queue up to maxQueuedFrames frames without blocking the game thread.
+// A frame is counted from the flush at the end of the frame until the frame is presented.
+//
+// If maxQueuedFrames is 0, this is the same as SetOSThreadAsRenderThread.
+func SetOSThreadAsRenderThreadWithQueuedFrames(maxQueuedFrames int) {
+	if maxQueuedFrames <= 0 {
+		SetOSThreadAsRenderThread()
+		return
+	}
+	theRenderThread = thread.NewOSThreadWithQueueSize(renderThreadQueueSizeForQueuedFrames)
+	setMaxQueuedFrames(maxQueuedFrames)
+}
+
+// setMaxQueuedFrames resets the frame limiter. If maxQueuedFrames is 0, queuing frames is disabled.
+func setMaxQueuedFrames(maxQueuedFrames int) {
+	if maxQueuedFrames <= 0 {
+		theFrameLimiter.Store(nil)
+		return
+	}
+	theFrameLimiter.Store(newFrameLimiter(maxQueuedFrames))
 }
 
 func LoopRenderThread(ctx context.Context) {
@@ -38,7 +94,7 @@ func runOnRenderThread(f func(), sync bool) {
 		return
 	}
 
-	// As the current thread doesn't have a capacity in a channel,
+	// Unless queuing frames is enabled, as the current thread doesn't have a capacity in a channel,
 	// CallAsync should block when the previously-queued task is not executed yet.
 	// This blocking is expected as double-buffering is used.
 	theRenderThread.CallAsync(f)
@@ -0,0 +1,66 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicscommand_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
+)
+
+func TestFrameLimiter(t *testing.T) {
+	const maxQueuedFrames = 2
+	l := graphicscommand.NewFrameLimiterForTesting(maxQueuedFrames)
+
+	// Up to maxQueuedFrames frames can be queued without blocking.
+	for i := 0; i < maxQueuedFrames; i++ {
+		l.QueueFrame()
+	}
+
+	queued := make(chan struct{})
+	go func() {
+		l.QueueFrame()
+		close(queued)
+	}()
+
+	select {
+	case <-queued:
+		t.Fatalf("QueueFrame must block while %d frames are not presented", maxQueuedFrames)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	l.PresentFrame()
+	select {
+	case <-queued:
+	case <-time.After(time.Second):
+		t.Fatalf("QueueFrame must return after a frame is presented")
+	}
+}
+
+func TestSetMaxQueuedFrames(t *testing.T) {
+	defer graphicscommand.SetMaxQueuedFramesForTesting(graphicscommand.MaxQueuedFramesForTesting())
+
+	graphicscommand.SetMaxQueuedFramesForTesting(2)
+	if got, want := graphicscommand.MaxQueuedFramesForTesting(), 2; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	// Disabling queuing frames must reset the previous state.
+	graphicscommand.SetMaxQueuedFramesForTesting(0)
+	if got, want := graphicscommand.MaxQueuedFramesForTesting(), 0; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
@@ -21,6 +21,13 @@ type Blend struct {
 	BlendFactorDestinationAlpha BlendFactor
 	BlendOperationRGB           BlendOperation
 	BlendOperationAlpha         BlendOperation
+
+	// BlendColor is the constant color used by BlendFactorConstantColor and BlendFactorOneMinusConstantColor in RGBA.
+	BlendColor [4]float32
+
+	// LogicOperation is a logic operation applied to the source and destination colors.
+	// If LogicOperation is not LogicOperationNone, the blend factors and the blend operations are ignored.
+	LogicOperation LogicOperation
 }
 
 type BlendFactor byte
@@ -37,6 +44,8 @@ const (
 	BlendFactorDestinationAlpha
 	BlendFactorOneMinusDestinationAlpha
 	BlendFactorSourceAlphaSaturated
+	BlendFactorConstantColor
+	BlendFactorOneMinusConstantColor
 )
 
 type BlendOperation byte
@@ -49,6 +58,16 @@ const (
 	BlendOperationMax
 )
 
+type LogicOperation byte
+
+const (
+	LogicOperationNone LogicOperation = iota
+	LogicOperationAnd
+	LogicOperationOr
+	LogicOperationXor
+	LogicOperationInvert
+)
+
 var BlendSourceOver = Blend{
 	BlendFactorSourceRGB:        BlendFactorOne,
 	BlendFactorSourceAlpha:      BlendFactorOne,
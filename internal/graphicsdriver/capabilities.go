@@ -0,0 +1,89 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicsdriver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Feature represents a set of optional features of a graphics driver.
+type Feature uint32
+
+const (
+	// FeatureLogicOperation indicates that Blend's LogicOperation is available.
+	FeatureLogicOperation Feature = 1 << iota
+
+	// FeatureClearingScreenRequired indicates that the screen must be cleared at every frame.
+	FeatureClearingScreenRequired
+
+	// FeatureReset indicates that the driver's state can be reset. See Resetter.
+	FeatureReset
+
+	// FeatureMissedFrames indicates that the driver can report missed frames. See MissedFramesCounter.
+	FeatureMissedFrames
+
+	// FeatureNativeTextureImport indicates that the driver can wrap a native texture. See NativeTextureImporter.
+	FeatureNativeTextureImport
+
+	// FeatureNativeTextureExport indicates that the driver's images can expose their native textures.
+	// See NativeTextureExporter.
+	FeatureNativeTextureExport
+)
+
+var featureNames = []string{
+	"LogicOperation",
+	"ClearingScreenRequired",
+	"Reset",
+	"MissedFrames",
+	"NativeTextureImport",
+	"NativeTextureExport",
+}
+
+func (f Feature) String() string {
+	if f == 0 {
+		return "0"
+	}
+	var names []string
+	for i, name := range featureNames {
+		if f&(1<<i) != 0 {
+			names = append(names, name)
+			f &^= 1 << i
+		}
+	}
+	if f != 0 {
+		names = append(names, fmt.Sprintf("Feature(%#x)", uint32(f)))
+	}
+	return strings.Join(names, "|")
+}
+
+// Limits represents the limits of a graphics driver.
+type Limits struct {
+	// MaxImageSize is the maximum width and height of an image.
+	MaxImageSize int
+}
+
+// Capabilities represents the features and the limits of a graphics driver.
+//
+// The upper layers should consult Capabilities instead of assuming features from build tags or driver types.
+type Capabilities struct {
+	Features Feature
+	Limits   Limits
+}
+
+// Has reports whether all the given features are available.
+func (c *Capabilities) Has(features Feature) bool {
+	return c.Features&features == features
+}
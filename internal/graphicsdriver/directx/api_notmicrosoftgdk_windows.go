@@ -52,6 +52,10 @@ func _ID3D12GraphicsCommandList_IASetVertexBuffers(i *_ID3D12GraphicsCommandList
 	panic("not implemented")
 }
 
+func _ID3D12GraphicsCommandList_OMSetBlendFactor(i *_ID3D12GraphicsCommandList, blendFactor *[4]float32) {
+	panic("not implemented")
+}
+
 func _ID3D12GraphicsCommandList_OMSetRenderTargets(i *_ID3D12GraphicsCommandList, renderTargetDescriptors []_D3D12_CPU_DESCRIPTOR_HANDLE, rtsSingleHandleToDescriptorRange bool, pDepthStencilDescriptor *_D3D12_CPU_DESCRIPTOR_HANDLE) {
 	panic("not implemented")
 }
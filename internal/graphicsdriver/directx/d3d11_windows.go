@@ -822,6 +822,12 @@ func (i *_ID3D11Device) CreateVertexShader(pShaderBytecode unsafe.Pointer, bytec
 	return vertexShader, nil
 }
 
+// GetDeviceRemovedReason returns the HRESULT of the reason why the device was removed, or S_OK if it is not removed.
+func (i *_ID3D11Device) GetDeviceRemovedReason() handleError {
+	r, _, _ := syscall.Syscall(i.vtbl.GetDeviceRemovedReason, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+	return handleError(windows.Handle(uint32(r)))
+}
+
 func (i *_ID3D11Device) QueryInterface(riid *windows.GUID) (unsafe.Pointer, error) {
 	var v unsafe.Pointer
 	r, _, _ := syscall.Syscall(i.vtbl.QueryInterface, 3, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(riid)), uintptr(unsafe.Pointer(&v)))
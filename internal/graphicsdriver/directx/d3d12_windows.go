@@ -1555,12 +1555,10 @@ func (i *_ID3D12Device) GetDescriptorHandleIncrementSize(descriptorHeapType _D3D
 	return uint32(r)
 }
 
-func (i *_ID3D12Device) GetDeviceRemovedReason() error {
+// GetDeviceRemovedReason returns the HRESULT of the reason why the device was removed, or S_OK if it is not removed.
+func (i *_ID3D12Device) GetDeviceRemovedReason() handleError {
 	r, _, _ := syscall.Syscall(i.vtbl.GetDeviceRemovedReason, 1, uintptr(unsafe.Pointer(i)), 0, 0)
-	if uint32(r) != uint32(windows.S_OK) {
-		return fmt.Errorf("directx: ID3D12Device::GetDeviceRemovedReason failed: %w", handleError(windows.Handle(uint32(r))))
-	}
-	return nil
+	return handleError(windows.Handle(uint32(r)))
 }
 
 func (i *_ID3D12Device) ScheduleFrameEventX(typ _D3D12XBOX_FRAME_EVENT_TYPE, intervalOffsetInMicroseconds uint32, pAncillarySignalList *_D3D12XBOX_SCHEDULE_FRAME_OBJECT_LIST, flags _D3D12XBOX_SCHEDULE_FRAME_EVENT_FLAGS) error {
@@ -1836,6 +1834,15 @@ func (i *_ID3D12GraphicsCommandList) IASetVertexBuffers(startSlot uint32, views
 	runtime.KeepAlive(views)
 }
 
+func (i *_ID3D12GraphicsCommandList) OMSetBlendFactor(blendFactor *[4]float32) {
+	if microsoftgdk.IsXbox() {
+		_ID3D12GraphicsCommandList_OMSetBlendFactor(i, blendFactor)
+		return
+	}
+	_, _, _ = syscall.Syscall(i.vtbl.OMSetBlendFactor, 2, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(blendFactor)), 0)
+	runtime.KeepAlive(blendFactor)
+}
+
 func (i *_ID3D12GraphicsCommandList) OMSetRenderTargets(renderTargetDescriptors []_D3D12_CPU_DESCRIPTOR_HANDLE, rtsSingleHandleToDescriptorRange bool, pDepthStencilDescriptor *_D3D12_CPU_DESCRIPTOR_HANDLE) {
 	if microsoftgdk.IsXbox() {
 		_ID3D12GraphicsCommandList_OMSetRenderTargets(i, renderTargetDescriptors, rtsSingleHandleToDescriptorRange, pDepthStencilDescriptor)
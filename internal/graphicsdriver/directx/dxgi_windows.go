@@ -92,7 +92,12 @@ const (
 
 	_DXGI_CREATE_FACTORY_DEBUG = 0x01
 
-	_DXGI_ERROR_NOT_FOUND = handleError(0x887A0002)
+	_DXGI_ERROR_INVALID_CALL          = handleError(0x887A0001)
+	_DXGI_ERROR_NOT_FOUND             = handleError(0x887A0002)
+	_DXGI_ERROR_DEVICE_REMOVED        = handleError(0x887A0005)
+	_DXGI_ERROR_DEVICE_HUNG           = handleError(0x887A0006)
+	_DXGI_ERROR_DEVICE_RESET          = handleError(0x887A0007)
+	_DXGI_ERROR_DRIVER_INTERNAL_ERROR = handleError(0x887A0020)
 
 	_DXGI_MWA_NO_ALT_ENTER      = 0x2
 	_DXGI_MWA_NO_WINDOW_CHANGES = 0x1
@@ -135,6 +140,15 @@ type _DXGI_ADAPTER_DESC1 struct {
 	Flags                 uint32
 }
 
+type _DXGI_FRAME_STATISTICS struct {
+	PresentCount        uint32
+	PresentRefreshCount uint32
+	SyncRefreshCount    uint32
+	_                   uint32 // Padding for LARGE_INTEGER, which is 8-byte aligned even on 32bit machines.
+	SyncQPCTime         int64
+	SyncGPUTime         int64
+}
+
 type _DXGI_MODE_DESC struct {
 	Width            uint32
 	Height           uint32
@@ -546,6 +560,14 @@ func (i *_IDXGISwapChain) Present(syncInterval uint32, flags uint32) (occluded b
 	return false, nil
 }
 
+func (i *_IDXGISwapChain) GetFrameStatistics(pStats *_DXGI_FRAME_STATISTICS) error {
+	r, _, _ := syscall.Syscall(i.vtbl.GetFrameStatistics, 2, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(pStats)), 0)
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("directx: IDXGISwapChain::GetFrameStatistics failed: %w", handleError(windows.Handle(uint32(r))))
+	}
+	return nil
+}
+
 func (i *_IDXGISwapChain) QueryInterface(riid *windows.GUID) (unsafe.Pointer, error) {
 	var v unsafe.Pointer
 	r, _, _ := syscall.Syscall(i.vtbl.QueryInterface, 3, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(riid)), uintptr(unsafe.Pointer(&v)))
@@ -93,6 +93,10 @@ func blendFactorToBlend11(f graphicsdriver.BlendFactor, alpha bool) _D3D11_BLEND
 		return _D3D11_BLEND_INV_DEST_ALPHA
 	case graphicsdriver.BlendFactorSourceAlphaSaturated:
 		return _D3D11_BLEND_SRC_ALPHA_SAT
+	case graphicsdriver.BlendFactorConstantColor:
+		return _D3D11_BLEND_BLEND_FACTOR
+	case graphicsdriver.BlendFactorOneMinusConstantColor:
+		return _D3D11_BLEND_INV_BLEND_FACTOR
 	default:
 		panic(fmt.Sprintf("directx: invalid blend factor: %d", f))
 	}
@@ -277,7 +281,7 @@ func (g *graphics11) End(present bool) error {
 	}
 
 	if err := g.graphicsInfra.present(g.vsyncEnabled); err != nil {
-		return err
+		return newDeviceRemovedErrorIfNeeded(err, g.device.GetDeviceRemovedReason)
 	}
 
 	if g.newScreenWidth != 0 && g.newScreenHeight != 0 {
@@ -463,22 +467,34 @@ func (g *graphics11) SetVsyncEnabled(enabled bool) {
 	g.vsyncEnabled = enabled
 }
 
-func (g *graphics11) NeedsClearingScreen() bool {
-	// TODO: Confirm this is really true.
-	return true
-}
-
-func (g *graphics11) MaxImageSize() int {
+func (g *graphics11) Capabilities() (graphicsdriver.Capabilities, error) {
+	var maxImageSize int
 	switch g.featureLevel {
 	case _D3D_FEATURE_LEVEL_10_0:
-		return 8192
+		maxImageSize = 8192
 	case _D3D_FEATURE_LEVEL_10_1:
-		return 8192
+		maxImageSize = 8192
 	case _D3D_FEATURE_LEVEL_11_0:
-		return 16384
+		maxImageSize = 16384
 	default:
-		panic(fmt.Sprintf("directx: invalid feature level: 0x%x", g.featureLevel))
+		return graphicsdriver.Capabilities{}, fmt.Errorf("directx: invalid feature level: 0x%x", g.featureLevel)
+	}
+	return graphicsdriver.Capabilities{
+		// TODO: Confirm clearing the screen is really required.
+		// Logic operations are available only for UINT render targets, while Ebitengine uses UNORM render targets.
+		Features: graphicsdriver.FeatureClearingScreenRequired |
+			graphicsdriver.FeatureMissedFrames,
+		Limits: graphicsdriver.Limits{
+			MaxImageSize: maxImageSize,
+		},
+	}, nil
+}
+
+func (g *graphics11) MissedFrames() (uint64, bool) {
+	if g.graphicsInfra == nil {
+		return 0, false
 	}
+	return g.graphicsInfra.missedFramesCount()
 }
 
 func (g *graphics11) NewShader(program *shaderir.Program) (graphicsdriver.Shader, error) {
@@ -557,7 +573,7 @@ func (g *graphics11) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphic
 		if err != nil {
 			return err
 		}
-		g.deviceContext.OMSetBlendState(bs, nil, 0xffffffff)
+		g.deviceContext.OMSetBlendState(bs, &blend.BlendColor, 0xffffffff)
 
 		dss, err := g.depthStencilState(noStencil)
 		if err != nil {
@@ -584,7 +600,7 @@ func (g *graphics11) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphic
 			if err != nil {
 				return err
 			}
-			g.deviceContext.OMSetBlendState(bs, nil, 0xffffffff)
+			g.deviceContext.OMSetBlendState(bs, &blend.BlendColor, 0xffffffff)
 			dss, err := g.depthStencilState(incrementStencil)
 			if err != nil {
 				return err
@@ -596,7 +612,7 @@ func (g *graphics11) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphic
 			if err != nil {
 				return err
 			}
-			g.deviceContext.OMSetBlendState(bs, nil, 0xffffffff)
+			g.deviceContext.OMSetBlendState(bs, &blend.BlendColor, 0xffffffff)
 			dss, err := g.depthStencilState(invertStencil)
 			if err != nil {
 				return err
@@ -610,7 +626,7 @@ func (g *graphics11) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphic
 			if err != nil {
 				return err
 			}
-			g.deviceContext.OMSetBlendState(bs, nil, 0xffffffff)
+			g.deviceContext.OMSetBlendState(bs, &blend.BlendColor, 0xffffffff)
 			dss, err := g.depthStencilState(drawWithStencil)
 			if err != nil {
 				return err
@@ -641,6 +657,9 @@ func (g *graphics11) blendState(blend graphicsdriver.Blend, stencilMode stencilM
 		writeMask = uint8(_D3D11_COLOR_WRITE_ENABLE_ALL)
 	}
 
+	// The blend color is not a part of a blend state. This is specified at OMSetBlendState.
+	blend.BlendColor = [4]float32{}
+
 	key := blendStateKey{
 		blend:     blend,
 		writeMask: writeMask,
@@ -706,7 +706,7 @@ func (g *graphics12) End(present bool) error {
 			}
 		} else {
 			if err := g.presentDesktop(); err != nil {
-				return err
+				return newDeviceRemovedErrorIfNeeded(err, g.device.GetDeviceRemovedReason)
 			}
 		}
 
@@ -1054,13 +1054,24 @@ func (g *graphics12) SetVsyncEnabled(enabled bool) {
 	g.vsyncEnabled = enabled
 }
 
-func (g *graphics12) NeedsClearingScreen() bool {
-	// TODO: Confirm this is really true.
-	return true
+func (g *graphics12) Capabilities() (graphicsdriver.Capabilities, error) {
+	return graphicsdriver.Capabilities{
+		// TODO: Confirm clearing the screen is really required.
+		// Logic operations are available only for UINT render targets, while Ebitengine uses UNORM render targets.
+		Features: graphicsdriver.FeatureClearingScreenRequired |
+			graphicsdriver.FeatureMissedFrames,
+		Limits: graphicsdriver.Limits{
+			MaxImageSize: _D3D12_REQ_TEXTURE2D_U_OR_V_DIMENSION,
+		},
+	}, nil
 }
 
-func (g *graphics12) MaxImageSize() int {
-	return _D3D12_REQ_TEXTURE2D_U_OR_V_DIMENSION
+func (g *graphics12) MissedFrames() (uint64, bool) {
+	// On Xbox, the frame statistics of DXGI are not available.
+	if g.graphicsInfra == nil {
+		return 0, false
+	}
+	return g.graphicsInfra.missedFramesCount()
 }
 
 func (g *graphics12) NewShader(program *shaderir.Program) (graphicsdriver.Shader, error) {
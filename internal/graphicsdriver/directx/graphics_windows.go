@@ -160,6 +160,14 @@ type graphicsInfra struct {
 	lastTime time.Time
 
 	bufferCount int
+
+	// missedFrames is the total number of the vertical blanks missed by the presentations.
+	missedFrames uint64
+
+	// lastFrameStats is the last frame statistics of the swap chain.
+	// lastFrameStats is valid only when frameStatsValid is true.
+	lastFrameStats  _DXGI_FRAME_STATISTICS
+	frameStatsValid bool
 }
 
 // newGraphicsInfra takes the ownership of the given factory.
@@ -357,6 +365,12 @@ func (g *graphicsInfra) present(vsyncEnabled bool) error {
 	}
 	g.occluded = occluded
 
+	if vsyncEnabled && !g.occluded {
+		g.updateMissedFrames()
+	} else {
+		g.frameStatsValid = false
+	}
+
 	// Reduce FPS when the screen is invisible.
 	now := time.Now()
 	if g.occluded {
@@ -369,6 +383,82 @@ func (g *graphicsInfra) present(vsyncEnabled bool) error {
 	return nil
 }
 
+func (g *graphicsInfra) updateMissedFrames() {
+	var stats _DXGI_FRAME_STATISTICS
+	if err := g.swapChain.GetFrameStatistics(&stats); err != nil {
+		// GetFrameStatistics can fail e.g. with DXGI_ERROR_FRAME_STATISTICS_DISJOINT when the display mode changes.
+		// Restart counting from the next frame.
+		g.frameStatsValid = false
+		return
+	}
+
+	if g.frameStatsValid {
+		// When every present is shown at every vertical blank, both counts increase at the same pace.
+		presents := stats.PresentCount - g.lastFrameStats.PresentCount
+		refreshes := stats.PresentRefreshCount - g.lastFrameStats.PresentRefreshCount
+		if refreshes > presents {
+			g.missedFrames += uint64(refreshes - presents)
+		}
+	}
+	g.lastFrameStats = stats
+	g.frameStatsValid = true
+}
+
+// missedFramesCount returns the total number of the missed vertical blanks, and reports whether the number is available.
+func (g *graphicsInfra) missedFramesCount() (uint64, bool) {
+	return g.missedFrames, g.frameStatsValid
+}
+
 func (g *graphicsInfra) getBuffer(buffer uint32, riid *windows.GUID) (unsafe.Pointer, error) {
 	return g.swapChain.GetBuffer(buffer, riid)
 }
+
+// deviceRemovedError represents an error when the device is removed or reset,
+// e.g. by a GPU driver update or a timeout detection and recovery (TDR).
+//
+// Recreating the device is not supported, as the images' contents exist only on the GPU.
+type deviceRemovedError struct {
+	err error
+
+	// reason is the HRESULT returned by GetDeviceRemovedReason.
+	reason handleError
+}
+
+func (e *deviceRemovedError) Error() string {
+	return fmt.Sprintf("directx: the device was removed: %v (reason: %s)", e.err, deviceRemovedReasonString(e.reason))
+}
+
+func (e *deviceRemovedError) Unwrap() error {
+	return e.err
+}
+
+func deviceRemovedReasonString(reason handleError) string {
+	switch reason {
+	case handleError(windows.S_OK):
+		return "S_OK"
+	case _DXGI_ERROR_DEVICE_HUNG:
+		return "DXGI_ERROR_DEVICE_HUNG"
+	case _DXGI_ERROR_DEVICE_REMOVED:
+		return "DXGI_ERROR_DEVICE_REMOVED"
+	case _DXGI_ERROR_DEVICE_RESET:
+		return "DXGI_ERROR_DEVICE_RESET"
+	case _DXGI_ERROR_DRIVER_INTERNAL_ERROR:
+		return "DXGI_ERROR_DRIVER_INTERNAL_ERROR"
+	case _DXGI_ERROR_INVALID_CALL:
+		return "DXGI_ERROR_INVALID_CALL"
+	default:
+		return fmt.Sprintf("0x%08X", uint32(reason))
+	}
+}
+
+// newDeviceRemovedErrorIfNeeded returns a deviceRemovedError with the removal reason if err indicates the device is removed.
+// Otherwise, newDeviceRemovedErrorIfNeeded returns err as it is.
+func newDeviceRemovedErrorIfNeeded(err error, getDeviceRemovedReason func() handleError) error {
+	if !errors.Is(err, _DXGI_ERROR_DEVICE_REMOVED) && !errors.Is(err, _DXGI_ERROR_DEVICE_RESET) {
+		return err
+	}
+	return &deviceRemovedError{
+		err:    err,
+		reason: getDeviceRemovedReason(),
+	}
+}
@@ -68,6 +68,9 @@ func (s *shader12) disposeImpl() {
 }
 
 func (s *shader12) pipelineState(blend graphicsdriver.Blend, stencilMode stencilMode, screen bool) (*_ID3D12PipelineState, error) {
+	// The blend color is not a part of a pipeline state. This is set by OMSetBlendFactor.
+	blend.BlendColor = [4]float32{}
+
 	key := pipelineStateKey{
 		blend:       blend,
 		stencilMode: stencilMode,
@@ -62,8 +62,12 @@ type Graphics interface {
 	NewImage(width, height int) (Image, error)
 	NewScreenFramebufferImage(width, height int) (Image, error)
 	SetVsyncEnabled(enabled bool)
-	NeedsClearingScreen() bool
-	MaxImageSize() int
+
+	// Capabilities returns the features and the limits of the driver.
+	//
+	// Capabilities is called after Initialize succeeds, and after Reset succeeds if the driver is a Resetter.
+	// The returned value must not change until the next Initialize or Reset.
+	Capabilities() (Capabilities, error)
 
 	NewShader(program *shaderir.Program) (Shader, error)
 
@@ -75,6 +79,27 @@ type Resetter interface {
 	Reset() error
 }
 
+// MissedFramesCounter is an optional interface for Graphics that can report the presentation statistics of the screen.
+type MissedFramesCounter interface {
+	// MissedFrames returns the total number of the vertical blanks missed by the presentations so far.
+	// MissedFrames returns false when the number is not available, e.g. vsync is disabled.
+	MissedFrames() (uint64, bool)
+}
+
+// NativeTextureImporter is an optional interface for Graphics that can wrap an existing native texture as an Image.
+type NativeTextureImporter interface {
+	// NewImageFromNativeTexture creates an Image with a native texture, e.g. an OpenGL texture name or an MTLTexture.
+	// The returned image doesn't own the texture, and disposing the image doesn't release the texture.
+	NewImageFromNativeTexture(width, height int, native uintptr) (Image, error)
+}
+
+// NativeTextureExporter is an optional interface for Image that can expose its native texture.
+type NativeTextureExporter interface {
+	// NativeTexture flushes the rendering commands for the image, and returns its native texture,
+	// e.g. an OpenGL texture name or an MTLTexture.
+	NativeTexture() (uintptr, error)
+}
+
 type Image interface {
 	ID() ImageID
 	Dispose()
@@ -15,11 +15,13 @@
 package metal
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"math"
 	"runtime"
 	"sort"
+	"time"
 	"unsafe"
 
 	"github.com/ebitengine/purego/objc"
@@ -57,9 +59,10 @@ type Graphics struct {
 	shaders      map[graphicsdriver.ShaderID]*Shader
 	nextShaderID graphicsdriver.ShaderID
 
-	transparent  bool
-	maxImageSize int
-	tmpTextures  []mtl.Texture
+	transparent      bool
+	maxImageSize     int
+	minFrameInterval time.Duration
+	tmpTextures      []mtl.Texture
 
 	pool cocoa.NSAutoreleasePool
 }
@@ -236,7 +239,11 @@ func (g *Graphics) flushIfNeeded(present bool) {
 			g.screenDrawable = g.view.nextDrawable()
 		}
 		if g.screenDrawable != (ca.MetalDrawable{}) {
-			g.cb.PresentDrawable(g.screenDrawable)
+			if g.minFrameInterval > 0 && g.cb.IsPresentDrawableAfterMinimumDurationAvailable() {
+				g.cb.PresentDrawableAfterMinimumDuration(g.screenDrawable, g.minFrameInterval.Seconds())
+			} else {
+				g.cb.PresentDrawable(g.screenDrawable)
+			}
 		}
 	}
 
@@ -257,7 +264,7 @@ func (g *Graphics) checkSize(width, height int) {
 	if height < 1 {
 		panic(fmt.Sprintf("metal: height (%d) must be equal or more than %d", height, 1))
 	}
-	m := g.MaxImageSize()
+	m := g.getMaxImageSize()
 	if width > m {
 		panic(fmt.Sprintf("metal: width (%d) must be less than or equal to %d", width, m))
 	}
@@ -311,6 +318,26 @@ func (g *Graphics) NewScreenFramebufferImage(width, height int) (graphicsdriver.
 	return i, nil
 }
 
+// NewImageFromNativeTexture implements graphicsdriver.NativeTextureImporter.
+//
+// native is an id<MTLTexture> of MTLTextureType2D with the exact size of width and height.
+func (g *Graphics) NewImageFromNativeTexture(width, height int, native uintptr) (graphicsdriver.Image, error) {
+	if native == 0 {
+		return nil, errors.New("metal: the texture must not be nil")
+	}
+	g.checkSize(width, height)
+	i := &Image{
+		id:       g.genNextImageID(),
+		graphics: g,
+		width:    width,
+		height:   height,
+		texture:  mtl.NewTexture(objc.ID(native)),
+		external: true,
+	}
+	g.addImage(i)
+	return i, nil
+}
+
 func (g *Graphics) addImage(img *Image) {
 	if g.images == nil {
 		g.images = map[graphicsdriver.ImageID]*Image{}
@@ -353,6 +380,10 @@ func blendFactorToMetalBlendFactor(c graphicsdriver.BlendFactor) mtl.BlendFactor
 		return mtl.BlendFactorOneMinusDestinationAlpha
 	case graphicsdriver.BlendFactorSourceAlphaSaturated:
 		return mtl.BlendFactorSourceAlphaSaturated
+	case graphicsdriver.BlendFactorConstantColor:
+		return mtl.BlendFactorBlendColor
+	case graphicsdriver.BlendFactorOneMinusConstantColor:
+		return mtl.BlendFactorOneMinusBlendColor
 	default:
 		panic(fmt.Sprintf("metal: invalid blend factor: %d", c))
 	}
@@ -571,6 +602,8 @@ func (g *Graphics) draw(dst *Image, dstRegions []graphicsdriver.DstRegion, srcs
 		drawWithStencilRpss = s
 	}
 
+	g.rce.SetBlendColor(blend.BlendColor[0], blend.BlendColor[1], blend.BlendColor[2], blend.BlendColor[3])
+
 	for _, dstRegion := range dstRegions {
 		g.rce.SetScissorRect(mtl.ScissorRect{
 			X:      dstRegion.Region.Min.X,
@@ -692,11 +725,18 @@ func (g *Graphics) SetVsyncEnabled(enabled bool) {
 	g.view.setDisplaySyncEnabled(enabled)
 }
 
-func (g *Graphics) NeedsClearingScreen() bool {
-	return false
+// SetFrameIntervalRange sets the range of the intervals between presenting frames.
+// A zero value means no limit.
+// A minimum interval shorter than 1/60 seconds opts in a high frame rate like 120Hz on ProMotion displays.
+//
+// maxInterval is not used here. The display keeps showing the last drawable until a new drawable is presented,
+// so the UI layer presents a frame at least every maxInterval instead.
+func (g *Graphics) SetFrameIntervalRange(minInterval, maxInterval time.Duration) {
+	g.minFrameInterval = minInterval
+	g.view.setHighFrameRate(minInterval > 0 && minInterval < time.Second/60)
 }
 
-func (g *Graphics) MaxImageSize() int {
+func (g *Graphics) getMaxImageSize() int {
 	if g.maxImageSize != 0 {
 		return g.maxImageSize
 	}
@@ -745,6 +785,17 @@ func (g *Graphics) MaxImageSize() int {
 	return g.maxImageSize
 }
 
+func (g *Graphics) Capabilities() (graphicsdriver.Capabilities, error) {
+	return graphicsdriver.Capabilities{
+		// Metal doesn't have logic operations, and doesn't need to clear the screen.
+		Features: graphicsdriver.FeatureNativeTextureImport |
+			graphicsdriver.FeatureNativeTextureExport,
+		Limits: graphicsdriver.Limits{
+			MaxImageSize: g.getMaxImageSize(),
+		},
+	}, nil
+}
+
 func (g *Graphics) NewShader(program *shaderir.Program) (graphicsdriver.Shader, error) {
 	s, err := newShader(g.view.getMTLDevice(), g.genNextShaderID(), program)
 	if err != nil {
@@ -776,6 +827,9 @@ type Image struct {
 	screen   bool
 	texture  mtl.Texture
 	stencil  mtl.Texture
+
+	// external reports whether the texture is owned by the caller of NewImageFromNativeTexture.
+	external bool
 }
 
 func (i *Image) ID() graphicsdriver.ImageID {
@@ -783,7 +837,7 @@ func (i *Image) ID() graphicsdriver.ImageID {
 }
 
 func (i *Image) internalSize() (int, int) {
-	if i.screen {
+	if i.screen || i.external {
 		return i.width, i.height
 	}
 	return graphics.InternalImageSize(i.width), graphics.InternalImageSize(i.height)
@@ -795,7 +849,9 @@ func (i *Image) Dispose() {
 		i.stencil = mtl.Texture{}
 	}
 	if i.texture != (mtl.Texture{}) {
-		i.texture.Release()
+		if !i.external {
+			i.texture.Release()
+		}
 		i.texture = mtl.Texture{}
 	}
 	i.graphics.removeImage(i)
@@ -820,6 +876,21 @@ func (i *Image) syncTexture() {
 	cb.WaitUntilCompleted()
 }
 
+// NativeTexture implements graphicsdriver.NativeTextureExporter.
+func (i *Image) NativeTexture() (uintptr, error) {
+	if i.screen {
+		return 0, errors.New("metal: the screen doesn't have a texture")
+	}
+	i.graphics.flushIfNeeded(false)
+
+	// Wait for the committed commands so that the texture can be used by another command queue.
+	cb := i.graphics.cq.CommandBuffer()
+	cb.Commit()
+	cb.WaitUntilCompleted()
+
+	return uintptr(i.texture.Native()), nil
+}
+
 func (i *Image) ReadPixels(args []graphicsdriver.PixelsArgs) error {
 	i.graphics.flushIfNeeded(false)
 	i.syncTexture()
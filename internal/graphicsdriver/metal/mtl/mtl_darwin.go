@@ -528,6 +528,7 @@ var (
 	sel_commandBuffer                                                                                                                 = objc.RegisterName("commandBuffer")
 	sel_status                                                                                                                        = objc.RegisterName("status")
 	sel_presentDrawable                                                                                                               = objc.RegisterName("presentDrawable:")
+	sel_presentDrawableAfterMinimumDuration                                                                                           = objc.RegisterName("presentDrawable:afterMinimumDuration:")
 	sel_commit                                                                                                                        = objc.RegisterName("commit")
 	sel_waitUntilCompleted                                                                                                            = objc.RegisterName("waitUntilCompleted")
 	sel_waitUntilScheduled                                                                                                            = objc.RegisterName("waitUntilScheduled")
@@ -823,6 +824,20 @@ func (cb CommandBuffer) PresentDrawable(d Drawable) {
 	cb.commandBuffer.Send(sel_presentDrawable, d.Drawable())
 }
 
+// IsPresentDrawableAfterMinimumDurationAvailable reports whether PresentDrawableAfterMinimumDuration is available.
+// PresentDrawableAfterMinimumDuration is available as of macOS 10.15.4 and iOS 10.3.
+func (cb CommandBuffer) IsPresentDrawableAfterMinimumDurationAvailable() bool {
+	return cb.commandBuffer.Send(sel_respondsToSelector, sel_presentDrawableAfterMinimumDuration) != 0
+}
+
+// PresentDrawableAfterMinimumDuration registers a drawable presentation to occur after waiting for the previous drawable
+// to meet the minimum display time in seconds.
+//
+// Reference: https://developer.apple.com/documentation/metal/mtlcommandbuffer/2887074-presentdrawable?language=objc.
+func (cb CommandBuffer) PresentDrawableAfterMinimumDuration(d Drawable, duration float64) {
+	cb.commandBuffer.Send(sel_presentDrawableAfterMinimumDuration, d.Drawable(), duration)
+}
+
 // Commit commits this command buffer for execution as soon as possible.
 //
 // Reference: https://developer.apple.com/documentation/metal/mtlcommandbuffer/1443003-commit?language=objc.
@@ -1069,6 +1084,11 @@ func NewTexture(texture objc.ID) Texture {
 	return Texture{texture: texture}
 }
 
+// Native returns the underlying id<MTLTexture> pointer.
+func (t Texture) Native() objc.ID {
+	return t.texture
+}
+
 // resource implements the Resource interface.
 func (t Texture) resource() unsafe.Pointer {
 	return *(*unsafe.Pointer)(unsafe.Pointer(&t.texture))
@@ -138,6 +138,9 @@ func (s *Shader) init(device mtl.Device) error {
 }
 
 func (s *Shader) RenderPipelineState(view *view, blend graphicsdriver.Blend, stencilMode stencilMode, screen bool) (mtl.RenderPipelineState, error) {
+	// The blend color is not a part of a render pipeline state. This is set by SetBlendColor.
+	blend.BlendColor = [4]float32{}
+
 	key := shaderRpsKey{
 		blend:       blend,
 		stencilMode: stencilMode,
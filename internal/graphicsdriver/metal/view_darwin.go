@@ -27,6 +27,7 @@ type view struct {
 
 	windowChanged bool
 	vsyncDisabled bool
+	highFrameRate bool
 
 	device mtl.Device
 	ml     ca.MetalLayer
@@ -54,6 +55,10 @@ func (v *view) forceSetDisplaySyncEnabled(enabled bool) {
 	v.vsyncDisabled = !enabled
 }
 
+func (v *view) setHighFrameRate(highFrameRate bool) {
+	v.highFrameRate = highFrameRate
+}
+
 func (v *view) colorPixelFormat() mtl.PixelFormat {
 	return v.ml.PixelFormat()
 }
@@ -64,6 +64,12 @@ func (v *view) maximumDrawableCount() int {
 		return 3
 	}
 
+	// Use 3 for a high frame rate like 120Hz on ProMotion displays.
+	// With 2, rendering a frame might have to wait for the previous drawable and the frame rate becomes half.
+	if v.highFrameRate {
+		return 3
+	}
+
 	// Use 3 in fullscren.
 	// Though this might degrade FPS, this is necessary to avoid mysterious rendering delays.
 	if v.isFullscreen() {
@@ -20,6 +20,8 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"os"
+	"strings"
 	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
@@ -56,6 +58,10 @@ func convertBlendFactor(f graphicsdriver.BlendFactor) blendFactor {
 		return gl.ONE_MINUS_DST_ALPHA
 	case graphicsdriver.BlendFactorSourceAlphaSaturated:
 		return gl.SRC_ALPHA_SATURATE
+	case graphicsdriver.BlendFactorConstantColor:
+		return gl.CONSTANT_COLOR
+	case graphicsdriver.BlendFactorOneMinusConstantColor:
+		return gl.ONE_MINUS_CONSTANT_COLOR
 	default:
 		panic(fmt.Sprintf("opengl: invalid blend factor %d", f))
 	}
@@ -78,6 +84,21 @@ func convertBlendOperation(o graphicsdriver.BlendOperation) blendOperation {
 	}
 }
 
+func convertLogicOperation(o graphicsdriver.LogicOperation) uint32 {
+	switch o {
+	case graphicsdriver.LogicOperationAnd:
+		return gl.AND
+	case graphicsdriver.LogicOperationOr:
+		return gl.OR
+	case graphicsdriver.LogicOperationXor:
+		return gl.XOR
+	case graphicsdriver.LogicOperationInvert:
+		return gl.INVERT
+	default:
+		panic(fmt.Sprintf("opengl: invalid logic operation %d", o))
+	}
+}
+
 type (
 	textureNative      uint32
 	renderbufferNative uint32
@@ -113,6 +134,10 @@ type context struct {
 	highp              bool
 	highpOnce          sync.Once
 	initOnce           sync.Once
+
+	// gles2 reports whether the context is OpenGL ES 2.0.
+	// OpenGL ES 2.0 is a reduced-feature fallback for old devices without vertex array objects and GLSL ES 3.00.
+	gles2 bool
 }
 
 func (c *context) bindTexture(t textureNative) {
@@ -177,6 +202,16 @@ func (c *context) getMaxTextureSize() int {
 	return c.maxTextureSize
 }
 
+// isDebugOutputRequested reports whether the option "debug" is specified for the environment variable EBITENGINE_OPENGL.
+func isDebugOutputRequested() bool {
+	for _, t := range strings.Split(os.Getenv("EBITENGINE_OPENGL"), ",") {
+		if strings.TrimSpace(t) == "debug" {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *context) reset() error {
 	var err1 error
 	c.initOnce.Do(func() {
@@ -185,6 +220,18 @@ func (c *context) reset() error {
 			err1 = err
 			return
 		}
+		c.gles2 = c.ctx.IsES() && strings.HasPrefix(c.ctx.GetString(gl.VERSION), "OpenGL ES 2.")
+		if c.gles2 {
+			if err := c.checkGLES2Extensions(); err != nil {
+				err1 = err
+				return
+			}
+		}
+		if isDebugOutputRequested() {
+			if !c.ctx.EnableDebugOutput() {
+				fmt.Fprintln(os.Stderr, "opengl: debug output is not available")
+			}
+		}
 	})
 	if err1 != nil {
 		return err1
@@ -205,10 +252,43 @@ func (c *context) reset() error {
 	return nil
 }
 
+// gles2RequiredExtensions is the extensions required to run with OpenGL ES 2.0.
+// The indices are always 32-bit integers, and the min/max blend operations are used as they are in OpenGL ES 3.0.
+var gles2RequiredExtensions = []string{
+	"GL_OES_element_index_uint",
+	"GL_EXT_blend_minmax",
+}
+
+func (c *context) checkGLES2Extensions() error {
+	// OpenGL ES 2.0 doesn't have glGetStringi. The extensions are a space-separated list.
+	exts := map[string]struct{}{}
+	for _, e := range strings.Fields(c.ctx.GetString(gl.EXTENSIONS)) {
+		exts[e] = struct{}{}
+	}
+	for _, e := range gles2RequiredExtensions {
+		if _, ok := exts[e]; !ok {
+			return fmt.Errorf("opengl: OpenGL ES 2.0 without the extension %s is not supported", e)
+		}
+	}
+	return nil
+}
+
 func (c *context) blend(blend graphicsdriver.Blend) {
 	if c.lastBlend == blend {
 		return
 	}
+	if c.lastBlend.BlendColor != blend.BlendColor {
+		c.ctx.BlendColor(blend.BlendColor[0], blend.BlendColor[1], blend.BlendColor[2], blend.BlendColor[3])
+	}
+	// Logic operations are not available on OpenGL ES. In this case, LogicOperation is ignored.
+	if c.lastBlend.LogicOperation != blend.LogicOperation && !c.ctx.IsES() {
+		if blend.LogicOperation == graphicsdriver.LogicOperationNone {
+			c.ctx.Disable(gl.COLOR_LOGIC_OP)
+		} else {
+			c.ctx.Enable(gl.COLOR_LOGIC_OP)
+			c.ctx.LogicOp(convertLogicOperation(blend.LogicOperation))
+		}
+	}
 	c.lastBlend = blend
 	c.ctx.BlendFuncSeparate(
 		uint32(convertBlendFactor(blend.BlendFactorSourceRGB)),
@@ -491,6 +571,9 @@ func (c *context) newElementArrayBuffer(size int) buffer {
 }
 
 func (c *context) glslVersion() glsl.GLSLVersion {
+	if c.gles2 {
+		return glsl.GLSLVersionES100
+	}
 	if c.ctx.IsES() {
 		return glsl.GLSLVersionES300
 	}
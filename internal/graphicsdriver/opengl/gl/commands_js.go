@@ -0,0 +1,303 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gl
+
+import (
+	"math"
+	"syscall/js"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/jsutil"
+)
+
+const (
+	commandActiveTexture = iota
+	commandBindBuffer
+	commandBindFramebuffer
+	commandBindTexture
+	commandBindVertexArray
+	commandBlendColor
+	commandBlendEquationSeparate
+	commandBlendFuncSeparate
+	commandColorMask
+	commandDisable
+	commandDisableVertexAttribArray
+	commandDrawElements
+	commandEnable
+	commandEnableVertexAttribArray
+	commandScissor
+	commandStencilFunc
+	commandStencilOpSeparate
+	commandUniform1fv
+	commandUniform1i
+	commandUniform1iv
+	commandUniform2fv
+	commandUniform2iv
+	commandUniform3fv
+	commandUniform3iv
+	commandUniform4fv
+	commandUniform4iv
+	commandUniformMatrix2fv
+	commandUniformMatrix3fv
+	commandUniformMatrix4fv
+	commandUseProgram
+	commandVertexAttribPointer
+	commandViewport
+)
+
+type objectKind int
+
+const (
+	objectKindBuffer objectKind = iota
+	objectKindFramebuffer
+	objectKindProgram
+	objectKindTexture
+	objectKindVertexArray
+	objectKindUniformLocation
+)
+
+// commandBufferSource is the JavaScript function body to create a command buffer interpreter.
+// The case numbers must match the command constants above.
+//
+// Object IDs are resolved to WebGL objects on the JavaScript side so that the Go side doesn't have to pass js.Values.
+const commandBufferSource = `"use strict";
+const objects = [new Map(), new Map(), new Map(), new Map(), new Map(), new Map()];
+const [buffers, framebuffers, programs, textures, vertexArrays, uniformLocations] = objects;
+return {
+	set: (kind, id, value) => { objects[kind].set(id, value); },
+	delete: (kind, id) => { objects[kind].delete(id); },
+	execute: (i, f, n) => {
+		let p = 0;
+		while (p < n) {
+			switch (i[p]) {
+			case 0:
+				gl.activeTexture(i[p+1]);
+				p += 2;
+				break;
+			case 1:
+				gl.bindBuffer(i[p+1], buffers.get(i[p+2]));
+				p += 3;
+				break;
+			case 2:
+				gl.bindFramebuffer(i[p+1], framebuffers.get(i[p+2]));
+				p += 3;
+				break;
+			case 3:
+				gl.bindTexture(i[p+1], textures.get(i[p+2]));
+				p += 3;
+				break;
+			case 4:
+				gl.bindVertexArray(vertexArrays.get(i[p+1]));
+				p += 2;
+				break;
+			case 5:
+				gl.blendColor(f[p+1], f[p+2], f[p+3], f[p+4]);
+				p += 5;
+				break;
+			case 6:
+				gl.blendEquationSeparate(i[p+1], i[p+2]);
+				p += 3;
+				break;
+			case 7:
+				gl.blendFuncSeparate(i[p+1], i[p+2], i[p+3], i[p+4]);
+				p += 5;
+				break;
+			case 8:
+				gl.colorMask(i[p+1] !== 0, i[p+2] !== 0, i[p+3] !== 0, i[p+4] !== 0);
+				p += 5;
+				break;
+			case 9:
+				gl.disable(i[p+1]);
+				p += 2;
+				break;
+			case 10:
+				gl.disableVertexAttribArray(i[p+1]);
+				p += 2;
+				break;
+			case 11:
+				gl.drawElements(i[p+1], i[p+2], i[p+3], i[p+4]);
+				p += 5;
+				break;
+			case 12:
+				gl.enable(i[p+1]);
+				p += 2;
+				break;
+			case 13:
+				gl.enableVertexAttribArray(i[p+1]);
+				p += 2;
+				break;
+			case 14:
+				gl.scissor(i[p+1], i[p+2], i[p+3], i[p+4]);
+				p += 5;
+				break;
+			case 15:
+				gl.stencilFunc(i[p+1], i[p+2], i[p+3]);
+				p += 4;
+				break;
+			case 16:
+				gl.stencilOpSeparate(i[p+1], i[p+2], i[p+3], i[p+4]);
+				p += 5;
+				break;
+			case 17:
+				gl.uniform1fv(uniformLocations.get(i[p+1]), f, p+3, i[p+2]);
+				p += 3 + i[p+2];
+				break;
+			case 18:
+				gl.uniform1i(uniformLocations.get(i[p+1]), i[p+2]);
+				p += 3;
+				break;
+			case 19:
+				gl.uniform1iv(uniformLocations.get(i[p+1]), i, p+3, i[p+2]);
+				p += 3 + i[p+2];
+				break;
+			case 20:
+				gl.uniform2fv(uniformLocations.get(i[p+1]), f, p+3, i[p+2]);
+				p += 3 + i[p+2];
+				break;
+			case 21:
+				gl.uniform2iv(uniformLocations.get(i[p+1]), i, p+3, i[p+2]);
+				p += 3 + i[p+2];
+				break;
+			case 22:
+				gl.uniform3fv(uniformLocations.get(i[p+1]), f, p+3, i[p+2]);
+				p += 3 + i[p+2];
+				break;
+			case 23:
+				gl.uniform3iv(uniformLocations.get(i[p+1]), i, p+3, i[p+2]);
+				p += 3 + i[p+2];
+				break;
+			case 24:
+				gl.uniform4fv(uniformLocations.get(i[p+1]), f, p+3, i[p+2]);
+				p += 3 + i[p+2];
+				break;
+			case 25:
+				gl.uniform4iv(uniformLocations.get(i[p+1]), i, p+3, i[p+2]);
+				p += 3 + i[p+2];
+				break;
+			case 26:
+				gl.uniformMatrix2fv(uniformLocations.get(i[p+1]), false, f, p+3, i[p+2]);
+				p += 3 + i[p+2];
+				break;
+			case 27:
+				gl.uniformMatrix3fv(uniformLocations.get(i[p+1]), false, f, p+3, i[p+2]);
+				p += 3 + i[p+2];
+				break;
+			case 28:
+				gl.uniformMatrix4fv(uniformLocations.get(i[p+1]), false, f, p+3, i[p+2]);
+				p += 3 + i[p+2];
+				break;
+			case 29:
+				gl.useProgram(programs.get(i[p+1]));
+				p += 2;
+				break;
+			case 30:
+				gl.vertexAttribPointer(i[p+1], i[p+2], i[p+3], i[p+4] !== 0, i[p+5], i[p+6]);
+				p += 7;
+				break;
+			case 31:
+				gl.viewport(i[p+1], i[p+2], i[p+3], i[p+4]);
+				p += 5;
+				break;
+			default:
+				throw new Error("gl: unexpected command: " + i[p]);
+			}
+		}
+	},
+};`
+
+// commandBuffer records WebGL calls that don't return values, and executes them at once on the JavaScript side.
+//
+// Crossing the boundary between Go and JavaScript is expensive, and each call with js.Value arguments allocates.
+// With commandBuffer, the boundary is crossed only once per flush, e.g., once per frame.
+//
+// All the arguments are encoded as int32 values. Float values are encoded as their bit representations.
+type commandBuffer struct {
+	words []int32
+
+	fnSet     js.Value
+	fnDelete  js.Value
+	fnExecute js.Value
+}
+
+// newCommandBuffer creates a new commandBuffer for the given WebGL context.
+// newCommandBuffer returns nil when the interpreter cannot be created,
+// e.g., when the Function constructor is prohibited by the Content Security Policy.
+func newCommandBuffer(gl js.Value) (cb *commandBuffer) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(js.Error); !ok {
+				panic(r)
+			}
+			cb = nil
+		}
+	}()
+
+	v := js.Global().Get("Function").New("gl", commandBufferSource).Invoke(gl)
+	return &commandBuffer{
+		fnSet:     v.Get("set"),
+		fnDelete:  v.Get("delete"),
+		fnExecute: v.Get("execute"),
+	}
+}
+
+func (c *commandBuffer) add(words ...int32) {
+	c.words = append(c.words, words...)
+}
+
+func (c *commandBuffer) addFloat32s(command int32, location int32, values []float32) {
+	c.words = append(c.words, command, location, int32(len(values)))
+	for _, v := range values {
+		c.words = append(c.words, int32(math.Float32bits(v)))
+	}
+}
+
+func (c *commandBuffer) addInt32s(command int32, location int32, values []int32) {
+	c.words = append(c.words, command, location, int32(len(values)))
+	c.words = append(c.words, values...)
+}
+
+func (c *commandBuffer) setObject(kind objectKind, id uint32, value js.Value) {
+	if c == nil {
+		return
+	}
+	c.fnSet.Invoke(int(kind), id, value)
+}
+
+func (c *commandBuffer) deleteObject(kind objectKind, id uint32) {
+	if c == nil {
+		return
+	}
+	c.fnDelete.Invoke(int(kind), id)
+}
+
+// flush executes all the recorded commands.
+func (c *commandBuffer) flush() {
+	if c == nil {
+		return
+	}
+	if len(c.words) == 0 {
+		return
+	}
+	i := jsutil.TemporaryInt32Array(len(c.words), c.words)
+	f := jsutil.TemporaryFloat32Array(len(c.words), nil)
+	c.fnExecute.Invoke(i, f, len(c.words))
+	c.words = c.words[:0]
+}
+
+func boolToInt32(v bool) int32 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -93,6 +93,14 @@ func (d *DebugContext) BindVertexArray(arg0 uint32) {
 	}
 }
 
+func (d *DebugContext) BlendColor(arg0 float32, arg1 float32, arg2 float32, arg3 float32) {
+	d.Context.BlendColor(arg0, arg1, arg2, arg3)
+	fmt.Fprintln(os.Stderr, "BlendColor")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at BlendColor", e))
+	}
+}
+
 func (d *DebugContext) BlendEquationSeparate(arg0 uint32, arg1 uint32) {
 	d.Context.BlendEquationSeparate(arg0, arg1)
 	fmt.Fprintln(os.Stderr, "BlendEquationSeparate")
@@ -309,6 +317,15 @@ func (d *DebugContext) Enable(arg0 uint32) {
 	}
 }
 
+func (d *DebugContext) EnableDebugOutput() bool {
+	out0 := d.Context.EnableDebugOutput()
+	fmt.Fprintln(os.Stderr, "EnableDebugOutput")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at EnableDebugOutput", e))
+	}
+	return out0
+}
+
 func (d *DebugContext) EnableVertexAttribArray(arg0 uint32) {
 	d.Context.EnableVertexAttribArray(arg0)
 	fmt.Fprintln(os.Stderr, "EnableVertexAttribArray")
@@ -392,6 +409,15 @@ func (d *DebugContext) GetShaderi(arg0 uint32, arg1 uint32) int {
 	return out0
 }
 
+func (d *DebugContext) GetString(arg0 uint32) string {
+	out0 := d.Context.GetString(arg0)
+	fmt.Fprintln(os.Stderr, "GetString")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at GetString", e))
+	}
+	return out0
+}
+
 func (d *DebugContext) GetUniformLocation(arg0 uint32, arg1 string) int32 {
 	out0 := d.Context.GetUniformLocation(arg0, arg1)
 	fmt.Fprintln(os.Stderr, "GetUniformLocation")
@@ -446,6 +472,14 @@ func (d *DebugContext) LoadFunctions() error {
 	return out0
 }
 
+func (d *DebugContext) LogicOp(arg0 uint32) {
+	d.Context.LogicOp(arg0)
+	fmt.Fprintln(os.Stderr, "LogicOp")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at LogicOp", e))
+	}
+}
+
 func (d *DebugContext) PixelStorei(arg0 uint32, arg1 int32) {
 	d.Context.PixelStorei(arg0, arg1)
 	fmt.Fprintln(os.Stderr, "PixelStorei")
@@ -0,0 +1,78 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !js && !playstation5
+
+package gl
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	debugSeverityHigh         = 0x9146
+	debugSeverityMedium       = 0x9147
+	debugSeverityLow          = 0x9148
+	debugSeverityNotification = 0x826B
+)
+
+// logDebugMessage is called as a callback of glDebugMessageCallback.
+// The messages are printed to the standard error, where the other debug outputs of Ebitengine are printed.
+func logDebugMessage(source, xtype, id, severity uint32, message string) {
+	// Notifications are too verbose e.g. on NVIDIA drivers.
+	if severity == debugSeverityNotification {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "gl: debug message (source: 0x%04x, type: 0x%04x, id: %d, severity: %s): %s\n", source, xtype, id, debugSeverityString(severity), message)
+}
+
+func debugSeverityString(severity uint32) string {
+	switch severity {
+	case debugSeverityHigh:
+		return "high"
+	case debugSeverityMedium:
+		return "medium"
+	case debugSeverityLow:
+		return "low"
+	case debugSeverityNotification:
+		return "notification"
+	default:
+		return fmt.Sprintf("0x%04x", severity)
+	}
+}
+
+// isDebugOutputAvailable reports whether glDebugMessageCallback is available.
+// glDebugMessageCallback is available as of OpenGL 4.3 and OpenGL ES 3.2, or with the extension GL_KHR_debug.
+//
+// Checking the existence of the function pointer is not enough, as glXGetProcAddress can return a non-nil value
+// even for an unsupported function.
+func (c *defaultContext) isDebugOutputAvailable() bool {
+	major, minor := c.GetInteger(MAJOR_VERSION), c.GetInteger(MINOR_VERSION)
+	if c.IsES() {
+		if major > 3 || (major == 3 && minor >= 2) {
+			return true
+		}
+	} else {
+		if major > 4 || (major == 4 && minor >= 3) {
+			return true
+		}
+	}
+	for i := 0; i < c.GetInteger(NUM_EXTENSIONS); i++ {
+		if c.getStringi(EXTENSIONS, uint32(i)) == "GL_KHR_debug" {
+			return true
+		}
+	}
+	return false
+}
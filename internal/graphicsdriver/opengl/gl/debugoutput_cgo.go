@@ -0,0 +1,38 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build nintendosdk || ebitenginecustomplatform
+
+package gl
+
+// typedef unsigned int GLenum;
+// typedef unsigned int GLuint;
+// typedef int GLsizei;
+// typedef char GLchar;
+import "C"
+
+import (
+	"unsafe"
+)
+
+//export ebitengine_glDebugMessageCallback
+func ebitengine_glDebugMessageCallback(source C.GLenum, xtype C.GLenum, id C.GLuint, severity C.GLenum, length C.GLsizei, message *C.GLchar, userParam unsafe.Pointer) {
+	var msg string
+	if length >= 0 {
+		msg = C.GoStringN((*C.char)(unsafe.Pointer(message)), C.int(length))
+	} else {
+		msg = C.GoString((*C.char)(unsafe.Pointer(message)))
+	}
+	logDebugMessage(uint32(source), uint32(xtype), uint32(id), uint32(severity), msg)
+}
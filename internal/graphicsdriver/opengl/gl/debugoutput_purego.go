@@ -0,0 +1,49 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build (darwin || freebsd || (linux && (amd64 || arm64)) || windows) && !nintendosdk && !playstation5 && !ebitenginecustomplatform
+
+package gl
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+)
+
+var (
+	theDebugMessageCallback     uintptr
+	theDebugMessageCallbackOnce sync.Once
+)
+
+// debugMessageCallback returns a function pointer for glDebugMessageCallback.
+func debugMessageCallback() uintptr {
+	theDebugMessageCallbackOnce.Do(func() {
+		theDebugMessageCallback = purego.NewCallback(func(source, xtype, id, severity, length uintptr, message *byte, userParam uintptr) uintptr {
+			l := int(int32(length))
+			if l < 0 {
+				// The message is null-terminated.
+				l = 0
+				for *(*byte)(unsafe.Add(unsafe.Pointer(message), l)) != 0 {
+					l++
+				}
+			}
+			msg := string(unsafe.Slice(message, l))
+			logDebugMessage(uint32(source), uint32(xtype), uint32(id), uint32(severity), msg)
+			return 0
+		})
+	})
+	return theDebugMessageCallback
+}
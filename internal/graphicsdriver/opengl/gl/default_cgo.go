@@ -2,7 +2,7 @@
 // SPDX-FileCopyrightText: 2014 Eric Woroshow
 // SPDX-FileCopyrightText: 2022 The Ebitengine Authors
 
-//go:build nintendosdk
+//go:build nintendosdk || ebitenginecustomplatform
 
 package gl
 
@@ -19,6 +19,9 @@ package gl
 // typedef char GLchar;
 // typedef ptrdiff_t GLintptr;
 // typedef ptrdiff_t GLsizeiptr;
+// typedef unsigned char GLubyte;
+//
+// extern void ebitengine_glDebugMessageCallback(GLenum source, GLenum type, GLuint id, GLenum severity, GLsizei length, const GLchar* message, const void* userParam);
 //
 // static void glowActiveTexture(uintptr_t fnptr, GLenum texture) {
 //   typedef void (*fn)(GLenum texture);
@@ -52,6 +55,10 @@ package gl
 //   typedef void (*fn)(GLuint array);
 //   ((fn)(fnptr))(array);
 // }
+// static void glowBlendColor(uintptr_t fnptr, GLfloat red, GLfloat green, GLfloat blue, GLfloat alpha) {
+//   typedef void (*fn)(GLfloat red, GLfloat green, GLfloat blue, GLfloat alpha);
+//   ((fn)(fnptr))(red, green, blue, alpha);
+// }
 // static void glowBlendEquationSeparate(uintptr_t fnptr, GLenum modeRGB, GLenum modeAlpha) {
 //   typedef void (*fn)(GLenum modeRGB, GLenum modeAlpha);
 //   ((fn)(fnptr))(modeRGB, modeAlpha);
@@ -92,6 +99,11 @@ package gl
 //   typedef GLuint (*fn)(GLenum type);
 //   return ((fn)(fnptr))(type);
 // }
+// static void glowDebugMessageCallback(uintptr_t fnptr) {
+//   typedef void (*callback)(GLenum source, GLenum type, GLuint id, GLenum severity, GLsizei length, const GLchar* message, const void* userParam);
+//   typedef void (*fn)(callback callback, const void* userParam);
+//   ((fn)(fnptr))(ebitengine_glDebugMessageCallback, NULL);
+// }
 // static void glowDeleteBuffers(uintptr_t fnptr, GLsizei n, const GLuint* buffers) {
 //   typedef void (*fn)(GLsizei n, const GLuint* buffers);
 //   ((fn)(fnptr))(n, buffers);
@@ -196,6 +208,14 @@ package gl
 //   typedef void (*fn)(GLuint shader, GLenum pname, GLint* params);
 //   ((fn)(fnptr))(shader, pname, params);
 // }
+// static const GLubyte* glowGetString(uintptr_t fnptr, GLenum name) {
+//   typedef const GLubyte* (*fn)(GLenum name);
+//   return ((fn)(fnptr))(name);
+// }
+// static const GLubyte* glowGetStringi(uintptr_t fnptr, GLenum name, GLuint index) {
+//   typedef const GLubyte* (*fn)(GLenum name, GLuint index);
+//   return ((fn)(fnptr))(name, index);
+// }
 // static GLint glowGetUniformLocation(uintptr_t fnptr, GLuint program, const GLchar* name) {
 //   typedef GLint (*fn)(GLuint program, const GLchar* name);
 //   return ((fn)(fnptr))(program, name);
@@ -216,6 +236,10 @@ package gl
 //   typedef void (*fn)(GLuint program);
 //   ((fn)(fnptr))(program);
 // }
+// static void glowLogicOp(uintptr_t fnptr, GLenum opcode) {
+//   typedef void (*fn)(GLenum opcode);
+//   ((fn)(fnptr))(opcode);
+// }
 // static void glowPixelStorei(uintptr_t fnptr, GLenum pname, GLint param) {
 //   typedef void (*fn)(GLenum pname, GLint param);
 //   ((fn)(fnptr))(pname, param);
@@ -332,6 +356,7 @@ type defaultContext struct {
 	gpBindRenderbuffer         C.uintptr_t
 	gpBindTexture              C.uintptr_t
 	gpBindVertexArray          C.uintptr_t
+	gpBlendColor               C.uintptr_t
 	gpBlendEquationSeparate    C.uintptr_t
 	gpBlendFuncSeparate        C.uintptr_t
 	gpBufferData               C.uintptr_t
@@ -342,6 +367,7 @@ type defaultContext struct {
 	gpCompileShader            C.uintptr_t
 	gpCreateProgram            C.uintptr_t
 	gpCreateShader             C.uintptr_t
+	gpDebugMessageCallback     C.uintptr_t
 	gpDeleteBuffers            C.uintptr_t
 	gpDeleteFramebuffers       C.uintptr_t
 	gpDeleteProgram            C.uintptr_t
@@ -368,11 +394,14 @@ type defaultContext struct {
 	gpGetProgramiv             C.uintptr_t
 	gpGetShaderInfoLog         C.uintptr_t
 	gpGetShaderiv              C.uintptr_t
+	gpGetString                C.uintptr_t
+	gpGetStringi               C.uintptr_t
 	gpGetUniformLocation       C.uintptr_t
 	gpIsFramebuffer            C.uintptr_t
 	gpIsProgram                C.uintptr_t
 	gpIsRenderbuffer           C.uintptr_t
 	gpLinkProgram              C.uintptr_t
+	gpLogicOp                  C.uintptr_t
 	gpPixelStorei              C.uintptr_t
 	gpReadPixels               C.uintptr_t
 	gpRenderbufferStorage      C.uintptr_t
@@ -455,6 +484,10 @@ func (c *defaultContext) BindVertexArray(array uint32) {
 	C.glowBindVertexArray(c.gpBindVertexArray, C.GLuint(array))
 }
 
+func (c *defaultContext) BlendColor(red float32, green float32, blue float32, alpha float32) {
+	C.glowBlendColor(c.gpBlendColor, C.GLfloat(red), C.GLfloat(green), C.GLfloat(blue), C.GLfloat(alpha))
+}
+
 func (c *defaultContext) BlendEquationSeparate(modeRGB uint32, modeAlpha uint32) {
 	C.glowBlendEquationSeparate(c.gpBlendEquationSeparate, C.GLenum(modeRGB), C.GLenum(modeAlpha))
 }
@@ -573,6 +606,19 @@ func (c *defaultContext) Enable(cap uint32) {
 	C.glowEnable(c.gpEnable, C.GLenum(cap))
 }
 
+func (c *defaultContext) EnableDebugOutput() bool {
+	if c.gpDebugMessageCallback == 0 || c.gpGetStringi == 0 {
+		return false
+	}
+	if !c.isDebugOutputAvailable() {
+		return false
+	}
+	C.glowDebugMessageCallback(c.gpDebugMessageCallback)
+	c.Enable(DEBUG_OUTPUT)
+	c.Enable(DEBUG_OUTPUT_SYNCHRONOUS)
+	return true
+}
+
 func (c *defaultContext) EnableVertexAttribArray(index uint32) {
 	C.glowEnableVertexAttribArray(c.gpEnableVertexAttribArray, C.GLuint(index))
 }
@@ -626,6 +672,14 @@ func (c *defaultContext) GetShaderi(shader uint32, pname uint32) int {
 	return int(dst)
 }
 
+func (c *defaultContext) GetString(pname uint32) string {
+	return C.GoString((*C.char)(unsafe.Pointer(C.glowGetString(c.gpGetString, C.GLenum(pname)))))
+}
+
+func (c *defaultContext) getStringi(name uint32, index uint32) string {
+	return C.GoString((*C.char)(unsafe.Pointer(C.glowGetStringi(c.gpGetStringi, C.GLenum(name), C.GLuint(index)))))
+}
+
 func (c *defaultContext) GetUniformLocation(program uint32, name string) int32 {
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
@@ -652,6 +706,10 @@ func (c *defaultContext) LinkProgram(program uint32) {
 	C.glowLinkProgram(c.gpLinkProgram, C.GLuint(program))
 }
 
+func (c *defaultContext) LogicOp(opcode uint32) {
+	C.glowLogicOp(c.gpLogicOp, C.GLenum(opcode))
+}
+
 func (c *defaultContext) PixelStorei(pname uint32, param int32) {
 	C.glowPixelStorei(c.gpPixelStorei, C.GLenum(pname), C.GLint(param))
 }
@@ -782,6 +840,7 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpBindRenderbuffer = C.uintptr_t(g.get("glBindRenderbuffer"))
 	c.gpBindTexture = C.uintptr_t(g.get("glBindTexture"))
 	c.gpBindVertexArray = C.uintptr_t(g.get("glBindVertexArray"))
+	c.gpBlendColor = C.uintptr_t(g.get("glBlendColor"))
 	c.gpBlendEquationSeparate = C.uintptr_t(g.get("glBlendEquationSeparate"))
 	c.gpBlendFuncSeparate = C.uintptr_t(g.get("glBlendFuncSeparate"))
 	c.gpBufferData = C.uintptr_t(g.get("glBufferData"))
@@ -818,11 +877,16 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpGetProgramiv = C.uintptr_t(g.get("glGetProgramiv"))
 	c.gpGetShaderInfoLog = C.uintptr_t(g.get("glGetShaderInfoLog"))
 	c.gpGetShaderiv = C.uintptr_t(g.get("glGetShaderiv"))
+	c.gpGetString = C.uintptr_t(g.get("glGetString"))
 	c.gpGetUniformLocation = C.uintptr_t(g.get("glGetUniformLocation"))
 	c.gpIsFramebuffer = C.uintptr_t(g.get("glIsFramebuffer"))
 	c.gpIsProgram = C.uintptr_t(g.get("glIsProgram"))
 	c.gpIsRenderbuffer = C.uintptr_t(g.get("glIsRenderbuffer"))
 	c.gpLinkProgram = C.uintptr_t(g.get("glLinkProgram"))
+	if !c.isES {
+		// glLogicOp is not available on OpenGL ES.
+		c.gpLogicOp = C.uintptr_t(g.get("glLogicOp"))
+	}
 	c.gpPixelStorei = C.uintptr_t(g.get("glPixelStorei"))
 	c.gpReadPixels = C.uintptr_t(g.get("glReadPixels"))
 	c.gpRenderbufferStorage = C.uintptr_t(g.get("glRenderbufferStorage"))
@@ -849,5 +913,18 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpVertexAttribPointer = C.uintptr_t(g.get("glVertexAttribPointer"))
 	c.gpViewport = C.uintptr_t(g.get("glViewport"))
 
-	return g.error()
+	if err := g.error(); err != nil {
+		return err
+	}
+
+	// These functions are optional.
+	debugMessageCallback, _ := c.getProcAddress("glDebugMessageCallback")
+	if debugMessageCallback == 0 && c.isES {
+		debugMessageCallback, _ = c.getProcAddress("glDebugMessageCallbackKHR")
+	}
+	c.gpDebugMessageCallback = C.uintptr_t(debugMessageCallback)
+	getStringi, _ := c.getProcAddress("glGetStringi")
+	c.gpGetStringi = C.uintptr_t(getStringi)
+
+	return nil
 }
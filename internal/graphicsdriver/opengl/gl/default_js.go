@@ -16,6 +16,7 @@ package gl
 
 import (
 	"fmt"
+	"math"
 	"syscall/js"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/jsutil"
@@ -30,6 +31,7 @@ type defaultContext struct {
 	fnBindRenderbuffer         js.Value
 	fnBindTexture              js.Value
 	fnBindVertexArray          js.Value
+	fnBlendColor               js.Value
 	fnBlendEquationSeparate    js.Value
 	fnBlendFuncSeparate        js.Value
 	fnBufferData               js.Value
@@ -106,6 +108,8 @@ type defaultContext struct {
 	textures         values
 	vertexArrays     values
 	uniformLocations map[uint32]*values
+
+	commands *commandBuffer
 }
 
 type values struct {
@@ -136,14 +140,6 @@ func (v *values) getID(value js.Value) (uint32, bool) {
 	return 0, false
 }
 
-func (v *values) getOrCreate(value js.Value) uint32 {
-	id, ok := v.getID(value)
-	if ok {
-		return id
-	}
-	return v.create(value)
-}
-
 func (v *values) delete(id uint32) {
 	delete(v.idToValue, id)
 }
@@ -160,6 +156,7 @@ func NewDefaultContext(v js.Value) (Context, error) {
 		fnBindRenderbuffer:         v.Get("bindRenderbuffer").Call("bind", v),
 		fnBindTexture:              v.Get("bindTexture").Call("bind", v),
 		fnBindVertexArray:          v.Get("bindVertexArray").Call("bind", v),
+		fnBlendColor:               v.Get("blendColor").Call("bind", v),
 		fnBlendEquationSeparate:    v.Get("blendEquationSeparate").Call("bind", v),
 		fnBlendFuncSeparate:        v.Get("blendFuncSeparate").Call("bind", v),
 		fnBufferData:               v.Get("bufferData").Call("bind", v),
@@ -227,6 +224,7 @@ func NewDefaultContext(v js.Value) (Context, error) {
 		fnUseProgram:               v.Get("useProgram").Call("bind", v),
 		fnVertexAttribPointer:      v.Get("vertexAttribPointer").Call("bind", v),
 		fnViewport:                 v.Get("viewport").Call("bind", v),
+		commands:                   newCommandBuffer(v),
 	}
 
 	return g, nil
@@ -247,172 +245,289 @@ func (c *defaultContext) IsES() bool {
 }
 
 func (c *defaultContext) ActiveTexture(texture uint32) {
+	if c.commands != nil {
+		c.commands.add(commandActiveTexture, int32(texture))
+		return
+	}
 	c.fnActiveTexture.Invoke(texture)
 }
 
 func (c *defaultContext) AttachShader(program uint32, shader uint32) {
+	c.commands.flush()
 	c.fnAttachShader.Invoke(c.programs.get(program), c.shaders.get(shader))
 }
 
 func (c *defaultContext) BindAttribLocation(program uint32, index uint32, name string) {
+	c.commands.flush()
 	c.fnBindAttribLocation.Invoke(c.programs.get(program), index, name)
 }
 
 func (c *defaultContext) BindBuffer(target uint32, buffer uint32) {
+	if c.commands != nil {
+		c.commands.add(commandBindBuffer, int32(target), int32(buffer))
+		return
+	}
 	c.fnBindBuffer.Invoke(target, c.buffers.get(buffer))
 }
 
 func (c *defaultContext) BindFramebuffer(target uint32, framebuffer uint32) {
+	if c.commands != nil {
+		c.commands.add(commandBindFramebuffer, int32(target), int32(framebuffer))
+		return
+	}
 	c.fnBindFramebuffer.Invoke(target, c.framebuffers.get(framebuffer))
 }
 
 func (c *defaultContext) BindRenderbuffer(target uint32, renderbuffer uint32) {
+	c.commands.flush()
 	c.fnBindRenderbuffer.Invoke(target, c.renderbuffers.get(renderbuffer))
 }
 
 func (c *defaultContext) BindTexture(target uint32, texture uint32) {
+	if c.commands != nil {
+		c.commands.add(commandBindTexture, int32(target), int32(texture))
+		return
+	}
 	c.fnBindTexture.Invoke(target, c.textures.get(texture))
 }
 
 func (c *defaultContext) BindVertexArray(array uint32) {
+	if c.commands != nil {
+		c.commands.add(commandBindVertexArray, int32(array))
+		return
+	}
 	c.fnBindVertexArray.Invoke(c.vertexArrays.get(array))
 }
 
+func (c *defaultContext) BlendColor(red float32, green float32, blue float32, alpha float32) {
+	if c.commands != nil {
+		c.commands.add(commandBlendColor, int32(math.Float32bits(red)), int32(math.Float32bits(green)), int32(math.Float32bits(blue)), int32(math.Float32bits(alpha)))
+		return
+	}
+	c.fnBlendColor.Invoke(red, green, blue, alpha)
+}
+
 func (c *defaultContext) BlendEquationSeparate(modeRGB uint32, modeAlpha uint32) {
+	if c.commands != nil {
+		c.commands.add(commandBlendEquationSeparate, int32(modeRGB), int32(modeAlpha))
+		return
+	}
 	c.fnBlendEquationSeparate.Invoke(modeRGB, modeAlpha)
 }
 
 func (c *defaultContext) BlendFuncSeparate(srcRGB uint32, dstRGB uint32, srcAlpha uint32, dstAlpha uint32) {
+	if c.commands != nil {
+		c.commands.add(commandBlendFuncSeparate, int32(srcRGB), int32(dstRGB), int32(srcAlpha), int32(dstAlpha))
+		return
+	}
 	c.fnBlendFuncSeparate.Invoke(srcRGB, dstRGB, srcAlpha, dstAlpha)
 }
 
 func (c *defaultContext) BufferInit(target uint32, size int, usage uint32) {
+	c.commands.flush()
 	c.fnBufferData.Invoke(target, size, usage)
 }
 
 func (c *defaultContext) BufferSubData(target uint32, offset int, data []byte) {
+	c.commands.flush()
 	l := len(data)
 	arr := jsutil.TemporaryUint8ArrayFromUint8Slice(l, data)
 	c.fnBufferSubData.Invoke(target, offset, arr, 0, l)
 }
 
 func (c *defaultContext) CheckFramebufferStatus(target uint32) uint32 {
+	c.commands.flush()
 	return uint32(c.fnCheckFramebufferStatus.Invoke(target).Int())
 }
 
 func (c *defaultContext) Clear(mask uint32) {
+	c.commands.flush()
 	c.fnClear.Invoke(mask)
 }
 
 func (c *defaultContext) ColorMask(red, green, blue, alpha bool) {
+	if c.commands != nil {
+		c.commands.add(commandColorMask, boolToInt32(red), boolToInt32(green), boolToInt32(blue), boolToInt32(alpha))
+		return
+	}
 	c.fnColorMask.Invoke(red, green, blue, alpha)
 }
 
 func (c *defaultContext) CompileShader(shader uint32) {
+	c.commands.flush()
 	c.fnCompileShader.Invoke(c.shaders.get(shader))
 }
 
 func (c *defaultContext) CreateBuffer() uint32 {
-	return c.buffers.create(c.fnCreateBuffer.Invoke())
+	c.commands.flush()
+	v := c.fnCreateBuffer.Invoke()
+	id := c.buffers.create(v)
+	c.commands.setObject(objectKindBuffer, id, v)
+	return id
 }
 
 func (c *defaultContext) CreateFramebuffer() uint32 {
-	return c.framebuffers.create(c.fnCreateFramebuffer.Invoke())
+	c.commands.flush()
+	v := c.fnCreateFramebuffer.Invoke()
+	id := c.framebuffers.create(v)
+	c.commands.setObject(objectKindFramebuffer, id, v)
+	return id
 }
 
 func (c *defaultContext) CreateProgram() uint32 {
-	return c.programs.create(c.fnCreateProgram.Invoke())
+	c.commands.flush()
+	v := c.fnCreateProgram.Invoke()
+	id := c.programs.create(v)
+	c.commands.setObject(objectKindProgram, id, v)
+	return id
 }
 
 func (c *defaultContext) CreateRenderbuffer() uint32 {
+	c.commands.flush()
 	return c.renderbuffers.create(c.fnCreateRenderbuffer.Invoke())
 }
 
 func (c *defaultContext) CreateShader(xtype uint32) uint32 {
+	c.commands.flush()
 	return c.shaders.create(c.fnCreateShader.Invoke(xtype))
 }
 
 func (c *defaultContext) CreateTexture() uint32 {
-	return c.textures.create(c.fnCreateTexture.Invoke())
+	c.commands.flush()
+	v := c.fnCreateTexture.Invoke()
+	id := c.textures.create(v)
+	c.commands.setObject(objectKindTexture, id, v)
+	return id
 }
 
 func (c *defaultContext) CreateVertexArray() uint32 {
-	return c.vertexArrays.create(c.fnCreateVertexArray.Invoke())
+	c.commands.flush()
+	v := c.fnCreateVertexArray.Invoke()
+	id := c.vertexArrays.create(v)
+	c.commands.setObject(objectKindVertexArray, id, v)
+	return id
 }
 
 func (c *defaultContext) DeleteBuffer(buffer uint32) {
+	c.commands.flush()
 	c.fnDeleteBuffer.Invoke(c.buffers.get(buffer))
 	c.buffers.delete(buffer)
+	c.commands.deleteObject(objectKindBuffer, buffer)
 }
 
 func (c *defaultContext) DeleteFramebuffer(framebuffer uint32) {
+	c.commands.flush()
 	c.fnDeleteFramebuffer.Invoke(c.framebuffers.get(framebuffer))
 	c.framebuffers.delete(framebuffer)
+	c.commands.deleteObject(objectKindFramebuffer, framebuffer)
 }
 
 func (c *defaultContext) DeleteProgram(program uint32) {
+	c.commands.flush()
 	c.fnDeleteProgram.Invoke(c.programs.get(program))
 	c.programs.delete(program)
+	c.commands.deleteObject(objectKindProgram, program)
+	if vs, ok := c.uniformLocations[program]; ok {
+		for idx := range vs.idToValue {
+			c.commands.deleteObject(objectKindUniformLocation, (program<<5)|idx)
+		}
+	}
 	delete(c.uniformLocations, program)
 }
 
 func (c *defaultContext) DeleteRenderbuffer(renderbuffer uint32) {
+	c.commands.flush()
 	c.fnDeleteRenderbuffer.Invoke(c.renderbuffers.get(renderbuffer))
 	c.renderbuffers.delete(renderbuffer)
 }
 
 func (c *defaultContext) DeleteShader(shader uint32) {
+	c.commands.flush()
 	c.fnDeleteShader.Invoke(c.shaders.get(shader))
 	c.shaders.delete(shader)
 }
 
 func (c *defaultContext) DeleteTexture(texture uint32) {
+	c.commands.flush()
 	c.fnDeleteTexture.Invoke(c.textures.get(texture))
 	c.textures.delete(texture)
+	c.commands.deleteObject(objectKindTexture, texture)
 }
 
 func (c *defaultContext) DeleteVertexArray(array uint32) {
+	c.commands.flush()
 	c.fnDeleteVertexArray.Invoke(c.vertexArrays.get(array))
-	c.textures.delete(array)
+	c.vertexArrays.delete(array)
+	c.commands.deleteObject(objectKindVertexArray, array)
 }
 
 func (c *defaultContext) Disable(cap uint32) {
+	if c.commands != nil {
+		c.commands.add(commandDisable, int32(cap))
+		return
+	}
 	c.fnDisable.Invoke(cap)
 }
 
 func (c *defaultContext) DisableVertexAttribArray(index uint32) {
+	if c.commands != nil {
+		c.commands.add(commandDisableVertexAttribArray, int32(index))
+		return
+	}
 	c.fnDisableVertexAttribArray.Invoke(index)
 }
 
 func (c *defaultContext) DrawElements(mode uint32, count int32, xtype uint32, offset int) {
+	if c.commands != nil {
+		c.commands.add(commandDrawElements, int32(mode), count, int32(xtype), int32(offset))
+		return
+	}
 	c.fnDrawElements.Invoke(mode, count, xtype, offset)
 }
 
 func (c *defaultContext) Enable(cap uint32) {
+	if c.commands != nil {
+		c.commands.add(commandEnable, int32(cap))
+		return
+	}
 	c.fnEnable.Invoke(cap)
 }
 
+func (c *defaultContext) EnableDebugOutput() bool {
+	// WebGL doesn't have KHR_debug.
+	return false
+}
+
 func (c *defaultContext) EnableVertexAttribArray(index uint32) {
+	if c.commands != nil {
+		c.commands.add(commandEnableVertexAttribArray, int32(index))
+		return
+	}
 	c.fnEnableVertexAttribArray.Invoke(index)
 }
 
 func (c *defaultContext) Flush() {
+	c.commands.flush()
 	c.fnFlush.Invoke()
 }
 
 func (c *defaultContext) FramebufferRenderbuffer(target uint32, attachment uint32, renderbuffertarget uint32, renderbuffer uint32) {
+	c.commands.flush()
 	c.fnFramebufferRenderbuffer.Invoke(target, attachment, renderbuffertarget, c.renderbuffers.get(renderbuffer))
 }
 
 func (c *defaultContext) FramebufferTexture2D(target uint32, attachment uint32, textarget uint32, texture uint32, level int32) {
+	c.commands.flush()
 	c.fnFramebufferTexture2D.Invoke(target, attachment, textarget, c.textures.get(texture), level)
 }
 
 func (c *defaultContext) GetError() uint32 {
+	c.commands.flush()
 	return uint32(c.fnGetError.Invoke().Int())
 }
 
 func (c *defaultContext) GetInteger(pname uint32) int {
+	c.commands.flush()
 	ret := c.fnGetParameter.Invoke(pname)
 	switch pname {
 	case FRAMEBUFFER_BINDING:
@@ -429,10 +544,12 @@ func (c *defaultContext) GetInteger(pname uint32) int {
 }
 
 func (c *defaultContext) GetProgramInfoLog(program uint32) string {
+	c.commands.flush()
 	return c.fnGetProgramInfoLog.Invoke(c.programs.get(program)).String()
 }
 
 func (c *defaultContext) GetProgrami(program uint32, pname uint32) int {
+	c.commands.flush()
 	v := c.fnGetProgramParameter.Invoke(c.programs.get(program), pname)
 	switch v.Type() {
 	case js.TypeNumber:
@@ -448,10 +565,12 @@ func (c *defaultContext) GetProgrami(program uint32, pname uint32) int {
 }
 
 func (c *defaultContext) GetShaderInfoLog(shader uint32) string {
+	c.commands.flush()
 	return c.fnGetShaderInfoLog.Invoke(c.shaders.get(shader)).String()
 }
 
 func (c *defaultContext) GetShaderi(shader uint32, pname uint32) int {
+	c.commands.flush()
 	v := c.fnGetShaderParameter.Invoke(c.shaders.get(shader), pname)
 	switch v.Type() {
 	case js.TypeNumber:
@@ -467,7 +586,13 @@ func (c *defaultContext) GetShaderi(shader uint32, pname uint32) int {
 
 }
 
+func (c *defaultContext) GetString(pname uint32) string {
+	c.commands.flush()
+	return c.fnGetParameter.Invoke(pname).String()
+}
+
 func (c *defaultContext) GetUniformLocation(program uint32, name string) int32 {
+	c.commands.flush()
 	location := c.fnGetUniformLocation.Invoke(c.programs.get(program), name)
 	if c.uniformLocations == nil {
 		c.uniformLocations = map[uint32]*values{}
@@ -477,31 +602,45 @@ func (c *defaultContext) GetUniformLocation(program uint32, name string) int32 {
 		vs = &values{}
 		c.uniformLocations[program] = vs
 	}
-	idx := vs.getOrCreate(location)
+	idx, ok := vs.getID(location)
+	if !ok {
+		idx = vs.create(location)
+		c.commands.setObject(objectKindUniformLocation, (program<<5)|idx, location)
+	}
 	return int32((program << 5) | idx)
 }
 
 func (c *defaultContext) IsFramebuffer(framebuffer uint32) bool {
+	c.commands.flush()
 	return c.fnIsFramebuffer.Invoke(c.framebuffers.get(framebuffer)).Bool()
 }
 
 func (c *defaultContext) IsProgram(program uint32) bool {
+	c.commands.flush()
 	return c.fnIsProgram.Invoke(c.programs.get(program)).Bool()
 }
 
 func (c *defaultContext) IsRenderbuffer(renderbuffer uint32) bool {
+	c.commands.flush()
 	return c.fnIsRenderbuffer.Invoke(c.renderbuffers.get(renderbuffer)).Bool()
 }
 
 func (c *defaultContext) LinkProgram(program uint32) {
+	c.commands.flush()
 	c.fnLinkProgram.Invoke(c.programs.get(program))
 }
 
+func (c *defaultContext) LogicOp(opcode uint32) {
+	panic("gl: LogicOp is not available on WebGL")
+}
+
 func (c *defaultContext) PixelStorei(pname uint32, param int32) {
+	c.commands.flush()
 	c.fnPixelStorei.Invoke(pname, param)
 }
 
 func (c *defaultContext) ReadPixels(dst []byte, x int32, y int32, width int32, height int32, format uint32, xtype uint32) {
+	c.commands.flush()
 	if dst == nil {
 		c.fnReadPixels.Invoke(x, y, width, height, format, xtype, 0)
 		return
@@ -512,26 +651,41 @@ func (c *defaultContext) ReadPixels(dst []byte, x int32, y int32, width int32, h
 }
 
 func (c *defaultContext) RenderbufferStorage(target uint32, internalFormat uint32, width int32, height int32) {
+	c.commands.flush()
 	c.fnRenderbufferStorage.Invoke(target, internalFormat, width, height)
 }
 
 func (c *defaultContext) Scissor(x, y, width, height int32) {
+	if c.commands != nil {
+		c.commands.add(commandScissor, x, y, width, height)
+		return
+	}
 	c.fnScissor.Invoke(x, y, width, height)
 }
 
 func (c *defaultContext) ShaderSource(shader uint32, xstring string) {
+	c.commands.flush()
 	c.fnShaderSource.Invoke(c.shaders.get(shader), xstring)
 }
 
 func (c *defaultContext) StencilFunc(func_ uint32, ref int32, mask uint32) {
+	if c.commands != nil {
+		c.commands.add(commandStencilFunc, int32(func_), ref, int32(mask))
+		return
+	}
 	c.fnStencilFunc.Invoke(func_, ref, mask)
 }
 
 func (c *defaultContext) StencilOpSeparate(face, sfail, dpfail, dppass uint32) {
+	if c.commands != nil {
+		c.commands.add(commandStencilOpSeparate, int32(face), int32(sfail), int32(dpfail), int32(dppass))
+		return
+	}
 	c.fnStencilOpSeparate.Invoke(face, sfail, dpfail, dppass)
 }
 
 func (c *defaultContext) TexImage2D(target uint32, level int32, internalformat int32, width int32, height int32, format uint32, xtype uint32, pixels []byte) {
+	c.commands.flush()
 	if pixels != nil {
 		panic("gl: TexImage2D with non-nil pixels is not implemented")
 	}
@@ -539,10 +693,12 @@ func (c *defaultContext) TexImage2D(target uint32, level int32, internalformat i
 }
 
 func (c *defaultContext) TexParameteri(target uint32, pname uint32, param int32) {
+	c.commands.flush()
 	c.fnTexParameteri.Invoke(target, pname, param)
 }
 
 func (c *defaultContext) TexSubImage2D(target uint32, level int32, xoffset int32, yoffset int32, width int32, height int32, format uint32, xtype uint32, pixels []byte) {
+	c.commands.flush()
 	arr := jsutil.TemporaryUint8ArrayFromUint8Slice(len(pixels), pixels)
 	// void texSubImage2D(GLenum target, GLint level, GLint xoffset, GLint yoffset,
 	//                    GLsizei width, GLsizei height,
@@ -551,84 +707,144 @@ func (c *defaultContext) TexSubImage2D(target uint32, level int32, xoffset int32
 }
 
 func (c *defaultContext) Uniform1fv(location int32, value []float32) {
+	if c.commands != nil {
+		c.commands.addFloat32s(commandUniform1fv, location, value)
+		return
+	}
 	l := c.getUniformLocation(location)
 	arr := jsutil.TemporaryFloat32Array(len(value), value)
 	c.fnUniform1fv.Invoke(l, arr, 0, len(value))
 }
 
 func (c *defaultContext) Uniform1i(location int32, v0 int32) {
+	if c.commands != nil {
+		c.commands.add(commandUniform1i, location, v0)
+		return
+	}
 	l := c.getUniformLocation(location)
 	c.fnUniform1i.Invoke(l, v0)
 }
 
 func (c *defaultContext) Uniform1iv(location int32, value []int32) {
+	if c.commands != nil {
+		c.commands.addInt32s(commandUniform1iv, location, value)
+		return
+	}
 	l := c.getUniformLocation(location)
 	arr := jsutil.TemporaryInt32Array(len(value), value)
 	c.fnUniform1iv.Invoke(l, arr, 0, len(value))
 }
 
 func (c *defaultContext) Uniform2fv(location int32, value []float32) {
+	if c.commands != nil {
+		c.commands.addFloat32s(commandUniform2fv, location, value)
+		return
+	}
 	l := c.getUniformLocation(location)
 	arr := jsutil.TemporaryFloat32Array(len(value), value)
 	c.fnUniform2fv.Invoke(l, arr, 0, len(value))
 }
 
 func (c *defaultContext) Uniform2iv(location int32, value []int32) {
+	if c.commands != nil {
+		c.commands.addInt32s(commandUniform2iv, location, value)
+		return
+	}
 	l := c.getUniformLocation(location)
 	arr := jsutil.TemporaryInt32Array(len(value), value)
 	c.fnUniform2iv.Invoke(l, arr, 0, len(value))
 }
 
 func (c *defaultContext) Uniform3fv(location int32, value []float32) {
+	if c.commands != nil {
+		c.commands.addFloat32s(commandUniform3fv, location, value)
+		return
+	}
 	l := c.getUniformLocation(location)
 	arr := jsutil.TemporaryFloat32Array(len(value), value)
 	c.fnUniform3fv.Invoke(l, arr, 0, len(value))
 }
 
 func (c *defaultContext) Uniform3iv(location int32, value []int32) {
+	if c.commands != nil {
+		c.commands.addInt32s(commandUniform3iv, location, value)
+		return
+	}
 	l := c.getUniformLocation(location)
 	arr := jsutil.TemporaryInt32Array(len(value), value)
 	c.fnUniform3iv.Invoke(l, arr, 0, len(value))
 }
 
 func (c *defaultContext) Uniform4fv(location int32, value []float32) {
+	if c.commands != nil {
+		c.commands.addFloat32s(commandUniform4fv, location, value)
+		return
+	}
 	l := c.getUniformLocation(location)
 	arr := jsutil.TemporaryFloat32Array(len(value), value)
 	c.fnUniform4fv.Invoke(l, arr, 0, len(value))
 }
 
 func (c *defaultContext) Uniform4iv(location int32, value []int32) {
+	if c.commands != nil {
+		c.commands.addInt32s(commandUniform4iv, location, value)
+		return
+	}
 	l := c.getUniformLocation(location)
 	arr := jsutil.TemporaryInt32Array(len(value), value)
 	c.fnUniform4iv.Invoke(l, arr, 0, len(value))
 }
 
 func (c *defaultContext) UniformMatrix2fv(location int32, value []float32) {
+	if c.commands != nil {
+		c.commands.addFloat32s(commandUniformMatrix2fv, location, value)
+		return
+	}
 	l := c.getUniformLocation(location)
 	arr := jsutil.TemporaryFloat32Array(len(value), value)
 	c.fnUniformMatrix2fv.Invoke(l, false, arr, 0, len(value))
 }
 
 func (c *defaultContext) UniformMatrix3fv(location int32, value []float32) {
+	if c.commands != nil {
+		c.commands.addFloat32s(commandUniformMatrix3fv, location, value)
+		return
+	}
 	l := c.getUniformLocation(location)
 	arr := jsutil.TemporaryFloat32Array(len(value), value)
 	c.fnUniformMatrix3fv.Invoke(l, false, arr, 0, len(value))
 }
 
 func (c *defaultContext) UniformMatrix4fv(location int32, value []float32) {
+	if c.commands != nil {
+		c.commands.addFloat32s(commandUniformMatrix4fv, location, value)
+		return
+	}
 	l := c.getUniformLocation(location)
 	arr := jsutil.TemporaryFloat32Array(len(value), value)
 	c.fnUniformMatrix4fv.Invoke(l, false, arr, 0, len(value))
 }
 
 func (c *defaultContext) UseProgram(program uint32) {
+	if c.commands != nil {
+		c.commands.add(commandUseProgram, int32(program))
+		return
+	}
 	c.fnUseProgram.Invoke(c.programs.get(program))
 }
 
 func (c *defaultContext) VertexAttribPointer(index uint32, size int32, xtype uint32, normalized bool, stride int32, offset int) {
+	if c.commands != nil {
+		c.commands.add(commandVertexAttribPointer, int32(index), size, int32(xtype), boolToInt32(normalized), stride, int32(offset))
+		return
+	}
 	c.fnVertexAttribPointer.Invoke(index, size, xtype, normalized, stride, offset)
 }
 
 func (c *defaultContext) Viewport(x int32, y int32, width int32, height int32) {
+	if c.commands != nil {
+		c.commands.add(commandViewport, x, y, width, height)
+		return
+	}
 	c.fnViewport.Invoke(x, y, width, height)
 }
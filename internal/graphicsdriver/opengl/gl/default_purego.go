@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build (darwin || freebsd || linux || netbsd || openbsd || windows) && !nintendosdk && !playstation5
+//go:build (darwin || freebsd || linux || netbsd || openbsd || windows) && !nintendosdk && !playstation5 && !ebitenginecustomplatform
 
 package gl
 
@@ -32,6 +32,7 @@ type defaultContext struct {
 	gpBindRenderbuffer         uintptr
 	gpBindTexture              uintptr
 	gpBindVertexArray          uintptr
+	gpBlendColor               uintptr
 	gpBlendEquationSeparate    uintptr
 	gpBlendFuncSeparate        uintptr
 	gpBufferData               uintptr
@@ -42,6 +43,7 @@ type defaultContext struct {
 	gpCompileShader            uintptr
 	gpCreateProgram            uintptr
 	gpCreateShader             uintptr
+	gpDebugMessageCallback     uintptr
 	gpDeleteBuffers            uintptr
 	gpDeleteFramebuffers       uintptr
 	gpDeleteProgram            uintptr
@@ -68,11 +70,14 @@ type defaultContext struct {
 	gpGetProgramiv             uintptr
 	gpGetShaderInfoLog         uintptr
 	gpGetShaderiv              uintptr
+	gpGetString                uintptr
+	gpGetStringi               uintptr
 	gpGetUniformLocation       uintptr
 	gpIsFramebuffer            uintptr
 	gpIsProgram                uintptr
 	gpIsRenderbuffer           uintptr
 	gpLinkProgram              uintptr
+	gpLogicOp                  uintptr
 	gpPixelStorei              uintptr
 	gpReadPixels               uintptr
 	gpRenderbufferStorage      uintptr
@@ -99,6 +104,10 @@ type defaultContext struct {
 	gpVertexAttribPointer      uintptr
 	gpViewport                 uintptr
 
+	fnBlendColor func(red float32, green float32, blue float32, alpha float32)
+	fnGetString  func(name uint32) string
+	fnGetStringi func(name uint32, index uint32) string
+
 	isES bool
 }
 
@@ -155,6 +164,11 @@ func (c *defaultContext) BindVertexArray(array uint32) {
 	purego.SyscallN(c.gpBindVertexArray, uintptr(array))
 }
 
+func (c *defaultContext) BlendColor(red float32, green float32, blue float32, alpha float32) {
+	// purego.SyscallN cannot pass floating-point values. Use a function registered by purego.RegisterFunc instead.
+	c.fnBlendColor(red, green, blue, alpha)
+}
+
 func (c *defaultContext) BlendEquationSeparate(modeRGB uint32, modeAlpha uint32) {
 	purego.SyscallN(c.gpBlendEquationSeparate, uintptr(modeRGB), uintptr(modeAlpha))
 }
@@ -273,6 +287,23 @@ func (c *defaultContext) Enable(cap uint32) {
 	purego.SyscallN(c.gpEnable, uintptr(cap))
 }
 
+func (c *defaultContext) EnableDebugOutput() bool {
+	if c.gpDebugMessageCallback == 0 || c.gpGetStringi == 0 {
+		return false
+	}
+	if !c.isDebugOutputAvailable() {
+		return false
+	}
+	callback := debugMessageCallback()
+	if callback == 0 {
+		return false
+	}
+	purego.SyscallN(c.gpDebugMessageCallback, callback, 0)
+	c.Enable(DEBUG_OUTPUT)
+	c.Enable(DEBUG_OUTPUT_SYNCHRONOUS)
+	return true
+}
+
 func (c *defaultContext) EnableVertexAttribArray(index uint32) {
 	purego.SyscallN(c.gpEnableVertexAttribArray, uintptr(index))
 }
@@ -326,6 +357,14 @@ func (c *defaultContext) GetShaderi(shader uint32, pname uint32) int {
 	return int(dst)
 }
 
+func (c *defaultContext) GetString(pname uint32) string {
+	return c.fnGetString(pname)
+}
+
+func (c *defaultContext) getStringi(name uint32, index uint32) string {
+	return c.fnGetStringi(name, index)
+}
+
 func (c *defaultContext) GetUniformLocation(program uint32, name string) int32 {
 	cname, free := cStr(name)
 	defer free()
@@ -352,6 +391,10 @@ func (c *defaultContext) LinkProgram(program uint32) {
 	purego.SyscallN(c.gpLinkProgram, uintptr(program))
 }
 
+func (c *defaultContext) LogicOp(opcode uint32) {
+	purego.SyscallN(c.gpLogicOp, uintptr(opcode))
+}
+
 func (c *defaultContext) PixelStorei(pname uint32, param int32) {
 	purego.SyscallN(c.gpPixelStorei, uintptr(pname), uintptr(param))
 }
@@ -482,6 +525,7 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpBindRenderbuffer = g.get("glBindRenderbuffer")
 	c.gpBindTexture = g.get("glBindTexture")
 	c.gpBindVertexArray = g.get("glBindVertexArray")
+	c.gpBlendColor = g.get("glBlendColor")
 	c.gpBlendEquationSeparate = g.get("glBlendEquationSeparate")
 	c.gpBlendFuncSeparate = g.get("glBlendFuncSeparate")
 	c.gpBufferData = g.get("glBufferData")
@@ -518,11 +562,16 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpGetProgramiv = g.get("glGetProgramiv")
 	c.gpGetShaderInfoLog = g.get("glGetShaderInfoLog")
 	c.gpGetShaderiv = g.get("glGetShaderiv")
+	c.gpGetString = g.get("glGetString")
 	c.gpGetUniformLocation = g.get("glGetUniformLocation")
 	c.gpIsFramebuffer = g.get("glIsFramebuffer")
 	c.gpIsProgram = g.get("glIsProgram")
 	c.gpIsRenderbuffer = g.get("glIsRenderbuffer")
 	c.gpLinkProgram = g.get("glLinkProgram")
+	if !c.isES {
+		// glLogicOp is not available on OpenGL ES.
+		c.gpLogicOp = g.get("glLogicOp")
+	}
 	c.gpPixelStorei = g.get("glPixelStorei")
 	c.gpReadPixels = g.get("glReadPixels")
 	c.gpRenderbufferStorage = g.get("glRenderbufferStorage")
@@ -549,7 +598,24 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpVertexAttribPointer = g.get("glVertexAttribPointer")
 	c.gpViewport = g.get("glViewport")
 
-	return g.error()
+	if err := g.error(); err != nil {
+		return err
+	}
+
+	// These functions are optional.
+	c.gpDebugMessageCallback, _ = c.getProcAddress("glDebugMessageCallback")
+	if c.gpDebugMessageCallback == 0 && c.isES {
+		c.gpDebugMessageCallback, _ = c.getProcAddress("glDebugMessageCallbackKHR")
+	}
+	c.gpGetStringi, _ = c.getProcAddress("glGetStringi")
+
+	purego.RegisterFunc(&c.fnBlendColor, c.gpBlendColor)
+	purego.RegisterFunc(&c.fnGetString, c.gpGetString)
+	if c.gpGetStringi != 0 {
+		purego.RegisterFunc(&c.fnGetStringi, c.gpGetStringi)
+	}
+
+	return nil
 }
 
 // cStr takes a Go string (with or without null-termination)
@@ -35,6 +35,7 @@ type Context interface {
 	BindRenderbuffer(target uint32, renderbuffer uint32)
 	BindTexture(target uint32, texture uint32)
 	BindVertexArray(array uint32)
+	BlendColor(red float32, green float32, blue float32, alpha float32)
 	BlendEquationSeparate(modeRGB uint32, modeAlpha uint32)
 	BlendFuncSeparate(srcRGB uint32, dstRGB uint32, srcAlpha uint32, dstAlpha uint32)
 	BufferInit(target uint32, size int, usage uint32)
@@ -61,6 +62,7 @@ type Context interface {
 	DisableVertexAttribArray(index uint32)
 	DrawElements(mode uint32, count int32, xtype uint32, offset int)
 	Enable(cap uint32)
+	EnableDebugOutput() bool
 	EnableVertexAttribArray(index uint32)
 	Flush()
 	FramebufferRenderbuffer(target uint32, attachment uint32, renderbuffertarget uint32, renderbuffer uint32)
@@ -71,11 +73,13 @@ type Context interface {
 	GetProgrami(program uint32, pname uint32) int
 	GetShaderInfoLog(shader uint32) string
 	GetShaderi(shader uint32, pname uint32) int
+	GetString(pname uint32) string
 	GetUniformLocation(program uint32, name string) int32
 	IsFramebuffer(framebuffer uint32) bool
 	IsProgram(program uint32) bool
 	IsRenderbuffer(renderbuffer uint32) bool
 	LinkProgram(program uint32)
+	LogicOp(opcode uint32)
 	PixelStorei(pname uint32, param int32)
 	ReadPixels(dst []byte, x int32, y int32, width int32, height int32, format uint32, xtype uint32)
 	RenderbufferStorage(target uint32, internalFormat uint32, width int32, height int32)
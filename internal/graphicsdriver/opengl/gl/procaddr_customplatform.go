@@ -0,0 +1,40 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ebitenginecustomplatform
+
+package gl
+
+import (
+	"errors"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/customplatform"
+)
+
+func (c *defaultContext) init() error {
+	p := customplatform.Get()
+	if p == nil {
+		return errors.New("gl: no platform layer is registered")
+	}
+	gl := p.OpenGL()
+	if gl == nil {
+		return errors.New("gl: the platform layer doesn't support OpenGL")
+	}
+	c.isES = gl.IsES()
+	return nil
+}
+
+func (c *defaultContext) getProcAddress(name string) (uintptr, error) {
+	return customplatform.Get().OpenGL().GetProcAddress(name), nil
+}
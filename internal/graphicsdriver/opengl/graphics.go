@@ -17,6 +17,7 @@
 package opengl
 
 import (
+	"errors"
 	"fmt"
 	"unsafe"
 
@@ -152,6 +153,26 @@ func (g *Graphics) NewScreenFramebufferImage(width, height int) (graphicsdriver.
 	return i, nil
 }
 
+// NewImageFromNativeTexture implements graphicsdriver.NativeTextureImporter.
+//
+// native is an OpenGL texture name of GL_TEXTURE_2D with the exact size of width and height.
+func (g *Graphics) NewImageFromNativeTexture(width, height int, native uintptr) (graphicsdriver.Image, error) {
+	if native == 0 {
+		return nil, errors.New("opengl: the texture must not be 0")
+	}
+	g.checkSize(width, height)
+	i := &Image{
+		id:       g.genNextImageID(),
+		graphics: g,
+		texture:  textureNative(native),
+		width:    width,
+		height:   height,
+		external: true,
+	}
+	g.addImage(i)
+	return i, nil
+}
+
 func (g *Graphics) addImage(img *Image) {
 	if g.images == nil {
 		g.images = map[graphicsdriver.ImageID]*Image{}
@@ -309,12 +330,21 @@ func (g *Graphics) SetVsyncEnabled(enabled bool) {
 	g.vsync = enabled
 }
 
-func (g *Graphics) NeedsClearingScreen() bool {
-	return true
-}
-
-func (g *Graphics) MaxImageSize() int {
-	return g.context.getMaxTextureSize()
+func (g *Graphics) Capabilities() (graphicsdriver.Capabilities, error) {
+	c := graphicsdriver.Capabilities{
+		Features: graphicsdriver.FeatureClearingScreenRequired |
+			graphicsdriver.FeatureReset |
+			graphicsdriver.FeatureNativeTextureImport |
+			graphicsdriver.FeatureNativeTextureExport,
+		Limits: graphicsdriver.Limits{
+			MaxImageSize: g.context.getMaxTextureSize(),
+		},
+	}
+	// OpenGL ES and WebGL don't have logic operations.
+	if !g.context.ctx.IsES() {
+		c.Features |= graphicsdriver.FeatureLogicOperation
+	}
+	return c, nil
 }
 
 func (g *Graphics) NewShader(program *shaderir.Program) (graphicsdriver.Shader, error) {
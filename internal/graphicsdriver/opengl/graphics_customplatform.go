@@ -0,0 +1,58 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ebitenginecustomplatform
+
+package opengl
+
+import (
+	"errors"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/customplatform"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver/opengl/gl"
+)
+
+type graphicsPlatform struct {
+	gl customplatform.OpenGL
+}
+
+// NewGraphics creates an implementation of graphicsdriver.Graphics for OpenGL with the registered platform layer.
+// The returned graphics value is nil iff the error is not nil.
+func NewGraphics() (graphicsdriver.Graphics, error) {
+	p := customplatform.Get()
+	if p == nil {
+		return nil, errors.New("opengl: no platform layer is registered")
+	}
+	pgl := p.OpenGL()
+	if pgl == nil {
+		return nil, errors.New("opengl: the platform layer doesn't support OpenGL")
+	}
+
+	ctx, err := gl.NewDefaultContext()
+	if err != nil {
+		return nil, err
+	}
+	g := newGraphics(ctx)
+	g.gl = pgl
+	return g, nil
+}
+
+func (g *Graphics) makeContextCurrent() error {
+	return g.gl.MakeContextCurrent()
+}
+
+func (g *Graphics) swapBuffers() error {
+	return g.gl.SwapBuffers()
+}
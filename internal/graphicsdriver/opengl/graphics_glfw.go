@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !android && !ios && !js && !nintendosdk && !playstation5
+//go:build !android && !ios && !js && !nintendosdk && !playstation5 && !ebitenginecustomplatform
 
 package opengl
 
@@ -50,6 +50,12 @@ func NewGraphics() (graphicsdriver.Graphics, error) {
 }
 
 func setGLFWClientAPI(isES bool) error {
+	if isDebugOutputRequested() {
+		if err := glfw.WindowHint(glfw.OpenGLDebugContext, glfw.True); err != nil {
+			return err
+		}
+	}
+
 	if isES {
 		if err := glfw.WindowHint(glfw.ClientAPI, glfw.OpenGLESAPI); err != nil {
 			return err
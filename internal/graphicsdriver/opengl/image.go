@@ -33,6 +33,9 @@ type Image struct {
 	width       int
 	height      int
 	screen      bool
+
+	// external reports whether the texture is owned by the caller of NewImageFromNativeTexture.
+	external bool
 }
 
 // framebuffer is a wrapper of OpenGL's framebuffer.
@@ -50,7 +53,7 @@ func (i *Image) Dispose() {
 	if i.framebuffer != nil {
 		i.graphics.context.deleteFramebuffer(i.framebuffer.native)
 	}
-	if i.texture != 0 {
+	if i.texture != 0 && !i.external {
 		i.graphics.context.deleteTexture(i.texture)
 	}
 	if i.stencil != 0 {
@@ -60,6 +63,15 @@ func (i *Image) Dispose() {
 	i.graphics.removeImage(i)
 }
 
+// NativeTexture implements graphicsdriver.NativeTextureExporter.
+func (i *Image) NativeTexture() (uintptr, error) {
+	if i.screen {
+		return 0, errors.New("opengl: the screen doesn't have a texture")
+	}
+	i.graphics.context.ctx.Flush()
+	return uintptr(i.texture), nil
+}
+
 func (i *Image) setViewport() error {
 	if err := i.ensureFramebuffer(); err != nil {
 		return err
@@ -87,6 +99,10 @@ func (i *Image) viewportSize() (int, int) {
 		// Edge can't treat a bigger viewport than the drawing area (#71).
 		return i.width, i.height
 	}
+	if i.external {
+		// The size of an external texture is decided by the caller.
+		return i.width, i.height
+	}
 	return graphics.InternalImageSize(i.width), graphics.InternalImageSize(i.height)
 }
 
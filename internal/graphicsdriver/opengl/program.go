@@ -178,11 +178,21 @@ func pow2(x int) int {
 	return p2
 }
 
+// canOrphanBuffers reports whether the buffers' storages can be orphaned before updating them.
+//
+// On browsers, reallocating a buffer storage is expensive as WebGL validates and zero-clears the new storage.
+
+const canOrphanBuffers = runtime.GOOS != "js"
+
 func (s *openGLState) setVertices(context *context, vertices []float32, indices []uint32) {
-	if s.vertexArray == 0 {
-		s.vertexArray = context.ctx.CreateVertexArray()
+	// OpenGL ES 2.0 doesn't have vertex array objects. As only one array buffer is used, the vertex attribute
+	// state of the context works as it is.
+	if !context.gles2 {
+		if s.vertexArray == 0 {
+			s.vertexArray = context.ctx.CreateVertexArray()
+		}
+		context.ctx.BindVertexArray(s.vertexArray)
 	}
-	context.ctx.BindVertexArray(s.vertexArray)
 
 	if size := len(vertices) * int(unsafe.Sizeof(vertices[0])); s.arrayBufferSizeInBytes < size {
 		if s.arrayBuffer != 0 {
@@ -196,6 +206,10 @@ func (s *openGLState) setVertices(context *context, vertices []float32, indices
 
 		// Reenable the array buffer layout explicitly after resetting the array buffer.
 		theArrayBufferLayout.enable(context)
+	} else if canOrphanBuffers {
+		// Orphan the current storage so that the driver doesn't have to wait for the previous draw calls using
+		// the buffer. The driver allocates a new storage and releases the old one when it is no longer used.
+		context.ctx.BufferInit(gl.ARRAY_BUFFER, s.arrayBufferSizeInBytes, gl.DYNAMIC_DRAW)
 	}
 
 	if size := len(indices) * int(unsafe.Sizeof(indices[0])); s.elementArrayBufferSizeInBytes < size {
@@ -207,6 +221,8 @@ func (s *openGLState) setVertices(context *context, vertices []float32, indices
 		// newElementArrayBuffer calls BindBuffer.
 		s.elementArrayBuffer = context.newElementArrayBuffer(newSize)
 		s.elementArrayBufferSizeInBytes = newSize
+	} else if canOrphanBuffers {
+		context.ctx.BufferInit(gl.ELEMENT_ARRAY_BUFFER, s.elementArrayBufferSizeInBytes, gl.DYNAMIC_DRAW)
 	}
 
 	// Note that the vertices and the indices passed to BufferSubData is not under GC management in the gl package.
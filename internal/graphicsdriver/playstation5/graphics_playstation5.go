@@ -97,12 +97,13 @@ func (g *Graphics) NewScreenFramebufferImage(width, height int) (graphicsdriver.
 func (g *Graphics) SetVsyncEnabled(enabled bool) {
 }
 
-func (g *Graphics) NeedsClearingScreen() bool {
-	return false
-}
-
-func (g *Graphics) MaxImageSize() int {
-	return 4096 // TODO: Get the value from the SDK.
+func (g *Graphics) Capabilities() (graphicsdriver.Capabilities, error) {
+	// TODO: Get the values from the SDK.
+	return graphicsdriver.Capabilities{
+		Limits: graphicsdriver.Limits{
+			MaxImageSize: 4096,
+		},
+	}, nil
 }
 
 func (g *Graphics) NewShader(program *shaderir.Program) (graphicsdriver.Shader, error) {
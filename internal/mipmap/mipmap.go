@@ -27,7 +27,7 @@ import (
 
 func canUseMipmap(imageType atlas.ImageType) bool {
 	switch imageType {
-	case atlas.ImageTypeRegular, atlas.ImageTypeUnmanaged:
+	case atlas.ImageTypeRegular, atlas.ImageTypeUnmanaged, atlas.ImageTypeExtruded:
 		return true
 	}
 	return false
@@ -52,10 +52,25 @@ func New(width, height int, imageType atlas.ImageType) *Mipmap {
 	}
 }
 
+// NewExternal returns a new Mipmap that wraps an existing native texture.
+// An external image doesn't use mipmaps.
+func NewExternal(width, height int, native uintptr) *Mipmap {
+	return &Mipmap{
+		width:     width,
+		height:    height,
+		orig:      buffered.NewExternalImage(width, height, native),
+		imageType: atlas.ImageTypeExternal,
+	}
+}
+
 func (m *Mipmap) DumpScreenshot(graphicsDriver graphicsdriver.Graphics, name string, blackbg bool) (string, error) {
 	return m.orig.DumpScreenshot(graphicsDriver, name, blackbg)
 }
 
+func (m *Mipmap) BackendInfo() atlas.BackendInfo {
+	return m.orig.BackendInfo()
+}
+
 func (m *Mipmap) WritePixels(pix []byte, region image.Rectangle) {
 	m.orig.WritePixels(pix, region)
 	m.deallocateMipmaps()
@@ -65,6 +80,10 @@ func (m *Mipmap) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byt
 	return m.orig.ReadPixels(graphicsDriver, pixels, region)
 }
 
+func (m *Mipmap) NativeTexture(graphicsDriver graphicsdriver.Graphics) (native uintptr, width, height int, ok bool, err error) {
+	return m.orig.NativeTexture(graphicsDriver)
+}
+
 func (m *Mipmap) DrawTriangles(srcs [graphics.ShaderImageCount]*Mipmap, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderImageCount]image.Rectangle, shader *atlas.Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool) {
 	if len(indices) == 0 {
 		return
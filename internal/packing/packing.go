@@ -59,6 +59,21 @@ func (p *Page) IsEmpty() bool {
 	return !p.root.used && p.root.child0 == nil && p.root.child1 == nil
 }
 
+// UsedArea returns the total area of the allocated nodes in pixels.
+func (p *Page) UsedArea() int {
+	if p.root == nil {
+		return 0
+	}
+	var area int
+	_ = walk(p.root, func(n *Node) error {
+		if n.used {
+			area += n.region.Dx() * n.region.Dy()
+		}
+		return nil
+	})
+	return area
+}
+
 type Node struct {
 	region image.Rectangle
 	used   bool
@@ -169,6 +184,21 @@ func (p *Page) Alloc(width, height int) *Node {
 	return p.extendAndAlloc(width, height)
 }
 
+// AllocWithoutExtension is similar to Alloc but never extends the page.
+// AllocWithoutExtension returns nil if the current page doesn't have enough space.
+func (p *Page) AllocWithoutExtension(width, height int) *Node {
+	if width <= 0 || height <= 0 {
+		panic("packing: width and height must > 0")
+	}
+
+	if p.root == nil {
+		p.root = &Node{
+			region: image.Rect(0, 0, p.width, p.height),
+		}
+	}
+	return alloc(p.root, width, height)
+}
+
 func (p *Page) Free(node *Node) {
 	if node.child0 != nil || node.child1 != nil {
 		panic("packing: can't free the node including children")
@@ -449,3 +449,40 @@ func TestRemoveAtRootsChild(t *testing.T) {
 	n6 := p.Alloc(18, 18)
 	p.Free(n6)
 }
+
+func TestUsedArea(t *testing.T) {
+	p := packing.NewPage(1024, 1024, 1024)
+	if got, want := p.UsedArea(), 0; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	n0 := p.Alloc(100, 100)
+	n1 := p.Alloc(50, 20)
+	if got, want := p.UsedArea(), 100*100+50*20; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	p.Free(n0)
+	if got, want := p.UsedArea(), 50*20; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+
+	p.Free(n1)
+	if got, want := p.UsedArea(), 0; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
+
+func TestAllocWithoutExtension(t *testing.T) {
+	p := packing.NewPage(256, 256, 1024)
+	if n := p.AllocWithoutExtension(256, 256); n == nil {
+		t.Fatalf("AllocWithoutExtension(256, 256) must succeed")
+	}
+	if n := p.AllocWithoutExtension(1, 1); n != nil {
+		t.Errorf("AllocWithoutExtension(1, 1) must fail but got: %v", n.Region())
+	}
+	gotWidth, gotHeight := p.Size()
+	if wantWidth, wantHeight := 256, 256; gotWidth != wantWidth || gotHeight != wantHeight {
+		t.Errorf("got: (%d, %d), want: (%d, %d)", gotWidth, gotHeight, wantWidth, wantHeight)
+	}
+}
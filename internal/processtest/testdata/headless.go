@@ -0,0 +1,76 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ignore
+
+package main
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const updateCount = 100
+
+type Game struct {
+	count int
+	src   *ebiten.Image
+	dst   *ebiten.Image
+}
+
+func (g *Game) Update() error {
+	if g.src == nil {
+		g.src = ebiten.NewImage(16, 16)
+		g.dst = ebiten.NewImage(16, 16)
+	}
+
+	// Drawing in Update must work without a graphics device.
+	g.src.Fill(color.White)
+	g.dst.DrawImage(g.src, nil)
+
+	// Window functions must not crash without a window.
+	ebiten.SetWindowSize(320, 240)
+	ebiten.SetWindowTitle("headless")
+	_, _ = ebiten.WindowPosition()
+	_ = ebiten.IsFocused()
+	_, _ = ebiten.CursorPosition()
+
+	g.count++
+	if g.count >= updateCount {
+		return ebiten.Termination
+	}
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	panic("Draw must not be called in the headless mode")
+}
+
+func (g *Game) Layout(width, height int) (int, int) {
+	panic("Layout must not be called in the headless mode")
+}
+
+func main() {
+	g := &Game{}
+	if err := ebiten.RunGameWithOptions(g, &ebiten.RunGameOptions{
+		Headless: true,
+	}); err != nil {
+		panic(err)
+	}
+	if g.count != updateCount {
+		panic(errors.New("Update was not called as expected"))
+	}
+}
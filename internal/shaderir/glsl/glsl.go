@@ -30,8 +30,15 @@ type GLSLVersion int
 const (
 	GLSLVersionDefault GLSLVersion = iota
 	GLSLVersionES300
+	GLSLVersionES100
 )
 
+// textureSizesUniformIndex is the index of the uniform variable for the source texture sizes in pixels.
+// This must be consistent with the preserved uniform variables in the graphics package.
+//
+// The texture sizes are used to emulate texelFetch with GLSL ES 1.00.
+const textureSizesUniformIndex = 1
+
 // utilFunctions is GLSL utility functions for old GLSL versions.
 const utilFunctions = `int modInt(int x, int y) {
 	return x - y*(x/y);
@@ -67,11 +74,30 @@ func VertexPrelude(version GLSLVersion) string {
 		return `#version 150` + "\n\n" + utilFunctions
 	case GLSLVersionES300:
 		return `#version 300 es`
+	case GLSLVersionES100:
+		return `#version 100` + "\n\n" + utilFunctions
 	}
 	return ""
 }
 
 func FragmentPrelude(version GLSLVersion) string {
+	if version == GLSLVersionES100 {
+		// highp might not be available in fragment shaders with GLSL ES 1.00.
+		return `#version 100
+
+#extension GL_OES_standard_derivatives : enable
+
+#if defined(GL_FRAGMENT_PRECISION_HIGH)
+precision highp float;
+precision highp int;
+#else
+precision mediump float;
+precision mediump int;
+#endif
+
+` + utilFunctions
+	}
+
 	var prefix string
 	switch version {
 	case GLSLVersionDefault:
@@ -139,10 +165,10 @@ func Compile(p *shaderir.Program, version GLSLVersion) (vertexShader, fragmentSh
 				vslines = append(vslines, fmt.Sprintf("uniform sampler2D T%d;", i))
 			}
 			for i, t := range p.Attributes {
-				vslines = append(vslines, fmt.Sprintf("in %s;", c.varDecl(p, &t, fmt.Sprintf("A%d", i))))
+				vslines = append(vslines, fmt.Sprintf("%s %s;", c.attributeQualifier(), c.varDecl(p, &t, fmt.Sprintf("A%d", i))))
 			}
 			for i, t := range p.Varyings {
-				vslines = append(vslines, fmt.Sprintf("out %s;", c.varDecl(p, &t, fmt.Sprintf("V%d", i))))
+				vslines = append(vslines, fmt.Sprintf("%s %s;", c.varyingQualifier(true), c.varDecl(p, &t, fmt.Sprintf("V%d", i))))
 			}
 		}
 
@@ -228,7 +254,7 @@ func Compile(p *shaderir.Program, version GLSLVersion) (vertexShader, fragmentSh
 				fslines = append(fslines, fmt.Sprintf("uniform sampler2D T%d;", i))
 			}
 			for i, t := range p.Varyings {
-				fslines = append(fslines, fmt.Sprintf("in %s;", c.varDecl(p, &t, fmt.Sprintf("V%d", i))))
+				fslines = append(fslines, fmt.Sprintf("%s %s;", c.varyingQualifier(false), c.varDecl(p, &t, fmt.Sprintf("V%d", i))))
 			}
 		}
 
@@ -295,6 +321,30 @@ func Compile(p *shaderir.Program, version GLSLVersion) (vertexShader, fragmentSh
 	return vs, fs
 }
 
+func (c *compileContext) attributeQualifier() string {
+	if c.version == GLSLVersionES100 {
+		return "attribute"
+	}
+	return "in"
+}
+
+func (c *compileContext) varyingQualifier(vertex bool) string {
+	if c.version == GLSLVersionES100 {
+		return "varying"
+	}
+	if vertex {
+		return "out"
+	}
+	return "in"
+}
+
+func (c *compileContext) fragColor() string {
+	if c.version == GLSLVersionES100 {
+		return "gl_FragColor"
+	}
+	return "fragColor"
+}
+
 func (c *compileContext) typ(p *shaderir.Program, t *shaderir.Type) (string, string) {
 	switch t.Main {
 	case shaderir.None:
@@ -496,7 +546,7 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 			}
 			return fmt.Sprintf("%s(%s)", op, expr(&e.Exprs[0]))
 		case shaderir.Binary:
-			if e.Op == shaderir.ModOp && c.version == GLSLVersionDefault {
+			if e.Op == shaderir.ModOp && (c.version == GLSLVersionDefault || c.version == GLSLVersionES100) {
 				// '%' is not defined.
 				return fmt.Sprintf("modInt((%s), (%s))", expr(&e.Exprs[0]), expr(&e.Exprs[1]))
 			}
@@ -509,6 +559,10 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 				args = append(args, expr(&exp))
 			}
 			f := expr(&e.Exprs[0])
+			if e.Exprs[0].Type == shaderir.BuiltinFuncExpr && e.Exprs[0].BuiltinFunc == shaderir.TexelAt && c.unit == shaderir.Pixels && c.version == GLSLVersionES100 {
+				// texelFetch is not available. Sample the center of the texel with the nearest filter instead.
+				return fmt.Sprintf("%s(%s, (floor(%s) + 0.5) / U%d[%d])", f, args[0], args[1], textureSizesUniformIndex, e.Exprs[1].Index)
+			}
 			if f == "texelFetch" {
 				return fmt.Sprintf("%s(%s, ivec2(%s), 0)", f, args[0], args[1])
 			}
@@ -595,7 +649,7 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 		case shaderir.Return:
 			switch {
 			case topBlock == p.FragmentFunc.Block:
-				lines = append(lines, fmt.Sprintf("%sfragColor = %s;", idt, expr(&s.Exprs[0])))
+				lines = append(lines, fmt.Sprintf("%s%s = %s;", idt, c.fragColor(), expr(&s.Exprs[0])))
 				// The 'return' statement is not required so far, as the fragment entrypoint has only one sentence so far. See adjustProgram implementation.
 			case len(s.Exprs) == 0:
 				lines = append(lines, idt+"return;")
@@ -0,0 +1,85 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glsl_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir/glsl"
+)
+
+func TestCompileES100(t *testing.T) {
+	if runtime.GOOS == "js" {
+		t.Skip("file open might not be implemented in this environment")
+	}
+
+	files, err := filepath.Glob(filepath.Join("testdata", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no test files")
+	}
+
+	for _, f := range files {
+		f := f
+		name := strings.TrimSuffix(filepath.Base(f), ".go")
+		t.Run(name, func(t *testing.T) {
+			src, err := os.ReadFile(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ir, err := graphics.CompileShader(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			vs, fragment := glsl.Compile(ir, glsl.GLSLVersionES100)
+
+			for _, e := range []struct {
+				Ext string
+				Got string
+			}{
+				{
+					Ext: ".expected.es100.vs",
+					Got: vs,
+				},
+				{
+					Ext: ".expected.es100.fs",
+					Got: fragment,
+				},
+			} {
+				path := filepath.Join("testdata", name+e.Ext)
+				want, err := os.ReadFile(path)
+				if err != nil {
+					// The expected vertex shader is optional, as the vertex shaders are mostly the same.
+					if errors.Is(err, fs.ErrNotExist) && e.Ext == ".expected.es100.vs" {
+						continue
+					}
+					t.Fatal(err)
+				}
+				if got := e.Got; got != string(want) {
+					t.Errorf("%s: got: %s, want: %s", path, got, want)
+				}
+			}
+		})
+	}
+}
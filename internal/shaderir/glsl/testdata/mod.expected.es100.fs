@@ -0,0 +1,65 @@
+#version 100
+
+#extension GL_OES_standard_derivatives : enable
+
+#if defined(GL_FRAGMENT_PRECISION_HIGH)
+precision highp float;
+precision highp int;
+#else
+precision mediump float;
+precision mediump int;
+#endif
+
+int modInt(int x, int y) {
+	return x - y*(x/y);
+}
+
+ivec2 modInt(ivec2 x, int y) {
+	return x - y*(x/y);
+}
+
+ivec3 modInt(ivec3 x, int y) {
+	return x - y*(x/y);
+}
+
+ivec4 modInt(ivec4 x, int y) {
+	return x - y*(x/y);
+}
+
+ivec2 modInt(ivec2 x, ivec2 y) {
+	return x - y*(x/y);
+}
+
+ivec3 modInt(ivec3 x, ivec3 y) {
+	return x - y*(x/y);
+}
+
+ivec4 modInt(ivec4 x, ivec4 y) {
+	return x - y*(x/y);
+}
+
+uniform vec2 U0;
+uniform vec2 U1[4];
+uniform vec2 U2;
+uniform vec2 U3;
+uniform vec2 U4[4];
+uniform vec2 U5[4];
+uniform mat4 U6;
+uniform sampler2D T0;
+uniform sampler2D T1;
+uniform sampler2D T2;
+uniform sampler2D T3;
+varying vec2 V0;
+varying vec4 V1;
+
+vec4 F22(in vec4 l0, in vec2 l1, in vec4 l2);
+
+vec4 F22(in vec4 l0, in vec2 l1, in vec4 l2) {
+	int l3 = 0;
+	l3 = modInt((int((l0).x)), (2));
+	return vec4(float(l3));
+}
+
+void main(void) {
+	gl_FragColor = F22(gl_FragCoord, V0, V1);
+}
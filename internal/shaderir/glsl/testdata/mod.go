@@ -0,0 +1,8 @@
+//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	x := int(dstPos.x) % 2
+	return vec4(float(x))
+}
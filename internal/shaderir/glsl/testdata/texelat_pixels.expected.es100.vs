@@ -0,0 +1,58 @@
+#version 100
+
+int modInt(int x, int y) {
+	return x - y*(x/y);
+}
+
+ivec2 modInt(ivec2 x, int y) {
+	return x - y*(x/y);
+}
+
+ivec3 modInt(ivec3 x, int y) {
+	return x - y*(x/y);
+}
+
+ivec4 modInt(ivec4 x, int y) {
+	return x - y*(x/y);
+}
+
+ivec2 modInt(ivec2 x, ivec2 y) {
+	return x - y*(x/y);
+}
+
+ivec3 modInt(ivec3 x, ivec3 y) {
+	return x - y*(x/y);
+}
+
+ivec4 modInt(ivec4 x, ivec4 y) {
+	return x - y*(x/y);
+}
+
+uniform vec2 U0;
+uniform vec2 U1[4];
+uniform vec2 U2;
+uniform vec2 U3;
+uniform vec2 U4[4];
+uniform vec2 U5[4];
+uniform mat4 U6;
+uniform sampler2D T0;
+uniform sampler2D T1;
+uniform sampler2D T2;
+uniform sampler2D T3;
+attribute vec2 A0;
+attribute vec2 A1;
+attribute vec4 A2;
+varying vec2 V0;
+varying vec4 V1;
+
+float touchUniforms() {
+	return float(U1[3].x) + float(U4[3].x) + float(U5[3].x);
+}
+
+void main(void) {
+	touchUniforms();
+	gl_Position = (U6) * (vec4(A0, 0.0, 1.0));
+	V0 = A1;
+	V1 = A2;
+	return;
+}
@@ -0,0 +1,7 @@
+//kage:unit texels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return imageSrc0UnsafeAt(srcPos) * color
+}
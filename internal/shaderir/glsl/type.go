@@ -122,6 +122,9 @@ func (c *compileContext) builtinFuncString(f shaderir.BuiltinFunc) string {
 	case shaderir.Dfdy:
 		return "dFdy"
 	case shaderir.TexelAt:
+		if c.version == GLSLVersionES100 {
+			return "texture2D"
+		}
 		if c.unit == shaderir.Pixels {
 			return "texelFetch"
 		}
@@ -40,8 +40,14 @@ type OSThread struct {
 
 // NewOSThread creates a new thread.
 func NewOSThread() *OSThread {
+	return NewOSThreadWithQueueSize(0)
+}
+
+// NewOSThreadWithQueueSize creates a new thread that can queue up to size functions by CallAsync without blocking,
+// in addition to the function being executed.
+func NewOSThreadWithQueueSize(size int) *OSThread {
 	return &OSThread{
-		funcs: make(chan queueItem),
+		funcs: make(chan queueItem, size),
 		done:  make(chan struct{}),
 	}
 }
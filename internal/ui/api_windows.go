@@ -30,7 +30,13 @@ const (
 	_CLSCTX_REMOTE_SERVER     = 0x10
 	_CLSCTX_SERVER            = _CLSCTX_INPROC_SERVER | _CLSCTX_LOCAL_SERVER | _CLSCTX_REMOTE_SERVER
 	_MONITOR_DEFAULTTONEAREST = 2
+	_MUI_LANGUAGE_NAME        = 0x8
 	_SM_CYCAPTION             = 4
+	_TBPF_NOPROGRESS          = 0x0
+	_TBPF_INDETERMINATE       = 0x1
+	_TBPF_NORMAL              = 0x2
+	_TBPF_ERROR               = 0x4
+	_TBPF_PAUSED              = 0x8
 )
 
 var (
@@ -46,6 +52,12 @@ var (
 		Data3: 0x11D0,
 		Data4: [...]byte{0x95, 0x8A, 0x00, 0x60, 0x97, 0xC9, 0xA0, 0x90},
 	}
+	_IID_ITaskbarList3 = windows.GUID{
+		Data1: 0xEA1AFB91,
+		Data2: 0x9E28,
+		Data3: 0x4B86,
+		Data4: [...]byte{0x90, 0xE9, 0x9E, 0x9F, 0x8A, 0x5E, 0xED, 0xAF},
+	}
 )
 
 type _RECT struct {
@@ -68,8 +80,11 @@ type _POINT struct {
 }
 
 var (
-	ole32  = windows.NewLazySystemDLL("ole32.dll")
-	user32 = windows.NewLazySystemDLL("user32.dll")
+	kernel32 = windows.NewLazySystemDLL("kernel32.dll")
+	ole32    = windows.NewLazySystemDLL("ole32.dll")
+	user32   = windows.NewLazySystemDLL("user32.dll")
+
+	procGetUserPreferredUILanguages = kernel32.NewProc("GetUserPreferredUILanguages")
 
 	procCoCreateInstance = ole32.NewProc("CoCreateInstance")
 
@@ -90,6 +105,44 @@ func _CoCreateInstance(rclsid *windows.GUID, pUnkOuter unsafe.Pointer, dwClsCont
 	return ptr, nil
 }
 
+func _GetUserPreferredUILanguages(dwFlags uint32) ([]string, error) {
+	var num, size uint32
+	r, _, e := procGetUserPreferredUILanguages.Call(uintptr(dwFlags), uintptr(unsafe.Pointer(&num)), 0, uintptr(unsafe.Pointer(&size)))
+	if int32(r) == 0 {
+		if e != nil && !errors.Is(e, windows.ERROR_SUCCESS) {
+			return nil, fmt.Errorf("ui: GetUserPreferredUILanguages failed: error code: %w", e)
+		}
+		return nil, fmt.Errorf("ui: GetUserPreferredUILanguages failed: returned 0")
+	}
+	if size == 0 {
+		return nil, nil
+	}
+
+	buf := make([]uint16, size)
+	r, _, e = procGetUserPreferredUILanguages.Call(uintptr(dwFlags), uintptr(unsafe.Pointer(&num)), uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)))
+	if int32(r) == 0 {
+		if e != nil && !errors.Is(e, windows.ERROR_SUCCESS) {
+			return nil, fmt.Errorf("ui: GetUserPreferredUILanguages failed: error code: %w", e)
+		}
+		return nil, fmt.Errorf("ui: GetUserPreferredUILanguages failed: returned 0")
+	}
+
+	// The buffer is a list of null-terminated strings, terminated by an empty string.
+	var langs []string
+	for len(buf) > 0 && buf[0] != 0 {
+		var n int
+		for n < len(buf) && buf[n] != 0 {
+			n++
+		}
+		langs = append(langs, windows.UTF16ToString(buf[:n]))
+		if n == len(buf) {
+			break
+		}
+		buf = buf[n+1:]
+	}
+	return langs, nil
+}
+
 func _GetSystemMetrics(nIndex int) (int32, error) {
 	r, _, _ := procGetSystemMetrics.Call(uintptr(nIndex))
 	if int32(r) == 0 {
@@ -158,3 +211,58 @@ func (i *_ITaskbarList) DeleteTab(hwnd windows.HWND) error {
 func (i *_ITaskbarList) Release() {
 	_, _, _ = syscall.Syscall(i.vtbl.Release, 1, uintptr(unsafe.Pointer(i)), 0, 0)
 }
+
+type _ITaskbarList3 struct {
+	vtbl *_ITaskbarList3_Vtbl
+}
+
+type _ITaskbarList3_Vtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+
+	HrInit       uintptr
+	AddTab       uintptr
+	DeleteTab    uintptr
+	ActivateTab  uintptr
+	SetActiveAlt uintptr
+
+	MarkFullscreenWindow uintptr
+
+	SetProgressValue uintptr
+	SetProgressState uintptr
+}
+
+func (i *_ITaskbarList3) HrInit() error {
+	r, _, _ := syscall.Syscall(i.vtbl.HrInit, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("ui: ITaskbarList3::HrInit failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_ITaskbarList3) SetProgressState(hwnd windows.HWND, tbpFlags uint32) error {
+	r, _, _ := syscall.Syscall(i.vtbl.SetProgressState, 3, uintptr(unsafe.Pointer(i)), uintptr(hwnd), uintptr(tbpFlags))
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("ui: ITaskbarList3::SetProgressState failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_ITaskbarList3) SetProgressValue(hwnd windows.HWND, ullCompleted, ullTotal uint64) error {
+	var r uintptr
+	if unsafe.Sizeof(uintptr(0)) == 4 {
+		// A 64-bit argument takes two slots on 32-bit machines.
+		r, _, _ = syscall.Syscall6(i.vtbl.SetProgressValue, 6, uintptr(unsafe.Pointer(i)), uintptr(hwnd), uintptr(ullCompleted), uintptr(ullCompleted>>32), uintptr(ullTotal), uintptr(ullTotal>>32))
+	} else {
+		r, _, _ = syscall.Syscall6(i.vtbl.SetProgressValue, 4, uintptr(unsafe.Pointer(i)), uintptr(hwnd), uintptr(ullCompleted), uintptr(ullTotal), 0, 0)
+	}
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("ui: ITaskbarList3::SetProgressValue failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_ITaskbarList3) Release() {
+	_, _, _ = syscall.Syscall(i.vtbl.Release, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"sync"
+)
+
+type BackGesturePhase int
+
+const (
+	BackGesturePhaseStarted BackGesturePhase = iota
+	BackGesturePhaseProgressed
+	BackGesturePhaseCancelled
+	BackGesturePhaseInvoked
+)
+
+type BackGestureEvent struct {
+	Phase    BackGesturePhase
+	Progress float64
+}
+
+type backGesture struct {
+	events []BackGestureEvent
+	m      sync.Mutex
+}
+
+// AddBackGestureEvent is called from mobile/ebitenmobileview.
+func (u *UserInterface) AddBackGestureEvent(event BackGestureEvent) {
+	u.backGesture.m.Lock()
+	defer u.backGesture.m.Unlock()
+
+	// Coalesce the progress events as only the latest progress matters.
+	if n := len(u.backGesture.events); n > 0 && event.Phase == BackGesturePhaseProgressed && u.backGesture.events[n-1].Phase == BackGesturePhaseProgressed {
+		u.backGesture.events[n-1] = event
+		return
+	}
+	u.backGesture.events = append(u.backGesture.events, event)
+}
+
+// AppendBackGestureEvents appends the back gesture events since the last call to events, and removes the events.
+func (u *UserInterface) AppendBackGestureEvents(events []BackGestureEvent) []BackGestureEvent {
+	u.backGesture.m.Lock()
+	defer u.backGesture.m.Unlock()
+
+	events = append(events, u.backGesture.events...)
+	u.backGesture.events = u.backGesture.events[:0]
+	return events
+}
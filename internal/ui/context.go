@@ -21,6 +21,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
 	"github.com/hajimehoshi/ebiten/v2/internal/clock"
 	"github.com/hajimehoshi/ebiten/v2/internal/debug"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
 	"github.com/hajimehoshi/ebiten/v2/internal/hook"
 )
@@ -38,6 +39,7 @@ type Game interface {
 	Update() error
 	DrawOffscreen() error
 	DrawFinalScreen(scale, offsetX, offsetY float64)
+	ChangeAppLifecycleState(state AppLifecycleState) error
 }
 
 type context struct {
@@ -56,6 +58,9 @@ type context struct {
 	isOffscreenModified bool
 	lastDrawTime        time.Time
 
+	// lastFrameStartTime is the time when the last frame started, used for the frame rate limit.
+	lastFrameStartTime time.Time
+
 	skipCount int
 
 	funcsInFrameCh chan func()
@@ -69,10 +74,35 @@ func newContext(game Game) *context {
 }
 
 func (c *context) updateFrame(graphicsDriver graphicsdriver.Graphics, outsideWidth, outsideHeight float64, deviceScaleFactor float64, ui *UserInterface) error {
+	c.waitForFrameRateLimit(ui)
+
+	clock.SetPresentationMissedFrames(graphicscommand.MissedFrames())
+
 	// TODO: If updateCount is 0 and vsync is disabled, swapping buffers can be skipped.
 	return c.updateFrameImpl(graphicsDriver, clock.UpdateFrame(), outsideWidth, outsideHeight, deviceScaleFactor, ui, false)
 }
 
+// waitForFrameRateLimit waits until the next frame can start based on the frame rate limit.
+func (c *context) waitForFrameRateLimit(ui *UserInterface) {
+	fps := ui.FrameRateLimit()
+	if fps <= 0 || c.lastFrameStartTime.IsZero() {
+		c.lastFrameStartTime = time.Now()
+		return
+	}
+
+	interval := time.Second / time.Duration(fps)
+	next := c.lastFrameStartTime.Add(interval)
+	waitUntil(next, ui.IsPreciseFramePacing())
+
+	// Advance the time by the interval so that errors are not accumulated.
+	// If the frame is too late, e.g. the game was suspended, reset the time.
+	if now := time.Now(); now.Sub(next) < interval {
+		c.lastFrameStartTime = next
+	} else {
+		c.lastFrameStartTime = now
+	}
+}
+
 func (c *context) forceUpdateFrame(graphicsDriver graphicsdriver.Graphics, outsideWidth, outsideHeight float64, deviceScaleFactor float64, ui *UserInterface) error {
 	n := 1
 	if ui.GraphicsLibrary() == GraphicsLibraryDirectX {
@@ -97,11 +127,20 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 
 	debug.Logf("----\n")
 
+	start := time.Now()
+
+	if ui.frameIntervalRangeDirty.Swap(false) {
+		minInterval, maxInterval := ui.FrameIntervalRange()
+		graphicscommand.SetFrameIntervalRange(minInterval, maxInterval, graphicsDriver)
+	}
+
 	if err := atlas.BeginFrame(graphicsDriver); err != nil {
 		return err
 	}
 
 	defer func() {
+		ui.lastFrameDuration.Store(int64(time.Since(start)))
+
 		if err1 := atlas.EndFrame(); err1 != nil && err == nil {
 			err = err1
 			return
@@ -118,6 +157,8 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 		return err
 	}
 
+	ui.frameDeviceScaleFactor.Store(math.Float64bits(deviceScaleFactor))
+
 	// ForceUpdate can be invoked even if the context is not initialized yet (#1591).
 	if w, h := c.layoutGame(outsideWidth, outsideHeight, deviceScaleFactor); w == 0 || h == 0 {
 		return nil
@@ -191,15 +232,19 @@ func (c *context) drawGame(graphicsDriver graphicsdriver.Graphics, ui *UserInter
 	// Draw should not update the game state and then the screen should not be updated without Update, but
 	// users might want to process something at Draw with the time intervals of FPS.
 	if ui.IsScreenClearedEveryFrame() {
-		c.offscreen.clear()
+		c.offscreen.clearWithBackgroundColor()
 	}
 
+	const maxSkipCount = 3
+
+	// The presentation is skipped if the offscreen is not modified at this frame.
+	// Clearing the screen modifies the offscreen, so the presentation is never skipped in this case.
+	ui.screenPresentationSkipped.Store(!forceDraw && !ui.IsScreenClearedEveryFrame() && c.skipCount+1 >= maxSkipCount)
+
 	if err := c.game.DrawOffscreen(); err != nil {
 		return err
 	}
 
-	const maxSkipCount = 3
-
 	if !forceDraw && !c.isOffscreenModified {
 		if c.skipCount < maxSkipCount {
 			c.skipCount++
@@ -214,9 +259,14 @@ func (c *context) drawGame(graphicsDriver graphicsdriver.Graphics, ui *UserInter
 	}()
 
 	if c.skipCount < maxSkipCount {
-		if graphicsDriver.NeedsClearingScreen() {
+		// The background color is also used for the letterbox bars.
+		caps, err := graphicscommand.Capabilities(graphicsDriver)
+		if err != nil {
+			return err
+		}
+		if caps.Has(graphicsdriver.FeatureClearingScreenRequired) || ui.hasBackgroundColor() {
 			// This clear is needed for fullscreen mode or some mobile platforms (#622).
-			c.screen.clear()
+			c.screen.clearWithBackgroundColor()
 		}
 
 		c.game.DrawFinalScreen(c.screenScaleAndOffsets())
@@ -301,6 +351,30 @@ func (u *UserInterface) LogicalPositionToClientPositionInNativePixels(x, y float
 	return x, y
 }
 
+// LogicalPositionToClientPosition converts a logical position on the game screen into a position in the client area
+// in device-independent pixels.
+func (u *UserInterface) LogicalPositionToClientPosition(x, y float64) (float64, float64) {
+	return u.context.logicalPositionToClientPosition(x, y, u.Monitor().DeviceScaleFactor())
+}
+
+// ClientPositionToLogicalPosition converts a position in the client area in device-independent pixels into
+// a logical position on the game screen.
+func (u *UserInterface) ClientPositionToLogicalPosition(x, y float64) (float64, float64) {
+	return u.context.clientPositionToLogicalPosition(x, y, u.Monitor().DeviceScaleFactor())
+}
+
+// LogicalPositionToDevicePixelPosition converts a logical position on the game screen into a position in the client area
+// in device pixels.
+func (u *UserInterface) LogicalPositionToDevicePixelPosition(x, y float64) (float64, float64) {
+	return u.context.logicalPositionToClientPosition(x, y, 1)
+}
+
+// DevicePixelPositionToLogicalPosition converts a position in the client area in device pixels into
+// a logical position on the game screen.
+func (u *UserInterface) DevicePixelPositionToLogicalPosition(x, y float64) (float64, float64) {
+	return u.context.clientPositionToLogicalPosition(x, y, 1)
+}
+
 func (c *context) runInFrame(f func()) {
 	ch := make(chan struct{})
 	c.funcsInFrameCh <- func() {
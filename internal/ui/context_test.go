@@ -0,0 +1,96 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"testing"
+)
+
+func TestScreenScaleAndOffsets(t *testing.T) {
+	cases := []struct {
+		Name            string
+		ScreenWidth     float64
+		ScreenHeight    float64
+		OffscreenWidth  float64
+		OffscreenHeight float64
+		Scale           float64
+		OffsetX         float64
+		OffsetY         float64
+	}{
+		{
+			Name:            "same size",
+			ScreenWidth:     640,
+			ScreenHeight:    480,
+			OffscreenWidth:  640,
+			OffscreenHeight: 480,
+			Scale:           1,
+		},
+		{
+			Name:            "integer scale",
+			ScreenWidth:     1280,
+			ScreenHeight:    960,
+			OffscreenWidth:  640,
+			OffscreenHeight: 480,
+			Scale:           2,
+		},
+		{
+			Name:            "letterbox",
+			ScreenWidth:     800,
+			ScreenHeight:    480,
+			OffscreenWidth:  320,
+			OffscreenHeight: 240,
+			Scale:           2,
+			OffsetX:         80,
+		},
+		{
+			Name:            "near-integer scale is not snapped",
+			ScreenWidth:     1281,
+			ScreenHeight:    961,
+			OffscreenWidth:  640,
+			OffscreenHeight: 480,
+			Scale:           1281.0 / 640.0,
+			OffsetY:         (961 - 480*1281.0/640.0) / 2,
+		},
+		{
+			Name:            "fractional scale",
+			ScreenWidth:     960,
+			ScreenHeight:    720,
+			OffscreenWidth:  640,
+			OffscreenHeight: 480,
+			Scale:           1.5,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			c := &context{
+				screenWidth:     tc.ScreenWidth,
+				screenHeight:    tc.ScreenHeight,
+				offscreenWidth:  tc.OffscreenWidth,
+				offscreenHeight: tc.OffscreenHeight,
+			}
+			scale, ox, oy := c.screenScaleAndOffsets()
+			if scale != tc.Scale {
+				t.Errorf("scale: got: %v, want: %v", scale, tc.Scale)
+			}
+			if ox != tc.OffsetX {
+				t.Errorf("offset x: got: %v, want: %v", ox, tc.OffsetX)
+			}
+			if oy != tc.OffsetY {
+				t.Errorf("offset y: got: %v, want: %v", oy, tc.OffsetY)
+			}
+		})
+	}
+}
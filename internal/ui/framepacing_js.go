@@ -0,0 +1,40 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"syscall/js"
+	"time"
+)
+
+// waitUntil waits until the given time with a setTimeout callback.
+//
+// On browsers, busy-waiting blocks the event loop and the page cannot process any events, so precise is ignored.
+func waitUntil(t time.Time, precise bool) {
+	d := time.Until(t)
+	if d <= 0 {
+		return
+	}
+
+	ch := make(chan struct{})
+	var f js.Func
+	f = js.FuncOf(func(this js.Value, args []js.Value) any {
+		f.Release()
+		close(ch)
+		return nil
+	})
+	setTimeout.Invoke(f, float64(d)/float64(time.Millisecond))
+	<-ch
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !js
+
+package ui
+
+import (
+	"runtime"
+	"time"
+)
+
+// waitUntil waits until the given time.
+// If precise is true, waitUntil busy-waits for the last short while.
+func waitUntil(t time.Time, precise bool) {
+	if !precise {
+		if d := time.Until(t); d > 0 {
+			time.Sleep(d)
+		}
+		return
+	}
+
+	// time.Sleep can be much longer than the given duration depending on the OS timer resolution.
+	// Sleep until a little before the deadline, and then busy-wait for the rest.
+	const margin = 2 * time.Millisecond
+	if d := time.Until(t) - margin; d > 0 {
+		time.Sleep(d)
+	}
+	for time.Now().Before(t) {
+		runtime.Gosched()
+	}
+}
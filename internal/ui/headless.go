@@ -0,0 +1,74 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !android && !ios
+
+package ui
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
+	"github.com/hajimehoshi/ebiten/v2/internal/clock"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
+	"github.com/hajimehoshi/ebiten/v2/internal/hook"
+	"github.com/hajimehoshi/ebiten/v2/internal/thread"
+)
+
+// runHeadless runs the game without a window or a graphics device.
+// Only Update is called based on TPS. Layout and Draw are never called.
+func (u *UserInterface) runHeadless(game Game) error {
+	u.mainThread = thread.NewNoopThread()
+
+	// Set the headless state before the running state so that window functions never see the running state without a window.
+	u.headless.Store(true)
+	u.setRunning(true)
+	defer u.setRunning(false)
+
+	u.context = newContext(game)
+
+	for {
+		n := clock.UpdateFrame()
+
+		// Ensure that Update is called once at first so that Update can be used for initialization.
+		if !u.context.updateCalled && n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			u.context.updateCalled = true
+			if err := hook.RunBeforeUpdateHooks(); err != nil {
+				return err
+			}
+			if err := game.Update(); err != nil {
+				return err
+			}
+			if err := u.error(); err != nil {
+				return err
+			}
+		}
+
+		// Drawing commands in Update are never executed as there is no graphics device.
+		// Drop them so that they don't accumulate.
+		atlas.DiscardDeferred()
+		graphicscommand.DiscardCommands()
+
+		// There is no vsync. Sleep for a while so as not to consume CPU.
+		// The clock catches up even if the sleep is longer than expected.
+		interval := time.Second / 60
+		if tps := clock.TPS(); tps > 0 {
+			interval = time.Second / time.Duration(tps)
+		}
+		time.Sleep(interval / 4)
+	}
+}
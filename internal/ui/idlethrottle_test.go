@@ -0,0 +1,56 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdleThrottle(t *testing.T) {
+	u := &UserInterface{}
+
+	if u.IsIdleThrottled() {
+		t.Errorf("IsIdleThrottled(): got: true, want: false when the idle throttle is disabled")
+	}
+
+	u.SetIdleThrottle(time.Second, 5)
+	if d, fps := u.IdleThrottle(); d != time.Second || fps != 5 {
+		t.Errorf("IdleThrottle(): got: (%v, %d), want: (%v, %d)", d, fps, time.Second, 5)
+	}
+	// SetIdleThrottle counts as an activity.
+	if u.IsIdleThrottled() {
+		t.Errorf("IsIdleThrottled(): got: true, want: false just after SetIdleThrottle")
+	}
+
+	// No activities for longer than the duration.
+	u.lastActivityTime.Store(time.Now().Add(-2 * time.Second).UnixNano())
+	if !u.IsIdleThrottled() {
+		t.Errorf("IsIdleThrottled(): got: false, want: true after the duration without activities")
+	}
+
+	// An activity ends the idle throttle.
+	u.notifyActivity()
+	if u.IsIdleThrottled() {
+		t.Errorf("IsIdleThrottled(): got: true, want: false after an activity")
+	}
+
+	// A zero duration disables the idle throttle.
+	u.SetIdleThrottle(0, 5)
+	u.lastActivityTime.Store(time.Now().Add(-2 * time.Second).UnixNano())
+	if u.IsIdleThrottled() {
+		t.Errorf("IsIdleThrottled(): got: true, want: false with a zero duration")
+	}
+}
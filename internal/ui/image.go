@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"image"
 	"math"
+	"regexp"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
@@ -67,6 +68,23 @@ func (u *UserInterface) NewImage(width, height int, imageType atlas.ImageType) *
 	}
 }
 
+// NewExternalImage returns a new read-only image that wraps an existing native texture.
+func (u *UserInterface) NewExternalImage(width, height int, native uintptr) *Image {
+	return &Image{
+		ui:        u,
+		mipmap:    mipmap.NewExternal(width, height, native),
+		width:     width,
+		height:    height,
+		imageType: atlas.ImageTypeExternal,
+		lastBlend: graphicsdriver.BlendSourceOver,
+	}
+}
+
+// ImageType returns the type of the image.
+func (i *Image) ImageType() atlas.ImageType {
+	return i.imageType
+}
+
 func (i *Image) Deallocate() {
 	if i.mipmap == nil {
 		return
@@ -78,6 +96,9 @@ func (i *Image) Deallocate() {
 }
 
 func (i *Image) DrawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, antialias bool) {
+	if i.imageType == atlas.ImageTypeExternal {
+		panic("ui: an external image cannot be modified")
+	}
 	if i.modifyCallback != nil {
 		i.modifyCallback()
 	}
@@ -88,7 +109,7 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices [
 		if i.bigOffscreenBuffer == nil {
 			var imageType atlas.ImageType
 			switch i.imageType {
-			case atlas.ImageTypeRegular, atlas.ImageTypeUnmanaged:
+			case atlas.ImageTypeRegular, atlas.ImageTypeUnmanaged, atlas.ImageTypeExtruded:
 				imageType = atlas.ImageTypeUnmanaged
 			case atlas.ImageTypeScreen, atlas.ImageTypeVolatile:
 				imageType = atlas.ImageTypeVolatile
@@ -117,6 +138,9 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderImageCount]*Image, vertices [
 }
 
 func (i *Image) WritePixels(pix []byte, region image.Rectangle) {
+	if i.imageType == atlas.ImageTypeExternal {
+		panic("ui: an external image cannot be modified")
+	}
 	if i.modifyCallback != nil {
 		i.modifyCallback()
 	}
@@ -140,6 +164,10 @@ func (i *Image) ReadPixels(pixels []byte, region image.Rectangle) {
 	}
 }
 
+func (i *Image) BackendInfo() atlas.BackendInfo {
+	return i.mipmap.BackendInfo()
+}
+
 func (i *Image) DumpScreenshot(name string, blackbg bool) (string, error) {
 	i.flushBufferIfNeeded()
 	return i.ui.dumpScreenshot(i.mipmap, name, blackbg)
@@ -155,14 +183,25 @@ func (i *Image) flushBigOffscreenBufferIfNeeded() {
 	}
 }
 
-func (u *UserInterface) DumpImages(dir string) (string, error) {
-	return u.dumpImages(dir)
+// NativeTexture returns the image's native texture and the texture's size.
+func (i *Image) NativeTexture() (native uintptr, width, height int, err error) {
+	i.flushBufferIfNeeded()
+	return i.ui.nativeTexture(i.mipmap)
+}
+
+func (u *UserInterface) DumpImages(dir string, filter *regexp.Regexp) (string, error) {
+	return u.dumpImages(dir, filter)
 }
 
 func (i *Image) clear() {
 	i.Fill(0, 0, 0, 0, image.Rect(0, 0, i.width, i.height))
 }
 
+func (i *Image) clearWithBackgroundColor() {
+	r, g, b, a := i.ui.BackgroundColor()
+	i.Fill(r, g, b, a, image.Rect(0, 0, i.width, i.height))
+}
+
 func (i *Image) Fill(r, g, b, a float32, region image.Rectangle) {
 	if len(i.tmpVerticesForFill) < 4*graphics.VertexFloatCount {
 		i.tmpVerticesForFill = make([]float32, 4*graphics.VertexFloatCount)
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !android && !ios && !js && !nintendosdk && !playstation5
+//go:build !android && !ios && !js && !nintendosdk && !playstation5 && !ebitenginecustomplatform
 
 package ui
 
@@ -37,6 +37,7 @@ func (u *UserInterface) registerInputCallbacks() error {
 		u.m.Lock()
 		defer u.m.Unlock()
 		u.inputState.appendRune(char)
+		u.notifyActivity()
 	}); err != nil {
 		return err
 	}
@@ -47,6 +48,25 @@ func (u *UserInterface) registerInputCallbacks() error {
 		defer u.m.Unlock()
 		u.inputState.WheelX += xoff
 		u.inputState.WheelY += yoff
+		u.notifyActivity()
+	}); err != nil {
+		return err
+	}
+
+	// The key states and the mouse states are polled at updateInputStateImpl.
+	// These callbacks are only to detect activities for the idle throttle.
+	if _, err := u.window.SetKeyCallback(func(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+		u.notifyActivity()
+	}); err != nil {
+		return err
+	}
+	if _, err := u.window.SetMouseButtonCallback(func(w *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+		u.notifyActivity()
+	}); err != nil {
+		return err
+	}
+	if _, err := u.window.SetCursorPosCallback(func(w *glfw.Window, xpos float64, ypos float64) {
+		u.notifyActivity()
 	}); err != nil {
 		return err
 	}
@@ -122,6 +142,43 @@ func (u *UserInterface) updateInputStateImpl() error {
 	return nil
 }
 
+// LatestCursorPosition returns the cursor position sampled just now in the logical coordinates.
+func (u *UserInterface) LatestCursorPosition() (float64, float64) {
+	u.m.RLock()
+	x, y := u.inputState.CursorX, u.inputState.CursorY
+	u.m.RUnlock()
+
+	if !u.isRunning() {
+		return x, y
+	}
+
+	u.mainThread.Call(func() {
+		if u.isTerminated() {
+			return
+		}
+		m, err := u.currentMonitor()
+		if err != nil {
+			u.setError(err)
+			return
+		}
+		s := m.DeviceScaleFactor()
+
+		cx, cy, err := u.window.GetCursorPos()
+		if err != nil {
+			u.setError(err)
+			return
+		}
+		cx = dipFromGLFWPixel(cx, s)
+		cy = dipFromGLFWPixel(cy, s)
+		cx, cy = u.context.clientPositionToLogicalPosition(cx, cy, s)
+		if math.IsNaN(cx) || math.IsNaN(cy) {
+			return
+		}
+		x, y = cx, cy
+	})
+	return x, y
+}
+
 func (u *UserInterface) KeyName(key Key) string {
 	if !u.isRunning() {
 		return ""
@@ -12,12 +12,13 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !android && !ios && !js && !nintendosdk && !playstation5
+//go:build !android && !ios && !js && !nintendosdk && !playstation5 && !ebitenginecustomplatform
 
 package ui
 
 import (
 	"image"
+	"math"
 	"sync"
 	"sync/atomic"
 
@@ -51,6 +52,23 @@ func (m *Monitor) Size() (int, int) {
 	return int(w), int(h)
 }
 
+// Position returns the position of the monitor's upper-left corner in the virtual desktop.
+// The position is in device-independent pixels scaled by the monitor's device scale factor.
+func (m *Monitor) Position() (int, int) {
+	s := m.DeviceScaleFactor()
+	x := dipFromGLFWPixel(float64(m.boundsInGLFWPixels.Min.X), s)
+	y := dipFromGLFWPixel(float64(m.boundsInGLFWPixels.Min.Y), s)
+	return int(math.Round(x)), int(math.Round(y))
+}
+
+// RefreshRate returns the refresh rate of the monitor in Hz, or 0 if the refresh rate is unknown.
+func (m *Monitor) RefreshRate() int {
+	if m.videoMode == nil {
+		return 0
+	}
+	return m.videoMode.RefreshRate
+}
+
 func (m *Monitor) sizeInDIP() (float64, float64) {
 	w, h := m.boundsInGLFWPixels.Dx(), m.boundsInGLFWPixels.Dy()
 	s := m.DeviceScaleFactor()
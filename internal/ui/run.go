@@ -27,6 +27,9 @@ import (
 )
 
 func (u *UserInterface) Run(game Game, options *RunOptions) error {
+	if options.Headless {
+		return u.runHeadless(game)
+	}
 	if options.SingleThread || buildTagSingleThread || runtime.GOOS == "js" {
 		return u.runSingleThread(game, options)
 	}
@@ -35,7 +38,7 @@ func (u *UserInterface) Run(game Game, options *RunOptions) error {
 
 func (u *UserInterface) runMultiThread(game Game, options *RunOptions) error {
 	u.mainThread = thread.NewOSThread()
-	graphicscommand.SetOSThreadAsRenderThread()
+	graphicscommand.SetOSThreadAsRenderThreadWithQueuedFrames(options.MaxQueuedFrames)
 
 	// Set the running state true after the main thread is set, and before initOnMainThread is called (#2742).
 	// TODO: As the existence of the main thread is the same as the value of `running`, this is redundant.
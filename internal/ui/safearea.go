@@ -0,0 +1,96 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"image"
+	"math"
+	"sync"
+)
+
+// safeArea is the safe area information reported by the platform in device-independent pixels.
+type safeArea struct {
+	// insets is the left, top, right, and bottom insets from the client area's edges.
+	insets [4]float64
+
+	// cutouts is the display cutouts' rectangles in the client area.
+	cutouts []image.Rectangle
+
+	m sync.Mutex
+}
+
+// SetSafeAreaInsets is called from mobile/ebitenmobileview.
+func (u *UserInterface) SetSafeAreaInsets(left, top, right, bottom float64) {
+	u.safeArea.m.Lock()
+	defer u.safeArea.m.Unlock()
+	u.safeArea.insets = [4]float64{left, top, right, bottom}
+}
+
+// ResetDisplayCutouts is called from mobile/ebitenmobileview.
+func (u *UserInterface) ResetDisplayCutouts() {
+	u.safeArea.m.Lock()
+	defer u.safeArea.m.Unlock()
+	u.safeArea.cutouts = u.safeArea.cutouts[:0]
+}
+
+// AddDisplayCutout is called from mobile/ebitenmobileview.
+// The rectangle is in device-independent pixels.
+func (u *UserInterface) AddDisplayCutout(x0, y0, x1, y1 float64) {
+	u.safeArea.m.Lock()
+	defer u.safeArea.m.Unlock()
+	u.safeArea.cutouts = append(u.safeArea.cutouts, image.Rect(int(math.Floor(x0)), int(math.Floor(y0)), int(math.Ceil(x1)), int(math.Ceil(y1))))
+}
+
+// SafeAreaInsets returns the insets of the safe area from the game screen's edges in the logical screen's pixels.
+func (u *UserInterface) SafeAreaInsets() (left, top, right, bottom float64) {
+	u.safeArea.m.Lock()
+	insets := u.safeArea.insets
+	u.safeArea.m.Unlock()
+
+	if insets == [4]float64{} || u.context == nil {
+		return 0, 0, 0, 0
+	}
+	s, ox, oy := u.context.screenScaleAndOffsets()
+	if s == 0 {
+		return 0, 0, 0, 0
+	}
+
+	// The game screen is centered, and the letterbox areas are already out of the game screen.
+	d := u.FrameDeviceScaleFactor()
+	inset := func(v, offset float64) float64 {
+		return math.Max(0, (v*d-offset)/s)
+	}
+	return inset(insets[0], ox), inset(insets[1], oy), inset(insets[2], ox), inset(insets[3], oy)
+}
+
+// AppendDisplayCutouts appends the display cutouts' rectangles in the logical screen's coordinates to cutouts.
+func (u *UserInterface) AppendDisplayCutouts(cutouts []image.Rectangle) []image.Rectangle {
+	u.safeArea.m.Lock()
+	defer u.safeArea.m.Unlock()
+
+	if u.context == nil {
+		return cutouts
+	}
+	d := u.FrameDeviceScaleFactor()
+	for _, r := range u.safeArea.cutouts {
+		x0, y0 := u.context.clientPositionToLogicalPosition(float64(r.Min.X), float64(r.Min.Y), d)
+		x1, y1 := u.context.clientPositionToLogicalPosition(float64(r.Max.X), float64(r.Max.Y), d)
+		if math.IsNaN(x0) || math.IsNaN(x1) {
+			continue
+		}
+		cutouts = append(cutouts, image.Rect(int(math.Floor(x0)), int(math.Floor(y0)), int(math.Ceil(x1)), int(math.Ceil(y1))))
+	}
+	return cutouts
+}
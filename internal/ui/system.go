@@ -0,0 +1,175 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type ColorScheme int
+
+const (
+	ColorSchemeUnknown ColorScheme = iota
+	ColorSchemeLight
+	ColorSchemeDark
+)
+
+// systemInfoCacheDuration is the duration to cache the system information that is polled.
+// Querying the system information might be slow, so the results are cached for a while.
+const systemInfoCacheDuration = time.Second
+
+type systemInfo struct {
+	locales            []string
+	localesLastUpdated time.Time
+
+	colorScheme            ColorScheme
+	colorSchemeLastUpdated time.Time
+
+	// colorSchemeWatching reports whether the color scheme is watched with change notifications from the system.
+	// If the color scheme is not watched, the color scheme is polled.
+	colorSchemeWatching atomic.Bool
+
+	// colorSchemeDirty reports whether the color scheme might be changed after the last query.
+	colorSchemeDirty atomic.Bool
+
+	watchOnce sync.Once
+	m         sync.Mutex
+}
+
+var theSystemInfo systemInfo
+
+func (s *systemInfo) updateLocales() {
+	now := time.Now()
+	if !s.localesLastUpdated.IsZero() && now.Sub(s.localesLastUpdated) < systemInfoCacheDuration {
+		return
+	}
+	s.locales = systemLocales()
+	s.localesLastUpdated = now
+}
+
+// updateColorScheme updates the color scheme.
+//
+// watchSystemColorScheme is implemented for each platform. watchSystemColorScheme starts watching the color scheme,
+// and calls changed when the color scheme might be changed, or stopped when the notifications are no longer available.
+// watchSystemColorScheme returns false if the notifications are not available.
+func (s *systemInfo) updateColorScheme() {
+	s.watchOnce.Do(func() {
+		s.colorSchemeDirty.Store(true)
+		s.colorSchemeWatching.Store(watchSystemColorScheme(s.notifyColorSchemeChanged, s.stopWatchingColorScheme))
+	})
+
+	if s.colorSchemeWatching.Load() {
+		// A notification during the query makes the next call query the color scheme again.
+		if s.colorSchemeDirty.CompareAndSwap(true, false) {
+			s.colorScheme = systemColorScheme()
+		}
+		return
+	}
+
+	now := time.Now()
+	if !s.colorSchemeLastUpdated.IsZero() && now.Sub(s.colorSchemeLastUpdated) < systemInfoCacheDuration {
+		return
+	}
+	s.colorScheme = systemColorScheme()
+	s.colorSchemeLastUpdated = now
+}
+
+// notifyColorSchemeChanged is called when the system notifies that the color scheme might be changed.
+// notifyColorSchemeChanged can be called from any goroutines.
+func (s *systemInfo) notifyColorSchemeChanged() {
+	s.colorSchemeDirty.Store(true)
+}
+
+// stopWatchingColorScheme is called when the change notifications are no longer available.
+// After this, the color scheme is polled.
+// stopWatchingColorScheme can be called from any goroutines.
+func (s *systemInfo) stopWatchingColorScheme() {
+	s.colorSchemeWatching.Store(false)
+}
+
+func (u *UserInterface) AppendSystemLocales(locales []string) []string {
+	theSystemInfo.m.Lock()
+	defer theSystemInfo.m.Unlock()
+	theSystemInfo.updateLocales()
+	return append(locales, theSystemInfo.locales...)
+}
+
+func (u *UserInterface) SystemColorScheme() ColorScheme {
+	theSystemInfo.m.Lock()
+	defer theSystemInfo.m.Unlock()
+	theSystemInfo.updateColorScheme()
+	return theSystemInfo.colorScheme
+}
+
+// posixLocaleToBCP47 converts a POSIX locale name like "en_US.UTF-8" to a BCP 47 language tag like "en-US".
+// posixLocaleToBCP47 returns an empty string for the C and POSIX locales.
+func posixLocaleToBCP47(locale string) string {
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	if locale == "" || locale == "C" || locale == "POSIX" {
+		return ""
+	}
+	return strings.ReplaceAll(locale, "_", "-")
+}
+
+// parsePortalColorScheme parses the result of the org.freedesktop.appearance color-scheme setting
+// from the XDG desktop portal printed by gdbus, like "(<<uint32 1>>,)".
+// parsePortalColorScheme returns false if the result is invalid or there is no preference.
+//
+// See https://flatpak.github.io/xdg-desktop-portal/docs/doc-org.freedesktop.portal.Settings.html
+func parsePortalColorScheme(out string) (ColorScheme, bool) {
+	const prefix = "uint32 "
+	i := strings.Index(out, prefix)
+	if i < 0 || i+len(prefix) >= len(out) {
+		return ColorSchemeUnknown, false
+	}
+	switch out[i+len(prefix)] {
+	case '1':
+		return ColorSchemeDark, true
+	case '2':
+		return ColorSchemeLight, true
+	}
+	// 0 means no preference.
+	return ColorSchemeUnknown, false
+}
+
+// parseGSettingsColorScheme parses the value of GNOME's org.gnome.desktop.interface color-scheme printed by gsettings,
+// like "'prefer-dark'".
+// parseGSettingsColorScheme returns false if the value is invalid or the default.
+func parseGSettingsColorScheme(out string) (ColorScheme, bool) {
+	switch strings.Trim(strings.TrimSpace(out), "'") {
+	case "prefer-dark":
+		return ColorSchemeDark, true
+	case "prefer-light":
+		return ColorSchemeLight, true
+	}
+	return ColorSchemeUnknown, false
+}
+
+// gtkThemeColorScheme returns the color scheme from a GTK theme name like "Adwaita:dark" or "Adwaita-dark".
+func gtkThemeColorScheme(theme string) ColorScheme {
+	theme = strings.ToLower(strings.Trim(strings.TrimSpace(theme), "'"))
+	if theme == "" {
+		return ColorSchemeUnknown
+	}
+	if strings.HasSuffix(theme, ":dark") || strings.HasSuffix(theme, "-dark") {
+		return ColorSchemeDark
+	}
+	return ColorSchemeLight
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ios
+
+package ui
+
+import (
+	"github.com/ebitengine/purego/objc"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/cocoa"
+)
+
+var (
+	class_NSDistributedNotificationCenter = objc.GetClass("NSDistributedNotificationCenter")
+	class_NSLocale                        = objc.GetClass("NSLocale")
+	class_NSUserDefaults                  = objc.GetClass("NSUserDefaults")
+
+	sel_addObserverSelectorNameObject = objc.RegisterName("addObserver:selector:name:object:")
+	sel_count                         = objc.RegisterName("count")
+	sel_defaultCenter                 = objc.RegisterName("defaultCenter")
+	sel_interfaceThemeChanged         = objc.RegisterName("interfaceThemeChanged:")
+	sel_objectAtIndex                 = objc.RegisterName("objectAtIndex:")
+	sel_preferredLanguages            = objc.RegisterName("preferredLanguages")
+	sel_release                       = objc.RegisterName("release")
+	sel_standardUserDefaults          = objc.RegisterName("standardUserDefaults")
+	sel_stringForKey                  = objc.RegisterName("stringForKey:")
+)
+
+func systemLocales() []string {
+	pool := cocoa.NSAutoreleasePool_new()
+	defer pool.Release()
+
+	langs := objc.ID(class_NSLocale).Send(sel_preferredLanguages)
+	if langs == 0 {
+		return nil
+	}
+	n := objc.Send[uint](langs, sel_count)
+	locales := make([]string, 0, n)
+	for i := uint(0); i < n; i++ {
+		locales = append(locales, cocoa.NSString{ID: langs.Send(sel_objectAtIndex, i)}.String())
+	}
+	return locales
+}
+
+func systemColorScheme() ColorScheme {
+	pool := cocoa.NSAutoreleasePool_new()
+	defer pool.Release()
+
+	key := cocoa.NSString_alloc().InitWithUTF8String("AppleInterfaceStyle")
+	defer key.Send(sel_release)
+
+	// AppleInterfaceStyle is "Dark" in the dark mode, and doesn't exist in the light mode.
+	style := objc.ID(class_NSUserDefaults).Send(sel_standardUserDefaults).Send(sel_stringForKey, key.ID)
+	if style == 0 {
+		return ColorSchemeLight
+	}
+	if (cocoa.NSString{ID: style}).String() == "Dark" {
+		return ColorSchemeDark
+	}
+	return ColorSchemeLight
+}
+
+func watchSystemColorScheme(changed func(), stopped func()) bool {
+	class, err := objc.RegisterClass(
+		"EbitengineColorSchemeObserver",
+		objc.GetClass("NSObject"),
+		nil,
+		nil,
+		[]objc.MethodDef{
+			{
+				Cmd: sel_interfaceThemeChanged,
+				Fn: func(id objc.ID, cmd objc.SEL, notification objc.ID) {
+					changed()
+				},
+			},
+		},
+	)
+	if err != nil {
+		return false
+	}
+
+	pool := cocoa.NSAutoreleasePool_new()
+	defer pool.Release()
+
+	name := cocoa.NSString_alloc().InitWithUTF8String("AppleInterfaceThemeChangedNotification")
+	defer name.Send(sel_release)
+
+	// The observer is never released as the notification is observed until the application ends.
+	// A distributed notification is delivered on the main thread's run loop.
+	observer := objc.ID(class).Send(sel_alloc).Send(sel_init)
+	objc.ID(class_NSDistributedNotificationCenter).Send(sel_defaultCenter).Send(sel_addObserverSelectorNameObject, observer, sel_interfaceThemeChanged, name.ID, 0)
+	return true
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"syscall/js"
+)
+
+func systemLocales() []string {
+	navigator := js.Global().Get("navigator")
+	if !navigator.Truthy() {
+		return nil
+	}
+	languages := navigator.Get("languages")
+	if !languages.Truthy() {
+		if l := navigator.Get("language"); l.Truthy() {
+			return []string{l.String()}
+		}
+		return nil
+	}
+	locales := make([]string, languages.Length())
+	for i := range locales {
+		locales[i] = languages.Index(i).String()
+	}
+	return locales
+}
+
+func systemColorScheme() ColorScheme {
+	if !window.Get("matchMedia").Truthy() {
+		return ColorSchemeUnknown
+	}
+	if window.Call("matchMedia", "(prefers-color-scheme: dark)").Get("matches").Bool() {
+		return ColorSchemeDark
+	}
+	if window.Call("matchMedia", "(prefers-color-scheme: light)").Get("matches").Bool() {
+		return ColorSchemeLight
+	}
+	return ColorSchemeUnknown
+}
+
+var colorSchemeMediaQueryList js.Value
+
+func watchSystemColorScheme(changed func(), stopped func()) bool {
+	if !window.Get("matchMedia").Truthy() {
+		return false
+	}
+	mql := window.Call("matchMedia", "(prefers-color-scheme: dark)")
+	if !mql.Get("addEventListener").Truthy() {
+		// Old browsers don't have addEventListener for MediaQueryList.
+		return false
+	}
+	// Keep the MediaQueryList so that it is not garbage-collected with the listener.
+	colorSchemeMediaQueryList = mql
+	// The function is never released as the event is observed until the application ends.
+	mql.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
+		changed()
+		return nil
+	}))
+	return true
+}
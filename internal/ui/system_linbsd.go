@@ -0,0 +1,135 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build (freebsd || (linux && !android) || netbsd || openbsd) && !nintendosdk && !playstation5 && !ebitenginecustomplatform
+
+package ui
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+func systemLocales() []string {
+	var locales []string
+	add := func(locale string) {
+		l := posixLocaleToBCP47(locale)
+		if l == "" {
+			return
+		}
+		for _, l2 := range locales {
+			if l == l2 {
+				return
+			}
+		}
+		locales = append(locales, l)
+	}
+
+	// LANGUAGE is a colon-separated list of the preferred languages, and is prior to the other variables.
+	// See https://www.gnu.org/software/gettext/manual/html_node/The-LANGUAGE-variable.html
+	for _, l := range strings.Split(os.Getenv("LANGUAGE"), ":") {
+		add(l)
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			add(v)
+			break
+		}
+	}
+	return locales
+}
+
+// commandTimeout is the timeout to query the system settings with a command.
+const commandTimeout = time.Second
+
+func runCommand(name string, args ...string) (string, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		return "", false
+	}
+	return string(out), true
+}
+
+var portalReadArgs = []string{
+	"call", "--session",
+	"--dest", "org.freedesktop.portal.Desktop",
+	"--object-path", "/org/freedesktop/portal/desktop",
+	"--method", "org.freedesktop.portal.Settings.Read",
+	"org.freedesktop.appearance", "color-scheme",
+}
+
+func systemColorScheme() ColorScheme {
+	// Use the XDG desktop portal, which works on most of the desktop environments including GNOME and KDE.
+	if out, ok := runCommand("gdbus", portalReadArgs...); ok {
+		if c, ok := parsePortalColorScheme(out); ok {
+			return c
+		}
+	}
+
+	// Use GNOME's settings.
+	if out, ok := runCommand("gsettings", "get", "org.gnome.desktop.interface", "color-scheme"); ok {
+		if c, ok := parseGSettingsColorScheme(out); ok {
+			return c
+		}
+	}
+	if out, ok := runCommand("gsettings", "get", "org.gnome.desktop.interface", "gtk-theme"); ok {
+		if c := gtkThemeColorScheme(out); c != ColorSchemeUnknown {
+			return c
+		}
+	}
+
+	// Use the GTK theme variable as a hint, e.g. "Adwaita:dark".
+	return gtkThemeColorScheme(os.Getenv("GTK_THEME"))
+}
+
+func watchSystemColorScheme(changed func(), stopped func()) bool {
+	var cmd *exec.Cmd
+	if _, ok := runCommand("gdbus", portalReadArgs...); ok {
+		// Monitor the SettingChanged signals of the portal.
+		cmd = exec.Command("gdbus", "monitor", "--session",
+			"--dest", "org.freedesktop.portal.Desktop",
+			"--object-path", "/org/freedesktop/portal/desktop")
+	} else if _, ok := runCommand("gsettings", "get", "org.gnome.desktop.interface", "color-scheme"); ok {
+		// Monitor the changes of the keys like color-scheme and gtk-theme.
+		cmd = exec.Command("gsettings", "monitor", "org.gnome.desktop.interface")
+	} else {
+		return false
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return false
+	}
+	if err := cmd.Start(); err != nil {
+		return false
+	}
+
+	go func() {
+		s := bufio.NewScanner(stdout)
+		for s.Scan() {
+			if l := s.Text(); strings.Contains(l, "color-scheme") || strings.Contains(l, "gtk-theme") {
+				changed()
+			}
+		}
+		_ = cmd.Wait()
+		stopped()
+	}()
+	return true
+}
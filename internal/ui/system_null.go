@@ -0,0 +1,30 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build android || ios || nintendosdk || playstation5 || ebitenginecustomplatform
+
+package ui
+
+func systemLocales() []string {
+	// TODO: Implement this for the mobiles and the consoles.
+	return nil
+}
+
+func systemColorScheme() ColorScheme {
+	return ColorSchemeUnknown
+}
+
+func watchSystemColorScheme(changed func(), stopped func()) bool {
+	return false
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"testing"
+)
+
+func TestParsePortalColorScheme(t *testing.T) {
+	testCases := []struct {
+		Out    string
+		Want   ColorScheme
+		WantOK bool
+	}{
+		{Out: "(<<uint32 1>>,)\n", Want: ColorSchemeDark, WantOK: true},
+		{Out: "(<<uint32 2>>,)\n", Want: ColorSchemeLight, WantOK: true},
+		// ReadOne returns a value without the nested variant.
+		{Out: "(<uint32 1>,)\n", Want: ColorSchemeDark, WantOK: true},
+		// No preference.
+		{Out: "(<<uint32 0>>,)\n", Want: ColorSchemeUnknown, WantOK: false},
+		{Out: "", Want: ColorSchemeUnknown, WantOK: false},
+		{Out: "uint32 ", Want: ColorSchemeUnknown, WantOK: false},
+	}
+	for _, tc := range testCases {
+		got, ok := parsePortalColorScheme(tc.Out)
+		if got != tc.Want || ok != tc.WantOK {
+			t.Errorf("parsePortalColorScheme(%q): got: (%v, %v), want: (%v, %v)", tc.Out, got, ok, tc.Want, tc.WantOK)
+		}
+	}
+}
+
+func TestParseGSettingsColorScheme(t *testing.T) {
+	testCases := []struct {
+		Out    string
+		Want   ColorScheme
+		WantOK bool
+	}{
+		{Out: "'prefer-dark'\n", Want: ColorSchemeDark, WantOK: true},
+		{Out: "'prefer-light'\n", Want: ColorSchemeLight, WantOK: true},
+		{Out: "'default'\n", Want: ColorSchemeUnknown, WantOK: false},
+		{Out: "", Want: ColorSchemeUnknown, WantOK: false},
+	}
+	for _, tc := range testCases {
+		got, ok := parseGSettingsColorScheme(tc.Out)
+		if got != tc.Want || ok != tc.WantOK {
+			t.Errorf("parseGSettingsColorScheme(%q): got: (%v, %v), want: (%v, %v)", tc.Out, got, ok, tc.Want, tc.WantOK)
+		}
+	}
+}
+
+func TestGTKThemeColorScheme(t *testing.T) {
+	testCases := []struct {
+		Theme string
+		Want  ColorScheme
+	}{
+		{Theme: "Adwaita:dark", Want: ColorSchemeDark},
+		{Theme: "Adwaita-dark", Want: ColorSchemeDark},
+		{Theme: "'Yaru-Dark'\n", Want: ColorSchemeDark},
+		{Theme: "Adwaita", Want: ColorSchemeLight},
+		{Theme: "'Adwaita'\n", Want: ColorSchemeLight},
+		{Theme: "", Want: ColorSchemeUnknown},
+	}
+	for _, tc := range testCases {
+		if got := gtkThemeColorScheme(tc.Theme); got != tc.Want {
+			t.Errorf("gtkThemeColorScheme(%q): got: %v, want: %v", tc.Theme, got, tc.Want)
+		}
+	}
+}
+
+func TestSystemColorSchemeNotifications(t *testing.T) {
+	var s systemInfo
+	// Start watching, which is not available in this environment.
+	s.updateColorScheme()
+
+	// Emulate the change notifications. The first query is done regardless of notifications.
+	s.colorSchemeWatching.Store(true)
+	s.updateColorScheme()
+	s.colorScheme = ColorSchemeDark
+	s.updateColorScheme()
+	if got, want := s.colorScheme, ColorSchemeDark; got != want {
+		t.Errorf("colorScheme: got: %v, want: %v without notifications", got, want)
+	}
+
+	s.notifyColorSchemeChanged()
+	s.updateColorScheme()
+	if got, want := s.colorScheme, systemColorScheme(); got != want {
+		t.Errorf("colorScheme: got: %v, want: %v after a notification", got, want)
+	}
+	if s.colorSchemeDirty.Load() {
+		t.Errorf("colorSchemeDirty: got: true, want: false after the query")
+	}
+
+	s.stopWatchingColorScheme()
+	if s.colorSchemeWatching.Load() {
+		t.Errorf("colorSchemeWatching: got: true, want: false after stopping")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"runtime"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+const personalizeRegistryPath = `Software\Microsoft\Windows\CurrentVersion\Themes\Personalize`
+
+func systemLocales() []string {
+	langs, err := _GetUserPreferredUILanguages(_MUI_LANGUAGE_NAME)
+	if err != nil {
+		return nil
+	}
+	return langs
+}
+
+func systemColorScheme() ColorScheme {
+	k, err := registry.OpenKey(registry.CURRENT_USER, personalizeRegistryPath, registry.QUERY_VALUE)
+	if err != nil {
+		return ColorSchemeUnknown
+	}
+	defer func() {
+		_ = k.Close()
+	}()
+
+	// AppsUseLightTheme is 0 when the dark mode is enabled for apps.
+	v, _, err := k.GetIntegerValue("AppsUseLightTheme")
+	if err != nil {
+		return ColorSchemeUnknown
+	}
+	if v == 0 {
+		return ColorSchemeDark
+	}
+	return ColorSchemeLight
+}
+
+func watchSystemColorScheme(changed func(), stopped func()) bool {
+	k, err := registry.OpenKey(registry.CURRENT_USER, personalizeRegistryPath, registry.NOTIFY)
+	if err != nil {
+		return false
+	}
+
+	go func() {
+		// A notification registration is bound to the thread.
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		defer func() {
+			_ = k.Close()
+		}()
+		for {
+			// RegNotifyChangeKeyValue blocks until a value of the key is changed.
+			if err := windows.RegNotifyChangeKeyValue(windows.Handle(k), false, windows.REG_NOTIFY_CHANGE_LAST_SET, 0, false); err != nil {
+				stopped()
+				return
+			}
+			changed()
+		}
+	}()
+	return true
+}
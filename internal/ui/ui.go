@@ -17,12 +17,16 @@ package ui
 import (
 	"errors"
 	"image"
+	"math"
+	"regexp"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	_ "github.com/ebitengine/hideconsole"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
 	"github.com/hajimehoshi/ebiten/v2/internal/mipmap"
 	"github.com/hajimehoshi/ebiten/v2/internal/thread"
 )
@@ -71,14 +75,40 @@ const (
 	WindowResizingModeEnabled
 )
 
+type TaskbarProgressState int
+
+const (
+	TaskbarProgressStateNone TaskbarProgressState = iota
+	TaskbarProgressStateIndeterminate
+	TaskbarProgressStateNormal
+	TaskbarProgressStatePaused
+	TaskbarProgressStateError
+)
+
 type UserInterface struct {
 	err  error
 	errM sync.Mutex
 
 	isScreenClearedEveryFrame atomic.Bool
+	backgroundColor           atomic.Uint64
 	graphicsLibrary           atomic.Int32
 	running                   atomic.Bool
 	terminated                atomic.Bool
+	headless                  atomic.Bool
+	lastFrameDuration         atomic.Int64
+	screenPresentationSkipped atomic.Bool
+	frameRateLimit            atomic.Int32
+	preciseFramePacing        atomic.Bool
+	idleThrottleDuration      atomic.Int64
+	idleThrottleFPS           atomic.Int32
+	lastActivityTime          atomic.Int64
+	frameDeviceScaleFactor    atomic.Uint64
+	minFrameInterval          atomic.Int64
+	maxFrameInterval          atomic.Int64
+	frameIntervalRangeDirty   atomic.Bool
+
+	safeArea    safeArea
+	backGesture backGesture
 
 	whiteImage *Image
 
@@ -129,6 +159,9 @@ func (u *UserInterface) readPixels(mipmap *mipmap.Mipmap, pixels []byte, region
 	if !u.running.Load() {
 		panic("ui: ReadPixels cannot be called before the game starts")
 	}
+	if u.headless.Load() {
+		panic("ui: ReadPixels cannot be called in the headless mode")
+	}
 
 	ok, err := mipmap.ReadPixels(u.graphicsDriver, pixels, region)
 	if err != nil {
@@ -161,12 +194,57 @@ func (u *UserInterface) readPixels(mipmap *mipmap.Mipmap, pixels []byte, region
 	return nil
 }
 
+func (u *UserInterface) nativeTexture(mipmap *mipmap.Mipmap) (native uintptr, width, height int, err error) {
+	if !u.running.Load() {
+		return 0, 0, 0, errors.New("ui: a native texture cannot be got before the game starts")
+	}
+
+	native, width, height, ok, err := mipmap.NativeTexture(u.graphicsDriver)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if ok {
+		return native, width, height, nil
+	}
+
+	// NativeTexture failed since this was called in between two frames.
+	// Try this again at the next frame.
+	u.context.runInFrame(func() {
+		var ok bool
+		native, width, height, ok, err = mipmap.NativeTexture(u.graphicsDriver)
+		if err != nil {
+			return
+		}
+		if !ok {
+			// This never reaches since this function must be called in a frame.
+			panic("ui: NativeTexture unexpectedly failed")
+		}
+	})
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return native, width, height, nil
+}
+
 func (u *UserInterface) dumpScreenshot(mipmap *mipmap.Mipmap, name string, blackbg bool) (string, error) {
 	return mipmap.DumpScreenshot(u.graphicsDriver, name, blackbg)
 }
 
-func (u *UserInterface) dumpImages(dir string) (string, error) {
-	return atlas.DumpImages(u.graphicsDriver, dir)
+func (u *UserInterface) dumpImages(dir string, filter *regexp.Regexp) (string, error) {
+	return atlas.DumpImages(u.graphicsDriver, dir, filter)
+}
+
+// MaxImageSize returns the maximum width and height of an image.
+// MaxImageSize returns 0 before the game starts.
+func (u *UserInterface) MaxImageSize() int {
+	return atlas.MaxImageSize()
+}
+
+func (u *UserInterface) IsLogicOperationAvailable() bool {
+	if u.graphicsDriver == nil {
+		return false
+	}
+	return graphicscommand.IsLogicOperationAvailable(u.graphicsDriver)
 }
 
 type RunOptions struct {
@@ -175,6 +253,8 @@ type RunOptions struct {
 	ScreenTransparent bool
 	SkipTaskbar       bool
 	SingleThread      bool
+	MaxQueuedFrames   int
+	Headless          bool
 	X11ClassName      string
 	X11InstanceName   string
 }
@@ -206,6 +286,22 @@ func (u *UserInterface) SetScreenClearedEveryFrame(cleared bool) {
 	u.isScreenClearedEveryFrame.Store(cleared)
 }
 
+// BackgroundColor returns the color in premultiplied alpha to clear the screen with.
+func (u *UserInterface) BackgroundColor() (r, g, b, a float32) {
+	c := u.backgroundColor.Load()
+	return float32(c>>48) / 0xffff, float32((c>>32)&0xffff) / 0xffff, float32((c>>16)&0xffff) / 0xffff, float32(c&0xffff) / 0xffff
+}
+
+// SetBackgroundColor sets the color in premultiplied alpha to clear the screen with.
+// Each value is in 16 bits like color.RGBA64.
+func (u *UserInterface) SetBackgroundColor(r, g, b, a uint16) {
+	u.backgroundColor.Store(uint64(r)<<48 | uint64(g)<<32 | uint64(b)<<16 | uint64(a))
+}
+
+func (u *UserInterface) hasBackgroundColor() bool {
+	return u.backgroundColor.Load() != 0
+}
+
 func (u *UserInterface) setGraphicsLibrary(library GraphicsLibrary) {
 	u.graphicsLibrary.Store(int32(library))
 }
@@ -214,8 +310,86 @@ func (u *UserInterface) GraphicsLibrary() GraphicsLibrary {
 	return GraphicsLibrary(u.graphicsLibrary.Load())
 }
 
+// LastFrameDuration returns the time taken to update and draw the last frame.
+// This doesn't include the time to present the screen.
+func (u *UserInterface) LastFrameDuration() time.Duration {
+	return time.Duration(u.lastFrameDuration.Load())
+}
+
+// IsScreenPresentationSkipped reports whether presenting the screen is skipped at the current frame
+// unless the offscreen is modified.
+func (u *UserInterface) IsScreenPresentationSkipped() bool {
+	return u.screenPresentationSkipped.Load()
+}
+
+// FrameRateLimit returns the maximum number of frames per second. 0 means no limit.
+func (u *UserInterface) FrameRateLimit() int {
+	return int(u.frameRateLimit.Load())
+}
+
+func (u *UserInterface) SetFrameRateLimit(fps int) {
+	u.frameRateLimit.Store(int32(fps))
+}
+
+// IsPreciseFramePacing reports whether the frame pacing uses busy-waiting for precise timings.
+func (u *UserInterface) IsPreciseFramePacing() bool {
+	return u.preciseFramePacing.Load()
+}
+
+func (u *UserInterface) SetPreciseFramePacing(precise bool) {
+	u.preciseFramePacing.Store(precise)
+}
+
+// IdleThrottle returns the duration without activities to start the idle throttle and the FPS while idling.
+// A zero duration means the idle throttle is disabled.
+func (u *UserInterface) IdleThrottle() (time.Duration, int) {
+	return time.Duration(u.idleThrottleDuration.Load()), int(u.idleThrottleFPS.Load())
+}
+
+func (u *UserInterface) SetIdleThrottle(duration time.Duration, fps int) {
+	u.idleThrottleDuration.Store(int64(duration))
+	u.idleThrottleFPS.Store(int32(fps))
+	u.notifyActivity()
+}
+
+// notifyActivity records an activity like an input event, which ends the idle throttle.
+func (u *UserInterface) notifyActivity() {
+	u.lastActivityTime.Store(time.Now().UnixNano())
+}
+
+// IsIdleThrottled reports whether the idle throttle is working now.
+func (u *UserInterface) IsIdleThrottled() bool {
+	d := u.idleThrottleDuration.Load()
+	if d <= 0 {
+		return false
+	}
+	return time.Now().UnixNano()-u.lastActivityTime.Load() >= d
+}
+
+// FrameIntervalRange returns the range of the intervals between presenting frames. A zero value means no limit.
+func (u *UserInterface) FrameIntervalRange() (time.Duration, time.Duration) {
+	return time.Duration(u.minFrameInterval.Load()), time.Duration(u.maxFrameInterval.Load())
+}
+
+func (u *UserInterface) SetFrameIntervalRange(minInterval, maxInterval time.Duration) {
+	u.minFrameInterval.Store(int64(minInterval))
+	u.maxFrameInterval.Store(int64(maxInterval))
+	u.frameIntervalRangeDirty.Store(true)
+}
+
+// FrameDeviceScaleFactor returns the device scale factor used for the layout of the current frame.
+// Before the first frame, FrameDeviceScaleFactor returns the monitor's device scale factor.
+func (u *UserInterface) FrameDeviceScaleFactor() float64 {
+	if s := math.Float64frombits(u.frameDeviceScaleFactor.Load()); s > 0 {
+		return s
+	}
+	return u.Monitor().DeviceScaleFactor()
+}
+
+// isRunning reports whether the game is running with a window.
+// isRunning returns false in the headless mode, as there is no window to operate.
 func (u *UserInterface) isRunning() bool {
-	return u.running.Load() && !u.isTerminated()
+	return u.running.Load() && !u.headless.Load() && !u.isTerminated()
 }
 
 func (u *UserInterface) setRunning(running bool) {
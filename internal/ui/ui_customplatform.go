@@ -0,0 +1,212 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ebitenginecustomplatform
+
+package ui
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/customplatform"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver/opengl"
+)
+
+type graphicsDriverCreatorImpl struct{}
+
+func (g *graphicsDriverCreatorImpl) newAuto() (graphicsdriver.Graphics, GraphicsLibrary, error) {
+	graphics, err := g.newOpenGL()
+	return graphics, GraphicsLibraryOpenGL, err
+}
+
+func (*graphicsDriverCreatorImpl) newOpenGL() (graphicsdriver.Graphics, error) {
+	return opengl.NewGraphics()
+}
+
+func (*graphicsDriverCreatorImpl) newDirectX() (graphicsdriver.Graphics, error) {
+	return nil, errors.New("ui: DirectX is not supported in this environment")
+}
+
+func (*graphicsDriverCreatorImpl) newMetal() (graphicsdriver.Graphics, error) {
+	return nil, errors.New("ui: Metal is not supported in this environment")
+}
+
+func (*graphicsDriverCreatorImpl) newPlayStation5() (graphicsdriver.Graphics, error) {
+	return nil, errors.New("ui: PlayStation 5 is not supported in this environment")
+}
+
+func init() {
+	runtime.LockOSThread()
+}
+
+type userInterfaceImpl struct {
+	graphicsDriver graphicsdriver.Graphics
+
+	context       *context
+	inputState    InputState
+	nativeTouches []customplatform.Touch
+
+	m sync.Mutex
+}
+
+func (u *UserInterface) init() error {
+	return nil
+}
+
+func (u *UserInterface) initOnMainThread(options *RunOptions) error {
+	p := customplatform.Get()
+	if p == nil {
+		return errors.New("ui: no platform layer is registered; call platform.Register in the package exp/platform")
+	}
+	if err := p.Initialize(); err != nil {
+		return err
+	}
+
+	g, lib, err := newGraphicsDriver(&graphicsDriverCreatorImpl{}, options.GraphicsLibrary)
+	if err != nil {
+		return err
+	}
+	u.graphicsDriver = g
+	u.setGraphicsLibrary(lib)
+
+	return nil
+}
+
+func (u *UserInterface) loopGame() error {
+	p := customplatform.Get()
+	for {
+		w, h := p.ScreenSize()
+		if err := u.context.updateFrame(u.graphicsDriver, float64(w), float64(h), theMonitor.DeviceScaleFactor(), u); err != nil {
+			return err
+		}
+	}
+}
+
+func (*UserInterface) IsFocused() bool {
+	return true
+}
+
+func (u *UserInterface) readInputState(inputState *InputState) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.inputState.copyAndReset(inputState)
+}
+
+func (*UserInterface) CursorMode() CursorMode {
+	return CursorModeHidden
+}
+
+func (*UserInterface) SetCursorMode(mode CursorMode) {
+}
+
+func (*UserInterface) SetCursorPosition(x, y float64) {
+}
+
+func (*UserInterface) LatestCursorPosition() (float64, float64) {
+	return 0, 0
+}
+
+func (*UserInterface) CursorShape() CursorShape {
+	return CursorShapeDefault
+}
+
+func (*UserInterface) SetCursorShape(shape CursorShape) {
+}
+
+func (*UserInterface) ClipboardText() (string, error) {
+	return "", errors.New("ui: the clipboard is not supported in this environment")
+}
+
+func (*UserInterface) SetClipboardText(text string) error {
+	return errors.New("ui: the clipboard is not supported in this environment")
+}
+
+func (*UserInterface) IsFullscreen() bool {
+	return false
+}
+
+func (*UserInterface) SetFullscreen(fullscreen bool) {
+}
+
+func (*UserInterface) IsRunnableOnUnfocused() bool {
+	return false
+}
+
+func (*UserInterface) SetRunnableOnUnfocused(runnableOnUnfocused bool) {
+}
+
+func (*UserInterface) FPSMode() FPSModeType {
+	return FPSModeVsyncOn
+}
+
+func (*UserInterface) SetFPSMode(mode FPSModeType) {
+}
+
+func (*UserInterface) ScheduleFrame() {
+}
+
+func (*UserInterface) Window() Window {
+	return &nullWindow{}
+}
+
+func (u *UserInterface) updateIconIfNeeded() error {
+	return nil
+}
+
+type Monitor struct{}
+
+var theMonitor = &Monitor{}
+
+func (m *Monitor) Name() string {
+	return ""
+}
+
+func (m *Monitor) DeviceScaleFactor() float64 {
+	return 1
+}
+
+func (m *Monitor) Size() (int, int) {
+	p := customplatform.Get()
+	if p == nil {
+		return 0, 0
+	}
+	return p.ScreenSize()
+}
+
+func (m *Monitor) Position() (int, int) {
+	return 0, 0
+}
+
+func (m *Monitor) RefreshRate() int {
+	return 0
+}
+
+func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
+	return append(mons, theMonitor)
+}
+
+func (u *UserInterface) Monitor() *Monitor {
+	return theMonitor
+}
+
+func IsScreenTransparentAvailable() bool {
+	return false
+}
+
+func dipToNativePixels(x float64, scale float64) float64 {
+	return x
+}
@@ -433,3 +433,7 @@ func initializeWindowAfterCreation(w *glfw.Window) error {
 func (u *UserInterface) skipTaskbar() error {
 	return nil
 }
+
+func (u *UserInterface) setTaskbarProgress(state TaskbarProgressState, value float64) error {
+	return nil
+}
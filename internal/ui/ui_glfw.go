@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !android && !ios && !js && !nintendosdk && !playstation5
+//go:build !android && !ios && !js && !nintendosdk && !playstation5 && !ebitenginecustomplatform
 
 package ui
 
@@ -86,11 +86,15 @@ type userInterfaceImpl struct {
 
 	origWindowPosX        int
 	origWindowPosY        int
-	origWindowWidthInDIP  int
-	origWindowHeightInDIP int
+	origWindowWidthInDIP  float64
+	origWindowHeightInDIP float64
 
 	fpsModeInited bool
 
+	// glfwInitErr is an error at initializing GLFW.
+	// The error is reported when the game starts with a window, and ignored in the headless mode.
+	glfwInitErr error
+
 	inputState   InputState
 	iwindow      glfwWindow
 	savedCursorX float64
@@ -145,7 +149,10 @@ func (u *UserInterface) init() error {
 		return err
 	}
 	if err := u.initializeGLFW(); err != nil {
-		return err
+		// GLFW might not be available, e.g., when there is no display.
+		// Report the error later, as the game might run in the headless mode.
+		u.glfwInitErr = err
+		return nil
 	}
 	if _, err := glfw.SetMonitorCallback(func(monitor *glfw.Monitor, event glfw.PeripheralEvent) {
 		if err := theMonitors.update(); err != nil {
@@ -322,8 +329,8 @@ func (u *UserInterface) setWindowMonitor(monitor *Monitor) error {
 	}
 
 	s := monitor.DeviceScaleFactor()
-	w := dipToGLFWPixel(float64(ww), s)
-	h := dipToGLFWPixel(float64(wh), s)
+	w := dipToGLFWPixel(ww, s)
+	h := dipToGLFWPixel(wh, s)
 	mx := monitor.boundsInGLFWPixels.Min.X
 	my := monitor.boundsInGLFWPixels.Min.Y
 	mw, mh := monitor.sizeInDIP()
@@ -709,6 +716,7 @@ func (u *UserInterface) ScheduleFrame() {
 	if !u.isRunning() {
 		return
 	}
+	u.notifyActivity()
 	// As the main thread can be blocked, do not check the current FPS mode.
 	// PostEmptyEvent is concurrent safe.
 	if err := glfw.PostEmptyEvent(); err != nil {
@@ -777,6 +785,30 @@ func (u *UserInterface) SetCursorMode(mode CursorMode) {
 	})
 }
 
+func (u *UserInterface) SetCursorPosition(x, y float64) {
+	if u.isTerminated() {
+		return
+	}
+	if !u.isRunning() {
+		return
+	}
+	// In the captured mode, the cursor position is virtual and moving the system cursor doesn't make sense.
+	if u.CursorMode() == CursorModeCaptured {
+		return
+	}
+	// Do not move the cursor when the user is using another window.
+	if !u.IsFocused() {
+		return
+	}
+
+	// The cursor is moved at the next input state update, in the same way as restoring the cursor position
+	// after switching the fullscreen mode.
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.savedCursorX = x
+	u.savedCursorY = y
+}
+
 func (u *UserInterface) CursorShape() CursorShape {
 	return u.getCursorShape()
 }
@@ -804,6 +836,43 @@ func (u *UserInterface) SetCursorShape(shape CursorShape) {
 	})
 }
 
+func (u *UserInterface) ClipboardText() (string, error) {
+	if u.isTerminated() {
+		return "", nil
+	}
+	if !u.isRunning() {
+		return "", errors.New("ui: the clipboard is not available before the game starts")
+	}
+
+	var text string
+	var err error
+	u.mainThread.Call(func() {
+		if u.isTerminated() {
+			return
+		}
+		text, err = glfw.GetClipboardString()
+	})
+	return text, err
+}
+
+func (u *UserInterface) SetClipboardText(text string) error {
+	if u.isTerminated() {
+		return nil
+	}
+	if !u.isRunning() {
+		return errors.New("ui: the clipboard is not available before the game starts")
+	}
+
+	var err error
+	u.mainThread.Call(func() {
+		if u.isTerminated() {
+			return
+		}
+		err = u.window.SetClipboardString(text)
+	})
+	return err
+}
+
 // createWindow creates a GLFW window.
 //
 // createWindow must be called from the main thread.
@@ -839,12 +908,12 @@ func (u *UserInterface) createWindow() error {
 	if wy < 0 {
 		wy = 0
 	}
-	if err := u.setWindowPositionInDIP(wx, wy, monitor); err != nil {
+	if err := u.setWindowPositionInDIP(float64(wx), float64(wy), monitor); err != nil {
 		return err
 	}
 
 	// Though the size is already specified, call setWindowSizeInDIP explicitly to adjust member variables.
-	if err := u.setWindowSizeInDIP(ww, wh, true); err != nil {
+	if err := u.setWindowSizeInDIP(float64(ww), float64(wh), true); err != nil {
 		return err
 	}
 
@@ -938,8 +1007,10 @@ func (u *UserInterface) registerWindowFramebufferSizeCallback() error {
 				return
 			}
 			s := m.DeviceScaleFactor()
-			ww := int(float64(w) / s)
-			wh := int(float64(h) / s)
+			// Do not round the size in device-independent pixels, or the size is shrunk gradually
+			// with a fractional device scale factor.
+			ww := float64(w) / s
+			wh := float64(h) / s
 			if err := u.setWindowSizeInDIP(ww, wh, false); err != nil {
 				u.setError(err)
 				return
@@ -1023,6 +1094,10 @@ event:
 }
 
 func (u *UserInterface) initOnMainThread(options *RunOptions) error {
+	if u.glfwInitErr != nil {
+		return u.glfwInitErr
+	}
+
 	if err := glfw.WindowHint(glfw.AutoIconify, glfw.False); err != nil {
 		return err
 	}
@@ -1198,7 +1273,7 @@ func (u *UserInterface) outsideSize() (float64, float64, error) {
 		return 0, 0, err
 	}
 	if a == glfw.True {
-		return float64(u.origWindowWidthInDIP), float64(u.origWindowHeightInDIP), nil
+		return u.origWindowWidthInDIP, u.origWindowHeightInDIP, nil
 	}
 
 	// Instead of u.origWindow{Width,Height}InDIP, use the actual window size here.
@@ -1318,8 +1393,8 @@ func (u *UserInterface) update() (float64, float64, error) {
 				return
 			}
 			s := m.DeviceScaleFactor()
-			newW := int(dipToGLFWPixel(float64(u.origWindowWidthInDIP), s))
-			newH := int(dipToGLFWPixel(float64(u.origWindowHeightInDIP), s))
+			newW := int(dipToGLFWPixel(u.origWindowWidthInDIP, s))
+			newH := int(dipToGLFWPixel(u.origWindowHeightInDIP, s))
 
 			// Even though a framebuffer callback is not called, waitForFramebufferSizeCallback returns by timeout,
 			// so it is safe to use this.
@@ -1343,7 +1418,28 @@ func (u *UserInterface) update() (float64, float64, error) {
 		}
 	}
 
-	if u.fpsMode != FPSModeVsyncOffMinimum {
+	if u.fpsMode != FPSModeVsyncOffMinimum && u.IsIdleThrottled() {
+		// Wait for events for a while. An input event wakes the main thread up instantly.
+		// Present a frame at least every maxInterval of SetFrameIntervalRange.
+		_, maxInterval := u.FrameIntervalRange()
+		if _, fps := u.IdleThrottle(); fps > 0 {
+			timeout := 1 / float64(fps)
+			if maxInterval > 0 && maxInterval.Seconds() < timeout {
+				timeout = maxInterval.Seconds()
+			}
+			if err := glfw.WaitEventsTimeout(timeout); err != nil {
+				return 0, 0, err
+			}
+		} else if maxInterval > 0 {
+			if err := glfw.WaitEventsTimeout(maxInterval.Seconds()); err != nil {
+				return 0, 0, err
+			}
+		} else {
+			if err := glfw.WaitEvents(); err != nil {
+				return 0, 0, err
+			}
+		}
+	} else if u.fpsMode != FPSModeVsyncOffMinimum {
 		// TODO: Updating the input can be skipped when clock.Update returns 0 (#1367).
 		if err := glfw.PollEvents(); err != nil {
 			return 0, 0, err
@@ -1604,20 +1700,38 @@ func (u *UserInterface) adjustWindowSizeBasedOnSizeLimitsInDIP(width, height int
 	return width, height
 }
 
+// adjustWindowSizeBasedOnSizeLimitsInDIPF is the float version of adjustWindowSizeBasedOnSizeLimitsInDIP.
+func (u *UserInterface) adjustWindowSizeBasedOnSizeLimitsInDIPF(width, height float64) (float64, float64) {
+	minw, minh, maxw, maxh := u.getWindowSizeLimitsInDIP()
+	if minw >= 0 && width < float64(minw) {
+		width = float64(minw)
+	}
+	if minh >= 0 && height < float64(minh) {
+		height = float64(minh)
+	}
+	if maxw >= 0 && width > float64(maxw) {
+		width = float64(maxw)
+	}
+	if maxh >= 0 && height > float64(maxh) {
+		height = float64(maxh)
+	}
+	return width, height
+}
+
 // setWindowSize must be called from the main thread.
-func (u *UserInterface) setWindowSizeInDIP(width, height int, callSetSize bool) error {
+func (u *UserInterface) setWindowSizeInDIP(width, height float64, callSetSize bool) error {
 	if microsoftgdk.IsXbox() {
 		// Do nothing. The size is always fixed.
 		return nil
 	}
 
-	width, height = u.adjustWindowSizeBasedOnSizeLimitsInDIP(width, height)
+	width, height = u.adjustWindowSizeBasedOnSizeLimitsInDIPF(width, height)
 	m, err := u.minimumWindowWidth()
 	if err != nil {
 		return err
 	}
-	if width < m {
-		width = m
+	if width < float64(m) {
+		width = float64(m)
 	}
 	if height < 1 {
 		height = 1
@@ -1652,8 +1766,8 @@ func (u *UserInterface) setWindowSizeInDIP(width, height int, callSetSize bool)
 			return err
 		}
 		s := m.DeviceScaleFactor()
-		newW := int(dipToGLFWPixel(float64(width), s))
-		newH := int(dipToGLFWPixel(float64(height), s))
+		newW := int(dipToGLFWPixel(width, s))
+		newH := int(dipToGLFWPixel(height, s))
 		if oldW != newW || oldH != newH {
 			// Just after SetSize, GetSize is not reliable especially on Linux/UNIX.
 			// Let's wait for FramebufferSize callback in any cases.
@@ -1753,8 +1867,8 @@ func (u *UserInterface) setFullscreen(fullscreen bool) error {
 		return err
 	}
 	s := m.DeviceScaleFactor()
-	ww := int(dipToGLFWPixel(float64(u.origWindowWidthInDIP), s))
-	wh := int(dipToGLFWPixel(float64(u.origWindowHeightInDIP), s))
+	ww := int(dipToGLFWPixel(u.origWindowWidthInDIP, s))
+	wh := int(dipToGLFWPixel(u.origWindowHeightInDIP, s))
 	if u.isNativeFullscreenAvailable() {
 		if err := u.setNativeFullscreen(false); err != nil {
 			return err
@@ -2061,7 +2175,7 @@ func (u *UserInterface) setWindowResizingMode(mode WindowResizingMode) error {
 // x and y are the position in device-independent pixels.
 //
 // setWindowPositionInDIP must be called from the main thread.
-func (u *UserInterface) setWindowPositionInDIP(x, y int, monitor *Monitor) error {
+func (u *UserInterface) setWindowPositionInDIP(x, y float64, monitor *Monitor) error {
 	if microsoftgdk.IsXbox() {
 		// Do nothing. The position is always fixed.
 		return nil
@@ -2075,8 +2189,8 @@ func (u *UserInterface) setWindowPositionInDIP(x, y int, monitor *Monitor) error
 	mx := monitor.boundsInGLFWPixels.Min.X
 	my := monitor.boundsInGLFWPixels.Min.Y
 	s := monitor.DeviceScaleFactor()
-	xf := dipToGLFWPixel(float64(x), s)
-	yf := dipToGLFWPixel(float64(y), s)
+	xf := dipToGLFWPixel(x, s)
+	yf := dipToGLFWPixel(y, s)
 	if x, y := u.adjustWindowPosition(mx+int(xf), my+int(yf), monitor); f {
 		u.setOrigWindowPos(x, y)
 	} else {
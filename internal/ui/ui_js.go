@@ -212,6 +212,15 @@ func (u *UserInterface) SetCursorMode(mode CursorMode) {
 	u.setCursorMode(mode)
 }
 
+func (u *UserInterface) SetCursorPosition(x, y float64) {
+	// Browsers don't allow to move the cursor.
+}
+
+func (u *UserInterface) LatestCursorPosition() (float64, float64) {
+	// Events are never dispatched during a frame, so the cursor position updated at the frame's beginning is the latest.
+	return u.inputState.CursorX, u.inputState.CursorY
+}
+
 func (u *UserInterface) setCursorMode(mode CursorMode) {
 	u.captureCursorLater = false
 
@@ -266,6 +275,14 @@ func (u *UserInterface) SetCursorShape(shape CursorShape) {
 	}
 }
 
+func (u *UserInterface) ClipboardText() (string, error) {
+	return "", errors.New("ui: the clipboard is not supported in this environment")
+}
+
+func (u *UserInterface) SetClipboardText(text string) error {
+	return errors.New("ui: the clipboard is not supported in this environment")
+}
+
 func (u *UserInterface) outsideSize() (float64, float64) {
 	if document.Truthy() {
 		body := document.Get("body")
@@ -803,6 +820,14 @@ func (m *Monitor) Size() (int, int) {
 	return screen.Get("width").Int(), screen.Get("height").Int()
 }
 
+func (m *Monitor) Position() (int, int) {
+	return 0, 0
+}
+
+func (m *Monitor) RefreshRate() int {
+	return 0
+}
+
 func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
 	return append(mons, theMonitor)
 }
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build (freebsd || (linux && !android) || netbsd || openbsd) && !nintendosdk && !playstation5
+//go:build (freebsd || (linux && !android) || netbsd || openbsd) && !nintendosdk && !playstation5 && !ebitenginecustomplatform
 
 package ui
 
@@ -200,3 +200,7 @@ func initializeWindowAfterCreation(w *glfw.Window) error {
 func (u *UserInterface) skipTaskbar() error {
 	return nil
 }
+
+func (u *UserInterface) setTaskbarProgress(state TaskbarProgressState, value float64) error {
+	return nil
+}
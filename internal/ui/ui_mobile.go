@@ -18,6 +18,7 @@ package ui
 
 import (
 	stdcontext "context"
+	"errors"
 	"fmt"
 	"runtime"
 	"runtime/debug"
@@ -42,6 +43,7 @@ func (u *UserInterface) init() error {
 	u.userInterfaceImpl = userInterfaceImpl{
 		graphicsLibraryInitCh: make(chan struct{}),
 		errCh:                 make(chan error),
+		appLifecycleEventCh:   make(chan appLifecycleEvent),
 
 		// Give a default outside size so that the game can start without initializing them.
 		outsideWidth:  640,
@@ -92,6 +94,9 @@ type userInterfaceImpl struct {
 	foreground atomic.Bool
 	errCh      chan error
 
+	// appLifecycleEventCh receives an event to notify the game of a lifecycle state change on the game's goroutine.
+	appLifecycleEventCh chan appLifecycleEvent
+
 	context *context
 
 	inputState InputState
@@ -103,14 +108,62 @@ type userInterfaceImpl struct {
 	m sync.RWMutex
 }
 
+type appLifecycleEvent struct {
+	state AppLifecycleState
+	errCh chan error
+}
+
+// SetForeground is called from mobile/ebitenmobileview.
+//
+// SetForeground notifies the game of the lifecycle state change and waits for the notification to finish,
+// so that the game can save its state before the application is suspended.
 func (u *UserInterface) SetForeground(foreground bool) error {
+	if u.foreground.Load() == foreground {
+		return nil
+	}
 	u.foreground.Store(foreground)
 
+	state := AppLifecycleStateForeground
+	if !foreground {
+		state = AppLifecycleStateBackground
+	}
+
+	// Suspend the audio before the game's notification, and resume the audio after the game's notification.
+	if !foreground {
+		if err := hook.SuspendAudio(); err != nil {
+			return err
+		}
+	}
+
+	if u.isRunning() {
+		e := appLifecycleEvent{
+			state: state,
+			errCh: make(chan error),
+		}
+		select {
+		case u.appLifecycleEventCh <- e:
+			if err := <-e.errCh; err != nil {
+				return err
+			}
+		case err := <-u.errCh:
+			return err
+		}
+	}
+
 	if foreground {
-		return hook.ResumeAudio()
-	} else {
-		return hook.SuspendAudio()
+		if err := hook.ResumeAudio(); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+// AppLifecycleState returns the lifecycle state of the application.
+func (u *UserInterface) AppLifecycleState() AppLifecycleState {
+	if u.foreground.Load() {
+		return AppLifecycleStateForeground
+	}
+	return AppLifecycleStateBackground
 }
 
 func (u *UserInterface) Run(game Game, options *RunOptions) error {
@@ -167,7 +220,12 @@ func (u *UserInterface) outsideSize() (float64, float64) {
 }
 
 func (u *UserInterface) update() error {
-	<-renderCh
+	select {
+	case <-renderCh:
+	case e := <-u.appLifecycleEventCh:
+		e.errCh <- u.context.game.ChangeAppLifecycleState(e.state)
+		return nil
+	}
 	defer func() {
 		renderEndCh <- struct{}{}
 	}()
@@ -199,6 +257,14 @@ func (u *UserInterface) SetCursorMode(mode CursorMode) {
 	// Do nothing
 }
 
+func (u *UserInterface) SetCursorPosition(x, y float64) {
+	// Do nothing
+}
+
+func (u *UserInterface) LatestCursorPosition() (float64, float64) {
+	return 0, 0
+}
+
 func (u *UserInterface) CursorShape() CursorShape {
 	return CursorShapeDefault
 }
@@ -207,6 +273,14 @@ func (u *UserInterface) SetCursorShape(shape CursorShape) {
 	// Do nothing
 }
 
+func (u *UserInterface) ClipboardText() (string, error) {
+	return "", errors.New("ui: the clipboard is not supported in this environment")
+}
+
+func (u *UserInterface) SetClipboardText(text string) error {
+	return errors.New("ui: the clipboard is not supported in this environment")
+}
+
 func (u *UserInterface) IsFullscreen() bool {
 	return false
 }
@@ -284,6 +358,14 @@ func (m *Monitor) Size() (int, int) {
 	return 0, 0
 }
 
+func (m *Monitor) Position() (int, int) {
+	return 0, 0
+}
+
+func (m *Monitor) RefreshRate() int {
+	return 0
+}
+
 func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
 	return append(mons, theMonitor)
 }
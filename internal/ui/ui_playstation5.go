@@ -103,6 +103,13 @@ func (*UserInterface) CursorMode() CursorMode {
 func (*UserInterface) SetCursorMode(mode CursorMode) {
 }
 
+func (*UserInterface) SetCursorPosition(x, y float64) {
+}
+
+func (*UserInterface) LatestCursorPosition() (float64, float64) {
+	return 0, 0
+}
+
 func (*UserInterface) CursorShape() CursorShape {
 	return CursorShapeDefault
 }
@@ -110,6 +117,14 @@ func (*UserInterface) CursorShape() CursorShape {
 func (*UserInterface) SetCursorShape(shape CursorShape) {
 }
 
+func (*UserInterface) ClipboardText() (string, error) {
+	return "", errors.New("ui: the clipboard is not supported in this environment")
+}
+
+func (*UserInterface) SetClipboardText(text string) error {
+	return errors.New("ui: the clipboard is not supported in this environment")
+}
+
 func (*UserInterface) IsFullscreen() bool {
 	return false
 }
@@ -163,6 +178,14 @@ func (m *Monitor) Size() (int, int) {
 	return screenWidth, screenHeight
 }
 
+func (m *Monitor) Position() (int, int) {
+	return 0, 0
+}
+
+func (m *Monitor) RefreshRate() int {
+	return 0
+}
+
 func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
 	return append(mons, theMonitor)
 }
@@ -17,6 +17,7 @@ package ui
 import (
 	"errors"
 	"fmt"
+	"math"
 	"runtime"
 	"syscall"
 
@@ -242,6 +243,89 @@ func (u *UserInterface) skipTaskbar() error {
 	return nil
 }
 
+// theTaskbarList3 is an ITaskbarList3 instance reused by setTaskbarProgress.
+// theTaskbarList3 must be accessed from the main thread.
+//
+// As SetTaskbarProgress can be called every frame, the instance is created once and kept with the COM library
+// initialized until the process exits.
+var theTaskbarList3 *_ITaskbarList3
+
+// taskbarList3 must be called from the main thread.
+func taskbarList3() (*_ITaskbarList3, error) {
+	if theTaskbarList3 != nil {
+		return theTaskbarList3, nil
+	}
+
+	// S_FALSE is returned when CoInitializeEx is nested. This is a successful case.
+	if err := windows.CoInitializeEx(0, windows.COINIT_MULTITHREADED); err != nil && !errors.Is(err, syscall.Errno(windows.S_FALSE)) {
+		return nil, err
+	}
+
+	ptr, err := _CoCreateInstance(&_CLSID_TaskbarList, nil, _CLSCTX_SERVER, &_IID_ITaskbarList3)
+	if err != nil {
+		// CoUninitialize should be called even when CoInitializeEx returns S_FALSE.
+		windows.CoUninitialize()
+		return nil, err
+	}
+
+	t := (*_ITaskbarList3)(ptr)
+	if err := t.HrInit(); err != nil {
+		t.Release()
+		windows.CoUninitialize()
+		return nil, err
+	}
+
+	theTaskbarList3 = t
+	return t, nil
+}
+
+// setTaskbarProgress must be called from the main thread.
+func (u *UserInterface) setTaskbarProgress(state TaskbarProgressState, value float64) error {
+	if microsoftgdk.IsXbox() {
+		return nil
+	}
+
+	t, err := taskbarList3()
+	if err != nil {
+		return err
+	}
+
+	w, err := u.window.GetWin32Window()
+	if err != nil {
+		return err
+	}
+
+	var flags uint32
+	switch state {
+	case TaskbarProgressStateNone:
+		flags = _TBPF_NOPROGRESS
+	case TaskbarProgressStateIndeterminate:
+		flags = _TBPF_INDETERMINATE
+	case TaskbarProgressStateNormal:
+		flags = _TBPF_NORMAL
+	case TaskbarProgressStatePaused:
+		flags = _TBPF_PAUSED
+	case TaskbarProgressStateError:
+		flags = _TBPF_ERROR
+	default:
+		return fmt.Errorf("ui: invalid TaskbarProgressState: %d", state)
+	}
+	if err := t.SetProgressState(w, flags); err != nil {
+		return err
+	}
+
+	if state == TaskbarProgressStateNone || state == TaskbarProgressStateIndeterminate {
+		return nil
+	}
+
+	const total = 10000
+	if err := t.SetProgressValue(w, uint64(math.Round(value*total)), total); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func init() {
 	if microsoftgdk.IsXbox() {
 		// TimeBeginPeriod might not be defined in Xbox.
@@ -25,9 +25,13 @@ type Window interface {
 	SetResizingMode(mode WindowResizingMode)
 	SetMonitor(*Monitor)
 	Position() (int, int)
+	PositionF() (float64, float64)
 	SetPosition(x, y int)
+	SetPositionF(x, y float64)
 	Size() (int, int)
+	SizeF() (float64, float64)
 	SetSize(width, height int)
+	SetSizeF(width, height float64)
 	SizeLimits() (minw, minh, maxw, maxh int)
 	SetSizeLimits(minw, minh, maxw, maxh int)
 	IsFloating() bool
@@ -43,6 +47,7 @@ type Window interface {
 	IsClosingHandled() bool
 	SetMousePassthrough(enabled bool)
 	IsMousePassthrough() bool
+	SetTaskbarProgress(state TaskbarProgressState, value float64)
 }
 
 type nullWindow struct{}
@@ -68,16 +73,30 @@ func (*nullWindow) Position() (int, int) {
 	return 0, 0
 }
 
+func (*nullWindow) PositionF() (float64, float64) {
+	return 0, 0
+}
+
 func (*nullWindow) SetPosition(x, y int) {
 }
 
+func (*nullWindow) SetPositionF(x, y float64) {
+}
+
 func (*nullWindow) Size() (int, int) {
 	return 0, 0
 }
 
+func (*nullWindow) SizeF() (float64, float64) {
+	return 0, 0
+}
+
 func (*nullWindow) SetSize(width, height int) {
 }
 
+func (*nullWindow) SetSizeF(width, height float64) {
+}
+
 func (*nullWindow) SizeLimits() (minw, minh, maxw, maxh int) {
 	return -1, -1, -1, -1
 }
@@ -128,3 +147,6 @@ func (*nullWindow) SetMousePassthrough(enabled bool) {
 func (*nullWindow) IsMousePassthrough() bool {
 	return false
 }
+
+func (*nullWindow) SetTaskbarProgress(state TaskbarProgressState, value float64) {
+}
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !android && !ios && !js && !nintendosdk && !playstation5
+//go:build !android && !ios && !js && !nintendosdk && !playstation5 && !ebitenginecustomplatform
 
 package ui
 
@@ -287,13 +287,18 @@ func (w *glfwWindow) SetMonitor(monitor *Monitor) {
 }
 
 func (w *glfwWindow) Position() (int, int) {
+	x, y := w.PositionF()
+	return int(x), int(y)
+}
+
+func (w *glfwWindow) PositionF() (float64, float64) {
 	if w.ui.isTerminated() {
 		return 0, 0
 	}
 	if !w.ui.isRunning() {
 		panic("ui: WindowPosition can't be called before the main loop starts")
 	}
-	var x, y int
+	var x, y float64
 	w.ui.mainThread.Call(func() {
 		if w.ui.isTerminated() {
 			return
@@ -323,19 +328,22 @@ func (w *glfwWindow) Position() (int, int) {
 		wx -= m.boundsInGLFWPixels.Min.X
 		wy -= m.boundsInGLFWPixels.Min.Y
 		s := m.DeviceScaleFactor()
-		xf := dipFromGLFWPixel(float64(wx), s)
-		yf := dipFromGLFWPixel(float64(wy), s)
-		x, y = int(xf), int(yf)
+		x = dipFromGLFWPixel(float64(wx), s)
+		y = dipFromGLFWPixel(float64(wy), s)
 	})
 	return x, y
 }
 
 func (w *glfwWindow) SetPosition(x, y int) {
+	w.SetPositionF(float64(x), float64(y))
+}
+
+func (w *glfwWindow) SetPositionF(x, y float64) {
 	if w.ui.isTerminated() {
 		return
 	}
 	if !w.ui.isRunning() {
-		w.ui.setInitWindowPositionInDIP(x, y)
+		w.ui.setInitWindowPositionInDIP(int(x), int(y))
 		return
 	}
 	w.ui.mainThread.Call(func() {
@@ -355,14 +363,20 @@ func (w *glfwWindow) SetPosition(x, y int) {
 }
 
 func (w *glfwWindow) Size() (int, int) {
+	ww, wh := w.SizeF()
+	return int(ww), int(wh)
+}
+
+func (w *glfwWindow) SizeF() (float64, float64) {
 	if w.ui.isTerminated() {
 		return 0, 0
 	}
 	if !w.ui.isRunning() {
 		ww, wh := w.ui.getInitWindowSizeInDIP()
-		return w.ui.adjustWindowSizeBasedOnSizeLimitsInDIP(ww, wh)
+		ww, wh = w.ui.adjustWindowSizeBasedOnSizeLimitsInDIP(ww, wh)
+		return float64(ww), float64(wh)
 	}
-	var ww, wh int
+	var ww, wh float64
 	w.ui.mainThread.Call(func() {
 		if w.ui.isTerminated() {
 			return
@@ -375,12 +389,16 @@ func (w *glfwWindow) Size() (int, int) {
 }
 
 func (w *glfwWindow) SetSize(width, height int) {
+	w.SetSizeF(float64(width), float64(height))
+}
+
+func (w *glfwWindow) SetSizeF(width, height float64) {
 	if w.ui.isTerminated() {
 		return
 	}
 	if !w.ui.isRunning() {
 		// If the window is initially maximized, the set size is ignored anyway.
-		w.ui.setInitWindowSizeInDIP(width, height)
+		w.ui.setInitWindowSizeInDIP(int(width), int(height))
 		return
 	}
 	w.ui.mainThread.Call(func() {
@@ -506,3 +524,21 @@ func (w *glfwWindow) IsMousePassthrough() bool {
 	})
 	return v
 }
+
+func (w *glfwWindow) SetTaskbarProgress(state TaskbarProgressState, value float64) {
+	if w.ui.isTerminated() {
+		return
+	}
+	if !w.ui.isRunning() {
+		return
+	}
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		if err := w.ui.setTaskbarProgress(state, value); err != nil {
+			w.ui.setError(err)
+			return
+		}
+	})
+}
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build (!android && !ios && !js) || nintendosdk || playstation5
+//go:build (!android && !ios && !js) || nintendosdk || playstation5 || ebitenginecustomplatform
 
 package vibrate
 
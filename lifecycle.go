@@ -0,0 +1,70 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// AppLifecycleStateType represents the lifecycle state of the application.
+type AppLifecycleStateType = ui.AppLifecycleState
+
+// AppLifecycleStateTypes
+const (
+	// AppLifecycleStateForeground indicates that the application is in the foreground and the game is running.
+	AppLifecycleStateForeground AppLifecycleStateType = ui.AppLifecycleStateForeground
+
+	// AppLifecycleStateBackground indicates that the application is in the background and the game is suspended.
+	AppLifecycleStateBackground AppLifecycleStateType = ui.AppLifecycleStateBackground
+)
+
+// AppLifecycleState returns the current lifecycle state of the application.
+//
+// AppLifecycleState returns AppLifecycleStateForeground on the environments other than mobiles.
+//
+// AppLifecycleState is concurrent-safe.
+func AppLifecycleState() AppLifecycleStateType {
+	return ui.Get().AppLifecycleState()
+}
+
+// Suspender is an interface for a game to be notified when the application goes to the background and
+// comes back to the foreground.
+//
+// Suspender works only on mobiles so far.
+//
+// Suspender doesn't release or recreate GPU resources. Ebitengine tries to keep the graphics context while
+// the application is in the background, and images are available after OnResume without any special treatment.
+// On Android, if the graphics context is lost in the background anyway, the application is terminated.
+type Suspender interface {
+	// OnSuspend is called when the application goes to the background.
+	// After OnSuspend returns, Update and Draw are not called until the application comes back to the foreground,
+	// and the operating system might terminate the application without any further notifications.
+	// Save the game's state in OnSuspend if needed.
+	//
+	// OnSuspend is called on the same goroutine as Update and Draw, and the application waits for OnSuspend to
+	// finish. Do not take a long time in OnSuspend, or the operating system might terminate the application.
+	//
+	// Audio players are paused before OnSuspend is called.
+	//
+	// If OnSuspend returns an error, the error is returned to the caller of the mobile framework's suspending
+	// function, e.g. the Java or Objective-C layer.
+	OnSuspend() error
+
+	// OnResume is called when the application comes back to the foreground.
+	// OnResume is called before Update and Draw are called again.
+	//
+	// Audio players are resumed after OnResume is called.
+	OnResume() error
+}
@@ -19,6 +19,7 @@ import (
 	"hash/crc32"
 	"unicode"
 
+	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepaddb"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
@@ -168,6 +169,43 @@ func OnKeyUpOnAndroid(keyCode int, source int, deviceID int) {
 	}
 }
 
+// Back gesture phases used in EbitenView.java.
+const (
+	backGesturePhaseStarted    = 0
+	backGesturePhaseProgressed = 1
+	backGesturePhaseCancelled  = 2
+	backGesturePhaseInvoked    = 3
+)
+
+// IsBackGestureHandledOnAndroid reports whether the current game implements ebiten.BackGestureHandler.
+//
+// EbitenView registers the back gesture callbacks only when this returns true,
+// so that the back gesture closes the application as usual otherwise.
+func IsBackGestureHandledOnAndroid() bool {
+	_, ok := theState.currentGame().(ebiten.BackGestureHandler)
+	return ok
+}
+
+func OnBackGestureOnAndroid(phase int, progress float64) {
+	var p ui.BackGesturePhase
+	switch phase {
+	case backGesturePhaseStarted:
+		p = ui.BackGesturePhaseStarted
+	case backGesturePhaseProgressed:
+		p = ui.BackGesturePhaseProgressed
+	case backGesturePhaseCancelled:
+		p = ui.BackGesturePhaseCancelled
+	case backGesturePhaseInvoked:
+		p = ui.BackGesturePhaseInvoked
+	default:
+		return
+	}
+	ui.Get().AddBackGestureEvent(ui.BackGestureEvent{
+		Phase:    p,
+		Progress: progress,
+	})
+}
+
 func OnGamepadAxisChanged(deviceID int, axisID int, value float32) {
 	gamepad.UpdateAndroidGamepadAxis(deviceID, axisID, float64(value))
 }
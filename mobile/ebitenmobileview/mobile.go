@@ -28,6 +28,7 @@ import "C"
 import (
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
@@ -43,6 +44,14 @@ type state struct {
 	running         bool
 	setGameNotifier SetGameNotifier
 
+	// game is the current game.
+	game ebiten.Game
+
+	frameRateRangeRequester FrameRateRangeRequester
+	frameRateRangeApplied   bool
+	minFrameInterval        time.Duration
+	maxFrameInterval        time.Duration
+
 	m sync.Mutex
 }
 
@@ -64,6 +73,51 @@ func (s *state) run() {
 	}
 }
 
+func (s *state) setGame(game ebiten.Game) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.game = game
+}
+
+func (s *state) currentGame() ebiten.Game {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.game
+}
+
+func (s *state) setFrameRateRangeRequester(frameRateRangeRequester FrameRateRangeRequester) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.frameRateRangeRequester = frameRateRangeRequester
+	s.frameRateRangeApplied = false
+}
+
+func (s *state) updateFrameRateRangeIfNeeded() {
+	minInterval, maxInterval := ebiten.FrameIntervalRange()
+
+	s.m.Lock()
+	r := s.frameRateRangeRequester
+	if r == nil || (s.frameRateRangeApplied && s.minFrameInterval == minInterval && s.maxFrameInterval == maxInterval) {
+		s.m.Unlock()
+		return
+	}
+	s.frameRateRangeApplied = true
+	s.minFrameInterval = minInterval
+	s.maxFrameInterval = maxInterval
+	s.m.Unlock()
+
+	// The maximum interval corresponds to the minimum frame rate, and vice versa.
+	r.SetPreferredFrameRateRange(intervalToFrameRate(maxInterval), intervalToFrameRate(minInterval))
+}
+
+// intervalToFrameRate returns the frame rate for the interval. A zero interval means no preference, and 0 is returned.
+func intervalToFrameRate(interval time.Duration) float64 {
+	if interval == 0 {
+		return 0
+	}
+	return float64(time.Second) / float64(interval)
+}
+
 func (s *state) setSetGameNotifier(setGameNotifier SetGameNotifier) {
 	s.m.Lock()
 	r := s.running
@@ -78,9 +132,13 @@ func (s *state) setSetGameNotifier(setGameNotifier SetGameNotifier) {
 	}
 }
 
+// SetGame sets the game to run.
+// If a game is already running, SetGame replaces the game and options are ignored.
 func SetGame(game ebiten.Game, options *ebiten.RunGameOptions) {
+	theState.setGame(game)
 	if theState.isRunning() {
-		panic("ebitenmobileview: SetGame cannot be called twice or more")
+		ebiten.ReplaceGameWithoutMainLoop(game)
+		return
 	}
 	ebiten.RunGameWithoutMainLoop(game, options)
 	theState.run()
@@ -101,6 +159,7 @@ func Update() error {
 		return nil
 	}
 
+	theState.updateFrameRateRangeIfNeeded()
 	return ui.Get().Update()
 }
 
@@ -112,6 +171,21 @@ func Resume() error {
 	return ui.Get().SetForeground(true)
 }
 
+// SetSafeAreaInsets sets the safe area insets from the view's edges in device-independent pixels.
+func SetSafeAreaInsets(left, top, right, bottom float64) {
+	ui.Get().SetSafeAreaInsets(left, top, right, bottom)
+}
+
+// ResetDisplayCutouts removes all the display cutouts.
+func ResetDisplayCutouts() {
+	ui.Get().ResetDisplayCutouts()
+}
+
+// AddDisplayCutout adds a display cutout's rectangle in the view in device-independent pixels.
+func AddDisplayCutout(x0, y0, x1, y1 float64) {
+	ui.Get().AddDisplayCutout(x0, y0, x1, y1)
+}
+
 func DeviceScale() float64 {
 	return ui.Get().Monitor().DeviceScaleFactor()
 }
@@ -125,6 +199,17 @@ func SetRenderRequester(renderRequester RenderRequester) {
 	ui.Get().SetRenderRequester(renderRequester)
 }
 
+// FrameRateRangeRequester requests the preferred frame rate range of the display, e.g. for ProMotion displays.
+//
+// minimum and maximum are in frames per second. 0 means no preference.
+type FrameRateRangeRequester interface {
+	SetPreferredFrameRateRange(minimum, maximum float64)
+}
+
+func SetFrameRateRangeRequester(frameRateRangeRequester FrameRateRangeRequester) {
+	theState.setFrameRateRangeRequester(frameRateRangeRequester)
+}
+
 func SetSetGameNotifier(setGameNotifier SetGameNotifier) {
 	theState.setSetGameNotifier(setGameNotifier)
 }
@@ -25,7 +25,10 @@ import (
 
 // SetGame sets a mobile game.
 //
-// SetGame is expected to be called only once.
+// If a game is already set, SetGame replaces the game at the next frame. This is useful to host different games,
+// e.g. minigames, in one view one by one. The replaced game's Update and Draw are no longer called.
+// Running multiple games at the same time in multiple views is not supported,
+// as all the games share the same graphics context and the same input states.
 //
 // SetGame can be called anytime. Until SetGame is called, the game does not start.
 func SetGame(game ebiten.Game) {
@@ -34,7 +37,7 @@ func SetGame(game ebiten.Game) {
 
 // SetGameWithOptions sets a mobile game with the specified options.
 //
-// SetGameWithOptions is expected to be called only once.
+// If a game is already set, SetGameWithOptions replaces the game in the same way as SetGame, and options are ignored.
 //
 // SetGameWithOptions can be called anytime. Until SetGameWithOptions is called, the game does not start.
 func SetGameWithOptions(game ebiten.Game, options *ebiten.RunGameOptions) {
@@ -51,6 +51,25 @@ func (m *MonitorType) Size() (int, int) {
 	return (*ui.Monitor)(m).Size()
 }
 
+// Position returns the position of the monitor's upper-left corner in the virtual desktop.
+// The unit is device-independent pixels of the monitor.
+// This is useful to know the arrangement of the monitors.
+//
+// Note that the position is converted with the monitor's own device scale factor, so the positions of monitors with
+// different device scale factors might not be consistent with each other, e.g. the monitors might overlap.
+//
+// On browsers and mobiles, Position returns (0, 0).
+func (m *MonitorType) Position() (int, int) {
+	return (*ui.Monitor)(m).Position()
+}
+
+// RefreshRate returns the refresh rate of the monitor in Hz.
+//
+// RefreshRate returns 0 if the refresh rate is unknown, e.g. on browsers and mobiles.
+func (m *MonitorType) RefreshRate() int {
+	return (*ui.Monitor)(m).RefreshRate()
+}
+
 // Monitor returns the current monitor.
 func Monitor() *MonitorType {
 	m := ui.Get().Monitor()
@@ -61,10 +80,25 @@ func Monitor() *MonitorType {
 }
 
 // SetMonitor sets the monitor that the window should be on. This can be called before or after Run.
+//
+// If the window is in fullscreen mode, the window becomes fullscreen on the new monitor.
+// To make the window fullscreen on a specific monitor, call SetMonitor and then SetFullscreen(true).
 func SetMonitor(monitor *MonitorType) {
 	ui.Get().Window().SetMonitor((*ui.Monitor)(monitor))
 }
 
+// SetWindowPositionOnMonitor moves the window to the monitor, and sets the window position.
+// The origin position is the upper-left corner of the monitor.
+// The unit is device-independent pixels.
+//
+// SetWindowPositionOnMonitor does nothing if the platform is not a desktop.
+//
+// SetWindowPositionOnMonitor is concurrent-safe.
+func SetWindowPositionOnMonitor(monitor *MonitorType, x, y int) {
+	SetMonitor(monitor)
+	SetWindowPosition(x, y)
+}
+
 // AppendMonitors returns the monitors reported by the system.
 // On desktop platforms, there will always be at least one monitor appended and the first monitor in the slice will be the primary monitor.
 // Any monitors added or removed will show up with subsequent calls to this function.
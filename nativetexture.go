@@ -0,0 +1,161 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"runtime"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// NewImageFromOpenGLTexture creates a new read-only image that wraps an existing OpenGL texture.
+// This is useful to render the result of another library, e.g. a video decoder, without copying pixels via CPU.
+//
+// texture is a texture name of GL_TEXTURE_2D created in Ebitengine's OpenGL context or a context sharing objects with it.
+// The texture's size must be exactly width x height, and its format must be RGBA with premultiplied alpha.
+//
+// The image is read-only: the image can be used as a source of rendering, but drawing onto it or writing pixels
+// to it panics.
+//
+// The caller owns the texture. Deallocating the image doesn't delete the texture,
+// and the texture must be alive until the image is deallocated.
+//
+// NewImageFromOpenGLTexture returns an error when the current graphics library is not OpenGL, e.g.
+// RunGame is not called yet, or on browsers.
+func NewImageFromOpenGLTexture(texture uint32, width, height int) (*Image, error) {
+	if runtime.GOOS == "js" {
+		return nil, errors.New("ebiten: NewImageFromOpenGLTexture is not available on browsers")
+	}
+	if texture == 0 {
+		return nil, errors.New("ebiten: texture must not be 0 at NewImageFromOpenGLTexture")
+	}
+	return newImageFromNativeTexture(ui.GraphicsLibraryOpenGL, uintptr(texture), width, height, "NewImageFromOpenGLTexture")
+}
+
+// NewImageFromMetalTexture creates a new read-only image that wraps an existing Metal texture.
+// This is useful to render the result of another library, e.g. a video decoder, without copying pixels via CPU.
+//
+// texture is an id<MTLTexture> of MTLTextureType2D created by the same MTLDevice as Ebitengine's.
+// The texture's size must be exactly width x height, and its pixel format must be MTLPixelFormatRGBA8Unorm
+// with premultiplied alpha.
+//
+// The image is read-only: the image can be used as a source of rendering, but drawing onto it or writing pixels
+// to it panics.
+//
+// The caller owns the texture. Deallocating the image doesn't release the texture,
+// and the texture must be alive until the image is deallocated.
+//
+// NewImageFromMetalTexture returns an error when the current graphics library is not Metal, e.g.
+// RunGame is not called yet.
+func NewImageFromMetalTexture(texture uintptr, width, height int) (*Image, error) {
+	if texture == 0 {
+		return nil, errors.New("ebiten: texture must not be nil at NewImageFromMetalTexture")
+	}
+	return newImageFromNativeTexture(ui.GraphicsLibraryMetal, texture, width, height, "NewImageFromMetalTexture")
+}
+
+func newImageFromNativeTexture(graphicsLibrary ui.GraphicsLibrary, native uintptr, width, height int, name string) (*Image, error) {
+	if isRunGameEnded() {
+		return nil, fmt.Errorf("ebiten: %s cannot be called after RunGame finishes", name)
+	}
+	if width <= 0 {
+		return nil, fmt.Errorf("ebiten: width at %s must be positive but %d", name, width)
+	}
+	if height <= 0 {
+		return nil, fmt.Errorf("ebiten: height at %s must be positive but %d", name, height)
+	}
+	if g := ui.Get().GraphicsLibrary(); g != graphicsLibrary {
+		return nil, fmt.Errorf("ebiten: %s is not available with the graphics library %s", name, g)
+	}
+
+	i := &Image{
+		image:  ui.Get().NewExternalImage(width, height, native),
+		bounds: image.Rect(0, 0, width, height),
+	}
+	i.addr = i
+	if isImageLeakReportEnabled {
+		i.leakTracker = newImageLeakTracker(width, height)
+	}
+	return i, nil
+}
+
+// NativeTexture represents a native texture of an image.
+type NativeTexture struct {
+	// Handle is a handle of the native texture.
+	// Handle is an OpenGL texture name with GraphicsLibraryOpenGL, and an id<MTLTexture> with GraphicsLibraryMetal.
+	Handle uintptr
+
+	// Width and Height are the size of the texture.
+	// The texture might be bigger than the image.
+	Width  int
+	Height int
+
+	// Region is the region of the image in the texture.
+	// The first row of the texture is the top of the image.
+	Region image.Rectangle
+}
+
+// NativeTexture flushes the rendering commands for the image, and returns the image's native texture.
+// This is useful to pass the rendering result to another library in the same process, e.g. a video encoder,
+// without reading pixels via CPU.
+//
+// The image must be created with Unmanaged option of NewImageOptions, or by NewImageFromOpenGLTexture or
+// NewImageFromMetalTexture, as other images might be put on or moved among internal texture atlases.
+// To export the screen, draw the screen onto such an image.
+//
+// The pixels of the texture are RGBA with premultiplied alpha.
+// Ebitengine owns the texture, and the texture is valid until the image is deallocated.
+// Do not modify the texture. The texture might be used for the following rendering commands.
+//
+// NativeTexture returns an error when the graphics library is neither OpenGL nor Metal, or on browsers.
+// Exporting a texture as a handle shareable with another process, e.g. a DXGI shared handle or an IOSurface,
+// is not supported yet.
+//
+// NativeTexture cannot be called before the main loop starts.
+func (i *Image) NativeTexture() (NativeTexture, error) {
+	if err := i.checkCopyErr(); err != nil {
+		return NativeTexture{}, err
+	}
+	if i.isDisposed() {
+		return NativeTexture{}, ErrImageDisposed
+	}
+	if runtime.GOOS == "js" {
+		return NativeTexture{}, errors.New("ebiten: NativeTexture is not available on browsers")
+	}
+	if g := ui.Get().GraphicsLibrary(); g != ui.GraphicsLibraryOpenGL && g != ui.GraphicsLibraryMetal {
+		return NativeTexture{}, fmt.Errorf("ebiten: NativeTexture is not available with the graphics library %s", g)
+	}
+
+	native, w, h, err := i.image.NativeTexture()
+	if err != nil {
+		return NativeTexture{}, err
+	}
+
+	r := i.Bounds()
+	if i.isSubImage() {
+		r = r.Sub(i.original.Bounds().Min)
+	} else {
+		r = r.Sub(r.Min)
+	}
+	return NativeTexture{
+		Handle: native,
+		Width:  w,
+		Height: h,
+		Region: r,
+	}, nil
+}
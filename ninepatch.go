@@ -0,0 +1,148 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image"
+)
+
+// NinePatchInsets represents the insets of a nine-patch image in pixels.
+//
+// The insets split a source image into 9 parts: 4 corners, 4 edges, and the center.
+// The corners are never scaled, the edges are scaled in one direction, and the center is scaled in both directions.
+type NinePatchInsets struct {
+	Left   int
+	Top    int
+	Right  int
+	Bottom int
+}
+
+// DrawNinePatchOptions represents options for DrawNinePatch.
+type DrawNinePatchOptions struct {
+	// GeoM is a geometry matrix applied after the nine-patch is laid out in the destination rectangle.
+	// The default (zero) value is identity.
+	GeoM GeoM
+
+	// ColorScale is a scale of color.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend Blend
+
+	// Filter is a type of texture filter.
+	// The default (zero) value is FilterNearest.
+	Filter Filter
+}
+
+// DrawNinePatch draws the source image src as a nine-patch onto dst, filling the destination rectangle dstRect.
+//
+// The 9 parts are rendered with one DrawTriangles call.
+// If dstRect is smaller than the total of the insets in a direction, the corners and the edges are shrunk
+// proportionally in the direction, and the center is not rendered.
+//
+// If an inset is negative, or the total of the insets exceeds src's size in a direction, DrawNinePatch panics.
+func DrawNinePatch(dst *Image, src *Image, insets NinePatchInsets, dstRect image.Rectangle, options *DrawNinePatchOptions) {
+	if insets.Left < 0 || insets.Top < 0 || insets.Right < 0 || insets.Bottom < 0 {
+		panic("ebiten: insets must not be negative at DrawNinePatch")
+	}
+	sb := src.Bounds()
+	if insets.Left+insets.Right > sb.Dx() || insets.Top+insets.Bottom > sb.Dy() {
+		panic("ebiten: the total of insets must not exceed the source size at DrawNinePatch")
+	}
+	if dstRect.Empty() || sb.Empty() {
+		return
+	}
+
+	if options == nil {
+		options = &DrawNinePatchOptions{}
+	}
+
+	// The source positions of the grid lines.
+	sxs := [4]float32{
+		float32(sb.Min.X),
+		float32(sb.Min.X + insets.Left),
+		float32(sb.Max.X - insets.Right),
+		float32(sb.Max.X),
+	}
+	sys := [4]float32{
+		float32(sb.Min.Y),
+		float32(sb.Min.Y + insets.Top),
+		float32(sb.Max.Y - insets.Bottom),
+		float32(sb.Max.Y),
+	}
+
+	// The destination positions of the grid lines.
+	dxs := ninePatchGridLines(dstRect.Min.X, dstRect.Max.X, insets.Left, insets.Right)
+	dys := ninePatchGridLines(dstRect.Min.Y, dstRect.Max.Y, insets.Top, insets.Bottom)
+
+	cs := options.ColorScale
+	vs := make([]Vertex, 0, 16)
+	for j := 0; j < 4; j++ {
+		for i := 0; i < 4; i++ {
+			x, y := options.GeoM.Apply(dxs[i], dys[j])
+			vs = append(vs, Vertex{
+				DstX:   float32(x),
+				DstY:   float32(y),
+				SrcX:   sxs[i],
+				SrcY:   sys[j],
+				ColorR: cs.R(),
+				ColorG: cs.G(),
+				ColorB: cs.B(),
+				ColorA: cs.A(),
+			})
+		}
+	}
+
+	is := make([]uint16, 0, 6*9)
+	for j := 0; j < 3; j++ {
+		for i := 0; i < 3; i++ {
+			// Skip empty parts, e.g. the center when the destination is smaller than the insets.
+			if sxs[i] == sxs[i+1] || sys[j] == sys[j+1] || dxs[i] == dxs[i+1] || dys[j] == dys[j+1] {
+				continue
+			}
+			v := uint16(4*j + i)
+			is = append(is, v, v+1, v+4, v+1, v+5, v+4)
+		}
+	}
+	if len(is) == 0 {
+		return
+	}
+
+	op := &DrawTrianglesOptions{}
+	op.ColorScaleMode = ColorScaleModePremultipliedAlpha
+	op.Blend = options.Blend
+	op.Filter = options.Filter
+	dst.DrawTriangles(vs, is, src, op)
+}
+
+// ninePatchGridLines returns the positions of the grid lines in one direction.
+func ninePatchGridLines(start, end int, inset0, inset1 int) [4]float64 {
+	size := end - start
+	i0, i1 := float64(inset0), float64(inset1)
+	if s := inset0 + inset1; s > size {
+		// Shrink the insets proportionally so that the corners don't overlap.
+		r := float64(size) / float64(s)
+		i0 *= r
+		i1 *= r
+	}
+	return [4]float64{
+		float64(start),
+		float64(start) + i0,
+		float64(end) - i1,
+		float64(end),
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestNinePatchGridLines(t *testing.T) {
+	testCases := []struct {
+		Start  int
+		End    int
+		Inset0 int
+		Inset1 int
+		Want   [4]float64
+	}{
+		{Start: 0, End: 10, Inset0: 2, Inset1: 3, Want: [4]float64{0, 2, 7, 10}},
+		{Start: 5, End: 15, Inset0: 0, Inset1: 0, Want: [4]float64{5, 5, 15, 15}},
+		{Start: 0, End: 5, Inset0: 2, Inset1: 3, Want: [4]float64{0, 2, 2, 5}},
+		// The insets are shrunk proportionally when the size is smaller than the total of the insets.
+		{Start: 0, End: 4, Inset0: 2, Inset1: 6, Want: [4]float64{0, 1, 1, 4}},
+		{Start: 10, End: 10, Inset0: 2, Inset1: 2, Want: [4]float64{10, 10, 10, 10}},
+	}
+	for _, tc := range testCases {
+		got := ebiten.NinePatchGridLinesForTesting(tc.Start, tc.End, tc.Inset0, tc.Inset1)
+		if got != tc.Want {
+			t.Errorf("ninePatchGridLines(%d, %d, %d, %d): got: %v, want: %v", tc.Start, tc.End, tc.Inset0, tc.Inset1, got, tc.Want)
+		}
+	}
+}
+
+func ninePatchPartColor(i, j int) color.RGBA {
+	return color.RGBA{R: uint8(0x20 * (i + 1)), G: uint8(0x20 * (j + 1)), B: 0x80, A: 0xff}
+}
+
+func TestDrawNinePatch(t *testing.T) {
+	// Each of the 9 parts in the source is 2x2 and has its own color.
+	src := ebiten.NewImage(6, 6)
+	pix := make([]byte, 4*6*6)
+	for j := 0; j < 6; j++ {
+		for i := 0; i < 6; i++ {
+			c := ninePatchPartColor(i/2, j/2)
+			idx := 4 * (j*6 + i)
+			pix[idx] = c.R
+			pix[idx+1] = c.G
+			pix[idx+2] = c.B
+			pix[idx+3] = c.A
+		}
+	}
+	src.WritePixels(pix)
+
+	dst := ebiten.NewImage(12, 10)
+	dstRect := image.Rect(1, 1, 11, 9)
+	ebiten.DrawNinePatch(dst, src, ebiten.NinePatchInsets{Left: 2, Top: 2, Right: 2, Bottom: 2}, dstRect, nil)
+
+	part := func(v, min, max int) int {
+		if v < min+2 {
+			return 0
+		}
+		if v >= max-2 {
+			return 2
+		}
+		return 1
+	}
+	for j := 0; j < 10; j++ {
+		for i := 0; i < 12; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			var want color.RGBA
+			if image.Pt(i, j).In(dstRect) {
+				want = ninePatchPartColor(part(i, dstRect.Min.X, dstRect.Max.X), part(j, dstRect.Min.Y, dstRect.Max.Y))
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDrawNinePatchSubImage(t *testing.T) {
+	// The source is a sub-image. The pixels outside the sub-image must not be used.
+	src := ebiten.NewImage(8, 8)
+	src.Fill(color.RGBA{R: 0xff, A: 0xff})
+	sub := src.SubImage(image.Rect(2, 2, 6, 6)).(*ebiten.Image)
+	sub.Fill(color.RGBA{G: 0xff, A: 0xff})
+
+	dst := ebiten.NewImage(16, 16)
+	ebiten.DrawNinePatch(dst, sub, ebiten.NinePatchInsets{Left: 1, Top: 1, Right: 1, Bottom: 1}, dst.Bounds(), nil)
+
+	want := color.RGBA{G: 0xff, A: 0xff}
+	for j := 0; j < 16; j++ {
+		for i := 0; i < 16; i++ {
+			if got := dst.At(i, j).(color.RGBA); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDrawNinePatchInvalidInsets(t *testing.T) {
+	src := ebiten.NewImage(4, 4)
+	dst := ebiten.NewImage(16, 16)
+	for _, insets := range []ebiten.NinePatchInsets{
+		{Left: -1},
+		{Left: 3, Right: 2},
+		{Top: 2, Bottom: 3},
+	} {
+		insets := insets
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("DrawNinePatch with %v must panic", insets)
+				}
+			}()
+			ebiten.DrawNinePatch(dst, src, insets, dst.Bounds(), nil)
+		}()
+	}
+}
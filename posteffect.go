@@ -0,0 +1,134 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image"
+	"math"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
+)
+
+// PostEffect is an effect applied to the whole screen after the game's Draw.
+//
+// The posteffect package provides built-in effects like bloom, CRT, vignette, and FXAA.
+type PostEffect interface {
+	// DrawPostEffect renders src onto dst with the effect.
+	// dst and src have the same size, and dst is cleared before DrawPostEffect is called.
+	//
+	// src is the game screen scaled to the final screen's resolution, so an effect works in the display's pixels
+	// regardless of the game's logical screen size.
+	DrawPostEffect(dst, src *Image)
+}
+
+var (
+	thePostEffects []PostEffect
+	postEffectsM   sync.Mutex
+)
+
+// SetPostEffects sets the post effects applied to the screen after the game's Draw.
+// The effects are applied in the given order. Calling SetPostEffects without arguments removes all the effects.
+//
+// The effects are applied to the game region of the final screen, i.e. the letterbox bars are not affected.
+// If the game implements FinalScreenDrawer, the effects are applied to the result of DrawFinalScreen.
+// In this case, DrawFinalScreen's screen argument is an intermediate image covering the game region
+// instead of the final screen. See FinalScreenDrawer.
+// LateDrawer's DrawLate is called after the effects are applied.
+//
+// SetPostEffects is concurrent-safe.
+func SetPostEffects(effects ...PostEffect) {
+	postEffectsM.Lock()
+	defer postEffectsM.Unlock()
+	thePostEffects = append(thePostEffects[:0:0], effects...)
+}
+
+func appendPostEffects(effects []PostEffect) []PostEffect {
+	postEffectsM.Lock()
+	defer postEffectsM.Unlock()
+	return append(effects, thePostEffects...)
+}
+
+// postEffectChain is a chain to apply post effects with two intermediate images.
+type postEffectChain struct {
+	effects []PostEffect
+	images  [2]*Image
+}
+
+func (p *postEffectChain) ensureImages(width, height int) {
+	for i, img := range p.images {
+		if img != nil && img.Bounds().Dx() == width && img.Bounds().Dy() == height {
+			continue
+		}
+		if img != nil {
+			img.Deallocate()
+		}
+		// Use unmanaged images so that effects sampling neighbor pixels don't pick other images on an atlas.
+		p.images[i] = newImage(image.Rect(0, 0, width, height), atlas.ImageTypeUnmanaged)
+	}
+}
+
+// draw draws the game region of the final screen with post effects.
+// drawGame draws the game onto the given image with the given geometry matrix.
+// draw reports whether the post effects are applied. If there are no effects, draw does nothing and returns false.
+func (p *postEffectChain) draw(screen *Image, geoM GeoM, gameWidth, gameHeight int, drawGame func(dst *Image, geoM GeoM)) bool {
+	p.effects = appendPostEffects(p.effects[:0])
+	defer func() {
+		for i := range p.effects {
+			p.effects[i] = nil
+		}
+	}()
+	if len(p.effects) == 0 {
+		for i, img := range p.images {
+			if img != nil {
+				img.Deallocate()
+				p.images[i] = nil
+			}
+		}
+		return false
+	}
+
+	r := postEffectRegion(screen.Bounds(), geoM, gameWidth, gameHeight)
+	if r.Empty() {
+		return true
+	}
+	p.ensureImages(r.Dx(), r.Dy())
+
+	// Draw the game at the final screen's resolution.
+	src := p.images[0]
+	src.Clear()
+	g := geoM
+	g.Translate(-float64(r.Min.X), -float64(r.Min.Y))
+	drawGame(src, g)
+
+	dst := p.images[1]
+	for _, e := range p.effects {
+		dst.Clear()
+		e.DrawPostEffect(dst, src)
+		src, dst = dst, src
+	}
+
+	op := &DrawImageOptions{}
+	op.GeoM.Translate(float64(r.Min.X), float64(r.Min.Y))
+	screen.DrawImage(src, op)
+	return true
+}
+
+// postEffectRegion returns the game region on the final screen in integers.
+func postEffectRegion(screenBounds image.Rectangle, geoM GeoM, gameWidth, gameHeight int) image.Rectangle {
+	x0, y0 := geoM.Apply(0, 0)
+	x1, y1 := geoM.Apply(float64(gameWidth), float64(gameHeight))
+	return image.Rect(int(math.Floor(x0)), int(math.Floor(y0)), int(math.Ceil(x1)), int(math.Ceil(y1))).Intersect(screenBounds)
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package posteffect
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var bloomBrightPassShader = &lazyShader{
+	src: `//kage:unit pixels
+
+package main
+
+var Threshold float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	// Average 4 pixels to downsample the source.
+	c := imageSrc0At(srcPos+vec2(-0.5, -0.5)) +
+		imageSrc0At(srcPos+vec2(0.5, -0.5)) +
+		imageSrc0At(srcPos+vec2(-0.5, 0.5)) +
+		imageSrc0At(srcPos+vec2(0.5, 0.5))
+	c /= 4
+	l := max(c.r, max(c.g, c.b))
+	return c * max(l-Threshold, 0) / max(l, 1.0/256)
+}
+`,
+}
+
+var bloomBlurShader = &lazyShader{
+	src: `//kage:unit pixels
+
+package main
+
+var Direction vec2
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	c := imageSrc0At(srcPos) * 0.227027
+	c += (imageSrc0At(srcPos+Direction) + imageSrc0At(srcPos-Direction)) * 0.1945946
+	c += (imageSrc0At(srcPos+2*Direction) + imageSrc0At(srcPos-2*Direction)) * 0.1216216
+	c += (imageSrc0At(srcPos+3*Direction) + imageSrc0At(srcPos-3*Direction)) * 0.054054
+	c += (imageSrc0At(srcPos+4*Direction) + imageSrc0At(srcPos-4*Direction)) * 0.016216
+	return c
+}
+`,
+}
+
+// Bloom is a post effect to make bright areas glow.
+//
+// The bright areas are extracted and blurred at the half resolution, and then added to the source.
+type Bloom struct {
+	// Threshold is the brightness in [0, 1] above which the areas glow.
+	Threshold float64
+
+	// Intensity is the strength of the glow.
+	Intensity float64
+
+	images [2]*ebiten.Image
+}
+
+// NewBloom returns a new Bloom with the default parameters.
+func NewBloom() *Bloom {
+	return &Bloom{
+		Threshold: 0.7,
+		Intensity: 1,
+	}
+}
+
+func (b *Bloom) ensureImages(width, height int) {
+	for i, img := range b.images {
+		if img != nil && img.Bounds().Dx() == width && img.Bounds().Dy() == height {
+			continue
+		}
+		if img != nil {
+			img.Deallocate()
+		}
+		b.images[i] = ebiten.NewImageWithOptions(image.Rect(0, 0, width, height), &ebiten.NewImageOptions{
+			Unmanaged: true,
+		})
+	}
+}
+
+// DrawPostEffect implements ebiten.PostEffect.
+func (b *Bloom) DrawPostEffect(dst, src *ebiten.Image) {
+	dst.DrawImage(src, nil)
+
+	w, h := (src.Bounds().Dx()+1)/2, (src.Bounds().Dy()+1)/2
+	b.ensureImages(w, h)
+	for _, img := range b.images {
+		img.Clear()
+	}
+
+	drawShader(b.images[0], src, bloomBrightPassShader.get(), map[string]any{
+		"Threshold": float32(b.Threshold),
+	})
+	drawShader(b.images[1], b.images[0], bloomBlurShader.get(), map[string]any{
+		"Direction": []float32{1, 0},
+	})
+	b.images[0].Clear()
+	drawShader(b.images[0], b.images[1], bloomBlurShader.get(), map[string]any{
+		"Direction": []float32{0, 1},
+	})
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(float64(src.Bounds().Dx())/float64(w), float64(src.Bounds().Dy())/float64(h))
+	op.GeoM.Translate(float64(dst.Bounds().Min.X), float64(dst.Bounds().Min.Y))
+	op.ColorScale.Scale(float32(b.Intensity), float32(b.Intensity), float32(b.Intensity), float32(b.Intensity))
+	op.Filter = ebiten.FilterLinear
+	op.Blend = ebiten.BlendLighter
+	dst.DrawImage(b.images[0], op)
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package posteffect
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var crtShader = &lazyShader{
+	src: `//kage:unit pixels
+
+package main
+
+var Curvature float
+var ScanlineIntensity float
+var ScanlineHeight float
+
+` + samplingFuncs + `
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+	uv := (srcPos-origin)/size - 0.5
+	uv *= 1 + Curvature*dot(uv, uv)
+	uv += 0.5
+	if uv.x < 0 || uv.x > 1 || uv.y < 0 || uv.y > 1 {
+		return vec4(0)
+	}
+	c := sample(origin + uv*size)
+	s := 1 - ScanlineIntensity*(0.5-0.5*cos(2*3.14159265*dstPos.y/ScanlineHeight))
+	return vec4(c.rgb*s, c.a)
+}
+`,
+}
+
+// CRT is a post effect to emulate a CRT display with a curved screen and scanlines.
+type CRT struct {
+	// Curvature is the strength of the barrel distortion. 0 means a flat screen.
+	Curvature float64
+
+	// ScanlineIntensity is the darkness of the scanlines in [0, 1]. 0 means no scanlines.
+	ScanlineIntensity float64
+
+	// ScanlineHeight is the period of the scanlines in the final screen's pixels.
+	// If ScanlineHeight is not positive, no scanlines are rendered.
+	ScanlineHeight float64
+}
+
+// NewCRT returns a new CRT with the default parameters.
+func NewCRT() *CRT {
+	return &CRT{
+		Curvature:         0.1,
+		ScanlineIntensity: 0.3,
+		ScanlineHeight:    3,
+	}
+}
+
+// DrawPostEffect implements ebiten.PostEffect.
+func (c *CRT) DrawPostEffect(dst, src *ebiten.Image) {
+	intensity := c.ScanlineIntensity
+	height := c.ScanlineHeight
+	if height <= 0 {
+		intensity = 0
+		height = 1
+	}
+	drawShader(dst, src, crtShader.get(), map[string]any{
+		"Curvature":         float32(c.Curvature),
+		"ScanlineIntensity": float32(intensity),
+		"ScanlineHeight":    float32(height),
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package posteffect
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// fxaaShader is based on Timothy Lottes's FXAA.
+var fxaaShader = &lazyShader{
+	src: `//kage:unit pixels
+
+package main
+
+` + samplingFuncs + `
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	const reduceMin = 1.0 / 128
+	const reduceMul = 1.0 / 8
+	const spanMax = 8.0
+
+	lumaNW := luma(sample(srcPos + vec2(-1, -1)))
+	lumaNE := luma(sample(srcPos + vec2(1, -1)))
+	lumaSW := luma(sample(srcPos + vec2(-1, 1)))
+	lumaSE := luma(sample(srcPos + vec2(1, 1)))
+	lumaM := luma(imageSrc0At(srcPos))
+	lumaMin := min(lumaM, min(min(lumaNW, lumaNE), min(lumaSW, lumaSE)))
+	lumaMax := max(lumaM, max(max(lumaNW, lumaNE), max(lumaSW, lumaSE)))
+
+	dir := vec2(-((lumaNW + lumaNE) - (lumaSW + lumaSE)), (lumaNW + lumaSW) - (lumaNE + lumaSE))
+	dirReduce := max((lumaNW+lumaNE+lumaSW+lumaSE)*0.25*reduceMul, reduceMin)
+	rcpDirMin := 1 / (min(abs(dir.x), abs(dir.y)) + dirReduce)
+	dir = clamp(dir*rcpDirMin, -spanMax, spanMax)
+
+	rgbA := 0.5 * (sample(srcPos+dir*(1.0/3-0.5)) + sample(srcPos+dir*(2.0/3-0.5)))
+	rgbB := rgbA*0.5 + 0.25*(sample(srcPos-dir*0.5)+sample(srcPos+dir*0.5))
+	lumaB := luma(rgbB)
+	if lumaB < lumaMin || lumaB > lumaMax {
+		return rgbA
+	}
+	return rgbB
+}
+`,
+}
+
+// FXAA is a post effect of fast approximate anti-aliasing.
+type FXAA struct{}
+
+// NewFXAA returns a new FXAA.
+func NewFXAA() *FXAA {
+	return &FXAA{}
+}
+
+// DrawPostEffect implements ebiten.PostEffect.
+func (f *FXAA) DrawPostEffect(dst, src *ebiten.Image) {
+	drawShader(dst, src, fxaaShader.get(), nil)
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package posteffect provides built-in post effects for ebiten.SetPostEffects.
+package posteffect
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Shader is a post effect with a user Kage shader.
+//
+// The shader is rendered with DrawRectShader, and the source image is given as the first image.
+type Shader struct {
+	// Shader is the shader to render the effect.
+	Shader *ebiten.Shader
+
+	// Uniforms is the uniform variables for the shader.
+	Uniforms map[string]any
+}
+
+// DrawPostEffect implements ebiten.PostEffect.
+func (s *Shader) DrawPostEffect(dst, src *ebiten.Image) {
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = src
+	op.Uniforms = s.Uniforms
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dst.DrawRectShader(w, h, s.Shader, op)
+}
+
+// lazyShader is a built-in shader compiled at the first use.
+type lazyShader struct {
+	src    string
+	shader *ebiten.Shader
+	once   sync.Once
+}
+
+func (l *lazyShader) get() *ebiten.Shader {
+	l.once.Do(func() {
+		s, err := ebiten.NewShader([]byte(l.src))
+		if err != nil {
+			panic(fmt.Sprintf("posteffect: NewShader for a built-in shader failed: %v", err))
+		}
+		l.shader = s
+	})
+	return l.shader
+}
+
+// drawShader renders the whole src stretched to the whole dst with the shader.
+// The shader's unit must be pixels.
+func drawShader(dst, src *ebiten.Image, shader *ebiten.Shader, uniforms map[string]any) {
+	db := dst.Bounds()
+	sb := src.Bounds()
+	vs := []ebiten.Vertex{
+		{
+			DstX: float32(db.Min.X),
+			DstY: float32(db.Min.Y),
+			SrcX: float32(sb.Min.X),
+			SrcY: float32(sb.Min.Y),
+		},
+		{
+			DstX: float32(db.Max.X),
+			DstY: float32(db.Min.Y),
+			SrcX: float32(sb.Max.X),
+			SrcY: float32(sb.Min.Y),
+		},
+		{
+			DstX: float32(db.Min.X),
+			DstY: float32(db.Max.Y),
+			SrcX: float32(sb.Min.X),
+			SrcY: float32(sb.Max.Y),
+		},
+		{
+			DstX: float32(db.Max.X),
+			DstY: float32(db.Max.Y),
+			SrcX: float32(sb.Max.X),
+			SrcY: float32(sb.Max.Y),
+		},
+	}
+	for i := range vs {
+		vs[i].ColorR = 1
+		vs[i].ColorG = 1
+		vs[i].ColorB = 1
+		vs[i].ColorA = 1
+	}
+	is := []uint16{0, 1, 2, 1, 3, 2}
+
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	op.Images[0] = src
+	op.Uniforms = uniforms
+	dst.DrawTrianglesShader(vs, is, shader, op)
+}
+
+// samplingFuncs is Kage functions to sample the source image with the bilinear filter.
+// Positions out of the source image are clamped.
+const samplingFuncs = `
+func sample(pos vec2) vec4 {
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+	pos = clamp(pos, origin+0.5, origin+size-0.5) - 0.5
+	f := fract(pos)
+	pos = floor(pos) + 0.5
+	c00 := imageSrc0At(pos)
+	c10 := imageSrc0At(pos + vec2(1, 0))
+	c01 := imageSrc0At(pos + vec2(0, 1))
+	c11 := imageSrc0At(pos + vec2(1, 1))
+	return mix(mix(c00, c10, f.x), mix(c01, c11, f.x), f.y)
+}
+
+func luma(c vec4) float {
+	return dot(c.rgb, vec3(0.299, 0.587, 0.114))
+}
+`
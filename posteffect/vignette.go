@@ -0,0 +1,70 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package posteffect
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var vignetteShader = &lazyShader{
+	src: `//kage:unit pixels
+
+package main
+
+var Radius float
+var Softness float
+var Strength float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	c := imageSrc0At(srcPos)
+	p := (srcPos-imageSrc0Origin())/imageSrc0Size() - 0.5
+	// d is 0 at the center and 1 at the corners.
+	d := length(p) * sqrt(2)
+	v := 1 - Strength*smoothstep(Radius, Radius+Softness, d)
+	return vec4(c.rgb*v, c.a)
+}
+`,
+}
+
+// Vignette is a post effect to darken the screen's edges.
+type Vignette struct {
+	// Radius is the distance from the center where the darkening starts.
+	// The distance is 0 at the center and 1 at the corners.
+	Radius float64
+
+	// Softness is the length of the transition from Radius to the darkest area.
+	Softness float64
+
+	// Strength is the darkness at the darkest area in [0, 1].
+	Strength float64
+}
+
+// NewVignette returns a new Vignette with the default parameters.
+func NewVignette() *Vignette {
+	return &Vignette{
+		Radius:   0.5,
+		Softness: 0.5,
+		Strength: 0.8,
+	}
+}
+
+// DrawPostEffect implements ebiten.PostEffect.
+func (v *Vignette) DrawPostEffect(dst, src *ebiten.Image) {
+	drawShader(dst, src, vignetteShader.get(), map[string]any{
+		"Radius":   float32(v.Radius),
+		"Softness": float32(v.Softness),
+		"Strength": float32(v.Strength),
+	})
+}
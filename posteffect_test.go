@@ -0,0 +1,201 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestPostEffectRegion(t *testing.T) {
+	testCases := []struct {
+		Name       string
+		Screen     image.Rectangle
+		Scale      float64
+		OffsetX    float64
+		OffsetY    float64
+		GameWidth  int
+		GameHeight int
+		Want       image.Rectangle
+	}{
+		{
+			Name:       "same size",
+			Screen:     image.Rect(0, 0, 320, 240),
+			Scale:      1,
+			GameWidth:  320,
+			GameHeight: 240,
+			Want:       image.Rect(0, 0, 320, 240),
+		},
+		{
+			Name:       "pillarbox",
+			Screen:     image.Rect(0, 0, 800, 480),
+			Scale:      2,
+			OffsetX:    80,
+			GameWidth:  320,
+			GameHeight: 240,
+			Want:       image.Rect(80, 0, 720, 480),
+		},
+		{
+			Name:       "fractional",
+			Screen:     image.Rect(0, 0, 500, 300),
+			Scale:      1.25,
+			OffsetX:    49.5,
+			GameWidth:  320,
+			GameHeight: 240,
+			Want:       image.Rect(49, 0, 450, 300),
+		},
+		{
+			Name:       "clipped",
+			Screen:     image.Rect(0, 0, 300, 200),
+			Scale:      1,
+			OffsetX:    -10,
+			OffsetY:    -10,
+			GameWidth:  320,
+			GameHeight: 240,
+			Want:       image.Rect(0, 0, 300, 200),
+		},
+		{
+			Name:       "outside",
+			Screen:     image.Rect(0, 0, 320, 240),
+			Scale:      1,
+			OffsetX:    400,
+			GameWidth:  320,
+			GameHeight: 240,
+			Want:       image.Rectangle{},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var geoM ebiten.GeoM
+			geoM.Scale(tc.Scale, tc.Scale)
+			geoM.Translate(tc.OffsetX, tc.OffsetY)
+			got := ebiten.PostEffectRegionForTesting(tc.Screen, geoM, tc.GameWidth, tc.GameHeight)
+			if got.Empty() && tc.Want.Empty() {
+				return
+			}
+			if got != tc.Want {
+				t.Errorf("got: %v, want: %v", got, tc.Want)
+			}
+		})
+	}
+}
+
+type testPostEffect struct {
+	clr     color.RGBA
+	log     *[]string
+	name    string
+	srcSize image.Point
+	dstSize image.Point
+}
+
+func (e *testPostEffect) DrawPostEffect(dst, src *ebiten.Image) {
+	*e.log = append(*e.log, e.name)
+	e.srcSize = src.Bounds().Size()
+	e.dstSize = dst.Bounds().Size()
+	if got := dst.At(0, 0); got != (color.RGBA{}) {
+		*e.log = append(*e.log, "dst not cleared")
+	}
+	dst.Fill(e.clr)
+}
+
+func TestPostEffectChainNoEffects(t *testing.T) {
+	ebiten.SetPostEffects()
+
+	screen := ebiten.NewImage(16, 16)
+	defer screen.Deallocate()
+
+	var c ebiten.PostEffectChainForTesting
+	var called bool
+	if c.Draw(screen, ebiten.GeoM{}, 16, 16, func(dst *ebiten.Image, geoM ebiten.GeoM) {
+		called = true
+	}) {
+		t.Errorf("Draw returned true without effects")
+	}
+	if called {
+		t.Errorf("drawGame must not be called without effects")
+	}
+}
+
+func TestPostEffectChain(t *testing.T) {
+	const (
+		screenWidth  = 24
+		screenHeight = 16
+		gameWidth    = 8
+		gameHeight   = 8
+	)
+
+	var log []string
+	e0 := &testPostEffect{clr: color.RGBA{0xff, 0, 0, 0xff}, log: &log, name: "e0"}
+	e1 := &testPostEffect{clr: color.RGBA{0, 0xff, 0, 0xff}, log: &log, name: "e1"}
+	ebiten.SetPostEffects(e0, e1)
+	defer ebiten.SetPostEffects()
+
+	screen := ebiten.NewImage(screenWidth, screenHeight)
+	defer screen.Deallocate()
+	bg := color.RGBA{0, 0, 0xff, 0xff}
+	screen.Fill(bg)
+
+	// The game is scaled by 2 and put at the center of the screen: (4, 0)-(20, 16).
+	var geoM ebiten.GeoM
+	geoM.Scale(2, 2)
+	geoM.Translate(4, 0)
+
+	var c ebiten.PostEffectChainForTesting
+	var gameDst image.Rectangle
+	var gameX, gameY float64
+	if !c.Draw(screen, geoM, gameWidth, gameHeight, func(dst *ebiten.Image, geoM ebiten.GeoM) {
+		log = append(log, "game")
+		gameDst = dst.Bounds()
+		gameX, gameY = geoM.Apply(0, 0)
+	}) {
+		t.Fatalf("Draw returned false with effects")
+	}
+
+	if got, want := log, []string{"game", "e0", "e1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("log: got: %v, want: %v", got, want)
+	}
+	if got, want := gameDst, image.Rect(0, 0, 16, 16); got != want {
+		t.Errorf("game destination: got: %v, want: %v", got, want)
+	}
+	if gameX != 0 || gameY != 0 {
+		t.Errorf("game origin: got: (%v, %v), want: (0, 0)", gameX, gameY)
+	}
+	for _, e := range []*testPostEffect{e0, e1} {
+		if got, want := e.srcSize, image.Pt(16, 16); got != want {
+			t.Errorf("%s src size: got: %v, want: %v", e.name, got, want)
+		}
+		if got, want := e.dstSize, image.Pt(16, 16); got != want {
+			t.Errorf("%s dst size: got: %v, want: %v", e.name, got, want)
+		}
+	}
+
+	for j := 0; j < screenHeight; j++ {
+		for i := 0; i < screenWidth; i++ {
+			got := screen.At(i, j)
+			want := bg
+			if i >= 4 && i < 20 {
+				want = e1.clr
+			}
+			if got != want {
+				t.Errorf("screen.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,421 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// A ProjGeoM represents a 3x3 projective matrix to transform geometry when rendering an image.
+// Unlike GeoM, a ProjGeoM can represent perspective, which is useful for fake 3D effects like mode 7.
+//
+// The initial value is identity.
+type ProjGeoM struct {
+	a_1 float64 // The actual 'a' value minus 1
+	b   float64
+	tx  float64
+	c   float64
+	d_1 float64 // The actual 'd' value minus 1
+	ty  float64
+	p   float64
+	q   float64
+	w_1 float64 // The actual 'w' value minus 1
+}
+
+// ProjGeoMFromGeoM returns a ProjGeoM that represents the same transformation as the given GeoM.
+func ProjGeoMFromGeoM(geoM GeoM) ProjGeoM {
+	return ProjGeoM{
+		a_1: geoM.a_1,
+		b:   geoM.b,
+		tx:  geoM.tx,
+		c:   geoM.c,
+		d_1: geoM.d_1,
+		ty:  geoM.ty,
+	}
+}
+
+// String returns a string representation of ProjGeoM.
+func (g *ProjGeoM) String() string {
+	return fmt.Sprintf("[[%f, %f, %f], [%f, %f, %f], [%f, %f, %f]]", g.a_1+1, g.b, g.tx, g.c, g.d_1+1, g.ty, g.p, g.q, g.w_1+1)
+}
+
+// Reset resets the ProjGeoM as identity.
+func (g *ProjGeoM) Reset() {
+	*g = ProjGeoM{}
+}
+
+func (g *ProjGeoM) elements() [9]float64 {
+	return [...]float64{g.a_1 + 1, g.b, g.tx, g.c, g.d_1 + 1, g.ty, g.p, g.q, g.w_1 + 1}
+}
+
+func (g *ProjGeoM) setElements(e [9]float64) {
+	g.a_1 = e[0] - 1
+	g.b = e[1]
+	g.tx = e[2]
+	g.c = e[3]
+	g.d_1 = e[4] - 1
+	g.ty = e[5]
+	g.p = e[6]
+	g.q = e[7]
+	g.w_1 = e[8] - 1
+}
+
+// Apply pre-multiplies a vector (x, y, 1) by the matrix, and divides the result by its third element.
+// The return value is x and y values of the divided vector.
+//
+// If the third element is 0, the results are infinities or NaNs.
+func (g *ProjGeoM) Apply(x, y float64) (float64, float64) {
+	x, y, w := g.apply3(x, y)
+	return x / w, y / w
+}
+
+// apply3 pre-multiplies a vector (x, y, 1) by the matrix.
+func (g *ProjGeoM) apply3(x, y float64) (float64, float64, float64) {
+	return (g.a_1+1)*x + g.b*y + g.tx, g.c*x + (g.d_1+1)*y + g.ty, g.p*x + g.q*y + g.w_1 + 1
+}
+
+// Element returns a value of a matrix at (i, j).
+func (g *ProjGeoM) Element(i, j int) float64 {
+	if i < 0 || i >= GeoMDim || j < 0 || j >= GeoMDim {
+		panic("ebiten: i or j is out of index")
+	}
+	return g.elements()[i*GeoMDim+j]
+}
+
+// SetElement sets an element at (i, j).
+func (g *ProjGeoM) SetElement(i, j int, element float64) {
+	if i < 0 || i >= GeoMDim || j < 0 || j >= GeoMDim {
+		panic("ebiten: i or j is out of index")
+	}
+	e := g.elements()
+	e[i*GeoMDim+j] = element
+	g.setElements(e)
+}
+
+// Concat multiplies a geometry matrix with the other geometry matrix.
+// This is same as multiplying the matrix other and the matrix g in this order.
+func (g *ProjGeoM) Concat(other ProjGeoM) {
+	l := other.elements()
+	r := g.elements()
+	var e [9]float64
+	for i := 0; i < GeoMDim; i++ {
+		for j := 0; j < GeoMDim; j++ {
+			for k := 0; k < GeoMDim; k++ {
+				e[i*GeoMDim+j] += l[i*GeoMDim+k] * r[k*GeoMDim+j]
+			}
+		}
+	}
+	g.setElements(e)
+}
+
+// ConcatGeoM multiplies a geometry matrix with the affine matrix other.
+// This is same as multiplying the matrix other and the matrix g in this order.
+func (g *ProjGeoM) ConcatGeoM(other GeoM) {
+	g.Concat(ProjGeoMFromGeoM(other))
+}
+
+// Scale scales the matrix by (x, y).
+func (g *ProjGeoM) Scale(x, y float64) {
+	var s GeoM
+	s.Scale(x, y)
+	g.ConcatGeoM(s)
+}
+
+// Translate translates the matrix by (tx, ty).
+func (g *ProjGeoM) Translate(tx, ty float64) {
+	var t GeoM
+	t.Translate(tx, ty)
+	g.ConcatGeoM(t)
+}
+
+// Rotate rotates the matrix by theta.
+// The unit is radian.
+func (g *ProjGeoM) Rotate(theta float64) {
+	var r GeoM
+	r.Rotate(theta)
+	g.ConcatGeoM(r)
+}
+
+func (g *ProjGeoM) det() float64 {
+	e := g.elements()
+	return e[0]*(e[4]*e[8]-e[5]*e[7]) - e[1]*(e[3]*e[8]-e[5]*e[6]) + e[2]*(e[3]*e[7]-e[4]*e[6])
+}
+
+// IsInvertible returns a boolean value indicating
+// whether the matrix g is invertible or not.
+func (g *ProjGeoM) IsInvertible() bool {
+	return g.det() != 0
+}
+
+// Invert inverts the matrix.
+// If g is not invertible, Invert panics.
+func (g *ProjGeoM) Invert() {
+	det := g.det()
+	if det == 0 {
+		panic("ebiten: g is not invertible")
+	}
+	e := g.elements()
+	g.setElements([...]float64{
+		(e[4]*e[8] - e[5]*e[7]) / det,
+		(e[2]*e[7] - e[1]*e[8]) / det,
+		(e[1]*e[5] - e[2]*e[4]) / det,
+		(e[5]*e[6] - e[3]*e[8]) / det,
+		(e[0]*e[8] - e[2]*e[6]) / det,
+		(e[2]*e[3] - e[0]*e[5]) / det,
+		(e[3]*e[7] - e[4]*e[6]) / det,
+		(e[1]*e[6] - e[0]*e[7]) / det,
+		(e[0]*e[4] - e[1]*e[3]) / det,
+	})
+}
+
+// SetRectToQuad sets g to the matrix that maps the rectangle (0, 0)-(width, height) to the quadrilateral
+// whose vertices are (x0, y0), (x1, y1), (x2, y2), and (x3, y3).
+// The vertices correspond to the upper-left, the upper-right, the lower-right, and the lower-left corners in this order.
+//
+// SetRectToQuad reports whether the matrix is set. If the rectangle is empty or the quadrilateral is degenerate,
+// SetRectToQuad doesn't modify g and returns false.
+func (g *ProjGeoM) SetRectToQuad(width, height float64, x0, y0, x1, y1, x2, y2, x3, y3 float64) bool {
+	if width == 0 || height == 0 {
+		return false
+	}
+
+	// Calculate the matrix that maps the unit square to the quadrilateral.
+	// See Paul Heckbert, "Fundamentals of Texture Mapping and Image Warping".
+	sx := x0 - x1 + x2 - x3
+	sy := y0 - y1 + y2 - y3
+	var e [9]float64
+	if sx == 0 && sy == 0 {
+		// The quadrilateral is a parallelogram.
+		e = [...]float64{x1 - x0, x3 - x0, x0, y1 - y0, y3 - y0, y0, 0, 0, 1}
+	} else {
+		dx1, dx2 := x1-x2, x3-x2
+		dy1, dy2 := y1-y2, y3-y2
+		den := dx1*dy2 - dx2*dy1
+		if den == 0 {
+			return false
+		}
+		p := (sx*dy2 - dx2*sy) / den
+		q := (dx1*sy - sx*dy1) / den
+		e = [...]float64{x1 - x0 + p*x1, x3 - x0 + q*x3, x0, y1 - y0 + p*y1, y3 - y0 + q*y3, y0, p, q, 1}
+	}
+
+	var m ProjGeoM
+	m.setElements(e)
+	if !m.IsInvertible() {
+		return false
+	}
+	var s ProjGeoM
+	s.Scale(1/width, 1/height)
+	s.Concat(m)
+	*g = s
+	return true
+}
+
+// DrawImageProjectiveOptions represents options for DrawImageProjective.
+type DrawImageProjectiveOptions struct {
+	// ProjGeoM is a projective matrix to transform the source image.
+	// The default (zero) value is identity.
+	ProjGeoM ProjGeoM
+
+	// ColorScale is a scale of color.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend Blend
+
+	// Filter is a type of texture filter.
+	// The default (zero) value is FilterNearest.
+	Filter Filter
+
+	// Subdivisions is the number of the grid cells in each direction to clip the part behind the viewer.
+	//
+	// The perspective is calculated for each pixel, so Subdivisions doesn't affect the accuracy of the perspective.
+	// Subdivisions is used only when a part of the source image is behind the viewer.
+	// In this case, the source image is split into a grid, and the grid cells that are even partly behind the viewer are not rendered.
+	// A larger value makes the clipped edge closer to the horizon, and makes more vertices.
+	//
+	// The default (zero) value is 16. The maximum value is 255.
+	Subdivisions int
+}
+
+// projectiveMinW is the minimum third element of a projected vertex.
+// A vertex with a smaller value is behind the viewer.
+const projectiveMinW = 1e-6
+
+// DrawImageProjective draws the source image src onto dst with a projective transformation in one draw call.
+//
+// The source position is calculated from the destination position for each pixel with the inverse matrix,
+// so the perspective is correct without any distortion.
+//
+// A part of the source image behind the viewer, i.e. whose third element is non-positive after the projection,
+// is not rendered. As the part is clipped by the grid cells of Subdivisions, a cell crossing the horizon is not rendered
+// as a whole.
+//
+// If ProjGeoM is not invertible, DrawImageProjective does nothing.
+func DrawImageProjective(dst *Image, src *Image, options *DrawImageProjectiveOptions) {
+	if options == nil {
+		options = &DrawImageProjectiveOptions{}
+	}
+	sb := src.Bounds()
+	if sb.Empty() {
+		return
+	}
+	if !options.ProjGeoM.IsInvertible() {
+		return
+	}
+
+	n := options.Subdivisions
+	if n <= 0 {
+		n = 16
+	}
+	if n > 255 {
+		n = 255
+	}
+	// A projective transformation maps a line to a line. If the whole image is in front of the viewer,
+	// the image can be rendered as one quadrilateral.
+	if projectiveInFront(&options.ProjGeoM, float64(sb.Dx()), float64(sb.Dy())) {
+		n = 1
+	}
+
+	cs := options.ColorScale
+	vs := make([]Vertex, 0, (n+1)*(n+1))
+	visible := make([]bool, 0, (n+1)*(n+1))
+	for j := 0; j <= n; j++ {
+		for i := 0; i <= n; i++ {
+			sx := float64(sb.Min.X) + float64(sb.Dx())*float64(i)/float64(n)
+			sy := float64(sb.Min.Y) + float64(sb.Dy())*float64(j)/float64(n)
+			x, y, w := options.ProjGeoM.apply3(sx-float64(sb.Min.X), sy-float64(sb.Min.Y))
+			v := w > projectiveMinW && !math.IsInf(x/w, 0) && !math.IsInf(y/w, 0)
+			visible = append(visible, v)
+			if !v {
+				w = 1
+			}
+			vs = append(vs, Vertex{
+				DstX:   float32(x / w),
+				DstY:   float32(y / w),
+				SrcX:   float32(sx),
+				SrcY:   float32(sy),
+				ColorR: cs.R(),
+				ColorG: cs.G(),
+				ColorB: cs.B(),
+				ColorA: cs.A(),
+			})
+		}
+	}
+
+	is := make([]uint16, 0, 6*n*n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			v := uint16(j*(n+1) + i)
+			if !visible[v] || !visible[v+1] || !visible[v+uint16(n)+1] || !visible[v+uint16(n)+2] {
+				continue
+			}
+			is = append(is, v, v+1, v+uint16(n)+1, v+1, v+uint16(n)+2, v+uint16(n)+1)
+		}
+	}
+	if len(is) == 0 {
+		return
+	}
+
+	// The inverse matrix maps a destination position to a source position relative to the source image's upper-left corner.
+	inv := options.ProjGeoM
+	inv.Invert()
+	e := inv.elements()
+	db := dst.Bounds()
+	var filter int
+	if options.Filter == FilterLinear {
+		filter = 1
+	}
+
+	op := &DrawTrianglesShaderOptions{}
+	op.Blend = options.Blend
+	op.Images[0] = src
+	op.Uniforms = map[string]any{
+		"InvRow0":      []float32{float32(e[0]), float32(e[1]), float32(e[2])},
+		"InvRow1":      []float32{float32(e[3]), float32(e[4]), float32(e[5])},
+		"InvRow2":      []float32{float32(e[6]), float32(e[7]), float32(e[8])},
+		"DstOffset":    []float32{float32(db.Min.X), float32(db.Min.Y)},
+		"FilterLinear": filter,
+	}
+	dst.DrawTrianglesShader(vs, is, projectiveShader(), op)
+}
+
+// projectiveInFront reports whether the whole rectangle (0, 0)-(width, height) is in front of the viewer after the projection.
+func projectiveInFront(g *ProjGeoM, width, height float64) bool {
+	// The third element is an affine function of the position, so checking the corners is enough.
+	for _, p := range [...][2]float64{{0, 0}, {width, 0}, {0, height}, {width, height}} {
+		if _, _, w := g.apply3(p[0], p[1]); w <= projectiveMinW {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	theProjectiveShader     *Shader
+	theProjectiveShaderOnce sync.Once
+)
+
+func projectiveShader() *Shader {
+	theProjectiveShaderOnce.Do(func() {
+		s, err := NewShader([]byte(projectiveShaderSrc))
+		if err != nil {
+			panic(fmt.Sprintf("ebiten: compiling the projective shader failed: %v", err))
+		}
+		theProjectiveShader = s
+	})
+	return theProjectiveShader
+}
+
+const projectiveShaderSrc = `//kage:unit pixels
+
+package main
+
+var InvRow0 vec3
+var InvRow1 vec3
+var InvRow2 vec3
+var DstOffset vec2
+var FilterLinear int
+
+func texelAt(p vec2) vec4 {
+	// Clamp the position to the source region like the regular rendering does.
+	origin := imageSrc0Origin()
+	return imageSrc0UnsafeAt(clamp(p, origin+0.5, origin+imageSrc0Size()-0.5))
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	// Calculate the source position from the destination position for each pixel.
+	d := vec3(dstPos.xy-imageDstOrigin()+DstOffset, 1)
+	s := vec3(dot(InvRow0, d), dot(InvRow1, d), dot(InvRow2, d))
+	p := s.xy/s.z + imageSrc0Origin()
+
+	if FilterLinear == 0 {
+		return texelAt(floor(p)+0.5) * color
+	}
+
+	p -= 0.5
+	f := fract(p)
+	p = floor(p) + 0.5
+	c00 := texelAt(p)
+	c10 := texelAt(p + vec2(1, 0))
+	c01 := texelAt(p + vec2(0, 1))
+	c11 := texelAt(p + vec2(1, 1))
+	return mix(mix(c00, c10, f.x), mix(c01, c11, f.x), f.y) * color
+}
+`
@@ -0,0 +1,141 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestProjGeoMFromGeoM(t *testing.T) {
+	var g ebiten.GeoM
+	g.Scale(2, 3)
+	g.Rotate(math.Pi / 6)
+	g.Translate(10, 20)
+	p := ebiten.ProjGeoMFromGeoM(g)
+
+	for _, v := range [][2]float64{{0, 0}, {1, 0}, {0, 1}, {-5, 7}} {
+		wantX, wantY := g.Apply(v[0], v[1])
+		gotX, gotY := p.Apply(v[0], v[1])
+		if math.Abs(gotX-wantX) > 1e-9 || math.Abs(gotY-wantY) > 1e-9 {
+			t.Errorf("p.Apply(%f, %f): got: (%f, %f), want: (%f, %f)", v[0], v[1], gotX, gotY, wantX, wantY)
+		}
+	}
+}
+
+func TestProjGeoMSetRectToQuad(t *testing.T) {
+	quad := [4][2]float64{{10, 20}, {110, 30}, {90, 140}, {5, 120}}
+	var p ebiten.ProjGeoM
+	if !p.SetRectToQuad(64, 32, quad[0][0], quad[0][1], quad[1][0], quad[1][1], quad[2][0], quad[2][1], quad[3][0], quad[3][1]) {
+		t.Fatal("SetRectToQuad failed")
+	}
+
+	for i, v := range [][2]float64{{0, 0}, {64, 0}, {64, 32}, {0, 32}} {
+		gotX, gotY := p.Apply(v[0], v[1])
+		if math.Abs(gotX-quad[i][0]) > 1e-9 || math.Abs(gotY-quad[i][1]) > 1e-9 {
+			t.Errorf("p.Apply(%f, %f): got: (%f, %f), want: (%f, %f)", v[0], v[1], gotX, gotY, quad[i][0], quad[i][1])
+		}
+	}
+
+	q := p
+	q.Invert()
+	for _, v := range quad {
+		x, y := p.Apply(q.Apply(v[0], v[1]))
+		if math.Abs(x-v[0]) > 1e-9 || math.Abs(y-v[1]) > 1e-9 {
+			t.Errorf("p.Apply(q.Apply(%f, %f)): got: (%f, %f)", v[0], v[1], x, y)
+		}
+	}
+}
+
+func TestProjectiveShader(t *testing.T) {
+	if _, err := ebiten.NewShader([]byte(ebiten.ProjectiveShaderSrcForTesting)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDrawImageProjective(t *testing.T) {
+	// Each pixel of the source has its own color.
+	const srcSize = 4
+	src := ebiten.NewImage(srcSize, srcSize)
+	pix := make([]byte, 4*srcSize*srcSize)
+	for j := 0; j < srcSize; j++ {
+		for i := 0; i < srcSize; i++ {
+			idx := 4 * (j*srcSize + i)
+			pix[idx] = uint8(0x40 * i)
+			pix[idx+1] = uint8(0x40 * j)
+			pix[idx+2] = 0x80
+			pix[idx+3] = 0xff
+		}
+	}
+	src.WritePixels(pix)
+
+	// Map the source to a trapezoid like a floor in a perspective view.
+	op := &ebiten.DrawImageProjectiveOptions{}
+	if !op.ProjGeoM.SetRectToQuad(srcSize, srcSize, 40, 10, 88, 10, 120, 110, 8, 110) {
+		t.Fatal("SetRectToQuad failed")
+	}
+	dst := ebiten.NewImage(128, 128)
+	ebiten.DrawImageProjective(dst, src, op)
+
+	// The center of each source pixel must be at the projected position exactly.
+	for j := 0; j < srcSize; j++ {
+		for i := 0; i < srcSize; i++ {
+			x, y := op.ProjGeoM.Apply(float64(i)+0.5, float64(j)+0.5)
+			got := dst.At(int(x), int(y))
+			want := color.RGBA{R: uint8(0x40 * i), G: uint8(0x40 * j), B: 0x80, A: 0xff}
+			if got != want {
+				t.Errorf("dst.At(%d, %d) for the source (%d, %d): got: %v, want: %v", int(x), int(y), i, j, got, want)
+			}
+		}
+	}
+
+	// Outside of the trapezoid is not rendered.
+	if got, want := dst.At(2, 2), (color.RGBA{}); got != want {
+		t.Errorf("dst.At(2, 2): got: %v, want: %v", got, want)
+	}
+}
+
+func TestDrawImageProjectiveBehindViewer(t *testing.T) {
+	src := ebiten.NewImage(16, 16)
+	src.Fill(color.White)
+	dst := ebiten.NewImage(16, 16)
+
+	// The third element is 1 - y/8, so the lower half of the source is behind the viewer.
+	op := &ebiten.DrawImageProjectiveOptions{}
+	op.ProjGeoM.SetElement(2, 1, -1.0/8)
+	op.Subdivisions = 16
+	ebiten.DrawImageProjective(dst, src, op)
+
+	// The upper-left corner is not affected by the perspective.
+	if got, want := dst.At(0, 0), (color.RGBA{0xff, 0xff, 0xff, 0xff}); got != want {
+		t.Errorf("dst.At(0, 0): got: %v, want: %v", got, want)
+	}
+}
+
+func TestDrawImageProjectiveNotInvertible(t *testing.T) {
+	src := ebiten.NewImage(16, 16)
+	src.Fill(color.White)
+	dst := ebiten.NewImage(16, 16)
+
+	op := &ebiten.DrawImageProjectiveOptions{}
+	op.ProjGeoM.Scale(0, 1)
+	ebiten.DrawImageProjective(dst, src, op)
+	if got, want := dst.At(0, 0), (color.RGBA{}); got != want {
+		t.Errorf("dst.At(0, 0): got: %v, want: %v", got, want)
+	}
+}
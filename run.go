@@ -16,10 +16,12 @@ package ebiten
 
 import (
 	"errors"
+	"fmt"
 	"image"
 	"image/color"
 	"io/fs"
 	"sync/atomic"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/clock"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
@@ -96,6 +98,8 @@ type LayoutFer interface {
 	// size in pixels. The logical size is used for 1) the screen size given at Draw and 2) calculation of the
 	// scale from the screen to the final screen size. For 1), the actual screen size is a rounded up of the
 	// logical size.
+	//
+	// To render the game at the display's resolution, return the outside size multiplied by FrameDeviceScaleFactor.
 	LayoutF(outsideWidth, outsideHeight float64) (screenWidth, screenHeight float64)
 }
 
@@ -127,9 +131,53 @@ type FinalScreenDrawer interface {
 	// geoM is the default geometry matrix to render the offscreen onto the final screen.
 	// geoM scales the offscreen to fit the final screen without changing the aspect ratio, and
 	// translates the offscreen to put it in the center of the final screen.
+	//
+	// If post effects are set by SetPostEffects, screen is not the final screen but an intermediate image
+	// covering only the game region of the final screen, and geoM is translated accordingly.
+	// The result is drawn onto the final screen after the post effects are applied.
+	// In this case, screen's bounds don't start at the final screen's origin, and what is rendered
+	// outside the game region is discarded.
 	DrawFinalScreen(screen FinalScreen, offscreen *Image, geoM GeoM)
 }
 
+// LetterboxDrawer is an interface for a custom function to render the letterbox bars,
+// i.e. the regions outside of the game screen on the final screen.
+// This is useful to render decorative borders like classic console emulators.
+type LetterboxDrawer interface {
+	// DrawLetterbox draws the letterbox bars onto the final screen.
+	// If a game implementing LetterboxDrawer is passed to RunGame, DrawLetterbox is called after Draw,
+	// and before the offscreen is rendered onto the final screen, including DrawFinalScreen of FinalScreenDrawer.
+	//
+	// screen is the final screen.
+	// screen is cleared with the background color only when a color other than transparent black is set by SetBackgroundColor
+	// or the graphics driver requires clearing the screen at every frame.
+	// Otherwise, the content of screen is undefined, so DrawLetterbox should fill the whole region outside of gameRegion.
+	//
+	// gameRegion is the region of the final screen where the offscreen is rendered.
+	// What is drawn in gameRegion is overwritten by the offscreen.
+	DrawLetterbox(screen FinalScreen, gameRegion image.Rectangle)
+}
+
+// LateDrawer is an interface for a custom function to render onto the final screen as late as possible.
+// This is useful to reduce the latency between the user's input and the result on the display,
+// e.g. to render a software cursor for a mouse-driven game.
+type LateDrawer interface {
+	// DrawLate draws onto the final screen.
+	// If a game implementing LateDrawer is passed to RunGame, DrawLate is called after the offscreen is rendered
+	// onto the final screen, including DrawFinalScreen of FinalScreenDrawer, and just before the rendering
+	// commands are submitted.
+	//
+	// screen is the final screen. geoM is the same as the argument of DrawFinalScreen of FinalScreenDrawer.
+	// cursorX and cursorY are the cursor position sampled just before DrawLate is called.
+	// The position is in the offscreen's coordinates in the same way as CursorPosition, and is not rounded.
+	// On mobiles, cursorX and cursorY are always 0.
+	//
+	// DrawLate is not called when the presentation of the screen is skipped. See also IsScreenPresentationSkipped.
+	//
+	// DrawLate should not update the game state. The input states other than cursorX and cursorY are not updated.
+	DrawLate(screen FinalScreen, geoM GeoM, cursorX, cursorY float64)
+}
+
 // DefaultTPS represents a default ticks per second, that represents how many times game updating happens in a second.
 const DefaultTPS = clock.DefaultTPS
 
@@ -146,6 +194,38 @@ func ActualFPS() float64 {
 	return clock.ActualFPS()
 }
 
+// TickInterpolationFactor returns the progress from the last tick to the next tick at the current frame in [0, 1].
+//
+// TickInterpolationFactor is useful to render states between ticks smoothly, e.g. when TPS is 30 and FPS is 60.
+// In Draw, a value updated every tick can be rendered as prev + (curr - prev) * TickInterpolationFactor(),
+// where prev and curr are the values at the last two ticks.
+// See also the package exp/interpolation.
+//
+// For smooth rendering, SetFrameRateLimit and SetPreciseFramePacing can be used to make intervals between frames regular.
+//
+// If TPS is SyncWithFPS, TickInterpolationFactor always returns 1.
+//
+// TickInterpolationFactor should be called in Draw.
+//
+// TickInterpolationFactor is concurrent-safe.
+func TickInterpolationFactor() float64 {
+	return clock.InterpolationFactor()
+}
+
+// ActualDroppedFrames returns the current number of dropped frames per second, that represents
+// how many frames failed to be presented at the display's refresh timing in a second.
+//
+// On DirectX with vsync enabled, ActualDroppedFrames is based on the swap chain's frame statistics.
+// Otherwise, ActualDroppedFrames is estimated from the intervals between frames: a frame that takes much longer than
+// the usual interval is counted as dropped frames.
+//
+// This value is for measurement and/or debug like performance HUDs, and your game logic should not rely on this value.
+//
+// ActualDroppedFrames is concurrent-safe.
+func ActualDroppedFrames() float64 {
+	return clock.ActualDroppedFrames()
+}
+
 // CurrentFPS returns the current number of FPS (frames per second), that represents
 // how many swapping buffer happens per second.
 //
@@ -161,6 +241,9 @@ var (
 // SetScreenClearedEveryFrame enables or disables the clearing of the screen at the beginning of each frame.
 // The default value is true and the screen is cleared each frame by default.
 //
+// When the screen is not cleared every frame and Draw doesn't render anything to the screen for a while,
+// Ebitengine skips presenting the screen. See also IsScreenPresentationSkipped.
+//
 // SetScreenClearedEveryFrame is concurrent-safe.
 func SetScreenClearedEveryFrame(cleared bool) {
 	ui.Get().SetScreenClearedEveryFrame(cleared)
@@ -173,6 +256,53 @@ func IsScreenClearedEveryFrame() bool {
 	return ui.Get().IsScreenClearedEveryFrame()
 }
 
+// IsScreenPresentationSkipped reports whether Ebitengine skips presenting the screen at the current frame
+// unless Draw renders something to the screen.
+//
+// IsScreenPresentationSkipped is meaningful in Draw.
+// When IsScreenPresentationSkipped returns true, the screen keeps the content of the previous frame, and
+// the content is not presented to the display again as long as Draw doesn't render anything to the screen.
+// Then, Draw can early-out if the game doesn't have anything new to render.
+// Note that rendering anything to the screen makes the frame presented.
+//
+// IsScreenPresentationSkipped always returns false when the screen is cleared every frame,
+// since the screen is presented at every frame in this case. See also SetScreenClearedEveryFrame.
+// IsScreenPresentationSkipped also returns false when the screen must be presented, e.g. just after the window is resized.
+//
+// IsScreenPresentationSkipped is concurrent-safe.
+func IsScreenPresentationSkipped() bool {
+	return ui.Get().IsScreenPresentationSkipped()
+}
+
+// SetBackgroundColor sets the color to clear the screen with at the beginning of each frame, instead of transparent black.
+// This is useful for a game without a full-screen background to avoid filling the screen by itself.
+//
+// The color is also used for the letterbox bars, i.e. the regions outside of the game screen
+// when the aspect ratios of the game screen and the window are different.
+// The screen is cleared with the color only when IsScreenClearedEveryFrame is true,
+// while the letterbox bars are always filled with the color.
+//
+// The default value is transparent black.
+//
+// SetBackgroundColor is concurrent-safe.
+func SetBackgroundColor(clr color.Color) {
+	r, g, b, a := clr.RGBA()
+	ui.Get().SetBackgroundColor(uint16(r), uint16(g), uint16(b), uint16(a))
+}
+
+// BackgroundColor returns the color to clear the screen with.
+//
+// BackgroundColor is concurrent-safe.
+func BackgroundColor() color.Color {
+	r, g, b, a := ui.Get().BackgroundColor()
+	return color.RGBA64{
+		R: uint16(r*0xffff + 0.5),
+		G: uint16(g*0xffff + 0.5),
+		B: uint16(b*0xffff + 0.5),
+		A: uint16(a*0xffff + 0.5),
+	}
+}
+
 // SetScreenFilterEnabled enables/disables the use of the "screen" filter Ebitengine uses.
 //
 // The "screen" filter is a box filter from game to display resolution.
@@ -274,6 +404,33 @@ type RunGameOptions struct {
 	// The default (zero) value is false, which means that the single thread mode is disabled.
 	SingleThread bool
 
+	// MaxQueuedFrames is the maximum number of frames whose rendering is queued to the render thread
+	// without blocking the game's Update and Draw.
+	//
+	// With a positive value, the rendering commands and the presentation are executed on a dedicated OS thread separated
+	// from Update and Draw, and up to MaxQueuedFrames frames can be in flight even when vsync is enabled.
+	// This improves the frame pacing when the graphics driver's work competes with the game logic, e.g. on Windows and macOS.
+	// On the other hand, the input latency increases by the queued frames.
+	// 1 or 2 is recommended.
+	//
+	// MaxQueuedFrames works only with desktops, and is ignored in the single thread mode.
+	//
+	// The default (zero) value is 0, which means that the presentation of a frame is waited for when vsync is enabled.
+	MaxQueuedFrames int
+
+	// Headless indicates whether the game runs without a window or a graphics device.
+	// In the headless mode, only Update is called based on TPS, and Layout and Draw are never called.
+	// This is useful to reuse a game's logic for servers and tests.
+	//
+	// In the headless mode, inputs are never reported, and window functions don't affect anything.
+	// Drawing to images is allowed but discarded, and functions that require a graphics device,
+	// like reading pixels from an image, must not be called.
+	//
+	// Headless works only with desktops, browsers and consoles.
+	//
+	// The default (zero) value is false, which means that a window and a graphics device are created.
+	Headless bool
+
 	// X11DisplayName is a class name in the ICCCM WM_CLASS window property.
 	X11ClassName string
 
@@ -317,9 +474,10 @@ type RunGameOptions struct {
 func RunGameWithOptions(game Game, options *RunGameOptions) error {
 	defer isRunGameEnded_.Store(true)
 
-	initializeWindowPositionIfNeeded(WindowSize())
-
 	op := toUIRunOptions(options)
+	if !op.Headless {
+		initializeWindowPositionIfNeeded(WindowSize())
+	}
 	// This is necessary to change the result of IsScreenTransparent.
 	screenTransparent.Store(op.ScreenTransparent)
 	g := newGameForUI(game, op.ScreenTransparent)
@@ -491,6 +649,22 @@ func DeviceScaleFactor() float64 {
 	return Monitor().DeviceScaleFactor()
 }
 
+// FrameDeviceScaleFactor returns the device scale factor used for the layout of the current frame.
+//
+// The outside size given to Layout or LayoutF is multiplied by this value to calculate the final screen size
+// in device pixels. For a sharp rendering on a high-DPI display, LayoutF can return the outside size multiplied
+// by FrameDeviceScaleFactor as the logical screen size, even when the device scale factor is fractional.
+//
+// Unlike Monitor().DeviceScaleFactor(), the value is consistent with the outside size during Layout, Update, and Draw
+// in the same frame, even while the window is moving between monitors with different device scale factors.
+//
+// Before the first frame, FrameDeviceScaleFactor returns the same value as Monitor().DeviceScaleFactor().
+//
+// FrameDeviceScaleFactor is concurrent-safe.
+func FrameDeviceScaleFactor() float64 {
+	return ui.Get().FrameDeviceScaleFactor()
+}
+
 // IsVsyncEnabled returns a boolean value indicating whether
 // the game uses the display's vsync.
 func IsVsyncEnabled() bool {
@@ -625,6 +799,130 @@ func SetTPS(tps int) {
 	clock.SetTPS(tps)
 }
 
+// FrameRateLimit returns the maximum number of frames per second.
+// FrameRateLimit returns 0 if there is no limit.
+//
+// FrameRateLimit is concurrent-safe.
+func FrameRateLimit() int {
+	return ui.Get().FrameRateLimit()
+}
+
+// SetFrameRateLimit sets the maximum number of frames per second.
+// The initial value is 0, which means there is no limit other than vsync.
+//
+// SetFrameRateLimit is useful when vsync is off, e.g. with FPSModeVsyncOffMaximum, and the frame rate should be regular.
+// When vsync is on, the frame rate is also limited by the display's refresh rate.
+//
+// If fps is negative, SetFrameRateLimit panics.
+//
+// SetFrameRateLimit is concurrent-safe.
+func SetFrameRateLimit(fps int) {
+	if fps < 0 {
+		panic(fmt.Sprintf("ebiten: fps must be >= 0 but %d", fps))
+	}
+	ui.Get().SetFrameRateLimit(fps)
+}
+
+// IsPreciseFramePacing reports whether the precise frame pacing is enabled.
+//
+// IsPreciseFramePacing is concurrent-safe.
+func IsPreciseFramePacing() bool {
+	return ui.Get().IsPreciseFramePacing()
+}
+
+// SetPreciseFramePacing enables or disables the precise frame pacing.
+// The initial value is false.
+//
+// When the precise frame pacing is enabled, Ebitengine busy-waits for a short while before a frame
+// so that the interval between frames is as close to the frame rate limit as possible.
+// This is more precise than sleeping, which depends on the OS timer resolution, but consumes more CPU.
+//
+// On browsers, SetPreciseFramePacing has no effect since busy-waiting would block the browser's event loop.
+//
+// SetPreciseFramePacing is effective only when the frame rate limit is set by SetFrameRateLimit.
+//
+// SetPreciseFramePacing is concurrent-safe.
+func SetPreciseFramePacing(precise bool) {
+	ui.Get().SetPreciseFramePacing(precise)
+}
+
+// FrameIntervalRange returns the range of the intervals between presenting frames set by SetFrameIntervalRange.
+//
+// FrameIntervalRange is concurrent-safe.
+func FrameIntervalRange() (minInterval, maxInterval time.Duration) {
+	return ui.Get().FrameIntervalRange()
+}
+
+// SetFrameIntervalRange sets the preferred range of the intervals between presenting frames
+// for variable refresh rate displays like ProMotion displays.
+// The initial values are 0, which means no preference.
+//
+// minInterval is the minimum duration to show a frame, i.e. the reciprocal of the maximum frame rate.
+// maxInterval is the maximum duration to show a frame, i.e. the reciprocal of the minimum frame rate.
+// A zero value means no limit.
+//
+// For example, SetFrameIntervalRange(time.Second/120, time.Second/60) renders a game at up to 120Hz on capable displays.
+//
+// On iOS, the range is applied to the display link as its preferred frame rate range.
+// To exceed 60Hz on iPhones, Info.plist must have CADisableMinimumFrameDurationOnPhone with true.
+// On macOS with Metal, minInterval is applied when presenting a frame,
+// and minInterval shorter than 1/60 seconds opts in a high frame rate.
+// On desktops, a frame is presented at least every maxInterval even while the idle throttle is working.
+// On the other environments, SetFrameIntervalRange doesn't affect the timing of presenting frames.
+//
+// If minInterval or maxInterval is negative, or maxInterval is positive and shorter than minInterval,
+// SetFrameIntervalRange panics.
+//
+// SetFrameIntervalRange is concurrent-safe.
+func SetFrameIntervalRange(minInterval, maxInterval time.Duration) {
+	if minInterval < 0 || maxInterval < 0 {
+		panic(fmt.Sprintf("ebiten: minInterval and maxInterval must be >= 0 but %s and %s", minInterval, maxInterval))
+	}
+	if maxInterval > 0 && maxInterval < minInterval {
+		panic(fmt.Sprintf("ebiten: maxInterval (%s) must be >= minInterval (%s)", maxInterval, minInterval))
+	}
+	ui.Get().SetFrameIntervalRange(minInterval, maxInterval)
+}
+
+// IdleThrottle returns the current idle throttle settings set by SetIdleThrottle.
+//
+// IdleThrottle is concurrent-safe.
+func IdleThrottle() (idleDuration time.Duration, fps int) {
+	return ui.Get().IdleThrottle()
+}
+
+// SetIdleThrottle enables or disables the idle throttle.
+// The idle throttle is disabled by default.
+//
+// When the idle throttle is enabled, Ebitengine lowers the frame rate to fps after idleDuration passes
+// without any activities, which saves CPU and battery for tool-style applications.
+// An activity is an input event from keyboards, mice, or touches, or a call of ScheduleFrame.
+// At an activity, the main thread wakes up instantly and the frame rate is restored.
+// Gamepad inputs are not activities as they don't wake up the main thread.
+//
+// While the game is idle, Update is called once per frame and the game's logical time progresses slower than TPS.
+//
+// If idleDuration is 0 or less, the idle throttle is disabled.
+// If fps is 0, no frame is processed until an activity happens while the game is idle.
+// If fps is negative, SetIdleThrottle panics.
+//
+// SetIdleThrottle works only on desktops so far.
+//
+// SetIdleThrottle is concurrent-safe.
+func SetIdleThrottle(idleDuration time.Duration, fps int) {
+	if fps < 0 {
+		panic(fmt.Sprintf("ebiten: fps must be >= 0 but %d", fps))
+	}
+	ui.Get().SetIdleThrottle(idleDuration, fps)
+}
+
+// IsIdleThrottled reports whether the frame rate is lowered by the idle throttle now.
+//
+// IsIdleThrottled is concurrent-safe.
+func IsIdleThrottled() bool {
+	return ui.Get().IsIdleThrottled()
+}
+
 // SetMaxTPS sets the maximum TPS (ticks per second),
 // that represents how many times updating function is called per second.
 //
@@ -703,6 +1001,8 @@ func toUIRunOptions(options *RunGameOptions) *ui.RunOptions {
 		ScreenTransparent: options.ScreenTransparent,
 		SkipTaskbar:       options.SkipTaskbar,
 		SingleThread:      options.SingleThread,
+		MaxQueuedFrames:   options.MaxQueuedFrames,
+		Headless:          options.Headless,
 		X11ClassName:      options.X11ClassName,
 		X11InstanceName:   options.X11InstanceName,
 	}
@@ -29,5 +29,20 @@ import (
 // TODO: Remove this. In order to remove this, the gameForUI should be in another package.
 func RunGameWithoutMainLoop(game Game, options *RunGameOptions) {
 	op := toUIRunOptions(options)
-	ui.Get().RunWithoutMainLoop(newGameForUI(game, op.ScreenTransparent), op)
+	theGameForUIWithoutMainLoop = newGameForUI(game, op.ScreenTransparent)
+	ui.Get().RunWithoutMainLoop(theGameForUIWithoutMainLoop, op)
+}
+
+var theGameForUIWithoutMainLoop *gameForUI
+
+// ReplaceGameWithoutMainLoop replaces the game run by RunGameWithoutMainLoop with the given game.
+// The given game starts at the next frame with the same view and the same graphics context.
+//
+// Ebitengine users should NOT call ReplaceGameWithoutMainLoop.
+// Instead, functions in github.com/hajimehoshi/ebiten/v2/mobile package calls this.
+func ReplaceGameWithoutMainLoop(game Game) {
+	if theGameForUIWithoutMainLoop == nil {
+		panic("ebiten: RunGameWithoutMainLoop must be called before ReplaceGameWithoutMainLoop")
+	}
+	theGameForUIWithoutMainLoop.replaceGame(game)
 }
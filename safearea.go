@@ -0,0 +1,46 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// SafeAreaInsets returns the insets of the safe area from the game screen's edges in the logical screen's pixels,
+// i.e. in the same coordinates as the screen image given at Draw.
+// The safe area is the area not covered by system bars, display cutouts like notches, and system gesture areas.
+// Render important contents like UI within the safe area.
+//
+// The letterbox areas outside of the game screen are taken into account, so an inset is 0 if the unsafe area is
+// entirely in a letterbox area.
+//
+// SafeAreaInsets works on Android and iOS. SafeAreaInsets returns zeros on the other environments.
+//
+// SafeAreaInsets should be called in Update or Draw.
+func SafeAreaInsets() (left, top, right, bottom float64) {
+	return ui.Get().SafeAreaInsets()
+}
+
+// AppendDisplayCutouts appends the rectangles of the display cutouts like notches in the logical screen's coordinates
+// to cutouts, and returns the extended buffer.
+//
+// AppendDisplayCutouts works only on Android so far. AppendDisplayCutouts appends nothing on the other environments.
+//
+// AppendDisplayCutouts should be called in Update or Draw.
+func AppendDisplayCutouts(cutouts []image.Rectangle) []image.Rectangle {
+	return ui.Get().AppendDisplayCutouts(cutouts)
+}
@@ -77,6 +77,35 @@ func (s *Shader) Deallocate() {
 	s.shader.Deallocate()
 }
 
+// Reload recompiles the shader with the given Kage source and replaces the shader program in place.
+//
+// The shader object stays the same, so existing references to the shader, e.g. in DrawRectShaderOptions,
+// keep working and use the new program from the next draw.
+// The draw calls before Reload use the old program.
+//
+// If the compilation fails, Reload returns an error and the shader keeps the current program.
+// The uniform variables should be adjusted by the caller when the new program declares different ones.
+//
+// Reload is useful to iterate a shader without restarting the game, e.g. by watching the source file.
+//
+// Reload panics if the shader is disposed.
+func (s *Shader) Reload(src []byte) error {
+	if s.isDisposed() {
+		panic("ebiten: Reload cannot be called on a disposed shader")
+	}
+
+	ir, err := graphics.CompileShader(src)
+	if err != nil {
+		return err
+	}
+
+	old := s.shader
+	s.shader = ui.NewShader(ir)
+	s.unit = ir.Unit
+	old.Deallocate()
+	return nil
+}
+
 func (s *Shader) appendUniforms(dst []uint32, uniforms map[string]any) []uint32 {
 	return s.shader.AppendUniforms(dst, uniforms)
 }
@@ -2595,3 +2595,59 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 		}
 	}
 }
+
+func TestShaderReload(t *testing.T) {
+	const w, h = 16, 16
+
+	dst := ebiten.NewImage(w, h)
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return vec4(1, 0, 0, 1)
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst.DrawRectShader(w/2, h, s, nil)
+
+	if err := s.Reload([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return vec4(0, 0, 1, 1)
+}
+`)); err != nil {
+		t.Fatal(err)
+	}
+
+	// An invalid source must not break the current program.
+	if err := s.Reload([]byte(`package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return invalid
+}
+`)); err == nil {
+		t.Errorf("Reload with an invalid source must return an error")
+	}
+
+	op := &ebiten.DrawRectShaderOptions{}
+	op.GeoM.Translate(w/2, 0)
+	dst.DrawRectShader(w/2, h, s, op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{B: 0xff, A: 0xff}
+			if i < w/2 {
+				want = color.RGBA{R: 0xff, A: 0xff}
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
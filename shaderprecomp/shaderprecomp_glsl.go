@@ -0,0 +1,57 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !playstation5
+
+package shaderprecomp
+
+import (
+	"io"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir/glsl"
+)
+
+// GLSLVersion represents a GLSL version.
+type GLSLVersion int
+
+const (
+	// GLSLVersionDefault is GLSL 1.50 for desktop OpenGL.
+	GLSLVersionDefault GLSLVersion = GLSLVersion(glsl.GLSLVersionDefault)
+
+	// GLSLVersionES300 is GLSL ES 3.00 for OpenGL ES 3 and WebGL 2.
+	GLSLVersionES300 GLSLVersion = GLSLVersion(glsl.GLSLVersionES300)
+)
+
+// CompileToGLSL compiles the shader source to OpenGL Shading Language to writers.
+//
+// The results are the same as what Ebitengine passes to OpenGL, including the preludes.
+// OpenGL doesn't have a portable binary format, so there is no function to register precompiled GLSL.
+// CompileToGLSL is useful to inspect or validate the generated shaders at build time, e.g. with glslangValidator.
+//
+// CompileToGLSL is concurrent-safe.
+func CompileToGLSL(vertexWriter, fragmentWriter io.Writer, source *ShaderSource, version GLSLVersion) error {
+	ir, err := graphics.CompileShader(source.source)
+	if err != nil {
+		return err
+	}
+	vs, fs := glsl.Compile(ir, glsl.GLSLVersion(version))
+	if _, err = vertexWriter.Write([]byte(vs)); err != nil {
+		return err
+	}
+	if _, err = fragmentWriter.Write([]byte(fs)); err != nil {
+		return err
+	}
+	return nil
+}
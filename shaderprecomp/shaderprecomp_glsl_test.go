@@ -0,0 +1,97 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !playstation5
+
+package shaderprecomp_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/shaderprecomp"
+)
+
+const testShaderSource = `//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return vec4(1, 0, 0, 1)
+}
+`
+
+func TestCompileToGLSL(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Version shaderprecomp.GLSLVersion
+		Prefix  string
+	}{
+		{Name: "default", Version: shaderprecomp.GLSLVersionDefault, Prefix: "#version 150"},
+		{Name: "ES 3.00", Version: shaderprecomp.GLSLVersionES300, Prefix: "#version 300 es"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			var vs, fs strings.Builder
+			if err := shaderprecomp.CompileToGLSL(&vs, &fs, shaderprecomp.NewShaderSource([]byte(testShaderSource)), tc.Version); err != nil {
+				t.Fatal(err)
+			}
+			for name, src := range map[string]string{"vertex": vs.String(), "fragment": fs.String()} {
+				if !strings.HasPrefix(src, tc.Prefix) {
+					t.Errorf("%s shader must start with %q: %q", name, tc.Prefix, src)
+				}
+				if !strings.Contains(src, "void main(") {
+					t.Errorf("%s shader must have main: %q", name, src)
+				}
+			}
+		})
+	}
+}
+
+func TestCompileToGLSLBuiltinShaders(t *testing.T) {
+	for i, s := range shaderprecomp.AppendBuildinShaderSources(nil) {
+		var vs, fs strings.Builder
+		if err := shaderprecomp.CompileToGLSL(&vs, &fs, s, shaderprecomp.GLSLVersionDefault); err != nil {
+			t.Errorf("the built-in shader %d: %v", i, err)
+		}
+	}
+}
+
+func TestCompileToGLSLInvalidSource(t *testing.T) {
+	var vs, fs strings.Builder
+	if err := shaderprecomp.CompileToGLSL(&vs, &fs, shaderprecomp.NewShaderSource([]byte("package main\n\nfunc Fragment(")), shaderprecomp.GLSLVersionDefault); err == nil {
+		t.Errorf("got: nil, want: an error")
+	}
+	if vs.Len() != 0 || fs.Len() != 0 {
+		t.Errorf("nothing must be written for an invalid source")
+	}
+}
+
+type errorWriter struct {
+	err error
+}
+
+func (e *errorWriter) Write(b []byte) (int, error) {
+	return 0, e.err
+}
+
+func TestCompileToGLSLWriterError(t *testing.T) {
+	want := errors.New("test")
+	var vs strings.Builder
+	if err := shaderprecomp.CompileToGLSL(&vs, &errorWriter{err: want}, shaderprecomp.NewShaderSource([]byte(testShaderSource)), shaderprecomp.GLSLVersionDefault); !errors.Is(err, want) {
+		t.Errorf("got: %v, want: %v", err, want)
+	}
+}
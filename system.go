@@ -0,0 +1,64 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// ColorSchemeType represents a color scheme preferred by the system, like the light and dark modes.
+type ColorSchemeType = ui.ColorScheme
+
+// ColorSchemeTypes
+const (
+	// ColorSchemeUnknown indicates that the preferred color scheme is unknown.
+	ColorSchemeUnknown ColorSchemeType = ui.ColorSchemeUnknown
+
+	// ColorSchemeLight indicates that the light mode is preferred.
+	ColorSchemeLight ColorSchemeType = ui.ColorSchemeLight
+
+	// ColorSchemeDark indicates that the dark mode is preferred.
+	ColorSchemeDark ColorSchemeType = ui.ColorSchemeDark
+)
+
+// SystemColorScheme returns the color scheme preferred by the system.
+//
+// The result is updated when the system setting changes. To detect a change, compare the result with the previous
+// result in Update. The system setting is queried again only when the system notifies a change.
+//
+// SystemColorScheme works on Windows, macOS, and browsers.
+// On Linux and BSD, the XDG desktop portal and GNOME's settings are used via the gdbus and gsettings commands
+// if available, and the GTK_THEME environment variable is used as a hint otherwise.
+// SystemColorScheme returns ColorSchemeUnknown on the other environments.
+//
+// SystemColorScheme is concurrent-safe.
+func SystemColorScheme() ColorSchemeType {
+	return ui.Get().SystemColorScheme()
+}
+
+// AppendSystemLocales appends the locales preferred by the user in the order of the preference to locales,
+// and returns the extended buffer.
+// A locale is a BCP 47 language tag like "en-US". The format might slightly differ by the environment,
+// so parse the results with a library like golang.org/x/text/language.
+//
+// Giving a slice that already has enough capacity works efficiently.
+//
+// AppendSystemLocales works on Windows, macOS, Linux, BSD, and browsers.
+// AppendSystemLocales appends nothing on the other environments.
+//
+// AppendSystemLocales is concurrent-safe.
+func AppendSystemLocales(locales []string) []string {
+	return ui.Get().AppendSystemLocales(locales)
+}
@@ -15,7 +15,14 @@
 package text
 
 import (
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/go-text/typesetting/opentype/api"
 	"golang.org/x/image/math/fixed"
+
+	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 func Fixed26_6ToFloat32(x fixed.Int26_6) float32 {
@@ -33,3 +40,93 @@ func Float32ToFixed26_6(x float32) fixed.Int26_6 {
 func Float64ToFixed26_6(x float64) fixed.Int26_6 {
 	return float64ToFixed26_6(x)
 }
+
+type COLRLayerForTesting struct {
+	GID          uint16
+	PaletteIndex uint16
+}
+
+func ParseCOLRTableForTesting(colr, cpal []byte) (map[uint16][]COLRLayerForTesting, []color.RGBA, error) {
+	t, err := parseCOLRTable(colr, cpal)
+	if err != nil {
+		return nil, nil, err
+	}
+	layers := map[uint16][]COLRLayerForTesting{}
+	for gid, ls := range t.layers {
+		for _, l := range ls {
+			layers[uint16(gid)] = append(layers[uint16(gid)], COLRLayerForTesting{
+				GID:          uint16(l.gid),
+				PaletteIndex: l.paletteIndex,
+			})
+		}
+	}
+	return layers, t.palette, nil
+}
+
+// runeFace is a Face that has only the given runes.
+type runeFace struct {
+	runes string
+}
+
+// NewRuneFaceForTesting returns a Face that has only the given runes.
+func NewRuneFaceForTesting(runes string) Face {
+	return &runeFace{runes: runes}
+}
+
+func (r *runeFace) Metrics() Metrics {
+	return Metrics{}
+}
+
+func (r *runeFace) advance(text string) float64 {
+	return 0
+}
+
+func (r *runeFace) hasGlyph(rn rune) bool {
+	return strings.ContainsRune(r.runes, rn)
+}
+
+func (r *runeFace) appendGlyphsForLine(glyphs []Glyph, line string, indexOffset int, originX, originY float64) []Glyph {
+	return glyphs
+}
+
+func (r *runeFace) appendVectorPathForLine(path *vector.Path, line string, originX, originY float64) {
+}
+
+func (r *runeFace) direction() Direction {
+	return DirectionLeftToRight
+}
+
+func (r *runeFace) private() {
+}
+
+type TextChunkForTesting struct {
+	Text      string
+	FaceIndex int
+}
+
+func SplitTextForTesting(m *MultiFace, text string) []TextChunkForTesting {
+	var chunks []TextChunkForTesting
+	for _, c := range m.splitText(text) {
+		chunks = append(chunks, TextChunkForTesting{
+			Text:      text[c.textStartIndex:c.textEndIndex],
+			FaceIndex: c.faceIndex,
+		})
+	}
+	return chunks
+}
+
+func SDFSpreadForTesting(sdfSize float64) float64 {
+	return sdfSpread(sdfSize)
+}
+
+func SegmentsToSDFForTesting(segs []api.Segment, minX, minY float32, width, height int, spread float64) *image.RGBA {
+	return segmentsToSDF(segs, minX, minY, width, height, spread)
+}
+
+func DistanceTransformForTesting(grid []float64, width, height int) {
+	distanceTransform(grid, width, height)
+}
+
+func (g *GoTextFace) SDFSizeForTesting() float64 {
+	return g.sdfSize()
+}
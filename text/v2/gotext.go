@@ -18,9 +18,11 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math"
 
 	"github.com/go-text/typesetting/di"
 	glanguage "github.com/go-text/typesetting/language"
+	"github.com/go-text/typesetting/opentype/api"
 	"github.com/go-text/typesetting/opentype/api/font"
 	"github.com/go-text/typesetting/opentype/loader"
 	"github.com/go-text/typesetting/shaping"
@@ -58,6 +60,23 @@ type GoTextFace struct {
 	// If this is empty, the script is guessed from the specified language.
 	Script language.Script
 
+	// SDF specifies whether glyphs are rendered with signed distance fields.
+	//
+	// With SDF, a glyph image is created once regardless of Size, and is scaled with a shader at Draw.
+	// This keeps glyphs crisp when the text is scaled or rotated with DrawOptions' GeoM,
+	// and also avoids creating glyph images for each size when Size changes smoothly, e.g., in a zooming UI.
+	// On the other hand, small glyphs might look less sharp than the regular rendering, and very thin strokes might be lost.
+	//
+	// With SDF, glyph images returned by AppendGlyphs are distance fields, and they cannot be rendered with DrawImage as they are.
+	// Use Draw to render them. DrawOptions' ColorM and Filter are ignored.
+	SDF bool
+
+	// SDFSize is the font size in pixels to create a signed distance field when SDF is true.
+	//
+	// A larger SDFSize keeps finer details of glyphs and sharper corners, but uses more memory.
+	// If SDFSize is not positive, 64 is used.
+	SDFSize float64
+
 	variations []font.Variation
 	features   []shaping.FontFeature
 
@@ -304,7 +323,29 @@ func (g *GoTextFace) appendGlyphsForLine(glyphs []Glyph, line string, indexOffse
 	}
 	_, gs := g.Source.shape(line, g)
 	for _, glyph := range gs {
-		img, imgX, imgY := g.glyphImage(glyph, origin.Add(fixed.Point26_6{
+		// A color glyph is always rendered as a regular image even with SDF.
+		if g.SDF && !glyph.isColored() {
+			img, imgX, imgY, scale := g.sdfGlyphImage(glyph, origin.Add(fixed.Point26_6{
+				X: glyph.shapingGlyph.XOffset,
+				Y: -glyph.shapingGlyph.YOffset,
+			}))
+			glyphs = append(glyphs, Glyph{
+				StartIndexInBytes: indexOffset + glyph.startIndex,
+				EndIndexInBytes:   indexOffset + glyph.endIndex,
+				GID:               uint32(glyph.shapingGlyph.GlyphID),
+				Image:             img,
+				X:                 imgX,
+				Y:                 imgY,
+				sdfScale:          scale,
+			})
+			origin = origin.Add(fixed.Point26_6{
+				X: glyph.shapingGlyph.XAdvance,
+				Y: -glyph.shapingGlyph.YAdvance,
+			})
+			continue
+		}
+
+		img, fgImg, imgX, imgY := g.glyphImage(glyph, origin.Add(fixed.Point26_6{
 			X: glyph.shapingGlyph.XOffset,
 			Y: -glyph.shapingGlyph.YOffset,
 		}))
@@ -317,6 +358,8 @@ func (g *GoTextFace) appendGlyphsForLine(glyphs []Glyph, line string, indexOffse
 			Image:             img,
 			X:                 float64(imgX),
 			Y:                 float64(imgY),
+			Colored:           glyph.isColored(),
+			ForegroundImage:   fgImg,
 		})
 		origin = origin.Add(fixed.Point26_6{
 			X: glyph.shapingGlyph.XAdvance,
@@ -327,7 +370,8 @@ func (g *GoTextFace) appendGlyphsForLine(glyphs []Glyph, line string, indexOffse
 	return glyphs
 }
 
-func (g *GoTextFace) glyphImage(glyph glyph, origin fixed.Point26_6) (*ebiten.Image, int, int) {
+// glyphImage returns the glyph image, the foreground image of a color glyph if exists, and the position.
+func (g *GoTextFace) glyphImage(glyph glyph, origin fixed.Point26_6) (*ebiten.Image, *ebiten.Image, int, int) {
 	if g.direction().isHorizontal() {
 		origin.X = adjustGranularity(origin.X, g)
 		origin.Y &^= ((1 << 6) - 1)
@@ -347,13 +391,78 @@ func (g *GoTextFace) glyphImage(glyph glyph, origin fixed.Point26_6) (*ebiten.Im
 		yoffset:    subpixelOffset.Y,
 		variations: g.ensureVariationsString(),
 	}
+	// The foreground image is created with the color glyph image at the same time.
+	var fgImg *ebiten.Image
+	var fgImgCreated bool
 	img := g.Source.getOrCreateGlyphImage(g, key, func() *ebiten.Image {
+		switch {
+		case len(glyph.colorLayers) > 0:
+			img, fg := colorLayersToImages(glyph.colorLayers, subpixelOffset, b)
+			fgImg = fg
+			fgImgCreated = true
+			return img
+		case glyph.bitmap != nil:
+			return bitmapToImage(glyph.bitmap, subpixelOffset, b)
+		}
 		return segmentsToImage(glyph.scaledSegments, subpixelOffset, b)
 	})
+	if hasForegroundLayers(glyph.colorLayers) {
+		fgKey := key
+		fgKey.foreground = true
+		fgImg = g.Source.getOrCreateGlyphImage(g, fgKey, func() *ebiten.Image {
+			if fgImgCreated {
+				return fgImg
+			}
+			_, fg := colorLayersToImages(glyph.colorLayers, subpixelOffset, b)
+			return fg
+		})
+	}
 
 	imgX := (origin.X + b.Min.X).Floor()
 	imgY := (origin.Y + b.Min.Y).Floor()
-	return img, imgX, imgY
+	return img, fgImg, imgX, imgY
+}
+
+// sdfGlyphImage returns a signed distance field image of the glyph, its position, and its scale to render.
+func (g *GoTextFace) sdfGlyphImage(glyph glyph, origin fixed.Point26_6) (*ebiten.Image, float64, float64, float64) {
+	sdfSize := g.sdfSize()
+	spread := sdfSpread(sdfSize)
+
+	// The segments are scaled to the face size. Rescale them to the distance field's size.
+	k := float32(sdfSize / g.Size)
+	b := glyph.bounds
+	minX := float32(math.Floor(float64(fixed26_6ToFloat32(b.Min.X)*k)) - spread)
+	minY := float32(math.Floor(float64(fixed26_6ToFloat32(b.Min.Y)*k)) - spread)
+	maxX := float32(math.Ceil(float64(fixed26_6ToFloat32(b.Max.X)*k)) + spread)
+	maxY := float32(math.Ceil(float64(fixed26_6ToFloat32(b.Max.Y)*k)) + spread)
+
+	key := goTextGlyphImageCacheKey{
+		gid:        glyph.shapingGlyph.GlyphID,
+		variations: g.ensureVariationsString(),
+	}
+	img := g.Source.getOrCreateSDFGlyphImage(g, key, func() *ebiten.Image {
+		segs := make([]api.Segment, len(glyph.scaledSegments))
+		for i, seg := range glyph.scaledSegments {
+			segs[i] = seg
+			for j := range seg.Args {
+				segs[i].Args[j].X *= k
+				segs[i].Args[j].Y *= k
+			}
+		}
+		return segmentsToSDFImage(segs, minX, minY, int(maxX-minX), int(maxY-minY), spread)
+	})
+
+	scale := g.Size / sdfSize
+	x := fixed26_6ToFloat64(origin.X) + float64(minX)*scale
+	y := fixed26_6ToFloat64(origin.Y) + float64(minY)*scale
+	return img, x, y, scale
+}
+
+func (g *GoTextFace) sdfSize() float64 {
+	if g.SDFSize <= 0 {
+		return defaultSDFSize
+	}
+	return g.SDFSize
 }
 
 // appendVectorPathForLine implements Face.
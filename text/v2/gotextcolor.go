@@ -0,0 +1,332 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/go-text/typesetting/opentype/api"
+	"github.com/go-text/typesetting/opentype/loader"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/math/fixed"
+	gvector "golang.org/x/image/vector"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/internal/png"
+)
+
+// colrForegroundPaletteIndex is the palette index that means the text's foreground color.
+const colrForegroundPaletteIndex = 0xffff
+
+type colrLayerRecord struct {
+	gid          api.GID
+	paletteIndex uint16
+}
+
+// colrTable is a color table of the COLR (version 0) table and the first palette of the CPAL table.
+type colrTable struct {
+	layers  map[api.GID][]colrLayerRecord
+	palette []color.RGBA
+}
+
+func loadCOLRTable(l *loader.Loader) *colrTable {
+	colr, err := l.RawTable(loader.MustNewTag("COLR"))
+	if err != nil {
+		return nil
+	}
+	cpal, err := l.RawTable(loader.MustNewTag("CPAL"))
+	if err != nil {
+		return nil
+	}
+	t, err := parseCOLRTable(colr, cpal)
+	if err != nil {
+		// A broken color table is ignored, and the outlines are used instead.
+		return nil
+	}
+	return t
+}
+
+func parseCOLRTable(colr, cpal []byte) (*colrTable, error) {
+	errBroken := errors.New("text: broken COLR or CPAL table")
+
+	// COLR version 0 header. Version 1 tables also have the version 0 records.
+	if len(colr) < 14 {
+		return nil, errBroken
+	}
+	numBaseGlyphs := int(binary.BigEndian.Uint16(colr[2:]))
+	baseGlyphsOffset := int(binary.BigEndian.Uint32(colr[4:]))
+	layersOffset := int(binary.BigEndian.Uint32(colr[8:]))
+	numLayers := int(binary.BigEndian.Uint16(colr[12:]))
+	if baseGlyphsOffset+6*numBaseGlyphs > len(colr) || layersOffset+4*numLayers > len(colr) {
+		return nil, errBroken
+	}
+
+	// CPAL header.
+	if len(cpal) < 12 {
+		return nil, errBroken
+	}
+	numPaletteEntries := int(binary.BigEndian.Uint16(cpal[2:]))
+	numPalettes := int(binary.BigEndian.Uint16(cpal[4:]))
+	colorRecordsOffset := int(binary.BigEndian.Uint32(cpal[8:]))
+	if numPalettes == 0 || len(cpal) < 14 {
+		return nil, errBroken
+	}
+	// Use the first palette, which is the default palette.
+	firstColorIndex := int(binary.BigEndian.Uint16(cpal[12:]))
+	start := colorRecordsOffset + 4*firstColorIndex
+	if start+4*numPaletteEntries > len(cpal) {
+		return nil, errBroken
+	}
+
+	t := &colrTable{
+		layers:  make(map[api.GID][]colrLayerRecord, numBaseGlyphs),
+		palette: make([]color.RGBA, numPaletteEntries),
+	}
+	for i := range t.palette {
+		// A color record is in BGRA in non-premultiplied alpha.
+		c := cpal[start+4*i:]
+		t.palette[i] = color.RGBA{R: c[2], G: c[1], B: c[0], A: c[3]}
+	}
+	for i := 0; i < numBaseGlyphs; i++ {
+		r := colr[baseGlyphsOffset+6*i:]
+		gid := api.GID(binary.BigEndian.Uint16(r))
+		first := int(binary.BigEndian.Uint16(r[2:]))
+		n := int(binary.BigEndian.Uint16(r[4:]))
+		if first+n > numLayers {
+			return nil, errBroken
+		}
+		layers := make([]colrLayerRecord, n)
+		for j := range layers {
+			l := colr[layersOffset+4*(first+j):]
+			layers[j] = colrLayerRecord{
+				gid:          api.GID(binary.BigEndian.Uint16(l)),
+				paletteIndex: binary.BigEndian.Uint16(l[2:]),
+			}
+		}
+		t.layers[gid] = layers
+	}
+	return t, nil
+}
+
+// colorLayer is a layer of a color glyph with scaled segments.
+type colorLayer struct {
+	segments []api.Segment
+
+	// color is the color of the layer. color is nil if the layer is in the foreground color.
+	color color.Color
+}
+
+func (c *colrTable) colorFor(paletteIndex uint16) color.Color {
+	// The foreground color is unknown when a glyph image is created. Such a layer is rendered in a separate image.
+	if paletteIndex == colrForegroundPaletteIndex || int(paletteIndex) >= len(c.palette) {
+		return nil
+	}
+	return color.NRGBA(c.palette[paletteIndex])
+}
+
+func hasForegroundLayers(layers []colorLayer) bool {
+	for _, l := range layers {
+		if l.color == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// colorLayersToImages creates a color glyph image and a foreground image from the layers.
+//
+// The color glyph image has the layers in the palette colors, and the foreground image has the coverage of the layers
+// in the foreground color as a grayscale image. Compositing the layers in order is the same as
+// rendering the color glyph image as it is and then adding the foreground image scaled by the foreground color.
+// This is exact when the foreground color is opaque.
+//
+// The foreground image is nil if there are no layers in the foreground color.
+func colorLayersToImages(layers []colorLayer, subpixelOffset fixed.Point26_6, glyphBounds fixed.Rectangle26_6) (*ebiten.Image, *ebiten.Image) {
+	w, h := (glyphBounds.Max.X - glyphBounds.Min.X).Ceil(), (glyphBounds.Max.Y - glyphBounds.Min.Y).Ceil()
+	if w == 0 || h == 0 {
+		return nil, nil
+	}
+	w++
+	h++
+
+	biasX := fixed26_6ToFloat32(-glyphBounds.Min.X + subpixelOffset.X)
+	biasY := fixed26_6ToFloat32(-glyphBounds.Min.Y + subpixelOffset.Y)
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	foreground := hasForegroundLayers(layers)
+	var fg []float32
+	if foreground {
+		fg = make([]float32, w*h)
+	}
+	mask := image.NewAlpha(dst.Bounds())
+	for _, l := range layers {
+		if len(l.segments) == 0 {
+			continue
+		}
+		for i := range mask.Pix {
+			mask.Pix[i] = 0
+		}
+		rast := gvector.NewRasterizer(w, h)
+		rast.DrawOp = draw.Src
+		for _, seg := range l.segments {
+			switch seg.Op {
+			case api.SegmentOpMoveTo:
+				rast.MoveTo(seg.Args[0].X+biasX, seg.Args[0].Y+biasY)
+			case api.SegmentOpLineTo:
+				rast.LineTo(seg.Args[0].X+biasX, seg.Args[0].Y+biasY)
+			case api.SegmentOpQuadTo:
+				rast.QuadTo(
+					seg.Args[0].X+biasX, seg.Args[0].Y+biasY,
+					seg.Args[1].X+biasX, seg.Args[1].Y+biasY,
+				)
+			case api.SegmentOpCubeTo:
+				rast.CubeTo(
+					seg.Args[0].X+biasX, seg.Args[0].Y+biasY,
+					seg.Args[1].X+biasX, seg.Args[1].Y+biasY,
+					seg.Args[2].X+biasX, seg.Args[2].Y+biasY,
+				)
+			}
+		}
+		rast.ClosePath()
+		rast.Draw(mask, mask.Bounds(), image.Opaque, image.Point{})
+
+		// The layers are composited from the bottom to the top.
+		if l.color != nil {
+			draw.DrawMask(dst, dst.Bounds(), image.NewUniform(l.color), image.Point{}, mask, image.Point{}, draw.Over)
+			if foreground {
+				// The foreground parts below this layer are covered by this layer.
+				_, _, _, a := l.color.RGBA()
+				for i, m := range mask.Pix {
+					fg[i] *= 1 - float32(m)/0xff*float32(a)/0xffff
+				}
+			}
+			continue
+		}
+
+		// A layer in the foreground color is regarded as an opaque layer with no colors in dst,
+		// and its coverage is accumulated in fg.
+		for i, m := range mask.Pix {
+			if m == 0 {
+				continue
+			}
+			k := float32(m) / 0xff
+			p := dst.Pix[4*i : 4*i+4]
+			p[0] = uint8(float32(p[0]) * (1 - k))
+			p[1] = uint8(float32(p[1]) * (1 - k))
+			p[2] = uint8(float32(p[2]) * (1 - k))
+			p[3] = uint8(float32(m) + float32(p[3])*(1-k) + 0.5)
+			fg[i] = k + fg[i]*(1-k)
+		}
+	}
+
+	img := ebiten.NewImageFromImage(dst)
+	if !foreground {
+		return img, nil
+	}
+	fgImg := image.NewRGBA(dst.Bounds())
+	for i, v := range fg {
+		c := uint8(v*0xff + 0.5)
+		fgImg.Pix[4*i] = c
+		fgImg.Pix[4*i+1] = c
+		fgImg.Pix[4*i+2] = c
+		fgImg.Pix[4*i+3] = c
+	}
+	return img, ebiten.NewImageFromImage(fgImg)
+}
+
+// bitmapToImage creates a glyph image from a bitmap like a CBDT or sbix glyph, scaling it to the glyph bounds.
+func bitmapToImage(bitmap *api.GlyphBitmap, subpixelOffset fixed.Point26_6, glyphBounds fixed.Rectangle26_6) *ebiten.Image {
+	w, h := (glyphBounds.Max.X - glyphBounds.Min.X).Ceil(), (glyphBounds.Max.Y - glyphBounds.Min.Y).Ceil()
+	if w == 0 || h == 0 {
+		return nil
+	}
+	w++
+	h++
+
+	src, err := png.Decode(bytes.NewReader(bitmap.Data))
+	if err != nil {
+		return nil
+	}
+
+	x0 := fixed26_6ToFloat64(subpixelOffset.X)
+	y0 := fixed26_6ToFloat64(subpixelOffset.Y)
+	sx := fixed26_6ToFloat64(glyphBounds.Max.X-glyphBounds.Min.X) / float64(src.Bounds().Dx())
+	sy := fixed26_6ToFloat64(glyphBounds.Max.Y-glyphBounds.Min.Y) / float64(src.Bounds().Dy())
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	xdraw.BiLinear.Transform(dst, [6]float64{sx, 0, x0, 0, sy, y0}, src, src.Bounds(), draw.Over, nil)
+	return ebiten.NewImageFromImage(dst)
+}
+
+// colorGlyphData returns the color information of the glyph, either color layers or a bitmap.
+// The returned bounds are the bounds of the color glyph.
+func (g *GoTextFaceSource) colorGlyphData(gid api.GID, scale float32) ([]colorLayer, *api.GlyphBitmap, fixed.Rectangle26_6, bool) {
+	if g.colr != nil {
+		if records, ok := g.colr.layers[gid]; ok {
+			layers := make([]colorLayer, 0, len(records))
+			var bounds fixed.Rectangle26_6
+			for _, r := range records {
+				outline, ok := g.f.GlyphData(r.gid).(api.GlyphOutline)
+				if !ok {
+					continue
+				}
+				segs := make([]api.Segment, len(outline.Segments))
+				for i, seg := range outline.Segments {
+					segs[i] = seg
+					for j := range seg.Args {
+						segs[i].Args[j].X *= scale
+						segs[i].Args[j].Y *= -scale
+					}
+				}
+				layers = append(layers, colorLayer{
+					segments: segs,
+					color:    g.colr.colorFor(r.paletteIndex),
+				})
+				if len(segs) > 0 {
+					bounds = bounds.Union(segmentsToBounds(segs))
+				}
+			}
+			if len(layers) > 0 {
+				return layers, nil, bounds, true
+			}
+		}
+	}
+
+	bitmap, ok := g.f.GlyphData(gid).(api.GlyphBitmap)
+	if !ok || bitmap.Format != api.PNG {
+		return nil, nil, fixed.Rectangle26_6{}, false
+	}
+	ext, ok := g.f.GlyphExtents(gid)
+	if !ok {
+		return nil, nil, fixed.Rectangle26_6{}, false
+	}
+	// The extents are in font units in the Y-up coordinate.
+	bounds := fixed.Rectangle26_6{
+		Min: fixed.Point26_6{
+			X: float32ToFixed26_6(ext.XBearing * scale),
+			Y: float32ToFixed26_6(-ext.YBearing * scale),
+		},
+		Max: fixed.Point26_6{
+			X: float32ToFixed26_6((ext.XBearing + ext.Width) * scale),
+			Y: float32ToFixed26_6(-(ext.YBearing + ext.Height) * scale),
+		},
+	}
+	return nil, &bitmap, bounds, true
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text_test
+
+import (
+	"encoding/binary"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// colrV0ForTesting builds a COLR version 0 table.
+func colrV0ForTesting(baseGlyphs [][3]uint16, layers [][2]uint16) []byte {
+	const headerSize = 14
+	b := make([]byte, headerSize+6*len(baseGlyphs)+4*len(layers))
+	binary.BigEndian.PutUint16(b[2:], uint16(len(baseGlyphs)))
+	binary.BigEndian.PutUint32(b[4:], headerSize)
+	binary.BigEndian.PutUint32(b[8:], uint32(headerSize+6*len(baseGlyphs)))
+	binary.BigEndian.PutUint16(b[12:], uint16(len(layers)))
+	for i, g := range baseGlyphs {
+		r := b[headerSize+6*i:]
+		binary.BigEndian.PutUint16(r, g[0])
+		binary.BigEndian.PutUint16(r[2:], g[1])
+		binary.BigEndian.PutUint16(r[4:], g[2])
+	}
+	for i, l := range layers {
+		r := b[headerSize+6*len(baseGlyphs)+4*i:]
+		binary.BigEndian.PutUint16(r, l[0])
+		binary.BigEndian.PutUint16(r[2:], l[1])
+	}
+	return b
+}
+
+// cpalForTesting builds a CPAL table with one palette. A color is in BGRA.
+func cpalForTesting(colors [][4]byte) []byte {
+	const headerSize = 14
+	b := make([]byte, headerSize+4*len(colors))
+	binary.BigEndian.PutUint16(b[2:], uint16(len(colors)))
+	binary.BigEndian.PutUint16(b[4:], 1)
+	binary.BigEndian.PutUint16(b[6:], uint16(len(colors)))
+	binary.BigEndian.PutUint32(b[8:], headerSize)
+	for i, c := range colors {
+		copy(b[headerSize+4*i:], c[:])
+	}
+	return b
+}
+
+func TestParseCOLRTable(t *testing.T) {
+	colr := colrV0ForTesting([][3]uint16{
+		// Glyph 10 has the layers 0 and 1, and glyph 20 has the layer 2.
+		{10, 0, 2},
+		{20, 2, 1},
+	}, [][2]uint16{
+		{11, 1},
+		{12, 0xffff},
+		{21, 0},
+	})
+	cpal := cpalForTesting([][4]byte{
+		{0x30, 0x20, 0x10, 0xff},
+		{0x00, 0x00, 0xff, 0x80},
+	})
+
+	layers, palette, err := text.ParseCOLRTableForTesting(colr, cpal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantLayers := map[uint16][]text.COLRLayerForTesting{
+		10: {{GID: 11, PaletteIndex: 1}, {GID: 12, PaletteIndex: 0xffff}},
+		20: {{GID: 21, PaletteIndex: 0}},
+	}
+	if !reflect.DeepEqual(layers, wantLayers) {
+		t.Errorf("layers: got: %v, want: %v", layers, wantLayers)
+	}
+	wantPalette := []color.RGBA{
+		{R: 0x10, G: 0x20, B: 0x30, A: 0xff},
+		{R: 0xff, G: 0x00, B: 0x00, A: 0x80},
+	}
+	if !reflect.DeepEqual(palette, wantPalette) {
+		t.Errorf("palette: got: %v, want: %v", palette, wantPalette)
+	}
+}
+
+func TestParseCOLRTableBroken(t *testing.T) {
+	colr := colrV0ForTesting([][3]uint16{{10, 0, 1}}, [][2]uint16{{11, 0}})
+	cpal := cpalForTesting([][4]byte{{0, 0, 0, 0xff}})
+
+	// A base glyph referring to layers out of range.
+	colrOutOfRange := colrV0ForTesting([][3]uint16{{10, 0, 2}}, [][2]uint16{{11, 0}})
+
+	// No palettes.
+	cpalNoPalettes := append([]byte{}, cpal...)
+	binary.BigEndian.PutUint16(cpalNoPalettes[4:], 0)
+
+	testCases := []struct {
+		Name string
+		COLR []byte
+		CPAL []byte
+	}{
+		{Name: "empty COLR", COLR: nil, CPAL: cpal},
+		{Name: "empty CPAL", COLR: colr, CPAL: nil},
+		{Name: "truncated COLR", COLR: colr[:len(colr)-1], CPAL: cpal},
+		{Name: "truncated CPAL", COLR: colr, CPAL: cpal[:len(cpal)-1]},
+		{Name: "layers out of range", COLR: colrOutOfRange, CPAL: cpal},
+		{Name: "no palettes", COLR: colr, CPAL: cpalNoPalettes},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			if _, _, err := text.ParseCOLRTableForTesting(tc.COLR, tc.CPAL); err == nil {
+				t.Errorf("got: nil, want: an error")
+			}
+		})
+	}
+}
@@ -46,6 +46,15 @@ type glyph struct {
 	endIndex       int
 	scaledSegments []api.Segment
 	bounds         fixed.Rectangle26_6
+
+	// colorLayers and bitmap are the color information of a color glyph like an emoji.
+	// At most one of them is non-nil.
+	colorLayers []colorLayer
+	bitmap      *api.GlyphBitmap
+}
+
+func (g *glyph) isColored() bool {
+	return len(g.colorLayers) > 0 || g.bitmap != nil
 }
 
 type goTextOutputCacheValue struct {
@@ -59,15 +68,20 @@ type goTextGlyphImageCacheKey struct {
 	xoffset    fixed.Int26_6
 	yoffset    fixed.Int26_6
 	variations string
+
+	// foreground reports whether the image is the foreground image of a color glyph.
+	foreground bool
 }
 
 // GoTextFaceSource is a source of a GoTextFace. This can be shared by multiple GoTextFace objects.
 type GoTextFaceSource struct {
 	f        font.Face
 	metadata Metadata
+	colr     *colrTable
 
-	outputCache     map[goTextOutputCacheKey]*goTextOutputCacheValue
-	glyphImageCache map[float64]*glyphImageCache[goTextGlyphImageCacheKey]
+	outputCache        map[goTextOutputCacheKey]*goTextOutputCacheValue
+	glyphImageCache    map[float64]*glyphImageCache[goTextGlyphImageCacheKey]
+	sdfGlyphImageCache map[float64]*glyphImageCache[goTextGlyphImageCacheKey]
 
 	addr *GoTextFaceSource
 
@@ -116,6 +130,7 @@ func NewGoTextFaceSource(source io.Reader) (*GoTextFaceSource, error) {
 	}
 	s.addr = s
 	s.metadata = metadataFromLoader(l)
+	s.colr = loadCOLRTable(l)
 
 	return s, nil
 }
@@ -143,6 +158,7 @@ func NewGoTextFaceSourcesFromCollection(source io.Reader) ([]*GoTextFaceSource,
 		}
 		s.addr = s
 		s.metadata = metadataFromLoader(l)
+		s.colr = loadCOLRTable(l)
 		sources[i] = s
 	}
 	return sources, nil
@@ -245,13 +261,19 @@ func (g *GoTextFaceSource) shape(text string, face *GoTextFace) ([]shaping.Outpu
 				}
 			}
 
-			gs = append(gs, glyph{
+			glyph := glyph{
 				shapingGlyph:   &gl,
 				startIndex:     indices[gl.ClusterIndex],
 				endIndex:       indices[gl.ClusterIndex+gl.RuneCount],
 				scaledSegments: scaledSegs,
 				bounds:         segmentsToBounds(scaledSegs),
-			})
+			}
+			if layers, bitmap, bounds, ok := g.colorGlyphData(gl.GlyphID, scale); ok {
+				glyph.colorLayers = layers
+				glyph.bitmap = bitmap
+				glyph.bounds = bounds
+			}
+			gs = append(gs, glyph)
 		}
 	}
 
@@ -292,6 +314,18 @@ func (g *GoTextFaceSource) getOrCreateGlyphImage(goTextFace *GoTextFace, key goT
 	return g.glyphImageCache[goTextFace.Size].getOrCreate(goTextFace, key, create)
 }
 
+func (g *GoTextFaceSource) getOrCreateSDFGlyphImage(goTextFace *GoTextFace, key goTextGlyphImageCacheKey, create func() *ebiten.Image) *ebiten.Image {
+	// A signed distance field image doesn't depend on the size, but depends on the size of the field.
+	if g.sdfGlyphImageCache == nil {
+		g.sdfGlyphImageCache = map[float64]*glyphImageCache[goTextGlyphImageCacheKey]{}
+	}
+	sdfSize := goTextFace.sdfSize()
+	if _, ok := g.sdfGlyphImageCache[sdfSize]; !ok {
+		g.sdfGlyphImageCache[sdfSize] = &glyphImageCache[goTextGlyphImageCacheKey]{}
+	}
+	return g.sdfGlyphImageCache[sdfSize].getOrCreate(goTextFace, key, create)
+}
+
 type singleFontmap struct {
 	face font.Face
 }
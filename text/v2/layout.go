@@ -68,6 +68,10 @@ type LayoutOptions struct {
 //
 // The '\n' newline character puts the following text on the next line.
 //
+// Color glyphs like emojis are rendered with their own colors, and only the alpha of DrawOptions' ColorScale is applied.
+// In a color glyph of a COLR font, the layers in the foreground color are rendered in the color of DrawOptions' ColorScale.
+// The foreground layers are added to the destination regardless of DrawOptions' Blend.
+//
 // Glyphs used for rendering are cached in least-recently-used way.
 // Then old glyphs might be evicted from the cache.
 // As the cache capacity has limit, it is not guaranteed that all the glyphs for runes given at Draw are cached.
@@ -115,13 +119,42 @@ func Draw(dst *ebiten.Image, text string, face Face, options *DrawOptions) {
 		if g.Image == nil {
 			continue
 		}
+		if g.sdfScale != 0 {
+			drawSDFGlyph(dst, &g, geoM, &drawOp)
+			continue
+		}
 		drawOp.GeoM.Reset()
 		drawOp.GeoM.Translate(g.X, g.Y)
 		drawOp.GeoM.Concat(geoM)
+		if g.Colored {
+			// A color glyph keeps its own colors. Only the alpha is applied.
+			op := drawOp
+			op.ColorScale.Reset()
+			op.ColorScale.ScaleAlpha(drawOp.ColorScale.A())
+			dst.DrawImage(g.Image, &op)
+			if g.ForegroundImage != nil {
+				// The color glyph image already has the alpha values of the foreground parts.
+				// Add only the RGB values in the foreground color.
+				op.ColorScale = drawOp.ColorScale
+				op.Blend = foregroundBlend
+				dst.DrawImage(g.ForegroundImage, &op)
+			}
+			continue
+		}
 		dst.DrawImage(g.Image, &drawOp)
 	}
 }
 
+// foregroundBlend is the blend to add the foreground parts of a color glyph to the RGB values.
+var foregroundBlend = ebiten.Blend{
+	BlendFactorSourceRGB:        ebiten.BlendFactorOne,
+	BlendFactorSourceAlpha:      ebiten.BlendFactorZero,
+	BlendFactorDestinationRGB:   ebiten.BlendFactorOne,
+	BlendFactorDestinationAlpha: ebiten.BlendFactorOne,
+	BlendOperationRGB:           ebiten.BlendOperationAdd,
+	BlendOperationAlpha:         ebiten.BlendOperationAdd,
+}
+
 // AppendGlyphs appends glyphs to the given slice and returns a slice.
 //
 // AppendGlyphs is a low-level API, and you can use AppendGlyphs to have more control than Draw.
@@ -139,6 +172,9 @@ func AppendGlyphs(glyphs []Glyph, text string, face Face, options *LayoutOptions
 // AppendVectorPath works only when the face is *GoTextFace or a composite face using *GoTextFace so far.
 // For other types, AppendVectorPath does nothing.
 func AppendVectorPath(path *vector.Path, text string, face Face, options *LayoutOptions) {
+	if text == "" {
+		return
+	}
 	forEachLine(text, face, options, func(line string, indexOffset int, originX, originY float64) {
 		face.appendVectorPathForLine(path, line, originX, originY)
 	})
@@ -149,6 +185,9 @@ func AppendVectorPath(path *vector.Path, text string, face Face, options *Layout
 // appendGlyphs assumes the text is rendered with the position (x, y).
 // (x, y) might affect the subpixel rendering results.
 func appendGlyphs(glyphs []Glyph, text string, face Face, x, y float64, options *LayoutOptions) []Glyph {
+	if text == "" {
+		return glyphs
+	}
 	forEachLine(text, face, options, func(line string, indexOffset int, originX, originY float64) {
 		glyphs = face.appendGlyphsForLine(glyphs, line, indexOffset, originX+x, originY+y)
 	})
@@ -156,11 +195,8 @@ func appendGlyphs(glyphs []Glyph, text string, face Face, x, y float64, options
 }
 
 // forEachLine interates lines.
+// Even if text is empty, f is called once for the empty line.
 func forEachLine(text string, face Face, options *LayoutOptions, f func(text string, indexOffset int, originX, originY float64)) {
-	if text == "" {
-		return
-	}
-
 	if options == nil {
 		options = &LayoutOptions{}
 	}
@@ -16,6 +16,7 @@ package text
 
 import (
 	"errors"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/hajimehoshi/ebiten/v2/vector"
@@ -26,6 +27,17 @@ var _ Face = (*MultiFace)(nil)
 // MultiFace is a Face that consists of multiple Face objects.
 // The face in the first index is used in the highest priority, and the last the lowest priority.
 //
+// MultiFace is useful as a fallback chain for mixed scripts and emojis, e.g. a Latin face, a CJK face, and a color emoji face.
+// A face is selected for each run of a text, and each run is shaped by the selected face.
+// To shape complex scripts like Arabic and Indic scripts correctly, a run is not split in the following cases:
+//
+//   - A combining mark follows its base character if the face for the base character has the mark.
+//   - A character common to scripts, like a space, a digit, or a punctuation, stays in the current run if the face has it.
+//   - An emoji sequence like a ZWJ sequence or a skin tone modifier sequence is kept in one face.
+//
+// A character followed by U+FE0F (the emoji presentation selector) is rendered with the first face that has both of them,
+// even if a face in a higher priority has the character, e.g. U+2764 HEAVY BLACK HEART in a text face.
+//
 // There is a known issue: if the writing directions of the faces don't agree, the rendering result might be messed up.
 type MultiFace struct {
 	faces []Face
@@ -159,19 +171,49 @@ type textChunk struct {
 func (m *MultiFace) splitText(text string) []textChunk {
 	var chunks []textChunk
 
+	var prev rune
 	for ri, r := range text {
 		fi := -1
 		_, l := utf8.DecodeRuneInString(text[ri:])
-		for i, f := range m.faces {
-			if !f.hasGlyph(r) && i < len(m.faces)-1 {
-				continue
+		if len(chunks) > 0 {
+			last := chunks[len(chunks)-1].faceIndex
+			switch lf := m.faces[last]; {
+			case isEmojiSequenceContinuation(r):
+				// Keep an emoji sequence in the same face so that the face can render the sequence as one glyph.
+				fi = last
+			case prev == zeroWidthJoiner && lf.hasGlyph(r):
+				fi = last
+			case isClusterContinuation(r) && lf.hasGlyph(r):
+				// Keep a combining mark with its base character so that the face can shape the cluster,
+				// e.g. Arabic vowel marks and Indic vowel signs.
+				fi = last
+			case unicode.Is(unicode.Common, r) && lf.hasGlyph(r):
+				// Keep a character used in any scripts like a space or a punctuation in the current run
+				// so that a run of a complex script like Arabic is not split into words.
+				fi = last
+			}
+		}
+		if fi == -1 {
+			for i, f := range m.faces {
+				if !f.hasGlyph(r) && i < len(m.faces)-1 {
+					continue
+				}
+				fi = i
+				break
 			}
-			fi = i
-			break
 		}
 		if fi == -1 {
 			panic("text: a face was not selected correctly")
 		}
+		if r == variationSelectorEmoji && len(chunks) > 0 && !m.faces[fi].hasGlyph(r) {
+			// The face of the current run doesn't have the emoji presentation, e.g. a text face with U+2764.
+			// Move the base character to a face that has both of the base and the variation selector.
+			if ef := m.emojiPresentationFaceIndex(prev); ef != -1 {
+				chunks = moveLastRune(chunks, ri, utf8.RuneLen(prev), ef)
+				fi = ef
+			}
+		}
+		prev = r
 
 		var s int
 		if len(chunks) > 0 {
@@ -190,3 +232,67 @@ func (m *MultiFace) splitText(text string) []textChunk {
 
 	return chunks
 }
+
+// emojiPresentationFaceIndex returns the index of the first face that has both base and the emoji variation selector.
+// emojiPresentationFaceIndex returns -1 if there is no such face.
+func (m *MultiFace) emojiPresentationFaceIndex(base rune) int {
+	for i, f := range m.faces {
+		if f.hasGlyph(base) && f.hasGlyph(variationSelectorEmoji) {
+			return i
+		}
+	}
+	return -1
+}
+
+// moveLastRune moves the last rune of chunks, which ends at end and has size bytes, to a chunk for faceIndex.
+func moveLastRune(chunks []textChunk, end int, size int, faceIndex int) []textChunk {
+	chunks[len(chunks)-1].textEndIndex -= size
+	if c := chunks[len(chunks)-1]; c.textStartIndex == c.textEndIndex {
+		chunks = chunks[:len(chunks)-1]
+	}
+	if len(chunks) > 0 && chunks[len(chunks)-1].faceIndex == faceIndex {
+		chunks[len(chunks)-1].textEndIndex = end
+		return chunks
+	}
+	return append(chunks, textChunk{
+		textStartIndex: end - size,
+		textEndIndex:   end,
+		faceIndex:      faceIndex,
+	})
+}
+
+const (
+	zeroWidthNonJoiner     = 0x200c
+	zeroWidthJoiner        = 0x200d
+	variationSelectorEmoji = 0xfe0f
+)
+
+// isClusterContinuation reports whether r is a part of the previous rune's cluster, like a combining mark.
+func isClusterContinuation(r rune) bool {
+	if r == zeroWidthNonJoiner {
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me)
+}
+
+// isEmojiSequenceContinuation reports whether r continues the previous rune as an emoji sequence,
+// like a ZWJ sequence, a variation sequence, a keycap sequence, a modifier sequence, or a tag sequence.
+func isEmojiSequenceContinuation(r rune) bool {
+	switch {
+	case r == zeroWidthJoiner:
+		return true
+	case r == 0xfe0e || r == variationSelectorEmoji:
+		// Variation selectors for the text and the emoji presentations.
+		return true
+	case r == 0x20e3:
+		// Combining enclosing keycap.
+		return true
+	case 0x1f3fb <= r && r <= 0x1f3ff:
+		// Skin tone modifiers.
+		return true
+	case 0xe0020 <= r && r <= 0xe007f:
+		// Tags for subdivision flags.
+		return true
+	}
+	return false
+}
@@ -16,6 +16,7 @@ package text_test
 
 import (
 	"bytes"
+	"reflect"
 	"testing"
 
 	"github.com/hajimehoshi/bitmapfont/v3"
@@ -61,3 +62,147 @@ func TestMultiFaceFallback(t *testing.T) {
 		t.Errorf("got: %d, want: %d", len(got), len(want))
 	}
 }
+
+func TestMultiFaceSplitTextEmoji(t *testing.T) {
+	textFace := text.NewRuneFaceForTesting("ab ❤#")
+	emojiFace := text.NewRuneFaceForTesting("❤️‍\U0001f525#⃣\U0001f44d\U0001f3fd")
+	multiFace, err := text.NewMultiFace(textFace, emojiFace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Text string
+		Want []text.TextChunkForTesting
+	}{
+		{
+			Text: "ab",
+			Want: []text.TextChunkForTesting{{Text: "ab", FaceIndex: 0}},
+		},
+		{
+			// U+2764 without U+FE0F is in the text presentation.
+			Text: "a❤b",
+			Want: []text.TextChunkForTesting{{Text: "a❤b", FaceIndex: 0}},
+		},
+		{
+			Text: "a❤️b",
+			Want: []text.TextChunkForTesting{
+				{Text: "a", FaceIndex: 0},
+				{Text: "❤️", FaceIndex: 1},
+				{Text: "b", FaceIndex: 0},
+			},
+		},
+		{
+			Text: "❤️",
+			Want: []text.TextChunkForTesting{{Text: "❤️", FaceIndex: 1}},
+		},
+		{
+			// The previous emoji run is extended instead of adding a new run.
+			Text: "\U0001f44d❤️",
+			Want: []text.TextChunkForTesting{{Text: "\U0001f44d❤️", FaceIndex: 1}},
+		},
+		{
+			// A ZWJ sequence after a moved base character.
+			Text: "a❤️‍\U0001f525",
+			Want: []text.TextChunkForTesting{
+				{Text: "a", FaceIndex: 0},
+				{Text: "❤️‍\U0001f525", FaceIndex: 1},
+			},
+		},
+		{
+			// A keycap sequence.
+			Text: "a#️⃣",
+			Want: []text.TextChunkForTesting{
+				{Text: "a", FaceIndex: 0},
+				{Text: "#️⃣", FaceIndex: 1},
+			},
+		},
+		{
+			// A skin tone modifier sequence.
+			Text: "a\U0001f44d\U0001f3fd",
+			Want: []text.TextChunkForTesting{
+				{Text: "a", FaceIndex: 0},
+				{Text: "\U0001f44d\U0001f3fd", FaceIndex: 1},
+			},
+		},
+		{
+			// If no face has both of the characters, U+FE0F stays in the current run.
+			Text: "b️",
+			Want: []text.TextChunkForTesting{{Text: "b️", FaceIndex: 0}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Text, func(t *testing.T) {
+			got := text.SplitTextForTesting(multiFace, tc.Text)
+			if !reflect.DeepEqual(got, tc.Want) {
+				t.Errorf("got: %q, want: %q", got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestMultiFaceSplitTextScripts(t *testing.T) {
+	latinFace := text.NewRuneFaceForTesting("ab .12َ")
+	arabicFace := text.NewRuneFaceForTesting("بسم .12َ")
+	multiFace, err := text.NewMultiFace(latinFace, arabicFace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Name string
+		Text string
+		Want []text.TextChunkForTesting
+	}{
+		{
+			Name: "latin",
+			Text: "ab ba",
+			Want: []text.TextChunkForTesting{{Text: "ab ba", FaceIndex: 0}},
+		},
+		{
+			// A space and a punctuation stay in the Arabic run even though the Latin face has them.
+			Name: "arabic words",
+			Text: "بسم بسم.",
+			Want: []text.TextChunkForTesting{{Text: "بسم بسم.", FaceIndex: 1}},
+		},
+		{
+			Name: "digits",
+			Text: "بسم 12",
+			Want: []text.TextChunkForTesting{{Text: "بسم 12", FaceIndex: 1}},
+		},
+		{
+			Name: "mixed",
+			Text: "ab بسم ab",
+			Want: []text.TextChunkForTesting{
+				{Text: "ab ", FaceIndex: 0},
+				{Text: "بسم ", FaceIndex: 1},
+				{Text: "ab", FaceIndex: 0},
+			},
+		},
+		{
+			// A combining mark stays with its base character even though the Latin face has the mark.
+			Name: "combining mark",
+			Text: "بَس",
+			Want: []text.TextChunkForTesting{{Text: "بَس", FaceIndex: 1}},
+		},
+		{
+			// A combining mark is not kept in the run if the face doesn't have it.
+			Name: "missing combining mark",
+			Text: "a\u0301",
+			Want: []text.TextChunkForTesting{
+				{Text: "a", FaceIndex: 0},
+				{Text: "\u0301", FaceIndex: 1},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			got := text.SplitTextForTesting(multiFace, tc.Text)
+			if !reflect.DeepEqual(got, tc.Want) {
+				t.Errorf("got: %q, want: %q", got, tc.Want)
+			}
+		})
+	}
+}
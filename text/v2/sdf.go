@@ -0,0 +1,256 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"image"
+	"image/draw"
+	"math"
+	"sync"
+
+	"github.com/go-text/typesetting/opentype/api"
+	gvector "golang.org/x/image/vector"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// defaultSDFSize is the default font size in pixels to create a signed distance field.
+	// A distance field is created once at this size and is scaled at rendering.
+	defaultSDFSize = 64
+
+	// sdfSpreadRatio is the ratio of the maximum distance encoded in a distance field to the font size of the field.
+	sdfSpreadRatio = 1.0 / 8
+)
+
+// sdfSpread returns the maximum distance in pixels encoded in a distance field created at the given font size.
+func sdfSpread(sdfSize float64) float64 {
+	return math.Max(math.Ceil(sdfSize*sdfSpreadRatio), 1)
+}
+
+const sdfShaderSrc = `//kage:unit pixels
+
+package main
+
+func sample(p vec2) float {
+	// Interpolate the distance field linearly.
+	// A distance field's pixel has the same values in all the RGBA channels.
+	p -= 0.5
+	f := fract(p)
+	p = floor(p) + 0.5
+	d00 := imageSrc0At(p).a
+	d10 := imageSrc0At(p + vec2(1, 0)).a
+	d01 := imageSrc0At(p + vec2(0, 1)).a
+	d11 := imageSrc0At(p + vec2(1, 1)).a
+	return mix(mix(d00, d10, f.x), mix(d01, d11, f.x), f.y)
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	d := sample(srcPos)
+	// The width of the edge is one pixel in the destination, regardless of the scale and the rotation.
+	w := max(length(vec2(dfdx(d), dfdy(d))), 1.0/65536)
+	a := clamp((d-0.5)/w+0.5, 0, 1)
+	return color * a
+}
+`
+
+var (
+	theSDFShader     *ebiten.Shader
+	theSDFShaderOnce sync.Once
+)
+
+func sdfShader() *ebiten.Shader {
+	theSDFShaderOnce.Do(func() {
+		s, err := ebiten.NewShader([]byte(sdfShaderSrc))
+		if err != nil {
+			panic("text: compiling the SDF shader failed: " + err.Error())
+		}
+		theSDFShader = s
+	})
+	return theSDFShader
+}
+
+// drawSDFGlyph draws a glyph whose image is a signed distance field.
+func drawSDFGlyph(dst *ebiten.Image, glyph *Glyph, geoM ebiten.GeoM, options *ebiten.DrawImageOptions) {
+	op := &ebiten.DrawRectShaderOptions{}
+	op.GeoM.Scale(glyph.sdfScale, glyph.sdfScale)
+	op.GeoM.Translate(glyph.X, glyph.Y)
+	op.GeoM.Concat(geoM)
+	op.ColorScale = options.ColorScale
+	op.CompositeMode = options.CompositeMode
+	op.Blend = options.Blend
+	op.BlendColor = options.BlendColor
+	op.Images[0] = glyph.Image
+	b := glyph.Image.Bounds()
+	dst.DrawRectShader(b.Dx(), b.Dy(), sdfShader(), op)
+}
+
+// segmentsToSDFImage creates a signed distance field image from the segments.
+// The image's upper-left position is (minX, minY) in the segments' coordinate.
+// spread is the maximum distance in pixels encoded in the image.
+func segmentsToSDFImage(segs []api.Segment, minX, minY float32, width, height int, spread float64) *ebiten.Image {
+	if len(segs) == 0 || width == 0 || height == 0 {
+		return nil
+	}
+	return ebiten.NewImageFromImage(segmentsToSDF(segs, minX, minY, width, height, spread))
+}
+
+// segmentsToSDF creates a signed distance field from the segments.
+// A pixel value is 0.5 at the edge, and increases toward the inside by 0.5/spread per pixel.
+func segmentsToSDF(segs []api.Segment, minX, minY float32, width, height int, spread float64) *image.RGBA {
+
+	rast := gvector.NewRasterizer(width, height)
+	rast.DrawOp = draw.Src
+	for _, seg := range segs {
+		switch seg.Op {
+		case api.SegmentOpMoveTo:
+			rast.MoveTo(seg.Args[0].X-minX, seg.Args[0].Y-minY)
+		case api.SegmentOpLineTo:
+			rast.LineTo(seg.Args[0].X-minX, seg.Args[0].Y-minY)
+		case api.SegmentOpQuadTo:
+			rast.QuadTo(
+				seg.Args[0].X-minX, seg.Args[0].Y-minY,
+				seg.Args[1].X-minX, seg.Args[1].Y-minY,
+			)
+		case api.SegmentOpCubeTo:
+			rast.CubeTo(
+				seg.Args[0].X-minX, seg.Args[0].Y-minY,
+				seg.Args[1].X-minX, seg.Args[1].Y-minY,
+				seg.Args[2].X-minX, seg.Args[2].Y-minY,
+			)
+		}
+	}
+	rast.ClosePath()
+
+	coverage := image.NewAlpha(image.Rect(0, 0, width, height))
+	rast.Draw(coverage, coverage.Bounds(), image.Opaque, image.Point{})
+
+	// Calculate the squared distances to the nearest outer pixel and the nearest inner pixel.
+	toOuter := make([]float64, width*height)
+	toInner := make([]float64, width*height)
+	for i, a := range coverage.Pix {
+		if a >= 0x80 {
+			toInner[i] = 0
+			toOuter[i] = math.Inf(1)
+		} else {
+			toInner[i] = math.Inf(1)
+			toOuter[i] = 0
+		}
+	}
+	distanceTransform(toOuter, width, height)
+	distanceTransform(toInner, width, height)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := range coverage.Pix {
+		// The edge is between the pixel centers, so subtract a half pixel.
+		var d float64
+		if toInner[i] == 0 {
+			d = math.Sqrt(toOuter[i]) - 0.5
+		} else {
+			d = -(math.Sqrt(toInner[i]) - 0.5)
+		}
+		v := 0.5 + d/(2*spread)
+		if v < 0 {
+			v = 0
+		}
+		if v > 1 {
+			v = 1
+		}
+		c := uint8(math.Round(v * 0xff))
+		dst.Pix[4*i] = c
+		dst.Pix[4*i+1] = c
+		dst.Pix[4*i+2] = c
+		dst.Pix[4*i+3] = c
+	}
+	return dst
+}
+
+// distanceTransform calculates the squared Euclidean distance transform of grid in place.
+// A zero value in grid is a target pixel, and an infinity value is a non-target pixel.
+//
+// See Felzenszwalb and Huttenlocher, "Distance Transforms of Sampled Functions".
+func distanceTransform(grid []float64, width, height int) {
+	n := width
+	if n < height {
+		n = height
+	}
+	f := make([]float64, n)
+	d := make([]float64, n)
+	v := make([]int, n)
+	z := make([]float64, n+1)
+
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			f[y] = grid[y*width+x]
+		}
+		distanceTransform1D(f[:height], d, v, z)
+		for y := 0; y < height; y++ {
+			grid[y*width+x] = d[y]
+		}
+	}
+	for y := 0; y < height; y++ {
+		copy(f, grid[y*width:(y+1)*width])
+		distanceTransform1D(f[:width], d, v, z)
+		copy(grid[y*width:(y+1)*width], d[:width])
+	}
+}
+
+func distanceTransform1D(f []float64, d []float64, v []int, z []float64) {
+	n := len(f)
+
+	// Find the first finite value. If there is none, all the distances are infinite.
+	k := -1
+	for q := 0; q < n; q++ {
+		if !math.IsInf(f[q], 1) {
+			k = 0
+			v[0] = q
+			z[0] = math.Inf(-1)
+			z[1] = math.Inf(1)
+			break
+		}
+	}
+	if k < 0 {
+		copy(d, f)
+		return
+	}
+
+	for q := v[0] + 1; q < n; q++ {
+		if math.IsInf(f[q], 1) {
+			continue
+		}
+		var s float64
+		for {
+			p := v[k]
+			s = ((f[q] + float64(q*q)) - (f[p] + float64(p*p))) / float64(2*q-2*p)
+			if s > z[k] {
+				break
+			}
+			k--
+		}
+		k++
+		v[k] = q
+		z[k] = s
+		z[k+1] = math.Inf(1)
+	}
+
+	k = 0
+	for q := 0; q < n; q++ {
+		for z[k+1] < float64(q) {
+			k++
+		}
+		dq := float64(q - v[k])
+		d[q] = dq*dq + f[v[k]]
+	}
+}
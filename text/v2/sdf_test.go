@@ -0,0 +1,170 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/go-text/typesetting/opentype/api"
+
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+func TestGoTextFaceSDFSize(t *testing.T) {
+	testCases := []struct {
+		SDFSize float64
+		Want    float64
+	}{
+		{SDFSize: 0, Want: 64},
+		{SDFSize: -1, Want: 64},
+		{SDFSize: 32, Want: 32},
+		{SDFSize: 128, Want: 128},
+	}
+	for _, tc := range testCases {
+		f := &text.GoTextFace{SDF: true, SDFSize: tc.SDFSize}
+		if got := f.SDFSizeForTesting(); got != tc.Want {
+			t.Errorf("SDFSize: %v, got: %v, want: %v", tc.SDFSize, got, tc.Want)
+		}
+	}
+}
+
+func TestSDFSpread(t *testing.T) {
+	testCases := []struct {
+		SDFSize float64
+		Want    float64
+	}{
+		{SDFSize: 64, Want: 8},
+		{SDFSize: 128, Want: 16},
+		{SDFSize: 20, Want: 3},
+		{SDFSize: 1, Want: 1},
+	}
+	for _, tc := range testCases {
+		if got := text.SDFSpreadForTesting(tc.SDFSize); got != tc.Want {
+			t.Errorf("SDFSize: %v, got: %v, want: %v", tc.SDFSize, got, tc.Want)
+		}
+	}
+}
+
+func TestDistanceTransform(t *testing.T) {
+	inf := math.Inf(1)
+	testCases := []struct {
+		Name   string
+		Grid   []float64
+		Width  int
+		Height int
+		Want   []float64
+	}{
+		{
+			Name:   "row",
+			Grid:   []float64{inf, inf, 0, inf, inf},
+			Width:  5,
+			Height: 1,
+			Want:   []float64{4, 1, 0, 1, 4},
+		},
+		{
+			Name: "square",
+			Grid: []float64{
+				inf, inf, inf,
+				inf, 0, inf,
+				inf, inf, inf,
+			},
+			Width:  3,
+			Height: 3,
+			Want: []float64{
+				2, 1, 2,
+				1, 0, 1,
+				2, 1, 2,
+			},
+		},
+		{
+			Name: "two targets",
+			Grid: []float64{
+				0, inf, inf, inf,
+				inf, inf, inf, 0,
+			},
+			Width:  4,
+			Height: 2,
+			Want: []float64{
+				0, 1, 2, 1,
+				1, 2, 1, 0,
+			},
+		},
+		{
+			Name:   "no targets",
+			Grid:   []float64{inf, inf, inf, inf},
+			Width:  2,
+			Height: 2,
+			Want:   []float64{inf, inf, inf, inf},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			got := append([]float64{}, tc.Grid...)
+			text.DistanceTransformForTesting(got, tc.Width, tc.Height)
+			if !reflect.DeepEqual(got, tc.Want) {
+				t.Errorf("got: %v, want: %v", got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestSegmentsToSDF(t *testing.T) {
+	// A square from (8, 8) to (24, 24).
+	segs := []api.Segment{
+		{Op: api.SegmentOpMoveTo, Args: [3]api.SegmentPoint{{X: 8, Y: 8}}},
+		{Op: api.SegmentOpLineTo, Args: [3]api.SegmentPoint{{X: 24, Y: 8}}},
+		{Op: api.SegmentOpLineTo, Args: [3]api.SegmentPoint{{X: 24, Y: 24}}},
+		{Op: api.SegmentOpLineTo, Args: [3]api.SegmentPoint{{X: 8, Y: 24}}},
+	}
+	const spread = 8
+	img := text.SegmentsToSDFForTesting(segs, 0, 0, 32, 32, spread)
+
+	for i := 0; i < len(img.Pix); i += 4 {
+		if img.Pix[i] != img.Pix[i+1] || img.Pix[i] != img.Pix[i+2] || img.Pix[i] != img.Pix[i+3] {
+			t.Fatalf("Pix[%d:%d]: got: %v, want: the same values in all the channels", i, i+4, img.Pix[i:i+4])
+		}
+	}
+
+	testCases := []struct {
+		X    int
+		Y    int
+		Want uint8
+	}{
+		// Far outside is 0.
+		{X: 0, Y: 0, Want: 0},
+		// The pixels next to the edge are 0.5 -/+ 0.5/(2*spread).
+		{X: 7, Y: 16, Want: uint8(math.Round((0.5 - 0.5/(2*spread)) * 0xff))},
+		{X: 8, Y: 16, Want: uint8(math.Round((0.5 + 0.5/(2*spread)) * 0xff))},
+		{X: 16, Y: 23, Want: uint8(math.Round((0.5 + 0.5/(2*spread)) * 0xff))},
+		{X: 16, Y: 24, Want: uint8(math.Round((0.5 - 0.5/(2*spread)) * 0xff))},
+		// The center is 7.5 pixels away from the edge.
+		{X: 16, Y: 16, Want: uint8(math.Round((0.5 + 7.5/(2*spread)) * 0xff))},
+	}
+	for _, tc := range testCases {
+		if got := img.Pix[img.PixOffset(tc.X, tc.Y)]; got != tc.Want {
+			t.Errorf("(%d, %d): got: %d, want: %d", tc.X, tc.Y, got, tc.Want)
+		}
+	}
+
+	// The distance field increases monotonically from the outside to the center.
+	for x := 1; x <= 16; x++ {
+		if prev, cur := img.Pix[img.PixOffset(x-1, 16)], img.Pix[img.PixOffset(x, 16)]; prev > cur {
+			t.Errorf("(%d, 16): got: %d, want: >= %d", x, cur, prev)
+		}
+	}
+}
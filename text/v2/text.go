@@ -125,10 +125,23 @@ type Glyph struct {
 	GID uint32
 
 	// Image is a rasterized glyph image.
-	// Image is a grayscale image i.e. RGBA values are the same.
+	// Image is a grayscale image i.e. RGBA values are the same, unless Colored is true.
 	// Image should be used as a render source and should not be modified.
 	Image *ebiten.Image
 
+	// Colored reports whether Image is a color glyph image, like an emoji from a COLR, CBDT, or sbix font.
+	// A color glyph image should be rendered without scaling its RGB values, as Draw does.
+	Colored bool
+
+	// ForegroundImage is a grayscale image of the parts of a color glyph in the foreground color, i.e. the text color.
+	// ForegroundImage is nil unless Colored is true and the glyph has such parts, like a COLR glyph with the palette index 0xffff.
+	//
+	// ForegroundImage is placed at the same position as Image.
+	// To render such a glyph, render Image, and then add ForegroundImage scaled by the foreground color
+	// to the RGB values without changing the alpha values, as Draw does.
+	// ForegroundImage should be used as a render source and should not be modified.
+	ForegroundImage *ebiten.Image
+
 	// X is the X position to render this glyph.
 	// The position is determined in a sequence of characters given at AppendGlyphs.
 	// The position's origin is the first character's origin position.
@@ -138,6 +151,10 @@ type Glyph struct {
 	// The position is determined in a sequence of characters given at AppendGlyphs.
 	// The position's origin is the first character's origin position.
 	Y float64
+
+	// sdfScale is the scale to render Image when Image is a signed distance field.
+	// sdfScale is 0 when Image is a regular glyph image.
+	sdfScale float64
 }
 
 // Advance returns the advanced distance from the origin position when rendering the given text with the given face.
@@ -371,3 +371,83 @@ func TestDrawOptionsNotModified(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestWrap(t *testing.T) {
+	f := text.NewGoXFace(bitmapfont.Face)
+
+	testCases := []struct {
+		In    string
+		Width float64
+		Out   string
+	}{
+		{
+			In:    "The quick brown fox",
+			Width: text.Advance("The quick", f),
+			Out:   "The quick\nbrown fox",
+		},
+		{
+			In:    "abcdefghijkl",
+			Width: text.Advance("abcde", f),
+			Out:   "abcde\nfghij\nkl",
+		},
+		{
+			In:    "abcdefgh   ij kl",
+			Width: text.Advance("abcde", f),
+			Out:   "abcde\nfgh\nij kl",
+		},
+		{
+			In:    "The  quick\n\nbrown fox",
+			Width: 0,
+			Out:   "The  quick\n\nbrown fox",
+		},
+		{
+			In:    "",
+			Width: 100,
+			Out:   "",
+		},
+	}
+	for _, tc := range testCases {
+		if got, want := text.Wrap(tc.In, f, tc.Width), tc.Out; got != want {
+			t.Errorf("text.Wrap(%q, %f): got: %q, want: %q", tc.In, tc.Width, got, want)
+		}
+	}
+}
+
+func TestCaretPositionAndHitTest(t *testing.T) {
+	const str = "The quick brown fox"
+
+	f := text.NewGoXFace(bitmapfont.Face)
+	op := &text.WrapOptions{
+		Width: text.Advance("The quick", f),
+	}
+	op.LineSpacing = 20
+
+	lines := text.AppendLines(nil, str, f, op)
+	if got, want := len(lines), 2; got != want {
+		t.Fatalf("len(lines): got: %d, want: %d", got, want)
+	}
+	if got, want := str[lines[1].StartIndexInBytes:lines[1].EndIndexInBytes], "brown fox"; got != want {
+		t.Errorf("lines[1]: got: %q, want: %q", got, want)
+	}
+	if got, want := lines[1].Y-lines[0].Y, 20.0; got != want {
+		t.Errorf("lines[1].Y - lines[0].Y: got: %f, want: %f", got, want)
+	}
+
+	// The caret before "own" is at the second line.
+	idx := strings.Index(str, "own")
+	x, y := text.CaretPosition(str, f, op, idx)
+	if got, want := x, text.Advance("br", f); got != want {
+		t.Errorf("x: got: %f, want: %f", got, want)
+	}
+	if got, want := y, lines[1].Y; got != want {
+		t.Errorf("y: got: %f, want: %f", got, want)
+	}
+
+	if got, want := text.HitTest(str, f, op, x+1, y+1), idx; got != want {
+		t.Errorf("text.HitTest(%f, %f): got: %d, want: %d", x+1, y+1, got, want)
+	}
+	// A position beyond the line end hits the line end.
+	if got, want := text.HitTest(str, f, op, 1000, lines[0].Y), lines[0].EndIndexInBytes; got != want {
+		t.Errorf("text.HitTest: got: %d, want: %d", got, want)
+	}
+}
@@ -0,0 +1,371 @@
+// Copyright 2024 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-text/typesetting/segmenter"
+)
+
+// WrapOptions represents options for wrapping texts.
+type WrapOptions struct {
+	LayoutOptions
+
+	// Width is the maximum advance of a line in pixels.
+	// For a vertical-direction face, Width is the maximum length of a line in the vertical direction.
+	//
+	// If Width is 0 or negative, lines are broken only at newline characters.
+	Width float64
+}
+
+// Line represents a line of a wrapped text.
+type Line struct {
+	// StartIndexInBytes is the start index in bytes of the line for the given text.
+	StartIndexInBytes int
+
+	// EndIndexInBytes is the end index in bytes of the line for the given text.
+	// The spaces and the newline character at the end of the line are not included.
+	EndIndexInBytes int
+
+	// X, Y, Width and Height are the bounds of the line.
+	// The position is the same as Draw renders the text returned by Wrap with the same LayoutOptions.
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+type lineRange struct {
+	start int
+	end   int
+}
+
+func trimTrailingSpaces(str string, start, end int) int {
+	for end > start {
+		r, size := utf8.DecodeLastRuneInString(str[start:end])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		end -= size
+	}
+	return end
+}
+
+// wrapLines returns the ranges of the lines in text.
+func wrapLines(text string, face Face, width float64) []lineRange {
+	var ranges []lineRange
+	var seg, graphemeSeg segmenter.Segmenter
+	var offset int
+	for t := text; ; {
+		p, rest, found := strings.Cut(t, "\n")
+		for _, r := range wrapParagraph(&seg, &graphemeSeg, p, face, width) {
+			ranges = append(ranges, lineRange{
+				start: r.start + offset,
+				end:   r.end + offset,
+			})
+		}
+		if !found {
+			break
+		}
+		t = rest
+		offset += len(p) + 1
+	}
+	return ranges
+}
+
+// wrapParagraph returns the ranges of the lines in a paragraph p following the Unicode line breaking algorithm (UAX #14).
+// A segment wider than width is broken at grapheme cluster boundaries.
+//
+// Each segment and grapheme cluster is measured only once, and the advance of a line is the sum of them.
+// This keeps wrapping linear to the length of p, though kerning between segments is not taken into account.
+func wrapParagraph(seg, graphemeSeg *segmenter.Segmenter, p string, face Face, width float64) []lineRange {
+	if p == "" {
+		return []lineRange{{}}
+	}
+
+	runes := []rune(p)
+	byteIndices := runeByteIndices(p)
+
+	// measure returns the advance of p[start:end] and the advance without the trailing spaces.
+	measure := func(start, end int) (float64, float64) {
+		if width <= 0 {
+			return 0, 0
+		}
+		a := face.advance(p[start:end])
+		if trimmed := trimTrailingSpaces(p, start, end); trimmed < end {
+			return a, face.advance(p[start:trimmed])
+		}
+		return a, a
+	}
+
+	var ranges []lineRange
+	appendLine := func(start, end int) {
+		ranges = append(ranges, lineRange{
+			start: start,
+			end:   trimTrailingSpaces(p, start, end),
+		})
+	}
+
+	// The current line is [start, end), and its advance including the trailing spaces is lineAdvance.
+	var start, end int
+	var lineAdvance float64
+	seg.Init(runes)
+	iter := seg.LineIterator()
+	for iter.Next() {
+		l := iter.Line()
+		segStart := byteIndices[l.Offset]
+		segEnd := byteIndices[l.Offset+len(l.Text)]
+		segAdvance, segTrimmedAdvance := measure(segStart, segEnd)
+
+		if end > start && width > 0 && lineAdvance+segTrimmedAdvance > width {
+			appendLine(start, end)
+			start = end
+			lineAdvance = 0
+		}
+
+		if end == start && width > 0 && segTrimmedAdvance > width {
+			// The segment doesn't fit in a line by itself. Break the segment at grapheme cluster boundaries.
+			graphemeSeg.Init(l.Text)
+			giter := graphemeSeg.GraphemeIterator()
+			lineStart := segStart
+			prev := segStart
+			// lineAdvance is the advance of [lineStart, prev) without the trailing spaces, and spacesAdvance is the advance of the trailing spaces.
+			var spacesAdvance float64
+			for giter.Next() {
+				g := giter.Grapheme()
+				gStart := byteIndices[l.Offset+g.Offset]
+				gEnd := byteIndices[l.Offset+g.Offset+len(g.Text)]
+				gAdvance := face.advance(p[gStart:gEnd])
+				if trimTrailingSpaces(p, gStart, gEnd) == gStart {
+					spacesAdvance += gAdvance
+					prev = gEnd
+					continue
+				}
+				if prev > lineStart && lineAdvance+spacesAdvance+gAdvance > width {
+					appendLine(lineStart, prev)
+					lineStart = prev
+					lineAdvance = 0
+					spacesAdvance = 0
+				}
+				lineAdvance += spacesAdvance + gAdvance
+				spacesAdvance = 0
+				prev = gEnd
+			}
+			start = lineStart
+			lineAdvance += spacesAdvance
+		} else {
+			lineAdvance += segAdvance
+		}
+		end = segEnd
+
+		if l.IsMandatoryBreak && end < len(p) {
+			appendLine(start, end)
+			start = end
+			lineAdvance = 0
+		}
+	}
+	appendLine(start, end)
+
+	return ranges
+}
+
+// runeByteIndices returns the byte indices of the runes in str and len(str).
+func runeByteIndices(str string) []int {
+	indices := make([]int, 0, len(str)+1)
+	for i := range str {
+		indices = append(indices, i)
+	}
+	return append(indices, len(str))
+}
+
+func joinLines(text string, ranges []lineRange) string {
+	var b strings.Builder
+	for i, r := range ranges {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(text[r.start:r.end])
+	}
+	return b.String()
+}
+
+// Wrap wraps the text so that each line fits in the width, and returns the text with newline characters.
+//
+// The text is broken at line break opportunities following the Unicode line breaking algorithm (UAX #14).
+// A word longer than width is broken at grapheme cluster boundaries.
+// The spaces at the end of each line are removed.
+//
+// Wrap is concurrent-safe.
+func Wrap(text string, face Face, width float64) string {
+	return joinLines(text, wrapLines(text, face, width))
+}
+
+// AppendLines wraps the text in the same way as Wrap, appends the lines to lines, and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+//
+// AppendLines is useful to measure each line's bounds, e.g. for a dialog box.
+//
+// AppendLines is concurrent-safe.
+func AppendLines(lines []Line, text string, face Face, options *WrapOptions) []Line {
+	if options == nil {
+		options = &WrapOptions{}
+	}
+	ranges := wrapLines(text, face, options.Width)
+
+	d := face.direction()
+	m := face.Metrics()
+
+	var i int
+	forEachLine(joinLines(text, ranges), face, &options.LayoutOptions, func(line string, indexOffset int, originX, originY float64) {
+		l := Line{
+			StartIndexInBytes: ranges[i].start,
+			EndIndexInBytes:   ranges[i].end,
+		}
+		a := face.advance(line)
+		if d.isHorizontal() {
+			l.X = originX
+			l.Y = originY - m.HAscent
+			l.Width = a
+			l.Height = m.HAscent + m.HDescent
+		} else {
+			l.X = originX - m.VDescent
+			l.Y = originY
+			l.Width = m.VAscent + m.VDescent
+			l.Height = a
+		}
+		lines = append(lines, l)
+		i++
+	})
+	return lines
+}
+
+// lineIndexAt returns the index of the line that has a caret at indexInBytes.
+func lineIndexAt(lines []Line, indexInBytes int) int {
+	var idx int
+	for i, l := range lines {
+		if l.StartIndexInBytes > indexInBytes {
+			break
+		}
+		idx = i
+	}
+	return idx
+}
+
+// caretOffset returns the distance from the start of the line to a caret in the primary direction.
+func caretOffset(text string, face Face, line Line, indexInBytes int) float64 {
+	a := face.advance(text[line.StartIndexInBytes:indexInBytes])
+	if face.direction() == DirectionRightToLeft {
+		return line.Width - a
+	}
+	return a
+}
+
+// CaretPosition returns the position of a caret at indexInBytes for text wrapped with the options.
+//
+// For a horizontal-direction face, the position is the top of the caret, and the caret's height is the line's height.
+// For a vertical-direction face, the position is the left of the caret, and the caret's width is the line's width.
+//
+// If indexInBytes points to spaces removed at a line end or a newline character, the position is at the line end.
+// Bidirectional texts are not considered so far.
+//
+// CaretPosition is concurrent-safe.
+func CaretPosition(text string, face Face, options *WrapOptions, indexInBytes int) (x, y float64) {
+	lines := AppendLines(nil, text, face, options)
+	if len(lines) == 0 {
+		return 0, 0
+	}
+
+	if indexInBytes < 0 {
+		indexInBytes = 0
+	}
+	l := lines[lineIndexAt(lines, indexInBytes)]
+	if indexInBytes > l.EndIndexInBytes {
+		indexInBytes = l.EndIndexInBytes
+	}
+	o := caretOffset(text, face, l, indexInBytes)
+	if face.direction().isHorizontal() {
+		return l.X + o, l.Y
+	}
+	return l.X, l.Y + o
+}
+
+// HitTest returns the index in bytes of the nearest caret position to (x, y) for text wrapped with the options.
+// This is useful to put a caret at a clicked position.
+//
+// The returned index is always at a grapheme cluster boundary.
+//
+// HitTest is concurrent-safe.
+func HitTest(text string, face Face, options *WrapOptions, x, y float64) int {
+	lines := AppendLines(nil, text, face, options)
+	if len(lines) == 0 {
+		return 0
+	}
+
+	horizontal := face.direction().isHorizontal()
+
+	// Find the nearest line in the secondary direction.
+	var line Line
+	minDist := math.Inf(1)
+	for _, l := range lines {
+		var dist float64
+		if horizontal {
+			dist = distanceToRange(y, l.Y, l.Y+l.Height)
+		} else {
+			dist = distanceToRange(x, l.X, l.X+l.Width)
+		}
+		if dist < minDist {
+			minDist = dist
+			line = l
+		}
+	}
+
+	// Find the nearest caret position in the primary direction.
+	p := x - line.X
+	if !horizontal {
+		p = y - line.Y
+	}
+
+	str := text[line.StartIndexInBytes:line.EndIndexInBytes]
+	idx := line.StartIndexInBytes
+	minDist = math.Abs(p - caretOffset(text, face, line, idx))
+
+	var seg segmenter.Segmenter
+	seg.Init([]rune(str))
+	byteIndices := runeByteIndices(str)
+	iter := seg.GraphemeIterator()
+	for iter.Next() {
+		g := iter.Grapheme()
+		end := line.StartIndexInBytes + byteIndices[g.Offset+len(g.Text)]
+		if dist := math.Abs(p - caretOffset(text, face, line, end)); dist < minDist {
+			minDist = dist
+			idx = end
+		}
+	}
+	return idx
+}
+
+func distanceToRange(v, min, max float64) float64 {
+	if v < min {
+		return min - v
+	}
+	if v > max {
+		return v - max
+	}
+	return 0
+}
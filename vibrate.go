@@ -70,7 +70,9 @@ type VibrateGamepadOptions struct {
 
 // VibrateGamepad vibrates the specified gamepad with the specified options.
 //
-// VibrateGamepad works only on browsers and Nintendo Switch so far.
+// VibrateGamepad works on Windows, Linux, browsers and Nintendo Switch so far.
+// On Windows, only XInput and GameInput gamepads are supported.
+// On Linux, the gamepad's device file must be writable and the gamepad must support a rumble effect.
 //
 // VibrateGamepad is concurrent-safe.
 func VibrateGamepad(gamepadID GamepadID, options *VibrateGamepadOptions) {
@@ -80,3 +82,94 @@ func VibrateGamepad(gamepadID GamepadID, options *VibrateGamepadOptions) {
 	}
 	g.Vibrate(options.Duration, options.StrongMagnitude, options.WeakMagnitude)
 }
+
+// GamepadForceFeedbackEffectType represents a type of a force feedback effect.
+type GamepadForceFeedbackEffectType int
+
+const (
+	// GamepadForceFeedbackEffectTypeConstant represents an effect applying a constant force.
+	GamepadForceFeedbackEffectTypeConstant = GamepadForceFeedbackEffectType(gamepad.ForceFeedbackEffectTypeConstant)
+
+	// GamepadForceFeedbackEffectTypeSpring represents an effect pulling the device back to the center.
+	GamepadForceFeedbackEffectTypeSpring = GamepadForceFeedbackEffectType(gamepad.ForceFeedbackEffectTypeSpring)
+
+	// GamepadForceFeedbackEffectTypeDamper represents an effect resisting the movement of the device.
+	GamepadForceFeedbackEffectTypeDamper = GamepadForceFeedbackEffectType(gamepad.ForceFeedbackEffectTypeDamper)
+)
+
+// GamepadForceFeedbackOptions represents the options for a force feedback effect.
+type GamepadForceFeedbackOptions struct {
+	// Type is the type of the effect.
+	Type GamepadForceFeedbackEffectType
+
+	// Duration is the time duration of the effect.
+	// If Duration is 0, the effect continues until StopGamepadForceFeedback is called.
+	// A negative Duration is treated as 0.
+	Duration time.Duration
+
+	// Magnitude is the force of a constant effect.
+	// The value is in between -1 and 1. The sign represents the direction along the device's primary axis, e.g. the wheel.
+	Magnitude float64
+
+	// Coefficient is the strength of a spring or damper effect.
+	// The value is in between 0 and 1.
+	Coefficient float64
+
+	// Center is the center position of a spring effect.
+	// The value is in between -1 and 1.
+	Center float64
+
+	// Deadband is the size of the region around the center where a spring or damper effect is not active.
+	// The value is in between 0 and 1.
+	Deadband float64
+}
+
+// IsGamepadForceFeedbackSupported reports whether the specified gamepad supports the force feedback effect type.
+//
+// IsGamepadForceFeedbackSupported is concurrent-safe.
+func IsGamepadForceFeedbackSupported(gamepadID GamepadID, effectType GamepadForceFeedbackEffectType) bool {
+	g := gamepad.Get(gamepadID)
+	if g == nil {
+		return false
+	}
+	return g.IsForceFeedbackSupported(gamepad.ForceFeedbackEffectType(effectType))
+}
+
+// PlayGamepadForceFeedback plays a force feedback effect on the specified gamepad, e.g. a racing wheel.
+//
+// Only one effect is played for each effect type at the same time.
+// Playing an effect of the same type again updates the effect.
+// Effects of different types are combined.
+//
+// PlayGamepadForceFeedback does nothing if the gamepad doesn't support the effect type.
+// Use IsGamepadForceFeedbackSupported to check this.
+//
+// PlayGamepadForceFeedback works on Linux so far.
+// On Linux, the gamepad's device file must be writable.
+//
+// PlayGamepadForceFeedback is concurrent-safe.
+func PlayGamepadForceFeedback(gamepadID GamepadID, options *GamepadForceFeedbackOptions) {
+	g := gamepad.Get(gamepadID)
+	if g == nil {
+		return
+	}
+	g.PlayForceFeedback(&gamepad.ForceFeedbackEffect{
+		Type:        gamepad.ForceFeedbackEffectType(options.Type),
+		Duration:    options.Duration,
+		Magnitude:   options.Magnitude,
+		Coefficient: options.Coefficient,
+		Center:      options.Center,
+		Deadband:    options.Deadband,
+	})
+}
+
+// StopGamepadForceFeedback stops all the force feedback effects on the specified gamepad.
+//
+// StopGamepadForceFeedback is concurrent-safe.
+func StopGamepadForceFeedback(gamepadID GamepadID) {
+	g := gamepad.Get(gamepadID)
+	if g == nil {
+		return
+	}
+	g.StopForceFeedback()
+}
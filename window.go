@@ -16,6 +16,7 @@ package ebiten
 
 import (
 	"image"
+	"math"
 	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
@@ -125,11 +126,55 @@ func SetWindowTitle(title string) {
 //
 // SetWindowIcon doesn't work if the platform is not a desktop.
 //
+// SetWindowIcon can be called at any time, both before and after RunGame.
+// The icon is updated at the next frame.
+//
 // SetWindowIcon is concurrent-safe.
 func SetWindowIcon(iconImages []image.Image) {
 	ui.Get().Window().SetIcon(iconImages)
 }
 
+// TaskbarProgressStateType represents a state of the progress on the taskbar.
+type TaskbarProgressStateType = ui.TaskbarProgressState
+
+// TaskbarProgressStateTypes
+const (
+	// TaskbarProgressStateNone indicates that no progress is shown.
+	TaskbarProgressStateNone TaskbarProgressStateType = ui.TaskbarProgressStateNone
+
+	// TaskbarProgressStateIndeterminate indicates that a progress is shown without a specific value.
+	TaskbarProgressStateIndeterminate TaskbarProgressStateType = ui.TaskbarProgressStateIndeterminate
+
+	// TaskbarProgressStateNormal indicates that a progress is shown normally.
+	TaskbarProgressStateNormal TaskbarProgressStateType = ui.TaskbarProgressStateNormal
+
+	// TaskbarProgressStatePaused indicates that a progress is shown as paused.
+	TaskbarProgressStatePaused TaskbarProgressStateType = ui.TaskbarProgressStatePaused
+
+	// TaskbarProgressStateError indicates that a progress is shown as an error.
+	TaskbarProgressStateError TaskbarProgressStateType = ui.TaskbarProgressStateError
+)
+
+// SetTaskbarProgress sets the progress shown on the window's taskbar button.
+// This is useful to show a progress of a long loading natively.
+//
+// value is the progress in between 0 and 1. NaN is treated as 0.
+// value is ignored when state is TaskbarProgressStateNone or TaskbarProgressStateIndeterminate.
+//
+// SetTaskbarProgress is Windows-only. SetTaskbarProgress does nothing on the other environments including macOS,
+// Linux, browsers, mobiles, and Xbox. SetTaskbarProgress also does nothing before the main loop starts.
+//
+// SetTaskbarProgress is concurrent-safe.
+func SetTaskbarProgress(state TaskbarProgressStateType, value float64) {
+	if math.IsNaN(value) || value < 0 {
+		value = 0
+	}
+	if value > 1 {
+		value = 1
+	}
+	ui.Get().Window().SetTaskbarProgress(state, value)
+}
+
 // WindowPosition returns the window position.
 // The origin position is the upper-left corner of the current monitor.
 // The unit is device-independent pixels.
@@ -159,6 +204,32 @@ func SetWindowPosition(x, y int) {
 	ui.Get().Window().SetPosition(x, y)
 }
 
+// WindowPositionF is the float version of WindowPosition.
+//
+// Unlike WindowPosition, WindowPositionF doesn't round the position.
+// With a fractional device scale factor, e.g. 1.25 or 1.5, a position in device-independent pixels might not be an integer.
+// WindowPositionF and SetWindowPositionF round-trip without accumulating errors in this case.
+//
+// WindowPositionF is concurrent-safe.
+func WindowPositionF() (x, y float64) {
+	return ui.Get().Window().PositionF()
+}
+
+// SetWindowPositionF is the float version of SetWindowPosition.
+//
+// The actual window position is truncated to an integer in native pixels.
+//
+// SetWindowPositionF panics if x or y is NaN or infinity.
+//
+// SetWindowPositionF is concurrent-safe.
+func SetWindowPositionF(x, y float64) {
+	if math.IsNaN(x) || math.IsNaN(y) || math.IsInf(x, 0) || math.IsInf(y, 0) {
+		panic("ebiten: x and y must be finite numbers")
+	}
+	windowPositionSetExplicitly.Store(true)
+	ui.Get().Window().SetPositionF(x, y)
+}
+
 var (
 	windowPositionSetExplicitly atomic.Bool
 )
@@ -197,6 +268,32 @@ func SetWindowSize(width, height int) {
 	ui.Get().Window().SetSize(width, height)
 }
 
+// WindowSizeF is the float version of WindowSize.
+//
+// Unlike WindowSize, WindowSizeF doesn't round the size.
+// With a fractional device scale factor, e.g. 1.25 or 1.5, a size in device-independent pixels might not be an integer.
+// WindowSizeF and SetWindowSizeF round-trip without accumulating errors in this case.
+//
+// WindowSizeF is concurrent-safe.
+func WindowSizeF() (width, height float64) {
+	return ui.Get().Window().SizeF()
+}
+
+// SetWindowSizeF is the float version of SetWindowSize.
+//
+// The actual window size is truncated to an integer in native pixels.
+//
+// SetWindowSizeF panics if width or height is not a positive finite number, e.g. NaN.
+//
+// SetWindowSizeF is concurrent-safe.
+func SetWindowSizeF(width, height float64) {
+	// Use negated comparisons so that NaN is rejected.
+	if !(width > 0) || !(height > 0) || math.IsInf(width, 0) || math.IsInf(height, 0) {
+		panic("ebiten: width and height must be positive finite numbers")
+	}
+	ui.Get().Window().SetSizeF(width, height)
+}
+
 // WindowSizeLimits returns the limitation of the window size on desktops.
 // A negative value indicates the size is not limited.
 //